@@ -51,12 +51,26 @@ type ACL struct {
 	// We use an iradix for the purposes of ordered iteration.
 	wildcardNamespaces *iradix.Tree
 
+	// variables and wildcardVariables map a namespace, or a glob pattern of
+	// a namespace, to the capabilities granted on its variable paths
+	variables         *iradix.Tree
+	wildcardVariables *iradix.Tree
+
 	agent    string
 	node     string
 	operator string
 	quota    string
+
+	// nodeCapabilities and operatorCapabilities are the fine-grained
+	// capabilities granted for nodes and operator endpoints
+	nodeCapabilities     capabilitySet
+	operatorCapabilities capabilitySet
 }
 
+// variablesPaths maps a variable path, which may contain globs, to a
+// capabilitySet
+type variablesPaths map[string]capabilitySet
+
 // maxPrivilege returns the policy which grants the most privilege
 // This handles the case of Deny always taking maximum precedence.
 func maxPrivilege(a, b string) string {
@@ -80,9 +94,14 @@ func NewACL(management bool, policies []*Policy) (*ACL, error) {
 	}
 
 	// Create the ACL object
-	acl := &ACL{}
+	acl := &ACL{
+		nodeCapabilities:     make(capabilitySet),
+		operatorCapabilities: make(capabilitySet),
+	}
 	nsTxn := iradix.New().Txn()
 	wnsTxn := iradix.New().Txn()
+	varTxn := iradix.New().Txn()
+	wvarTxn := iradix.New().Txn()
 
 	for _, policy := range policies {
 	NAMESPACES:
@@ -90,6 +109,39 @@ func NewACL(management bool, policies []*Policy) (*ACL, error) {
 			// Should the namespace be matched using a glob?
 			globDefinition := strings.Contains(ns.Name, "*")
 
+			// Add in the capabilities of the variable paths
+			if ns.Variables != nil {
+				txn := varTxn
+				if globDefinition {
+					txn = wvarTxn
+				}
+				var paths variablesPaths
+				if raw, ok := txn.Get([]byte(ns.Name)); ok {
+					paths = raw.(variablesPaths)
+				} else {
+					paths = make(variablesPaths)
+					txn.Insert([]byte(ns.Name), paths)
+				}
+				for _, path := range ns.Variables.Paths {
+					pathCaps, ok := paths[path.PathSpec]
+					if !ok {
+						pathCaps = make(capabilitySet)
+						paths[path.PathSpec] = pathCaps
+					}
+					if pathCaps.Check(VariablesCapabilityDeny) {
+						continue
+					}
+					for _, cap := range path.Capabilities {
+						if cap == VariablesCapabilityDeny {
+							pathCaps.Clear()
+							pathCaps.Set(VariablesCapabilityDeny)
+							break
+						}
+						pathCaps.Set(cap)
+					}
+				}
+			}
+
 			// Check for existing capabilities
 			var capabilities capabilitySet
 
@@ -134,9 +186,15 @@ func NewACL(management bool, policies []*Policy) (*ACL, error) {
 		}
 		if policy.Node != nil {
 			acl.node = maxPrivilege(acl.node, policy.Node.Policy)
+			for _, cap := range policy.Node.Capabilities {
+				acl.nodeCapabilities.Set(cap)
+			}
 		}
 		if policy.Operator != nil {
 			acl.operator = maxPrivilege(acl.operator, policy.Operator.Policy)
+			for _, cap := range policy.Operator.Capabilities {
+				acl.operatorCapabilities.Set(cap)
+			}
 		}
 		if policy.Quota != nil {
 			acl.quota = maxPrivilege(acl.quota, policy.Quota.Policy)
@@ -146,6 +204,8 @@ func NewACL(management bool, policies []*Policy) (*ACL, error) {
 	// Finalize the namespaces
 	acl.namespaces = nsTxn.Commit()
 	acl.wildcardNamespaces = wnsTxn.Commit()
+	acl.variables = varTxn.Commit()
+	acl.wildcardVariables = wvarTxn.Commit()
 	return acl, nil
 }
 
@@ -154,6 +214,17 @@ func (a *ACL) AllowNsOp(ns string, op string) bool {
 	return a.AllowNamespaceOperation(ns, op)
 }
 
+// AllowNsOpAny checks if any of the given operations are allowed for a
+// namespace
+func (a *ACL) AllowNsOpAny(ns string, ops ...string) bool {
+	for _, op := range ops {
+		if a.AllowNamespaceOperation(ns, op) {
+			return true
+		}
+	}
+	return false
+}
+
 // AllowNamespaceOperation checks if a given operation is allowed for a namespace
 func (a *ACL) AllowNamespaceOperation(ns string, op string) bool {
 	// Hot path management tokens
@@ -264,6 +335,86 @@ func (a *ACL) findAllMatchingWildcards(ns string) []matchingGlob {
 	return matches
 }
 
+// AllowVariableOperation checks if a given operation is allowed on the
+// variable at the path of a namespace. A deny namespace capability takes
+// precedence over the capabilities of the variable paths.
+func (a *ACL) AllowVariableOperation(ns, path, op string) bool {
+	// Hot path management tokens
+	if a.management {
+		return true
+	}
+
+	if capabilities, ok := a.matchingCapabilitySet(ns); ok && capabilities.Check(NamespaceCapabilityDeny) {
+		return false
+	}
+
+	paths, ok := a.matchingVariablesPaths(ns)
+	if !ok {
+		return false
+	}
+
+	capabilities, ok := paths[path]
+	if !ok {
+		// Find the closest matching glob, sorting the paths so ties are
+		// broken consistently
+		specs := make([]string, 0, len(paths))
+		for spec := range paths {
+			specs = append(specs, spec)
+		}
+		sort.Strings(specs)
+
+		best := -1
+		for _, spec := range specs {
+			if !glob.Glob(spec, path) {
+				continue
+			}
+			if difference := globDifference(spec, path); best == -1 || difference < best {
+				best = difference
+				capabilities = paths[spec]
+			}
+		}
+		if best == -1 {
+			return false
+		}
+	}
+
+	if capabilities.Check(VariablesCapabilityDeny) {
+		return false
+	}
+	return capabilities.Check(op)
+}
+
+// matchingVariablesPaths looks for the variable paths of the namespace, if
+// no concrete definitions are found, then we return the closest matching
+// glob.
+func (a *ACL) matchingVariablesPaths(ns string) (variablesPaths, bool) {
+	raw, ok := a.variables.Get([]byte(ns))
+	if ok {
+		return raw.(variablesPaths), true
+	}
+
+	var paths variablesPaths
+	best := -1
+	a.wildcardVariables.Root().Walk(func(bk []byte, iv interface{}) bool {
+		k := string(bk)
+		if glob.Glob(k, ns) {
+			// The walk is ordered so ties are broken consistently
+			if difference := globDifference(k, ns); best == -1 || difference < best {
+				best = difference
+				paths = iv.(variablesPaths)
+			}
+		}
+		return false
+	})
+	return paths, best != -1
+}
+
+// globDifference returns the character difference between a glob and the
+// name it matches. The closest matching glob has the smallest difference.
+func globDifference(g, name string) int {
+	return len(name) - len(g) + strings.Count(g, glob.GLOB)
+}
+
 // AllowAgentRead checks if read operations are allowed for an agent
 func (a *ACL) AllowAgentRead() bool {
 	switch {
@@ -316,6 +467,21 @@ func (a *ACL) AllowNodeWrite() bool {
 	}
 }
 
+// AllowNodeOperation checks if a given fine-grained operation is allowed for
+// nodes. A deny node policy takes precedence over any capabilities.
+func (a *ACL) AllowNodeOperation(op string) bool {
+	switch {
+	case a.management:
+		return true
+	case a.node == PolicyDeny:
+		return false
+	case a.node == PolicyWrite:
+		return true
+	default:
+		return a.nodeCapabilities.Check(op)
+	}
+}
+
 // AllowOperatorRead checks if read operations are allowed for a operator
 func (a *ACL) AllowOperatorRead() bool {
 	switch {
@@ -342,6 +508,22 @@ func (a *ACL) AllowOperatorWrite() bool {
 	}
 }
 
+// AllowOperatorOperation checks if a given fine-grained operation is allowed
+// for operator endpoints. A deny operator policy takes precedence over any
+// capabilities.
+func (a *ACL) AllowOperatorOperation(op string) bool {
+	switch {
+	case a.management:
+		return true
+	case a.operator == PolicyDeny:
+		return false
+	case a.operator == PolicyWrite:
+		return true
+	default:
+		return a.operatorCapabilities.Check(op)
+	}
+}
+
 // AllowQuotaRead checks if read operations are allowed for all quotas
 func (a *ACL) AllowQuotaRead() bool {
 	switch {
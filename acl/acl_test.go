@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestCapabilitySet(t *testing.T) {
@@ -221,6 +222,95 @@ quota {
 }
 `
 
+func TestACL_FineGrainedCapabilities(t *testing.T) {
+	require := require.New(t)
+
+	// On-call may drain nodes and promote deployments but not change node
+	// eligibility, fail deployments or rewrite the scheduler configuration
+	p1, err := Parse(`
+		namespace "default" {
+			policy = "read"
+			capabilities = ["deployment-promote"]
+		}
+		node {
+			policy = "read"
+			capabilities = ["node-drain"]
+		}
+		operator {
+			policy = "read"
+		}
+	`)
+	require.NoError(err)
+	acl, err := NewACL(false, []*Policy{p1})
+	require.NoError(err)
+
+	require.True(acl.AllowNsOp("default", NamespaceCapabilityDeploymentPromote))
+	require.False(acl.AllowNsOp("default", NamespaceCapabilityDeploymentFail))
+	require.True(acl.AllowNsOpAny("default", NamespaceCapabilitySubmitJob, NamespaceCapabilityDeploymentPromote))
+	require.False(acl.AllowNsOpAny("default", NamespaceCapabilitySubmitJob, NamespaceCapabilityScaleJob))
+	require.True(acl.AllowNodeRead())
+	require.False(acl.AllowNodeWrite())
+	require.True(acl.AllowNodeOperation(NodeCapabilityDrain))
+	require.False(acl.AllowNodeOperation(NodeCapabilityEligibility))
+	require.True(acl.AllowOperatorRead())
+	require.False(acl.AllowOperatorOperation(OperatorCapabilitySchedulerConfigWrite))
+
+	// The write policies expand to all capabilities
+	p2, err := Parse(`
+		namespace "default" {
+			policy = "write"
+		}
+		node {
+			policy = "write"
+		}
+		operator {
+			policy = "write"
+		}
+	`)
+	require.NoError(err)
+	acl, err = NewACL(false, []*Policy{p2})
+	require.NoError(err)
+
+	require.True(acl.AllowNsOp("default", NamespaceCapabilityDeploymentFail))
+	require.True(acl.AllowNsOp("default", NamespaceCapabilityScaleJob))
+	require.True(acl.AllowNodeOperation(NodeCapabilityEligibility))
+	require.True(acl.AllowOperatorOperation(OperatorCapabilitySchedulerConfigWrite))
+
+	// A deny policy takes precedence over capabilities
+	p3, err := Parse(`
+		node {
+			policy = "deny"
+		}
+		operator {
+			policy = "deny"
+		}
+	`)
+	require.NoError(err)
+	acl, err = NewACL(false, []*Policy{p1, p3})
+	require.NoError(err)
+
+	require.False(acl.AllowNodeOperation(NodeCapabilityDrain))
+	require.False(acl.AllowOperatorOperation(OperatorCapabilitySchedulerConfigWrite))
+
+	// Management tokens are allowed everything
+	require.True(ManagementACL.AllowNodeOperation(NodeCapabilityDrain))
+	require.True(ManagementACL.AllowOperatorOperation(OperatorCapabilitySchedulerConfigWrite))
+
+	// Node capabilities imply reading nodes
+	p4, err := Parse(`
+		node {
+			capabilities = ["node-drain"]
+		}
+	`)
+	require.NoError(err)
+	acl, err = NewACL(false, []*Policy{p4})
+	require.NoError(err)
+
+	require.True(acl.AllowNodeRead())
+	require.False(acl.AllowNodeWrite())
+	require.True(acl.AllowNodeOperation(NodeCapabilityDrain))
+}
+
 func TestAllowNamespace(t *testing.T) {
 	tests := []struct {
 		Policy string
@@ -263,6 +353,85 @@ func TestAllowNamespace(t *testing.T) {
 	}
 }
 
+func TestACL_AllowVariableOperation(t *testing.T) {
+	policy, err := Parse(`
+	namespace "default" {
+		variables {
+			path "*" {
+				capabilities = ["list"]
+			}
+			path "nomad/jobs/*" {
+				capabilities = ["list", "read"]
+			}
+			path "nomad/jobs/example" {
+				capabilities = ["read", "write", "destroy"]
+			}
+			path "nomad/jobs/secret*" {
+				capabilities = ["deny"]
+			}
+		}
+	}
+	namespace "prod-*" {
+		variables {
+			path "shared/*" {
+				capabilities = ["read"]
+			}
+		}
+	}
+	namespace "prod-api" {
+		policy = "deny"
+	}
+	namespace "other" {
+		policy = "write"
+	}
+	`)
+	require.NoError(t, err)
+
+	acl, err := NewACL(false, []*Policy{policy})
+	require.NoError(t, err)
+
+	cases := []struct {
+		Namespace string
+		Path      string
+		Op        string
+		Allow     bool
+	}{
+		// Exact path
+		{"default", "nomad/jobs/example", VariablesCapabilityWrite, true},
+		{"default", "nomad/jobs/example", VariablesCapabilityList, false},
+
+		// Closest glob
+		{"default", "nomad/jobs/web", VariablesCapabilityRead, true},
+		{"default", "nomad/jobs/web", VariablesCapabilityWrite, false},
+		{"default", "foo", VariablesCapabilityList, true},
+		{"default", "foo", VariablesCapabilityRead, false},
+
+		// Deny
+		{"default", "nomad/jobs/secrets", VariablesCapabilityRead, false},
+		{"default", "nomad/jobs/secrets", VariablesCapabilityList, false},
+
+		// Wildcard namespace
+		{"prod-web", "shared/db", VariablesCapabilityRead, true},
+		{"prod-web", "db", VariablesCapabilityRead, false},
+
+		// A denied namespace denies its variables
+		{"prod-api", "shared/db", VariablesCapabilityRead, false},
+
+		// The namespace policy doesn't grant variable capabilities
+		{"other", "foo", VariablesCapabilityRead, false},
+		{"unknown", "foo", VariablesCapabilityRead, false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Namespace+"/"+tc.Path+"/"+tc.Op, func(t *testing.T) {
+			require.Equal(t, tc.Allow, acl.AllowVariableOperation(tc.Namespace, tc.Path, tc.Op))
+		})
+	}
+
+	// Management tokens can do anything
+	require.True(t, ManagementACL.AllowVariableOperation("default", "foo", VariablesCapabilityDestroy))
+}
+
 func TestWildcardNamespaceMatching(t *testing.T) {
 	tests := []struct {
 		Policy string
@@ -21,17 +21,45 @@ const (
 	// The Policy stanza is a short hand for granting several of these. When capabilities are
 	// combined we take the union of all capabilities. If the deny capability is present, it
 	// takes precedence and overwrites all other capabilities.
-	NamespaceCapabilityDeny             = "deny"
-	NamespaceCapabilityListJobs         = "list-jobs"
-	NamespaceCapabilityReadJob          = "read-job"
-	NamespaceCapabilitySubmitJob        = "submit-job"
-	NamespaceCapabilityDispatchJob      = "dispatch-job"
-	NamespaceCapabilityReadLogs         = "read-logs"
-	NamespaceCapabilityReadFS           = "read-fs"
-	NamespaceCapabilityAllocExec        = "alloc-exec"
-	NamespaceCapabilityAllocNodeExec    = "alloc-node-exec"
-	NamespaceCapabilityAllocLifecycle   = "alloc-lifecycle"
-	NamespaceCapabilitySentinelOverride = "sentinel-override"
+	NamespaceCapabilityDeny              = "deny"
+	NamespaceCapabilityListJobs          = "list-jobs"
+	NamespaceCapabilityReadJob           = "read-job"
+	NamespaceCapabilitySubmitJob         = "submit-job"
+	NamespaceCapabilityDispatchJob       = "dispatch-job"
+	NamespaceCapabilityReadLogs          = "read-logs"
+	NamespaceCapabilityReadFS            = "read-fs"
+	NamespaceCapabilityAllocExec         = "alloc-exec"
+	NamespaceCapabilityAllocNodeExec     = "alloc-node-exec"
+	NamespaceCapabilityAllocLifecycle    = "alloc-lifecycle"
+	NamespaceCapabilitySentinelOverride  = "sentinel-override"
+	NamespaceCapabilityDeploymentPromote = "deployment-promote"
+	NamespaceCapabilityDeploymentFail    = "deployment-fail"
+	NamespaceCapabilityScaleJob          = "job-scale"
+)
+
+const (
+	// The following are the capabilities that can be granted on the variable paths of
+	// a namespace. They are only granted by a variables stanza, the namespace policy
+	// short hand doesn't grant any. If the deny capability is present, it takes
+	// precedence and overwrites all other capabilities.
+	VariablesCapabilityList    = "list"
+	VariablesCapabilityRead    = "read"
+	VariablesCapabilityWrite   = "write"
+	VariablesCapabilityDestroy = "destroy"
+	VariablesCapabilityDeny    = "deny"
+)
+
+const (
+	// The following are the fine-grained capabilities that can be granted for nodes.
+	// The node write policy is a short hand for granting all of them.
+	NodeCapabilityDrain       = "node-drain"
+	NodeCapabilityEligibility = "node-eligibility"
+)
+
+const (
+	// The following are the fine-grained capabilities that can be granted for operator
+	// endpoints. The operator write policy is a short hand for granting all of them.
+	OperatorCapabilitySchedulerConfigWrite = "scheduler-config-write"
 )
 
 var (
@@ -63,6 +91,19 @@ type NamespacePolicy struct {
 	Name         string `hcl:",key"`
 	Policy       string
 	Capabilities []string
+	Variables    *VariablesPolicy `hcl:"variables"`
+}
+
+// VariablesPolicy is the policy for the variables of a namespace
+type VariablesPolicy struct {
+	Paths []*VariablesPathPolicy `hcl:"path,expand"`
+}
+
+// VariablesPathPolicy grants capabilities on the variables matching a path,
+// which may contain globs
+type VariablesPathPolicy struct {
+	PathSpec     string `hcl:",key"`
+	Capabilities []string
 }
 
 type AgentPolicy struct {
@@ -70,11 +111,13 @@ type AgentPolicy struct {
 }
 
 type NodePolicy struct {
-	Policy string
+	Policy       string
+	Capabilities []string
 }
 
 type OperatorPolicy struct {
-	Policy string
+	Policy       string
+	Capabilities []string
 }
 
 type QuotaPolicy struct {
@@ -97,7 +140,9 @@ func isNamespaceCapabilityValid(cap string) bool {
 	case NamespaceCapabilityDeny, NamespaceCapabilityListJobs, NamespaceCapabilityReadJob,
 		NamespaceCapabilitySubmitJob, NamespaceCapabilityDispatchJob, NamespaceCapabilityReadLogs,
 		NamespaceCapabilityReadFS, NamespaceCapabilityAllocLifecycle,
-		NamespaceCapabilityAllocExec, NamespaceCapabilityAllocNodeExec:
+		NamespaceCapabilityAllocExec, NamespaceCapabilityAllocNodeExec,
+		NamespaceCapabilityDeploymentPromote, NamespaceCapabilityDeploymentFail,
+		NamespaceCapabilityScaleJob:
 		return true
 	// Separate the enterprise-only capabilities
 	case NamespaceCapabilitySentinelOverride:
@@ -128,6 +173,69 @@ func expandNamespacePolicy(policy string) []string {
 			NamespaceCapabilityReadFS,
 			NamespaceCapabilityAllocExec,
 			NamespaceCapabilityAllocLifecycle,
+			NamespaceCapabilityDeploymentPromote,
+			NamespaceCapabilityDeploymentFail,
+			NamespaceCapabilityScaleJob,
+		}
+	default:
+		return nil
+	}
+}
+
+// isVariablesCapabilityValid ensures the given capability is valid for a
+// variables path policy
+func isVariablesCapabilityValid(cap string) bool {
+	switch cap {
+	case VariablesCapabilityList, VariablesCapabilityRead, VariablesCapabilityWrite,
+		VariablesCapabilityDestroy, VariablesCapabilityDeny:
+		return true
+	default:
+		return false
+	}
+}
+
+// isNodeCapabilityValid ensures the given capability is valid for a node policy
+func isNodeCapabilityValid(cap string) bool {
+	switch cap {
+	case NodeCapabilityDrain, NodeCapabilityEligibility:
+		return true
+	default:
+		return false
+	}
+}
+
+// expandNodePolicy provides the equivalent set of capabilities for a node
+// policy
+func expandNodePolicy(policy string) []string {
+	switch policy {
+	case PolicyWrite:
+		return []string{
+			NodeCapabilityDrain,
+			NodeCapabilityEligibility,
+		}
+	default:
+		return nil
+	}
+}
+
+// isOperatorCapabilityValid ensures the given capability is valid for an
+// operator policy
+func isOperatorCapabilityValid(cap string) bool {
+	switch cap {
+	case OperatorCapabilitySchedulerConfigWrite:
+		return true
+	default:
+		return false
+	}
+}
+
+// expandOperatorPolicy provides the equivalent set of capabilities for an
+// operator policy
+func expandOperatorPolicy(policy string) []string {
+	switch policy {
+	case PolicyWrite:
+		return []string{
+			OperatorCapabilitySchedulerConfigWrite,
 		}
 	default:
 		return nil
@@ -176,18 +284,55 @@ func Parse(rules string) (*Policy, error) {
 			extraCap := expandNamespacePolicy(ns.Policy)
 			ns.Capabilities = append(ns.Capabilities, extraCap...)
 		}
+
+		if ns.Variables != nil {
+			if len(ns.Variables.Paths) == 0 {
+				return nil, fmt.Errorf("Invalid namespace variables policy, no paths: %#v", ns)
+			}
+			for _, path := range ns.Variables.Paths {
+				if path.PathSpec == "" {
+					return nil, fmt.Errorf("Invalid missing variable path in namespace %s", ns.Name)
+				}
+				for _, cap := range path.Capabilities {
+					if !isVariablesCapabilityValid(cap) {
+						return nil, fmt.Errorf("Invalid variable capability '%s': %#v", cap, path)
+					}
+				}
+			}
+		}
 	}
 
 	if p.Agent != nil && !isPolicyValid(p.Agent.Policy) {
 		return nil, fmt.Errorf("Invalid agent policy: %#v", p.Agent)
 	}
 
-	if p.Node != nil && !isPolicyValid(p.Node.Policy) {
-		return nil, fmt.Errorf("Invalid node policy: %#v", p.Node)
+	if p.Node != nil {
+		if !isPolicyValid(p.Node.Policy) && (p.Node.Policy != "" || len(p.Node.Capabilities) == 0) {
+			return nil, fmt.Errorf("Invalid node policy: %#v", p.Node)
+		}
+		for _, cap := range p.Node.Capabilities {
+			if !isNodeCapabilityValid(cap) {
+				return nil, fmt.Errorf("Invalid node capability '%s': %#v", cap, p.Node)
+			}
+		}
+
+		// Node capabilities operate on nodes the token must be able to read
+		if p.Node.Policy == "" {
+			p.Node.Policy = PolicyRead
+		}
+		p.Node.Capabilities = append(p.Node.Capabilities, expandNodePolicy(p.Node.Policy)...)
 	}
 
-	if p.Operator != nil && !isPolicyValid(p.Operator.Policy) {
-		return nil, fmt.Errorf("Invalid operator policy: %#v", p.Operator)
+	if p.Operator != nil {
+		if !isPolicyValid(p.Operator.Policy) && (p.Operator.Policy != "" || len(p.Operator.Capabilities) == 0) {
+			return nil, fmt.Errorf("Invalid operator policy: %#v", p.Operator)
+		}
+		for _, cap := range p.Operator.Capabilities {
+			if !isOperatorCapabilityValid(cap) {
+				return nil, fmt.Errorf("Invalid operator capability '%s': %#v", cap, p.Operator)
+			}
+		}
+		p.Operator.Capabilities = append(p.Operator.Capabilities, expandOperatorPolicy(p.Operator.Policy)...)
 	}
 
 	if p.Quota != nil && !isPolicyValid(p.Quota.Policy) {
@@ -82,6 +82,9 @@ func TestParse(t *testing.T) {
 							NamespaceCapabilityReadFS,
 							NamespaceCapabilityAllocExec,
 							NamespaceCapabilityAllocLifecycle,
+							NamespaceCapabilityDeploymentPromote,
+							NamespaceCapabilityDeploymentFail,
+							NamespaceCapabilityScaleJob,
 						},
 					},
 					{
@@ -97,6 +100,10 @@ func TestParse(t *testing.T) {
 				},
 				Node: &NodePolicy{
 					Policy: PolicyWrite,
+					Capabilities: []string{
+						NodeCapabilityDrain,
+						NodeCapabilityEligibility,
+					},
 				},
 				Operator: &OperatorPolicy{
 					Policy: PolicyDeny,
@@ -142,6 +149,39 @@ func TestParse(t *testing.T) {
 			"Invalid node policy",
 			nil,
 		},
+		{
+			`
+			node {
+				capabilities = ["node-drain", "foo"]
+			}
+			`,
+			"Invalid node capability",
+			nil,
+		},
+		{
+			`
+			node {
+			}
+			`,
+			"Invalid node policy",
+			nil,
+		},
+		{
+			`
+			node {
+				capabilities = ["node-drain"]
+			}
+			`,
+			"",
+			&Policy{
+				Node: &NodePolicy{
+					Policy: PolicyRead,
+					Capabilities: []string{
+						NodeCapabilityDrain,
+					},
+				},
+			},
+		},
 		{
 			`
 			operator {
@@ -151,6 +191,53 @@ func TestParse(t *testing.T) {
 			"Invalid operator policy",
 			nil,
 		},
+		{
+			`
+			operator {
+				capabilities = ["foo"]
+			}
+			`,
+			"Invalid operator capability",
+			nil,
+		},
+		{
+			`
+			namespace "default" {
+				capabilities = ["deployment-promote", "deployment-fail", "job-scale"]
+			}
+			node {
+				policy = "read"
+				capabilities = ["node-drain"]
+			}
+			operator {
+				capabilities = ["scheduler-config-write"]
+			}
+			`,
+			"",
+			&Policy{
+				Namespaces: []*NamespacePolicy{
+					{
+						Name: "default",
+						Capabilities: []string{
+							NamespaceCapabilityDeploymentPromote,
+							NamespaceCapabilityDeploymentFail,
+							NamespaceCapabilityScaleJob,
+						},
+					},
+				},
+				Node: &NodePolicy{
+					Policy: PolicyRead,
+					Capabilities: []string{
+						NodeCapabilityDrain,
+					},
+				},
+				Operator: &OperatorPolicy{
+					Capabilities: []string{
+						OperatorCapabilitySchedulerConfigWrite,
+					},
+				},
+			},
+		},
 		{
 			`
 			quota {
@@ -199,6 +286,68 @@ func TestParse(t *testing.T) {
 				},
 			},
 		},
+		{
+			`
+			namespace "default" {
+				policy = "read"
+				variables {
+					path "nomad/jobs/*" {
+						capabilities = ["list", "read"]
+					}
+					path "shared" {
+						capabilities = ["write", "destroy"]
+					}
+				}
+			}
+			`,
+			"",
+			&Policy{
+				Namespaces: []*NamespacePolicy{
+					{
+						Name:   "default",
+						Policy: PolicyRead,
+						Capabilities: []string{
+							NamespaceCapabilityListJobs,
+							NamespaceCapabilityReadJob,
+						},
+						Variables: &VariablesPolicy{
+							Paths: []*VariablesPathPolicy{
+								{
+									PathSpec:     "nomad/jobs/*",
+									Capabilities: []string{VariablesCapabilityList, VariablesCapabilityRead},
+								},
+								{
+									PathSpec:     "shared",
+									Capabilities: []string{VariablesCapabilityWrite, VariablesCapabilityDestroy},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			`
+			namespace "default" {
+				variables {
+					path "foo" {
+						capabilities = ["submit-job"]
+					}
+				}
+			}
+			`,
+			"Invalid variable capability",
+			nil,
+		},
+		{
+			`
+			namespace "default" {
+				variables {}
+			}
+			`,
+			"no paths",
+			nil,
+		},
 	}
 
 	for idx, tc := range tcases {
@@ -33,6 +33,7 @@ const (
 	AllocClientStatusComplete = "complete"
 	AllocClientStatusFailed   = "failed"
 	AllocClientStatusLost     = "lost"
+	AllocClientStatusUnknown  = "unknown"
 )
 
 // Allocations is used to query the alloc-related endpoints.
@@ -463,7 +464,8 @@ type AllocatedSharedResources struct {
 }
 
 type AllocatedCpuResources struct {
-	CpuShares int64
+	CpuShares     int64
+	ReservedCores []uint16
 }
 
 type AllocatedMemoryResources struct {
@@ -169,6 +169,10 @@ type Deployment struct {
 type DeploymentState struct {
 	PlacedCanaries    []string
 	AutoRevert        bool
+	AutoPromote       bool
+	PromoteAfter      time.Duration
+	Steps             []*UpdateStep
+	CurrentStep       int
 	ProgressDeadline  time.Duration
 	RequireProgressBy time.Time
 	Promoted          bool
@@ -5,6 +5,7 @@ import (
 	"net/url"
 	"sort"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gorhill/cronexpr"
@@ -20,9 +21,32 @@ const (
 	// JobTypeSystem indicates a system process that should run on all clients
 	JobTypeSystem = "system"
 
+	// JobTypeSysBatch indicates a short-lived system process that should run
+	// on all clients.
+	JobTypeSysBatch = "sysbatch"
+
 	// PeriodicSpecCron is used for a cron spec.
 	PeriodicSpecCron = "cron"
 
+	// PeriodicExcludeDateFormat is the format of dates excluded from launching
+	// a periodic job.
+	PeriodicExcludeDateFormat = "2006-01-02"
+
+	// periodicExcludeHorizonYears bounds how far ahead the next launch of a
+	// periodic job that isn't excluded is searched for.
+	periodicExcludeHorizonYears = 5
+
+	// PeriodicCatchupSkip skips launches missed while there was no leader or
+	// the job was disabled.
+	PeriodicCatchupSkip = "skip"
+
+	// PeriodicCatchupRunOnce launches only the most recent missed launch.
+	PeriodicCatchupRunOnce = "run_once"
+
+	// PeriodicCatchupRunAll launches every missed launch within the catch-up
+	// lookback.
+	PeriodicCatchupRunAll = "run_all"
+
 	// DefaultNamespace is the default namespace.
 	DefaultNamespace = "default"
 )
@@ -43,6 +67,14 @@ type JobsParseRequest struct {
 	// JobHCL is an hcl jobspec
 	JobHCL string
 
+	// HCLv2 indicates whether the jobspec should be parsed as HCL2 rather
+	// than HCL1
+	HCLv2 bool `json:",omitempty"`
+
+	// Variables are HCL2 variable values in the variable file format. They
+	// require HCLv2.
+	Variables string `json:",omitempty"`
+
 	// Canonicalize is a flag as to if the server should return default values
 	// for unset fields
 	Canonicalize bool
@@ -56,11 +88,17 @@ func (c *Client) Jobs() *Jobs {
 // Parse is used to convert the HCL repesentation of a Job to JSON server side.
 // To parse the HCL client side see package github.com/hashicorp/nomad/jobspec
 func (j *Jobs) ParseHCL(jobHCL string, canonicalize bool) (*Job, error) {
-	var job Job
 	req := &JobsParseRequest{
 		JobHCL:       jobHCL,
 		Canonicalize: canonicalize,
 	}
+	return j.ParseHCLOpts(req)
+}
+
+// ParseHCLOpts is used to convert the HCL representation of a Job to JSON
+// server side, with the parsing options of the request.
+func (j *Jobs) ParseHCLOpts(req *JobsParseRequest) (*Job, error) {
+	var job Job
 	_, err := j.client.write("/v1/jobs/parse", req, &job, nil)
 	return &job, err
 }
@@ -312,11 +350,30 @@ func (j *Jobs) Summary(jobID string, q *QueryOptions) (*JobSummary, *QueryMeta,
 
 func (j *Jobs) Dispatch(jobID string, meta map[string]string,
 	payload []byte, q *WriteOptions) (*JobDispatchResponse, *WriteMeta, error) {
+	opts := DispatchOptions{Meta: meta, Payload: payload}
+	return j.DispatchOpts(jobID, &opts, q)
+}
+
+// DispatchOptions is used to pass through job dispatch parameters
+type DispatchOptions struct {
+	Meta    map[string]string
+	Payload []byte
+
+	// IdempotencyToken makes the dispatch idempotent. Repeated dispatches
+	// with the same token return the job dispatched first instead of
+	// dispatching a new job.
+	IdempotencyToken string
+}
+
+func (j *Jobs) DispatchOpts(jobID string, opts *DispatchOptions, q *WriteOptions) (*JobDispatchResponse, *WriteMeta, error) {
 	var resp JobDispatchResponse
 	req := &JobDispatchRequest{
-		JobID:   jobID,
-		Meta:    meta,
-		Payload: payload,
+		JobID: jobID,
+	}
+	if opts != nil {
+		req.Meta = opts.Meta
+		req.Payload = opts.Payload
+		req.IdempotencyToken = opts.IdempotencyToken
 	}
 	wm, err := j.client.write("/v1/job/"+jobID+"/dispatch", req, &resp, q)
 	if err != nil {
@@ -325,6 +382,16 @@ func (j *Jobs) Dispatch(jobID string, meta map[string]string,
 	return &resp, wm, nil
 }
 
+// Dispatches is used to list the jobs dispatched from a parameterized job
+func (j *Jobs) Dispatches(jobID string, q *QueryOptions) ([]*JobDispatchStub, *QueryMeta, error) {
+	var resp []*JobDispatchStub
+	qm, err := j.client.query("/v1/job/"+jobID+"/dispatches", &resp, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	return resp, qm, nil
+}
+
 // Revert is used to revert the given job to the passed version. If
 // enforceVersion is set, the job is only reverted if the current version is at
 // the passed version.
@@ -367,16 +434,170 @@ type periodicForceResponse struct {
 	EvalID string
 }
 
+// PeriodicHistory returns the launch history of the periodic job
+func (j *Jobs) PeriodicHistory(jobID string, q *QueryOptions) (*PeriodicLaunch, *QueryMeta, error) {
+	var resp PeriodicLaunch
+	qm, err := j.client.query("/v1/job/"+jobID+"/periodic/history", &resp, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &resp, qm, nil
+}
+
+// PeriodicLaunch tracks the launches of a periodic job.
+type PeriodicLaunch struct {
+	ID          string
+	Namespace   string
+	Launch      time.Time
+	History     []*PeriodicLaunchEvent
+	CreateIndex uint64
+	ModifyIndex uint64
+}
+
+// PeriodicLaunchEvent records the outcome of a single launch of a periodic
+// job.
+type PeriodicLaunchEvent struct {
+	Launch      time.Time
+	Status      string
+	Description string
+}
+
 // UpdateStrategy defines a task groups update strategy.
 type UpdateStrategy struct {
-	Stagger          *time.Duration `mapstructure:"stagger"`
-	MaxParallel      *int           `mapstructure:"max_parallel"`
-	HealthCheck      *string        `mapstructure:"health_check"`
-	MinHealthyTime   *time.Duration `mapstructure:"min_healthy_time"`
-	HealthyDeadline  *time.Duration `mapstructure:"healthy_deadline"`
-	ProgressDeadline *time.Duration `mapstructure:"progress_deadline"`
-	AutoRevert       *bool          `mapstructure:"auto_revert"`
-	Canary           *int           `mapstructure:"canary"`
+	Stagger          *time.Duration      `mapstructure:"stagger"`
+	MaxParallel      *int                `mapstructure:"max_parallel"`
+	HealthCheck      *string             `mapstructure:"health_check"`
+	MinHealthyTime   *time.Duration      `mapstructure:"min_healthy_time"`
+	HealthyDeadline  *time.Duration      `mapstructure:"healthy_deadline"`
+	ProgressDeadline *time.Duration      `mapstructure:"progress_deadline"`
+	AutoRevert       *bool               `mapstructure:"auto_revert"`
+	Canary           *int                `mapstructure:"canary"`
+	AutoPromote      *bool               `mapstructure:"auto_promote"`
+	PromoteAfter     *time.Duration      `mapstructure:"promote_after"`
+	Steps            []*UpdateStep       `mapstructure:"steps"`
+	Analysis         *DeploymentAnalysis `mapstructure:"analysis"`
+}
+
+// UpdateStep is a stage of a multi-step rollout.
+type UpdateStep struct {
+	Canary  *int           `mapstructure:"canary"`
+	Percent *int           `mapstructure:"percent"`
+	Pause   *time.Duration `mapstructure:"pause"`
+}
+
+func (s *UpdateStep) Copy() *UpdateStep {
+	if s == nil {
+		return nil
+	}
+
+	copy := new(UpdateStep)
+
+	if s.Canary != nil {
+		copy.Canary = intToPtr(*s.Canary)
+	}
+
+	if s.Percent != nil {
+		copy.Percent = intToPtr(*s.Percent)
+	}
+
+	if s.Pause != nil {
+		copy.Pause = timeToPtr(*s.Pause)
+	}
+
+	return copy
+}
+
+func (s *UpdateStep) Canonicalize() {
+	if s.Canary == nil {
+		s.Canary = intToPtr(0)
+	}
+
+	if s.Percent == nil {
+		s.Percent = intToPtr(0)
+	}
+
+	if s.Pause == nil {
+		s.Pause = timeToPtr(0)
+	}
+}
+
+func copyUpdateSteps(steps []*UpdateStep) []*UpdateStep {
+	if steps == nil {
+		return nil
+	}
+
+	copy := make([]*UpdateStep, len(steps))
+	for i, s := range steps {
+		copy[i] = s.Copy()
+	}
+	return copy
+}
+
+// DeploymentAnalysis gates a deployment on metrics queried from a Prometheus
+// compatible HTTP API.
+type DeploymentAnalysis struct {
+	Address      *string           `mapstructure:"address"`
+	Interval     *time.Duration    `mapstructure:"interval"`
+	FailureLimit *int              `mapstructure:"failure_limit"`
+	Metrics      []*AnalysisMetric `mapstructure:"metric"`
+}
+
+// AnalysisMetric is a metric of a deployment analysis and its thresholds.
+type AnalysisMetric struct {
+	Name  string   `mapstructure:"name"`
+	Query string   `mapstructure:"query"`
+	Min   *float64 `mapstructure:"min"`
+	Max   *float64 `mapstructure:"max"`
+}
+
+func (a *DeploymentAnalysis) Copy() *DeploymentAnalysis {
+	if a == nil {
+		return nil
+	}
+
+	copy := new(DeploymentAnalysis)
+
+	if a.Address != nil {
+		copy.Address = stringToPtr(*a.Address)
+	}
+
+	if a.Interval != nil {
+		copy.Interval = timeToPtr(*a.Interval)
+	}
+
+	if a.FailureLimit != nil {
+		copy.FailureLimit = intToPtr(*a.FailureLimit)
+	}
+
+	if a.Metrics != nil {
+		copy.Metrics = make([]*AnalysisMetric, len(a.Metrics))
+		for i, m := range a.Metrics {
+			nm := *m
+			if m.Min != nil {
+				nm.Min = float64ToPtr(*m.Min)
+			}
+			if m.Max != nil {
+				nm.Max = float64ToPtr(*m.Max)
+			}
+			copy.Metrics[i] = &nm
+		}
+	}
+
+	return copy
+}
+
+func (a *DeploymentAnalysis) Canonicalize() {
+	if a.Address == nil {
+		a.Address = stringToPtr("")
+	}
+
+	if a.Interval == nil {
+		a.Interval = timeToPtr(30 * time.Second)
+	}
+
+	if a.FailureLimit == nil {
+		a.FailureLimit = intToPtr(1)
+	}
 }
 
 // DefaultUpdateStrategy provides a baseline that can be used to upgrade
@@ -391,6 +612,8 @@ func DefaultUpdateStrategy() *UpdateStrategy {
 		ProgressDeadline: timeToPtr(10 * time.Minute),
 		AutoRevert:       boolToPtr(false),
 		Canary:           intToPtr(0),
+		AutoPromote:      boolToPtr(false),
+		PromoteAfter:     timeToPtr(0),
 	}
 }
 
@@ -433,6 +656,17 @@ func (u *UpdateStrategy) Copy() *UpdateStrategy {
 		copy.Canary = intToPtr(*u.Canary)
 	}
 
+	if u.AutoPromote != nil {
+		copy.AutoPromote = boolToPtr(*u.AutoPromote)
+	}
+
+	if u.PromoteAfter != nil {
+		copy.PromoteAfter = timeToPtr(*u.PromoteAfter)
+	}
+
+	copy.Steps = copyUpdateSteps(u.Steps)
+	copy.Analysis = u.Analysis.Copy()
+
 	return copy
 }
 
@@ -472,6 +706,22 @@ func (u *UpdateStrategy) Merge(o *UpdateStrategy) {
 	if o.Canary != nil {
 		u.Canary = intToPtr(*o.Canary)
 	}
+
+	if o.AutoPromote != nil {
+		u.AutoPromote = boolToPtr(*o.AutoPromote)
+	}
+
+	if o.PromoteAfter != nil {
+		u.PromoteAfter = timeToPtr(*o.PromoteAfter)
+	}
+
+	if o.Steps != nil {
+		u.Steps = copyUpdateSteps(o.Steps)
+	}
+
+	if o.Analysis != nil {
+		u.Analysis = o.Analysis.Copy()
+	}
 }
 
 func (u *UpdateStrategy) Canonicalize() {
@@ -508,6 +758,22 @@ func (u *UpdateStrategy) Canonicalize() {
 	if u.Canary == nil {
 		u.Canary = d.Canary
 	}
+
+	if u.AutoPromote == nil {
+		u.AutoPromote = d.AutoPromote
+	}
+
+	if u.PromoteAfter == nil {
+		u.PromoteAfter = d.PromoteAfter
+	}
+
+	for _, s := range u.Steps {
+		s.Canonicalize()
+	}
+
+	if u.Analysis != nil {
+		u.Analysis.Canonicalize()
+	}
 }
 
 // Empty returns whether the UpdateStrategy is empty or has user defined values.
@@ -548,6 +814,22 @@ func (u *UpdateStrategy) Empty() bool {
 		return false
 	}
 
+	if u.AutoPromote != nil && *u.AutoPromote {
+		return false
+	}
+
+	if u.PromoteAfter != nil && *u.PromoteAfter != 0 {
+		return false
+	}
+
+	if len(u.Steps) != 0 {
+		return false
+	}
+
+	if u.Analysis != nil {
+		return false
+	}
+
 	return true
 }
 
@@ -555,9 +837,13 @@ func (u *UpdateStrategy) Empty() bool {
 type PeriodicConfig struct {
 	Enabled         *bool
 	Spec            *string
+	Specs           []string
+	Exclude         []string
 	SpecType        *string
-	ProhibitOverlap *bool   `mapstructure:"prohibit_overlap"`
-	TimeZone        *string `mapstructure:"time_zone"`
+	ProhibitOverlap *bool          `mapstructure:"prohibit_overlap"`
+	TimeZone        *string        `mapstructure:"time_zone"`
+	Catchup         *string        `mapstructure:"catchup"`
+	CatchupLookback *time.Duration `mapstructure:"catchup_lookback"`
 }
 
 func (p *PeriodicConfig) Canonicalize() {
@@ -576,20 +862,216 @@ func (p *PeriodicConfig) Canonicalize() {
 	if p.TimeZone == nil || *p.TimeZone == "" {
 		p.TimeZone = stringToPtr("UTC")
 	}
+	if p.Catchup == nil || *p.Catchup == "" {
+		p.Catchup = stringToPtr(PeriodicCatchupRunOnce)
+	}
+	if p.CatchupLookback == nil {
+		p.CatchupLookback = timeToPtr(0)
+	}
 }
 
 // Next returns the closest time instant matching the spec that is after the
 // passed time. If no matching instance exists, the zero value of time.Time is
 // returned. The `time.Location` of the returned value matches that of the
 // passed time.
+// ---  THIS FUNCTION IS REPLICATED IN nomad/structs/structs.go
+// and should be kept in sync.
 func (p *PeriodicConfig) Next(fromTime time.Time) (time.Time, error) {
-	if *p.SpecType == PeriodicSpecCron {
-		if e, err := cronexpr.Parse(*p.Spec); err == nil {
-			return cronParseNext(e, fromTime, *p.Spec)
+	if *p.SpecType != PeriodicSpecCron {
+		return time.Time{}, nil
+	}
+
+	// Parse the specs and exclusions once rather than for every launch
+	specs := p.cronSpecs()
+	exclusions := p.exclusions(fromTime.Location())
+
+	// Excluded days and hours are skipped as a whole, so the search past
+	// excluded launches is bounded by time rather than by their number
+	horizon := fromTime.AddDate(periodicExcludeHorizonYears, 0, 0)
+	next := fromTime
+	for skipped := false; ; skipped = true {
+		var err error
+		next, err = periodicNextCron(specs, next)
+		if err != nil || next.IsZero() {
+			return next, err
+		}
+		if skipped && next.After(horizon) {
+			return time.Time{}, fmt.Errorf("every launch within %d years is excluded", periodicExcludeHorizonYears)
+		}
+
+		excluded, skipTo := periodicExcluded(exclusions, next)
+		if !excluded {
+			return next, nil
+		}
+		if !skipTo.IsZero() {
+			next = skipTo
+		}
+	}
+}
+
+// NextLaunches returns up to n launch times after the passed time. Fewer
+// launches are returned if the periodic config has no more launches.
+func (p *PeriodicConfig) NextLaunches(fromTime time.Time, n int) ([]time.Time, error) {
+	var launches []time.Time
+	next := fromTime
+	for len(launches) < n {
+		var err error
+		next, err = p.Next(next)
+		if err != nil {
+			return nil, err
+		}
+		if next.IsZero() {
+			break
+		}
+		launches = append(launches, next)
+	}
+	return launches, nil
+}
+
+// periodicCronSpec is a parsed cron spec of a periodic config
+type periodicCronSpec struct {
+	spec string
+	expr *cronexpr.Expression
+}
+
+// cronSpecs returns the parsed cron specs of the periodic config. Specs that
+// fail to parse are ignored.
+// ---  THIS FUNCTION IS REPLICATED IN nomad/structs/structs.go
+// and should be kept in sync.
+func (p *PeriodicConfig) cronSpecs() []*periodicCronSpec {
+	specs := p.Specs
+	if p.Spec != nil {
+		specs = append([]string{*p.Spec}, specs...)
+	}
+
+	parsed := make([]*periodicCronSpec, 0, len(specs))
+	for _, spec := range specs {
+		e, err := cronexpr.Parse(spec)
+		if err != nil {
+			continue
 		}
+		parsed = append(parsed, &periodicCronSpec{spec: spec, expr: e})
 	}
+	return parsed
+}
 
-	return time.Time{}, nil
+// periodicExclusion is a parsed exclusion of a periodic config, which is
+// either a date or a cron expression. Cron expressions matching every minute
+// of an hour, or of a day, exclude the whole hour or day.
+type periodicExclusion struct {
+	spec      string
+	date      time.Time
+	expr      *cronexpr.Expression
+	wholeHour bool
+	wholeDay  bool
+}
+
+// exclusions returns the parsed exclusions of the periodic config, with dates
+// in the passed location. Exclusions that fail to parse are ignored.
+// ---  THIS FUNCTION IS REPLICATED IN nomad/structs/structs.go
+// and should be kept in sync.
+func (p *PeriodicConfig) exclusions(location *time.Location) []*periodicExclusion {
+	exclusions := make([]*periodicExclusion, 0, len(p.Exclude))
+	for _, exclude := range p.Exclude {
+		if date, err := time.ParseInLocation(PeriodicExcludeDateFormat, exclude, location); err == nil {
+			exclusions = append(exclusions, &periodicExclusion{spec: exclude, date: date})
+			continue
+		}
+
+		e, err := cronexpr.Parse(exclude)
+		if err != nil {
+			continue
+		}
+		wholeHour, wholeDay := cronWholeHourDay(exclude)
+		exclusions = append(exclusions, &periodicExclusion{
+			spec:      exclude,
+			expr:      e,
+			wholeHour: wholeHour,
+			wholeDay:  wholeDay,
+		})
+	}
+	return exclusions
+}
+
+// cronWholeHourDay returns whether the cron expression matches every minute
+// of the hours it matches, and whether it also matches every hour of the days
+// it matches.
+// ---  THIS FUNCTION IS REPLICATED IN nomad/structs/structs.go
+// and should be kept in sync.
+func cronWholeHourDay(spec string) (bool, bool) {
+	fields := strings.Fields(spec)
+	var minute, hour string
+	switch len(fields) {
+	case 5, 6:
+		minute, hour = fields[0], fields[1]
+	case 7:
+		if !cronWildcard(fields[0], 59) {
+			return false, false
+		}
+		minute, hour = fields[1], fields[2]
+	default:
+		return false, false
+	}
+
+	wholeHour := cronWildcard(minute, 59)
+	return wholeHour, wholeHour && cronWildcard(hour, 23)
+}
+
+// cronWildcard returns whether the cron field matches all of its values from
+// zero to max
+// ---  THIS FUNCTION IS REPLICATED IN nomad/structs/structs.go
+// and should be kept in sync.
+func cronWildcard(field string, max int) bool {
+	return field == "*" || field == "*/1" || field == fmt.Sprintf("0-%d", max)
+}
+
+// periodicNextCron returns the earliest time after the passed time matching
+// any of the cron specs.
+// ---  THIS FUNCTION IS REPLICATED IN nomad/structs/structs.go
+// and should be kept in sync.
+func periodicNextCron(specs []*periodicCronSpec, fromTime time.Time) (time.Time, error) {
+	var next time.Time
+	for _, spec := range specs {
+		t, err := cronParseNext(spec.expr, fromTime, spec.spec)
+		if err != nil {
+			return time.Time{}, err
+		}
+		if !t.IsZero() && (next.IsZero() || t.Before(next)) {
+			next = t
+		}
+	}
+	return next, nil
+}
+
+// periodicExcluded returns whether the passed launch time is excluded and,
+// for launches excluded by a date or by a cron expression matching their
+// whole day or hour, the time to continue searching for launches from.
+// ---  THIS FUNCTION IS REPLICATED IN nomad/structs/structs.go
+// and should be kept in sync.
+func periodicExcluded(exclusions []*periodicExclusion, launch time.Time) (bool, time.Time) {
+	y, m, d := launch.Date()
+	endOfDay := time.Date(y, m, d+1, 0, 0, 0, 0, launch.Location()).Add(-time.Second)
+	for _, exclusion := range exclusions {
+		if !exclusion.date.IsZero() {
+			if ey, em, ed := exclusion.date.Date(); ey == y && em == m && ed == d {
+				return true, endOfDay
+			}
+			continue
+		}
+
+		t, err := cronParseNext(exclusion.expr, launch.Add(-time.Second), exclusion.spec)
+		if err != nil || !t.Equal(launch) {
+			continue
+		}
+		switch {
+		case exclusion.wholeDay:
+			return true, endOfDay
+		case exclusion.wholeHour:
+			return true, time.Date(y, m, d, launch.Hour()+1, 0, 0, 0, launch.Location()).Add(-time.Second)
+		}
+		return true, time.Time{}
+	}
+	return false, time.Time{}
 }
 
 // cronParseNext is a helper that parses the next time for the given expression
@@ -616,9 +1098,10 @@ func (p *PeriodicConfig) GetLocation() (*time.Location, error) {
 
 // ParameterizedJobConfig is used to configure the parameterized job.
 type ParameterizedJobConfig struct {
-	Payload      string
-	MetaRequired []string `mapstructure:"meta_required"`
-	MetaOptional []string `mapstructure:"meta_optional"`
+	Payload       string
+	MetaRequired  []string `mapstructure:"meta_required"`
+	MetaOptional  []string `mapstructure:"meta_optional"`
+	MaxConcurrent int      `mapstructure:"max_concurrent"`
 }
 
 // Job is used to serialize a job.
@@ -764,6 +1247,7 @@ type JobSummary struct {
 
 // JobChildrenSummary contains the summary of children job status
 type JobChildrenSummary struct {
+	Queued  int64
 	Pending int64
 	Running int64
 	Dead    int64
@@ -774,7 +1258,7 @@ func (jc *JobChildrenSummary) Sum() int {
 		return 0
 	}
 
-	return int(jc.Pending + jc.Running + jc.Dead)
+	return int(jc.Queued + jc.Pending + jc.Running + jc.Dead)
 }
 
 // TaskGroup summarizes the state of all the allocations of a particular
@@ -786,6 +1270,7 @@ type TaskGroupSummary struct {
 	Running  int
 	Starting int
 	Lost     int
+	Unknown  int
 }
 
 // JobListStub is used to return a subset of information about
@@ -1066,9 +1551,26 @@ type DesiredUpdates struct {
 }
 
 type JobDispatchRequest struct {
-	JobID   string
-	Payload []byte
-	Meta    map[string]string
+	JobID            string
+	Payload          []byte
+	Meta             map[string]string
+	IdempotencyToken string
+}
+
+// JobDispatchStub is used to summarize a job dispatched from a parameterized
+// job.
+type JobDispatchStub struct {
+	ID                string
+	ParentID          string
+	Stop              bool
+	Status            string
+	StatusDescription string
+	Meta              map[string]string
+	PayloadSize       int
+	IdempotencyToken  string
+	SubmitTime        int64
+	CreateIndex       uint64
+	ModifyIndex       uint64
 }
 
 type JobDispatchResponse struct {
@@ -1076,6 +1578,7 @@ type JobDispatchResponse struct {
 	EvalID          string
 	EvalCreateIndex uint64
 	JobCreateIndex  uint64
+	Queued          bool
 	WriteMeta
 }
 
@@ -323,6 +323,8 @@ func TestJobs_Canonicalize(t *testing.T) {
 					ProgressDeadline: timeToPtr(10 * time.Minute),
 					AutoRevert:       boolToPtr(false),
 					Canary:           intToPtr(0),
+					AutoPromote:      boolToPtr(false),
+					PromoteAfter:     timeToPtr(0),
 				},
 				TaskGroups: []*TaskGroup{
 					{
@@ -357,6 +359,8 @@ func TestJobs_Canonicalize(t *testing.T) {
 							ProgressDeadline: timeToPtr(10 * time.Minute),
 							AutoRevert:       boolToPtr(false),
 							Canary:           intToPtr(0),
+							AutoPromote:      boolToPtr(false),
+							PromoteAfter:     timeToPtr(0),
 						},
 						Migrate: DefaultMigrateStrategy(),
 						Tasks: []*Task{
@@ -467,6 +471,8 @@ func TestJobs_Canonicalize(t *testing.T) {
 					SpecType:        stringToPtr(PeriodicSpecCron),
 					ProhibitOverlap: boolToPtr(false),
 					TimeZone:        stringToPtr("UTC"),
+					Catchup:         stringToPtr(PeriodicCatchupRunOnce),
+					CatchupLookback: timeToPtr(0),
 				},
 			},
 		},
@@ -541,6 +547,8 @@ func TestJobs_Canonicalize(t *testing.T) {
 					ProgressDeadline: timeToPtr(7 * time.Minute),
 					AutoRevert:       boolToPtr(false),
 					Canary:           intToPtr(0),
+					AutoPromote:      boolToPtr(false),
+					PromoteAfter:     timeToPtr(0),
 				},
 				TaskGroups: []*TaskGroup{
 					{
@@ -574,6 +582,8 @@ func TestJobs_Canonicalize(t *testing.T) {
 							ProgressDeadline: timeToPtr(7 * time.Minute),
 							AutoRevert:       boolToPtr(true),
 							Canary:           intToPtr(1),
+							AutoPromote:      boolToPtr(false),
+							PromoteAfter:     timeToPtr(0),
 						},
 						Migrate: DefaultMigrateStrategy(),
 						Tasks: []*Task{
@@ -616,6 +626,8 @@ func TestJobs_Canonicalize(t *testing.T) {
 							ProgressDeadline: timeToPtr(7 * time.Minute),
 							AutoRevert:       boolToPtr(false),
 							Canary:           intToPtr(0),
+							AutoPromote:      boolToPtr(false),
+							PromoteAfter:     timeToPtr(0),
 						},
 						Migrate: DefaultMigrateStrategy(),
 						Tasks: []*Task{
@@ -1041,6 +1053,45 @@ func TestJobs_ForceEvaluate(t *testing.T) {
 	t.Fatalf("evaluation %q missing", evalID)
 }
 
+func TestJobs_PeriodicConfig_NextLaunches(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	p := &PeriodicConfig{
+		Spec:    stringToPtr("0 9 * * 1-5"),
+		Specs:   []string{"0 12 * * 0,6"},
+		Exclude: []string{"2019-12-25"},
+	}
+	p.Canonicalize()
+
+	from := time.Date(2019, 12, 24, 0, 0, 0, 0, time.UTC)
+	launches, err := p.NextLaunches(from, 4)
+	require.NoError(err)
+	require.Equal([]time.Time{
+		time.Date(2019, 12, 24, 9, 0, 0, 0, time.UTC),
+		time.Date(2019, 12, 26, 9, 0, 0, 0, time.UTC),
+		time.Date(2019, 12, 27, 9, 0, 0, 0, time.UTC),
+		time.Date(2019, 12, 28, 12, 0, 0, 0, time.UTC),
+	}, launches)
+
+	// Excluded months of launches every minute are skipped
+	p.Spec = stringToPtr("* * * * *")
+	p.Specs = nil
+	p.Exclude = []string{"* * * 12 *"}
+	launches, err = p.NextLaunches(time.Date(2019, 11, 30, 23, 59, 0, 0, time.UTC), 2)
+	require.NoError(err)
+	require.Equal([]time.Time{
+		time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2020, 1, 1, 0, 1, 0, 0, time.UTC),
+	}, launches)
+
+	// Exclusions ruling out every launch fail
+	p.Spec = stringToPtr("0 9 * * *")
+	p.Exclude = []string{"0 9 * * *"}
+	_, err = p.NextLaunches(from, 1)
+	require.Error(err)
+}
+
 func TestJobs_PeriodicForce(t *testing.T) {
 	t.Parallel()
 	c, s := makeClient(t, nil, nil)
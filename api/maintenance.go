@@ -0,0 +1,157 @@
+package api
+
+import (
+	"sort"
+	"time"
+)
+
+const (
+	// MaintenancePlanStatus* are the statuses of a maintenance plan
+	MaintenancePlanStatusPending   = "pending"
+	MaintenancePlanStatusRunning   = "running"
+	MaintenancePlanStatusComplete  = "complete"
+	MaintenancePlanStatusCancelled = "cancelled"
+
+	// MaintenanceNodeStatus* are the statuses of a node in a maintenance
+	// plan
+	MaintenanceNodeStatusPending  = "pending"
+	MaintenanceNodeStatusDraining = "draining"
+	MaintenanceNodeStatusDrained  = "drained"
+	MaintenanceNodeStatusComplete = "complete"
+	MaintenanceNodeStatusSkipped  = "skipped"
+)
+
+// Maintenance is used to query the maintenance plan endpoints.
+type Maintenance struct {
+	client *Client
+}
+
+// Maintenance returns a new handle on the maintenance plans.
+func (c *Client) Maintenance() *Maintenance {
+	return &Maintenance{client: c}
+}
+
+// MaintenancePlan drains a set of nodes in batches. The next batch of nodes
+// is only drained once all the nodes of the previous batch are drained and
+// marked eligible again.
+type MaintenancePlan struct {
+	ID string
+
+	// Datacenters, NodeClass and Meta select the nodes of the plan. A node
+	// must match all of the given criteria to be selected.
+	Datacenters []string
+	NodeClass   string
+	Meta        map[string]string
+
+	// BatchSize is the number of nodes drained at the same time
+	BatchSize int
+
+	// StartTime is the start of the maintenance window. It defaults to the
+	// creation time of the plan.
+	StartTime time.Time
+
+	// Deadline is the drain deadline of each node. A zero deadline lets
+	// allocations drain without being force stopped.
+	Deadline time.Duration
+
+	// IgnoreSystemJobs leaves the system jobs running on the drained nodes
+	IgnoreSystemJobs bool
+
+	Status            string
+	StatusDescription string
+
+	// Nodes are the nodes selected when the plan was created, in the order
+	// they are drained
+	Nodes []*MaintenancePlanNode
+
+	CreateIndex uint64
+	ModifyIndex uint64
+}
+
+// MaintenancePlanNode is the maintenance status of a node of a plan
+type MaintenancePlanNode struct {
+	NodeID string
+	Name   string
+	Batch  int
+	Status string
+}
+
+// MaintenancePlanRegisterRequest is used to create a maintenance plan
+type MaintenancePlanRegisterRequest struct {
+	Plan *MaintenancePlan
+	WriteRequest
+}
+
+// MaintenancePlanUpdateResponse is used to respond to a maintenance plan
+// creation or cancellation
+type MaintenancePlanUpdateResponse struct {
+	Plan *MaintenancePlan
+	WriteMeta
+}
+
+// MaintenancePlanIndexSort is a wrapper to sort maintenance plans by
+// CreateIndex. We reverse the test so that we get the highest index first.
+type MaintenancePlanIndexSort []*MaintenancePlan
+
+func (m MaintenancePlanIndexSort) Len() int {
+	return len(m)
+}
+
+func (m MaintenancePlanIndexSort) Less(i, j int) bool {
+	return m[i].CreateIndex > m[j].CreateIndex
+}
+
+func (m MaintenancePlanIndexSort) Swap(i, j int) {
+	m[i], m[j] = m[j], m[i]
+}
+
+// List is used to dump all of the maintenance plans.
+func (m *Maintenance) List(q *QueryOptions) ([]*MaintenancePlan, *QueryMeta, error) {
+	var resp []*MaintenancePlan
+	qm, err := m.client.query("/v1/maintenance/plans", &resp, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	sort.Sort(MaintenancePlanIndexSort(resp))
+	return resp, qm, nil
+}
+
+// PrefixList is used to list the maintenance plans with an ID prefix.
+func (m *Maintenance) PrefixList(prefix string) ([]*MaintenancePlan, *QueryMeta, error) {
+	return m.List(&QueryOptions{Prefix: prefix})
+}
+
+// Info is used to query a single maintenance plan by its ID.
+func (m *Maintenance) Info(planID string, q *QueryOptions) (*MaintenancePlan, *QueryMeta, error) {
+	var resp MaintenancePlan
+	qm, err := m.client.query("/v1/maintenance/plan/"+planID, &resp, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &resp, qm, nil
+}
+
+// Register is used to create a maintenance plan. The created plan is returned
+// with the nodes it selected.
+func (m *Maintenance) Register(plan *MaintenancePlan, q *WriteOptions) (*MaintenancePlan, *WriteMeta, error) {
+	var resp MaintenancePlanUpdateResponse
+	req := &MaintenancePlanRegisterRequest{
+		Plan: plan,
+	}
+	wm, err := m.client.write("/v1/maintenance/plans", req, &resp, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	return resp.Plan, wm, nil
+}
+
+// Cancel is used to cancel an active maintenance plan. The nodes already
+// draining keep draining.
+func (m *Maintenance) Cancel(planID string, q *WriteOptions) (*MaintenancePlan, *WriteMeta, error) {
+	var resp MaintenancePlanUpdateResponse
+	wm, err := m.client.write("/v1/maintenance/plan/"+planID+"/cancel", nil, &resp, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	return resp.Plan, wm, nil
+}
@@ -3,15 +3,17 @@ package api
 import (
 	"context"
 	"fmt"
+	"reflect"
 	"sort"
 	"strconv"
 	"time"
 )
 
 const (
-	NodeStatusInit  = "initializing"
-	NodeStatusReady = "ready"
-	NodeStatusDown  = "down"
+	NodeStatusInit         = "initializing"
+	NodeStatusReady        = "ready"
+	NodeStatusDown         = "down"
+	NodeStatusDisconnected = "disconnected"
 
 	// NodeSchedulingEligible and Ineligible marks the node as eligible or not,
 	// respectively, for receiving allocations. This is orthoginal to the node
@@ -131,11 +133,12 @@ func (n *Nodes) MonitorDrain(ctx context.Context, nodeID string, index uint64, i
 	outCh := make(chan *MonitorMessage, 8)
 	nodeCh := make(chan *MonitorMessage, 1)
 	allocCh := make(chan *MonitorMessage, 8)
+	progressCh := make(chan *MonitorMessage, 8)
 
-	// Multiplex node and alloc chans onto outCh. This goroutine closes
-	// outCh when other chans have been closed.
+	// Multiplex node, alloc and progress chans onto outCh. This goroutine
+	// closes outCh when other chans have been closed.
 	multiplexCtx, cancel := context.WithCancel(ctx)
-	go n.monitorDrainMultiplex(multiplexCtx, cancel, outCh, nodeCh, allocCh)
+	go n.monitorDrainMultiplex(multiplexCtx, cancel, outCh, nodeCh, allocCh, progressCh)
 
 	// Monitor node for updates
 	go n.monitorDrainNode(multiplexCtx, nodeID, index, nodeCh)
@@ -143,24 +146,28 @@ func (n *Nodes) MonitorDrain(ctx context.Context, nodeID string, index uint64, i
 	// Monitor allocs on node for updates
 	go n.monitorDrainAllocs(multiplexCtx, nodeID, ignoreSys, allocCh)
 
+	// Monitor the progress of the drain
+	go n.monitorDrainProgress(multiplexCtx, nodeID, progressCh)
+
 	return outCh
 }
 
-// monitorDrainMultiplex multiplexes node and alloc updates onto the out chan.
-// Closes out chan when either the context is canceled, both update chans are
-// closed, or an error occurs.
+// monitorDrainMultiplex multiplexes node, alloc and progress updates onto the
+// out chan. Closes out chan when either the context is canceled, all update
+// chans are closed, or an error occurs.
 func (n *Nodes) monitorDrainMultiplex(ctx context.Context, cancel func(),
-	outCh chan<- *MonitorMessage, nodeCh, allocCh <-chan *MonitorMessage) {
+	outCh chan<- *MonitorMessage, nodeCh, allocCh, progressCh <-chan *MonitorMessage) {
 
 	defer cancel()
 	defer close(outCh)
 
 	nodeOk := true
 	allocOk := true
+	progressOk := true
 	var msg *MonitorMessage
 	for {
-		// If both chans have been closed, close the output chan
-		if !nodeOk && !allocOk {
+		// If all chans have been closed, close the output chan
+		if !nodeOk && !allocOk && !progressOk {
 			return
 		}
 
@@ -179,6 +186,13 @@ func (n *Nodes) monitorDrainMultiplex(ctx context.Context, cancel func(),
 				continue
 			}
 
+		case msg, progressOk = <-progressCh:
+			if !progressOk {
+				// nil chan to prevent further recvs
+				progressCh = nil
+				continue
+			}
+
 		case <-ctx.Done():
 			return
 		}
@@ -345,6 +359,61 @@ func (n *Nodes) monitorDrainAllocs(ctx context.Context, nodeID string, ignoreSys
 	}
 }
 
+// monitorDrainProgress emits the progress of the drain on progressCh when it
+// changes and closes the channel when the node has finished draining.
+func (n *Nodes) monitorDrainProgress(ctx context.Context, nodeID string, progressCh chan<- *MonitorMessage) {
+	defer close(progressCh)
+
+	var last *DrainProgress
+	q := QueryOptions{AllowStale: true}
+	for {
+		strategy, meta, err := n.DrainStatus(nodeID, &q)
+		if err != nil {
+			// Servers without drain progress reporting fail the request,
+			// so don't abort monitoring the drain
+			msg := Messagef(MonitorMsgLevelWarn, "Error monitoring drain progress: %v", err)
+			select {
+			case progressCh <- msg:
+			case <-ctx.Done():
+			}
+			return
+		}
+
+		// The node monitor reports when the drain is complete
+		if strategy == nil {
+			return
+		}
+
+		q.WaitIndex = meta.LastIndex
+
+		progress := strategy.Progress
+		if progress == nil || reflect.DeepEqual(progress, last) {
+			continue
+		}
+		last = progress
+
+		for _, msg := range progress.messages() {
+			select {
+			case progressCh <- msg:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}
+}
+
+// DrainStatus is used to query the drain strategy of a node along with the
+// progress of the drain. The returned strategy is nil if the node isn't
+// draining.
+func (n *Nodes) DrainStatus(nodeID string, q *QueryOptions) (*DrainStrategy, *QueryMeta, error) {
+	var resp *DrainStrategy
+	qm, err := n.client.query("/v1/node/"+nodeID+"/drain/status", &resp, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	return resp, qm, nil
+}
+
 // NodeUpdateEligibilityRequest is used to update the drain specification for a node.
 type NodeUpdateEligibilityRequest struct {
 	// NodeID is the node to update the drain specification for.
@@ -472,7 +541,8 @@ type NodeResources struct {
 }
 
 type NodeCpuResources struct {
-	CpuShares int64
+	CpuShares          int64
+	ReservableCpuCores []uint16
 }
 
 type NodeMemoryResources struct {
@@ -514,6 +584,10 @@ type DrainStrategy struct {
 	// ForceDeadline is the deadline time for the drain after which drains will
 	// be forced
 	ForceDeadline time.Time
+
+	// Progress is the progress of the drain. It is only set when querying
+	// the drain status of a node.
+	Progress *DrainProgress
 }
 
 // DrainSpec describes a Node's drain behavior.
@@ -525,6 +599,46 @@ type DrainSpec struct {
 	// IgnoreSystemJobs allows systems jobs to remain on the node even though it
 	// has been marked for draining.
 	IgnoreSystemJobs bool
+
+	// OrderByPriority drains the allocations of lower priority jobs before
+	// migrating the allocations of higher priority jobs.
+	OrderByPriority bool
+}
+
+// DrainProgress describes the progress of a node drain
+type DrainProgress struct {
+	// AllocsRemaining is the number of allocations left to drain
+	AllocsRemaining int
+
+	// AllocsMigrating is the number of remaining allocations marked for
+	// migration
+	AllocsMigrating int
+
+	// BlockedJobs are the jobs with remaining allocations that are not yet
+	// marked for migration, ordered by priority
+	BlockedJobs []*DrainBlockedJob
+}
+
+// DrainBlockedJob is a job blocking the progress of a node drain
+type DrainBlockedJob struct {
+	Namespace string
+	JobID     string
+	Priority  int
+	Allocs    int
+	Reason    string
+}
+
+// messages returns the monitor messages describing the drain progress
+func (p *DrainProgress) messages() []*MonitorMessage {
+	msgs := []*MonitorMessage{
+		Messagef(MonitorMsgLevelNormal, "Drain progress: %d allocs remaining, %d migrating",
+			p.AllocsRemaining, p.AllocsMigrating),
+	}
+	for _, job := range p.BlockedJobs {
+		msgs = append(msgs, Messagef(MonitorMsgLevelNormal, "Job %q (priority %d): %d allocs %s",
+			job.JobID, job.Priority, job.Allocs, job.Reason))
+	}
+	return msgs
 }
 
 func (d *DrainStrategy) Equal(o *DrainStrategy) bool {
@@ -541,6 +655,9 @@ func (d *DrainStrategy) Equal(o *DrainStrategy) bool {
 	if d.IgnoreSystemJobs != o.IgnoreSystemJobs {
 		return false
 	}
+	if d.OrderByPriority != o.OrderByPriority {
+		return false
+	}
 
 	return true
 }
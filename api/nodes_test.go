@@ -173,7 +173,8 @@ func TestNodes_ToggleDrain(t *testing.T) {
 
 	// Toggle it on
 	spec := &DrainSpec{
-		Deadline: 10 * time.Second,
+		Deadline:        10 * time.Second,
+		OrderByPriority: true,
 	}
 	drainOut, err := nodes.UpdateDrain(nodeID, spec, false, nil)
 	require.Nil(err)
@@ -185,6 +186,15 @@ func TestNodes_ToggleDrain(t *testing.T) {
 	if out.SchedulingEligibility != NodeSchedulingIneligible {
 		t.Fatalf("bad eligibility: %v vs %v", out.SchedulingEligibility, NodeSchedulingIneligible)
 	}
+	require.True(out.DrainStrategy.OrderByPriority)
+
+	// Check the drain status
+	status, qm, err := nodes.DrainStatus(nodeID, nil)
+	require.Nil(err)
+	assertQueryMeta(t, qm)
+	require.NotNil(status)
+	require.NotNil(status.Progress)
+	require.Zero(status.Progress.AllocsRemaining)
 
 	// Toggle off again
 	drainOut, err = nodes.UpdateDrain(nodeID, nil, true, nil)
@@ -203,6 +213,10 @@ func TestNodes_ToggleDrain(t *testing.T) {
 	if out.SchedulingEligibility != NodeSchedulingEligible {
 		t.Fatalf("should be eligible")
 	}
+
+	status, _, err = nodes.DrainStatus(nodeID, nil)
+	require.Nil(err)
+	require.Nil(status)
 }
 
 func TestNodes_ToggleEligibility(t *testing.T) {
@@ -366,10 +380,11 @@ func TestNodes_MonitorDrain_Multiplex_Bad(t *testing.T) {
 	outCh := make(chan *MonitorMessage, 8)
 	nodeCh := make(chan *MonitorMessage, 1)
 	allocCh := make(chan *MonitorMessage, 8)
+	progressCh := make(chan *MonitorMessage, 8)
 	exitedCh := make(chan struct{})
 	go func() {
 		defer close(exitedCh)
-		nodeClient.monitorDrainMultiplex(ctx, cancel, outCh, nodeCh, allocCh)
+		nodeClient.monitorDrainMultiplex(ctx, cancel, outCh, nodeCh, allocCh, progressCh)
 	}()
 
 	// Fake an alloc update
@@ -418,10 +433,11 @@ func TestNodes_MonitorDrain_Multiplex_Good(t *testing.T) {
 	outCh := make(chan *MonitorMessage, 8)
 	nodeCh := make(chan *MonitorMessage, 1)
 	allocCh := make(chan *MonitorMessage, 8)
+	progressCh := make(chan *MonitorMessage, 8)
 	exitedCh := make(chan struct{})
 	go func() {
 		defer close(exitedCh)
-		nodeClient.monitorDrainMultiplex(ctx, cancel, outCh, nodeCh, allocCh)
+		nodeClient.monitorDrainMultiplex(ctx, cancel, outCh, nodeCh, allocCh, progressCh)
 	}()
 
 	// Fake a node updating and finishing
@@ -430,6 +446,12 @@ func TestNodes_MonitorDrain_Multiplex_Good(t *testing.T) {
 	close(nodeCh)
 	require.Equal(msg, <-outCh)
 
+	// Fake a progress update and the drain progress monitor finishing
+	msg = Messagef(0, "progress update")
+	progressCh <- msg
+	close(progressCh)
+	require.Equal(msg, <-outCh)
+
 	// Nothing else should have exited yet
 	select {
 	case msg, ok := <-outCh:
@@ -504,6 +526,13 @@ func TestNodes_DrainStrategy_Equal(t *testing.T) {
 
 	o.IgnoreSystemJobs = true
 	require.True(d.Equal(o))
+
+	// OrderByPriority
+	d.OrderByPriority = true
+	require.False(d.Equal(o))
+
+	o.OrderByPriority = true
+	require.True(d.Equal(o))
 }
 
 func TestNodeStatValueFormatting(t *testing.T) {
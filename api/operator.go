@@ -176,3 +176,75 @@ func (op *Operator) SchedulerCASConfiguration(conf *SchedulerConfiguration, q *W
 
 	return &out, wm, nil
 }
+
+// SchedulerSimulateRequest describes hypothetical changes to the nodes of the
+// cluster to simulate scheduling against.
+type SchedulerSimulateRequest struct {
+	// AddNodes are the nodes to add to the cluster.
+	AddNodes []*SimulateNodeAdd
+
+	// RemoveNodeIDs are the IDs of the nodes to remove from the cluster.
+	RemoveNodeIDs []string
+
+	// RemoveDatacenters are the datacenters whose nodes are all removed from
+	// the cluster.
+	RemoveDatacenters []string
+
+	// DrainNodeIDs are the IDs of the nodes to drain.
+	DrainNodeIDs []string
+}
+
+// SimulateNodeAdd describes nodes to add to the cluster as copies of an
+// existing node.
+type SimulateNodeAdd struct {
+	CloneNodeID string
+	Count       int
+	Datacenter  string
+	NodeClass   string
+}
+
+// SchedulerSimulateResponse is the result of a scheduling simulation.
+type SchedulerSimulateResponse struct {
+	// Jobs are the results of scheduling each job affected by the changes.
+	Jobs []*SimulateJobResult
+
+	// UtilizationBefore and UtilizationAfter are the utilization of the
+	// schedulable nodes of the cluster before and after the simulation.
+	UtilizationBefore *ClusterUtilization
+	UtilizationAfter  *ClusterUtilization
+}
+
+// SimulateJobResult is the result of scheduling a single job during a
+// simulation.
+type SimulateJobResult struct {
+	Namespace         string
+	JobID             string
+	Placements        int
+	Stops             int
+	FailedTGAllocs    map[string]*AllocationMetric
+	QueuedAllocations map[string]int
+}
+
+// ClusterUtilization is the resource usage of the schedulable nodes of a
+// cluster.
+type ClusterUtilization struct {
+	Nodes             int
+	CPU               int64
+	MemoryMB          int64
+	DiskMB            int64
+	AllocatedCPU      int64
+	AllocatedMemoryMB int64
+	AllocatedDiskMB   int64
+}
+
+// SchedulerSimulate is used to simulate scheduling the jobs of the cluster
+// against hypothetical changes to its nodes. The simulation has no side
+// effects on the cluster.
+func (op *Operator) SchedulerSimulate(req *SchedulerSimulateRequest, q *WriteOptions) (*SchedulerSimulateResponse, *WriteMeta, error) {
+	var resp SchedulerSimulateResponse
+	wm, err := op.c.write("/v1/operator/scheduler/simulate", req, &resp, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &resp, wm, nil
+}
@@ -8,6 +8,7 @@ import (
 // a given task or task group.
 type Resources struct {
 	CPU      *int
+	Cores    *int
 	MemoryMB *int `mapstructure:"memory"`
 	DiskMB   *int `mapstructure:"disk"`
 	Networks []*NetworkResource
@@ -69,6 +70,9 @@ func (r *Resources) Merge(other *Resources) {
 	if other.CPU != nil {
 		r.CPU = other.CPU
 	}
+	if other.Cores != nil {
+		r.Cores = other.Cores
+	}
 	if other.MemoryMB != nil {
 		r.MemoryMB = other.MemoryMB
 	}
@@ -208,7 +208,7 @@ func NewDefaultReschedulePolicy(jobType string) *ReschedulePolicy {
 			Unlimited: boolToPtr(false),
 		}
 
-	case "system":
+	case "system", "sysbatch":
 		dp = &ReschedulePolicy{
 			Attempts:      intToPtr(0),
 			Interval:      timeToPtr(0),
@@ -482,18 +482,19 @@ func (m *MigrateStrategy) Copy() *MigrateStrategy {
 
 // TaskGroup is the unit of scheduling.
 type TaskGroup struct {
-	Name             *string
-	Count            *int
-	Constraints      []*Constraint
-	Affinities       []*Affinity
-	Tasks            []*Task
-	Spreads          []*Spread
-	RestartPolicy    *RestartPolicy
-	ReschedulePolicy *ReschedulePolicy
-	EphemeralDisk    *EphemeralDisk
-	Update           *UpdateStrategy
-	Migrate          *MigrateStrategy
-	Meta             map[string]string
+	Name                *string
+	Count               *int
+	Constraints         []*Constraint
+	Affinities          []*Affinity
+	Tasks               []*Task
+	Spreads             []*Spread
+	RestartPolicy       *RestartPolicy
+	ReschedulePolicy    *ReschedulePolicy
+	EphemeralDisk       *EphemeralDisk
+	Update              *UpdateStrategy
+	Migrate             *MigrateStrategy
+	Meta                map[string]string
+	MaxClientDisconnect *time.Duration `mapstructure:"max_client_disconnect"`
 }
 
 // NewTaskGroup creates a new TaskGroup.
@@ -546,7 +547,7 @@ func (g *TaskGroup) Canonicalize(job *Job) {
 		g.ReschedulePolicy = jobReschedule
 	}
 	// Only use default reschedule policy for non system jobs
-	if g.ReschedulePolicy == nil && *job.Type != "system" {
+	if g.ReschedulePolicy == nil && *job.Type != "system" && *job.Type != "sysbatch" {
 		g.ReschedulePolicy = NewDefaultReschedulePolicy(*job.Type)
 	}
 	if g.ReschedulePolicy != nil {
@@ -814,12 +815,20 @@ func (tmpl *Template) Canonicalize() {
 
 type Vault struct {
 	Policies     []string
+	Cluster      *string
+	Namespace    *string
 	Env          *bool
 	ChangeMode   *string `mapstructure:"change_mode"`
 	ChangeSignal *string `mapstructure:"change_signal"`
 }
 
 func (v *Vault) Canonicalize() {
+	if v.Cluster == nil {
+		v.Cluster = stringToPtr("default")
+	}
+	if v.Namespace == nil {
+		v.Namespace = stringToPtr("")
+	}
 	if v.Env == nil {
 		v.Env = boolToPtr(true)
 	}
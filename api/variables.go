@@ -0,0 +1,241 @@
+package api
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// ErrVariableNotFound is returned when reading a variable that doesn't exist
+var ErrVariableNotFound = fmt.Errorf("variable not found")
+
+// ErrCASConflict is returned when the check-and-set of a variable update or
+// delete fails. Conflict is the current variable, which only has its
+// metadata set if the token can't read it.
+type ErrCASConflict struct {
+	CheckIndex uint64
+	Conflict   *Variable
+}
+
+func (e ErrCASConflict) Error() string {
+	return fmt.Sprintf("cas conflict: expected ModifyIndex %d; found %d", e.CheckIndex, e.Conflict.ModifyIndex)
+}
+
+// Variables is used to query the variable endpoints.
+type Variables struct {
+	client *Client
+}
+
+// Variables returns a new handle on the variables.
+func (c *Client) Variables() *Variables {
+	return &Variables{client: c}
+}
+
+// List is used to dump the metadata of all the variables of the namespace.
+func (v *Variables) List(q *QueryOptions) ([]*VariableMetadata, *QueryMeta, error) {
+	var resp []*VariableMetadata
+	qm, err := v.client.query("/v1/vars", &resp, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	return resp, qm, nil
+}
+
+// PrefixList is used to list the metadata of the variables whose path
+// starts with the prefix.
+func (v *Variables) PrefixList(prefix string, q *QueryOptions) ([]*VariableMetadata, *QueryMeta, error) {
+	var opts QueryOptions
+	if q != nil {
+		opts = *q
+	}
+	opts.Prefix = prefix
+	return v.List(&opts)
+}
+
+// Read is used to query a variable. ErrVariableNotFound is returned if it
+// doesn't exist.
+func (v *Variables) Read(path string, q *QueryOptions) (*Variable, *QueryMeta, error) {
+	out, qm, err := v.Peek(path, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	if out == nil {
+		return nil, qm, ErrVariableNotFound
+	}
+	return out, qm, nil
+}
+
+// Peek is used to query a variable. Unlike Read, a nil variable is returned
+// if it doesn't exist.
+func (v *Variables) Peek(path string, q *QueryOptions) (*Variable, *QueryMeta, error) {
+	if path == "" {
+		return nil, nil, fmt.Errorf("missing variable path")
+	}
+
+	r, err := v.client.newRequest("GET", "/v1/var/"+path)
+	if err != nil {
+		return nil, nil, err
+	}
+	r.setQueryOptions(q)
+	rtt, resp, err := v.client.doRequest(r)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer resp.Body.Close()
+
+	qm := &QueryMeta{}
+	parseQueryMeta(resp, qm)
+	qm.RequestTime = rtt
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return nil, qm, nil
+	default:
+		return nil, nil, unexpectedResponse(resp)
+	}
+
+	var out Variable
+	if err := decodeBody(resp, &out); err != nil {
+		return nil, nil, err
+	}
+	return &out, qm, nil
+}
+
+// Update is used to create or update a variable.
+func (v *Variables) Update(variable *Variable, q *WriteOptions) (*Variable, *WriteMeta, error) {
+	if variable == nil || variable.Path == "" {
+		return nil, nil, fmt.Errorf("missing variable path")
+	}
+	return v.apply("PUT", "/v1/var/"+variable.Path, variable, 0, q)
+}
+
+// CheckedUpdate is used to update a variable only if its modify index is the
+// one of the given variable. A zero modify index only creates the variable.
+// ErrCASConflict is returned if the check fails.
+func (v *Variables) CheckedUpdate(variable *Variable, q *WriteOptions) (*Variable, *WriteMeta, error) {
+	if variable == nil || variable.Path == "" {
+		return nil, nil, fmt.Errorf("missing variable path")
+	}
+	endpoint := fmt.Sprintf("/v1/var/%s?cas=%d", variable.Path, variable.ModifyIndex)
+	return v.apply("PUT", endpoint, variable, variable.ModifyIndex, q)
+}
+
+// Delete is used to delete a variable.
+func (v *Variables) Delete(path string, q *WriteOptions) (*WriteMeta, error) {
+	if path == "" {
+		return nil, fmt.Errorf("missing variable path")
+	}
+	_, wm, err := v.apply("DELETE", "/v1/var/"+path, nil, 0, q)
+	return wm, err
+}
+
+// CheckedDelete is used to delete a variable only if its modify index is
+// checkIndex. ErrCASConflict is returned if the check fails.
+func (v *Variables) CheckedDelete(path string, checkIndex uint64, q *WriteOptions) (*WriteMeta, error) {
+	if path == "" {
+		return nil, fmt.Errorf("missing variable path")
+	}
+	endpoint := fmt.Sprintf("/v1/var/%s?cas=%d", path, checkIndex)
+	_, wm, err := v.apply("DELETE", endpoint, nil, checkIndex, q)
+	return wm, err
+}
+
+// apply writes or deletes a variable, decoding the current variable of a
+// check-and-set conflict into an ErrCASConflict.
+func (v *Variables) apply(method, endpoint string, in *Variable, checkIndex uint64, q *WriteOptions) (*Variable, *WriteMeta, error) {
+	r, err := v.client.newRequest(method, endpoint)
+	if err != nil {
+		return nil, nil, err
+	}
+	r.setWriteOptions(q)
+	if in != nil {
+		if in.Namespace != "" && (q == nil || q.Namespace == "") {
+			r.params.Set("namespace", in.Namespace)
+		}
+		r.obj = in
+	}
+	rtt, resp, err := v.client.doRequest(r)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusConflict:
+		var conflict Variable
+		if err := decodeBody(resp, &conflict); err != nil {
+			return nil, nil, err
+		}
+		return nil, nil, ErrCASConflict{CheckIndex: checkIndex, Conflict: &conflict}
+	default:
+		return nil, nil, unexpectedResponse(resp)
+	}
+
+	wm := &WriteMeta{RequestTime: rtt}
+	parseWriteMeta(resp, wm)
+
+	if method == "DELETE" {
+		return nil, wm, nil
+	}
+	var out Variable
+	if err := decodeBody(resp, &out); err != nil {
+		return nil, nil, err
+	}
+	return &out, wm, nil
+}
+
+// unexpectedResponse returns the error of a response with an unexpected
+// status code, formatted like the ones of requireOK.
+func unexpectedResponse(resp *http.Response) error {
+	var buf bytes.Buffer
+	io.Copy(&buf, resp.Body)
+	return fmt.Errorf("Unexpected response code: %d (%s)", resp.StatusCode, buf.Bytes())
+}
+
+// VariableMetadata is the metadata of a variable.
+type VariableMetadata struct {
+	Namespace   string
+	Path        string
+	CreateIndex uint64
+	CreateTime  int64
+	ModifyIndex uint64
+	ModifyTime  int64
+}
+
+// VariableItems are the key/value pairs of a variable.
+type VariableItems map[string]string
+
+// Variable is a variable and its decrypted items.
+type Variable struct {
+	Namespace   string
+	Path        string
+	CreateIndex uint64
+	CreateTime  int64
+	ModifyIndex uint64
+	ModifyTime  int64
+
+	Items VariableItems
+}
+
+// NewVariable returns a new variable at the path.
+func NewVariable(path string) *Variable {
+	return &Variable{
+		Path:  path,
+		Items: make(VariableItems),
+	}
+}
+
+// Metadata returns the metadata of the variable.
+func (v *Variable) Metadata() *VariableMetadata {
+	return &VariableMetadata{
+		Namespace:   v.Namespace,
+		Path:        v.Path,
+		CreateIndex: v.CreateIndex,
+		CreateTime:  v.CreateTime,
+		ModifyIndex: v.ModifyIndex,
+		ModifyTime:  v.ModifyTime,
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/hashicorp/nomad/nomad/structs"
 	"github.com/hashicorp/nomad/testutil"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestClient_ACL_resolveTokenValue(t *testing.T) {
@@ -165,3 +166,63 @@ func TestClient_ACL_ResolveToken(t *testing.T) {
 	assert.Equal(t, structs.ErrTokenNotFound, err)
 	assert.Nil(t, out4)
 }
+
+func TestClient_ACL_ResolveToken_Roles(t *testing.T) {
+	s1, _, _ := testACLServer(t, nil)
+	defer s1.Shutdown()
+	testutil.WaitForLeader(t, s1.RPC)
+
+	c1, cleanup := TestClient(t, func(c *config.Config) {
+		c.RPCHandler = s1
+		c.ACLEnabled = true
+	})
+	defer cleanup()
+
+	// Create a token granted a policy through a role
+	policy := mock.ACLPolicy()
+	policy.Rules = mock.NodePolicy(acl.PolicyWrite)
+	role := mock.ACLRole()
+	role.Policies = []string{policy.Name}
+	token := mock.ACLToken()
+	token.Policies = nil
+	token.Roles = []string{role.Name}
+	require.NoError(t, s1.State().UpsertACLPolicies(100, []*structs.ACLPolicy{policy}))
+	require.NoError(t, s1.State().UpsertACLRoles(110, []*structs.ACLRole{role}))
+	require.NoError(t, s1.State().UpsertACLTokens(120, []*structs.ACLToken{token}))
+
+	// Test the client resolution
+	out, err := c1.ResolveToken(token.SecretID)
+	require.NoError(t, err)
+	require.True(t, out.AllowNodeWrite())
+
+	// The role is cached
+	raw, ok := c1.roleCache.Get(role.Name)
+	require.True(t, ok)
+	require.Equal(t, role, raw.(*cachedACLValue).Role)
+}
+
+func TestClient_ACL_ResolveSecretToken(t *testing.T) {
+	s1, _, _ := testACLServer(t, nil)
+	defer s1.Shutdown()
+	testutil.WaitForLeader(t, s1.RPC)
+
+	c1, cleanup := TestClient(t, func(c *config.Config) {
+		c.RPCHandler = s1
+		c.ACLEnabled = true
+	})
+	defer cleanup()
+
+	token := mock.ACLToken()
+	err := s1.State().UpsertACLTokens(110, []*structs.ACLToken{token})
+	assert.Nil(t, err)
+
+	out, err := c1.ResolveSecretToken(token.SecretID)
+	assert.Nil(t, err)
+	if assert.NotNil(t, out) {
+		assert.Equal(t, token.AccessorID, out.AccessorID)
+	}
+
+	out, err = c1.ResolveSecretToken(uuid.Generate())
+	assert.Equal(t, structs.ErrTokenNotFound, err)
+	assert.Nil(t, out)
+}
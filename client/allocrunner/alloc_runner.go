@@ -18,6 +18,7 @@ import (
 	"github.com/hashicorp/nomad/client/consul"
 	"github.com/hashicorp/nomad/client/devicemanager"
 	cinterfaces "github.com/hashicorp/nomad/client/interfaces"
+	"github.com/hashicorp/nomad/client/lib/cgutil"
 	"github.com/hashicorp/nomad/client/pluginmanager/drivermanager"
 	cstate "github.com/hashicorp/nomad/client/state"
 	cstructs "github.com/hashicorp/nomad/client/structs"
@@ -61,8 +62,9 @@ type allocRunner struct {
 	// registering services and checks
 	consulClient consul.ConsulServiceAPI
 
-	// vaultClient is the used to manage Vault tokens
-	vaultClient vaultclient.VaultClient
+	// vaultFunc returns the clients used to manage the Vault tokens of the
+	// Vault clusters
+	vaultFunc vaultclient.VaultClientFunc
 
 	// waitCh is closed when the Run loop has exited
 	waitCh chan struct{}
@@ -119,6 +121,13 @@ type allocRunner struct {
 	// deviceStatsReporter is used to lookup resource usage for alloc devices
 	deviceStatsReporter cinterfaces.DeviceStatsReporter
 
+	// coresReporter is used to lookup the cpu cores reserved on the client
+	coresReporter cinterfaces.ReservedCoresReporter
+
+	// cpusetManager is used to keep task cpusets in line with the cpu cores
+	// reserved on the client
+	cpusetManager cgutil.CpusetManager
+
 	// allocBroadcaster sends client allocation updates to all listeners
 	allocBroadcaster *cstructs.AllocBroadcaster
 
@@ -141,6 +150,9 @@ type allocRunner struct {
 	// servers have been contacted for the first time in case of a failed
 	// restore.
 	serversContactedCh chan struct{}
+
+	// rpcClient is passed to TaskRunners to make RPC calls to the servers
+	rpcClient cinterfaces.RPCer
 }
 
 // NewAllocRunner returns a new allocation runner.
@@ -156,7 +168,7 @@ func NewAllocRunner(config *Config) (*allocRunner, error) {
 		alloc:                    alloc,
 		clientConfig:             config.ClientConfig,
 		consulClient:             config.Consul,
-		vaultClient:              config.Vault,
+		vaultFunc:                config.VaultFunc,
 		tasks:                    make(map[string]*taskrunner.TaskRunner, len(tg.Tasks)),
 		waitCh:                   make(chan struct{}),
 		destroyCh:                make(chan struct{}),
@@ -168,11 +180,14 @@ func NewAllocRunner(config *Config) (*allocRunner, error) {
 		taskStateUpdateHandlerCh: make(chan struct{}),
 		allocUpdatedCh:           make(chan *structs.Allocation, 1),
 		deviceStatsReporter:      config.DeviceStatsReporter,
+		coresReporter:            config.CoresReporter,
+		cpusetManager:            config.CpusetManager,
 		prevAllocWatcher:         config.PrevAllocWatcher,
 		prevAllocMigrator:        config.PrevAllocMigrator,
 		devicemanager:            config.DeviceManager,
 		driverManager:            config.DriverManager,
 		serversContactedCh:       config.ServersContactedCh,
+		rpcClient:                config.RPCClient,
 	}
 
 	// Create the logger based on the allocation ID
@@ -198,6 +213,21 @@ func NewAllocRunner(config *Config) (*allocRunner, error) {
 // initTaskRunners creates task runners but does *not* run them.
 func (ar *allocRunner) initTaskRunners(tasks []*structs.Task) error {
 	for _, task := range tasks {
+		// Get the client of the Vault cluster of the task
+		vaultConfig := ar.clientConfig.VaultConfigFor(task.Vault)
+		var vaultClient vaultclient.VaultClient
+		if task.Vault != nil {
+			if vaultConfig == nil {
+				return fmt.Errorf("Vault cluster %q of task %q is not configured", task.Vault.GetCluster(), task.Name)
+			}
+
+			var err error
+			vaultClient, err = ar.vaultFunc(vaultConfig)
+			if err != nil {
+				return fmt.Errorf("failed creating Vault client for task %q: %v", task.Name, err)
+			}
+		}
+
 		config := &taskrunner.Config{
 			Alloc:               ar.alloc,
 			ClientConfig:        ar.clientConfig,
@@ -207,11 +237,15 @@ func (ar *allocRunner) initTaskRunners(tasks []*structs.Task) error {
 			StateDB:             ar.stateDB,
 			StateUpdater:        ar,
 			Consul:              ar.consulClient,
-			Vault:               ar.vaultClient,
+			Vault:               vaultClient,
+			VaultConfig:         vaultConfig,
 			DeviceStatsReporter: ar.deviceStatsReporter,
+			CoresReporter:       ar.coresReporter,
+			CpusetManager:       ar.cpusetManager,
 			DeviceManager:       ar.devicemanager,
 			DriverManager:       ar.driverManager,
 			ServersContactedCh:  ar.serversContactedCh,
+			RPCClient:           ar.rpcClient,
 		}
 
 		// Create, but do not Run, the task runner
@@ -726,6 +760,11 @@ func (ar *allocRunner) handleAllocUpdate(update *structs.Allocation) {
 		ar.killTasks()
 	}
 
+	// The server marked the alloc unknown while the client was disconnected,
+	// so resend its state to let the server reconcile it
+	if update.ClientStatus == structs.AllocClientStatusUnknown {
+		ar.TaskStateUpdated()
+	}
 }
 
 func (ar *allocRunner) Listener() *cstructs.AllocListener {
@@ -7,6 +7,7 @@ import (
 	"github.com/hashicorp/nomad/client/consul"
 	"github.com/hashicorp/nomad/client/devicemanager"
 	"github.com/hashicorp/nomad/client/interfaces"
+	"github.com/hashicorp/nomad/client/lib/cgutil"
 	"github.com/hashicorp/nomad/client/pluginmanager/drivermanager"
 	cstate "github.com/hashicorp/nomad/client/state"
 	"github.com/hashicorp/nomad/client/vaultclient"
@@ -30,8 +31,9 @@ type Config struct {
 	// Consul is the Consul client used to register task services and checks
 	Consul consul.ConsulServiceAPI
 
-	// Vault is the Vault client to use to retrieve Vault tokens
-	Vault vaultclient.VaultClient
+	// VaultFunc returns the Vault client to use to retrieve the Vault tokens
+	// of a Vault cluster
+	VaultFunc vaultclient.VaultClientFunc
 
 	// StateUpdater is used to emit updated task state
 	StateUpdater interfaces.AllocStateHandler
@@ -39,6 +41,13 @@ type Config struct {
 	// DeviceStatsReporter is used to lookup resource usage for alloc devices
 	DeviceStatsReporter interfaces.DeviceStatsReporter
 
+	// CoresReporter is used to lookup the cpu cores reserved on the client
+	CoresReporter interfaces.ReservedCoresReporter
+
+	// CpusetManager is used to keep task cpusets in line with the cpu cores
+	// reserved on the client
+	CpusetManager cgutil.CpusetManager
+
 	// PrevAllocWatcher handles waiting on previous or preempted allocations
 	PrevAllocWatcher allocwatcher.PrevAllocWatcher
 
@@ -55,4 +64,8 @@ type Config struct {
 	// ServersContactedCh is closed when the first GetClientAllocs call to
 	// servers succeeds and allocs are synced.
 	ServersContactedCh chan struct{}
+
+	// RPCClient is used to make RPC calls to the servers on behalf of the
+	// tasks, such as reading variables in templates
+	RPCClient interfaces.RPCer
 }
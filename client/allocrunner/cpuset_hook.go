@@ -0,0 +1,47 @@
+package allocrunner
+
+import (
+	log "github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/nomad/client/lib/cgutil"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// cpusetHook registers the cores reserved by an allocation with the cpuset
+// manager while it runs, moving the other running tasks off of them, and
+// releases them once the allocation stops.
+type cpusetHook struct {
+	alloc   *structs.Allocation
+	manager cgutil.CpusetManager
+	logger  log.Logger
+}
+
+func newCpusetHook(logger log.Logger, alloc *structs.Allocation, manager cgutil.CpusetManager) *cpusetHook {
+	if manager == nil {
+		manager = cgutil.NoopCpusetManager()
+	}
+	h := &cpusetHook{
+		alloc:   alloc,
+		manager: manager,
+	}
+	h.logger = logger.Named(h.Name())
+	return h
+}
+
+func (h *cpusetHook) Name() string {
+	return "cpuset"
+}
+
+func (h *cpusetHook) Prerun() error {
+	h.manager.AddAlloc(h.alloc)
+	return nil
+}
+
+func (h *cpusetHook) Postrun() error {
+	h.manager.RemoveAlloc(h.alloc.ID)
+	return nil
+}
+
+func (h *cpusetHook) Destroy() error {
+	h.manager.RemoveAlloc(h.alloc.ID)
+	return nil
+}
@@ -70,9 +70,9 @@ type allocHealthWatcherHook struct {
 func newAllocHealthWatcherHook(logger log.Logger, alloc *structs.Allocation, hs healthSetter,
 	listener *cstructs.AllocListener, consul consul.ConsulServiceAPI) interfaces.RunnerHook {
 
-	// Neither deployments nor migrations care about the health of
-	// non-service jobs so never watch their health
-	if alloc.Job.Type != structs.JobTypeService {
+	// Neither deployments nor migrations care about the health of batch
+	// jobs so never watch their health
+	if alloc.Job.Type != structs.JobTypeService && alloc.Job.Type != structs.JobTypeSystem {
 		return noopAllocHealthWatcherHook{}
 	}
 
@@ -115,6 +115,12 @@ func (h *allocHealthWatcherHook) init() error {
 
 	h.isDeploy = h.alloc.DeploymentID != ""
 
+	// System jobs are not migrated so their health only matters as part of
+	// a deployment
+	if !h.isDeploy && h.alloc.Job.Type == structs.JobTypeSystem {
+		return nil
+	}
+
 	// No need to watch allocs for deployments that rely on operators
 	// manually setting health
 	if h.isDeploy && (tg.Update == nil || tg.Update.HealthCheck == structs.UpdateStrategyHealthCheck_Manual) {
@@ -300,25 +300,43 @@ func TestHealthHook_SetHealth(t *testing.T) {
 	require.NoError(h.Postrun())
 }
 
-// TestHealthHook_SystemNoop asserts that system jobs return the noop tracker.
-func TestHealthHook_SystemNoop(t *testing.T) {
+// TestHealthHook_System asserts that system jobs only watch health as part of
+// a deployment.
+func TestHealthHook_System(t *testing.T) {
 	t.Parallel()
+	require := require.New(t)
 
-	h := newAllocHealthWatcherHook(testlog.HCLogger(t), mock.SystemAlloc(), nil, nil, nil)
+	logger := testlog.HCLogger(t)
 
-	// Assert that it's the noop impl
-	_, ok := h.(noopAllocHealthWatcherHook)
-	require.True(t, ok)
+	b := cstructs.NewAllocBroadcaster(logger)
+	defer b.Close()
 
-	// Assert the noop impl does not implement any hooks
-	_, ok = h.(interfaces.RunnerPrerunHook)
-	require.False(t, ok)
-	_, ok = h.(interfaces.RunnerUpdateHook)
-	require.False(t, ok)
-	_, ok = h.(interfaces.RunnerPostrunHook)
-	require.False(t, ok)
-	_, ok = h.(interfaces.ShutdownHook)
-	require.False(t, ok)
+	consul := consul.NewMockConsulServiceClient(t, logger)
+	hs := &mockHealthSetter{}
+
+	alloc := mock.SystemAlloc()
+	h := newAllocHealthWatcherHook(logger, alloc, hs, b.Listen(), consul)
+
+	// Assert it's not the noop impl
+	ahw, ok := h.(*allocHealthWatcherHook)
+	require.True(ok)
+
+	// Prerun without a deployment does not watch health
+	require.NoError(ahw.Prerun())
+	ahw.hookLock.Lock()
+	require.False(ahw.isDeploy)
+	ahw.hookLock.Unlock()
+
+	// Updating the alloc to be part of a deployment starts watching
+	alloc = alloc.Copy()
+	alloc.DeploymentID = uuid.Generate()
+	alloc.Job.TaskGroups[0].Update = structs.DefaultUpdateStrategy.Copy()
+	require.NoError(ahw.Update(&interfaces.RunnerUpdateRequest{Alloc: alloc}))
+	ahw.hookLock.Lock()
+	require.True(ahw.isDeploy)
+	ahw.hookLock.Unlock()
+
+	require.NoError(ahw.Postrun())
 }
 
 // TestHealthHook_BatchNoop asserts that batch jobs return the noop tracker.
@@ -2,6 +2,9 @@ package taskrunner
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
 	"io/ioutil"
 	"os"
 	"path/filepath"
@@ -9,19 +12,44 @@ import (
 	"github.com/golang/snappy"
 	hclog "github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/nomad/client/allocrunner/interfaces"
+	cinterfaces "github.com/hashicorp/nomad/client/interfaces"
 	"github.com/hashicorp/nomad/nomad/structs"
 )
 
-// dispatchHook writes a dispatch payload to the task dir
+// dispatchHook writes a dispatch payload to the task dir. Payloads too large
+// to be stored on the job are fetched from the servers.
 type dispatchHook struct {
+	alloc   *structs.Allocation
 	payload []byte
 
+	// identity returns the current workload identity of the task, which is
+	// used to fetch the payload blob
+	identity func() string
+
+	// nodeSecret is the secret ID of the node, which is used to fetch the
+	// payload blob if the task has no workload identity
+	nodeSecret string
+
+	// payloadBlob is the ID of the blob holding the payload, if any
+	payloadBlob string
+
+	// rpcClient is used to fetch the payload blob from the servers
+	rpcClient cinterfaces.RPCer
+	region    string
+
 	logger hclog.Logger
 }
 
-func newDispatchHook(alloc *structs.Allocation, logger hclog.Logger) *dispatchHook {
+func newDispatchHook(alloc *structs.Allocation, identity func() string, nodeSecret string,
+	rpcClient cinterfaces.RPCer, region string, logger hclog.Logger) *dispatchHook {
 	h := &dispatchHook{
-		payload: alloc.Job.Payload,
+		alloc:       alloc,
+		identity:    identity,
+		nodeSecret:  nodeSecret,
+		payload:     alloc.Job.Payload,
+		payloadBlob: alloc.Job.PayloadBlob,
+		rpcClient:   rpcClient,
+		region:      region,
 	}
 	h.logger = logger.Named(h.Name())
 	return h
@@ -34,13 +62,24 @@ func (*dispatchHook) Name() string {
 }
 
 func (h *dispatchHook) Prestart(ctx context.Context, req *interfaces.TaskPrestartRequest, resp *interfaces.TaskPrestartResponse) error {
-	if len(h.payload) == 0 || req.Task.DispatchPayload == nil || req.Task.DispatchPayload.File == "" {
+	if (len(h.payload) == 0 && h.payloadBlob == "") || req.Task.DispatchPayload == nil || req.Task.DispatchPayload.File == "" {
 		// No dispatch payload
 		resp.Done = true
 		return nil
 	}
 
-	err := writeDispatchPayload(req.TaskDir.LocalDir, req.Task.DispatchPayload.File, h.payload)
+	var payload []byte
+	var err error
+	if h.payloadBlob != "" {
+		payload, err = h.fetchPayload()
+	} else {
+		payload, err = snappy.Decode(nil, h.payload)
+	}
+	if err != nil {
+		return err
+	}
+
+	err = writeDispatchPayload(req.TaskDir.LocalDir, req.Task.DispatchPayload.File, payload)
 	if err != nil {
 		return err
 	}
@@ -48,7 +87,7 @@ func (h *dispatchHook) Prestart(ctx context.Context, req *interfaces.TaskPrestar
 	h.logger.Trace("dispatch payload written",
 		"path", req.TaskDir.LocalDir,
 		"filename", req.Task.DispatchPayload.File,
-		"bytes", len(h.payload),
+		"bytes", len(payload),
 	)
 
 	// Dispatch payload written successfully; mark as done
@@ -56,18 +95,63 @@ func (h *dispatchHook) Prestart(ctx context.Context, req *interfaces.TaskPrestar
 	return nil
 }
 
-// writeDispatchPayload writes the payload to the given file or returns an
-// error.
-func writeDispatchPayload(base, filename string, payload []byte) error {
-	renderTo := filepath.Join(base, filename)
-	decoded, err := snappy.Decode(nil, payload)
-	if err != nil {
-		return err
+// fetchPayload fetches the chunks of the payload blob from the servers with
+// the workload identity of the task and returns the uncompressed payload. The
+// secret ID of the node is used if the task has no workload identity, such as
+// when the servers have not initialized the keyring yet.
+func (h *dispatchHook) fetchPayload() ([]byte, error) {
+	if h.rpcClient == nil {
+		return nil, fmt.Errorf("dispatch payload blob %q can not be fetched without servers", h.payloadBlob)
+	}
+
+	token := ""
+	if h.identity != nil {
+		token = h.identity()
+	}
+	if token == "" {
+		token = h.nodeSecret
+	}
+
+	var payload []byte
+	for i, chunks := uint(0), uint(1); i < chunks; i++ {
+		args := structs.DispatchPayloadReadRequest{
+			JobID:   h.alloc.JobID,
+			Chunk:   i,
+			AllocID: h.alloc.ID,
+			QueryOptions: structs.QueryOptions{
+				Region:    h.region,
+				Namespace: h.alloc.Namespace,
+				AuthToken: token,
+			},
+		}
+		var resp structs.DispatchPayloadReadResponse
+		if err := h.rpcClient.RPC("DispatchPayload.Read", &args, &resp); err != nil {
+			return nil, fmt.Errorf("failed to fetch dispatch payload: %v", err)
+		}
+
+		data, err := snappy.Decode(nil, resp.Data)
+		if err != nil {
+			return nil, err
+		}
+		payload = append(payload, data...)
+		chunks = uint(resp.Blob.Chunks)
+	}
+
+	// Verify the payload against its content address
+	sum := sha256.Sum256(payload)
+	if id := hex.EncodeToString(sum[:]); id != h.payloadBlob {
+		return nil, fmt.Errorf("dispatch payload blob %q does not match its checksum %q", h.payloadBlob, id)
 	}
+	return payload, nil
+}
 
+// writeDispatchPayload writes the uncompressed payload to the given file or
+// returns an error.
+func writeDispatchPayload(base, filename string, payload []byte) error {
+	renderTo := filepath.Join(base, filename)
 	if err := os.MkdirAll(filepath.Dir(renderTo), 0777); err != nil {
 		return err
 	}
 
-	return ioutil.WriteFile(renderTo, decoded, 0777)
+	return ioutil.WriteFile(renderTo, payload, 0777)
 }
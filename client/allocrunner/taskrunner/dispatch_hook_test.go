@@ -2,7 +2,9 @@ package taskrunner
 
 import (
 	"context"
+	"fmt"
 	"io/ioutil"
+	"os"
 	"path/filepath"
 	"testing"
 
@@ -35,7 +37,7 @@ func TestTaskRunner_DispatchHook_NoPayload(t *testing.T) {
 	taskDir := allocDir.NewTaskDir(task.Name)
 	require.NoError(taskDir.Build(false, nil))
 
-	h := newDispatchHook(alloc, logger)
+	h := newDispatchHook(alloc, nil, "", nil, "global", logger)
 
 	req := interfaces.TaskPrestartRequest{
 		Task:    task,
@@ -80,7 +82,7 @@ func TestTaskRunner_DispatchHook_Ok(t *testing.T) {
 	taskDir := allocDir.NewTaskDir(task.Name)
 	require.NoError(taskDir.Build(false, nil))
 
-	h := newDispatchHook(alloc, logger)
+	h := newDispatchHook(alloc, nil, "", nil, "global", logger)
 
 	req := interfaces.TaskPrestartRequest{
 		Task:    task,
@@ -124,7 +126,7 @@ func TestTaskRunner_DispatchHook_Error(t *testing.T) {
 	taskDir := allocDir.NewTaskDir(task.Name)
 	require.NoError(taskDir.Build(false, nil))
 
-	h := newDispatchHook(alloc, logger)
+	h := newDispatchHook(alloc, nil, "", nil, "global", logger)
 
 	req := interfaces.TaskPrestartRequest{
 		Task:    task,
@@ -141,3 +143,102 @@ func TestTaskRunner_DispatchHook_Error(t *testing.T) {
 	require.NoError(err)
 	require.Empty(files)
 }
+
+// mockDispatchPayloadRPC is a mock of the dispatch payload RPC endpoint
+// serving the chunks of a blob
+type mockDispatchPayloadRPC struct {
+	Blob   *structs.DispatchBlob
+	Chunks []*structs.DispatchBlobChunk
+
+	// Token is the auth token expected in the requests
+	Token string
+}
+
+func (m *mockDispatchPayloadRPC) RPC(method string, args interface{}, reply interface{}) error {
+	if method != "DispatchPayload.Read" {
+		return fmt.Errorf("unexpected RPC %q", method)
+	}
+	req := args.(*structs.DispatchPayloadReadRequest)
+	if req.AuthToken != m.Token || req.AllocID == "" {
+		return structs.ErrPermissionDenied
+	}
+	if int(req.Chunk) >= len(m.Chunks) {
+		return fmt.Errorf("unknown chunk %d", req.Chunk)
+	}
+	resp := reply.(*structs.DispatchPayloadReadResponse)
+	resp.Blob = m.Blob
+	resp.Data = m.Chunks[req.Chunk].Data
+	return nil
+}
+
+// TestTaskRunner_DispatchHook_Blob asserts that dispatch payloads stored in
+// blobs are fetched from the servers and written to a file in the task dir.
+func TestTaskRunner_DispatchHook_Blob(t *testing.T) {
+	t.Parallel()
+
+	require := require.New(t)
+	ctx := context.Background()
+	logger := testlog.HCLogger(t)
+	allocDir := allocdir.NewAllocDir(logger, "nomadtest_dispatchblob")
+	defer allocDir.Destroy()
+
+	// Default mock alloc/job is not a dispatch job; update it
+	alloc := mock.BatchAlloc()
+	alloc.Job.ParameterizedJob = &structs.ParameterizedJobConfig{
+		Payload: structs.DispatchPayloadRequired,
+	}
+	expected := make([]byte, structs.DispatchBlobChunkSize+100)
+	for i := range expected {
+		expected[i] = byte(i)
+	}
+	blob, chunks := structs.NewDispatchBlob(expected)
+	alloc.Job.PayloadBlob = blob.ID
+
+	// Set the filename and create the task dir
+	task := alloc.Job.TaskGroups[0].Tasks[0]
+	task.DispatchPayload = &structs.DispatchPayloadConfig{
+		File: "out",
+	}
+	taskDir := allocDir.NewTaskDir(task.Name)
+	require.NoError(taskDir.Build(false, nil))
+
+	rpc := &mockDispatchPayloadRPC{
+		Blob:   blob,
+		Chunks: chunks,
+		Token:  "identity",
+	}
+	h := newDispatchHook(alloc, func() string { return "identity" }, "", rpc, "global", logger)
+
+	req := interfaces.TaskPrestartRequest{
+		Task:    task,
+		TaskDir: taskDir,
+	}
+	resp := interfaces.TaskPrestartResponse{}
+	require.NoError(h.Prestart(ctx, &req, &resp))
+	require.True(resp.Done)
+
+	filename := filepath.Join(req.TaskDir.LocalDir, task.DispatchPayload.File)
+	result, err := ioutil.ReadFile(filename)
+	require.NoError(err)
+	require.Equal(expected, result)
+
+	// The node secret ID is used without a workload identity
+	rpc.Token = "node-secret"
+	h = newDispatchHook(alloc, func() string { return "" }, "node-secret", rpc, "global", logger)
+	require.NoError(os.Remove(filename))
+	resp = interfaces.TaskPrestartResponse{}
+	require.NoError(h.Prestart(ctx, &req, &resp))
+	require.True(resp.Done)
+
+	result, err = ioutil.ReadFile(filename)
+	require.NoError(err)
+	require.Equal(expected, result)
+
+	// A payload not matching its content address is rejected
+	rpc.Chunks = rpc.Chunks[:1]
+	rpc.Blob = blob.Copy()
+	rpc.Blob.Chunks = 1
+	resp = interfaces.TaskPrestartResponse{}
+	require.Error(h.Prestart(ctx, &req, &resp))
+	require.False(resp.Done)
+}
@@ -0,0 +1,228 @@
+package taskrunner
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"time"
+
+	hclog "github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/nomad/client/allocrunner/interfaces"
+	cinterfaces "github.com/hashicorp/nomad/client/interfaces"
+	"github.com/hashicorp/nomad/lib/auth/jwt"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+const (
+	// identityTokenFile is the name of the file holding the workload identity
+	// inside the task's secrets directory
+	identityTokenFile = "nomad_token"
+
+	// identityRenewBaseBackoff and identityRenewMaxBackoff bound the backoff
+	// between failed attempts to renew the workload identity
+	identityRenewBaseBackoff = 5 * time.Second
+	identityRenewMaxBackoff  = 1 * time.Minute
+)
+
+// identityUpdater is used to read and update the current workload identity of
+// the task
+type identityUpdater interface {
+	getIdentity() string
+	setIdentity(token string)
+}
+
+type identityHookConfig struct {
+	alloc     *structs.Allocation
+	task      string
+	updater   identityUpdater
+	rpcClient cinterfaces.RPCer
+	region    string
+	secretID  string
+	logger    hclog.Logger
+}
+
+// identityHook writes the workload identity signed by the servers for the
+// task to the task's secrets dir, and renews it before it expires for as long
+// as the task runs.
+type identityHook struct {
+	alloc    *structs.Allocation
+	taskName string
+	updater  identityUpdater
+
+	// rpcClient is used to renew the identity with the servers, which
+	// authenticate the node by its secret ID
+	rpcClient cinterfaces.RPCer
+	region    string
+	secretID  string
+
+	logger hclog.Logger
+
+	// tokenPath is the path the identity is written to
+	tokenPath string
+
+	// firstRun stores whether the hook has yet to start renewing the
+	// identity
+	firstRun bool
+
+	// ctx and cancel are used to stop renewing the identity
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+func newIdentityHook(config *identityHookConfig) *identityHook {
+	ctx, cancel := context.WithCancel(context.Background())
+	h := &identityHook{
+		alloc:     config.alloc,
+		taskName:  config.task,
+		updater:   config.updater,
+		rpcClient: config.rpcClient,
+		region:    config.region,
+		secretID:  config.secretID,
+		firstRun:  true,
+		ctx:       ctx,
+		cancel:    cancel,
+	}
+	h.logger = config.logger.Named(h.Name())
+	return h
+}
+
+func (*identityHook) Name() string {
+	return "identity"
+}
+
+func (h *identityHook) Prestart(ctx context.Context, req *interfaces.TaskPrestartRequest, resp *interfaces.TaskPrestartResponse) error {
+	// The hook is not marked as done so that the identity is renewed again
+	// when the task is restored, but it only starts renewing it once.
+	if !h.firstRun {
+		return nil
+	}
+
+	token := h.updater.getIdentity()
+	if token == "" {
+		// Allocations placed before the keyring was initialized have no
+		// identity
+		resp.Done = true
+		return nil
+	}
+
+	// The identity may have expired while the client was down. Identities
+	// that can't be decoded are written as is and the renewal loop logs why.
+	renewAt, err := identityRenewTime(token)
+	if err == nil && !renewAt.IsZero() && !time.Now().Before(renewAt) {
+		token, err = h.renew(ctx)
+		if err != nil {
+			// The task is being killed
+			return nil
+		}
+	}
+
+	h.tokenPath = filepath.Join(req.TaskDir.SecretsDir, identityTokenFile)
+	if err := h.writeToken(token); err != nil {
+		return err
+	}
+	h.logger.Trace("workload identity written", "path", h.tokenPath)
+
+	h.firstRun = false
+	go h.run(token)
+	return nil
+}
+
+func (h *identityHook) Stop(ctx context.Context, req *interfaces.TaskStopRequest, resp *interfaces.TaskStopResponse) error {
+	h.cancel()
+	return nil
+}
+
+func (h *identityHook) Shutdown() {
+	h.cancel()
+}
+
+// run should be called in a go-routine and renews the identity halfway
+// through its lifetime until the hook is stopped.
+func (h *identityHook) run(token string) {
+	for {
+		renewAt, err := identityRenewTime(token)
+		if err != nil {
+			h.logger.Error("failed to decode workload identity", "error", err)
+			return
+		}
+		if renewAt.IsZero() {
+			// Identities signed without an expiry don't need renewing
+			return
+		}
+
+		timer := time.NewTimer(time.Until(renewAt))
+		select {
+		case <-h.ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		token, err = h.renew(h.ctx)
+		if err != nil {
+			return
+		}
+		if err := h.writeToken(token); err != nil {
+			h.logger.Error("failed to write renewed workload identity", "error", err)
+		}
+	}
+}
+
+// renew signs a new identity for the task, retrying with a backoff until it
+// succeeds or the context is done.
+func (h *identityHook) renew(ctx context.Context) (string, error) {
+	backoff := identityRenewBaseBackoff
+	for {
+		args := structs.AllocSpecificRequest{
+			AllocID: h.alloc.ID,
+			QueryOptions: structs.QueryOptions{
+				Region:    h.region,
+				AuthToken: h.secretID,
+			},
+		}
+		var resp structs.AllocSignIdentitiesResponse
+		err := h.rpcClient.RPC("Alloc.SignIdentities", &args, &resp)
+		if err == nil {
+			token, ok := resp.SignedIdentities[h.taskName]
+			if ok {
+				h.updater.setIdentity(token)
+				h.logger.Debug("workload identity renewed")
+				return token, nil
+			}
+			err = fmt.Errorf("no identity signed for task")
+		}
+
+		h.logger.Warn("failed to renew workload identity", "error", err, "retry", backoff)
+		select {
+		case <-ctx.Done():
+			return "", ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+		if backoff > identityRenewMaxBackoff {
+			backoff = identityRenewMaxBackoff
+		}
+	}
+}
+
+// writeToken writes the identity to the task's secrets dir
+func (h *identityHook) writeToken(token string) error {
+	if err := ioutil.WriteFile(h.tokenPath, []byte(token), 0600); err != nil {
+		return fmt.Errorf("failed to write workload identity: %v", err)
+	}
+	return nil
+}
+
+// identityRenewTime returns when the identity should be renewed, which is
+// halfway through its lifetime, or the zero time if it does not expire.
+func identityRenewTime(token string) (time.Time, error) {
+	var claims structs.IdentityClaims
+	if err := jwt.DecodeClaims(token, &claims); err != nil {
+		return time.Time{}, fmt.Errorf("failed to decode workload identity: %v", err)
+	}
+	if claims.Expiry == 0 {
+		return time.Time{}, nil
+	}
+	return time.Unix(claims.IssuedAt+(claims.Expiry-claims.IssuedAt)/2, 0), nil
+}
@@ -0,0 +1,309 @@
+package taskrunner
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/client/allocdir"
+	"github.com/hashicorp/nomad/client/allocrunner/interfaces"
+	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/hashicorp/nomad/helper/uuid"
+	"github.com/hashicorp/nomad/lib/auth/jwt"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+// Statically assert the identity hook implements the expected interfaces
+var _ interfaces.TaskPrestartHook = (*identityHook)(nil)
+var _ interfaces.TaskStopHook = (*identityHook)(nil)
+var _ interfaces.ShutdownHook = (*identityHook)(nil)
+
+// mockIdentityUpdater stores the current identity of a task
+type mockIdentityUpdater struct {
+	identity string
+	lock     sync.Mutex
+}
+
+func newMockIdentityUpdater(identity string) *mockIdentityUpdater {
+	return &mockIdentityUpdater{identity: identity}
+}
+
+func (m *mockIdentityUpdater) getIdentity() string {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return m.identity
+}
+
+func (m *mockIdentityUpdater) setIdentity(token string) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.identity = token
+}
+
+// mockSignIdentitiesRPC is a mock of the RPC endpoint renewing workload
+// identities, signing them with the given lifetime
+type mockSignIdentitiesRPC struct {
+	key      *ecdsa.PrivateKey
+	lifetime time.Duration
+
+	// SecretID is the node secret expected in the requests
+	SecretID string
+
+	calls int
+	lock  sync.Mutex
+}
+
+func (m *mockSignIdentitiesRPC) RPC(method string, args interface{}, reply interface{}) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if method != "Alloc.SignIdentities" {
+		return fmt.Errorf("unexpected RPC %q", method)
+	}
+	m.calls++
+	req := args.(*structs.AllocSpecificRequest)
+	if req.AuthToken != m.SecretID {
+		return structs.ErrPermissionDenied
+	}
+	resp := reply.(*structs.AllocSignIdentitiesResponse)
+	resp.SignedIdentities = map[string]string{
+		"web": m.sign(time.Now()),
+	}
+	return nil
+}
+
+// sign returns an identity issued at the given time
+func (m *mockSignIdentitiesRPC) sign(now time.Time) string {
+	claims := structs.IdentityClaims{
+		ID:       uuid.Generate(),
+		IssuedAt: now.Unix(),
+		Expiry:   now.Add(m.lifetime).Unix(),
+	}
+	token, err := jwt.Sign(claims, "key", m.key)
+	if err != nil {
+		panic(err)
+	}
+	return token
+}
+
+func (m *mockSignIdentitiesRPC) Calls() int {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return m.calls
+}
+
+func newMockSignIdentitiesRPC(t *testing.T, lifetime time.Duration) *mockSignIdentitiesRPC {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	return &mockSignIdentitiesRPC{
+		key:      key,
+		lifetime: lifetime,
+		SecretID: "secret",
+	}
+}
+
+// TestTaskRunner_IdentityHook_NoIdentity asserts that the hook is a noop and
+// is marked as done if the allocation has no workload identity.
+func TestTaskRunner_IdentityHook_NoIdentity(t *testing.T) {
+	t.Parallel()
+
+	require := require.New(t)
+	logger := testlog.HCLogger(t)
+	allocDir := allocdir.NewAllocDir(logger, "nomadtest_noidentity")
+	defer allocDir.Destroy()
+
+	alloc := mock.BatchAlloc()
+	task := alloc.Job.TaskGroups[0].Tasks[0]
+	taskDir := allocDir.NewTaskDir(task.Name)
+	require.NoError(taskDir.Build(false, nil))
+
+	h := newIdentityHook(&identityHookConfig{
+		alloc:   alloc,
+		task:    task.Name,
+		updater: newMockIdentityUpdater(alloc.SignedIdentities[task.Name]),
+		logger:  logger,
+	})
+	defer h.Shutdown()
+
+	req := interfaces.TaskPrestartRequest{
+		Task:    task,
+		TaskDir: taskDir,
+	}
+	resp := interfaces.TaskPrestartResponse{}
+
+	require.NoError(h.Prestart(context.Background(), &req, &resp))
+	require.True(resp.Done)
+
+	_, err := os.Stat(filepath.Join(taskDir.SecretsDir, identityTokenFile))
+	require.True(os.IsNotExist(err))
+}
+
+// TestTaskRunner_IdentityHook_Ok asserts that the workload identity of the
+// task is written to its secrets dir.
+func TestTaskRunner_IdentityHook_Ok(t *testing.T) {
+	t.Parallel()
+
+	require := require.New(t)
+	logger := testlog.HCLogger(t)
+	allocDir := allocdir.NewAllocDir(logger, "nomadtest_identity")
+	defer allocDir.Destroy()
+
+	alloc := mock.BatchAlloc()
+	task := alloc.Job.TaskGroups[0].Tasks[0]
+	alloc.SignedIdentities = map[string]string{
+		task.Name: "header.claims.signature",
+		"other":   "other.claims.signature",
+	}
+	taskDir := allocDir.NewTaskDir(task.Name)
+	require.NoError(taskDir.Build(false, nil))
+
+	h := newIdentityHook(&identityHookConfig{
+		alloc:   alloc,
+		task:    task.Name,
+		updater: newMockIdentityUpdater(alloc.SignedIdentities[task.Name]),
+		logger:  logger,
+	})
+	defer h.Shutdown()
+
+	req := interfaces.TaskPrestartRequest{
+		Task:    task,
+		TaskDir: taskDir,
+	}
+	resp := interfaces.TaskPrestartResponse{}
+
+	require.NoError(h.Prestart(context.Background(), &req, &resp))
+	require.False(resp.Done)
+
+	tokenPath := filepath.Join(taskDir.SecretsDir, identityTokenFile)
+	data, err := ioutil.ReadFile(tokenPath)
+	require.NoError(err)
+	require.Equal("header.claims.signature", string(data))
+
+	fi, err := os.Stat(tokenPath)
+	require.NoError(err)
+	require.Equal(os.FileMode(0600), fi.Mode().Perm())
+}
+
+// TestTaskRunner_IdentityHook_RenewExpired asserts that an identity which
+// expired while the client was down is renewed before it is written.
+func TestTaskRunner_IdentityHook_RenewExpired(t *testing.T) {
+	t.Parallel()
+
+	require := require.New(t)
+	logger := testlog.HCLogger(t)
+	allocDir := allocdir.NewAllocDir(logger, "nomadtest_identityexpired")
+	defer allocDir.Destroy()
+
+	alloc := mock.BatchAlloc()
+	task := alloc.Job.TaskGroups[0].Tasks[0]
+	taskDir := allocDir.NewTaskDir(task.Name)
+	require.NoError(taskDir.Build(false, nil))
+
+	rpc := newMockSignIdentitiesRPC(t, time.Hour)
+	expired := rpc.sign(time.Now().Add(-2 * time.Hour))
+	updater := newMockIdentityUpdater(expired)
+
+	h := newIdentityHook(&identityHookConfig{
+		alloc:     alloc,
+		task:      task.Name,
+		updater:   updater,
+		rpcClient: rpc,
+		region:    "global",
+		secretID:  rpc.SecretID,
+		logger:    logger,
+	})
+	defer h.Shutdown()
+
+	req := interfaces.TaskPrestartRequest{
+		Task:    task,
+		TaskDir: taskDir,
+	}
+	resp := interfaces.TaskPrestartResponse{}
+	require.NoError(h.Prestart(context.Background(), &req, &resp))
+	require.Equal(1, rpc.Calls())
+
+	renewed := updater.getIdentity()
+	require.NotEqual(expired, renewed)
+	data, err := ioutil.ReadFile(filepath.Join(taskDir.SecretsDir, identityTokenFile))
+	require.NoError(err)
+	require.Equal(renewed, string(data))
+
+	// Running the hook again, as happens when the task restarts, doesn't
+	// renew the identity again
+	require.NoError(h.Prestart(context.Background(), &req, &resp))
+	require.Equal(1, rpc.Calls())
+}
+
+// TestTaskRunner_IdentityHook_Renew asserts that the identity is renewed
+// halfway through its lifetime and that renewing stops with the task.
+func TestTaskRunner_IdentityHook_Renew(t *testing.T) {
+	t.Parallel()
+
+	require := require.New(t)
+	logger := testlog.HCLogger(t)
+	allocDir := allocdir.NewAllocDir(logger, "nomadtest_identityrenew")
+	defer allocDir.Destroy()
+
+	alloc := mock.BatchAlloc()
+	task := alloc.Job.TaskGroups[0].Tasks[0]
+	taskDir := allocDir.NewTaskDir(task.Name)
+	require.NoError(taskDir.Build(false, nil))
+
+	// Identities are renewed a second after they are issued
+	rpc := newMockSignIdentitiesRPC(t, 2*time.Second)
+	initial := rpc.sign(time.Now())
+	updater := newMockIdentityUpdater(initial)
+
+	h := newIdentityHook(&identityHookConfig{
+		alloc:     alloc,
+		task:      task.Name,
+		updater:   updater,
+		rpcClient: rpc,
+		region:    "global",
+		secretID:  rpc.SecretID,
+		logger:    logger,
+	})
+	defer h.Shutdown()
+
+	req := interfaces.TaskPrestartRequest{
+		Task:    task,
+		TaskDir: taskDir,
+	}
+	resp := interfaces.TaskPrestartResponse{}
+	require.NoError(h.Prestart(context.Background(), &req, &resp))
+	require.Zero(rpc.Calls())
+
+	tokenPath := filepath.Join(taskDir.SecretsDir, identityTokenFile)
+	testutil.WaitForResult(func() (bool, error) {
+		if rpc.Calls() == 0 {
+			return false, fmt.Errorf("identity not renewed")
+		}
+		data, err := ioutil.ReadFile(tokenPath)
+		if err != nil {
+			return false, err
+		}
+		if string(data) == initial || string(data) != updater.getIdentity() {
+			return false, fmt.Errorf("renewed identity not written")
+		}
+		return true, nil
+	}, func(err error) {
+		t.Fatalf("err: %v", err)
+	})
+
+	// Stopping the task stops renewing the identity
+	require.NoError(h.Stop(context.Background(), nil, nil))
+	calls := rpc.Calls()
+	time.Sleep(2 * time.Second)
+	require.Equal(calls, rpc.Calls())
+}
@@ -20,6 +20,7 @@ import (
 	"github.com/hashicorp/nomad/client/consul"
 	"github.com/hashicorp/nomad/client/devicemanager"
 	cinterfaces "github.com/hashicorp/nomad/client/interfaces"
+	"github.com/hashicorp/nomad/client/lib/cgutil"
 	"github.com/hashicorp/nomad/client/pluginmanager/drivermanager"
 	cstate "github.com/hashicorp/nomad/client/state"
 	cstructs "github.com/hashicorp/nomad/client/structs"
@@ -28,7 +29,9 @@ import (
 	"github.com/hashicorp/nomad/helper/pluginutils/hclspecutils"
 	"github.com/hashicorp/nomad/helper/pluginutils/hclutils"
 	"github.com/hashicorp/nomad/helper/uuid"
+	"github.com/hashicorp/nomad/lib/cpuset"
 	"github.com/hashicorp/nomad/nomad/structs"
+	sconfig "github.com/hashicorp/nomad/nomad/structs/config"
 	bstructs "github.com/hashicorp/nomad/plugins/base/structs"
 	"github.com/hashicorp/nomad/plugins/drivers"
 )
@@ -161,11 +164,19 @@ type TaskRunner struct {
 	// vaultClient is the client to use to derive and renew Vault tokens
 	vaultClient vaultclient.VaultClient
 
+	// vaultConfig is the configuration of the Vault cluster of the task
+	vaultConfig *sconfig.VaultConfig
+
 	// vaultToken is the current Vault token. It should be accessed with the
 	// getter.
 	vaultToken     string
 	vaultTokenLock sync.Mutex
 
+	// identity is the current workload identity of the task, which is
+	// renewed before it expires. It should be accessed with the getter.
+	identity     string
+	identityLock sync.Mutex
+
 	// baseLabels are used when emitting tagged metrics. All task runner metrics
 	// will have these tags, and optionally more.
 	baseLabels []metrics.Label
@@ -182,6 +193,14 @@ type TaskRunner struct {
 	// deviceStatsReporter is used to lookup resource usage for alloc devices
 	deviceStatsReporter cinterfaces.DeviceStatsReporter
 
+	// coresReporter is used to lookup the cpu cores reserved on the client so
+	// that tasks without reserved cores can be kept off of them. May be nil.
+	coresReporter cinterfaces.ReservedCoresReporter
+
+	// cpusetManager provides the cpuset cgroup the task joins so that its
+	// cpuset follows the cores reserved on the client
+	cpusetManager cgutil.CpusetManager
+
 	// devicemanager is used to mount devices as well as lookup device
 	// statistics
 	devicemanager devicemanager.Manager
@@ -202,6 +221,10 @@ type TaskRunner struct {
 	// fails and the Run method should wait until serversContactedCh is
 	// closed.
 	waitOnServers bool
+
+	// rpcClient is used to make RPC calls to the servers on behalf of the
+	// task. May be nil.
+	rpcClient cinterfaces.RPCer
 }
 
 type Config struct {
@@ -215,6 +238,9 @@ type Config struct {
 	// Vault is the client to use to derive and renew Vault tokens
 	Vault vaultclient.VaultClient
 
+	// VaultConfig is the configuration of the Vault cluster of the task
+	VaultConfig *sconfig.VaultConfig
+
 	// StateDB is used to store and restore state.
 	StateDB cstate.StateDB
 
@@ -224,6 +250,12 @@ type Config struct {
 	// deviceStatsReporter is used to lookup resource usage for alloc devices
 	DeviceStatsReporter cinterfaces.DeviceStatsReporter
 
+	// CoresReporter is used to lookup the cpu cores reserved on the client
+	CoresReporter cinterfaces.ReservedCoresReporter
+
+	// CpusetManager provides the cpuset cgroup the task joins
+	CpusetManager cgutil.CpusetManager
+
 	// DeviceManager is used to mount devices as well as lookup device
 	// statistics
 	DeviceManager devicemanager.Manager
@@ -235,6 +267,10 @@ type Config struct {
 	// ServersContactedCh is closed when the first GetClientAllocs call to
 	// servers succeeds and allocs are synced.
 	ServersContactedCh chan struct{}
+
+	// RPCClient is used to make RPC calls to the servers on behalf of the
+	// task. May be nil.
+	RPCClient cinterfaces.RPCer
 }
 
 func NewTaskRunner(config *Config) (*TaskRunner, error) {
@@ -269,11 +305,14 @@ func NewTaskRunner(config *Config) (*TaskRunner, error) {
 		envBuilder:          envBuilder,
 		consulClient:        config.Consul,
 		vaultClient:         config.Vault,
+		vaultConfig:         config.VaultConfig,
 		state:               tstate,
 		localState:          state.NewLocalState(),
 		stateDB:             config.StateDB,
 		stateUpdater:        config.StateUpdater,
 		deviceStatsReporter: config.DeviceStatsReporter,
+		coresReporter:       config.CoresReporter,
+		cpusetManager:       config.CpusetManager,
 		killCtx:             killCtx,
 		killCtxCancel:       killCancel,
 		shutdownCtx:         trCtx,
@@ -284,6 +323,8 @@ func NewTaskRunner(config *Config) (*TaskRunner, error) {
 		driverManager:       config.DriverManager,
 		maxEvents:           defaultMaxEvents,
 		serversContactedCh:  config.ServersContactedCh,
+		rpcClient:           config.RPCClient,
+		identity:            config.Alloc.SignedIdentities[config.Task.Name],
 	}
 
 	// Create the logger based on the allocation ID
@@ -849,6 +890,8 @@ func (tr *TaskRunner) buildTaskConfig() *drivers.TaskConfig {
 				MemoryLimitBytes: taskResources.Memory.MemoryMB * 1024 * 1024,
 				CPUShares:        taskResources.Cpu.CpuShares,
 				PercentTicks:     float64(taskResources.Cpu.CpuShares) / float64(tr.clientConfig.Node.NodeResources.Cpu.CpuShares),
+				CpusetCPUs:       tr.cpusetCPUs(taskResources),
+				CpusetCgroupPath: tr.cpusetCgroupPath(),
 			},
 		},
 		Devices:    tr.hookResources.getDevices(),
@@ -863,6 +906,33 @@ func (tr *TaskRunner) buildTaskConfig() *drivers.TaskConfig {
 	}
 }
 
+// cpusetCPUs returns the cpuset the task should be confined to. Tasks with
+// reserved cores are pinned to them, while all other tasks are kept off of
+// the cores reserved by allocations on the client. An empty string leaves the
+// task unconstrained.
+func (tr *TaskRunner) cpusetCPUs(taskResources *structs.AllocatedTaskResources) string {
+	if len(taskResources.Cpu.ReservedCores) > 0 {
+		return cpuset.New(taskResources.Cpu.ReservedCores...).String()
+	}
+
+	nodeCores := tr.clientConfig.Node.NodeResources.Cpu.ReservableCpuCores
+	if len(nodeCores) == 0 || tr.coresReporter == nil {
+		return ""
+	}
+
+	shared := cpuset.New(nodeCores...).Difference(cpuset.New(tr.coresReporter.ReservedCores()...))
+	return shared.String()
+}
+
+// cpusetCgroupPath returns the cpuset cgroup the task should join, or an empty
+// string if the cpuset cgroups aren't managed by the client.
+func (tr *TaskRunner) cpusetCgroupPath() string {
+	if tr.cpusetManager == nil {
+		return ""
+	}
+	return tr.cpusetManager.CgroupPathFor(tr.allocID, tr.taskName)
+}
+
 // Restore task runner state. Called by AllocRunner.Restore after NewTaskRunner
 // but before Run so no locks need to be acquired.
 func (tr *TaskRunner) Restore() error {
@@ -895,7 +965,7 @@ func (tr *TaskRunner) Restore() error {
 		}
 
 		alloc := tr.Alloc()
-		if alloc.TerminalStatus() || alloc.Job.Type == structs.JobTypeSystem {
+		if alloc.TerminalStatus() || alloc.Job.IsNodeBound() {
 			return nil
 		}
 
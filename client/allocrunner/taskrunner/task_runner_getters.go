@@ -46,6 +46,19 @@ func (tr *TaskRunner) getVaultToken() string {
 	return tr.vaultToken
 }
 
+func (tr *TaskRunner) getIdentity() string {
+	tr.identityLock.Lock()
+	defer tr.identityLock.Unlock()
+	return tr.identity
+}
+
+// setIdentity updates the workload identity of the task after it is renewed
+func (tr *TaskRunner) setIdentity(token string) {
+	tr.identityLock.Lock()
+	defer tr.identityLock.Unlock()
+	tr.identity = token
+}
+
 // setVaultToken updates the vault token on the task runner as well as in the
 // task's environment. These two places must be set atomically to avoid a task
 // seeing a different token on the task runner and in its environment.
@@ -57,7 +70,11 @@ func (tr *TaskRunner) setVaultToken(token string) {
 	tr.vaultToken = token
 
 	// Update the task's environment
-	tr.envBuilder.SetVaultToken(token, tr.clientConfig.VaultConfig.Namespace, tr.task.Vault.Env)
+	namespace := ""
+	if tr.vaultConfig != nil {
+		namespace = tr.vaultConfig.Namespace
+	}
+	tr.envBuilder.SetVaultToken(token, namespace, tr.task.Vault.Env)
 }
 
 // getDriverHandle returns a driver handle.
@@ -60,7 +60,17 @@ func (tr *TaskRunner) initHooks() {
 		newValidateHook(tr.clientConfig, hookLogger),
 		newTaskDirHook(tr, hookLogger),
 		newLogMonHook(tr.logmonHookConfig, hookLogger),
-		newDispatchHook(tr.Alloc(), hookLogger),
+		newIdentityHook(&identityHookConfig{
+			alloc:     tr.Alloc(),
+			task:      tr.taskName,
+			updater:   tr,
+			rpcClient: tr.rpcClient,
+			region:    tr.clientConfig.Region,
+			secretID:  tr.clientConfig.Node.SecretID,
+			logger:    hookLogger,
+		}),
+		newDispatchHook(tr.Alloc(), tr.getIdentity, tr.clientConfig.Node.SecretID, tr.rpcClient,
+			tr.clientConfig.Region, hookLogger),
 		newArtifactHook(tr, hookLogger),
 		newStatsHook(tr, tr.clientConfig.StatsCollectionInterval, hookLogger),
 		newDeviceHook(tr.devicemanager, hookLogger),
@@ -88,7 +98,11 @@ func (tr *TaskRunner) initHooks() {
 			events:       tr,
 			templates:    task.Templates,
 			clientConfig: tr.clientConfig,
+			vaultConfig:  tr.vaultConfig,
 			envBuilder:   tr.envBuilder,
+			rpcClient:    tr.rpcClient,
+			namespace:    tr.Alloc().Namespace,
+			identity:     tr.getIdentity,
 		}))
 	}
 
@@ -102,6 +102,7 @@ func testTaskRunnerConfig(t *testing.T, alloc *structs.Allocation, taskName stri
 		TaskDir:            taskDir,
 		Logger:             clientConf.Logger,
 		Vault:              vaultclient.NewMockVaultClient(),
+		VaultConfig:        clientConf.GetDefaultVault(),
 		StateDB:            cstate.NoopDB{},
 		StateUpdater:       NewMockTaskStateUpdater(),
 		DeviceManager:      devicemanager.NoopMockManager(),
@@ -3,6 +3,7 @@ package template
 import (
 	"context"
 	"fmt"
+	"io/ioutil"
 	"math/rand"
 	"os"
 	"path/filepath"
@@ -19,9 +20,11 @@ import (
 	multierror "github.com/hashicorp/go-multierror"
 	"github.com/hashicorp/nomad/client/allocrunner/taskrunner/interfaces"
 	"github.com/hashicorp/nomad/client/config"
+	cinterfaces "github.com/hashicorp/nomad/client/interfaces"
 	"github.com/hashicorp/nomad/client/taskenv"
 	"github.com/hashicorp/nomad/helper"
 	"github.com/hashicorp/nomad/nomad/structs"
+	sconfig "github.com/hashicorp/nomad/nomad/structs/config"
 )
 
 const (
@@ -53,6 +56,9 @@ type TaskTemplateManager struct {
 	// runner is the consul-template runner
 	runner *manager.Runner
 
+	// variables watches the variables read by the templates, if any
+	variables *variableWatcher
+
 	// signals is a lookup map from the string representation of a signal to its
 	// actual signal
 	signals map[string]os.Signal
@@ -87,6 +93,9 @@ type TaskTemplateManagerConfig struct {
 	// VaultToken is the Vault token for the task.
 	VaultToken string
 
+	// VaultConfig is the configuration of the Vault cluster of the task
+	VaultConfig *sconfig.VaultConfig
+
 	// TaskDir is the task's directory
 	TaskDir string
 
@@ -96,6 +105,18 @@ type TaskTemplateManagerConfig struct {
 	// MaxTemplateEventRate is the maximum rate at which we should emit events.
 	MaxTemplateEventRate time.Duration
 
+	// RPCClient is used to watch the variables read by the nomadVar and
+	// nomadVarList template functions. The functions are not available if it
+	// is nil.
+	RPCClient cinterfaces.RPCer
+
+	// Namespace is the namespace of the task's allocation
+	Namespace string
+
+	// IdentityToken returns the current workload identity of the task, which
+	// is used to read the variables of its job
+	IdentityToken func() string
+
 	// retryRate is only used for testing and is used to increase the retry rate
 	retryRate time.Duration
 }
@@ -153,12 +174,13 @@ func NewTaskTemplateManager(config *TaskTemplateManagerConfig) (*TaskTemplateMan
 	}
 
 	// Build the consul-template runner
-	runner, lookup, err := templateRunner(config)
+	runner, lookup, variables, err := templateRunner(config)
 	if err != nil {
 		return nil, err
 	}
 	tm.runner = runner
 	tm.lookup = lookup
+	tm.variables = variables
 
 	go tm.run()
 	return tm, nil
@@ -180,6 +202,11 @@ func (tm *TaskTemplateManager) Stop() {
 	if tm.runner != nil {
 		tm.runner.Stop()
 	}
+
+	// Stop watching the variables
+	if tm.variables != nil {
+		tm.variables.stop()
+	}
 }
 
 // run is the long lived loop that handles errors and templates being rendered
@@ -191,6 +218,17 @@ func (tm *TaskTemplateManager) run() {
 		return
 	}
 
+	// Fetch the variables read by the templates before rendering them
+	if tm.variables != nil {
+		if err := tm.variables.start(); err != nil {
+			tm.config.Lifecycle.Kill(context.Background(),
+				structs.NewTaskEvent(structs.TaskKilling).
+					SetFailsTask().
+					SetDisplayMessage(fmt.Sprintf("Template failed: %v", err)))
+			return
+		}
+	}
+
 	// Start the runner
 	go tm.runner.Start()
 
@@ -481,31 +519,39 @@ func (tm *TaskTemplateManager) allTemplatesNoop() bool {
 	return true
 }
 
-// templateRunner returns a consul-template runner for the given templates and a
-// lookup by destination to the template. If no templates are in the config, a
-// nil template runner and lookup is returned.
+// templateRunner returns a consul-template runner for the given templates, a
+// lookup by destination to the template and the watcher of the variables they
+// read. If no templates are in the config, a nil template runner, lookup and
+// watcher is returned.
 func templateRunner(config *TaskTemplateManagerConfig) (
-	*manager.Runner, map[string][]*structs.Template, error) {
+	*manager.Runner, map[string][]*structs.Template, *variableWatcher, error) {
 
 	if len(config.Templates) == 0 {
-		return nil, nil, nil
+		return nil, nil, nil, nil
+	}
+
+	// The variable functions are only available if the variables can be
+	// read from the servers
+	var variables *variableWatcher
+	if config.RPCClient != nil {
+		variables = newVariableWatcher(config)
 	}
 
 	// Parse the templates
-	ctmplMapping, err := parseTemplateConfigs(config)
+	ctmplMapping, err := parseTemplateConfigs(config, variables)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
 
 	// Create the runner configuration.
 	runnerConfig, err := newRunnerConfig(config, ctmplMapping)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
 
 	runner, err := manager.NewRunner(runnerConfig, false, false)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
 
 	// Set Nomad's environment variables
@@ -522,12 +568,15 @@ func templateRunner(config *TaskTemplateManagerConfig) (
 		}
 	}
 
-	return runner, lookup, nil
+	return runner, lookup, variables, nil
 }
 
 // parseTemplateConfigs converts the tasks templates in the config into
-// consul-templates
-func parseTemplateConfigs(config *TaskTemplateManagerConfig) (map[ctconf.TemplateConfig]*structs.Template, error) {
+// consul-templates. If a variable watcher is given, the calls of the variable
+// functions are rewritten into reads of the variables it watches.
+func parseTemplateConfigs(config *TaskTemplateManagerConfig,
+	variables *variableWatcher) (map[ctconf.TemplateConfig]*structs.Template, error) {
+
 	allowAbs := config.ClientConfig.ReadBoolDefault(hostSrcOption, true)
 	taskEnv := config.EnvBuilder.Build()
 
@@ -549,10 +598,29 @@ func parseTemplateConfigs(config *TaskTemplateManagerConfig) (map[ctconf.Templat
 			dest = filepath.Join(config.TaskDir, taskEnv.ReplaceEnv(tmpl.DestPath))
 		}
 
+		contents := tmpl.EmbeddedTmpl
+		if variables != nil {
+			// Sources are read here rather than by consul-template so their
+			// variable functions can be rewritten
+			if src != "" {
+				data, err := ioutil.ReadFile(src)
+				if err != nil {
+					return nil, fmt.Errorf("Failed to read template source %q: %v", src, err)
+				}
+				src, contents = "", string(data)
+			}
+
+			var err error
+			contents, err = variables.rewrite(contents, tmpl.LeftDelim, tmpl.RightDelim)
+			if err != nil {
+				return nil, fmt.Errorf("Failed to parse template %q: %v", tmpl.DestPath, err)
+			}
+		}
+
 		ct := ctconf.DefaultTemplateConfig()
 		ct.Source = &src
 		ct.Destination = &dest
-		ct.Contents = &tmpl.EmbeddedTmpl
+		ct.Contents = &contents
 		ct.LeftDelim = &tmpl.LeftDelim
 		ct.RightDelim = &tmpl.RightDelim
 
@@ -641,25 +709,26 @@ func newRunnerConfig(config *TaskTemplateManagerConfig,
 	emptyStr := ""
 	conf.Vault.RenewToken = helper.BoolToPtr(false)
 	conf.Vault.Token = &emptyStr
-	if cc.VaultConfig != nil && cc.VaultConfig.IsEnabled() {
-		conf.Vault.Address = &cc.VaultConfig.Addr
+	vc := config.VaultConfig
+	if vc != nil && vc.IsEnabled() {
+		conf.Vault.Address = &vc.Addr
 		conf.Vault.Token = &config.VaultToken
 		conf.Vault.Grace = helper.TimeToPtr(vaultGrace)
-		if config.ClientConfig.VaultConfig.Namespace != "" {
-			conf.Vault.Namespace = &config.ClientConfig.VaultConfig.Namespace
+		if vc.Namespace != "" {
+			conf.Vault.Namespace = &vc.Namespace
 		}
 
-		if strings.HasPrefix(cc.VaultConfig.Addr, "https") || cc.VaultConfig.TLSCertFile != "" {
-			skipVerify := cc.VaultConfig.TLSSkipVerify != nil && *cc.VaultConfig.TLSSkipVerify
+		if strings.HasPrefix(vc.Addr, "https") || vc.TLSCertFile != "" {
+			skipVerify := vc.TLSSkipVerify != nil && *vc.TLSSkipVerify
 			verify := !skipVerify
 			conf.Vault.SSL = &ctconf.SSLConfig{
 				Enabled:    helper.BoolToPtr(true),
 				Verify:     &verify,
-				Cert:       &cc.VaultConfig.TLSCertFile,
-				Key:        &cc.VaultConfig.TLSKeyFile,
-				CaCert:     &cc.VaultConfig.TLSCaFile,
-				CaPath:     &cc.VaultConfig.TLSCaPath,
-				ServerName: &cc.VaultConfig.TLSServerName,
+				Cert:       &vc.TLSCertFile,
+				Key:        &vc.TLSKeyFile,
+				CaCert:     &vc.TLSCaFile,
+				CaPath:     &vc.TLSCaPath,
+				ServerName: &vc.TLSServerName,
 			}
 		} else {
 			conf.Vault.SSL = &ctconf.SSLConfig{
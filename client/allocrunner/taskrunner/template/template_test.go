@@ -8,6 +8,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -96,6 +97,102 @@ func (m *MockTaskHooks) EmitEvent(event *structs.TaskEvent) {
 
 func (m *MockTaskHooks) SetState(state string, event *structs.TaskEvent) {}
 
+// mockVariablesRPC is a mock of the variable RPC endpoints useful for
+// testing the variable template functions. Queries block until the variables
+// change past their min query index.
+type mockVariablesRPC struct {
+	variables []*structs.VariableDecrypted
+	index     uint64
+	updateCh  chan struct{}
+	lock      sync.Mutex
+
+	// Token is the auth token expected in the requests
+	Token string
+}
+
+func newMockVariablesRPC(token string, variables ...*structs.VariableDecrypted) *mockVariablesRPC {
+	return &mockVariablesRPC{
+		variables: variables,
+		index:     1,
+		updateCh:  make(chan struct{}),
+		Token:     token,
+	}
+}
+
+// SetVariable creates or updates a variable and unblocks the queries
+func (m *mockVariablesRPC) SetVariable(v *structs.VariableDecrypted) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	m.index++
+	v.ModifyIndex = m.index
+	for i, existing := range m.variables {
+		if existing.Path == v.Path {
+			m.variables[i] = v
+			v = nil
+			break
+		}
+	}
+	if v != nil {
+		m.variables = append(m.variables, v)
+	}
+	close(m.updateCh)
+	m.updateCh = make(chan struct{})
+}
+
+// wait blocks until the index is above the min query index and returns the
+// variables and their index
+func (m *mockVariablesRPC) wait(minIndex uint64) ([]*structs.VariableDecrypted, uint64) {
+	for {
+		m.lock.Lock()
+		variables, index, updateCh := m.variables, m.index, m.updateCh
+		m.lock.Unlock()
+		if index > minIndex {
+			return variables, index
+		}
+		select {
+		case <-updateCh:
+		case <-time.After(time.Second):
+			return variables, index
+		}
+	}
+}
+
+func (m *mockVariablesRPC) RPC(method string, args interface{}, reply interface{}) error {
+	switch method {
+	case structs.VariablesReadRPCMethod:
+		req := args.(*structs.VariablesReadRequest)
+		if req.AuthToken != m.Token || req.Namespace != structs.DefaultNamespace {
+			return structs.ErrPermissionDenied
+		}
+		variables, index := m.wait(req.MinQueryIndex)
+		resp := reply.(*structs.VariablesReadResponse)
+		resp.Index = index
+		for _, v := range variables {
+			if v.Path == req.Path {
+				resp.Data = v
+			}
+		}
+	case structs.VariablesListRPCMethod:
+		req := args.(*structs.VariablesListRequest)
+		if req.AuthToken != m.Token || req.Namespace != structs.DefaultNamespace {
+			return structs.ErrPermissionDenied
+		}
+		variables, index := m.wait(req.MinQueryIndex)
+		resp := reply.(*structs.VariablesListResponse)
+		resp.Index = index
+		for _, v := range variables {
+			if strings.HasPrefix(v.Path, req.Prefix) {
+				meta := v.VariableMetadata
+				resp.Data = append(resp.Data, &meta)
+			}
+		}
+	default:
+		return fmt.Errorf("unexpected RPC %q", method)
+	}
+	return nil
+}
+
 // testHarness is used to test the TaskTemplateManager by spinning up
 // Consul/Vault as needed
 type testHarness struct {
@@ -110,6 +207,8 @@ type testHarness struct {
 	vault      *testutil.TestVault
 	consul     *ctestutil.TestServer
 	emitRate   time.Duration
+	rpcClient  *mockVariablesRPC
+	identity   string
 }
 
 // newTestHarness returns a harness starting a dev consul and vault server,
@@ -149,7 +248,7 @@ func newTestHarness(t *testing.T, templates []*structs.Template, consul, vault b
 
 	if vault {
 		harness.vault = testutil.NewTestVault(t)
-		harness.config.VaultConfig = harness.vault.Config
+		harness.config.VaultConfigs[sconfig.VaultDefaultCluster] = harness.vault.Config
 		harness.vaultToken = harness.vault.RootToken
 	}
 
@@ -164,18 +263,25 @@ func (h *testHarness) start(t *testing.T) {
 
 func (h *testHarness) startWithErr() error {
 	var err error
-	h.manager, err = NewTaskTemplateManager(&TaskTemplateManagerConfig{
+	config := &TaskTemplateManagerConfig{
 		UnblockCh:            h.mockHooks.UnblockCh,
 		Lifecycle:            h.mockHooks,
 		Events:               h.mockHooks,
 		Templates:            h.templates,
 		ClientConfig:         h.config,
 		VaultToken:           h.vaultToken,
+		VaultConfig:          h.config.GetDefaultVault(),
 		TaskDir:              h.taskDir,
 		EnvBuilder:           h.envBuilder,
 		MaxTemplateEventRate: h.emitRate,
 		retryRate:            10 * time.Millisecond,
-	})
+	}
+	if h.rpcClient != nil {
+		config.RPCClient = h.rpcClient
+		config.Namespace = structs.DefaultNamespace
+		config.IdentityToken = func() string { return h.identity }
+	}
+	h.manager, err = NewTaskTemplateManager(config)
 
 	return err
 }
@@ -540,6 +646,94 @@ func TestTaskTemplateManager_Unblock_Static_AlreadyRendered(t *testing.T) {
 	}
 }
 
+func TestTaskTemplateManager_Unblock_Variables(t *testing.T) {
+	t.Parallel()
+	// Make a template that will render the variables of the job
+	content := `{{ with nomadVar "nomad/jobs/example/db" }}{{ .user }}:{{ .password }}{{ end }}
+{{ range nomadVarList "nomad/jobs/example" }}{{ .Path }} {{ end }}`
+	expected := "admin:hunter2\nnomad/jobs/example/db nomad/jobs/example/web "
+	file := "my.tmpl"
+	template := &structs.Template{
+		EmbeddedTmpl: content,
+		DestPath:     file,
+		ChangeMode:   structs.TemplateChangeModeNoop,
+	}
+
+	harness := newTestHarness(t, []*structs.Template{template}, false, false)
+	harness.identity = "a.b.c"
+	harness.rpcClient = newMockVariablesRPC(harness.identity,
+		&structs.VariableDecrypted{
+			VariableMetadata: structs.VariableMetadata{Path: "nomad/jobs/example/db"},
+			Items:            structs.VariableItems{"user": "admin", "password": "hunter2"},
+		},
+		&structs.VariableDecrypted{
+			VariableMetadata: structs.VariableMetadata{Path: "nomad/jobs/example/web"},
+			Items:            structs.VariableItems{"port": "8080"},
+		},
+	)
+	harness.start(t)
+	defer harness.stop()
+
+	// Wait for the unblock
+	select {
+	case <-harness.mockHooks.UnblockCh:
+	case <-time.After(time.Duration(5*testutil.TestMultiplier()) * time.Second):
+		t.Fatalf("Task unblock should have been called")
+	}
+
+	// Check the file is there
+	path := filepath.Join(harness.taskDir, file)
+	raw, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, expected, string(raw))
+}
+
+func TestTaskTemplateManager_Rerender_Variables(t *testing.T) {
+	t.Parallel()
+	// Make a template that will render a variable which doesn't exist yet
+	content := `{{ with nomadVar "nomad/jobs/example/db" }}{{ .user }}{{ else }}none{{ end }}`
+	file := "my.tmpl"
+	template := &structs.Template{
+		EmbeddedTmpl: content,
+		DestPath:     file,
+		ChangeMode:   structs.TemplateChangeModeRestart,
+	}
+
+	harness := newTestHarness(t, []*structs.Template{template}, false, false)
+	harness.identity = "a.b.c"
+	harness.rpcClient = newMockVariablesRPC(harness.identity)
+	harness.start(t)
+	defer harness.stop()
+
+	// Wait for the unblock
+	select {
+	case <-harness.mockHooks.UnblockCh:
+	case <-time.After(time.Duration(5*testutil.TestMultiplier()) * time.Second):
+		t.Fatalf("Task unblock should have been called")
+	}
+
+	path := filepath.Join(harness.taskDir, file)
+	raw, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, "none", string(raw))
+
+	// Create the variable and wait for the template to be re-rendered
+	harness.rpcClient.SetVariable(&structs.VariableDecrypted{
+		VariableMetadata: structs.VariableMetadata{Path: "nomad/jobs/example/db"},
+		Items:            structs.VariableItems{"user": "admin"},
+	})
+
+	select {
+	case <-harness.mockHooks.RestartCh:
+	case <-time.After(time.Duration(10*testutil.TestMultiplier()) * time.Second):
+		t.Fatalf("Should have received a restart")
+	}
+
+	raw, err = ioutil.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, "admin", string(raw))
+}
+
 func TestTaskTemplateManager_Unblock_Consul(t *testing.T) {
 	t.Parallel()
 	// Make a template that will render based on a key in Consul
@@ -1262,7 +1456,7 @@ OUTER:
 func TestTaskTemplateManager_Config_ServerName(t *testing.T) {
 	t.Parallel()
 	c := config.DefaultConfig()
-	c.VaultConfig = &sconfig.VaultConfig{
+	vc := &sconfig.VaultConfig{
 		Enabled:       helper.BoolToPtr(true),
 		Addr:          "https://localhost/",
 		TLSServerName: "notlocalhost",
@@ -1270,14 +1464,15 @@ func TestTaskTemplateManager_Config_ServerName(t *testing.T) {
 	config := &TaskTemplateManagerConfig{
 		ClientConfig: c,
 		VaultToken:   "token",
+		VaultConfig:  vc,
 	}
 	ctconf, err := newRunnerConfig(config, nil)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
 
-	if *ctconf.Vault.SSL.ServerName != c.VaultConfig.TLSServerName {
-		t.Fatalf("expected %q but found %q", c.VaultConfig.TLSServerName, *ctconf.Vault.SSL.ServerName)
+	if *ctconf.Vault.SSL.ServerName != vc.TLSServerName {
+		t.Fatalf("expected %q but found %q", vc.TLSServerName, *ctconf.Vault.SSL.ServerName)
 	}
 }
 
@@ -1288,7 +1483,7 @@ func TestTaskTemplateManager_Config_VaultGrace(t *testing.T) {
 	assert := assert.New(t)
 	c := config.DefaultConfig()
 	c.Node = mock.Node()
-	c.VaultConfig = &sconfig.VaultConfig{
+	vc := &sconfig.VaultConfig{
 		Enabled:       helper.BoolToPtr(true),
 		Addr:          "https://localhost/",
 		TLSServerName: "notlocalhost",
@@ -1298,6 +1493,7 @@ func TestTaskTemplateManager_Config_VaultGrace(t *testing.T) {
 	config := &TaskTemplateManagerConfig{
 		ClientConfig: c,
 		VaultToken:   "token",
+		VaultConfig:  vc,
 
 		// Make a template that will render immediately
 		Templates: []*structs.Template{
@@ -1317,7 +1513,7 @@ func TestTaskTemplateManager_Config_VaultGrace(t *testing.T) {
 		EnvBuilder: taskenv.NewBuilder(c.Node, alloc, alloc.Job.TaskGroups[0].Tasks[0], c.Region),
 	}
 
-	ctmplMapping, err := parseTemplateConfigs(config)
+	ctmplMapping, err := parseTemplateConfigs(config, nil)
 	assert.Nil(err, "Parsing Templates")
 
 	ctconf, err := newRunnerConfig(config, ctmplMapping)
@@ -1335,7 +1531,7 @@ func TestTaskTemplateManager_Config_VaultNamespace(t *testing.T) {
 	testNS := "test-namespace"
 	c := config.DefaultConfig()
 	c.Node = mock.Node()
-	c.VaultConfig = &sconfig.VaultConfig{
+	vc := &sconfig.VaultConfig{
 		Enabled:       helper.BoolToPtr(true),
 		Addr:          "https://localhost/",
 		TLSServerName: "notlocalhost",
@@ -1346,10 +1542,11 @@ func TestTaskTemplateManager_Config_VaultNamespace(t *testing.T) {
 	config := &TaskTemplateManagerConfig{
 		ClientConfig: c,
 		VaultToken:   "token",
+		VaultConfig:  vc,
 		EnvBuilder:   taskenv.NewBuilder(c.Node, alloc, alloc.Job.TaskGroups[0].Tasks[0], c.Region),
 	}
 
-	ctmplMapping, err := parseTemplateConfigs(config)
+	ctmplMapping, err := parseTemplateConfigs(config, nil)
 	assert.Nil(err, "Parsing Templates")
 
 	ctconf, err := newRunnerConfig(config, ctmplMapping)
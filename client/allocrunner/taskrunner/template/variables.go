@@ -0,0 +1,376 @@
+package template
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/nomad/client/allocdir"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+const (
+	// variablesDir is the directory inside the task's secrets directory the
+	// variables read by the templates are written to
+	variablesDir = ".nomad_variables"
+
+	// variableRetryBaseBackoff and variableRetryMaxBackoff bound the backoff
+	// between failed queries of a variable
+	variableRetryBaseBackoff = 1 * time.Second
+	variableRetryMaxBackoff  = 1 * time.Minute
+)
+
+// The templates read variables with the workload identity of the task, which
+// only grants access to the variables of its job under "nomad/jobs/<job ID>":
+//
+//	nomadVar "<path>" returns the items of the variable at the path
+//	nomadVarList "<prefix>" returns the metadata of the variables under the prefix
+//
+// consul-template can't be given functions of its own, so the calls are
+// rewritten into reads of files holding the variables with its "file"
+// function. The variableWatcher keeps the files up to date with blocking
+// queries and consul-template re-renders the templates when they change.
+
+// variableQuery is a variable, or a list of variables under a prefix, read by
+// a template
+type variableQuery struct {
+	List bool
+	Path string
+}
+
+func (q variableQuery) String() string {
+	if q.List {
+		return fmt.Sprintf("nomadVarList(%s)", q.Path)
+	}
+	return fmt.Sprintf("nomadVar(%s)", q.Path)
+}
+
+// variableWatcher watches the variables read by the templates of a task and
+// writes them to files in the task's secrets dir.
+type variableWatcher struct {
+	config *TaskTemplateManagerConfig
+
+	// dir is the directory the variables are written to
+	dir string
+
+	// queries are the variables read by the templates
+	queries map[variableQuery]struct{}
+
+	// shutdownCh is closed to stop watching the variables
+	shutdownCh   chan struct{}
+	shutdown     bool
+	shutdownLock sync.Mutex
+}
+
+func newVariableWatcher(config *TaskTemplateManagerConfig) *variableWatcher {
+	return &variableWatcher{
+		config:     config,
+		dir:        filepath.Join(config.TaskDir, allocdir.TaskSecrets, variablesDir),
+		queries:    make(map[variableQuery]struct{}),
+		shutdownCh: make(chan struct{}),
+	}
+}
+
+// file returns the path of the file holding the result of the query
+func (w *variableWatcher) file(q variableQuery) string {
+	sum := sha256.Sum256([]byte(q.String()))
+	return filepath.Join(w.dir, hex.EncodeToString(sum[:])+".json")
+}
+
+// rewrite rewrites the calls of the variable functions in the actions of the
+// template into reads of the files the variables are written to, and records
+// the variables to watch. Variables must be read with literal paths.
+func (w *variableWatcher) rewrite(contents, leftDelim, rightDelim string) (string, error) {
+	if leftDelim == "" {
+		leftDelim = "{{"
+	}
+	if rightDelim == "" {
+		rightDelim = "}}"
+	}
+
+	var out strings.Builder
+	for {
+		start := strings.Index(contents, leftDelim)
+		if start < 0 {
+			out.WriteString(contents)
+			return out.String(), nil
+		}
+		start += len(leftDelim)
+		out.WriteString(contents[:start])
+		contents = contents[start:]
+
+		action, n, err := w.rewriteAction(contents, rightDelim)
+		if err != nil {
+			return "", err
+		}
+		out.WriteString(action)
+		contents = contents[n:]
+	}
+}
+
+// rewriteAction rewrites the action at the start of s up to and including the
+// right delimiter. It returns the rewritten action and the length of the
+// original one.
+func (w *variableWatcher) rewriteAction(s, rightDelim string) (string, int, error) {
+	// Comments are left as is
+	trimmed := strings.TrimLeft(strings.TrimPrefix(s, "-"), " \t\r\n")
+	if strings.HasPrefix(trimmed, "/*") {
+		end := strings.Index(s, "*/")
+		if end < 0 {
+			return "", 0, fmt.Errorf("unclosed comment")
+		}
+		return s[:end+2], end + 2, nil
+	}
+
+	var out strings.Builder
+	i := 0
+	for i < len(s) {
+		if strings.HasPrefix(s[i:], rightDelim) {
+			return out.String(), i, nil
+		}
+
+		c := s[i]
+		switch {
+		case c == '"' || c == '`' || c == '\'':
+			lit, err := scanLiteral(s[i:])
+			if err != nil {
+				return "", 0, err
+			}
+			out.WriteString(lit)
+			i += len(lit)
+		case isIdentStart(c):
+			j := i
+			for j < len(s) && isIdentChar(s[j]) {
+				j++
+			}
+			ident := s[i:j]
+
+			// Fields, variables and method calls aren't functions
+			isFunc := i == 0 || (s[i-1] != '.' && s[i-1] != '$')
+			if !isFunc || (ident != "nomadVar" && ident != "nomadVarList") {
+				out.WriteString(ident)
+				i = j
+				continue
+			}
+
+			q := variableQuery{List: ident == "nomadVarList"}
+			k := j
+			for k < len(s) && strings.IndexByte(" \t\r\n", s[k]) >= 0 {
+				k++
+			}
+			switch {
+			case k < len(s) && (s[k] == '"' || s[k] == '`'):
+				lit, err := scanLiteral(s[k:])
+				if err != nil {
+					return "", 0, err
+				}
+				path, err := strconv.Unquote(lit)
+				if err != nil {
+					return "", 0, fmt.Errorf("invalid path %s of %s: %v", lit, ident, err)
+				}
+				q.Path = path
+				k += len(lit)
+			case q.List && (k == len(s) || s[k] == ')' || s[k] == '|' ||
+				strings.HasPrefix(s[k:], rightDelim) || strings.HasPrefix(s[k:], "-"+rightDelim)):
+				// nomadVarList without a prefix lists all the variables. The
+				// whitespace is kept as it's required before a trim marker.
+				k = j
+			default:
+				return "", 0, fmt.Errorf("%s must be called with a literal path", ident)
+			}
+
+			w.queries[q] = struct{}{}
+			fmt.Fprintf(&out, "(file %s | parseJSON)", strconv.Quote(w.file(q)))
+			i = k
+		default:
+			out.WriteByte(c)
+			i++
+		}
+	}
+	return "", 0, fmt.Errorf("unclosed action")
+}
+
+// scanLiteral returns the string, raw string or character literal at the
+// start of s
+func scanLiteral(s string) (string, error) {
+	quote := s[0]
+	for i := 1; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			if quote != '`' {
+				i++
+			}
+		case quote:
+			return s[:i+1], nil
+		}
+	}
+	return "", fmt.Errorf("unterminated quoted string")
+}
+
+func isIdentStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isIdentChar(c byte) bool {
+	return isIdentStart(c) || (c >= '0' && c <= '9')
+}
+
+// start fetches the variables and writes them before the templates are first
+// rendered, then watches them for changes until the watcher is stopped.
+func (w *variableWatcher) start() error {
+	if len(w.queries) == 0 {
+		return nil
+	}
+	if err := os.MkdirAll(w.dir, 0700); err != nil {
+		return fmt.Errorf("failed to create variables dir: %v", err)
+	}
+
+	indexes := make(map[variableQuery]uint64, len(w.queries))
+	for q := range w.queries {
+		index, err := w.fetch(q, 0)
+		if err != nil {
+			return err
+		}
+		indexes[q] = index
+	}
+	for q, index := range indexes {
+		go w.watch(q, index)
+	}
+	return nil
+}
+
+// stop stops watching the variables
+func (w *variableWatcher) stop() {
+	w.shutdownLock.Lock()
+	defer w.shutdownLock.Unlock()
+
+	if w.shutdown {
+		return
+	}
+	close(w.shutdownCh)
+	w.shutdown = true
+}
+
+// watch blocks on changes of the query result, writing it each time it
+// changes, until the watcher is stopped.
+func (w *variableWatcher) watch(q variableQuery, index uint64) {
+	backoff := variableRetryBaseBackoff
+	for {
+		select {
+		case <-w.shutdownCh:
+			return
+		default:
+		}
+
+		next, err := w.fetch(q, index)
+		if err == nil {
+			index = next
+			backoff = variableRetryBaseBackoff
+			continue
+		}
+
+		// Failures are retried, so only the first of a series is reported
+		if backoff == variableRetryBaseBackoff {
+			w.config.Events.EmitEvent(structs.NewTaskEvent(consulTemplateSourceName).
+				SetDisplayMessage(fmt.Sprintf("Failed to watch variables: %v", err)))
+		}
+		select {
+		case <-w.shutdownCh:
+			return
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+		if backoff > variableRetryMaxBackoff {
+			backoff = variableRetryMaxBackoff
+		}
+	}
+}
+
+// fetch runs the query, blocking until its index is above the given one, and
+// writes the result if it changed. It returns the index of the result.
+func (w *variableWatcher) fetch(q variableQuery, index uint64) (uint64, error) {
+	opts := structs.QueryOptions{
+		Region:        w.config.ClientConfig.Region,
+		Namespace:     w.config.Namespace,
+		MinQueryIndex: index,
+		AllowStale:    true,
+	}
+	if w.config.IdentityToken != nil {
+		opts.AuthToken = w.config.IdentityToken()
+	}
+
+	var result interface{}
+	var meta structs.QueryMeta
+	if q.List {
+		opts.Prefix = q.Path
+		args := structs.VariablesListRequest{
+			QueryOptions: opts,
+		}
+		var resp structs.VariablesListResponse
+		if err := w.config.RPCClient.RPC(structs.VariablesListRPCMethod, &args, &resp); err != nil {
+			return 0, fmt.Errorf("failed to list variables: %v", err)
+		}
+		result, meta = resp.Data, resp.QueryMeta
+	} else {
+		args := structs.VariablesReadRequest{
+			Path:         q.Path,
+			QueryOptions: opts,
+		}
+		var resp structs.VariablesReadResponse
+		if err := w.config.RPCClient.RPC(structs.VariablesReadRPCMethod, &args, &resp); err != nil {
+			return 0, fmt.Errorf("failed to read variable %q: %v", q.Path, err)
+		}
+
+		// Variables that don't exist are written as null
+		if resp.Data != nil {
+			result = resp.Data.Items
+		}
+		meta = resp.QueryMeta
+	}
+
+	if err := w.write(q, result); err != nil {
+		return 0, err
+	}
+
+	// Ensure the index never goes backwards or is zero, which would turn
+	// the next query into a busy loop
+	if meta.Index > index {
+		index = meta.Index
+	}
+	if index == 0 {
+		index = 1
+	}
+	return index, nil
+}
+
+// write writes the result of the query to its file if it changed. The file
+// is replaced atomically so that the templates never read a partial write.
+func (w *variableWatcher) write(q variableQuery, result interface{}) error {
+	data, err := json.Marshal(result)
+	if err != nil {
+		return err
+	}
+
+	path := w.file(q)
+	if old, err := ioutil.ReadFile(path); err == nil && bytes.Equal(old, data) {
+		return nil
+	}
+
+	tmp := path + ".tmp"
+	if err := ioutil.WriteFile(tmp, data, 0600); err != nil {
+		return fmt.Errorf("failed to write %s: %v", q, err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("failed to write %s: %v", q, err)
+	}
+	return nil
+}
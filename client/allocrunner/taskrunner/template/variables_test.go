@@ -0,0 +1,82 @@
+package template
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/nomad/client/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVariableWatcher_Rewrite(t *testing.T) {
+	t.Parallel()
+
+	w := newVariableWatcher(&TaskTemplateManagerConfig{
+		ClientConfig: &config.Config{Region: "global"},
+		TaskDir:      "/task",
+	})
+	file := func(list bool, path string) string {
+		return "(file " + strconv.Quote(w.file(variableQuery{List: list, Path: path})) + " | parseJSON)"
+	}
+
+	cases := []struct {
+		Name     string
+		In       string
+		Left     string
+		Right    string
+		Expected string
+		Err      string
+	}{
+		{
+			Name:     "read",
+			In:       `{{ with nomadVar "nomad/jobs/a" }}{{ .user }}{{ end }}`,
+			Expected: `{{ with ` + file(false, "nomad/jobs/a") + ` }}{{ .user }}{{ end }}`,
+		},
+		{
+			Name:     "list",
+			In:       "{{ range nomadVarList `nomad/jobs` }}{{ .Path }}{{ end }}",
+			Expected: `{{ range ` + file(true, "nomad/jobs") + ` }}{{ .Path }}{{ end }}`,
+		},
+		{
+			Name:     "list without prefix",
+			In:       `{{- nomadVarList -}} {{ nomadVarList | toJSON }}`,
+			Expected: `{{- ` + file(true, "") + ` -}} {{ ` + file(true, "") + ` | toJSON }}`,
+		},
+		{
+			Name:     "custom delimiters",
+			In:       `[[ (nomadVar "nomad/jobs/a").user ]] {{ nomadVar }}`,
+			Left:     "[[",
+			Right:    "]]",
+			Expected: `[[ (` + file(false, "nomad/jobs/a") + `).user ]] {{ nomadVar }}`,
+		},
+		{
+			Name:     "strings, fields and comments",
+			In:       `nomadVar {{ "nomadVar }}" }}{{ .nomadVar }}{{/* nomadVar */}}`,
+			Expected: `nomadVar {{ "nomadVar }}" }}{{ .nomadVar }}{{/* nomadVar */}}`,
+		},
+		{
+			Name: "dynamic path",
+			In:   `{{ nomadVar (env "NOMAD_JOB_ID") }}`,
+			Err:  "nomadVar must be called with a literal path",
+		},
+		{
+			Name: "unclosed action",
+			In:   `{{ nomadVar "nomad/jobs/a"`,
+			Err:  "unclosed action",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			out, err := w.rewrite(c.In, c.Left, c.Right)
+			if c.Err != "" {
+				require.EqualError(t, err, c.Err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, c.Expected, out)
+		})
+	}
+
+	require.Len(t, w.queries, 3)
+}
@@ -10,8 +10,10 @@ import (
 	ti "github.com/hashicorp/nomad/client/allocrunner/taskrunner/interfaces"
 	"github.com/hashicorp/nomad/client/allocrunner/taskrunner/template"
 	"github.com/hashicorp/nomad/client/config"
+	cinterfaces "github.com/hashicorp/nomad/client/interfaces"
 	"github.com/hashicorp/nomad/client/taskenv"
 	"github.com/hashicorp/nomad/nomad/structs"
+	sconfig "github.com/hashicorp/nomad/nomad/structs/config"
 )
 
 type templateHookConfig struct {
@@ -30,8 +32,21 @@ type templateHookConfig struct {
 	// clientConfig is the Nomad Client configuration
 	clientConfig *config.Config
 
+	// vaultConfig is the configuration of the Vault cluster of the task
+	vaultConfig *sconfig.VaultConfig
+
 	// envBuilder is the environment variable builder for the task.
 	envBuilder *taskenv.Builder
+
+	// rpcClient is used by templates to read variables. May be nil.
+	rpcClient cinterfaces.RPCer
+
+	// namespace is the namespace of the allocation
+	namespace string
+
+	// identity returns the current workload identity of the task used to
+	// read variables
+	identity func() string
 }
 
 type templateHook struct {
@@ -98,9 +113,13 @@ func (h *templateHook) newManager() (unblock chan struct{}, err error) {
 		Templates:            h.config.templates,
 		ClientConfig:         h.config.clientConfig,
 		VaultToken:           h.vaultToken,
+		VaultConfig:          h.config.vaultConfig,
 		TaskDir:              h.taskDir,
 		EnvBuilder:           h.config.envBuilder,
 		MaxTemplateEventRate: template.DefaultMaxTemplateEventRate,
+		RPCClient:            h.config.rpcClient,
+		Namespace:            h.config.namespace,
+		IdentityToken:        h.config.identity,
 	})
 	if err != nil {
 		h.logger.Error("failed to create template manager", "error", err)
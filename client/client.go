@@ -28,6 +28,7 @@ import (
 	consulApi "github.com/hashicorp/nomad/client/consul"
 	"github.com/hashicorp/nomad/client/devicemanager"
 	"github.com/hashicorp/nomad/client/fingerprint"
+	"github.com/hashicorp/nomad/client/lib/cgutil"
 	"github.com/hashicorp/nomad/client/pluginmanager"
 	"github.com/hashicorp/nomad/client/pluginmanager/drivermanager"
 	"github.com/hashicorp/nomad/client/servers"
@@ -41,6 +42,7 @@ import (
 	hstats "github.com/hashicorp/nomad/helper/stats"
 	"github.com/hashicorp/nomad/helper/tlsutil"
 	"github.com/hashicorp/nomad/helper/uuid"
+	"github.com/hashicorp/nomad/lib/cpuset"
 	"github.com/hashicorp/nomad/nomad/structs"
 	nconfig "github.com/hashicorp/nomad/nomad/structs/config"
 	"github.com/hashicorp/nomad/plugins/device"
@@ -226,8 +228,10 @@ type Client struct {
 	// Shutdown() blocks on Wait() after closing shutdownCh.
 	shutdownGroup group
 
-	// vaultClient is used to interact with Vault for token and secret renewals
-	vaultClient vaultclient.VaultClient
+	// vaultClients are used to interact with Vault for token and secret
+	// renewals, indexed by Vault cluster and namespace
+	vaultClients     map[string]vaultclient.VaultClient
+	vaultClientsLock sync.Mutex
 
 	// garbageCollector is used to garbage collect terminal allocations present
 	// in the node automatically
@@ -250,6 +254,10 @@ type Client struct {
 	// drivermanager is responsible for managing driver plugins
 	drivermanager drivermanager.Manager
 
+	// cpusetManager keeps the cpusets of running tasks in line with the cpu
+	// cores reserved on the client
+	cpusetManager cgutil.CpusetManager
+
 	// baseLabels are used when emitting tagged metrics. All client metrics will
 	// have these tags, and optionally more.
 	baseLabels []metrics.Label
@@ -365,6 +373,9 @@ func NewClient(cfg *config.Config, consulCatalog consul.CatalogAPI, consulServic
 		return nil, fmt.Errorf("fingerprinting failed: %v", err)
 	}
 
+	// Setup the cpuset manager once the reservable cores are fingerprinted
+	c.setupCpusetManager()
+
 	// Build the white/blacklists of drivers.
 	allowlistDrivers := cfg.ReadStringListToMap("driver.whitelist")
 	blocklistDrivers := cfg.ReadStringListToMap("driver.blacklist")
@@ -435,8 +446,8 @@ func NewClient(cfg *config.Config, consulCatalog consul.CatalogAPI, consulServic
 		}
 	}
 
-	// Setup the vault client for token and secret renewals
-	if err := c.setupVaultClient(); err != nil {
+	// Setup the vault clients for token and secret renewals
+	if err := c.setupVaultClients(); err != nil {
 		return nil, fmt.Errorf("failed to setup vault client: %v", err)
 	}
 
@@ -664,9 +675,11 @@ func (c *Client) Shutdown() error {
 	c.logger.Info("shutting down")
 
 	// Stop renewing tokens and secrets
-	if c.vaultClient != nil {
-		c.vaultClient.Stop()
+	c.vaultClientsLock.Lock()
+	for _, vc := range c.vaultClients {
+		vc.Stop()
 	}
+	c.vaultClientsLock.Unlock()
 
 	// Stop Garbage collector
 	c.garbageCollector.Stop()
@@ -804,6 +817,46 @@ func (c *Client) LatestDeviceResourceStats(devices []*structs.AllocatedDeviceRes
 	return c.computeAllocatedDeviceGroupStats(devices, c.LatestHostStats().DeviceStats)
 }
 
+// setupCpusetManager creates the cpuset manager used to move running tasks off
+// of the cores reserved by allocations. The client falls back to pinning
+// tasks only when they start if the cpuset cgroups can't be managed.
+func (c *Client) setupCpusetManager() {
+	c.cpusetManager = cgutil.NoopCpusetManager()
+
+	c.configLock.RLock()
+	var cores []uint16
+	if r := c.config.Node.NodeResources; r != nil {
+		cores = r.Cpu.ReservableCpuCores
+	}
+	c.configLock.RUnlock()
+	if len(cores) == 0 {
+		return
+	}
+
+	manager := cgutil.NewCpusetManager(cgutil.DefaultCgroupParent, cores, c.logger)
+	if err := manager.Init(); err != nil {
+		c.logger.Warn("failed to initialize cpuset cgroups; running tasks will not follow core reservations", "error", err)
+		return
+	}
+	c.cpusetManager = manager
+}
+
+// ReservedCores returns the set of cpu cores reserved by the non-terminal
+// allocations running on the client.
+func (c *Client) ReservedCores() []uint16 {
+	reserved := cpuset.New()
+	for _, ar := range c.getAllocRunners() {
+		alloc := ar.Alloc()
+		if alloc.TerminalStatus() || alloc.AllocatedResources == nil {
+			continue
+		}
+		for _, tr := range alloc.AllocatedResources.Tasks {
+			reserved = reserved.Union(cpuset.New(tr.Cpu.ReservedCores...))
+		}
+	}
+	return reserved.ToSlice()
+}
+
 func (c *Client) computeAllocatedDeviceGroupStats(devices []*structs.AllocatedDeviceResource, hostDeviceGroupStats []*device.DeviceGroupStats) []*device.DeviceGroupStats {
 	// basic optimization for the usual case
 	if len(devices) == 0 || len(hostDeviceGroupStats) == 0 {
@@ -1011,13 +1064,16 @@ func (c *Client) restoreState() error {
 			StateDB:             c.stateDB,
 			StateUpdater:        c,
 			DeviceStatsReporter: c,
+			CoresReporter:       c,
+			CpusetManager:       c.cpusetManager,
 			Consul:              c.consulService,
-			Vault:               c.vaultClient,
+			VaultFunc:           c.vaultClient,
 			PrevAllocWatcher:    prevAllocWatcher,
 			PrevAllocMigrator:   prevAllocMigrator,
 			DeviceManager:       c.devicemanager,
 			DriverManager:       c.drivermanager,
 			ServersContactedCh:  c.serversContactedCh,
+			RPCClient:           c,
 		}
 		c.configLock.RUnlock()
 
@@ -1800,6 +1856,7 @@ func (c *Client) watchAllocations(updates chan *allocUpdates) {
 		QueryOptions: structs.QueryOptions{
 			Region:     c.Region(),
 			AllowStale: true,
+			AuthToken:  c.secretNodeID(),
 		},
 	}
 	var allocsResp structs.AllocsGetResponse
@@ -2199,13 +2256,16 @@ func (c *Client) addAlloc(alloc *structs.Allocation, migrateToken string) error
 		ClientConfig:        c.configCopy,
 		StateDB:             c.stateDB,
 		Consul:              c.consulService,
-		Vault:               c.vaultClient,
+		VaultFunc:           c.vaultClient,
 		StateUpdater:        c,
 		DeviceStatsReporter: c,
+		CoresReporter:       c,
+		CpusetManager:       c.cpusetManager,
 		PrevAllocWatcher:    prevAllocWatcher,
 		PrevAllocMigrator:   prevAllocMigrator,
 		DeviceManager:       c.devicemanager,
 		DriverManager:       c.drivermanager,
+		RPCClient:           c,
 	}
 	c.configLock.RUnlock()
 
@@ -2221,24 +2281,44 @@ func (c *Client) addAlloc(alloc *structs.Allocation, migrateToken string) error
 	return nil
 }
 
-// setupVaultClient creates an object to periodically renew tokens and secrets
-// with vault.
-func (c *Client) setupVaultClient() error {
-	var err error
-	c.vaultClient, err = vaultclient.NewVaultClient(c.config.VaultConfig, c.logger, c.deriveToken)
+// setupVaultClients creates the objects to periodically renew tokens and
+// secrets with the configured vault clusters.
+func (c *Client) setupVaultClients() error {
+	c.vaultClients = make(map[string]vaultclient.VaultClient, len(c.config.VaultConfigs))
+	for _, vaultConfig := range c.config.VaultConfigs {
+		if _, err := c.vaultClient(vaultConfig); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// vaultClient returns the object renewing tokens and secrets with the vault
+// cluster and namespace of the config, creating and starting it on first use.
+func (c *Client) vaultClient(vaultConfig *nconfig.VaultConfig) (vaultclient.VaultClient, error) {
+	c.vaultClientsLock.Lock()
+	defer c.vaultClientsLock.Unlock()
+
+	key := vaultConfig.GetName() + "/" + vaultConfig.Namespace
+	if vc, ok := c.vaultClients[key]; ok {
+		return vc, nil
+	}
+
+	vc, err := vaultclient.NewVaultClient(vaultConfig, c.logger, c.deriveToken)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	if c.vaultClient == nil {
+	if vc == nil {
 		c.logger.Error("failed to create vault client")
-		return fmt.Errorf("failed to create vault client")
+		return nil, fmt.Errorf("failed to create vault client")
 	}
 
 	// Start renewing tokens and secrets
-	c.vaultClient.Start()
+	vc.Start()
 
-	return nil
+	c.vaultClients[key] = vc
+	return vc, nil
 }
 
 // deriveToken takes in an allocation and a set of tasks and derives vault
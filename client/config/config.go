@@ -140,8 +140,9 @@ type Config struct {
 	// ConsulConfig is this Agent's Consul configuration
 	ConsulConfig *config.ConsulConfig
 
-	// VaultConfig is this Agent's Vault configuration
-	VaultConfig *config.VaultConfig
+	// VaultConfigs are this Agent's Vault configurations, indexed by the name
+	// of the Vault cluster
+	VaultConfigs map[string]*config.VaultConfig
 
 	// StatsCollectionInterval is the interval at which the Nomad client
 	// collects resource usage stats
@@ -227,15 +228,42 @@ func (c *Config) Copy() *Config {
 	nc.Servers = helper.CopySliceString(nc.Servers)
 	nc.Options = helper.CopyMapStringString(nc.Options)
 	nc.ConsulConfig = c.ConsulConfig.Copy()
-	nc.VaultConfig = c.VaultConfig.Copy()
+	nc.VaultConfigs = make(map[string]*config.VaultConfig, len(c.VaultConfigs))
+	for name, vaultConfig := range c.VaultConfigs {
+		nc.VaultConfigs[name] = vaultConfig.Copy()
+	}
 	return nc
 }
 
+// GetDefaultVault returns the configuration of the default Vault cluster
+func (c *Config) GetDefaultVault() *config.VaultConfig {
+	return c.VaultConfigs[config.VaultDefaultCluster]
+}
+
+// VaultConfigFor returns the configuration of the Vault cluster used by a
+// task's Vault block, with the namespace of the block if it sets one. Tasks
+// without a Vault block use the default cluster. Nil is returned if the
+// cluster isn't configured.
+func (c *Config) VaultConfigFor(v *structs.Vault) *config.VaultConfig {
+	if v == nil {
+		return c.GetDefaultVault()
+	}
+
+	vaultConfig := c.VaultConfigs[v.GetCluster()]
+	if vaultConfig == nil || v.Namespace == "" {
+		return vaultConfig
+	}
+
+	vaultConfig = vaultConfig.Copy()
+	vaultConfig.Namespace = v.Namespace
+	return vaultConfig
+}
+
 // DefaultConfig returns the default configuration
 func DefaultConfig() *Config {
 	return &Config{
 		Version:                    version.GetVersion(),
-		VaultConfig:                config.DefaultVaultConfig(),
+		VaultConfigs:               map[string]*config.VaultConfig{config.VaultDefaultCluster: config.DefaultVaultConfig()},
 		ConsulConfig:               config.DefaultConsulConfig(),
 		LogOutput:                  os.Stderr,
 		Region:                     "global",
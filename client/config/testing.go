@@ -41,7 +41,7 @@ func TestClientConfig(t testing.T) (*Config, func()) {
 	}
 	conf.StateDir = stateDir
 
-	conf.VaultConfig.Enabled = helper.BoolToPtr(false)
+	conf.GetDefaultVault().Enabled = helper.BoolToPtr(false)
 	conf.DevMode = true
 
 	// Loosen GC threshold
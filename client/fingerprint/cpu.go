@@ -22,7 +22,7 @@ func NewCPUFingerprint(logger log.Logger) Fingerprint {
 
 func (f *CPUFingerprint) Fingerprint(req *FingerprintRequest, resp *FingerprintResponse) error {
 	cfg := req.Config
-	setResourcesCPU := func(totalCompute int) {
+	setResourcesCPU := func(totalCompute int, cores []uint16) {
 		// COMPAT(0.10): Remove in 0.10
 		resp.Resources = &structs.Resources{
 			CPU: totalCompute,
@@ -30,7 +30,8 @@ func (f *CPUFingerprint) Fingerprint(req *FingerprintRequest, resp *FingerprintR
 
 		resp.NodeResources = &structs.NodeResources{
 			Cpu: structs.NodeCpuResources{
-				CpuShares: int64(totalCompute),
+				CpuShares:          int64(totalCompute),
+				ReservableCpuCores: cores,
 			},
 		}
 	}
@@ -39,8 +40,13 @@ func (f *CPUFingerprint) Fingerprint(req *FingerprintRequest, resp *FingerprintR
 		f.logger.Warn("failed initializing stats collector", "error", err)
 	}
 
+	cores, err := reservableCores()
+	if err != nil {
+		f.logger.Warn("failed to detect reservable cpu cores", "error", err)
+	}
+
 	if cfg.CpuCompute != 0 {
-		setResourcesCPU(cfg.CpuCompute)
+		setResourcesCPU(cfg.CpuCompute, cores)
 		return nil
 	}
 
@@ -73,7 +79,11 @@ func (f *CPUFingerprint) Fingerprint(req *FingerprintRequest, resp *FingerprintR
 	}
 
 	resp.AddAttribute("cpu.totalcompute", fmt.Sprintf("%d", tt))
-	setResourcesCPU(tt)
+	if len(cores) > 0 {
+		resp.AddAttribute("cpu.reservablecores", fmt.Sprintf("%d", len(cores)))
+	}
+
+	setResourcesCPU(tt, cores)
 	resp.Detected = true
 
 	return nil
@@ -0,0 +1,9 @@
+// +build !linux
+
+package fingerprint
+
+// reservableCores returns the set of cpu cores that may be reserved
+// exclusively by tasks. Core reservation is only supported on Linux.
+func reservableCores() ([]uint16, error) {
+	return nil, nil
+}
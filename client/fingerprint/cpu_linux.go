@@ -0,0 +1,31 @@
+package fingerprint
+
+import (
+	"io/ioutil"
+
+	"github.com/hashicorp/nomad/helper/stats"
+	"github.com/hashicorp/nomad/lib/cpuset"
+)
+
+// onlineCPUsPath lists the logical cpus that are currently online.
+const onlineCPUsPath = "/sys/devices/system/cpu/online"
+
+// reservableCores returns the set of cpu cores that may be reserved
+// exclusively by tasks. The topology is read from sysfs, falling back to
+// assuming contiguous core IDs if it is unavailable.
+func reservableCores() ([]uint16, error) {
+	raw, err := ioutil.ReadFile(onlineCPUsPath)
+	if err != nil {
+		var cores []uint16
+		for i := 0; i < stats.CPUNumCores(); i++ {
+			cores = append(cores, uint16(i))
+		}
+		return cores, nil
+	}
+
+	set, err := cpuset.Parse(string(raw))
+	if err != nil {
+		return nil, err
+	}
+	return set.ToSlice(), nil
+}
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	log "github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/nomad/nomad/structs/config"
 	vapi "github.com/hashicorp/vault/api"
 )
 
@@ -15,64 +16,94 @@ const (
 	vaultUnavailable = "unavailable"
 )
 
-// VaultFingerprint is used to fingerprint for Vault
+// VaultFingerprint is used to fingerprint for Vault. The attributes of the
+// default Vault cluster are "vault.*", the ones of the other clusters are
+// "vault.<cluster>.*".
 type VaultFingerprint struct {
-	logger    log.Logger
-	client    *vapi.Client
-	lastState string
+	logger log.Logger
+
+	// clients and lastStates are the Vault API clients and the last states of
+	// the Vault clusters, indexed by name
+	clients    map[string]*vapi.Client
+	lastStates map[string]string
 }
 
 // NewVaultFingerprint is used to create a Vault fingerprint
 func NewVaultFingerprint(logger log.Logger) Fingerprint {
-	return &VaultFingerprint{logger: logger.Named("vault"), lastState: vaultUnavailable}
+	return &VaultFingerprint{
+		logger:     logger.Named("vault"),
+		clients:    make(map[string]*vapi.Client),
+		lastStates: make(map[string]string),
+	}
 }
 
 func (f *VaultFingerprint) Fingerprint(req *FingerprintRequest, resp *FingerprintResponse) error {
-	config := req.Config
+	for name, vaultConfig := range req.Config.VaultConfigs {
+		if vaultConfig == nil || !vaultConfig.IsEnabled() {
+			continue
+		}
 
-	if config.VaultConfig == nil || !config.VaultConfig.IsEnabled() {
-		return nil
+		if err := f.fingerprintCluster(name, vaultConfig, resp); err != nil {
+			return err
+		}
 	}
+	return nil
+}
+
+// fingerprintCluster fingerprints a single Vault cluster
+func (f *VaultFingerprint) fingerprintCluster(name string, vaultConfig *config.VaultConfig, resp *FingerprintResponse) error {
+	prefix := "vault."
+	if name != config.VaultDefaultCluster {
+		prefix = "vault." + name + "."
+	}
+	logger := f.logger.With("cluster", name)
 
 	// Only create the client once to avoid creating too many connections to
 	// Vault.
-	if f.client == nil {
-		vaultConfig, err := config.VaultConfig.ApiConfig()
+	client := f.clients[name]
+	if client == nil {
+		apiConfig, err := vaultConfig.ApiConfig()
 		if err != nil {
-			return fmt.Errorf("Failed to initialize the Vault client config: %v", err)
+			return fmt.Errorf("Failed to initialize the Vault client config of cluster %q: %v", name, err)
 		}
 
-		f.client, err = vapi.NewClient(vaultConfig)
+		client, err = vapi.NewClient(apiConfig)
 		if err != nil {
-			return fmt.Errorf("Failed to initialize Vault client: %s", err)
+			return fmt.Errorf("Failed to initialize Vault client of cluster %q: %s", name, err)
 		}
+		f.clients[name] = client
+	}
+
+	lastState, ok := f.lastStates[name]
+	if !ok {
+		lastState = vaultUnavailable
 	}
 
 	// Connect to vault and parse its information
-	status, err := f.client.Sys().SealStatus()
+	status, err := client.Sys().SealStatus()
 	if err != nil {
-		f.clearVaultAttributes(resp)
+		f.clearVaultAttributes(prefix, resp)
 		// Print a message indicating that Vault is not available anymore
-		if f.lastState == vaultAvailable {
-			f.logger.Info("Vault is unavailable")
+		if lastState == vaultAvailable {
+			logger.Info("Vault is unavailable")
 		}
-		f.lastState = vaultUnavailable
+		f.lastStates[name] = vaultUnavailable
 		return nil
 	}
 
-	resp.AddAttribute("vault.accessible", strconv.FormatBool(true))
+	resp.AddAttribute(prefix+"accessible", strconv.FormatBool(true))
 	// We strip the Vault prefix because < 0.6.2 the version looks like:
 	// status.Version = "Vault v0.6.1"
-	resp.AddAttribute("vault.version", strings.TrimPrefix(status.Version, "Vault "))
-	resp.AddAttribute("vault.cluster_id", status.ClusterID)
-	resp.AddAttribute("vault.cluster_name", status.ClusterName)
+	resp.AddAttribute(prefix+"version", strings.TrimPrefix(status.Version, "Vault "))
+	resp.AddAttribute(prefix+"cluster_id", status.ClusterID)
+	resp.AddAttribute(prefix+"cluster_name", status.ClusterName)
 
 	// If Vault was previously unavailable print a message to indicate the Agent
 	// is available now
-	if f.lastState == vaultUnavailable {
-		f.logger.Info("Vault is available")
+	if lastState == vaultUnavailable {
+		logger.Info("Vault is available")
 	}
-	f.lastState = vaultAvailable
+	f.lastStates[name] = vaultAvailable
 	resp.Detected = true
 	return nil
 }
@@ -81,9 +112,9 @@ func (f *VaultFingerprint) Periodic() (bool, time.Duration) {
 	return true, 15 * time.Second
 }
 
-func (f *VaultFingerprint) clearVaultAttributes(r *FingerprintResponse) {
-	r.RemoveAttribute("vault.accessible")
-	r.RemoveAttribute("vault.version")
-	r.RemoveAttribute("vault.cluster_id")
-	r.RemoveAttribute("vault.cluster_name")
+func (f *VaultFingerprint) clearVaultAttributes(prefix string, r *FingerprintResponse) {
+	r.RemoveAttribute(prefix + "accessible")
+	r.RemoveAttribute(prefix + "version")
+	r.RemoveAttribute(prefix + "cluster_id")
+	r.RemoveAttribute(prefix + "cluster_name")
 }
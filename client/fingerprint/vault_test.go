@@ -6,6 +6,7 @@ import (
 	"github.com/hashicorp/nomad/client/config"
 	"github.com/hashicorp/nomad/helper/testlog"
 	"github.com/hashicorp/nomad/nomad/structs"
+	sconfig "github.com/hashicorp/nomad/nomad/structs/config"
 	"github.com/hashicorp/nomad/testutil"
 )
 
@@ -19,7 +20,7 @@ func TestVaultFingerprint(t *testing.T) {
 	}
 
 	conf := config.DefaultConfig()
-	conf.VaultConfig = tv.Config
+	conf.VaultConfigs[sconfig.VaultDefaultCluster] = tv.Config
 
 	request := &FingerprintRequest{Config: conf, Node: node}
 	var response FingerprintResponse
@@ -21,3 +21,14 @@ type AllocStateHandler interface {
 type DeviceStatsReporter interface {
 	LatestDeviceResourceStats([]*structs.AllocatedDeviceResource) []*device.DeviceGroupStats
 }
+
+// RPCer is the interface needed to make RPC calls to the servers
+type RPCer interface {
+	RPC(method string, args interface{}, reply interface{}) error
+}
+
+// ReservedCoresReporter gives access to the set of cpu cores exclusively
+// reserved by the allocations running on the client
+type ReservedCoresReporter interface {
+	ReservedCores() []uint16
+}
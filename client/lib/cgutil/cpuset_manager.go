@@ -0,0 +1,86 @@
+package cgutil
+
+import (
+	"github.com/hashicorp/nomad/lib/cpuset"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+const (
+	// DefaultCgroupParent is the name of the cgroup under which the cpuset
+	// cgroups of the tasks are created
+	DefaultCgroupParent = "nomad"
+
+	// SharedCpusetCgroupName is the name of the cgroup joined by all the
+	// tasks without reserved cores
+	SharedCpusetCgroupName = "shared"
+
+	// ReservedCpusetCgroupName is the name of the cgroup under which the
+	// cgroups of the tasks with reserved cores are created
+	ReservedCpusetCgroupName = "reserved"
+)
+
+// CpusetManager manages the cpuset cgroups of the tasks running on the
+// client. Each task with reserved cores is pinned to them in a cgroup of its
+// own, while all the other tasks share a cgroup whose cpuset excludes every
+// reserved core. The cpusets are updated whenever an allocation is added or
+// removed so that running tasks are moved off of the cores reserved after
+// they started.
+type CpusetManager interface {
+	// Init creates the cgroups managed by the manager
+	Init() error
+
+	// AddAlloc reserves the cores of the tasks of the allocation
+	AddAlloc(alloc *structs.Allocation)
+
+	// RemoveAlloc releases the cores reserved by the allocation
+	RemoveAlloc(allocID string)
+
+	// CgroupPathFor returns the path of the cpuset cgroup the task should
+	// join, or an empty string if the cpuset of the task isn't managed.
+	CgroupPathFor(allocID, task string) string
+}
+
+// NoopCpusetManager returns a CpusetManager that doesn't manage any cgroup,
+// for clients where cpuset cgroups aren't supported.
+func NoopCpusetManager() CpusetManager { return noopCpusetManager{} }
+
+type noopCpusetManager struct{}
+
+func (noopCpusetManager) Init() error                               { return nil }
+func (noopCpusetManager) AddAlloc(alloc *structs.Allocation)        {}
+func (noopCpusetManager) RemoveAlloc(allocID string)                {}
+func (noopCpusetManager) CgroupPathFor(allocID, task string) string { return "" }
+
+// allocReservations returns the cores reserved by each task of the
+// allocation, omitting the tasks without reserved cores.
+func allocReservations(alloc *structs.Allocation) map[string]cpuset.CPUSet {
+	reservations := make(map[string]cpuset.CPUSet)
+	if alloc.AllocatedResources == nil {
+		return reservations
+	}
+	for task, resources := range alloc.AllocatedResources.Tasks {
+		if len(resources.Cpu.ReservedCores) == 0 {
+			continue
+		}
+		reservations[task] = cpuset.New(resources.Cpu.ReservedCores...)
+	}
+	return reservations
+}
+
+// sharedCpuset returns the cores the tasks without reserved cores may use:
+// the reservable cores of the client minus the cores reserved by any task.
+func sharedCpuset(reservable cpuset.CPUSet, allocs map[string]map[string]cpuset.CPUSet) cpuset.CPUSet {
+	shared := reservable
+	for _, tasks := range allocs {
+		for _, cores := range tasks {
+			shared = shared.Difference(cores)
+		}
+	}
+	return shared
+}
+
+// reservedCgroupName returns the name of the cgroup of a task with reserved
+// cores
+func reservedCgroupName(allocID, task string) string {
+	return allocID + "-" + task
+}
@@ -0,0 +1,13 @@
+// +build !linux
+
+package cgutil
+
+import (
+	hclog "github.com/hashicorp/go-hclog"
+)
+
+// NewCpusetManager returns a no-op CpusetManager as cpuset cgroups are only
+// supported on Linux.
+func NewCpusetManager(cgroupParent string, reservable []uint16, logger hclog.Logger) CpusetManager {
+	return NoopCpusetManager()
+}
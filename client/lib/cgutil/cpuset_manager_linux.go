@@ -0,0 +1,190 @@
+package cgutil
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	hclog "github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/nomad/lib/cpuset"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/opencontainers/runc/libcontainer/cgroups"
+)
+
+const (
+	cpusetCpusFile = "cpuset.cpus"
+	cpusetMemsFile = "cpuset.mems"
+)
+
+// NewCpusetManager returns a CpusetManager creating the cpuset cgroups of the
+// tasks under the given cgroup parent. reservable is the set of cores that
+// may be reserved by the tasks.
+func NewCpusetManager(cgroupParent string, reservable []uint16, logger hclog.Logger) CpusetManager {
+	if cgroupParent == "" {
+		cgroupParent = DefaultCgroupParent
+	}
+	return &cpusetManager{
+		cgroupParent: cgroupParent,
+		reservable:   cpuset.New(reservable...),
+		allocs:       make(map[string]map[string]cpuset.CPUSet),
+		logger:       logger.Named("cpuset_manager"),
+	}
+}
+
+type cpusetManager struct {
+	// cgroupParent is the name of the cgroup under which the cgroups are
+	// created and cgroupParentPath its path once initialized
+	cgroupParent     string
+	cgroupParentPath string
+
+	// reservable is the set of cores that may be reserved by the tasks
+	reservable cpuset.CPUSet
+
+	// allocs is the cores reserved by the tasks of each allocation
+	allocs map[string]map[string]cpuset.CPUSet
+	mu     sync.Mutex
+
+	logger hclog.Logger
+}
+
+func (c *cpusetManager) Init() error {
+	mount, err := cgroups.FindCgroupMountpoint("", "cpuset")
+	if err != nil {
+		return fmt.Errorf("failed to find cpuset cgroup mountpoint: %v", err)
+	}
+
+	// Child cpusets must be given the memory nodes before tasks can join
+	// them, so inherit them from the root cpuset
+	mems, err := ioutil.ReadFile(filepath.Join(mount, cpusetMemsFile))
+	if err != nil {
+		return fmt.Errorf("failed to read root cpuset memory nodes: %v", err)
+	}
+
+	parentPath := filepath.Join(mount, c.cgroupParent)
+	all := c.reservable.String()
+	for _, path := range []string{
+		parentPath,
+		filepath.Join(parentPath, SharedCpusetCgroupName),
+		filepath.Join(parentPath, ReservedCpusetCgroupName),
+	} {
+		if err := createCpuset(path, all, mems); err != nil {
+			return err
+		}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.cgroupParentPath = parentPath
+	c.reconcile()
+	return nil
+}
+
+func (c *cpusetManager) AddAlloc(alloc *structs.Allocation) {
+	reservations := allocReservations(alloc)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.allocs[alloc.ID] = reservations
+	c.reconcile()
+}
+
+func (c *cpusetManager) RemoveAlloc(allocID string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.allocs[allocID]; !ok {
+		return
+	}
+	delete(c.allocs, allocID)
+	c.reconcile()
+}
+
+func (c *cpusetManager) CgroupPathFor(allocID, task string) string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.cgroupParentPath == "" {
+		return ""
+	}
+	if _, ok := c.allocs[allocID][task]; ok {
+		return filepath.Join(c.cgroupParentPath, ReservedCpusetCgroupName, reservedCgroupName(allocID, task))
+	}
+	return filepath.Join(c.cgroupParentPath, SharedCpusetCgroupName)
+}
+
+// reconcile writes the cpusets of the shared cgroup and of the cgroups of
+// the tasks with reserved cores, and removes the cgroups of the released
+// reservations. It must be called with the lock held.
+func (c *cpusetManager) reconcile() {
+	if c.cgroupParentPath == "" {
+		return
+	}
+
+	// Move the tasks without reserved cores off of the reserved cores
+	shared := sharedCpuset(c.reservable, c.allocs)
+	if shared.Size() == 0 {
+		c.logger.Warn("all reservable cores are reserved, leaving shared cpuset unchanged")
+	} else if err := writeCpus(filepath.Join(c.cgroupParentPath, SharedCpusetCgroupName), shared.String()); err != nil {
+		c.logger.Error("failed to update shared cpuset", "error", err)
+	}
+
+	reservedPath := filepath.Join(c.cgroupParentPath, ReservedCpusetCgroupName)
+	mems, err := ioutil.ReadFile(filepath.Join(reservedPath, cpusetMemsFile))
+	if err != nil {
+		c.logger.Error("failed to read reserved cpuset memory nodes", "error", err)
+		return
+	}
+
+	expected := make(map[string]struct{})
+	for allocID, tasks := range c.allocs {
+		for task, cores := range tasks {
+			name := reservedCgroupName(allocID, task)
+			expected[name] = struct{}{}
+			if err := createCpuset(filepath.Join(reservedPath, name), cores.String(), mems); err != nil {
+				c.logger.Error("failed to update reserved cpuset", "alloc_id", allocID, "task", task, "error", err)
+			}
+		}
+	}
+
+	// Remove the cgroups of the released reservations. Removal fails while
+	// processes remain in the cgroup, in which case it is retried on the next
+	// update.
+	entries, err := ioutil.ReadDir(reservedPath)
+	if err != nil {
+		c.logger.Error("failed to list reserved cpusets", "error", err)
+		return
+	}
+	for _, entry := range entries {
+		if _, ok := expected[entry.Name()]; ok || !entry.IsDir() {
+			continue
+		}
+		if err := os.Remove(filepath.Join(reservedPath, entry.Name())); err != nil {
+			c.logger.Debug("failed to remove released cpuset", "cgroup", entry.Name(), "error", err)
+		}
+	}
+}
+
+// createCpuset creates the cpuset cgroup if it doesn't exist and sets its
+// cpus and memory nodes
+func createCpuset(path, cpus string, mems []byte) error {
+	if err := os.MkdirAll(path, 0755); err != nil {
+		return fmt.Errorf("failed to create cpuset cgroup %q: %v", path, err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(path, cpusetMemsFile), mems, 0644); err != nil {
+		return fmt.Errorf("failed to set memory nodes of cpuset cgroup %q: %v", path, err)
+	}
+	return writeCpus(path, cpus)
+}
+
+// writeCpus sets the cpus of the cpuset cgroup
+func writeCpus(path, cpus string) error {
+	current, err := ioutil.ReadFile(filepath.Join(path, cpusetCpusFile))
+	if err == nil && strings.TrimSpace(string(current)) == cpus {
+		return nil
+	}
+	if err := ioutil.WriteFile(filepath.Join(path, cpusetCpusFile), []byte(cpus), 0644); err != nil {
+		return fmt.Errorf("failed to set cpus of cpuset cgroup %q: %v", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+package cgutil
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/nomad/client/testutil"
+	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/hashicorp/nomad/helper/uuid"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/opencontainers/runc/libcontainer/cgroups"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCpusetManager_Linux(t *testing.T) {
+	testutil.CgroupCompatible(t)
+	if os.Geteuid() != 0 {
+		t.Skip("Must be root to manage cgroups")
+	}
+	require := require.New(t)
+
+	mount, err := cgroups.FindCgroupMountpoint("", "cpuset")
+	if err != nil {
+		t.Skipf("Failed to find cpuset cgroup mount: %v", err)
+	}
+
+	// Reserve the first online core
+	parent := "nomad-test-" + uuid.Generate()[:8]
+	manager := NewCpusetManager(parent, []uint16{0}, testlog.HCLogger(t))
+	require.Empty(manager.CgroupPathFor("foo", "web"))
+	require.NoError(manager.Init())
+
+	parentPath := filepath.Join(mount, parent)
+	sharedPath := filepath.Join(parentPath, SharedCpusetCgroupName)
+	reservedPath := filepath.Join(parentPath, ReservedCpusetCgroupName)
+	defer func() {
+		dirs, _ := ioutil.ReadDir(reservedPath)
+		for _, dir := range dirs {
+			if dir.IsDir() {
+				os.Remove(filepath.Join(reservedPath, dir.Name()))
+			}
+		}
+		os.Remove(reservedPath)
+		os.Remove(sharedPath)
+		os.Remove(parentPath)
+	}()
+
+	readCpus := func(path string) string {
+		raw, err := ioutil.ReadFile(filepath.Join(path, cpusetCpusFile))
+		require.NoError(err)
+		return strings.TrimSpace(string(raw))
+	}
+	require.Equal("0", readCpus(sharedPath))
+
+	// Tasks without reserved cores join the shared cgroup
+	alloc := mock.Alloc()
+	require.Equal(sharedPath, manager.CgroupPathFor(alloc.ID, "web"))
+
+	// Tasks with reserved cores get a cgroup of their own
+	alloc.AllocatedResources.Tasks["web"].Cpu.ReservedCores = []uint16{0}
+	manager.AddAlloc(alloc)
+	taskPath := filepath.Join(reservedPath, reservedCgroupName(alloc.ID, "web"))
+	require.Equal(taskPath, manager.CgroupPathFor(alloc.ID, "web"))
+	require.Equal("0", readCpus(taskPath))
+
+	// Releasing the reservation removes the cgroup
+	manager.RemoveAlloc(alloc.ID)
+	require.Equal(sharedPath, manager.CgroupPathFor(alloc.ID, "web"))
+	_, err = os.Stat(taskPath)
+	require.True(os.IsNotExist(err))
+}
@@ -0,0 +1,46 @@
+package cgutil
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/lib/cpuset"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCpusetManager_AllocReservations(t *testing.T) {
+	require := require.New(t)
+
+	alloc := mock.Alloc()
+	require.Empty(allocReservations(alloc))
+
+	alloc.AllocatedResources.Tasks["web"].Cpu.ReservedCores = []uint16{2, 3}
+	alloc.AllocatedResources.Tasks["sidecar"] = &structs.AllocatedTaskResources{}
+	reservations := allocReservations(alloc)
+	require.Len(reservations, 1)
+	require.True(reservations["web"].Equals(cpuset.New(2, 3)))
+
+	alloc.AllocatedResources = nil
+	require.Empty(allocReservations(alloc))
+}
+
+func TestCpusetManager_SharedCpuset(t *testing.T) {
+	require := require.New(t)
+
+	reservable := cpuset.New(0, 1, 2, 3, 4, 5)
+	allocs := map[string]map[string]cpuset.CPUSet{}
+	require.True(sharedCpuset(reservable, allocs).Equals(reservable))
+
+	allocs["a"] = map[string]cpuset.CPUSet{
+		"web": cpuset.New(1, 2),
+		"db":  cpuset.New(4),
+	}
+	allocs["b"] = map[string]cpuset.CPUSet{
+		"cache": cpuset.New(5),
+	}
+	require.Equal("0,3", sharedCpuset(reservable, allocs).String())
+
+	delete(allocs, "a")
+	require.Equal("0-4", sharedCpuset(reservable, allocs).String())
+}
@@ -21,6 +21,10 @@ import (
 // wrapped tokens will be unwrapped using the vault API client.
 type TokenDeriverFunc func(*structs.Allocation, []string, *vaultapi.Client) (map[string]string, error)
 
+// VaultClientFunc returns the VaultClient of a Vault cluster and namespace
+// configuration.
+type VaultClientFunc func(*config.VaultConfig) (VaultClient, error)
+
 // The interface which nomad client uses to interact with vault and
 // periodically renews the tokens and secrets.
 type VaultClient interface {
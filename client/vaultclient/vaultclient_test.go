@@ -111,10 +111,10 @@ func TestVaultClient_NamespaceSupport(t *testing.T) {
 	logger := testlog.HCLogger(t)
 
 	conf := config.DefaultConfig()
-	conf.VaultConfig.Enabled = &tr
-	conf.VaultConfig.Token = "testvaulttoken"
-	conf.VaultConfig.Namespace = testNs
-	c, err := NewVaultClient(conf.VaultConfig, logger, nil)
+	conf.GetDefaultVault().Enabled = &tr
+	conf.GetDefaultVault().Token = "testvaulttoken"
+	conf.GetDefaultVault().Namespace = testNs
+	c, err := NewVaultClient(conf.GetDefaultVault(), logger, nil)
 	require.NoError(err)
 	require.Equal(testNs, c.client.Headers().Get(vaultconsts.NamespaceHeaderName))
 }
@@ -123,12 +123,12 @@ func TestVaultClient_Heap(t *testing.T) {
 	t.Parallel()
 	tr := true
 	conf := config.DefaultConfig()
-	conf.VaultConfig.Enabled = &tr
-	conf.VaultConfig.Token = "testvaulttoken"
-	conf.VaultConfig.TaskTokenTTL = "10s"
+	conf.GetDefaultVault().Enabled = &tr
+	conf.GetDefaultVault().Token = "testvaulttoken"
+	conf.GetDefaultVault().TaskTokenTTL = "10s"
 
 	logger := testlog.HCLogger(t)
-	c, err := NewVaultClient(conf.VaultConfig, logger, nil)
+	c, err := NewVaultClient(conf.GetDefaultVault(), logger, nil)
 	if err != nil {
 		t.Fatal(err)
 	}
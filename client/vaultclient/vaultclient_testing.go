@@ -5,6 +5,7 @@ import (
 
 	"github.com/hashicorp/nomad/helper/uuid"
 	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/nomad/structs/config"
 	vaultapi "github.com/hashicorp/vault/api"
 )
 
@@ -37,6 +38,15 @@ type MockVaultClient struct {
 // NewMockVaultClient returns a MockVaultClient for testing
 func NewMockVaultClient() *MockVaultClient { return &MockVaultClient{} }
 
+// NewMockVaultClientFunc returns a VaultClientFunc for testing which returns
+// a single MockVaultClient for all the Vault clusters
+func NewMockVaultClientFunc() VaultClientFunc {
+	vc := NewMockVaultClient()
+	return func(*config.VaultConfig) (VaultClient, error) {
+		return vc, nil
+	}
+}
+
 func (vc *MockVaultClient) DeriveToken(a *structs.Allocation, tasks []string) (map[string]string, error) {
 	vc.mu.Lock()
 	defer vc.mu.Unlock()
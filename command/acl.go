@@ -14,9 +14,11 @@ func (f *ACLCommand) Help() string {
 	helpText := `
 Usage: nomad acl <subcommand> [options] [args]
 
-  This command groups subcommands for interacting with ACL policies and tokens.
-  Users can bootstrap Nomad's ACL system, create policies that restrict access,
-  and generate tokens from those policies.
+  This command groups subcommands for interacting with ACL policies, roles,
+  tokens, auth methods and binding rules. Users can bootstrap Nomad's ACL
+  system, create policies that restrict access, group them into roles, and
+  generate tokens from those policies and roles. Auth methods and binding
+  rules allow users of external identity providers to login and obtain tokens.
 
   Bootstrap ACLs:
 
@@ -28,7 +30,7 @@ Usage: nomad acl <subcommand> [options] [args]
 }
 
 func (f *ACLCommand) Synopsis() string {
-	return "Interact with ACL policies and tokens"
+	return "Interact with ACL policies, roles, tokens and auth methods"
 }
 
 func (f *ACLCommand) Name() string { return "acl" }
@@ -0,0 +1,158 @@
+package command
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/posener/complete"
+)
+
+type ACLAuthMethodCreateCommand struct {
+	Meta
+}
+
+func (c *ACLAuthMethodCreateCommand) Help() string {
+	helpText := `
+Usage: nomad acl auth-method create [options]
+
+  Create is used to create a new ACL auth method. Requires a management token.
+
+General Options:
+
+  ` + generalOptionsUsage() + `
+
+Create Options:
+
+  -name=""
+    Sets the name of the ACL auth method. Required.
+
+  -type=""
+    Sets the type of the ACL auth method. Must be "jwt" or "oidc". Required.
+
+  -token-locality="local"
+    Sets whether the tokens created by a login are "local" to the region or
+    "global" and replicated to all regions.
+
+  -max-token-ttl="1h"
+    Sets the lifetime of the tokens created by a login.
+
+  -config=""
+    Path to a JSON file containing the configuration of the ACL auth method.
+    Required.
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *ACLAuthMethodCreateCommand) AutocompleteFlags() complete.Flags {
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
+		complete.Flags{
+			"name":           complete.PredictAnything,
+			"type":           complete.PredictSet("jwt", "oidc"),
+			"token-locality": complete.PredictSet("local", "global"),
+			"max-token-ttl":  complete.PredictAnything,
+			"config":         complete.PredictFiles("*.json"),
+		})
+}
+
+func (c *ACLAuthMethodCreateCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (c *ACLAuthMethodCreateCommand) Synopsis() string {
+	return "Create a new ACL auth method"
+}
+
+func (c *ACLAuthMethodCreateCommand) Name() string { return "acl auth-method create" }
+
+func (c *ACLAuthMethodCreateCommand) Run(args []string) int {
+	var name, methodType, locality, configPath string
+	var maxTokenTTL time.Duration
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
+	flags.Usage = func() { c.Ui.Output(c.Help()) }
+	flags.StringVar(&name, "name", "", "")
+	flags.StringVar(&methodType, "type", "", "")
+	flags.StringVar(&locality, "token-locality", "", "")
+	flags.DurationVar(&maxTokenTTL, "max-token-ttl", 0, "")
+	flags.StringVar(&configPath, "config", "", "")
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	// Check that we got no arguments
+	args = flags.Args()
+	if l := len(args); l != 0 {
+		c.Ui.Error("This command takes no arguments")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
+	if name == "" {
+		c.Ui.Error("ACL auth method name must be specified using the -name flag")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+	if methodType == "" {
+		c.Ui.Error("ACL auth method type must be specified using the -type flag")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+	if configPath == "" {
+		c.Ui.Error("ACL auth method config must be specified using the -config flag")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
+	config, err := readAuthMethodConfig(configPath)
+	if err != nil {
+		c.Ui.Error(err.Error())
+		return 1
+	}
+
+	// Get the HTTP client
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+
+	// Upserting overwrites existing auth methods, so check the auth method
+	// doesn't exist
+	if existing, _, err := client.ACLAuthMethods().Info(name, nil); err == nil && existing != nil {
+		c.Ui.Error(fmt.Sprintf("ACL auth method %q already exists", name))
+		return 1
+	}
+
+	// Create the auth method
+	method := &api.ACLAuthMethod{
+		Name:          name,
+		Type:          methodType,
+		TokenLocality: locality,
+		MaxTokenTTL:   maxTokenTTL,
+		Config:        config,
+	}
+	if _, err := client.ACLAuthMethods().Upsert(method, nil); err != nil {
+		c.Ui.Error(fmt.Sprintf("Error creating ACL auth method: %s", err))
+		return 1
+	}
+
+	c.Ui.Output(fmt.Sprintf("Successfully created %s auth method!", name))
+	return 0
+}
+
+// readAuthMethodConfig reads the JSON encoded auth method config at the path
+func readAuthMethodConfig(path string) (*api.ACLAuthMethodConfig, error) {
+	raw, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("Error reading ACL auth method config: %s", err)
+	}
+
+	var config api.ACLAuthMethodConfig
+	if err := json.Unmarshal(raw, &config); err != nil {
+		return nil, fmt.Errorf("Error parsing ACL auth method config: %s", err)
+	}
+	return &config, nil
+}
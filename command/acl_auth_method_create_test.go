@@ -0,0 +1,70 @@
+package command
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/command/agent"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/mitchellh/cli"
+	"github.com/stretchr/testify/require"
+)
+
+func TestACLAuthMethodCreateCommand(t *testing.T) {
+	require := require.New(t)
+	t.Parallel()
+	config := func(c *agent.Config) {
+		c.ACL.Enabled = true
+	}
+
+	srv, _, url := testServer(t, true, config)
+	state := srv.Agent.Server().State()
+	defer srv.Shutdown()
+
+	// Bootstrap an initial ACL token
+	token := srv.RootToken
+	require.NotNil(token, "failed to bootstrap ACL token")
+
+	// Write the config of the auth method
+	method := mock.ACLAuthMethod()
+	raw, err := json.Marshal(method.Config)
+	require.NoError(err)
+	f, err := ioutil.TempFile("", "nomad-test")
+	require.NoError(err)
+	defer os.Remove(f.Name())
+	_, err = f.Write(raw)
+	require.NoError(err)
+	require.NoError(f.Close())
+
+	ui := new(cli.MockUi)
+	cmd := &ACLAuthMethodCreateCommand{Meta: Meta{Ui: ui, flagAddress: url}}
+
+	// The auth method type is required
+	code := cmd.Run([]string{"-address=" + url, "-token=" + token.SecretID, "-name=example", "-config=" + f.Name()})
+	require.Equal(1, code)
+
+	// Request to create an auth method without a valid management token
+	invalidToken := mock.ACLToken()
+	code = cmd.Run([]string{"-address=" + url, "-token=" + invalidToken.SecretID, "-name=example", "-type=jwt", "-config=" + f.Name()})
+	require.Equal(1, code)
+
+	// Request to create an auth method with a valid management token
+	code = cmd.Run([]string{"-address=" + url, "-token=" + token.SecretID, "-name=example", "-type=jwt", "-max-token-ttl=10m", "-config=" + f.Name()})
+	require.Equal(0, code)
+	require.Contains(ui.OutputWriter.String(), "Successfully created example auth method")
+
+	out, err := state.ACLAuthMethodByName(nil, "example")
+	require.NoError(err)
+	require.Equal(10*time.Minute, out.MaxTokenTTL)
+	require.Equal(structs.ACLAuthMethodTokenLocalityLocal, out.TokenLocality)
+	require.Equal(method.Config.BoundIssuer, out.Config.BoundIssuer)
+
+	// Creating the auth method again fails
+	code = cmd.Run([]string{"-address=" + url, "-token=" + token.SecretID, "-name=example", "-type=jwt", "-config=" + f.Name()})
+	require.Equal(1, code)
+	require.Contains(ui.ErrorWriter.String(), "already exists")
+}
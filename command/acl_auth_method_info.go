@@ -0,0 +1,130 @@
+package command
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/posener/complete"
+)
+
+type ACLAuthMethodInfoCommand struct {
+	Meta
+}
+
+func (c *ACLAuthMethodInfoCommand) Help() string {
+	helpText := `
+Usage: nomad acl auth-method info [options] <name>
+
+  Info is used to fetch information on an existing ACL auth method. Requires a
+  management token.
+
+General Options:
+
+  ` + generalOptionsUsage() + `
+
+Info Options:
+
+  -json
+    Output the ACL auth method in a JSON format.
+
+  -t
+    Format and display the ACL auth method using a Go template.
+`
+
+	return strings.TrimSpace(helpText)
+}
+
+func (c *ACLAuthMethodInfoCommand) AutocompleteFlags() complete.Flags {
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
+		complete.Flags{
+			"-json": complete.PredictNothing,
+			"-t":    complete.PredictAnything,
+		})
+}
+
+func (c *ACLAuthMethodInfoCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (c *ACLAuthMethodInfoCommand) Synopsis() string {
+	return "Fetch info on an existing ACL auth method"
+}
+
+func (c *ACLAuthMethodInfoCommand) Name() string { return "acl auth-method info" }
+
+func (c *ACLAuthMethodInfoCommand) Run(args []string) int {
+	var json bool
+	var tmpl string
+
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
+	flags.Usage = func() { c.Ui.Output(c.Help()) }
+	flags.BoolVar(&json, "json", false, "")
+	flags.StringVar(&tmpl, "t", "", "")
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	// Check that we got exactly one argument
+	args = flags.Args()
+	if l := len(args); l != 1 {
+		c.Ui.Error("This command takes one argument: <name>")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
+	// Get the auth method name
+	methodName := args[0]
+
+	// Get the HTTP client
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+
+	// Fetch info on the auth method
+	method, _, err := client.ACLAuthMethods().Info(methodName, nil)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error fetching info on ACL auth method: %s", err))
+		return 1
+	}
+
+	if json || len(tmpl) > 0 {
+		out, err := Format(json, tmpl, method)
+		if err != nil {
+			c.Ui.Error(err.Error())
+			return 1
+		}
+
+		c.Ui.Output(out)
+		return 0
+	}
+
+	c.Ui.Output(formatKVAuthMethod(method))
+	return 0
+}
+
+// formatKVAuthMethod returns a K/V formatted ACL auth method
+func formatKVAuthMethod(method *api.ACLAuthMethod) string {
+	output := []string{
+		fmt.Sprintf("Name|%s", method.Name),
+		fmt.Sprintf("Type|%s", method.Type),
+		fmt.Sprintf("Token Locality|%s", method.TokenLocality),
+		fmt.Sprintf("Max Token TTL|%s", method.MaxTokenTTL),
+	}
+	if config := method.Config; config != nil {
+		if config.OIDCDiscoveryURL != "" {
+			output = append(output, fmt.Sprintf("OIDC Discovery URL|%s", config.OIDCDiscoveryURL))
+		}
+		output = append(output,
+			fmt.Sprintf("Bound Issuer|%s", config.BoundIssuer),
+			fmt.Sprintf("Bound Audiences|%v", config.BoundAudiences),
+		)
+	}
+	output = append(output,
+		fmt.Sprintf("Create Index|%d", method.CreateIndex),
+		fmt.Sprintf("Modify Index|%d", method.ModifyIndex),
+	)
+	return formatKV(output)
+}
@@ -0,0 +1,53 @@
+package command
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/command/agent"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/mitchellh/cli"
+	"github.com/stretchr/testify/require"
+)
+
+func TestACLAuthMethodInfoCommand(t *testing.T) {
+	require := require.New(t)
+	t.Parallel()
+	config := func(c *agent.Config) {
+		c.ACL.Enabled = true
+	}
+
+	srv, _, url := testServer(t, true, config)
+	state := srv.Agent.Server().State()
+	defer srv.Shutdown()
+
+	// Bootstrap an initial ACL token
+	token := srv.RootToken
+	require.NotNil(token, "failed to bootstrap ACL token")
+
+	method := mock.ACLAuthMethod()
+	require.NoError(state.UpsertACLAuthMethods(1000, []*structs.ACLAuthMethod{method}))
+
+	ui := new(cli.MockUi)
+	cmd := &ACLAuthMethodInfoCommand{Meta: Meta{Ui: ui, flagAddress: url}}
+
+	// Fetch the auth method without a valid token
+	invalidToken := mock.ACLToken()
+	code := cmd.Run([]string{"-address=" + url, "-token=" + invalidToken.SecretID, method.Name})
+	require.Equal(1, code)
+
+	// Fetch the auth method with a valid management token
+	code = cmd.Run([]string{"-address=" + url, "-token=" + token.SecretID, method.Name})
+	require.Equal(0, code)
+
+	out := ui.OutputWriter.String()
+	require.Contains(out, method.Name)
+	require.Contains(out, method.Config.BoundIssuer)
+	require.Contains(out, "[nomad]")
+	ui.OutputWriter.Reset()
+
+	// Fetch json
+	code = cmd.Run([]string{"-address=" + url, "-token=" + token.SecretID, "-json", method.Name})
+	require.Equal(0, code)
+	require.Contains(ui.OutputWriter.String(), "JWTValidationPubKeys")
+}
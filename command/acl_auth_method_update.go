@@ -0,0 +1,119 @@
+package command
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/posener/complete"
+)
+
+type ACLAuthMethodUpdateCommand struct {
+	Meta
+}
+
+func (c *ACLAuthMethodUpdateCommand) Help() string {
+	helpText := `
+Usage: nomad acl auth-method update [options] <name>
+
+  Update is used to update an existing ACL auth method. Requires a management
+  token.
+
+General Options:
+
+  ` + generalOptionsUsage() + `
+
+Update Options:
+
+  -token-locality=""
+    Sets whether the tokens created by a login are "local" to the region or
+    "global" and replicated to all regions.
+
+  -max-token-ttl=""
+    Sets the lifetime of the tokens created by a login.
+
+  -config=""
+    Path to a JSON file containing the configuration of the ACL auth method,
+    which replaces the existing configuration.
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *ACLAuthMethodUpdateCommand) AutocompleteFlags() complete.Flags {
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
+		complete.Flags{
+			"token-locality": complete.PredictSet("local", "global"),
+			"max-token-ttl":  complete.PredictAnything,
+			"config":         complete.PredictFiles("*.json"),
+		})
+}
+
+func (c *ACLAuthMethodUpdateCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (c *ACLAuthMethodUpdateCommand) Synopsis() string {
+	return "Update an existing ACL auth method"
+}
+
+func (c *ACLAuthMethodUpdateCommand) Name() string { return "acl auth-method update" }
+
+func (c *ACLAuthMethodUpdateCommand) Run(args []string) int {
+	var locality, configPath string
+	var maxTokenTTL time.Duration
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
+	flags.Usage = func() { c.Ui.Output(c.Help()) }
+	flags.StringVar(&locality, "token-locality", "", "")
+	flags.DurationVar(&maxTokenTTL, "max-token-ttl", 0, "")
+	flags.StringVar(&configPath, "config", "", "")
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	// Check that we got exactly one argument
+	args = flags.Args()
+	if l := len(args); l != 1 {
+		c.Ui.Error("This command takes one argument: <name>")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
+	methodName := args[0]
+
+	// Get the HTTP client
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+
+	// Get the specified auth method
+	method, _, err := client.ACLAuthMethods().Info(methodName, nil)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error fetching ACL auth method: %s", err))
+		return 1
+	}
+
+	// Update the auth method
+	if locality != "" {
+		method.TokenLocality = locality
+	}
+	if maxTokenTTL != 0 {
+		method.MaxTokenTTL = maxTokenTTL
+	}
+	if configPath != "" {
+		config, err := readAuthMethodConfig(configPath)
+		if err != nil {
+			c.Ui.Error(err.Error())
+			return 1
+		}
+		method.Config = config
+	}
+	if _, err := client.ACLAuthMethods().Upsert(method, nil); err != nil {
+		c.Ui.Error(fmt.Sprintf("Error updating ACL auth method: %s", err))
+		return 1
+	}
+
+	c.Ui.Output(fmt.Sprintf("Successfully updated %s auth method!", methodName))
+	return 0
+}
@@ -0,0 +1,49 @@
+package command
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/command/agent"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/mitchellh/cli"
+	"github.com/stretchr/testify/require"
+)
+
+func TestACLAuthMethodUpdateCommand(t *testing.T) {
+	require := require.New(t)
+	t.Parallel()
+	config := func(c *agent.Config) {
+		c.ACL.Enabled = true
+	}
+
+	srv, _, url := testServer(t, true, config)
+	state := srv.Agent.Server().State()
+	defer srv.Shutdown()
+
+	// Bootstrap an initial ACL token
+	token := srv.RootToken
+	require.NotNil(token, "failed to bootstrap ACL token")
+
+	method := mock.ACLAuthMethod()
+	require.NoError(state.UpsertACLAuthMethods(1000, []*structs.ACLAuthMethod{method}))
+
+	ui := new(cli.MockUi)
+	cmd := &ACLAuthMethodUpdateCommand{Meta: Meta{Ui: ui, flagAddress: url}}
+
+	// Update the auth method without a valid management token
+	invalidToken := mock.ACLToken()
+	code := cmd.Run([]string{"-address=" + url, "-token=" + invalidToken.SecretID, "-token-locality=global", method.Name})
+	require.Equal(1, code)
+
+	// Update the auth method with a valid management token
+	code = cmd.Run([]string{"-address=" + url, "-token=" + token.SecretID, "-token-locality=global", method.Name})
+	require.Equal(0, code)
+	require.Contains(ui.OutputWriter.String(), "Successfully updated "+method.Name+" auth method")
+
+	out, err := state.ACLAuthMethodByName(nil, method.Name)
+	require.NoError(err)
+	require.Equal(structs.ACLAuthMethodTokenLocalityGlobal, out.TokenLocality)
+	require.Equal(method.MaxTokenTTL, out.MaxTokenTTL)
+	require.Equal(method.Config, out.Config)
+}
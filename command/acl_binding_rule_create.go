@@ -0,0 +1,119 @@
+package command
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/posener/complete"
+)
+
+type ACLBindingRuleCreateCommand struct {
+	Meta
+}
+
+func (c *ACLBindingRuleCreateCommand) Help() string {
+	helpText := `
+Usage: nomad acl binding-rule create [options]
+
+  Create is used to create a new ACL binding rule. Requires a management token.
+
+General Options:
+
+  ` + generalOptionsUsage() + `
+
+Create Options:
+
+  -auth-method=""
+    Sets the name of the ACL auth method the binding rule applies to. Required.
+
+  -description=""
+    Sets the human readable description of the ACL binding rule.
+
+  -selector=""
+    Sets the expression the identity of a login must match for the binding
+    rule to apply, such as '"engineering" in list.groups'. Matches every
+    identity if empty.
+
+  -bind-type=""
+    Sets whether the binding rule grants a "policy" or a "role". Required.
+
+  -bind-name=""
+    Sets the name of the policy or role granted. May reference the values of
+    the identity, such as "team-${value.team}". Required.
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *ACLBindingRuleCreateCommand) AutocompleteFlags() complete.Flags {
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
+		complete.Flags{
+			"auth-method": complete.PredictAnything,
+			"description": complete.PredictAnything,
+			"selector":    complete.PredictAnything,
+			"bind-type":   complete.PredictSet("policy", "role"),
+			"bind-name":   complete.PredictAnything,
+		})
+}
+
+func (c *ACLBindingRuleCreateCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (c *ACLBindingRuleCreateCommand) Synopsis() string {
+	return "Create a new ACL binding rule"
+}
+
+func (c *ACLBindingRuleCreateCommand) Name() string { return "acl binding-rule create" }
+
+func (c *ACLBindingRuleCreateCommand) Run(args []string) int {
+	var authMethod, description, selector, bindType, bindName string
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
+	flags.Usage = func() { c.Ui.Output(c.Help()) }
+	flags.StringVar(&authMethod, "auth-method", "", "")
+	flags.StringVar(&description, "description", "", "")
+	flags.StringVar(&selector, "selector", "", "")
+	flags.StringVar(&bindType, "bind-type", "", "")
+	flags.StringVar(&bindName, "bind-name", "", "")
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	// Check that we got no arguments
+	args = flags.Args()
+	if l := len(args); l != 0 {
+		c.Ui.Error("This command takes no arguments")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
+	if authMethod == "" {
+		c.Ui.Error("ACL auth method must be specified using the -auth-method flag")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
+	// Get the HTTP client
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+
+	// Create the binding rule
+	rule := &api.ACLBindingRule{
+		AuthMethod:  authMethod,
+		Description: description,
+		Selector:    selector,
+		BindType:    bindType,
+		BindName:    bindName,
+	}
+	rule, _, err = client.ACLBindingRules().Create(rule, nil)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error creating ACL binding rule: %s", err))
+		return 1
+	}
+
+	c.Ui.Output(formatKVBindingRule(rule))
+	return 0
+}
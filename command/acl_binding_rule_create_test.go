@@ -0,0 +1,62 @@
+package command
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/command/agent"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/mitchellh/cli"
+	"github.com/stretchr/testify/require"
+)
+
+func TestACLBindingRuleCreateCommand(t *testing.T) {
+	require := require.New(t)
+	t.Parallel()
+	config := func(c *agent.Config) {
+		c.ACL.Enabled = true
+	}
+
+	srv, _, url := testServer(t, true, config)
+	state := srv.Agent.Server().State()
+	defer srv.Shutdown()
+
+	// Bootstrap an initial ACL token
+	token := srv.RootToken
+	require.NotNil(token, "failed to bootstrap ACL token")
+
+	method := mock.ACLAuthMethod()
+	require.NoError(state.UpsertACLAuthMethods(1000, []*structs.ACLAuthMethod{method}))
+
+	ui := new(cli.MockUi)
+	cmd := &ACLBindingRuleCreateCommand{Meta: Meta{Ui: ui, flagAddress: url}}
+
+	// The auth method is required
+	code := cmd.Run([]string{"-address=" + url, "-token=" + token.SecretID, "-bind-type=policy", "-bind-name=ops"})
+	require.Equal(1, code)
+
+	// Request to create a binding rule without a valid management token
+	invalidToken := mock.ACLToken()
+	code = cmd.Run([]string{"-address=" + url, "-token=" + invalidToken.SecretID, "-auth-method=" + method.Name, "-bind-type=policy", "-bind-name=ops"})
+	require.Equal(1, code)
+
+	// Request to create a binding rule for an unknown auth method
+	code = cmd.Run([]string{"-address=" + url, "-token=" + token.SecretID, "-auth-method=unknown", "-bind-type=policy", "-bind-name=ops"})
+	require.Equal(1, code)
+	require.Contains(ui.ErrorWriter.String(), "cannot find auth method")
+
+	// Request to create a binding rule with a valid management token
+	code = cmd.Run([]string{"-address=" + url, "-token=" + token.SecretID, "-auth-method=" + method.Name,
+		"-selector=\"ops\" in list.groups", "-bind-type=policy", "-bind-name=ops"})
+	require.Equal(0, code)
+	require.Contains(ui.OutputWriter.String(), method.Name)
+
+	iter, err := state.ACLBindingRulesByAuthMethod(nil, method.Name)
+	require.NoError(err)
+	raw := iter.Next()
+	require.NotNil(raw)
+	rule := raw.(*structs.ACLBindingRule)
+	require.Equal(`"ops" in list.groups`, rule.Selector)
+	require.Equal("ops", rule.BindName)
+	require.Contains(ui.OutputWriter.String(), rule.ID)
+}
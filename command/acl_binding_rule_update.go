@@ -0,0 +1,120 @@
+package command
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/posener/complete"
+)
+
+type ACLBindingRuleUpdateCommand struct {
+	Meta
+}
+
+func (c *ACLBindingRuleUpdateCommand) Help() string {
+	helpText := `
+Usage: nomad acl binding-rule update [options] <id>
+
+  Update is used to update an existing ACL binding rule. Requires a management
+  token.
+
+General Options:
+
+  ` + generalOptionsUsage() + `
+
+Update Options:
+
+  -description=""
+    Sets the human readable description of the ACL binding rule.
+
+  -selector=""
+    Sets the expression the identity of a login must match for the binding
+    rule to apply.
+
+  -bind-type=""
+    Sets whether the binding rule grants a "policy" or a "role".
+
+  -bind-name=""
+    Sets the name of the policy or role granted.
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *ACLBindingRuleUpdateCommand) AutocompleteFlags() complete.Flags {
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
+		complete.Flags{
+			"description": complete.PredictAnything,
+			"selector":    complete.PredictAnything,
+			"bind-type":   complete.PredictSet("policy", "role"),
+			"bind-name":   complete.PredictAnything,
+		})
+}
+
+func (c *ACLBindingRuleUpdateCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (c *ACLBindingRuleUpdateCommand) Synopsis() string {
+	return "Update an existing ACL binding rule"
+}
+
+func (c *ACLBindingRuleUpdateCommand) Name() string { return "acl binding-rule update" }
+
+func (c *ACLBindingRuleUpdateCommand) Run(args []string) int {
+	var description, selector, bindType, bindName string
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
+	flags.Usage = func() { c.Ui.Output(c.Help()) }
+	flags.StringVar(&description, "description", "", "")
+	flags.StringVar(&selector, "selector", "", "")
+	flags.StringVar(&bindType, "bind-type", "", "")
+	flags.StringVar(&bindName, "bind-name", "", "")
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	// Check that we got exactly one argument
+	args = flags.Args()
+	if l := len(args); l != 1 {
+		c.Ui.Error("This command takes one argument: <id>")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
+	ruleID := args[0]
+
+	// Get the HTTP client
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+
+	// Get the specified binding rule
+	rule, _, err := client.ACLBindingRules().Info(ruleID, nil)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error fetching ACL binding rule: %s", err))
+		return 1
+	}
+
+	// Update the binding rule
+	if description != "" {
+		rule.Description = description
+	}
+	if selector != "" {
+		rule.Selector = selector
+	}
+	if bindType != "" {
+		rule.BindType = bindType
+	}
+	if bindName != "" {
+		rule.BindName = bindName
+	}
+	rule, _, err = client.ACLBindingRules().Update(rule, nil)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error updating ACL binding rule: %s", err))
+		return 1
+	}
+
+	c.Ui.Output(formatKVBindingRule(rule))
+	return 0
+}
@@ -3,6 +3,7 @@ package command
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/nomad/api"
 	"github.com/posener/complete"
@@ -98,18 +99,30 @@ func formatKVACLToken(token *api.ACLToken) string {
 		fmt.Sprintf("Global|%v", token.Global),
 	}
 
-	// Special case the policy output
+	// Special case the policy and role output
 	if token.Type == "management" {
-		output = append(output, "Policies|n/a")
+		output = append(output, "Policies|n/a", "Roles|n/a")
 	} else {
-		output = append(output, fmt.Sprintf("Policies|%v", token.Policies))
+		output = append(output,
+			fmt.Sprintf("Policies|%v", token.Policies),
+			fmt.Sprintf("Roles|%v", token.Roles))
 	}
 
 	// Add the generic output
 	output = append(output,
 		fmt.Sprintf("Create Time|%v", token.CreateTime),
+		fmt.Sprintf("Expiry Time|%s", formatACLTokenExpiry(token.ExpirationTime)),
 		fmt.Sprintf("Create Index|%d", token.CreateIndex),
 		fmt.Sprintf("Modify Index|%d", token.ModifyIndex),
 	)
 	return formatKV(output)
 }
+
+// formatACLTokenExpiry returns the expiration time of a token, or "<none>"
+// if it never expires.
+func formatACLTokenExpiry(expiry *time.Time) string {
+	if expiry == nil {
+		return "<none>"
+	}
+	return expiry.String()
+}
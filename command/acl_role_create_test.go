@@ -0,0 +1,57 @@
+package command
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/command/agent"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/mitchellh/cli"
+	"github.com/stretchr/testify/require"
+)
+
+func TestACLRoleCreateCommand(t *testing.T) {
+	require := require.New(t)
+	t.Parallel()
+	config := func(c *agent.Config) {
+		c.ACL.Enabled = true
+	}
+
+	srv, _, url := testServer(t, true, config)
+	state := srv.Agent.Server().State()
+	defer srv.Shutdown()
+
+	// Bootstrap an initial ACL token
+	token := srv.RootToken
+	require.NotNil(token, "failed to bootstrap ACL token")
+
+	policy := mock.ACLPolicy()
+	require.NoError(state.UpsertACLPolicies(1000, []*structs.ACLPolicy{policy}))
+
+	ui := new(cli.MockUi)
+	cmd := &ACLRoleCreateCommand{Meta: Meta{Ui: ui, flagAddress: url}}
+
+	// The role name is required
+	code := cmd.Run([]string{"-address=" + url, "-token=" + token.SecretID, "-policy=" + policy.Name})
+	require.Equal(1, code)
+
+	// Request to create a role without a valid management token
+	invalidToken := mock.ACLToken()
+	code = cmd.Run([]string{"-address=" + url, "-token=" + invalidToken.SecretID, "-name=ops", "-policy=" + policy.Name})
+	require.Equal(1, code)
+
+	// Request to create a role with a valid management token
+	code = cmd.Run([]string{"-address=" + url, "-token=" + token.SecretID, "-name=ops", "-description=operators", "-policy=" + policy.Name})
+	require.Equal(0, code)
+	require.Contains(ui.OutputWriter.String(), "Successfully created ops role")
+
+	role, err := state.ACLRoleByName(nil, "ops")
+	require.NoError(err)
+	require.Equal("operators", role.Description)
+	require.Equal([]string{policy.Name}, role.Policies)
+
+	// Creating the role again fails
+	code = cmd.Run([]string{"-address=" + url, "-token=" + token.SecretID, "-name=ops", "-policy=" + policy.Name})
+	require.Equal(1, code)
+	require.Contains(ui.ErrorWriter.String(), "already exists")
+}
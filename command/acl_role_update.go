@@ -0,0 +1,107 @@
+package command
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/posener/complete"
+)
+
+type ACLRoleUpdateCommand struct {
+	Meta
+}
+
+func (c *ACLRoleUpdateCommand) Help() string {
+	helpText := `
+Usage: nomad acl role update [options] <name>
+
+  Update is used to update an existing ACL role. Requires a management token.
+  Tokens associated with the role are granted its new policies.
+
+General Options:
+
+  ` + generalOptionsUsage() + `
+
+Update Options:
+
+  -description=""
+    Sets the human readable description of the ACL role.
+
+  -policy=""
+    Specifies a policy to associate with the role. Can be specified multiple
+    times and replaces the existing policies of the role.
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *ACLRoleUpdateCommand) AutocompleteFlags() complete.Flags {
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
+		complete.Flags{
+			"description": complete.PredictAnything,
+			"policy":      complete.PredictAnything,
+		})
+}
+
+func (c *ACLRoleUpdateCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (c *ACLRoleUpdateCommand) Synopsis() string {
+	return "Update an existing ACL role"
+}
+
+func (c *ACLRoleUpdateCommand) Name() string { return "acl role update" }
+
+func (c *ACLRoleUpdateCommand) Run(args []string) int {
+	var description string
+	var policies []string
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
+	flags.Usage = func() { c.Ui.Output(c.Help()) }
+	flags.StringVar(&description, "description", "", "")
+	flags.Var((funcVar)(func(s string) error {
+		policies = append(policies, s)
+		return nil
+	}), "policy", "")
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	// Check that we got exactly one argument
+	args = flags.Args()
+	if l := len(args); l != 1 {
+		c.Ui.Error("This command takes one argument: <name>")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
+	roleName := args[0]
+
+	// Get the HTTP client
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+
+	// Get the specified role
+	role, _, err := client.ACLRoles().Info(roleName, nil)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error fetching ACL role: %s", err))
+		return 1
+	}
+
+	// Update the role
+	if description != "" {
+		role.Description = description
+	}
+	if len(policies) != 0 {
+		role.Policies = policies
+	}
+	if _, err := client.ACLRoles().Upsert(role, nil); err != nil {
+		c.Ui.Error(fmt.Sprintf("Error updating ACL role: %s", err))
+		return 1
+	}
+
+	c.Ui.Output(fmt.Sprintf("Successfully updated %s role!", roleName))
+	return 0
+}
@@ -0,0 +1,50 @@
+package command
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/command/agent"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/mitchellh/cli"
+	"github.com/stretchr/testify/require"
+)
+
+func TestACLRoleUpdateCommand(t *testing.T) {
+	require := require.New(t)
+	t.Parallel()
+	config := func(c *agent.Config) {
+		c.ACL.Enabled = true
+	}
+
+	srv, _, url := testServer(t, true, config)
+	state := srv.Agent.Server().State()
+	defer srv.Shutdown()
+
+	// Bootstrap an initial ACL token
+	token := srv.RootToken
+	require.NotNil(token, "failed to bootstrap ACL token")
+
+	p1, p2 := mock.ACLPolicy(), mock.ACLPolicy()
+	require.NoError(state.UpsertACLPolicies(1000, []*structs.ACLPolicy{p1, p2}))
+	role := mock.ACLRole()
+	role.Policies = []string{p1.Name}
+	require.NoError(state.UpsertACLRoles(1001, []*structs.ACLRole{role}))
+
+	ui := new(cli.MockUi)
+	cmd := &ACLRoleUpdateCommand{Meta: Meta{Ui: ui, flagAddress: url}}
+
+	// Request to update a role without a valid management token
+	invalidToken := mock.ACLToken()
+	code := cmd.Run([]string{"-address=" + url, "-token=" + invalidToken.SecretID, "-policy=" + p2.Name, role.Name})
+	require.Equal(1, code)
+
+	// Request to update a role with a valid management token
+	code = cmd.Run([]string{"-address=" + url, "-token=" + token.SecretID, "-policy=" + p2.Name, role.Name})
+	require.Equal(0, code)
+
+	out, err := state.ACLRoleByName(nil, role.Name)
+	require.NoError(err)
+	require.Equal([]string{p2.Name}, out.Policies)
+	require.Equal(role.Description, out.Description)
+}
@@ -1,13 +1,18 @@
 package agent
 
 import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 
+	"github.com/hashicorp/nomad/lib/auth/jwt"
 	"github.com/hashicorp/nomad/nomad/mock"
 	"github.com/hashicorp/nomad/nomad/structs"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestHTTP_ACLPolicyList(t *testing.T) {
@@ -187,6 +192,247 @@ func TestHTTP_ACLPolicyDelete(t *testing.T) {
 	})
 }
 
+func TestHTTP_ACLRoleList(t *testing.T) {
+	t.Parallel()
+	httpACLTest(t, nil, func(s *TestAgent) {
+		r1 := mock.ACLRole()
+		r2 := mock.ACLRole()
+		state := s.Agent.server.State()
+		require.NoError(t, state.UpsertACLRoles(1000, []*structs.ACLRole{r1, r2}))
+
+		// Make the HTTP request
+		req, err := http.NewRequest("GET", "/v1/acl/roles", nil)
+		require.NoError(t, err)
+		respW := httptest.NewRecorder()
+		setToken(req, s.RootToken)
+
+		// Make the request
+		obj, err := s.Server.ACLRolesRequest(respW, req)
+		require.NoError(t, err)
+		require.Equal(t, "1000", respW.HeaderMap.Get("X-Nomad-Index"))
+		require.Len(t, obj.([]*structs.ACLRoleListStub), 2)
+	})
+}
+
+func TestHTTP_ACLRoleSpecific(t *testing.T) {
+	t.Parallel()
+	httpACLTest(t, nil, func(s *TestAgent) {
+		p1 := mock.ACLPolicy()
+		state := s.Agent.server.State()
+		require.NoError(t, state.UpsertACLPolicies(1000, []*structs.ACLPolicy{p1}))
+
+		// Create the role
+		r1 := mock.ACLRole()
+		r1.Policies = []string{p1.Name}
+		req, err := http.NewRequest("PUT", "/v1/acl/role/"+r1.Name, encodeReq(r1))
+		require.NoError(t, err)
+		respW := httptest.NewRecorder()
+		setToken(req, s.RootToken)
+		obj, err := s.Server.ACLRoleSpecificRequest(respW, req)
+		require.NoError(t, err)
+		require.Nil(t, obj)
+		require.NotEmpty(t, respW.HeaderMap.Get("X-Nomad-Index"))
+
+		// Query the role
+		req, err = http.NewRequest("GET", "/v1/acl/role/"+r1.Name, nil)
+		require.NoError(t, err)
+		respW = httptest.NewRecorder()
+		setToken(req, s.RootToken)
+		obj, err = s.Server.ACLRoleSpecificRequest(respW, req)
+		require.NoError(t, err)
+		require.Equal(t, r1.Policies, obj.(*structs.ACLRole).Policies)
+
+		// The name must match the path
+		req, err = http.NewRequest("PUT", "/v1/acl/role/other", encodeReq(r1))
+		require.NoError(t, err)
+		respW = httptest.NewRecorder()
+		setToken(req, s.RootToken)
+		_, err = s.Server.ACLRoleSpecificRequest(respW, req)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "does not match")
+
+		// Delete the role
+		req, err = http.NewRequest("DELETE", "/v1/acl/role/"+r1.Name, nil)
+		require.NoError(t, err)
+		respW = httptest.NewRecorder()
+		setToken(req, s.RootToken)
+		_, err = s.Server.ACLRoleSpecificRequest(respW, req)
+		require.NoError(t, err)
+
+		out, err := state.ACLRoleByName(nil, r1.Name)
+		require.NoError(t, err)
+		require.Nil(t, out)
+	})
+}
+
+func TestHTTP_ACLAuthMethodSpecific(t *testing.T) {
+	t.Parallel()
+	httpACLTest(t, nil, func(s *TestAgent) {
+		state := s.Agent.server.State()
+
+		// Create the auth method
+		m1 := mock.ACLAuthMethod()
+		req, err := http.NewRequest("PUT", "/v1/acl/auth-method/"+m1.Name, encodeReq(m1))
+		require.NoError(t, err)
+		respW := httptest.NewRecorder()
+		setToken(req, s.RootToken)
+		obj, err := s.Server.ACLAuthMethodSpecificRequest(respW, req)
+		require.NoError(t, err)
+		require.Nil(t, obj)
+		require.NotEmpty(t, respW.HeaderMap.Get("X-Nomad-Index"))
+
+		// List the auth methods without a token
+		req, err = http.NewRequest("GET", "/v1/acl/auth-methods", nil)
+		require.NoError(t, err)
+		respW = httptest.NewRecorder()
+		obj, err = s.Server.ACLAuthMethodsRequest(respW, req)
+		require.NoError(t, err)
+		require.Len(t, obj.([]*structs.ACLAuthMethodListStub), 1)
+
+		// Query the auth method
+		req, err = http.NewRequest("GET", "/v1/acl/auth-method/"+m1.Name, nil)
+		require.NoError(t, err)
+		respW = httptest.NewRecorder()
+		setToken(req, s.RootToken)
+		obj, err = s.Server.ACLAuthMethodSpecificRequest(respW, req)
+		require.NoError(t, err)
+		require.Equal(t, m1.Config, obj.(*structs.ACLAuthMethod).Config)
+
+		// The name must match the path
+		req, err = http.NewRequest("PUT", "/v1/acl/auth-method/other", encodeReq(m1))
+		require.NoError(t, err)
+		respW = httptest.NewRecorder()
+		setToken(req, s.RootToken)
+		_, err = s.Server.ACLAuthMethodSpecificRequest(respW, req)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "does not match")
+
+		// Delete the auth method
+		req, err = http.NewRequest("DELETE", "/v1/acl/auth-method/"+m1.Name, nil)
+		require.NoError(t, err)
+		respW = httptest.NewRecorder()
+		setToken(req, s.RootToken)
+		_, err = s.Server.ACLAuthMethodSpecificRequest(respW, req)
+		require.NoError(t, err)
+
+		out, err := state.ACLAuthMethodByName(nil, m1.Name)
+		require.NoError(t, err)
+		require.Nil(t, out)
+	})
+}
+
+func TestHTTP_ACLBindingRuleSpecific(t *testing.T) {
+	t.Parallel()
+	httpACLTest(t, nil, func(s *TestAgent) {
+		state := s.Agent.server.State()
+		m1 := mock.ACLAuthMethod()
+		require.NoError(t, state.UpsertACLAuthMethods(1000, []*structs.ACLAuthMethod{m1}))
+
+		// Create the binding rule
+		r1 := mock.ACLBindingRule()
+		r1.ID = ""
+		r1.AuthMethod = m1.Name
+		req, err := http.NewRequest("PUT", "/v1/acl/binding-rule", encodeReq(r1))
+		require.NoError(t, err)
+		respW := httptest.NewRecorder()
+		setToken(req, s.RootToken)
+		obj, err := s.Server.ACLBindingRuleSpecificRequest(respW, req)
+		require.NoError(t, err)
+		created := obj.(*structs.ACLBindingRule)
+		require.NotEmpty(t, created.ID)
+
+		// List the binding rules
+		req, err = http.NewRequest("GET", "/v1/acl/binding-rules", nil)
+		require.NoError(t, err)
+		respW = httptest.NewRecorder()
+		setToken(req, s.RootToken)
+		obj, err = s.Server.ACLBindingRulesRequest(respW, req)
+		require.NoError(t, err)
+		require.Len(t, obj.([]*structs.ACLBindingRuleListStub), 1)
+
+		// Update the binding rule
+		created.BindName = "updated"
+		req, err = http.NewRequest("PUT", "/v1/acl/binding-rule/"+created.ID, encodeReq(created))
+		require.NoError(t, err)
+		respW = httptest.NewRecorder()
+		setToken(req, s.RootToken)
+		_, err = s.Server.ACLBindingRuleSpecificRequest(respW, req)
+		require.NoError(t, err)
+
+		// Query the binding rule
+		req, err = http.NewRequest("GET", "/v1/acl/binding-rule/"+created.ID, nil)
+		require.NoError(t, err)
+		respW = httptest.NewRecorder()
+		setToken(req, s.RootToken)
+		obj, err = s.Server.ACLBindingRuleSpecificRequest(respW, req)
+		require.NoError(t, err)
+		require.Equal(t, "updated", obj.(*structs.ACLBindingRule).BindName)
+
+		// Delete the binding rule
+		req, err = http.NewRequest("DELETE", "/v1/acl/binding-rule/"+created.ID, nil)
+		require.NoError(t, err)
+		respW = httptest.NewRecorder()
+		setToken(req, s.RootToken)
+		_, err = s.Server.ACLBindingRuleSpecificRequest(respW, req)
+		require.NoError(t, err)
+
+		out, err := state.ACLBindingRuleByID(nil, created.ID)
+		require.NoError(t, err)
+		require.Nil(t, out)
+	})
+}
+
+func TestHTTP_ACLLogin(t *testing.T) {
+	t.Parallel()
+	httpACLTest(t, nil, func(s *TestAgent) {
+		state := s.Agent.server.State()
+
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, err)
+		pubKey, err := jwt.EncodePublicKeyPEM(key.Public())
+		require.NoError(t, err)
+
+		m1 := mock.ACLAuthMethod()
+		m1.Config.JWTValidationPubKeys = []string{pubKey}
+		require.NoError(t, state.UpsertACLAuthMethods(1000, []*structs.ACLAuthMethod{m1}))
+		p1 := mock.ACLPolicy()
+		p1.Name = "engineering"
+		require.NoError(t, state.UpsertACLPolicies(1001, []*structs.ACLPolicy{p1}))
+		r1 := mock.ACLBindingRule()
+		r1.AuthMethod = m1.Name
+		require.NoError(t, state.UpsertACLBindingRules(1002, []*structs.ACLBindingRule{r1}))
+
+		loginToken, err := jwt.Sign(map[string]interface{}{
+			"iss":    m1.Config.BoundIssuer,
+			"aud":    "nomad",
+			"groups": []string{"engineering"},
+		}, "", key)
+		require.NoError(t, err)
+
+		args := structs.ACLLoginRequest{
+			AuthMethodName: m1.Name,
+			LoginToken:     loginToken,
+		}
+		req, err := http.NewRequest("POST", "/v1/acl/login", encodeReq(args))
+		require.NoError(t, err)
+		respW := httptest.NewRecorder()
+		obj, err := s.Server.ACLLoginRequest(respW, req)
+		require.NoError(t, err)
+
+		token := obj.(*structs.ACLToken)
+		require.Equal(t, []string{p1.Name}, token.Policies)
+		require.NotNil(t, token.ExpirationTime)
+		require.NotEmpty(t, respW.HeaderMap.Get("X-Nomad-Index"))
+
+		// Only writes are allowed
+		req, err = http.NewRequest("GET", "/v1/acl/login", nil)
+		require.NoError(t, err)
+		respW = httptest.NewRecorder()
+		_, err = s.Server.ACLLoginRequest(respW, req)
+		require.Error(t, err)
+	})
+}
+
 func TestHTTP_ACLTokenBootstrap(t *testing.T) {
 	t.Parallel()
 	conf := func(c *Config) {
@@ -22,6 +22,7 @@ import (
 	"github.com/hashicorp/nomad/client"
 	clientconfig "github.com/hashicorp/nomad/client/config"
 	"github.com/hashicorp/nomad/client/state"
+	"github.com/hashicorp/nomad/command/agent/audit"
 	"github.com/hashicorp/nomad/command/agent/consul"
 	"github.com/hashicorp/nomad/helper/pluginutils/loader"
 	"github.com/hashicorp/nomad/helper/uuid"
@@ -72,6 +73,10 @@ type Agent struct {
 	// configured to run a server.
 	server *nomad.Server
 
+	// auditor writes audit events for HTTP requests. Nil if audit logging
+	// is disabled.
+	auditor *audit.Auditor
+
 	// pluginLoader is used to load plugins
 	pluginLoader loader.PluginCatalog
 
@@ -102,6 +107,12 @@ func NewAgent(config *Config, logger log.Logger, logOutput io.Writer, inmem *met
 	// Global logger should match internal logger as much as possible
 	golog.SetFlags(golog.LstdFlags | golog.Lmicroseconds)
 
+	auditor, err := audit.NewAuditor(config.Audit, config.DataDir, a.logger)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to initialize audit logging: %v", err)
+	}
+	a.auditor = auditor
+
 	if err := a.setupConsul(config.Consul); err != nil {
 		return nil, fmt.Errorf("Failed to initialize Consul client: %v", err)
 	}
@@ -123,6 +134,16 @@ func NewAgent(config *Config, logger log.Logger, logOutput io.Writer, inmem *met
 	return a, nil
 }
 
+// vaultConfigsByName indexes the configurations of the Vault clusters by
+// name
+func vaultConfigsByName(vaults []*config.VaultConfig) map[string]*config.VaultConfig {
+	configs := make(map[string]*config.VaultConfig, len(vaults))
+	for _, v := range vaults {
+		configs[v.GetName()] = v
+	}
+	return configs
+}
+
 // convertServerConfig takes an agent config and log output and returns a Nomad
 // Config. There may be missing fields that must be set by the agent. To do this
 // call finalizeServerConfig
@@ -189,6 +210,8 @@ func convertServerConfig(agentConfig *Config) (*nomad.Config, error) {
 		conf.EnabledSchedulers = schedulers
 
 	}
+	conf.JobValidationRules = agentConfig.Server.JobValidationRules
+	conf.DeploymentAnalysisAddresses = agentConfig.Server.DeploymentAnalysisAddresses
 	if agentConfig.ACL.Enabled {
 		conf.ACLEnabled = true
 	}
@@ -313,7 +336,7 @@ func convertServerConfig(agentConfig *Config) (*nomad.Config, error) {
 
 	// Add the Consul and Vault configs
 	conf.ConsulConfig = agentConfig.Consul
-	conf.VaultConfig = agentConfig.Vault
+	conf.VaultConfigs = vaultConfigsByName(agentConfig.Vaults)
 
 	// Set the TLS config
 	conf.TLSConfig = agentConfig.TLSConfig
@@ -499,7 +522,7 @@ func convertClientConfig(agentConfig *Config) (*clientconfig.Config, error) {
 	}
 
 	conf.ConsulConfig = agentConfig.Consul
-	conf.VaultConfig = agentConfig.Vault
+	conf.VaultConfigs = vaultConfigsByName(agentConfig.Vaults)
 
 	// Set up Telemetry configuration
 	conf.StatsCollectionInterval = agentConfig.Telemetry.collectionInterval
@@ -884,6 +907,12 @@ func (a *Agent) Shutdown() error {
 		a.logger.Error("shutting down Consul client failed", "error", err)
 	}
 
+	if a.auditor != nil {
+		if err := a.auditor.Close(); err != nil {
+			a.logger.Error("closing audit log failed", "error", err)
+		}
+	}
+
 	a.logger.Info("shutdown complete")
 	a.shutdown = true
 	close(a.shutdownCh)
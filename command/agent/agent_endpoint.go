@@ -83,8 +83,12 @@ func (s *HTTPServer) AgentSelfRequest(resp http.ResponseWriter, req *http.Reques
 		self.Config = ac.(*Config)
 	}
 
-	if self.Config != nil && self.Config.Vault != nil && self.Config.Vault.Token != "" {
-		self.Config.Vault.Token = "<redacted>"
+	if self.Config != nil {
+		for _, v := range self.Config.Vaults {
+			if v.Token != "" {
+				v.Token = "<redacted>"
+			}
+		}
 	}
 
 	return self, nil
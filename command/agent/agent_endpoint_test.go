@@ -46,19 +46,19 @@ func TestHTTP_AgentSelf(t *testing.T) {
 		}
 
 		// Check the Vault config
-		if self.Config.Vault.Token != "" {
+		if self.Config.Vaults[0].Token != "" {
 			t.Fatalf("bad: %#v", self)
 		}
 
 		// Assign a Vault token and require it is redacted.
-		s.Config.Vault.Token = "badc0deb-adc0-deba-dc0d-ebadc0debadc"
+		s.Config.Vaults[0].Token = "badc0deb-adc0-deba-dc0d-ebadc0debadc"
 		respW = httptest.NewRecorder()
 		obj, err = s.Server.AgentSelfRequest(respW, req)
 		if err != nil {
 			t.Fatalf("err: %v", err)
 		}
 		self = obj.(agentSelf)
-		if self.Config.Vault.Token != "<redacted>" {
+		if self.Config.Vaults[0].Token != "<redacted>" {
 			t.Fatalf("bad: %#v", self)
 		}
 	})
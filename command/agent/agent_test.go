@@ -615,7 +615,7 @@ func Test_GetConfig(t *testing.T) {
 		Ports:          &Ports{},
 		Addresses:      &Addresses{},
 		AdvertiseAddrs: &AdvertiseAddrs{},
-		Vault:          &sconfig.VaultConfig{},
+		Vaults:         []*sconfig.VaultConfig{{}},
 		Consul:         &sconfig.ConsulConfig{},
 		Sentinel:       &sconfig.SentinelConfig{},
 	}
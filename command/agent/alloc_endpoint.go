@@ -103,6 +103,13 @@ func (s *HTTPServer) allocGet(allocID string, resp http.ResponseWriter, req *htt
 		alloc = alloc.Copy()
 		alloc.Job.Payload = decoded
 	}
+
+	// The workload identities are only handed to the client running the
+	// allocation
+	if alloc.SignedIdentities != nil {
+		alloc = alloc.Copy()
+		alloc.SignedIdentities = nil
+	}
 	alloc.SetEventDisplayMessages()
 
 	return alloc, nil
@@ -204,6 +204,30 @@ func TestHTTP_AllocQuery(t *testing.T) {
 	})
 }
 
+func TestHTTP_AllocQuery_SignedIdentities(t *testing.T) {
+	t.Parallel()
+	httpTest(t, nil, func(s *TestAgent) {
+		// Directly manipulate the state
+		state := s.Agent.server.State()
+		alloc := mock.Alloc()
+		alloc.SignedIdentities = map[string]string{"web": "header.claims.signature"}
+		require.NoError(t, state.UpsertJobSummary(999, mock.JobSummary(alloc.JobID)))
+		require.NoError(t, state.UpsertAllocs(1000, []*structs.Allocation{alloc}))
+
+		// Make the HTTP request
+		req, err := http.NewRequest("GET", "/v1/allocation/"+alloc.ID, nil)
+		require.NoError(t, err)
+		respW := httptest.NewRecorder()
+		obj, err := s.Server.AllocSpecificRequest(respW, req)
+		require.NoError(t, err)
+
+		// The workload identities are not exposed
+		a := obj.(*structs.Allocation)
+		require.Equal(t, alloc.ID, a.ID)
+		require.Nil(t, a.SignedIdentities)
+	})
+}
+
 func TestHTTP_AllocQuery_Payload(t *testing.T) {
 	t.Parallel()
 	httpTest(t, nil, func(s *TestAgent) {
@@ -0,0 +1,98 @@
+package agent
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/nomad/command/agent/audit"
+	"github.com/hashicorp/nomad/helper/uuid"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// auditRequest tracks the audit events of a single HTTP request
+type auditRequest struct {
+	start   time.Time
+	auth    *audit.Auth
+	request *audit.Request
+}
+
+// auditReceived writes the OperationReceived event for the request. The
+// returned auditRequest is nil if audit logging is disabled. An error is
+// returned if the event could not be written to an enforced sink, in which
+// case the request must not be handled.
+func (s *HTTPServer) auditReceived(req *http.Request, start time.Time) (*auditRequest, error) {
+	auditor := s.agent.auditor
+	if auditor == nil {
+		return nil, nil
+	}
+
+	ar := &auditRequest{
+		start: start,
+		auth:  s.auditAuth(req),
+		request: &audit.Request{
+			ID:         uuid.Generate(),
+			Operation:  req.Method,
+			Endpoint:   req.URL.Path,
+			RemoteAddr: req.RemoteAddr,
+		},
+	}
+	parseNamespace(req, &ar.request.Namespace)
+
+	return ar, auditor.Event(ar.event(audit.OperationReceived, nil))
+}
+
+// auditComplete writes the OperationComplete event for the request with the
+// given response status. An error is returned if the event could not be
+// written to an enforced sink.
+func (s *HTTPServer) auditComplete(ar *auditRequest, code int, errMsg string) error {
+	if ar == nil {
+		return nil
+	}
+
+	return s.agent.auditor.Event(ar.event(audit.OperationComplete, &audit.Response{
+		StatusCode: code,
+		Error:      errMsg,
+		Duration:   time.Since(ar.start),
+	}))
+}
+
+// auditAuth returns the audit description of the token the request was made
+// with. Nothing is resolved if ACLs are disabled or the token is invalid.
+func (s *HTTPServer) auditAuth(req *http.Request) *audit.Auth {
+	var secretID string
+	s.parseToken(req, &secretID)
+
+	var token *structs.ACLToken
+	var err error
+	if srv := s.agent.Server(); srv != nil {
+		token, err = srv.ResolveSecretToken(secretID)
+	} else {
+		token, err = s.agent.Client().ResolveSecretToken(secretID)
+	}
+	if err != nil || token == nil {
+		return &audit.Auth{}
+	}
+
+	return &audit.Auth{
+		AccessorID: token.AccessorID,
+		Name:       token.Name,
+		Type:       token.Type,
+		Policies:   token.Policies,
+		Roles:      token.Roles,
+		Global:     token.Global,
+	}
+}
+
+// event returns a new audit event for the request at the given stage
+func (ar *auditRequest) event(stage audit.Stage, resp *audit.Response) *audit.Event {
+	return &audit.Event{
+		ID:        uuid.Generate(),
+		Type:      audit.EventType,
+		Stage:     stage,
+		Timestamp: time.Now().UTC(),
+		Version:   audit.EventVersion,
+		Auth:      ar.auth,
+		Request:   ar.request,
+		Response:  resp,
+	}
+}
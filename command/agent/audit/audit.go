@@ -0,0 +1,254 @@
+// Package audit implements audit logging of the HTTP requests served by the
+// Nomad agent.
+package audit
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	log "github.com/hashicorp/go-hclog"
+	multierror "github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/nomad/nomad/structs/config"
+	"github.com/ryanuber/go-glob"
+)
+
+const (
+	// EventType is the type of every event written by the auditor.
+	EventType = "audit"
+
+	// EventVersion is the version of the event schema.
+	EventVersion = 1
+)
+
+// Stage is the point in the lifecycle of a request an event was written at.
+type Stage string
+
+const (
+	// OperationReceived events are written before the request is handled.
+	OperationReceived Stage = "OperationReceived"
+
+	// OperationComplete events are written once the request has been
+	// handled and include the response.
+	OperationComplete Stage = "OperationComplete"
+
+	// allStages matches any stage in a filter.
+	allStages = "*"
+)
+
+// Event is a single audit log entry.
+type Event struct {
+	ID        string
+	Type      string
+	Stage     Stage
+	Timestamp time.Time
+	Version   int
+	Auth      *Auth
+	Request   *Request
+	Response  *Response `json:",omitempty"`
+}
+
+// Auth describes the ACL token a request was made with. It is empty if ACLs
+// are disabled or the token could not be resolved.
+type Auth struct {
+	AccessorID string
+	Name       string
+	Type       string
+	Policies   []string
+	Roles      []string
+	Global     bool
+}
+
+// Request describes the HTTP request being audited.
+type Request struct {
+	// ID is shared by all events written for the same request.
+	ID         string
+	Operation  string
+	Endpoint   string
+	Namespace  string
+	RemoteAddr string
+}
+
+// Response describes the outcome of the audited request.
+type Response struct {
+	StatusCode int
+	Error      string `json:",omitempty"`
+
+	// Duration is the time taken to handle the request, in nanoseconds.
+	Duration time.Duration
+}
+
+// Auditor writes audit events to its configured sinks.
+type Auditor struct {
+	sinks   []*sink
+	filters []*config.AuditFilter
+	logger  log.Logger
+}
+
+// sink is a configured destination for audit events.
+type sink struct {
+	name     string
+	enforced bool
+	w        *fileWriter
+}
+
+// NewAuditor returns an auditor for the given config. Sinks without a path
+// write to "audit/audit.log" within the data dir. A nil auditor is returned
+// if audit logging is disabled.
+func NewAuditor(cfg *config.AuditConfig, dataDir string, logger log.Logger) (*Auditor, error) {
+	if !cfg.IsEnabled() {
+		return nil, nil
+	}
+
+	if err := validateConfig(cfg); err != nil {
+		return nil, err
+	}
+
+	a := &Auditor{
+		filters: cfg.Filters,
+		logger:  logger.Named("audit"),
+	}
+	for _, s := range cfg.Sinks {
+		path := s.Path
+		if path == "" {
+			if dataDir == "" {
+				a.Close()
+				return nil, fmt.Errorf("audit sink %q requires a path when no data_dir is set", s.Name)
+			}
+			path = filepath.Join(dataDir, "audit", "audit.log")
+		}
+
+		w, err := newFileWriter(path, int64(s.RotateBytes), s.RotateDuration, s.RotateMaxFiles)
+		if err != nil {
+			a.Close()
+			return nil, fmt.Errorf("failed to open audit sink %q: %v", s.Name, err)
+		}
+		a.sinks = append(a.sinks, &sink{
+			name:     s.Name,
+			enforced: s.DeliveryGuarantee != config.AuditDeliveryBestEffort,
+			w:        w,
+		})
+	}
+	return a, nil
+}
+
+// validateConfig returns an error if the audit config is invalid.
+func validateConfig(cfg *config.AuditConfig) error {
+	var mErr multierror.Error
+	if len(cfg.Sinks) == 0 {
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("at least one audit sink is required"))
+	}
+
+	names := make(map[string]struct{}, len(cfg.Sinks))
+	for _, s := range cfg.Sinks {
+		if _, ok := names[s.Name]; ok {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("duplicate audit sink %q", s.Name))
+		}
+		names[s.Name] = struct{}{}
+
+		if s.Type != config.AuditSinkTypeFile {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("audit sink %q has unsupported type %q", s.Name, s.Type))
+		}
+		if s.Format != "" && s.Format != config.AuditSinkFormatJSON {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("audit sink %q has unsupported format %q", s.Name, s.Format))
+		}
+		switch s.DeliveryGuarantee {
+		case "", config.AuditDeliveryEnforced, config.AuditDeliveryBestEffort:
+		default:
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("audit sink %q has unsupported delivery guarantee %q", s.Name, s.DeliveryGuarantee))
+		}
+		if s.RotateBytes < 0 || s.RotateDuration < 0 || s.RotateMaxFiles < 0 {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("audit sink %q rotation settings must not be negative", s.Name))
+		}
+	}
+
+	for _, f := range cfg.Filters {
+		if f.Type != config.AuditFilterTypeHTTPEvent {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("audit filter %q has unsupported type %q", f.Name, f.Type))
+		}
+		for _, stage := range f.Stages {
+			switch Stage(stage) {
+			case allStages, OperationReceived, OperationComplete:
+			default:
+				mErr.Errors = append(mErr.Errors, fmt.Errorf("audit filter %q has unknown stage %q", f.Name, stage))
+			}
+		}
+	}
+	return mErr.ErrorOrNil()
+}
+
+// Event writes the event to every sink unless it is excluded by a filter.
+// An error is returned if the event could not be written to an enforced
+// sink; failures of best-effort sinks are only logged.
+func (a *Auditor) Event(e *Event) error {
+	if a.filtered(e) {
+		return nil
+	}
+
+	buf, err := json.Marshal(e)
+	if err != nil {
+		return fmt.Errorf("failed to encode audit event: %v", err)
+	}
+	buf = append(buf, '\n')
+
+	var mErr multierror.Error
+	for _, s := range a.sinks {
+		if _, err := s.w.Write(buf); err != nil {
+			if s.enforced {
+				mErr.Errors = append(mErr.Errors, fmt.Errorf("failed to write audit event to sink %q: %v", s.name, err))
+				continue
+			}
+			a.logger.Error("failed to write audit event", "sink", s.name, "error", err)
+		}
+	}
+	return mErr.ErrorOrNil()
+}
+
+// filtered returns true if the event matches any of the filters.
+func (a *Auditor) filtered(e *Event) bool {
+	if e.Request == nil {
+		return false
+	}
+
+	for _, f := range a.filters {
+		if matchAny(f.Endpoints, e.Request.Endpoint, false) &&
+			matchAny(f.Stages, string(e.Stage), false) &&
+			matchAny(f.Operations, e.Request.Operation, true) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchAny returns true if the value matches any of the glob patterns. An
+// empty set of patterns matches everything.
+func matchAny(patterns []string, value string, foldCase bool) bool {
+	if len(patterns) == 0 {
+		return true
+	}
+	if foldCase {
+		value = strings.ToUpper(value)
+	}
+	for _, p := range patterns {
+		if foldCase {
+			p = strings.ToUpper(p)
+		}
+		if glob.Glob(p, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// Close closes all the sinks of the auditor.
+func (a *Auditor) Close() error {
+	var mErr multierror.Error
+	for _, s := range a.sinks {
+		if err := s.w.Close(); err != nil {
+			mErr.Errors = append(mErr.Errors, err)
+		}
+	}
+	return mErr.ErrorOrNil()
+}
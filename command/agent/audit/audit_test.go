@@ -0,0 +1,221 @@
+package audit
+
+import (
+	"bufio"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/helper"
+	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/hashicorp/nomad/helper/uuid"
+	"github.com/hashicorp/nomad/nomad/structs/config"
+	"github.com/stretchr/testify/require"
+)
+
+func testEvent(stage Stage, method, endpoint string) *Event {
+	return &Event{
+		ID:        uuid.Generate(),
+		Type:      EventType,
+		Stage:     stage,
+		Timestamp: time.Now(),
+		Version:   EventVersion,
+		Auth: &Auth{
+			AccessorID: uuid.Generate(),
+		},
+		Request: &Request{
+			ID:        uuid.Generate(),
+			Operation: method,
+			Endpoint:  endpoint,
+			Namespace: "default",
+		},
+	}
+}
+
+func readEvents(t *testing.T, path string) []*Event {
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+
+	var out []*Event
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var e Event
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &e))
+		out = append(out, &e)
+	}
+	require.NoError(t, scanner.Err())
+	return out
+}
+
+func TestAuditor_Disabled(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	a, err := NewAuditor(config.DefaultAuditConfig(), "", testlog.HCLogger(t))
+	require.NoError(err)
+	require.Nil(a)
+
+	a, err = NewAuditor(nil, "", testlog.HCLogger(t))
+	require.NoError(err)
+	require.Nil(a)
+}
+
+func TestAuditor_InvalidConfig(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		Name   string
+		Config *config.AuditConfig
+		Err    string
+	}{
+		{
+			Name:   "no sinks",
+			Config: &config.AuditConfig{Enabled: helper.BoolToPtr(true)},
+			Err:    "at least one audit sink",
+		},
+		{
+			Name: "bad sink type",
+			Config: &config.AuditConfig{
+				Enabled: helper.BoolToPtr(true),
+				Sinks:   []*config.AuditSink{{Name: "foo", Type: "syslog"}},
+			},
+			Err: "unsupported type",
+		},
+		{
+			Name: "bad delivery guarantee",
+			Config: &config.AuditConfig{
+				Enabled: helper.BoolToPtr(true),
+				Sinks:   []*config.AuditSink{{Name: "foo", Type: "file", DeliveryGuarantee: "sometimes"}},
+			},
+			Err: "unsupported delivery guarantee",
+		},
+		{
+			Name: "bad filter stage",
+			Config: &config.AuditConfig{
+				Enabled: helper.BoolToPtr(true),
+				Sinks:   []*config.AuditSink{{Name: "foo", Type: "file"}},
+				Filters: []*config.AuditFilter{{Name: "bar", Type: "HTTPEvent", Stages: []string{"Never"}}},
+			},
+			Err: "unknown stage",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			dir, err := ioutil.TempDir("", "nomadtest-audit")
+			require.NoError(t, err)
+			defer os.RemoveAll(dir)
+
+			_, err = NewAuditor(tc.Config, dir, testlog.HCLogger(t))
+			require.Error(t, err)
+			require.Contains(t, err.Error(), tc.Err)
+		})
+	}
+}
+
+func TestAuditor_Event(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	dir, err := ioutil.TempDir("", "nomadtest-audit")
+	require.NoError(err)
+	defer os.RemoveAll(dir)
+
+	cfg := &config.AuditConfig{
+		Enabled: helper.BoolToPtr(true),
+		Sinks: []*config.AuditSink{
+			{
+				Name:   "file",
+				Type:   config.AuditSinkTypeFile,
+				Format: config.AuditSinkFormatJSON,
+			},
+		},
+		Filters: []*config.AuditFilter{
+			{
+				Name:       "metrics",
+				Type:       config.AuditFilterTypeHTTPEvent,
+				Endpoints:  []string{"/v1/metrics"},
+				Stages:     []string{"*"},
+				Operations: []string{"*"},
+			},
+			{
+				Name:       "reads",
+				Type:       config.AuditFilterTypeHTTPEvent,
+				Endpoints:  []string{"/v1/job/*"},
+				Stages:     []string{string(OperationReceived)},
+				Operations: []string{"get"},
+			},
+		},
+	}
+	a, err := NewAuditor(cfg, dir, testlog.HCLogger(t))
+	require.NoError(err)
+	require.NotNil(a)
+	defer a.Close()
+
+	e1 := testEvent(OperationReceived, "GET", "/v1/metrics")
+	e2 := testEvent(OperationReceived, "GET", "/v1/job/example")
+	e3 := testEvent(OperationComplete, "GET", "/v1/job/example")
+	e3.Response = &Response{StatusCode: 200, Duration: time.Second}
+	e4 := testEvent(OperationReceived, "POST", "/v1/job/example")
+	for _, e := range []*Event{e1, e2, e3, e4} {
+		require.NoError(a.Event(e))
+	}
+
+	// Only the events not matched by a filter are written
+	events := readEvents(t, filepath.Join(dir, "audit", "audit.log"))
+	require.Len(events, 2)
+	require.Equal(e3.ID, events[0].ID)
+	require.Equal(e3.Auth.AccessorID, events[0].Auth.AccessorID)
+	require.Equal(OperationComplete, events[0].Stage)
+	require.Equal(200, events[0].Response.StatusCode)
+	require.Equal(time.Second, events[0].Response.Duration)
+	require.Equal(e4.ID, events[1].ID)
+	require.Equal("POST", events[1].Request.Operation)
+	require.Nil(events[1].Response)
+}
+
+func TestAuditor_DeliveryGuarantee(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	dir, err := ioutil.TempDir("", "nomadtest-audit")
+	require.NoError(err)
+	defer os.RemoveAll(dir)
+
+	cfg := &config.AuditConfig{
+		Enabled: helper.BoolToPtr(true),
+		Sinks: []*config.AuditSink{
+			{
+				Name:              "enforced",
+				Type:              config.AuditSinkTypeFile,
+				DeliveryGuarantee: config.AuditDeliveryEnforced,
+				Path:              filepath.Join(dir, "enforced", "audit.log"),
+			},
+			{
+				Name:              "best-effort",
+				Type:              config.AuditSinkTypeFile,
+				DeliveryGuarantee: config.AuditDeliveryBestEffort,
+				Path:              filepath.Join(dir, "best-effort", "audit.log"),
+			},
+		},
+	}
+	a, err := NewAuditor(cfg, "", testlog.HCLogger(t))
+	require.NoError(err)
+	defer a.Close()
+
+	// Make the best-effort sink fail its writes
+	require.NoError(a.sinks[1].w.Close())
+	require.NoError(os.RemoveAll(filepath.Join(dir, "best-effort")))
+	require.NoError(a.Event(testEvent(OperationReceived, "GET", "/v1/jobs")))
+
+	// Make the enforced sink fail its writes
+	require.NoError(a.sinks[0].w.Close())
+	require.NoError(os.RemoveAll(filepath.Join(dir, "enforced")))
+	err = a.Event(testEvent(OperationReceived, "GET", "/v1/jobs"))
+	require.Error(err)
+	require.Contains(err.Error(), `sink "enforced"`)
+}
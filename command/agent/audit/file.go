@@ -0,0 +1,161 @@
+package audit
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// fileWriter is a synchronous writer that appends to a file and rotates it
+// once it grows too large or too old. Rotated files are renamed to
+// "<name>-<unix nanos><ext>" next to the active file.
+type fileWriter struct {
+	path           string
+	rotateBytes    int64
+	rotateDuration time.Duration
+	maxFiles       int
+
+	// now is overridden in tests
+	now func() time.Time
+
+	f      *os.File
+	size   int64
+	opened time.Time
+	l      sync.Mutex
+}
+
+func newFileWriter(path string, rotateBytes int64, rotateDuration time.Duration, maxFiles int) (*fileWriter, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return nil, err
+	}
+
+	w := &fileWriter{
+		path:           path,
+		rotateBytes:    rotateBytes,
+		rotateDuration: rotateDuration,
+		maxFiles:       maxFiles,
+		now:            time.Now,
+	}
+	if err := w.open(); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+// open opens the active file for appending.
+func (w *fileWriter) open() error {
+	f, err := os.OpenFile(w.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return err
+	}
+	fi, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return err
+	}
+
+	w.f = f
+	w.size = fi.Size()
+	w.opened = w.now()
+	return nil
+}
+
+// Write writes p to the active file, rotating it first if required. Each
+// call is written in full or returns an error.
+func (w *fileWriter) Write(p []byte) (int, error) {
+	w.l.Lock()
+	defer w.l.Unlock()
+
+	if w.f == nil {
+		if err := w.open(); err != nil {
+			return 0, err
+		}
+	}
+
+	if w.shouldRotate(int64(len(p))) {
+		if err := w.rotate(); err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := w.f.Write(p)
+	w.size += int64(n)
+	return n, err
+}
+
+// shouldRotate returns whether the active file has to be rotated before n
+// more bytes are written to it.
+func (w *fileWriter) shouldRotate(n int64) bool {
+	if w.size == 0 {
+		return false
+	}
+	if w.rotateBytes > 0 && w.size+n > w.rotateBytes {
+		return true
+	}
+	if w.rotateDuration > 0 && w.now().Sub(w.opened) >= w.rotateDuration {
+		return true
+	}
+	return false
+}
+
+// rotate renames the active file, opens a new one and removes the oldest
+// rotated files past the limit.
+func (w *fileWriter) rotate() error {
+	if err := w.f.Close(); err != nil {
+		return err
+	}
+	w.f = nil
+
+	ext := filepath.Ext(w.path)
+	base := strings.TrimSuffix(w.path, ext)
+	rotated := fmt.Sprintf("%s-%d%s", base, w.now().UnixNano(), ext)
+	if err := os.Rename(w.path, rotated); err != nil {
+		return err
+	}
+
+	if err := w.open(); err != nil {
+		return err
+	}
+	return w.prune(base, ext)
+}
+
+// prune removes the oldest rotated files so at most maxFiles are kept.
+func (w *fileWriter) prune(base, ext string) error {
+	if w.maxFiles <= 0 {
+		return nil
+	}
+
+	matches, err := filepath.Glob(base + "-*" + ext)
+	if err != nil {
+		return err
+	}
+	if len(matches) <= w.maxFiles {
+		return nil
+	}
+
+	// The timestamps have a fixed width so lexical order is creation order
+	sort.Strings(matches)
+	for _, m := range matches[:len(matches)-w.maxFiles] {
+		if err := os.Remove(m); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Close closes the active file.
+func (w *fileWriter) Close() error {
+	w.l.Lock()
+	defer w.l.Unlock()
+
+	if w.f == nil {
+		return nil
+	}
+	err := w.f.Close()
+	w.f = nil
+	return err
+}
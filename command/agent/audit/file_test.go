@@ -0,0 +1,95 @@
+package audit
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileWriter_RotateBytes(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	dir, err := ioutil.TempDir("", "nomadtest-audit")
+	require.NoError(err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "audit.log")
+	w, err := newFileWriter(path, 10, 0, 2)
+	require.NoError(err)
+	defer w.Close()
+
+	now := time.Now()
+	w.now = func() time.Time {
+		now = now.Add(time.Second)
+		return now
+	}
+
+	// Each write after the first exceeds the limit and rotates the file
+	for _, line := range []string{"aaaaaaaa\n", "bbbbbbbb\n", "cccccccc\n", "dddddddd\n"} {
+		_, err := w.Write([]byte(line))
+		require.NoError(err)
+	}
+
+	contents, err := ioutil.ReadFile(path)
+	require.NoError(err)
+	require.Equal("dddddddd\n", string(contents))
+
+	// Only the two most recent rotated files are kept
+	rotated, err := filepath.Glob(filepath.Join(dir, "audit-*.log"))
+	require.NoError(err)
+	require.Len(rotated, 2)
+
+	contents, err = ioutil.ReadFile(rotated[0])
+	require.NoError(err)
+	require.Equal("bbbbbbbb\n", string(contents))
+	contents, err = ioutil.ReadFile(rotated[1])
+	require.NoError(err)
+	require.Equal("cccccccc\n", string(contents))
+}
+
+func TestFileWriter_RotateDuration(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	dir, err := ioutil.TempDir("", "nomadtest-audit")
+	require.NoError(err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "audit.log")
+	w, err := newFileWriter(path, 0, time.Hour, 0)
+	require.NoError(err)
+	defer w.Close()
+
+	now := time.Now()
+	w.now = func() time.Time { return now }
+
+	_, err = w.Write([]byte("first\n"))
+	require.NoError(err)
+	_, err = w.Write([]byte("second\n"))
+	require.NoError(err)
+
+	rotated, err := filepath.Glob(filepath.Join(dir, "audit-*.log"))
+	require.NoError(err)
+	require.Empty(rotated)
+
+	// Writing after the rotation duration has elapsed rotates the file
+	now = now.Add(2 * time.Hour)
+	_, err = w.Write([]byte("third\n"))
+	require.NoError(err)
+
+	rotated, err = filepath.Glob(filepath.Join(dir, "audit-*.log"))
+	require.NoError(err)
+	require.Len(rotated, 1)
+
+	contents, err := ioutil.ReadFile(rotated[0])
+	require.NoError(err)
+	require.Equal("first\nsecond\n", string(contents))
+	contents, err = ioutil.ReadFile(path)
+	require.NoError(err)
+	require.Equal("third\n", string(contents))
+}
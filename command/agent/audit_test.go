@@ -0,0 +1,135 @@
+package agent
+
+import (
+	"bufio"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/nomad/command/agent/audit"
+	"github.com/hashicorp/nomad/helper"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/nomad/structs/config"
+	"github.com/stretchr/testify/require"
+)
+
+func auditTestConfig(c *Config) {
+	c.Audit = &config.AuditConfig{
+		Enabled: helper.BoolToPtr(true),
+		Sinks: []*config.AuditSink{
+			{
+				Name:              "file",
+				Type:              config.AuditSinkTypeFile,
+				DeliveryGuarantee: config.AuditDeliveryEnforced,
+			},
+		},
+		Filters: []*config.AuditFilter{
+			{
+				Name:      "metrics",
+				Type:      config.AuditFilterTypeHTTPEvent,
+				Endpoints: []string{"/v1/metrics"},
+			},
+		},
+	}
+}
+
+func readAuditEvents(t *testing.T, path string) []*audit.Event {
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+
+	var out []*audit.Event
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var e audit.Event
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &e))
+		out = append(out, &e)
+	}
+	require.NoError(t, scanner.Err())
+	return out
+}
+
+func TestHTTP_Audit(t *testing.T) {
+	t.Parallel()
+	httpACLTest(t, auditTestConfig, func(s *TestAgent) {
+		require := require.New(t)
+
+		ok := func(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+			return map[string]string{"foo": "bar"}, nil
+		}
+		denied := func(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+			return nil, structs.ErrPermissionDenied
+		}
+
+		req, err := http.NewRequest("POST", "/v1/job/example?namespace=prod", nil)
+		require.NoError(err)
+		setToken(req, s.RootToken)
+		resp := httptest.NewRecorder()
+		s.Server.wrap(ok)(resp, req)
+		require.Equal(200, resp.Code)
+
+		// Filtered endpoints are not audited
+		req, err = http.NewRequest("GET", "/v1/metrics", nil)
+		require.NoError(err)
+		resp = httptest.NewRecorder()
+		s.Server.wrap(ok)(resp, req)
+		require.Equal(200, resp.Code)
+
+		req, err = http.NewRequest("GET", "/v1/jobs", nil)
+		require.NoError(err)
+		resp = httptest.NewRecorder()
+		s.Server.wrap(denied)(resp, req)
+		require.Equal(403, resp.Code)
+
+		events := readAuditEvents(t, filepath.Join(s.DataDir, "audit", "audit.log"))
+		require.Len(events, 4)
+
+		received, complete := events[0], events[1]
+		require.Equal(audit.OperationReceived, received.Stage)
+		require.Equal(audit.OperationComplete, complete.Stage)
+		require.NotEqual(received.ID, complete.ID)
+		require.Equal(received.Request.ID, complete.Request.ID)
+		require.Equal(s.RootToken.AccessorID, complete.Auth.AccessorID)
+		require.Equal("POST", complete.Request.Operation)
+		require.Equal("/v1/job/example", complete.Request.Endpoint)
+		require.Equal("prod", complete.Request.Namespace)
+		require.Nil(received.Response)
+		require.Equal(200, complete.Response.StatusCode)
+		require.NotZero(complete.Response.Duration)
+
+		// Anonymous requests are audited with the anonymous token
+		complete = events[3]
+		require.Equal(structs.AnonymousACLToken.AccessorID, complete.Auth.AccessorID)
+		require.Equal("default", complete.Request.Namespace)
+		require.Equal(403, complete.Response.StatusCode)
+		require.Equal(structs.ErrPermissionDenied.Error(), complete.Response.Error)
+	})
+}
+
+func TestHTTP_Audit_Enforced(t *testing.T) {
+	t.Parallel()
+	httpACLTest(t, auditTestConfig, func(s *TestAgent) {
+		require := require.New(t)
+
+		// Make writes to the sink fail
+		require.NoError(s.Agent.auditor.Close())
+		require.NoError(os.RemoveAll(filepath.Join(s.DataDir, "audit")))
+
+		called := false
+		handler := func(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+			called = true
+			return nil, nil
+		}
+
+		req, err := http.NewRequest("POST", "/v1/job/example", nil)
+		require.NoError(err)
+		setToken(req, s.RootToken)
+		resp := httptest.NewRecorder()
+		s.Server.wrap(handler)(resp, req)
+		require.Equal(500, resp.Code)
+		require.False(called)
+	})
+}
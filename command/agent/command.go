@@ -69,10 +69,13 @@ func (c *Command) readConfig() *Config {
 		Server: &ServerConfig{
 			ServerJoin: &ServerJoin{},
 		},
-		Vault: &config.VaultConfig{},
-		ACL:   &ACLConfig{},
+		Vaults: []*config.VaultConfig{{}},
+		ACL:    &ACLConfig{},
 	}
 
+	// The Vault flags configure the default cluster
+	defaultVault := cmdConfig.Vaults[0]
+
 	flags := flag.NewFlagSet("agent", flag.ContinueOnError)
 	flags.Usage = func() { c.Ui.Error(c.Help()) }
 
@@ -154,26 +157,26 @@ func (c *Command) readConfig() *Config {
 
 	// Vault options
 	flags.Var((flaghelper.FuncBoolVar)(func(b bool) error {
-		cmdConfig.Vault.Enabled = &b
+		defaultVault.Enabled = &b
 		return nil
 	}), "vault-enabled", "")
 	flags.Var((flaghelper.FuncBoolVar)(func(b bool) error {
-		cmdConfig.Vault.AllowUnauthenticated = &b
+		defaultVault.AllowUnauthenticated = &b
 		return nil
 	}), "vault-allow-unauthenticated", "")
-	flags.StringVar(&cmdConfig.Vault.Token, "vault-token", "", "")
-	flags.StringVar(&cmdConfig.Vault.Addr, "vault-address", "", "")
-	flags.StringVar(&cmdConfig.Vault.Namespace, "vault-namespace", "", "")
-	flags.StringVar(&cmdConfig.Vault.Role, "vault-create-from-role", "", "")
-	flags.StringVar(&cmdConfig.Vault.TLSCaFile, "vault-ca-file", "", "")
-	flags.StringVar(&cmdConfig.Vault.TLSCaPath, "vault-ca-path", "", "")
-	flags.StringVar(&cmdConfig.Vault.TLSCertFile, "vault-cert-file", "", "")
-	flags.StringVar(&cmdConfig.Vault.TLSKeyFile, "vault-key-file", "", "")
+	flags.StringVar(&defaultVault.Token, "vault-token", "", "")
+	flags.StringVar(&defaultVault.Addr, "vault-address", "", "")
+	flags.StringVar(&defaultVault.Namespace, "vault-namespace", "", "")
+	flags.StringVar(&defaultVault.Role, "vault-create-from-role", "", "")
+	flags.StringVar(&defaultVault.TLSCaFile, "vault-ca-file", "", "")
+	flags.StringVar(&defaultVault.TLSCaPath, "vault-ca-path", "", "")
+	flags.StringVar(&defaultVault.TLSCertFile, "vault-cert-file", "", "")
+	flags.StringVar(&defaultVault.TLSKeyFile, "vault-key-file", "", "")
 	flags.Var((flaghelper.FuncBoolVar)(func(b bool) error {
-		cmdConfig.Vault.TLSSkipVerify = &b
+		defaultVault.TLSSkipVerify = &b
 		return nil
 	}), "vault-tls-skip-verify", "")
-	flags.StringVar(&cmdConfig.Vault.TLSServerName, "vault-tls-server-name", "", "")
+	flags.StringVar(&defaultVault.TLSServerName, "vault-tls-server-name", "", "")
 
 	// ACL options
 	flags.BoolVar(&cmdConfig.ACL.Enabled, "acl-enabled", false, "")
@@ -254,14 +257,15 @@ func (c *Command) readConfig() *Config {
 		return nil
 	}
 
-	// Check to see if we should read the Vault token from the environment
-	if config.Vault.Token == "" {
-		config.Vault.Token = os.Getenv("VAULT_TOKEN")
-	}
-
-	// Check to see if we should read the Vault namespace from the environment
-	if config.Vault.Namespace == "" {
-		config.Vault.Namespace = os.Getenv("VAULT_NAMESPACE")
+	// Check to see if we should read the Vault token and namespace of the
+	// default cluster from the environment
+	if vaultConfig := config.GetDefaultVault(); vaultConfig != nil {
+		if vaultConfig.Token == "" {
+			vaultConfig.Token = os.Getenv("VAULT_TOKEN")
+		}
+		if vaultConfig.Namespace == "" {
+			vaultConfig.Namespace = os.Getenv("VAULT_NAMESPACE")
+		}
 	}
 
 	// Default the plugin directory to be under that of the data directory if it
@@ -84,6 +84,9 @@ type Config struct {
 	// ACL has our acl related settings
 	ACL *ACLConfig `hcl:"acl"`
 
+	// Audit has our audit logging related settings
+	Audit *config.AuditConfig `hcl:"audit"`
+
 	// Telemetry is used to configure sending telemetry
 	Telemetry *Telemetry `hcl:"telemetry"`
 
@@ -113,9 +116,9 @@ type Config struct {
 	// discover the current Nomad servers.
 	Consul *config.ConsulConfig `hcl:"consul"`
 
-	// Vault contains the configuration for the Vault Agent and
-	// parameters necessary to derive tokens.
-	Vault *config.VaultConfig `hcl:"vault"`
+	// Vaults contains the configuration of the Vault clusters and the
+	// parameters necessary to derive tokens, one per named vault block.
+	Vaults []*config.VaultConfig `hcl:"vault"`
 
 	// NomadConfig is used to override the default config.
 	// This is largely used for testing purposes.
@@ -388,6 +391,14 @@ type ServerConfig struct {
 	// ServerJoin contains information that is used to attempt to join servers
 	ServerJoin *ServerJoin `hcl:"server_join"`
 
+	// JobValidationRules are the glob patterns of the files declaring the
+	// rules jobs are validated against.
+	JobValidationRules []string `hcl:"job_validation_rules"`
+
+	// DeploymentAnalysisAddresses are the addresses the metric analyses of
+	// deployments are allowed to query.
+	DeploymentAnalysisAddresses []string `hcl:"deployment_analysis_addresses"`
+
 	// ExtraKeysHCL is used by hcl to surface unexpected keys
 	ExtraKeysHCL []string `hcl:",unusedKeys" json:"-"`
 }
@@ -673,7 +684,7 @@ func DefaultConfig() *Config {
 		Addresses:      &Addresses{},
 		AdvertiseAddrs: &AdvertiseAddrs{},
 		Consul:         config.DefaultConsulConfig(),
-		Vault:          config.DefaultVaultConfig(),
+		Vaults:         []*config.VaultConfig{config.DefaultVaultConfig()},
 		Client: &ClientConfig{
 			Enabled:               false,
 			MaxKillTimeout:        "30s",
@@ -706,6 +717,7 @@ func DefaultConfig() *Config {
 			TokenTTL:  30 * time.Second,
 			PolicyTTL: 30 * time.Second,
 		},
+		Audit:          config.DefaultAuditConfig(),
 		SyslogFacility: "LOCAL0",
 		Telemetry: &Telemetry{
 			CollectionInterval: "1s",
@@ -721,6 +733,17 @@ func DefaultConfig() *Config {
 
 // Listener can be used to get a new listener using a custom bind address.
 // If the bind provided address is empty, the BindAddr is used instead.
+// GetDefaultVault returns the configuration of the default Vault cluster,
+// which is nil if it is not configured.
+func (c *Config) GetDefaultVault() *config.VaultConfig {
+	for _, v := range c.Vaults {
+		if v.GetName() == config.VaultDefaultCluster {
+			return v
+		}
+	}
+	return nil
+}
+
 func (c *Config) Listener(proto, addr string, port int) (net.Listener, error) {
 	if addr == "" {
 		addr = c.BindAddr
@@ -833,6 +856,13 @@ func (c *Config) Merge(b *Config) *Config {
 		result.ACL = result.ACL.Merge(b.ACL)
 	}
 
+	// Apply the audit config
+	if result.Audit == nil && b.Audit != nil {
+		result.Audit = b.Audit.Copy()
+	} else if b.Audit != nil {
+		result.Audit = result.Audit.Merge(b.Audit)
+	}
+
 	// Apply the ports config
 	if result.Ports == nil && b.Ports != nil {
 		ports := *b.Ports
@@ -864,12 +894,9 @@ func (c *Config) Merge(b *Config) *Config {
 		result.Consul = result.Consul.Merge(b.Consul)
 	}
 
-	// Apply the Vault Configuration
-	if result.Vault == nil && b.Vault != nil {
-		vaultConfig := *b.Vault
-		result.Vault = &vaultConfig
-	} else if b.Vault != nil {
-		result.Vault = result.Vault.Merge(b.Vault)
+	// Apply the Vault Configurations
+	if len(b.Vaults) != 0 {
+		result.Vaults = config.VaultConfigSetMerge(result.Vaults, b.Vaults)
 	}
 
 	// Apply the sentinel config
@@ -1169,6 +1196,12 @@ func (a *ServerConfig) Merge(b *ServerConfig) *ServerConfig {
 	// Add the schedulers
 	result.EnabledSchedulers = append(result.EnabledSchedulers, b.EnabledSchedulers...)
 
+	// Add the job validation rules
+	result.JobValidationRules = append(result.JobValidationRules, b.JobValidationRules...)
+
+	// Add the deployment analysis addresses
+	result.DeploymentAnalysisAddresses = append(result.DeploymentAnalysisAddresses, b.DeploymentAnalysisAddresses...)
+
 	// Copy the start join addresses
 	result.StartJoin = make([]string, 0, len(a.StartJoin)+len(b.StartJoin))
 	result.StartJoin = append(result.StartJoin, a.StartJoin...)
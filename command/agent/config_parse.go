@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/hashicorp/hcl"
+	"github.com/hashicorp/hcl/hcl/ast"
 	"github.com/hashicorp/nomad/nomad/structs/config"
 )
 
@@ -39,7 +40,6 @@ func ParseConfigFile(path string) (*Config, error) {
 		Consul:    config.DefaultConsulConfig(),
 		Autopilot: config.DefaultAutopilotConfig(),
 		Telemetry: &Telemetry{},
-		Vault:     config.DefaultVaultConfig(),
 	}
 
 	err = hcl.Decode(c, buf.String())
@@ -47,6 +47,13 @@ func ParseConfigFile(path string) (*Config, error) {
 		return nil, err
 	}
 
+	// HCL splits the keys of repeated vault blocks into separate configs, so
+	// the blocks are decoded one at a time
+	c.Vaults, err = parseVaults(buf.Bytes())
+	if err != nil {
+		return nil, err
+	}
+
 	// convert strings to time.Durations
 	err = durations([]td{
 		{"gc_interval", &c.Client.GCInterval, &c.Client.GCIntervalHCL},
@@ -66,6 +73,16 @@ func ParseConfigFile(path string) (*Config, error) {
 		return nil, err
 	}
 
+	if c.Audit != nil {
+		var sinks []td
+		for _, s := range c.Audit.Sinks {
+			sinks = append(sinks, td{"audit.sink." + s.Name + ".rotate_duration", &s.RotateDuration, &s.RotateDurationHCL})
+		}
+		if err := durations(sinks); err != nil {
+			return nil, err
+		}
+	}
+
 	// report unexpected keys
 	err = extraKeys(c)
 	if err != nil {
@@ -99,6 +116,50 @@ func durations(xs []td) error {
 	return nil
 }
 
+// parseVaults decodes the vault blocks of the config. Vault clusters start
+// from the default configuration, and are named after the default cluster
+// unless a name is given. The blocks of the same cluster are merged.
+func parseVaults(raw []byte) ([]*config.VaultConfig, error) {
+	root, err := hcl.ParseBytes(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	list, ok := root.Node.(*ast.ObjectList)
+	if !ok {
+		return nil, fmt.Errorf("error parsing: root should be an object")
+	}
+
+	var blocks []ast.Node
+	for _, item := range list.Filter("vault").Items {
+		if l, ok := item.Val.(*ast.ListType); ok {
+			blocks = append(blocks, l.List...)
+			continue
+		}
+		blocks = append(blocks, item.Val)
+	}
+
+	var vaults []*config.VaultConfig
+	for _, block := range blocks {
+		var v config.VaultConfig
+		if err := hcl.DecodeObject(&v, block); err != nil {
+			return nil, err
+		}
+		v.Name = v.GetName()
+		vaults = config.VaultConfigSetMerge(vaults, []*config.VaultConfig{&v})
+	}
+
+	out := []*config.VaultConfig{config.DefaultVaultConfig()}
+	for _, v := range vaults {
+		if v.Name == config.VaultDefaultCluster {
+			out[0] = out[0].Merge(v)
+			continue
+		}
+		out = append(out, config.DefaultVaultConfig().Merge(v))
+	}
+	return out, nil
+}
+
 // removeEqualFold removes the first string that EqualFold matches
 func removeEqualFold(xs *[]string, search string) {
 	sl := *xs
@@ -124,6 +185,10 @@ func extraKeys(c *Config) error {
 		removeEqualFold(&c.ExtraKeysHCL, "http_api_response_headers")
 	}
 
+	for range c.Vaults {
+		removeEqualFold(&c.ExtraKeysHCL, "vault")
+	}
+
 	for _, p := range c.Plugins {
 		removeEqualFold(&c.ExtraKeysHCL, p.Name)
 		removeEqualFold(&c.ExtraKeysHCL, "config")
@@ -138,11 +203,20 @@ func extraKeys(c *Config) error {
 	// stats is an unused key, continue to silently ignore it
 	removeEqualFold(&c.Client.ExtraKeysHCL, "stats")
 
-	for _, k := range []string{"enabled_schedulers", "start_join", "retry_join", "server_join"} {
+	for _, k := range []string{"enabled_schedulers", "start_join", "retry_join", "server_join", "job_validation_rules", "deployment_analysis_addresses"} {
 		removeEqualFold(&c.ExtraKeysHCL, k)
 		removeEqualFold(&c.ExtraKeysHCL, "server")
 	}
 
+	if c.Audit != nil {
+		for _, s := range c.Audit.Sinks {
+			removeEqualFold(&c.Audit.ExtraKeysHCL, s.Name)
+		}
+		for _, f := range c.Audit.Filters {
+			removeEqualFold(&c.Audit.ExtraKeysHCL, f.Name)
+		}
+	}
+
 	for _, k := range []string{"datadog_tags"} {
 		removeEqualFold(&c.ExtraKeysHCL, k)
 		removeEqualFold(&c.ExtraKeysHCL, "telemetry")
@@ -80,33 +80,35 @@ var basicConfig = &Config{
 		NoHostUUID:            helper.BoolToPtr(false),
 	},
 	Server: &ServerConfig{
-		Enabled:                true,
-		AuthoritativeRegion:    "foobar",
-		BootstrapExpect:        5,
-		DataDir:                "/tmp/data",
-		ProtocolVersion:        3,
-		RaftProtocol:           3,
-		NumSchedulers:          helper.IntToPtr(2),
-		EnabledSchedulers:      []string{"test"},
-		NodeGCThreshold:        "12h",
-		EvalGCThreshold:        "12h",
-		JobGCThreshold:         "12h",
-		DeploymentGCThreshold:  "12h",
-		HeartbeatGrace:         30 * time.Second,
-		HeartbeatGraceHCL:      "30s",
-		MinHeartbeatTTL:        33 * time.Second,
-		MinHeartbeatTTLHCL:     "33s",
-		MaxHeartbeatsPerSecond: 11.0,
-		RetryJoin:              []string{"1.1.1.1", "2.2.2.2"},
-		StartJoin:              []string{"1.1.1.1", "2.2.2.2"},
-		RetryInterval:          15 * time.Second,
-		RetryIntervalHCL:       "15s",
-		RejoinAfterLeave:       true,
-		RetryMaxAttempts:       3,
-		NonVotingServer:        true,
-		RedundancyZone:         "foo",
-		UpgradeVersion:         "0.8.0",
-		EncryptKey:             "abc",
+		Enabled:                     true,
+		AuthoritativeRegion:         "foobar",
+		BootstrapExpect:             5,
+		DataDir:                     "/tmp/data",
+		ProtocolVersion:             3,
+		RaftProtocol:                3,
+		NumSchedulers:               helper.IntToPtr(2),
+		EnabledSchedulers:           []string{"test"},
+		NodeGCThreshold:             "12h",
+		EvalGCThreshold:             "12h",
+		JobGCThreshold:              "12h",
+		DeploymentGCThreshold:       "12h",
+		HeartbeatGrace:              30 * time.Second,
+		HeartbeatGraceHCL:           "30s",
+		MinHeartbeatTTL:             33 * time.Second,
+		MinHeartbeatTTLHCL:          "33s",
+		MaxHeartbeatsPerSecond:      11.0,
+		RetryJoin:                   []string{"1.1.1.1", "2.2.2.2"},
+		StartJoin:                   []string{"1.1.1.1", "2.2.2.2"},
+		RetryInterval:               15 * time.Second,
+		RetryIntervalHCL:            "15s",
+		RejoinAfterLeave:            true,
+		RetryMaxAttempts:            3,
+		NonVotingServer:             true,
+		RedundancyZone:              "foo",
+		UpgradeVersion:              "0.8.0",
+		EncryptKey:                  "abc",
+		JobValidationRules:          []string{"/etc/nomad/rules/*.hcl"},
+		DeploymentAnalysisAddresses: []string{"http://prometheus.service.consul:9090"},
 		ServerJoin: &ServerJoin{
 			RetryJoin:        []string{"1.1.1.1", "2.2.2.2"},
 			RetryInterval:    time.Duration(15) * time.Second,
@@ -122,6 +124,31 @@ var basicConfig = &Config{
 		PolicyTTLHCL:     "60s",
 		ReplicationToken: "foobar",
 	},
+	Audit: &config.AuditConfig{
+		Enabled: &trueValue,
+		Sinks: []*config.AuditSink{
+			{
+				Name:              "file",
+				Type:              "file",
+				DeliveryGuarantee: "enforced",
+				Format:            "json",
+				Path:              "/opt/nomad/audit/audit.log",
+				RotateBytes:       100,
+				RotateDuration:    24 * time.Hour,
+				RotateDurationHCL: "24h",
+				RotateMaxFiles:    10,
+			},
+		},
+		Filters: []*config.AuditFilter{
+			{
+				Name:       "default",
+				Type:       "HTTPEvent",
+				Endpoints:  []string{"/v1/metrics"},
+				Stages:     []string{"*"},
+				Operations: []string{"*"},
+			},
+		},
+	},
 	Telemetry: &Telemetry{
 		StatsiteAddr:               "127.0.0.1:1234",
 		StatsdAddr:                 "127.0.0.1:2345",
@@ -162,7 +189,8 @@ var basicConfig = &Config{
 		ChecksUseAdvertise:  &trueValue,
 		Timeout:             5 * time.Second,
 	},
-	Vault: &config.VaultConfig{
+	Vaults: []*config.VaultConfig{{
+		Name:                 "default",
 		Addr:                 "127.0.0.1:9500",
 		AllowUnauthenticated: &trueValue,
 		ConnectionRetryIntv:  config.DefaultVaultConnectRetryIntv,
@@ -176,7 +204,14 @@ var basicConfig = &Config{
 		TLSSkipVerify:        &trueValue,
 		TaskTokenTTL:         "1s",
 		Token:                "12345",
-	},
+	}, {
+		Name:                 "secondary",
+		Addr:                 "127.0.0.1:9600",
+		Namespace:            "ops",
+		AllowUnauthenticated: &trueValue,
+		ConnectionRetryIntv:  config.DefaultVaultConnectRetryIntv,
+		Enabled:              &falseValue,
+	}},
 	TLSConfig: &config.TLSConfig{
 		EnableHTTP:                  true,
 		EnableRPC:                   true,
@@ -286,7 +321,7 @@ var pluginConfig = &Config{
 	DisableUpdateCheck:        nil,
 	DisableAnonymousSignature: false,
 	Consul:                    nil,
-	Vault:                     nil,
+	Vaults:                    nil,
 	TLSConfig:                 nil,
 	HTTPAPIResponseHeaders:    nil,
 	Sentinel:                  nil,
@@ -352,7 +387,7 @@ var nonoptConfig = &Config{
 	DisableUpdateCheck:        nil,
 	DisableAnonymousSignature: false,
 	Consul:                    nil,
-	Vault:                     nil,
+	Vaults:                    nil,
 	TLSConfig:                 nil,
 	HTTPAPIResponseHeaders:    nil,
 	Sentinel:                  nil,
@@ -442,8 +477,8 @@ func (c *Config) addDefaults() {
 	if c.Autopilot == nil {
 		c.Autopilot = config.DefaultAutopilotConfig()
 	}
-	if c.Vault == nil {
-		c.Vault = config.DefaultVaultConfig()
+	if len(c.Vaults) == 0 {
+		c.Vaults = []*config.VaultConfig{config.DefaultVaultConfig()}
 	}
 	if c.Telemetry == nil {
 		c.Telemetry = &Telemetry{}
@@ -566,14 +601,15 @@ var sample0 = &Config{
 		EnableSSL:           helper.BoolToPtr(false),
 		VerifySSL:           helper.BoolToPtr(true),
 	},
-	Vault: &config.VaultConfig{
+	Vaults: []*config.VaultConfig{{
+		Name:    "default",
 		Enabled: helper.BoolToPtr(true),
 		Role:    "nomad-cluster",
 		Addr:    "http://host.example.com:8200",
 		// Defaults
 		AllowUnauthenticated: helper.BoolToPtr(true),
 		ConnectionRetryIntv:  30 * time.Second,
-	},
+	}},
 	TLSConfig: &config.TLSConfig{
 		EnableHTTP:           true,
 		EnableRPC:            true,
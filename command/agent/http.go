@@ -163,9 +163,22 @@ func (s *HTTPServer) registerHandlers(enableDebug bool) {
 	s.mux.HandleFunc("/v1/deployments", s.wrap(s.DeploymentsRequest))
 	s.mux.HandleFunc("/v1/deployment/", s.wrap(s.DeploymentSpecificRequest))
 
+	s.mux.HandleFunc("/v1/maintenance/plans", s.wrap(s.MaintenancePlansRequest))
+	s.mux.HandleFunc("/v1/maintenance/plan/", s.wrap(s.MaintenancePlanSpecificRequest))
+
 	s.mux.HandleFunc("/v1/acl/policies", s.wrap(s.ACLPoliciesRequest))
 	s.mux.HandleFunc("/v1/acl/policy/", s.wrap(s.ACLPolicySpecificRequest))
 
+	s.mux.HandleFunc("/v1/acl/roles", s.wrap(s.ACLRolesRequest))
+	s.mux.HandleFunc("/v1/acl/role/", s.wrap(s.ACLRoleSpecificRequest))
+
+	s.mux.HandleFunc("/v1/acl/auth-methods", s.wrap(s.ACLAuthMethodsRequest))
+	s.mux.HandleFunc("/v1/acl/auth-method/", s.wrap(s.ACLAuthMethodSpecificRequest))
+	s.mux.HandleFunc("/v1/acl/binding-rules", s.wrap(s.ACLBindingRulesRequest))
+	s.mux.HandleFunc("/v1/acl/binding-rule", s.wrap(s.ACLBindingRuleSpecificRequest))
+	s.mux.HandleFunc("/v1/acl/binding-rule/", s.wrap(s.ACLBindingRuleSpecificRequest))
+	s.mux.HandleFunc("/v1/acl/login", s.wrap(s.ACLLoginRequest))
+
 	s.mux.HandleFunc("/v1/acl/bootstrap", s.wrap(s.ACLTokenBootstrap))
 	s.mux.HandleFunc("/v1/acl/tokens", s.wrap(s.ACLTokensRequest))
 	s.mux.HandleFunc("/v1/acl/token", s.wrap(s.ACLTokenSpecificRequest))
@@ -203,6 +216,12 @@ func (s *HTTPServer) registerHandlers(enableDebug bool) {
 	s.mux.HandleFunc("/v1/system/reconcile/summaries", s.wrap(s.ReconcileJobSummaries))
 
 	s.mux.HandleFunc("/v1/operator/scheduler/configuration", s.wrap(s.OperatorSchedulerConfiguration))
+	s.mux.HandleFunc("/v1/operator/scheduler/simulate", s.wrap(s.OperatorSchedulerSimulate))
+
+	s.mux.HandleFunc("/v1/vars", s.wrap(s.VariablesListRequest))
+	s.mux.HandleFunc("/v1/var/", s.wrap(s.VariableSpecificRequest))
+
+	s.mux.HandleFunc("/.well-known/jwks.json", s.wrap(s.JWKSRequest))
 
 	if uiEnabled {
 		s.mux.Handle("/ui/", http.StripPrefix("/ui/", handleUI(http.FileServer(&UIAssetWrapper{FileSystem: assetFS()}))))
@@ -291,6 +310,16 @@ func (s *HTTPServer) wrap(handler func(resp http.ResponseWriter, req *http.Reque
 		defer func() {
 			s.logger.Debug("request complete", "method", req.Method, "path", reqURL, "duration", time.Now().Sub(start))
 		}()
+
+		// Audit the request before it is handled
+		auditReq, err := s.auditReceived(req, start)
+		if err != nil {
+			s.logger.Error("failed to audit request", "method", req.Method, "path", reqURL, "error", err)
+			resp.WriteHeader(500)
+			resp.Write([]byte(err.Error()))
+			return
+		}
+
 		obj, err := handler(resp, req)
 
 		// Check for an error
@@ -308,9 +337,18 @@ func (s *HTTPServer) wrap(handler func(resp http.ResponseWriter, req *http.Reque
 				} else if strings.HasSuffix(errMsg, structs.ErrTokenNotFound.Error()) {
 					errMsg = structs.ErrTokenNotFound.Error()
 					code = 403
+				} else if strings.HasSuffix(errMsg, structs.ErrTokenExpired.Error()) {
+					errMsg = structs.ErrTokenExpired.Error()
+					code = 403
 				}
 			}
 
+			if auditErr := s.auditComplete(auditReq, code, errMsg); auditErr != nil {
+				s.logger.Error("failed to audit request", "method", req.Method, "path", reqURL, "error", auditErr)
+				code = 500
+				errMsg = auditErr.Error()
+			}
+
 			resp.WriteHeader(code)
 			resp.Write([]byte(errMsg))
 			s.logger.Error("request failed", "method", req.Method, "path", reqURL, "error", err, "code", code)
@@ -340,8 +378,21 @@ func (s *HTTPServer) wrap(handler func(resp http.ResponseWriter, req *http.Reque
 			if err != nil {
 				goto HAS_ERR
 			}
+			if err := s.auditComplete(auditReq, 200, ""); err != nil {
+				s.logger.Error("failed to audit request", "method", req.Method, "path", reqURL, "error", err)
+				resp.WriteHeader(500)
+				resp.Write([]byte(err.Error()))
+				return
+			}
 			resp.Header().Set("Content-Type", "application/json")
 			resp.Write(buf.Bytes())
+			return
+		}
+
+		// There is no body to write or the handler streamed its own response,
+		// so a failure to audit the completion can only be logged
+		if err := s.auditComplete(auditReq, 200, ""); err != nil {
+			s.logger.Error("failed to audit request", "method", req.Method, "path", reqURL, "error", err)
 		}
 	}
 	return f
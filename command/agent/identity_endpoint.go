@@ -0,0 +1,51 @@
+package agent
+
+import (
+	"crypto"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/nomad/lib/auth/jwt"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// JWKSRequest returns the public keys of the keyring as a JSON Web Key Set so
+// other systems can verify the workload identities of tasks.
+func (s *HTTPServer) JWKSRequest(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	if req.Method != "GET" {
+		return nil, CodedError(405, ErrInvalidMethod)
+	}
+
+	args := structs.KeyringListPublicRequest{}
+	if s.parse(resp, req, &args.Region, &args.QueryOptions) {
+		return nil, nil
+	}
+
+	var out structs.KeyringListPublicResponse
+	if err := s.agent.RPC("Keyring.ListPublic", &args, &out); err != nil {
+		return nil, err
+	}
+	setMeta(resp, &out.QueryMeta)
+
+	keys := make(map[string]crypto.PublicKey, len(out.PublicKeys))
+	for _, key := range out.PublicKeys {
+		pub, err := x509.ParsePKIXPublicKey(key.PublicKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode public key %q: %v", key.KeyID, err)
+		}
+		keys[key.KeyID] = pub
+	}
+
+	data, err := jwt.MarshalJWKS(keys)
+	if err != nil {
+		return nil, err
+	}
+
+	var jwks map[string]interface{}
+	if err := json.Unmarshal(data, &jwks); err != nil {
+		return nil, err
+	}
+	return jwks, nil
+}
@@ -0,0 +1,57 @@
+package agent
+
+import (
+	"crypto"
+	"crypto/x509"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/nomad/lib/auth/jwt"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTP_JWKS(t *testing.T) {
+	t.Parallel()
+	httpTest(t, nil, func(s *TestAgent) {
+		// Wait for the leader to initialize the keyring
+		state := s.Agent.server.State()
+		var key *structs.RootKey
+		testutil.WaitForResult(func() (bool, error) {
+			var err error
+			key, err = state.ActiveRootKey(nil)
+			return key != nil, err
+		}, func(err error) {
+			t.Fatalf("keyring not initialized: %v", err)
+		})
+
+		req, err := http.NewRequest("GET", "/.well-known/jwks.json", nil)
+		require.NoError(t, err)
+		respW := httptest.NewRecorder()
+		obj, err := s.Server.JWKSRequest(respW, req)
+		require.NoError(t, err)
+		require.NotEmpty(t, respW.HeaderMap.Get("X-Nomad-Index"))
+
+		// The key set holds the public key of the root key
+		data, err := json.Marshal(obj)
+		require.NoError(t, err)
+		keySet, err := jwt.ParseJWKS(data)
+		require.NoError(t, err)
+		require.Equal(t, 1, keySet.Len())
+
+		pub, err := x509.ParsePKIXPublicKey(key.PublicKey)
+		require.NoError(t, err)
+		expected, err := jwt.MarshalJWKS(map[string]crypto.PublicKey{key.KeyID: pub})
+		require.NoError(t, err)
+		require.JSONEq(t, string(expected), string(data))
+
+		// Only GET is allowed
+		req, err = http.NewRequest("PUT", "/.well-known/jwks.json", nil)
+		require.NoError(t, err)
+		_, err = s.Server.JWKSRequest(httptest.NewRecorder(), req)
+		require.Error(t, err)
+	})
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/golang/snappy"
 	"github.com/hashicorp/nomad/api"
 	"github.com/hashicorp/nomad/jobspec"
+	"github.com/hashicorp/nomad/jobspec2"
 	"github.com/hashicorp/nomad/nomad/structs"
 )
 
@@ -56,6 +57,9 @@ func (s *HTTPServer) JobSpecificRequest(resp http.ResponseWriter, req *http.Requ
 	case strings.HasSuffix(path, "/periodic/force"):
 		jobName := strings.TrimSuffix(path, "/periodic/force")
 		return s.periodicForceRequest(resp, req, jobName)
+	case strings.HasSuffix(path, "/periodic/history"):
+		jobName := strings.TrimSuffix(path, "/periodic/history")
+		return s.periodicHistoryRequest(resp, req, jobName)
 	case strings.HasSuffix(path, "/plan"):
 		jobName := strings.TrimSuffix(path, "/plan")
 		return s.jobPlan(resp, req, jobName)
@@ -65,6 +69,9 @@ func (s *HTTPServer) JobSpecificRequest(resp http.ResponseWriter, req *http.Requ
 	case strings.HasSuffix(path, "/dispatch"):
 		jobName := strings.TrimSuffix(path, "/dispatch")
 		return s.jobDispatchRequest(resp, req, jobName)
+	case strings.HasSuffix(path, "/dispatches"):
+		jobName := strings.TrimSuffix(path, "/dispatches")
+		return s.jobDispatches(resp, req, jobName)
 	case strings.HasSuffix(path, "/versions"):
 		jobName := strings.TrimSuffix(path, "/versions")
 		return s.jobVersions(resp, req, jobName)
@@ -211,6 +218,31 @@ func (s *HTTPServer) periodicForceRequest(resp http.ResponseWriter, req *http.Re
 	return out, nil
 }
 
+func (s *HTTPServer) periodicHistoryRequest(resp http.ResponseWriter, req *http.Request,
+	jobName string) (interface{}, error) {
+	if req.Method != "GET" {
+		return nil, CodedError(405, ErrInvalidMethod)
+	}
+
+	args := structs.JobSpecificRequest{
+		JobID: jobName,
+	}
+	if s.parse(resp, req, &args.Region, &args.QueryOptions) {
+		return nil, nil
+	}
+
+	var out structs.PeriodicHistoryResponse
+	if err := s.agent.RPC("Periodic.History", &args, &out); err != nil {
+		return nil, err
+	}
+
+	setMeta(resp, &out.QueryMeta)
+	if out.Launch == nil {
+		return nil, CodedError(404, "periodic launch not found")
+	}
+	return out.Launch, nil
+}
+
 func (s *HTTPServer) jobAllocations(resp http.ResponseWriter, req *http.Request,
 	jobName string) (interface{}, error) {
 	if req.Method != "GET" {
@@ -539,6 +571,30 @@ func (s *HTTPServer) jobSummaryRequest(resp http.ResponseWriter, req *http.Reque
 	return out.JobSummary, nil
 }
 
+func (s *HTTPServer) jobDispatches(resp http.ResponseWriter, req *http.Request, name string) (interface{}, error) {
+	if req.Method != "GET" {
+		return nil, CodedError(405, ErrInvalidMethod)
+	}
+
+	args := structs.JobSpecificRequest{
+		JobID: name,
+	}
+	if s.parse(resp, req, &args.Region, &args.QueryOptions) {
+		return nil, nil
+	}
+
+	var out structs.JobDispatchesResponse
+	if err := s.agent.RPC("Job.Dispatches", &args, &out); err != nil {
+		return nil, err
+	}
+
+	setMeta(resp, &out.QueryMeta)
+	if out.Dispatches == nil {
+		out.Dispatches = make([]*structs.JobDispatchStub, 0)
+	}
+	return out.Dispatches, nil
+}
+
 func (s *HTTPServer) jobDispatchRequest(resp http.ResponseWriter, req *http.Request, name string) (interface{}, error) {
 	if req.Method != "PUT" && req.Method != "POST" {
 		return nil, CodedError(405, ErrInvalidMethod)
@@ -578,8 +634,23 @@ func (s *HTTPServer) JobsParseRequest(resp http.ResponseWriter, req *http.Reques
 		return nil, CodedError(400, "Job spec is empty")
 	}
 
-	jobfile := strings.NewReader(args.JobHCL)
-	jobStruct, err := jobspec.Parse(jobfile)
+	var jobStruct *api.Job
+	var err error
+	if !args.HCLv2 {
+		if args.Variables != "" {
+			return nil, CodedError(400, "Variables require HCLv2")
+		}
+		jobStruct, err = jobspec.Parse(strings.NewReader(args.JobHCL))
+	} else {
+		// Filesystem functions are disabled as the job is parsed on behalf of
+		// a remote user.
+		jobStruct, err = jobspec2.ParseWithConfig(&jobspec2.ParseConfig{
+			Path:       "input.hcl",
+			Body:       []byte(args.JobHCL),
+			AllowFS:    false,
+			VarContent: args.Variables,
+		})
+	}
 	if err != nil {
 		return nil, CodedError(400, err.Error())
 	}
@@ -636,6 +707,10 @@ func ApiJobToStructJob(job *api.Job) *structs.Job {
 			SpecType:        *job.Periodic.SpecType,
 			ProhibitOverlap: *job.Periodic.ProhibitOverlap,
 			TimeZone:        *job.Periodic.TimeZone,
+			Catchup:         *job.Periodic.Catchup,
+			CatchupLookback: *job.Periodic.CatchupLookback,
+			Specs:           job.Periodic.Specs,
+			Exclude:         job.Periodic.Exclude,
 		}
 
 		if job.Periodic.Spec != nil {
@@ -645,9 +720,10 @@ func ApiJobToStructJob(job *api.Job) *structs.Job {
 
 	if job.ParameterizedJob != nil {
 		j.ParameterizedJob = &structs.ParameterizedJobConfig{
-			Payload:      job.ParameterizedJob.Payload,
-			MetaRequired: job.ParameterizedJob.MetaRequired,
-			MetaOptional: job.ParameterizedJob.MetaOptional,
+			Payload:       job.ParameterizedJob.Payload,
+			MetaRequired:  job.ParameterizedJob.MetaRequired,
+			MetaOptional:  job.ParameterizedJob.MetaOptional,
+			MaxConcurrent: job.ParameterizedJob.MaxConcurrent,
 		}
 	}
 
@@ -669,6 +745,7 @@ func ApiTgToStructsTG(taskGroup *api.TaskGroup, tg *structs.TaskGroup) {
 	tg.Meta = taskGroup.Meta
 	tg.Constraints = ApiConstraintsToStructs(taskGroup.Constraints)
 	tg.Affinities = ApiAffinitiesToStructs(taskGroup.Affinities)
+	tg.MaxClientDisconnect = taskGroup.MaxClientDisconnect
 
 	tg.RestartPolicy = &structs.RestartPolicy{
 		Attempts: *taskGroup.RestartPolicy.Attempts,
@@ -720,6 +797,36 @@ func ApiTgToStructsTG(taskGroup *api.TaskGroup, tg *structs.TaskGroup) {
 			ProgressDeadline: *taskGroup.Update.ProgressDeadline,
 			AutoRevert:       *taskGroup.Update.AutoRevert,
 			Canary:           *taskGroup.Update.Canary,
+			AutoPromote:      *taskGroup.Update.AutoPromote,
+			PromoteAfter:     *taskGroup.Update.PromoteAfter,
+		}
+
+		for _, step := range taskGroup.Update.Steps {
+			tg.Update.Steps = append(tg.Update.Steps, &structs.UpdateStep{
+				Canary:  *step.Canary,
+				Percent: *step.Percent,
+				Pause:   *step.Pause,
+			})
+		}
+
+		if analysis := taskGroup.Update.Analysis; analysis != nil {
+			tg.Update.Analysis = &structs.DeploymentAnalysis{
+				Address:      *analysis.Address,
+				Interval:     *analysis.Interval,
+				FailureLimit: *analysis.FailureLimit,
+			}
+
+			if l := len(analysis.Metrics); l != 0 {
+				tg.Update.Analysis.Metrics = make([]*structs.AnalysisMetric, l)
+				for i, metric := range analysis.Metrics {
+					tg.Update.Analysis.Metrics[i] = &structs.AnalysisMetric{
+						Name:  metric.Name,
+						Query: metric.Query,
+						Min:   metric.Min,
+						Max:   metric.Max,
+					}
+				}
+			}
 		}
 	}
 
@@ -815,6 +922,8 @@ func ApiTaskToStructsTask(apiTask *api.Task, structsTask *structs.Task) {
 	if apiTask.Vault != nil {
 		structsTask.Vault = &structs.Vault{
 			Policies:     apiTask.Vault.Policies,
+			Cluster:      *apiTask.Vault.Cluster,
+			Namespace:    *apiTask.Vault.Namespace,
 			Env:          *apiTask.Vault.Env,
 			ChangeMode:   *apiTask.Vault.ChangeMode,
 			ChangeSignal: *apiTask.Vault.ChangeSignal,
@@ -857,6 +966,10 @@ func ApiResourcesToStructs(in *api.Resources) *structs.Resources {
 		MemoryMB: *in.MemoryMB,
 	}
 
+	if in.Cores != nil {
+		out.Cores = *in.Cores
+	}
+
 	// COMPAT(0.10): Only being used to issue warnings
 	if in.IOPS != nil {
 		out.IOPS = *in.IOPS
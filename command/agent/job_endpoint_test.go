@@ -305,6 +305,73 @@ func TestHTTP_JobsParse(t *testing.T) {
 		}
 	})
 }
+
+func TestHTTP_JobsParse_Variables(t *testing.T) {
+	t.Parallel()
+	httpTest(t, nil, func(s *TestAgent) {
+		jobHCL := `
+variable "datacenter" {
+  type = string
+}
+
+job "example" {
+  datacenters = [var.datacenter]
+
+  group "web" {
+    task "server" {
+      driver = "exec"
+    }
+  }
+}
+`
+		buf := encodeReq(api.JobsParseRequest{
+			JobHCL:    jobHCL,
+			Variables: `datacenter = "dc2"`,
+			HCLv2:     true,
+		})
+		req, err := http.NewRequest("POST", "/v1/jobs/parse", buf)
+		require.NoError(t, err)
+
+		obj, err := s.Server.JobsParseRequest(httptest.NewRecorder(), req)
+		require.NoError(t, err)
+		require.Equal(t, []string{"dc2"}, obj.(*api.Job).Datacenters)
+
+		// Jobs are parsed as HCL1 by default, which rejects variables
+		buf = encodeReq(api.JobsParseRequest{
+			JobHCL:    jobHCL,
+			Variables: `datacenter = "dc2"`,
+		})
+		req, err = http.NewRequest("POST", "/v1/jobs/parse", buf)
+		require.NoError(t, err)
+
+		_, err = s.Server.JobsParseRequest(httptest.NewRecorder(), req)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "require HCLv2")
+
+		buf = encodeReq(api.JobsParseRequest{
+			JobHCL: jobHCL,
+		})
+		req, err = http.NewRequest("POST", "/v1/jobs/parse", buf)
+		require.NoError(t, err)
+
+		_, err = s.Server.JobsParseRequest(httptest.NewRecorder(), req)
+		require.Error(t, err)
+
+		// Filesystem functions are not available to remote users
+		buf = encodeReq(api.JobsParseRequest{
+			JobHCL:    strings.Replace(jobHCL, "[var.datacenter]", `[file("/etc/hostname")]`, 1),
+			Variables: `datacenter = "dc2"`,
+			HCLv2:     true,
+		})
+		req, err = http.NewRequest("POST", "/v1/jobs/parse", buf)
+		require.NoError(t, err)
+
+		_, err = s.Server.JobsParseRequest(httptest.NewRecorder(), req)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "filesystem functions are disabled")
+	})
+}
+
 func TestHTTP_JobQuery(t *testing.T) {
 	t.Parallel()
 	httpTest(t, nil, func(s *TestAgent) {
@@ -985,6 +1052,49 @@ func TestHTTP_PeriodicForce(t *testing.T) {
 	})
 }
 
+func TestHTTP_PeriodicHistory(t *testing.T) {
+	t.Parallel()
+	httpTest(t, nil, func(s *TestAgent) {
+		// Create and register a periodic job.
+		job := mock.PeriodicJob()
+		args := structs.JobRegisterRequest{
+			Job: job,
+			WriteRequest: structs.WriteRequest{
+				Region:    "global",
+				Namespace: structs.DefaultNamespace,
+			},
+		}
+		var resp structs.JobRegisterResponse
+		if err := s.Agent.RPC("Job.Register", &args, &resp); err != nil {
+			t.Fatalf("err: %v", err)
+		}
+
+		// Make the HTTP request
+		req, err := http.NewRequest("GET", "/v1/job/"+job.ID+"/periodic/history", nil)
+		if err != nil {
+			t.Fatalf("err: %v", err)
+		}
+		respW := httptest.NewRecorder()
+
+		// Make the request
+		obj, err := s.Server.JobSpecificRequest(respW, req)
+		if err != nil {
+			t.Fatalf("err: %v", err)
+		}
+
+		// Check for the index
+		if respW.HeaderMap.Get("X-Nomad-Index") == "" {
+			t.Fatalf("missing index")
+		}
+
+		// Check the response
+		launch := obj.(*structs.PeriodicLaunch)
+		if launch.ID != job.ID || launch.Launch.IsZero() {
+			t.Fatalf("bad: %#v", launch)
+		}
+	})
+}
+
 func TestHTTP_JobPlan(t *testing.T) {
 	t.Parallel()
 	httpTest(t, nil, func(s *TestAgent) {
@@ -1079,6 +1189,62 @@ func TestHTTP_JobDispatch(t *testing.T) {
 	})
 }
 
+func TestHTTP_JobDispatches(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	httpTest(t, nil, func(s *TestAgent) {
+		// Create the parameterized job
+		job := mock.BatchJob()
+		job.ParameterizedJob = &structs.ParameterizedJobConfig{}
+
+		args := structs.JobRegisterRequest{
+			Job: job,
+			WriteRequest: structs.WriteRequest{
+				Region:    "global",
+				Namespace: structs.DefaultNamespace,
+			},
+		}
+		var resp structs.JobRegisterResponse
+		require.Nil(s.Agent.RPC("Job.Register", &args, &resp))
+
+		// Listing without any dispatched jobs returns an empty list
+		req, err := http.NewRequest("GET", "/v1/job/"+job.ID+"/dispatches", nil)
+		require.Nil(err)
+		respW := httptest.NewRecorder()
+		obj, err := s.Server.JobSpecificRequest(respW, req)
+		require.Nil(err)
+		require.NotNil(obj)
+		require.Empty(obj.([]*structs.JobDispatchStub))
+
+		// Dispatch the job
+		dispatchReq := structs.JobDispatchRequest{
+			JobID:            job.ID,
+			IdempotencyToken: "foo",
+			WriteRequest: structs.WriteRequest{
+				Region:    "global",
+				Namespace: structs.DefaultNamespace,
+			},
+		}
+		var dispatchResp structs.JobDispatchResponse
+		require.Nil(s.Agent.RPC("Job.Dispatch", &dispatchReq, &dispatchResp))
+
+		// List the dispatched jobs
+		req, err = http.NewRequest("GET", "/v1/job/"+job.ID+"/dispatches", nil)
+		require.Nil(err)
+		respW = httptest.NewRecorder()
+		obj, err = s.Server.JobSpecificRequest(respW, req)
+		require.Nil(err)
+
+		dispatches := obj.([]*structs.JobDispatchStub)
+		require.Len(dispatches, 1)
+		require.Equal(dispatchResp.DispatchedJobID, dispatches[0].ID)
+		require.Equal("foo", dispatches[0].IdempotencyToken)
+
+		// Check for the index
+		require.NotEmpty(respW.HeaderMap.Get("X-Nomad-Index"))
+	})
+}
+
 func TestHTTP_JobRevert(t *testing.T) {
 	t.Parallel()
 	httpTest(t, nil, func(s *TestAgent) {
@@ -1251,11 +1417,16 @@ func TestJobs_ApiJobToStructsJob(t *testing.T) {
 			SpecType:        helper.StringToPtr("cron"),
 			ProhibitOverlap: helper.BoolToPtr(true),
 			TimeZone:        helper.StringToPtr("test zone"),
+			Specs:           []string{"spec2"},
+			Exclude:         []string{"2019-12-25"},
+			Catchup:         helper.StringToPtr("run_all"),
+			CatchupLookback: helper.TimeToPtr(1 * time.Hour),
 		},
 		ParameterizedJob: &api.ParameterizedJobConfig{
-			Payload:      "payload",
-			MetaRequired: []string{"a", "b"},
-			MetaOptional: []string{"c", "d"},
+			Payload:       "payload",
+			MetaRequired:  []string{"a", "b"},
+			MetaOptional:  []string{"c", "d"},
+			MaxConcurrent: 10,
 		},
 		Payload: []byte("payload"),
 		Meta: map[string]string{
@@ -1323,6 +1494,16 @@ func TestJobs_ApiJobToStructsJob(t *testing.T) {
 					HealthyDeadline:  helper.TimeToPtr(5 * time.Minute),
 					ProgressDeadline: helper.TimeToPtr(5 * time.Minute),
 					AutoRevert:       helper.BoolToPtr(true),
+					Analysis: &api.DeploymentAnalysis{
+						Address: helper.StringToPtr("http://prometheus:9090"),
+						Metrics: []*api.AnalysisMetric{
+							{
+								Name:  "errors",
+								Query: "errors",
+								Max:   helper.Float64ToPtr(0.1),
+							},
+						},
+					},
 				},
 
 				Meta: map[string]string{
@@ -1467,6 +1648,8 @@ func TestJobs_ApiJobToStructsJob(t *testing.T) {
 						},
 						Vault: &api.Vault{
 							Policies:     []string{"a", "b", "c"},
+							Cluster:      helper.StringToPtr("default"),
+							Namespace:    helper.StringToPtr("ops"),
 							Env:          helper.BoolToPtr(true),
 							ChangeMode:   helper.StringToPtr("c"),
 							ChangeSignal: helper.StringToPtr("sighup"),
@@ -1550,11 +1733,16 @@ func TestJobs_ApiJobToStructsJob(t *testing.T) {
 			SpecType:        "cron",
 			ProhibitOverlap: true,
 			TimeZone:        "test zone",
+			Specs:           []string{"spec2"},
+			Exclude:         []string{"2019-12-25"},
+			Catchup:         "run_all",
+			CatchupLookback: 1 * time.Hour,
 		},
 		ParameterizedJob: &structs.ParameterizedJobConfig{
-			Payload:      "payload",
-			MetaRequired: []string{"a", "b"},
-			MetaOptional: []string{"c", "d"},
+			Payload:       "payload",
+			MetaRequired:  []string{"a", "b"},
+			MetaOptional:  []string{"c", "d"},
+			MaxConcurrent: 10,
 		},
 		Payload: []byte("payload"),
 		Meta: map[string]string{
@@ -1625,6 +1813,18 @@ func TestJobs_ApiJobToStructsJob(t *testing.T) {
 					ProgressDeadline: 5 * time.Minute,
 					AutoRevert:       true,
 					Canary:           1,
+					Analysis: &structs.DeploymentAnalysis{
+						Address:      "http://prometheus:9090",
+						Interval:     30 * time.Second,
+						FailureLimit: 1,
+						Metrics: []*structs.AnalysisMetric{
+							{
+								Name:  "errors",
+								Query: "errors",
+								Max:   helper.Float64ToPtr(0.1),
+							},
+						},
+					},
 				},
 				Meta: map[string]string{
 					"key": "value",
@@ -1766,6 +1966,8 @@ func TestJobs_ApiJobToStructsJob(t *testing.T) {
 						},
 						Vault: &structs.Vault{
 							Policies:     []string{"a", "b", "c"},
+							Cluster:      "default",
+							Namespace:    "ops",
 							Env:          true,
 							ChangeMode:   "c",
 							ChangeSignal: "sighup",
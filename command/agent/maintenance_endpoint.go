@@ -0,0 +1,107 @@
+package agent
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+func (s *HTTPServer) MaintenancePlansRequest(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	switch req.Method {
+	case "GET":
+		return s.maintenancePlanList(resp, req)
+	case "PUT", "POST":
+		return s.maintenancePlanRegister(resp, req)
+	default:
+		return nil, CodedError(405, ErrInvalidMethod)
+	}
+}
+
+func (s *HTTPServer) maintenancePlanList(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	args := structs.MaintenancePlanListRequest{}
+	if s.parse(resp, req, &args.Region, &args.QueryOptions) {
+		return nil, nil
+	}
+
+	var out structs.MaintenancePlanListResponse
+	if err := s.agent.RPC("Maintenance.List", &args, &out); err != nil {
+		return nil, err
+	}
+
+	setMeta(resp, &out.QueryMeta)
+	if out.Plans == nil {
+		out.Plans = make([]*structs.MaintenancePlan, 0)
+	}
+	return out.Plans, nil
+}
+
+func (s *HTTPServer) maintenancePlanRegister(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	var args structs.MaintenancePlanRegisterRequest
+	if err := decodeBody(req, &args); err != nil {
+		return nil, CodedError(400, err.Error())
+	}
+	if args.Plan == nil {
+		return nil, CodedError(400, "Plan must be specified")
+	}
+	s.parseWriteRequest(req, &args.WriteRequest)
+
+	var out structs.MaintenancePlanUpdateResponse
+	if err := s.agent.RPC("Maintenance.Register", &args, &out); err != nil {
+		return nil, err
+	}
+	setIndex(resp, out.Index)
+	return out, nil
+}
+
+func (s *HTTPServer) MaintenancePlanSpecificRequest(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	path := strings.TrimPrefix(req.URL.Path, "/v1/maintenance/plan/")
+	switch {
+	case strings.HasSuffix(path, "/cancel"):
+		planID := strings.TrimSuffix(path, "/cancel")
+		return s.maintenancePlanCancel(resp, req, planID)
+	default:
+		return s.maintenancePlanQuery(resp, req, path)
+	}
+}
+
+func (s *HTTPServer) maintenancePlanCancel(resp http.ResponseWriter, req *http.Request, planID string) (interface{}, error) {
+	if req.Method != "PUT" && req.Method != "POST" {
+		return nil, CodedError(405, ErrInvalidMethod)
+	}
+	args := structs.MaintenancePlanCancelRequest{
+		PlanID: planID,
+	}
+	s.parseWriteRequest(req, &args.WriteRequest)
+
+	var out structs.MaintenancePlanUpdateResponse
+	if err := s.agent.RPC("Maintenance.Cancel", &args, &out); err != nil {
+		return nil, err
+	}
+	setIndex(resp, out.Index)
+	return out, nil
+}
+
+func (s *HTTPServer) maintenancePlanQuery(resp http.ResponseWriter, req *http.Request, planID string) (interface{}, error) {
+	if req.Method != "GET" {
+		return nil, CodedError(405, ErrInvalidMethod)
+	}
+
+	args := structs.MaintenancePlanSpecificRequest{
+		PlanID: planID,
+	}
+	if s.parse(resp, req, &args.Region, &args.QueryOptions) {
+		return nil, nil
+	}
+
+	var out structs.SingleMaintenancePlanResponse
+	if err := s.agent.RPC("Maintenance.GetPlan", &args, &out); err != nil {
+		return nil, err
+	}
+
+	setMeta(resp, &out.QueryMeta)
+	if out.Plan == nil {
+		return nil, CodedError(404, "maintenance plan not found")
+	}
+	return out.Plan, nil
+}
@@ -0,0 +1,100 @@
+package agent
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTP_MaintenancePlans(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	httpTest(t, nil, func(s *TestAgent) {
+		// Create a node in a datacenter of its own
+		node := mock.Node()
+		node.Datacenter = "maintenance"
+		args := structs.NodeRegisterRequest{
+			Node:         node,
+			WriteRequest: structs.WriteRequest{Region: "global"},
+		}
+		var resp structs.NodeUpdateResponse
+		require.Nil(s.Agent.RPC("Node.Register", &args, &resp))
+
+		// Register a plan starting later
+		plan := mock.MaintenancePlan()
+		plan.Datacenters = []string{node.Datacenter}
+		plan.StartTime = time.Now().Add(time.Hour)
+		buf := encodeReq(structs.MaintenancePlanRegisterRequest{Plan: plan})
+		req, err := http.NewRequest("PUT", "/v1/maintenance/plans", buf)
+		require.Nil(err)
+		respW := httptest.NewRecorder()
+
+		obj, err := s.Server.MaintenancePlansRequest(respW, req)
+		require.Nil(err)
+		require.NotZero(respW.HeaderMap.Get("X-Nomad-Index"))
+		reg := obj.(structs.MaintenancePlanUpdateResponse)
+		require.NotNil(reg.Plan)
+		require.Len(reg.Plan.Nodes, 1)
+		require.Equal(node.ID, reg.Plan.Nodes[0].NodeID)
+		planID := reg.Plan.ID
+
+		// List the plans
+		req, err = http.NewRequest("GET", "/v1/maintenance/plans", nil)
+		require.Nil(err)
+		respW = httptest.NewRecorder()
+
+		obj, err = s.Server.MaintenancePlansRequest(respW, req)
+		require.Nil(err)
+		require.NotZero(respW.HeaderMap.Get("X-Nomad-Index"))
+		plans := obj.([]*structs.MaintenancePlan)
+		require.Len(plans, 1)
+		require.Equal(planID, plans[0].ID)
+
+		// Lookup the plan
+		req, err = http.NewRequest("GET", "/v1/maintenance/plan/"+planID, nil)
+		require.Nil(err)
+		respW = httptest.NewRecorder()
+
+		obj, err = s.Server.MaintenancePlanSpecificRequest(respW, req)
+		require.Nil(err)
+		require.NotZero(respW.HeaderMap.Get("X-Nomad-Index"))
+		out := obj.(*structs.MaintenancePlan)
+		require.Equal(structs.MaintenancePlanStatusPending, out.Status)
+
+		// Cancel the plan
+		req, err = http.NewRequest("PUT", "/v1/maintenance/plan/"+planID+"/cancel", nil)
+		require.Nil(err)
+		respW = httptest.NewRecorder()
+
+		obj, err = s.Server.MaintenancePlanSpecificRequest(respW, req)
+		require.Nil(err)
+		require.NotZero(respW.HeaderMap.Get("X-Nomad-Index"))
+		cancel := obj.(structs.MaintenancePlanUpdateResponse)
+		require.Equal(structs.MaintenancePlanStatusCancelled, cancel.Plan.Status)
+
+		// Lookup a missing plan
+		req, err = http.NewRequest("GET", "/v1/maintenance/plan/foo", nil)
+		require.Nil(err)
+		respW = httptest.NewRecorder()
+
+		_, err = s.Server.MaintenancePlanSpecificRequest(respW, req)
+		require.NotNil(err)
+		httpErr, ok := err.(HTTPCodedError)
+		require.True(ok)
+		require.Equal(404, httpErr.Code())
+
+		// Registering requires a plan
+		req, err = http.NewRequest("PUT", "/v1/maintenance/plans", encodeReq(structs.MaintenancePlanRegisterRequest{}))
+		require.Nil(err)
+		_, err = s.Server.MaintenancePlansRequest(httptest.NewRecorder(), req)
+		require.NotNil(err)
+		httpErr, ok = err.(HTTPCodedError)
+		require.True(ok)
+		require.Equal(400, httpErr.Code())
+	})
+}
@@ -41,6 +41,9 @@ func (s *HTTPServer) NodeSpecificRequest(resp http.ResponseWriter, req *http.Req
 	case strings.HasSuffix(path, "/allocations"):
 		nodeName := strings.TrimSuffix(path, "/allocations")
 		return s.nodeAllocations(resp, req, nodeName)
+	case strings.HasSuffix(path, "/drain/status"):
+		nodeName := strings.TrimSuffix(path, "/drain/status")
+		return s.nodeDrainStatus(resp, req, nodeName)
 	case strings.HasSuffix(path, "/drain"):
 		nodeName := strings.TrimSuffix(path, "/drain")
 		return s.nodeToggleDrain(resp, req, nodeName)
@@ -95,11 +98,35 @@ func (s *HTTPServer) nodeAllocations(resp http.ResponseWriter, req *http.Request
 		out.Allocs = make([]*structs.Allocation, 0)
 	}
 	for _, alloc := range out.Allocs {
+		// The workload identities are only handed to the client running the
+		// allocation
+		alloc.SignedIdentities = nil
 		alloc.SetEventDisplayMessages()
 	}
 	return out.Allocs, nil
 }
 
+func (s *HTTPServer) nodeDrainStatus(resp http.ResponseWriter, req *http.Request,
+	nodeID string) (interface{}, error) {
+	if req.Method != "GET" {
+		return nil, CodedError(405, ErrInvalidMethod)
+	}
+	args := structs.NodeSpecificRequest{
+		NodeID: nodeID,
+	}
+	if s.parse(resp, req, &args.Region, &args.QueryOptions) {
+		return nil, nil
+	}
+
+	var out structs.NodeDrainStatusResponse
+	if err := s.agent.RPC("Node.DrainStatus", &args, &out); err != nil {
+		return nil, err
+	}
+
+	setMeta(resp, &out.QueryMeta)
+	return out.DrainStrategy, nil
+}
+
 func (s *HTTPServer) nodeToggleDrain(resp http.ResponseWriter, req *http.Request,
 	nodeID string) (interface{}, error) {
 	if req.Method != "PUT" && req.Method != "POST" {
@@ -143,6 +170,7 @@ func (s *HTTPServer) nodeToggleDrain(resp http.ResponseWriter, req *http.Request
 			DrainSpec: structs.DrainSpec{
 				Deadline:         drainRequest.DrainSpec.Deadline,
 				IgnoreSystemJobs: drainRequest.DrainSpec.IgnoreSystemJobs,
+				OrderByPriority:  drainRequest.DrainSpec.OrderByPriority,
 			},
 		}
 	}
@@ -302,6 +302,59 @@ func TestHTTP_NodeDrain(t *testing.T) {
 	})
 }
 
+func TestHTTP_NodeDrainStatus(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	httpTest(t, nil, func(s *TestAgent) {
+		// Create a draining node
+		node := mock.Node()
+		args := structs.NodeRegisterRequest{
+			Node:         node,
+			WriteRequest: structs.WriteRequest{Region: "global"},
+		}
+		var resp structs.NodeUpdateResponse
+		require.Nil(s.Agent.RPC("Node.Register", &args, &resp))
+
+		drainReq := structs.NodeUpdateDrainRequest{
+			NodeID: node.ID,
+			DrainStrategy: &structs.DrainStrategy{
+				DrainSpec: structs.DrainSpec{
+					Deadline:        10 * time.Second,
+					OrderByPriority: true,
+				},
+			},
+			WriteRequest: structs.WriteRequest{Region: "global"},
+		}
+		var drainResp structs.NodeDrainUpdateResponse
+		require.Nil(s.Agent.RPC("Node.UpdateDrain", &drainReq, &drainResp))
+
+		// Make the HTTP request
+		req, err := http.NewRequest("GET", "/v1/node/"+node.ID+"/drain/status", nil)
+		require.Nil(err)
+		respW := httptest.NewRecorder()
+
+		// Make the request
+		obj, err := s.Server.NodeSpecificRequest(respW, req)
+		require.Nil(err)
+
+		// Check for the index
+		require.NotZero(respW.HeaderMap.Get("X-Nomad-Index"))
+
+		// Check the response
+		strategy, ok := obj.(*structs.DrainStrategy)
+		require.True(ok)
+		require.NotNil(strategy)
+		require.True(strategy.OrderByPriority)
+		require.NotNil(strategy.Progress)
+
+		// Only GET is allowed
+		req, err = http.NewRequest("POST", "/v1/node/"+node.ID+"/drain/status", nil)
+		require.Nil(err)
+		_, err = s.Server.NodeSpecificRequest(httptest.NewRecorder(), req)
+		require.NotNil(err)
+	})
+}
+
 // Tests backwards compatibility code to support pre 0.8 clients
 func TestHTTP_NodeDrain_Compat(t *testing.T) {
 	t.Parallel()
@@ -274,3 +274,44 @@ func (s *HTTPServer) schedulerUpdateConfig(resp http.ResponseWriter, req *http.R
 	setIndex(resp, reply.Index)
 	return reply, nil
 }
+
+// OperatorSchedulerSimulate is used to simulate scheduling the jobs of the
+// cluster against hypothetical changes to its nodes.
+func (s *HTTPServer) OperatorSchedulerSimulate(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	if req.Method != "PUT" && req.Method != "POST" {
+		return nil, CodedError(405, ErrInvalidMethod)
+	}
+
+	var args structs.SchedulerSimulateRequest
+	if done := s.parse(resp, req, &args.Region, &args.QueryOptions); done {
+		return nil, nil
+	}
+
+	var sim api.SchedulerSimulateRequest
+	if err := decodeBody(req, &sim); err != nil {
+		return nil, CodedError(http.StatusBadRequest, fmt.Sprintf("Error parsing simulation request: %v", err))
+	}
+
+	args.RemoveNodeIDs = sim.RemoveNodeIDs
+	args.RemoveDatacenters = sim.RemoveDatacenters
+	args.DrainNodeIDs = sim.DrainNodeIDs
+	for _, add := range sim.AddNodes {
+		if add == nil {
+			continue
+		}
+		args.AddNodes = append(args.AddNodes, &structs.SimulateNodeAdd{
+			CloneNodeID: add.CloneNodeID,
+			Count:       add.Count,
+			Datacenter:  add.Datacenter,
+			NodeClass:   add.NodeClass,
+		})
+	}
+
+	var reply structs.SchedulerSimulateResponse
+	if err := s.agent.RPC("Operator.SchedulerSimulate", &args, &reply); err != nil {
+		return nil, err
+	}
+	setMeta(resp, &reply.QueryMeta)
+
+	return reply, nil
+}
@@ -380,3 +380,27 @@ func TestOperator_SchedulerCASConfiguration(t *testing.T) {
 		require.False(reply.SchedulerConfig.PreemptionConfig.BatchSchedulerEnabled)
 	})
 }
+
+func TestOperator_SchedulerSimulate(t *testing.T) {
+	t.Parallel()
+	httpTest(t, nil, func(s *TestAgent) {
+		require := require.New(t)
+		body := bytes.NewBuffer([]byte(`{"RemoveDatacenters": ["dc1"]}`))
+		req, _ := http.NewRequest("PUT", "/v1/operator/scheduler/simulate", body)
+		resp := httptest.NewRecorder()
+		obj, err := s.Server.OperatorSchedulerSimulate(resp, req)
+		require.Nil(err)
+		require.Equal(200, resp.Code)
+		out, ok := obj.(structs.SchedulerSimulateResponse)
+		require.True(ok)
+		require.NotNil(out.UtilizationAfter)
+		require.Zero(out.UtilizationAfter.Nodes)
+
+		// Only writes are supported
+		req, _ = http.NewRequest("GET", "/v1/operator/scheduler/simulate", nil)
+		resp = httptest.NewRecorder()
+		_, err = s.Server.OperatorSchedulerSimulate(resp, req)
+		require.NotNil(err)
+		require.Contains(err.Error(), ErrInvalidMethod)
+	})
+}
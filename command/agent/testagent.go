@@ -324,7 +324,9 @@ func (a *TestAgent) config() *Config {
 	conf.BindAddr = "127.0.0.1"
 
 	conf.Consul = sconfig.DefaultConsulConfig()
-	conf.Vault.Enabled = new(bool)
+	for _, v := range conf.Vaults {
+		v.Enabled = new(bool)
+	}
 
 	// Tighten the Serf timing
 	config.SerfConfig.MemberlistConfig.SuspicionMult = 2
@@ -94,6 +94,8 @@ server {
 	redundancy_zone = "foo"
 	upgrade_version = "0.8.0"
 	encrypt = "abc"
+	job_validation_rules = ["/etc/nomad/rules/*.hcl"]
+	deployment_analysis_addresses = ["http://prometheus.service.consul:9090"]
 	server_join {
 		retry_join = [ "1.1.1.1", "2.2.2.2" ]
 		retry_max = 3
@@ -106,6 +108,24 @@ acl {
 	policy_ttl = "60s"
 	replication_token = "foobar"
 }
+audit {
+	enabled = true
+	sink "file" {
+		type = "file"
+		delivery_guarantee = "enforced"
+		format = "json"
+		path = "/opt/nomad/audit/audit.log"
+		rotate_bytes = 100
+		rotate_duration = "24h"
+		rotate_max_files = 10
+	}
+	filter "default" {
+		type = "HTTPEvent"
+		endpoints = ["/v1/metrics"]
+		stages = ["*"]
+		operations = ["*"]
+	}
+}
 telemetry {
 	statsite_address = "127.0.0.1:1234"
 	statsd_address = "127.0.0.1:2345"
@@ -160,6 +180,12 @@ vault {
 	tls_skip_verify = true
 	create_from_role = "test_role"
 }
+vault {
+	name = "secondary"
+	address = "127.0.0.1:9600"
+	namespace = "ops"
+	enabled = false
+}
 tls {
 	http = true
 	rpc = true
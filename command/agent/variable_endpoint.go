@@ -0,0 +1,154 @@
+package agent
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+func (s *HTTPServer) VariablesListRequest(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	if req.Method != "GET" {
+		return nil, CodedError(405, ErrInvalidMethod)
+	}
+
+	args := structs.VariablesListRequest{}
+	if s.parse(resp, req, &args.Region, &args.QueryOptions) {
+		return nil, nil
+	}
+
+	var out structs.VariablesListResponse
+	if err := s.agent.RPC(structs.VariablesListRPCMethod, &args, &out); err != nil {
+		return nil, err
+	}
+
+	setMeta(resp, &out.QueryMeta)
+	if out.Data == nil {
+		out.Data = make([]*structs.VariableMetadata, 0)
+	}
+	return out.Data, nil
+}
+
+func (s *HTTPServer) VariableSpecificRequest(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	path := strings.TrimPrefix(req.URL.Path, "/v1/var/")
+	if len(path) == 0 {
+		return nil, CodedError(400, "Missing Variable Path")
+	}
+	switch req.Method {
+	case "GET":
+		return s.variableQuery(resp, req, path)
+	case "PUT", "POST":
+		return s.variableUpdate(resp, req, path)
+	case "DELETE":
+		return s.variableDelete(resp, req, path)
+	default:
+		return nil, CodedError(405, ErrInvalidMethod)
+	}
+}
+
+func (s *HTTPServer) variableQuery(resp http.ResponseWriter, req *http.Request,
+	path string) (interface{}, error) {
+	args := structs.VariablesReadRequest{
+		Path: path,
+	}
+	if s.parse(resp, req, &args.Region, &args.QueryOptions) {
+		return nil, nil
+	}
+
+	var out structs.VariablesReadResponse
+	if err := s.agent.RPC(structs.VariablesReadRPCMethod, &args, &out); err != nil {
+		return nil, err
+	}
+
+	setMeta(resp, &out.QueryMeta)
+	if out.Data == nil {
+		return nil, CodedError(404, "variable not found")
+	}
+	return out.Data, nil
+}
+
+func (s *HTTPServer) variableUpdate(resp http.ResponseWriter, req *http.Request,
+	path string) (interface{}, error) {
+	// Parse the variable
+	var v structs.VariableDecrypted
+	if err := decodeBody(req, &v); err != nil {
+		return nil, CodedError(500, err.Error())
+	}
+
+	// Ensure the path matches
+	if v.Path == "" {
+		v.Path = path
+	} else if v.Path != path {
+		return nil, CodedError(400, "Variable path does not match request path")
+	}
+
+	// Format the request
+	args := structs.VariablesApplyRequest{
+		Op:  structs.VarOpSet,
+		Var: &v,
+	}
+	if err := parseCAS(req, &args); err != nil {
+		return nil, err
+	}
+	s.parseWriteRequest(req, &args.WriteRequest)
+
+	return s.variableApply(resp, &args)
+}
+
+func (s *HTTPServer) variableDelete(resp http.ResponseWriter, req *http.Request,
+	path string) (interface{}, error) {
+	args := structs.VariablesApplyRequest{
+		Op: structs.VarOpDelete,
+		Var: &structs.VariableDecrypted{
+			VariableMetadata: structs.VariableMetadata{Path: path},
+		},
+	}
+	if err := parseCAS(req, &args); err != nil {
+		return nil, err
+	}
+	s.parseWriteRequest(req, &args.WriteRequest)
+
+	return s.variableApply(resp, &args)
+}
+
+// variableApply applies the request and writes the current variable with a
+// 409 status code on a check-and-set conflict
+func (s *HTTPServer) variableApply(resp http.ResponseWriter, args *structs.VariablesApplyRequest) (interface{}, error) {
+	var out structs.VariablesApplyResponse
+	if err := s.agent.RPC(structs.VariablesApplyRPCMethod, args, &out); err != nil {
+		return nil, err
+	}
+	setIndex(resp, out.Index)
+
+	if out.IsConflict() {
+		resp.Header().Set("Content-Type", "application/json")
+		resp.WriteHeader(http.StatusConflict)
+		return out.Conflict, nil
+	}
+	if out.Output == nil {
+		return nil, nil
+	}
+	return out.Output, nil
+}
+
+// parseCAS turns the request into a check-and-set operation if the "cas"
+// query parameter is set to the expected modify index of the variable
+func parseCAS(req *http.Request, args *structs.VariablesApplyRequest) error {
+	raw := req.URL.Query().Get("cas")
+	if raw == "" {
+		return nil
+	}
+	index, err := strconv.ParseUint(raw, 10, 64)
+	if err != nil {
+		return CodedError(400, "Invalid cas index: "+err.Error())
+	}
+
+	args.Var.ModifyIndex = index
+	if args.Op == structs.VarOpDelete {
+		args.Op = structs.VarOpDeleteCAS
+	} else {
+		args.Op = structs.VarOpCAS
+	}
+	return nil
+}
@@ -0,0 +1,97 @@
+package agent
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTP_Variables(t *testing.T) {
+	t.Parallel()
+	httpTest(t, nil, func(s *TestAgent) {
+		// Wait for the leader to initialize the keyring
+		state := s.Agent.server.State()
+		testutil.WaitForResult(func() (bool, error) {
+			key, err := state.ActiveRootKey(nil)
+			return key != nil, err
+		}, func(err error) {
+			t.Fatalf("keyring not initialized: %v", err)
+		})
+
+		// Create a variable only if it doesn't exist
+		v := &structs.VariableDecrypted{
+			Items: structs.VariableItems{"user": "admin"},
+		}
+		req, err := http.NewRequest("PUT", "/v1/var/nomad/jobs/example?cas=0", encodeReq(v))
+		require.NoError(t, err)
+		respW := httptest.NewRecorder()
+		obj, err := s.Server.VariableSpecificRequest(respW, req)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, respW.Code)
+		require.NotEmpty(t, respW.HeaderMap.Get("X-Nomad-Index"))
+
+		out := obj.(*structs.VariableDecrypted)
+		require.Equal(t, "nomad/jobs/example", out.Path)
+		require.Equal(t, structs.DefaultNamespace, out.Namespace)
+		require.Equal(t, v.Items, out.Items)
+		require.NotZero(t, out.ModifyIndex)
+
+		// Creating it again conflicts
+		req, err = http.NewRequest("PUT", "/v1/var/nomad/jobs/example?cas=0", encodeReq(v))
+		require.NoError(t, err)
+		respW = httptest.NewRecorder()
+		obj, err = s.Server.VariableSpecificRequest(respW, req)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusConflict, respW.Code)
+		require.Equal(t, out.ModifyIndex, obj.(*structs.VariableDecrypted).ModifyIndex)
+
+		// Read it
+		req, err = http.NewRequest("GET", "/v1/var/nomad/jobs/example", nil)
+		require.NoError(t, err)
+		respW = httptest.NewRecorder()
+		obj, err = s.Server.VariableSpecificRequest(respW, req)
+		require.NoError(t, err)
+		require.Equal(t, v.Items, obj.(*structs.VariableDecrypted).Items)
+
+		// List it
+		req, err = http.NewRequest("GET", "/v1/vars?prefix=nomad/jobs", nil)
+		require.NoError(t, err)
+		respW = httptest.NewRecorder()
+		obj, err = s.Server.VariablesListRequest(respW, req)
+		require.NoError(t, err)
+		list := obj.([]*structs.VariableMetadata)
+		require.Len(t, list, 1)
+		require.Equal(t, "nomad/jobs/example", list[0].Path)
+
+		// The path of the body must match the URL
+		v.Path = "other"
+		req, err = http.NewRequest("PUT", "/v1/var/nomad/jobs/example", encodeReq(v))
+		require.NoError(t, err)
+		_, err = s.Server.VariableSpecificRequest(httptest.NewRecorder(), req)
+		require.Error(t, err)
+
+		// Invalid cas indexes are rejected
+		req, err = http.NewRequest("DELETE", "/v1/var/nomad/jobs/example?cas=foo", nil)
+		require.NoError(t, err)
+		_, err = s.Server.VariableSpecificRequest(httptest.NewRecorder(), req)
+		require.Error(t, err)
+
+		// Delete it
+		req, err = http.NewRequest("DELETE", "/v1/var/nomad/jobs/example", nil)
+		require.NoError(t, err)
+		respW = httptest.NewRecorder()
+		_, err = s.Server.VariableSpecificRequest(respW, req)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, respW.Code)
+
+		req, err = http.NewRequest("GET", "/v1/var/nomad/jobs/example", nil)
+		require.NoError(t, err)
+		_, err = s.Server.VariableSpecificRequest(httptest.NewRecorder(), req)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "not found")
+	})
+}
@@ -195,7 +195,7 @@ func formatDeployment(d *api.Deployment, uuidLength int) string {
 
 func formatDeploymentGroups(d *api.Deployment, uuidLength int) string {
 	// Detect if we need to add these columns
-	var canaries, autorevert, progressDeadline bool
+	var canaries, autorevert, progressDeadline, steps bool
 	tgNames := make([]string, 0, len(d.TaskGroups))
 	for name, state := range d.TaskGroups {
 		tgNames = append(tgNames, name)
@@ -208,6 +208,9 @@ func formatDeploymentGroups(d *api.Deployment, uuidLength int) string {
 		if state.ProgressDeadline != 0 {
 			progressDeadline = true
 		}
+		if len(state.Steps) != 0 {
+			steps = true
+		}
 	}
 
 	// Sort the task group names to get a reliable ordering
@@ -221,6 +224,9 @@ func formatDeploymentGroups(d *api.Deployment, uuidLength int) string {
 	if canaries {
 		rowString += "Promoted|"
 	}
+	if steps {
+		rowString += "Step|"
+	}
 	rowString += "Desired|"
 	if canaries {
 		rowString += "Canaries|"
@@ -246,6 +252,9 @@ func formatDeploymentGroups(d *api.Deployment, uuidLength int) string {
 				row += fmt.Sprintf("%v|", "N/A")
 			}
 		}
+		if steps {
+			row += fmt.Sprintf("%s|", formatDeploymentStep(state))
+		}
 		row += fmt.Sprintf("%d|", state.DesiredTotal)
 		if canaries {
 			row += fmt.Sprintf("%d|", state.DesiredCanaries)
@@ -264,3 +273,26 @@ func formatDeploymentGroups(d *api.Deployment, uuidLength int) string {
 
 	return formatList(rows)
 }
+
+// formatDeploymentStep returns the rollout step in progress of the task group
+// and the number of allocations it deploys.
+func formatDeploymentStep(state *api.DeploymentState) string {
+	total := len(state.Steps)
+	switch {
+	case total == 0:
+		return "N/A"
+	case state.CurrentStep >= total:
+		return fmt.Sprintf("%d/%d (done)", total, total)
+	}
+
+	step := state.Steps[state.CurrentStep]
+	if step.Canary != nil && *step.Canary != 0 {
+		return fmt.Sprintf("%d/%d (%d canary)", state.CurrentStep+1, total, *step.Canary)
+	}
+
+	percent := 0
+	if step.Percent != nil {
+		percent = *step.Percent
+	}
+	return fmt.Sprintf("%d/%d (%d%%)", state.CurrentStep+1, total, percent)
+}
@@ -3,7 +3,10 @@ package command
 import (
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/nomad/helper"
 	"github.com/hashicorp/nomad/nomad/mock"
 	"github.com/mitchellh/cli"
 	"github.com/posener/complete"
@@ -61,3 +64,29 @@ func TestDeploymentStatusCommand_AutocompleteArgs(t *testing.T) {
 	assert.Equal(1, len(res))
 	assert.Equal(d.ID, res[0])
 }
+
+func TestDeploymentStatusCommand_FormatSteps(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+
+	steps := []*api.UpdateStep{
+		{Canary: helper.IntToPtr(1), Percent: helper.IntToPtr(0), Pause: helper.TimeToPtr(10 * time.Minute)},
+		{Canary: helper.IntToPtr(0), Percent: helper.IntToPtr(25), Pause: helper.TimeToPtr(30 * time.Minute)},
+		{Canary: helper.IntToPtr(0), Percent: helper.IntToPtr(100), Pause: helper.TimeToPtr(0)},
+	}
+	d := &api.Deployment{
+		TaskGroups: map[string]*api.DeploymentState{
+			"canary":  {Steps: steps, CurrentStep: 0, DesiredCanaries: 1, DesiredTotal: 4},
+			"percent": {Steps: steps, CurrentStep: 1, DesiredTotal: 4},
+			"done":    {Steps: steps, CurrentStep: 3, DesiredTotal: 4},
+			"none":    {DesiredTotal: 4},
+		},
+	}
+
+	out := formatDeploymentGroups(d, 8)
+	assert.Contains(out, "Step")
+	assert.Contains(out, "1/3 (1 canary)")
+	assert.Contains(out, "2/3 (25%)")
+	assert.Contains(out, "3/3 (done)")
+	assert.Contains(out, "N/A")
+}
@@ -2,17 +2,21 @@ package command
 
 import (
 	"bytes"
+	"flag"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
 	"time"
 
 	gg "github.com/hashicorp/go-getter"
 	"github.com/hashicorp/nomad/api"
+	flaghelper "github.com/hashicorp/nomad/helper/flag-helpers"
 	"github.com/hashicorp/nomad/jobspec"
+	"github.com/hashicorp/nomad/jobspec2"
 	"github.com/kr/text"
 	"github.com/posener/complete"
 
@@ -376,13 +380,37 @@ READ:
 }
 
 type JobGetter struct {
+	// hcl2 enables parsing the job file as HCL2 rather than HCL1
+	hcl2 bool
+
+	// vars and varFiles are the variable values and variable files passed
+	// with the -var and -var-file flags. They require HCL2.
+	vars     flaghelper.StringFlag
+	varFiles flaghelper.StringFlag
+
 	// The fields below can be overwritten for tests
 	testStdin io.Reader
 }
 
+// setFlags registers the flags that control how the job file is parsed.
+func (j *JobGetter) setFlags(flags *flag.FlagSet) {
+	flags.BoolVar(&j.hcl2, "hcl2", false, "")
+	flags.Var(&j.vars, "var", "")
+	flags.Var(&j.varFiles, "var-file", "")
+}
+
 // StructJob returns the Job struct from jobfile.
 func (j *JobGetter) ApiJob(jpath string) (*api.Job, error) {
+	if !j.hcl2 && (len(j.vars) != 0 || len(j.varFiles) != 0) {
+		return nil, fmt.Errorf("The -var and -var-file flags require -hcl2")
+	}
+
 	var jobfile io.Reader
+	baseDir, err := os.Getwd()
+	if err != nil {
+		return nil, err
+	}
+
 	switch jpath {
 	case "-":
 		if j.testStdin != nil {
@@ -427,10 +455,32 @@ func (j *JobGetter) ApiJob(jpath string) (*api.Job, error) {
 			}
 			jobfile = file
 		}
+
+		// Files referenced by local job files are relative to the job file
+		if _, err := os.Stat(jpath); err == nil {
+			baseDir = filepath.Dir(jpath)
+		}
 	}
 
 	// Parse the JobFile
-	jobStruct, err := jobspec.Parse(jobfile)
+	var jobStruct *api.Job
+	if !j.hcl2 {
+		jobStruct, err = jobspec.Parse(jobfile)
+	} else {
+		var buf bytes.Buffer
+		if _, err := io.Copy(&buf, jobfile); err != nil {
+			return nil, fmt.Errorf("Error reading job file from %s: %v", jpath, err)
+		}
+		jobStruct, err = jobspec2.ParseWithConfig(&jobspec2.ParseConfig{
+			Path:     jpath,
+			BaseDir:  baseDir,
+			Body:     buf.Bytes(),
+			AllowFS:  true,
+			ArgVars:  j.vars,
+			VarFiles: j.varFiles,
+			Envs:     os.Environ(),
+		})
+	}
 	if err != nil {
 		return nil, fmt.Errorf("Error parsing job file from %s: %v", jpath, err)
 	}
@@ -6,6 +6,7 @@ import (
 	"io/ioutil"
 	"net/http"
 	"os"
+	"path/filepath"
 	"reflect"
 	"strings"
 	"testing"
@@ -295,6 +296,96 @@ func TestJobGetter_HTTPServer(t *testing.T) {
 	}
 }
 
+// Test APIJob with variables passed as flags
+func TestJobGetter_Variables(t *testing.T) {
+	t.Parallel()
+	dir, err := ioutil.TempDir("", "nomad")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	defer os.RemoveAll(dir)
+
+	jobfile := filepath.Join(dir, "job.nomad")
+	src := `
+variable "datacenter" {
+  type = string
+}
+
+variable "restart_attempts" {
+  type    = number
+  default = 3
+}
+` + strings.Replace(strings.Replace(job, `[ "dc1" ]`, "[var.datacenter]", 1), "attempts = 10", "attempts = var.restart_attempts", 1)
+	if err := ioutil.WriteFile(jobfile, []byte(src), 0600); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	varfile := filepath.Join(dir, "job.vars")
+	if err := ioutil.WriteFile(varfile, []byte(`datacenter = "dc1"`), 0600); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	j := &JobGetter{
+		hcl2:     true,
+		vars:     []string{"restart_attempts=10"},
+		varFiles: []string{varfile},
+	}
+	aj, err := j.ApiJob(jobfile)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if !reflect.DeepEqual(expectedApiJob, aj) {
+		for _, d := range pretty.Diff(expectedApiJob, aj) {
+			t.Log(d)
+		}
+		t.Fatalf("Unexpected job")
+	}
+
+	// Variables are not supported by the HCL1 parser, which is the default
+	j.hcl2 = false
+	if _, err := j.ApiJob(jobfile); err == nil || !strings.Contains(err.Error(), "require -hcl2") {
+		t.Fatalf("expected error passing variables without -hcl2, got: %v", err)
+	}
+	j.vars, j.varFiles = nil, nil
+	if _, err := j.ApiJob(jobfile); err == nil {
+		t.Fatalf("expected error parsing variables as HCL1")
+	}
+}
+
+// Test APIJob parses job files as HCL1 by default
+func TestJobGetter_HCL1Default(t *testing.T) {
+	t.Parallel()
+	dir, err := ioutil.TempDir("", "nomad")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	defer os.RemoveAll(dir)
+
+	// Trailing commas are only valid HCL1
+	jobfile := filepath.Join(dir, "job.nomad")
+	src := strings.Replace(job, `attempts = 10`, `attempts = 10,`, 1)
+	if err := ioutil.WriteFile(jobfile, []byte(src), 0600); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	j := &JobGetter{}
+	aj, err := j.ApiJob(jobfile)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if !reflect.DeepEqual(expectedApiJob, aj) {
+		for _, d := range pretty.Diff(expectedApiJob, aj) {
+			t.Log(d)
+		}
+		t.Fatalf("Unexpected job")
+	}
+
+	j.hcl2 = true
+	if _, err := j.ApiJob(jobfile); err == nil {
+		t.Fatalf("expected error parsing trailing commas as HCL2")
+	}
+}
+
 func TestPrettyTimeDiff(t *testing.T) {
 	// Grab the time and truncate to the nearest second. This allows our tests
 	// to be deterministic since we don't have to worry about rounding.
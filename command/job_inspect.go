@@ -3,6 +3,7 @@ package command
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/nomad/api"
 	"github.com/hashicorp/nomad/api/contexts"
@@ -34,6 +35,10 @@ Inspect Options:
 
   -t
     Format and display job using a Go template.
+
+  -launches <n>
+    Display the next n launch times of a periodic job instead of the job
+    specification.
 `
 	return strings.TrimSpace(helpText)
 }
@@ -45,9 +50,10 @@ func (c *JobInspectCommand) Synopsis() string {
 func (c *JobInspectCommand) AutocompleteFlags() complete.Flags {
 	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
 		complete.Flags{
-			"-version": complete.PredictAnything,
-			"-json":    complete.PredictNothing,
-			"-t":       complete.PredictAnything,
+			"-version":  complete.PredictAnything,
+			"-json":     complete.PredictNothing,
+			"-t":        complete.PredictAnything,
+			"-launches": complete.PredictAnything,
 		})
 }
 
@@ -71,12 +77,14 @@ func (c *JobInspectCommand) Name() string { return "job inspect" }
 func (c *JobInspectCommand) Run(args []string) int {
 	var json bool
 	var tmpl, versionStr string
+	var launches int
 
 	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
 	flags.Usage = func() { c.Ui.Output(c.Help()) }
 	flags.BoolVar(&json, "json", false, "")
 	flags.StringVar(&tmpl, "t", "", "")
 	flags.StringVar(&versionStr, "version", "", "")
+	flags.IntVar(&launches, "launches", 0, "")
 
 	if err := flags.Parse(args); err != nil {
 		return 1
@@ -149,6 +157,33 @@ func (c *JobInspectCommand) Run(args []string) int {
 		return 1
 	}
 
+	// Display the upcoming launches of periodic jobs
+	if launches > 0 {
+		if !job.IsPeriodic() || job.IsParameterized() {
+			c.Ui.Error(fmt.Sprintf("Job %q is not a periodic job", *job.ID))
+			return 1
+		}
+
+		next, err := periodicNextLaunches(job, time.Now(), launches)
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("Error determining periodic launches: %s", err))
+			return 1
+		}
+
+		if json || len(tmpl) > 0 {
+			out, err := Format(json, tmpl, next)
+			if err != nil {
+				c.Ui.Error(err.Error())
+				return 1
+			}
+			c.Ui.Output(out)
+			return 0
+		}
+
+		c.Ui.Output(formatPeriodicLaunches(next, time.Now()))
+		return 0
+	}
+
 	// If output format is specified, format and output the data
 	if json || len(tmpl) > 0 {
 		out, err := Format(json, tmpl, job)
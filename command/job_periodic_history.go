@@ -0,0 +1,147 @@
+package command
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/posener/complete"
+)
+
+type JobPeriodicHistoryCommand struct {
+	Meta
+}
+
+func (c *JobPeriodicHistoryCommand) Help() string {
+	helpText := `
+Usage: nomad job periodic history <job id>
+
+  This command is used to display the recent launches of a periodic job. Each
+  launch is listed with its status: launched at its scheduled time or forced,
+  caught-up after being missed, or skipped after being missed. Launches are
+  missed when there is no leader or the job is disabled at the launch time and
+  are handled according to the job's catch-up policy.
+
+General Options:
+
+  ` + generalOptionsUsage() + `
+`
+
+	return strings.TrimSpace(helpText)
+}
+
+func (c *JobPeriodicHistoryCommand) Synopsis() string {
+	return "Display the launch history of a periodic job"
+}
+
+func (c *JobPeriodicHistoryCommand) AutocompleteFlags() complete.Flags {
+	return c.Meta.AutocompleteFlags(FlagSetClient)
+}
+
+func (c *JobPeriodicHistoryCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictFunc(func(a complete.Args) []string {
+		client, err := c.Meta.Client()
+		if err != nil {
+			return nil
+		}
+
+		resp, _, err := client.Jobs().PrefixList(a.Last)
+		if err != nil {
+			return []string{}
+		}
+
+		// filter this by periodic jobs
+		matches := make([]string, 0, len(resp))
+		for _, job := range resp {
+			if job.Periodic {
+				matches = append(matches, job.ID)
+			}
+		}
+		return matches
+	})
+}
+
+func (c *JobPeriodicHistoryCommand) Name() string { return "job periodic history" }
+
+func (c *JobPeriodicHistoryCommand) Run(args []string) int {
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
+	flags.Usage = func() { c.Ui.Output(c.Help()) }
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	// Check that we got exactly one argument
+	args = flags.Args()
+	if l := len(args); l != 1 {
+		c.Ui.Error("This command takes one argument: <job id>")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
+	// Get the HTTP client
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+
+	// Check if the job exists
+	jobID := args[0]
+	jobs, _, err := client.Jobs().PrefixList(jobID)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error retrieving periodic job history: %s", err))
+		return 1
+	}
+	// filter non-periodic jobs
+	periodicJobs := make([]*api.JobListStub, 0, len(jobs))
+	for _, j := range jobs {
+		if j.Periodic {
+			periodicJobs = append(periodicJobs, j)
+		}
+	}
+	if len(periodicJobs) == 0 {
+		c.Ui.Error(fmt.Sprintf("No periodic job(s) with prefix or id %q found", jobID))
+		return 1
+	}
+	if len(periodicJobs) > 1 {
+		c.Ui.Error(fmt.Sprintf("Prefix matched multiple periodic jobs\n\n%s", createStatusListOutput(periodicJobs)))
+		return 1
+	}
+	jobID = periodicJobs[0].ID
+
+	launch, _, err := client.Jobs().PeriodicHistory(jobID, nil)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error retrieving history of periodic job %q: %s", jobID, err))
+		return 1
+	}
+
+	c.Ui.Output(formatPeriodicHistory(launch))
+	return 0
+}
+
+// formatPeriodicHistory formats the launch history of a periodic job, most
+// recent launch first.
+func formatPeriodicHistory(launch *api.PeriodicLaunch) string {
+	basic := []string{
+		fmt.Sprintf("ID|%s", launch.ID),
+		fmt.Sprintf("Last Launch|%s", formatTime(launch.Launch)),
+	}
+	out := formatKV(basic)
+
+	if len(launch.History) == 0 {
+		return out + "\n\nNo launch history"
+	}
+
+	rows := make([]string, len(launch.History)+1)
+	rows[0] = "Launch|Status|Description"
+	for i, event := range launch.History {
+		desc := event.Description
+		if desc == "" {
+			desc = "<none>"
+		}
+		rows[len(launch.History)-i] = fmt.Sprintf("%s|%s|%s",
+			formatTime(event.Launch), event.Status, desc)
+	}
+	return out + "\n\n" + formatList(rows)
+}
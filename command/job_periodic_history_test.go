@@ -0,0 +1,57 @@
+package command
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/mitchellh/cli"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJobPeriodicHistoryCommand_Implements(t *testing.T) {
+	t.Parallel()
+	var _ cli.Command = &JobPeriodicHistoryCommand{}
+}
+
+func TestJobPeriodicHistoryCommand_Fails(t *testing.T) {
+	t.Parallel()
+	ui := new(cli.MockUi)
+	cmd := &JobPeriodicHistoryCommand{Meta: Meta{Ui: ui}}
+
+	// Fails on misuse
+	code := cmd.Run([]string{"some", "bad", "args"})
+	require.Equal(t, code, 1, "expected error")
+	out := ui.ErrorWriter.String()
+	require.Contains(t, out, commandErrorText(cmd), "expected help output")
+	ui.ErrorWriter.Reset()
+
+	code = cmd.Run([]string{"-address=nope", "12"})
+	require.Equal(t, code, 1, "expected error")
+	out = ui.ErrorWriter.String()
+	require.Contains(t, out, "Error retrieving periodic job history", "expected history error")
+}
+
+func TestJobPeriodicHistoryCommand_Format(t *testing.T) {
+	t.Parallel()
+	now := time.Now()
+	launch := &api.PeriodicLaunch{
+		ID:     "foo",
+		Launch: now,
+		History: []*api.PeriodicLaunchEvent{
+			{Launch: now.Add(-time.Hour), Status: "skipped", Description: "Launch missed"},
+			{Launch: now, Status: "caught-up", Description: "Launch caught up"},
+		},
+	}
+
+	out := formatPeriodicHistory(launch)
+	require.Contains(t, out, "foo")
+	require.Contains(t, out, "Launch missed")
+
+	// Most recent launches are listed first
+	require.True(t, strings.Index(out, "caught-up") < strings.Index(out, "skipped"))
+
+	launch.History = nil
+	require.Contains(t, formatPeriodicHistory(launch), "No launch history")
+}
@@ -71,9 +71,22 @@ Plan Options:
     Determines whether the diff between the remote job and planned job is shown.
     Defaults to true.
 
+  -hcl2
+    Parses the job file as HCL2, which supports variables, locals, dynamic
+    blocks and functions. By default, job files are parsed as HCL1.
+
   -policy-override
     Sets the flag to force override any soft mandatory Sentinel policies.
 
+  -var 'key=value'
+    Sets the value of a variable declared in the job file. This flag can be
+    specified multiple times and takes precedence over variable files and
+    NOMAD_VAR_<key> environment variables. Requires -hcl2.
+
+  -var-file=path
+    Sets the values of variables declared in the job file from a file. This
+    flag can be specified multiple times. Requires -hcl2.
+
   -verbose
     Increase diff verbosity.
 `
@@ -88,7 +101,10 @@ func (c *JobPlanCommand) AutocompleteFlags() complete.Flags {
 	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
 		complete.Flags{
 			"-diff":            complete.PredictNothing,
+			"-hcl2":            complete.PredictNothing,
 			"-policy-override": complete.PredictNothing,
+			"-var":             complete.PredictAnything,
+			"-var-file":        complete.PredictFiles("*.hcl"),
 			"-verbose":         complete.PredictNothing,
 		})
 }
@@ -106,6 +122,7 @@ func (c *JobPlanCommand) Run(args []string) int {
 	flags.BoolVar(&diff, "diff", true, "")
 	flags.BoolVar(&policyOverride, "policy-override", false, "")
 	flags.BoolVar(&verbose, "verbose", false, "")
+	c.JobGetter.setFlags(flags)
 
 	if err := flags.Parse(args); err != nil {
 		return 255
@@ -283,7 +300,7 @@ func formatDryRun(resp *api.JobPlanResponse, job *api.Job) string {
 		out = "[bold][green]- All tasks successfully allocated.[reset]\n"
 	} else {
 		// Change the output depending on if we are a system job or not
-		if job.Type != nil && *job.Type == "system" {
+		if job.Type != nil && (*job.Type == "system" || *job.Type == "sysbatch") {
 			out = "[bold][yellow]- WARNING: Failed to place allocations on all nodes.[reset]\n"
 		} else {
 			out = "[bold][yellow]- WARNING: Failed to place all allocations.[reset]\n"
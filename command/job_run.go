@@ -75,6 +75,10 @@ Run Options:
     the evaluation ID will be printed to the screen, which can be used to
     examine the evaluation using the eval-status command.
 
+  -hcl2
+    Parses the job file as HCL2, which supports variables, locals, dynamic
+    blocks and functions. By default, job files are parsed as HCL1.
+
   -output
     Output the JSON that would be submitted to the HTTP API without submitting
     the job.
@@ -88,6 +92,15 @@ Run Options:
     the job file. This overrides the token found in $VAULT_TOKEN environment
     variable and that found in the job.
 
+  -var 'key=value'
+    Sets the value of a variable declared in the job file. This flag can be
+    specified multiple times and takes precedence over variable files and
+    NOMAD_VAR_<key> environment variables. Requires -hcl2.
+
+  -var-file=path
+    Sets the values of variables declared in the job file from a file. This
+    flag can be specified multiple times. Requires -hcl2.
+
   -verbose
     Display full information.
 `
@@ -103,6 +116,9 @@ func (c *JobRunCommand) AutocompleteFlags() complete.Flags {
 		complete.Flags{
 			"-check-index":     complete.PredictNothing,
 			"-detach":          complete.PredictNothing,
+			"-hcl2":            complete.PredictNothing,
+			"-var":             complete.PredictAnything,
+			"-var-file":        complete.PredictFiles("*.hcl"),
 			"-verbose":         complete.PredictNothing,
 			"-vault-token":     complete.PredictAnything,
 			"-output":          complete.PredictNothing,
@@ -128,6 +144,7 @@ func (c *JobRunCommand) Run(args []string) int {
 	flags.BoolVar(&override, "policy-override", false, "")
 	flags.StringVar(&checkIndexStr, "check-index", "", "")
 	flags.StringVar(&vaultToken, "vault-token", "", "")
+	c.JobGetter.setFlags(flags)
 
 	if err := flags.Parse(args); err != nil {
 		return 1
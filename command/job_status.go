@@ -24,8 +24,15 @@ type JobStatusCommand struct {
 	evals     bool
 	allAllocs bool
 	verbose   bool
+	launches  int
 }
 
+const (
+	// defaultPeriodicLaunches is the number of upcoming launches of a periodic
+	// job that are displayed by default.
+	defaultPeriodicLaunches = 5
+)
+
 func (c *JobStatusCommand) Help() string {
 	helpText := `
 Usage: nomad status [options] <job>
@@ -50,6 +57,9 @@ Status Options:
     Display all allocations matching the job ID, including those from an older
     instance of the job.
 
+  -launches <n>
+    Number of upcoming launches of a periodic job to display. Defaults to 5.
+
   -verbose
     Display full information.
 `
@@ -66,6 +76,7 @@ func (c *JobStatusCommand) AutocompleteFlags() complete.Flags {
 			"-all-allocs": complete.PredictNothing,
 			"-evals":      complete.PredictNothing,
 			"-short":      complete.PredictNothing,
+			"-launches":   complete.PredictAnything,
 			"-verbose":    complete.PredictNothing,
 		})
 }
@@ -96,6 +107,7 @@ func (c *JobStatusCommand) Run(args []string) int {
 	flags.BoolVar(&c.evals, "evals", false, "")
 	flags.BoolVar(&c.allAllocs, "all-allocs", false, "")
 	flags.BoolVar(&c.verbose, "verbose", false, "")
+	flags.IntVar(&c.launches, "launches", defaultPeriodicLaunches, "")
 
 	if err := flags.Parse(args); err != nil {
 		return 1
@@ -223,6 +235,27 @@ func (c *JobStatusCommand) Run(args []string) int {
 	return 0
 }
 
+// periodicNextLaunches returns up to n launch times of the periodic job after
+// the passed time, evaluated in the job's time zone.
+func periodicNextLaunches(job *api.Job, from time.Time, n int) ([]time.Time, error) {
+	location, err := job.Periodic.GetLocation()
+	if err != nil {
+		return nil, err
+	}
+	return job.Periodic.NextLaunches(from.In(location), n)
+}
+
+// formatPeriodicLaunches formats launch times along with how far from now
+// they are.
+func formatPeriodicLaunches(launches []time.Time, now time.Time) string {
+	rows := make([]string, len(launches)+1)
+	rows[0] = "Launch|In"
+	for i, launch := range launches {
+		rows[i+1] = fmt.Sprintf("%s|%s", formatTime(launch), formatTimeDifference(now, launch, time.Second))
+	}
+	return formatList(rows)
+}
+
 // outputPeriodicInfo prints information about the passed periodic job. If a
 // request fails, an error is returned.
 func (c *JobStatusCommand) outputPeriodicInfo(client *api.Client, job *api.Job) error {
@@ -231,6 +264,23 @@ func (c *JobStatusCommand) outputPeriodicInfo(client *api.Client, job *api.Job)
 		return err
 	}
 
+	// Output the upcoming launches
+	if c.launches > 0 && !*job.Stop {
+		location, err := job.Periodic.GetLocation()
+		if err != nil {
+			return fmt.Errorf("Error loading periodic job time zone: %s", err)
+		}
+		now := time.Now().In(location)
+		launches, err := periodicNextLaunches(job, now, c.launches)
+		if err != nil {
+			return fmt.Errorf("Error determining periodic launches: %s", err)
+		}
+		if len(launches) != 0 {
+			c.Ui.Output(c.Colorize().Color("\n[bold]Upcoming Launches[reset]"))
+			c.Ui.Output(formatPeriodicLaunches(launches, now))
+		}
+	}
+
 	// Generate the prefix that matches launched jobs from the periodic job.
 	prefix := fmt.Sprintf("%s%s", *job.ID, structs.PeriodicLaunchSuffix)
 	children, _, err := client.Jobs().PrefixList(prefix)
@@ -271,6 +321,9 @@ func (c *JobStatusCommand) outputParameterizedInfo(client *api.Client, job *api.
 	parameterizedJob[0] = fmt.Sprintf("Payload|%s", job.ParameterizedJob.Payload)
 	parameterizedJob[1] = fmt.Sprintf("Required Metadata|%v", strings.Join(job.ParameterizedJob.MetaRequired, ", "))
 	parameterizedJob[2] = fmt.Sprintf("Optional Metadata|%v", strings.Join(job.ParameterizedJob.MetaOptional, ", "))
+	if job.ParameterizedJob.MaxConcurrent > 0 {
+		parameterizedJob = append(parameterizedJob, fmt.Sprintf("Max Concurrent|%d", job.ParameterizedJob.MaxConcurrent))
+	}
 	c.Ui.Output(formatKV(parameterizedJob))
 
 	// Output the summary
@@ -535,8 +588,8 @@ func (c *JobStatusCommand) outputJobSummary(client *api.Client, job *api.Job) er
 			c.Ui.Output(c.Colorize().Color("\n[bold]Children Job Summary[reset]"))
 		}
 		summaries := make([]string, 2)
-		summaries[0] = "Pending|Running|Dead"
-		summaries[1] = fmt.Sprintf("%d|%d|%d",
+		summaries[0] = "Queued|Pending|Running|Dead"
+		summaries[1] = fmt.Sprintf("%d|%d|%d|%d", summary.Children.Queued,
 			summary.Children.Pending, summary.Children.Running, summary.Children.Dead)
 		c.Ui.Output(formatList(summaries))
 	}
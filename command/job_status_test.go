@@ -9,6 +9,7 @@ import (
 
 	"github.com/hashicorp/nomad/api"
 	"github.com/hashicorp/nomad/command/agent"
+	"github.com/hashicorp/nomad/helper"
 	"github.com/hashicorp/nomad/nomad/mock"
 	"github.com/hashicorp/nomad/nomad/structs"
 	"github.com/hashicorp/nomad/testutil"
@@ -390,3 +391,33 @@ func waitForSuccess(ui cli.Ui, client *api.Client, length int, t *testing.T, eva
 	monErr := mon.monitor(evalId, false)
 	return monErr
 }
+
+func TestJobStatusCommand_PeriodicLaunches(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	job := &api.Job{
+		Periodic: &api.PeriodicConfig{
+			Spec:     helper.StringToPtr("0 9 * * 1-5"),
+			Specs:    []string{"0 12 * * 0,6"},
+			Exclude:  []string{"2019-12-25"},
+			TimeZone: helper.StringToPtr("America/New_York"),
+		},
+	}
+	job.Periodic.Canonicalize()
+
+	location, err := time.LoadLocation("America/New_York")
+	require.NoError(err)
+	now := time.Date(2019, 12, 24, 10, 0, 0, 0, location)
+
+	launches, err := periodicNextLaunches(job, now, 2)
+	require.NoError(err)
+	require.Equal([]time.Time{
+		time.Date(2019, 12, 26, 9, 0, 0, 0, location),
+		time.Date(2019, 12, 27, 9, 0, 0, 0, location),
+	}, launches)
+
+	out := formatPeriodicLaunches(launches, now)
+	require.Contains(out, formatTime(launches[0]))
+	require.Contains(out, "47h")
+}
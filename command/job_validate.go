@@ -27,6 +27,21 @@ Alias: nomad validate
   If the supplied path is "-", the jobfile is read from stdin. Otherwise
   it is read from the file at the supplied path or downloaded and
   read from URL specified.
+
+Validate Options:
+
+  -hcl2
+    Parses the job file as HCL2, which supports variables, locals, dynamic
+    blocks and functions. By default, job files are parsed as HCL1.
+
+  -var 'key=value'
+    Sets the value of a variable declared in the job file. This flag can be
+    specified multiple times and takes precedence over variable files and
+    NOMAD_VAR_<key> environment variables. Requires -hcl2.
+
+  -var-file=path
+    Sets the values of variables declared in the job file from a file. This
+    flag can be specified multiple times. Requires -hcl2.
 `
 	return strings.TrimSpace(helpText)
 }
@@ -36,7 +51,11 @@ func (c *JobValidateCommand) Synopsis() string {
 }
 
 func (c *JobValidateCommand) AutocompleteFlags() complete.Flags {
-	return nil
+	return complete.Flags{
+		"-hcl2":     complete.PredictNothing,
+		"-var":      complete.PredictAnything,
+		"-var-file": complete.PredictFiles("*.hcl"),
+	}
 }
 
 func (c *JobValidateCommand) AutocompleteArgs() complete.Predictor {
@@ -48,6 +67,7 @@ func (c *JobValidateCommand) Name() string { return "job validate" }
 func (c *JobValidateCommand) Run(args []string) int {
 	flags := c.Meta.FlagSet(c.Name(), FlagSetNone)
 	flags.Usage = func() { c.Ui.Output(c.Help()) }
+	c.JobGetter.setFlags(flags)
 	if err := flags.Parse(args); err != nil {
 		return 1
 	}
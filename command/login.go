@@ -0,0 +1,129 @@
+package command
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/posener/complete"
+)
+
+type LoginCommand struct {
+	Meta
+}
+
+func (c *LoginCommand) Help() string {
+	helpText := `
+Usage: nomad login [options]
+
+  Login is used to exchange the JWT or OIDC identity token of an external
+  identity provider for a Nomad ACL token. The identity token is validated by
+  the given ACL auth method, and the created ACL token is granted the policies
+  and roles of the binding rules its identity matches. Does not require a
+  token.
+
+General Options:
+
+  ` + generalOptionsUsage() + `
+
+Login Options:
+
+  -method=""
+    Sets the name of the ACL auth method to login with. Required.
+
+  -login-token=""
+    Sets the identity token issued by the identity provider. Required.
+
+  -json
+    Output the ACL token in a JSON format.
+
+  -t
+    Format and display the ACL token using a Go template.
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *LoginCommand) AutocompleteFlags() complete.Flags {
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
+		complete.Flags{
+			"method":      complete.PredictAnything,
+			"login-token": complete.PredictAnything,
+			"-json":       complete.PredictNothing,
+			"-t":          complete.PredictAnything,
+		})
+}
+
+func (c *LoginCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (c *LoginCommand) Synopsis() string {
+	return "Login to Nomad using an auth method"
+}
+
+func (c *LoginCommand) Name() string { return "login" }
+
+func (c *LoginCommand) Run(args []string) int {
+	var method, loginToken, tmpl string
+	var json bool
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
+	flags.Usage = func() { c.Ui.Output(c.Help()) }
+	flags.StringVar(&method, "method", "", "")
+	flags.StringVar(&loginToken, "login-token", "", "")
+	flags.BoolVar(&json, "json", false, "")
+	flags.StringVar(&tmpl, "t", "", "")
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	// Check that we got no arguments
+	args = flags.Args()
+	if l := len(args); l != 0 {
+		c.Ui.Error("This command takes no arguments")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
+	if method == "" {
+		c.Ui.Error("ACL auth method must be specified using the -method flag")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+	if loginToken == "" {
+		c.Ui.Error("Login token must be specified using the -login-token flag")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
+	// Get the HTTP client
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+
+	// Exchange the login token for an ACL token
+	req := &api.ACLLoginRequest{
+		AuthMethodName: method,
+		LoginToken:     loginToken,
+	}
+	token, _, err := client.ACLTokens().Login(req, nil)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error logging in: %s", err))
+		return 1
+	}
+
+	if json || len(tmpl) > 0 {
+		out, err := Format(json, tmpl, token)
+		if err != nil {
+			c.Ui.Error(err.Error())
+			return 1
+		}
+
+		c.Ui.Output(out)
+		return 0
+	}
+
+	c.Ui.Output(formatKVACLToken(token))
+	return 0
+}
@@ -0,0 +1,77 @@
+package command
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"testing"
+
+	"github.com/hashicorp/nomad/command/agent"
+	"github.com/hashicorp/nomad/lib/auth/jwt"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/mitchellh/cli"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoginCommand(t *testing.T) {
+	require := require.New(t)
+	t.Parallel()
+	config := func(c *agent.Config) {
+		c.ACL.Enabled = true
+	}
+
+	srv, _, url := testServer(t, true, config)
+	state := srv.Agent.Server().State()
+	defer srv.Shutdown()
+
+	// Bootstrap an initial ACL token
+	token := srv.RootToken
+	require.NotNil(token, "failed to bootstrap ACL token")
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(err)
+	pubKey, err := jwt.EncodePublicKeyPEM(key.Public())
+	require.NoError(err)
+
+	method := mock.ACLAuthMethod()
+	method.Config.JWTValidationPubKeys = []string{pubKey}
+	policy := mock.ACLPolicy()
+	policy.Name = "engineering"
+	rule := mock.ACLBindingRule()
+	rule.AuthMethod = method.Name
+	require.NoError(state.UpsertACLAuthMethods(1000, []*structs.ACLAuthMethod{method}))
+	require.NoError(state.UpsertACLPolicies(1001, []*structs.ACLPolicy{policy}))
+	require.NoError(state.UpsertACLBindingRules(1002, []*structs.ACLBindingRule{rule}))
+
+	sign := func(groups ...string) string {
+		loginToken, err := jwt.Sign(map[string]interface{}{
+			"iss":    method.Config.BoundIssuer,
+			"aud":    "nomad",
+			"sub":    "alice",
+			"groups": groups,
+		}, "", key)
+		require.NoError(err)
+		return loginToken
+	}
+
+	ui := new(cli.MockUi)
+	cmd := &LoginCommand{Meta: Meta{Ui: ui, flagAddress: url}}
+
+	// The login token is required
+	code := cmd.Run([]string{"-address=" + url, "-method=" + method.Name})
+	require.Equal(1, code)
+
+	// Login with an identity matching no binding rule
+	code = cmd.Run([]string{"-address=" + url, "-method=" + method.Name, "-login-token=" + sign("sales")})
+	require.Equal(1, code)
+	require.Contains(ui.ErrorWriter.String(), "Permission denied")
+
+	// Login with an identity matching the binding rule
+	code = cmd.Run([]string{"-address=" + url, "-method=" + method.Name, "-login-token=" + sign("engineering")})
+	require.Equal(0, code)
+
+	out := ui.OutputWriter.String()
+	require.Contains(out, "[engineering]")
+	require.Contains(out, "login via "+method.Name)
+}
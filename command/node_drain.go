@@ -50,7 +50,8 @@ Node Drain Options:
     Return immediately instead of entering monitor mode.
 
   -monitor
-    Enter monitor mode directly without modifying the drain status.
+    Enter monitor mode directly without modifying the drain status. The
+    monitor reports the progress of the drain and the jobs blocking it.
 
   -force
     Force remove allocations off the node immediately.
@@ -63,6 +64,10 @@ Node Drain Options:
     Ignore system allows the drain to complete without stopping system job
     allocations. By default system jobs are stopped last.
 
+  -order-by-priority
+    Order by priority drains the allocations of lower priority jobs before
+    migrating the allocations of higher priority jobs.
+
   -keep-ineligible
     Keep ineligible will maintain the node's scheduling ineligibility even if
     the drain is being disabled. This is useful when an existing drain is being
@@ -84,16 +89,17 @@ func (c *NodeDrainCommand) Synopsis() string {
 func (c *NodeDrainCommand) AutocompleteFlags() complete.Flags {
 	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
 		complete.Flags{
-			"-disable":         complete.PredictNothing,
-			"-enable":          complete.PredictNothing,
-			"-deadline":        complete.PredictAnything,
-			"-detach":          complete.PredictNothing,
-			"-force":           complete.PredictNothing,
-			"-no-deadline":     complete.PredictNothing,
-			"-ignore-system":   complete.PredictNothing,
-			"-keep-ineligible": complete.PredictNothing,
-			"-self":            complete.PredictNothing,
-			"-yes":             complete.PredictNothing,
+			"-disable":           complete.PredictNothing,
+			"-enable":            complete.PredictNothing,
+			"-deadline":          complete.PredictAnything,
+			"-detach":            complete.PredictNothing,
+			"-force":             complete.PredictNothing,
+			"-no-deadline":       complete.PredictNothing,
+			"-ignore-system":     complete.PredictNothing,
+			"-order-by-priority": complete.PredictNothing,
+			"-keep-ineligible":   complete.PredictNothing,
+			"-self":              complete.PredictNothing,
+			"-yes":               complete.PredictNothing,
 		})
 }
 
@@ -116,7 +122,7 @@ func (c *NodeDrainCommand) Name() string { return "node-drain" }
 
 func (c *NodeDrainCommand) Run(args []string) int {
 	var enable, disable, detach, force,
-		noDeadline, ignoreSystem, keepIneligible,
+		noDeadline, ignoreSystem, orderByPriority, keepIneligible,
 		self, autoYes, monitor bool
 	var deadline string
 
@@ -129,6 +135,7 @@ func (c *NodeDrainCommand) Run(args []string) int {
 	flags.BoolVar(&force, "force", false, "Force immediate drain")
 	flags.BoolVar(&noDeadline, "no-deadline", false, "Drain node with no deadline")
 	flags.BoolVar(&ignoreSystem, "ignore-system", false, "Do not drain system job allocations from the node")
+	flags.BoolVar(&orderByPriority, "order-by-priority", false, "Drain lower priority jobs first")
 	flags.BoolVar(&keepIneligible, "keep-ineligible", false, "Do not update the nodes scheduling eligibility")
 	flags.BoolVar(&self, "self", false, "")
 	flags.BoolVar(&autoYes, "yes", false, "Automatic yes to prompts.")
@@ -161,7 +168,7 @@ func (c *NodeDrainCommand) Run(args []string) int {
 	}
 
 	// Validate a compatible set of flags were set
-	if disable && (deadline != "" || force || noDeadline || ignoreSystem) {
+	if disable && (deadline != "" || force || noDeadline || ignoreSystem || orderByPriority) {
 		c.Ui.Error("-disable can't be combined with flags configuring drain strategy")
 		c.Ui.Error(commandErrorText(c))
 		return 1
@@ -291,6 +298,7 @@ func (c *NodeDrainCommand) Run(args []string) int {
 		spec = &api.DrainSpec{
 			Deadline:         d,
 			IgnoreSystemJobs: ignoreSystem,
+			OrderByPriority:  orderByPriority,
 		}
 	}
 
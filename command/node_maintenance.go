@@ -0,0 +1,47 @@
+package command
+
+import (
+	"strings"
+
+	"github.com/mitchellh/cli"
+)
+
+type NodeMaintenanceCommand struct {
+	Meta
+}
+
+func (f *NodeMaintenanceCommand) Name() string { return "maintenance" }
+
+func (f *NodeMaintenanceCommand) Run(args []string) int {
+	return cli.RunResultHelp
+}
+
+func (f *NodeMaintenanceCommand) Synopsis() string {
+	return "Interact with node maintenance plans"
+}
+
+func (f *NodeMaintenanceCommand) Help() string {
+	helpText := `
+Usage: nomad node maintenance <subcommand> [options] [args]
+
+  This command groups subcommands for interacting with maintenance plans.
+  Maintenance plans drain the nodes matching a selector in batches: the next
+  batch of nodes is only drained once all the nodes of the previous batch are
+  drained and marked eligible again.
+
+  Plan the maintenance of the nodes of a datacenter, two nodes at a time:
+
+      $ nomad node maintenance plan -datacenter dc1 -batch-size 2
+
+  Display the status of a maintenance plan:
+
+      $ nomad node maintenance status <plan_id>
+
+  Cancel a maintenance plan:
+
+      $ nomad node maintenance cancel <plan_id>
+
+  Please see the individual subcommand help for detailed usage information.
+`
+	return strings.TrimSpace(helpText)
+}
@@ -0,0 +1,101 @@
+package command
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/posener/complete"
+)
+
+type NodeMaintenanceCancelCommand struct {
+	Meta
+}
+
+func (c *NodeMaintenanceCancelCommand) Help() string {
+	helpText := `
+Usage: nomad node maintenance cancel [options] <plan id>
+
+  Cancel an active maintenance plan. No further node is drained by the plan.
+  The nodes already draining keep draining and can be stopped with "nomad node
+  drain -disable".
+
+General Options:
+
+  ` + generalOptionsUsage() + `
+
+Maintenance Cancel Options:
+
+  -verbose
+    Display full information.
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *NodeMaintenanceCancelCommand) Synopsis() string {
+	return "Cancel a maintenance plan"
+}
+
+func (c *NodeMaintenanceCancelCommand) AutocompleteFlags() complete.Flags {
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
+		complete.Flags{
+			"-verbose": complete.PredictNothing,
+		})
+}
+
+func (c *NodeMaintenanceCancelCommand) AutocompleteArgs() complete.Predictor {
+	return maintenancePlanPredictor(&c.Meta)
+}
+
+func (c *NodeMaintenanceCancelCommand) Name() string { return "node maintenance cancel" }
+
+func (c *NodeMaintenanceCancelCommand) Run(args []string) int {
+	var verbose bool
+
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
+	flags.Usage = func() { c.Ui.Output(c.Help()) }
+	flags.BoolVar(&verbose, "verbose", false, "")
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	// Check that we got exactly one argument
+	args = flags.Args()
+	if l := len(args); l != 1 {
+		c.Ui.Error("This command takes one argument: <plan id>")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
+	// Truncate the id unless full length is requested
+	length := shortId
+	if verbose {
+		length = fullId
+	}
+
+	// Get the HTTP client
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+
+	// Do a prefix lookup
+	plan, possible, err := getMaintenancePlan(client.Maintenance(), args[0])
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error retrieving maintenance plan: %s", err))
+		return 1
+	}
+	if len(possible) != 0 {
+		c.Ui.Error(fmt.Sprintf("Prefix matched multiple maintenance plans\n\n%s", formatMaintenancePlans(possible, length)))
+		return 1
+	}
+
+	if _, _, err := client.Maintenance().Cancel(plan.ID, nil); err != nil {
+		c.Ui.Error(fmt.Sprintf("Error cancelling maintenance plan: %s", err))
+		return 1
+	}
+
+	c.Ui.Output(fmt.Sprintf("Maintenance plan %q cancelled", limit(plan.ID, length)))
+	return 0
+}
@@ -0,0 +1,93 @@
+package command
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/nomad/testutil"
+	"github.com/mitchellh/cli"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNodeMaintenanceCancelCommand_Implements(t *testing.T) {
+	t.Parallel()
+	var _ cli.Command = &NodeMaintenanceCancelCommand{}
+}
+
+func TestNodeMaintenanceCancelCommand_Fails(t *testing.T) {
+	t.Parallel()
+	srv, _, url := testServer(t, false, nil)
+	defer srv.Shutdown()
+
+	ui := new(cli.MockUi)
+	cmd := &NodeMaintenanceCancelCommand{Meta: Meta{Ui: ui}}
+
+	// Fails on misuse
+	if code := cmd.Run([]string{"some", "bad", "args"}); code != 1 {
+		t.Fatalf("expected exit code 1, got: %d", code)
+	}
+	if out := ui.ErrorWriter.String(); !strings.Contains(out, commandErrorText(cmd)) {
+		t.Fatalf("expected help output, got: %s", out)
+	}
+	ui.ErrorWriter.Reset()
+
+	// Fails on connection failure
+	if code := cmd.Run([]string{"-address=nope", "12345678-abcd-efab-cdef-123456789abc"}); code != 1 {
+		t.Fatalf("expected exit code 1, got: %d", code)
+	}
+	if out := ui.ErrorWriter.String(); !strings.Contains(out, "Error retrieving maintenance plan") {
+		t.Fatalf("expected failed query error, got: %s", out)
+	}
+	ui.ErrorWriter.Reset()
+
+	// Fails on non-existent plan
+	if code := cmd.Run([]string{"-address=" + url, "12345678"}); code != 1 {
+		t.Fatalf("expected exit 1, got: %d", code)
+	}
+	if out := ui.ErrorWriter.String(); !strings.Contains(out, "matched no maintenance plans") {
+		t.Fatalf("expected not found error, got: %s", out)
+	}
+}
+
+func TestNodeMaintenanceCancelCommand_Run(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	srv, client, url := testServer(t, true, nil)
+	defer srv.Shutdown()
+
+	// Wait for a node to appear
+	testutil.WaitForResult(func() (bool, error) {
+		nodes, _, err := client.Nodes().List(nil)
+		if err != nil {
+			return false, err
+		}
+		if len(nodes) == 0 {
+			return false, fmt.Errorf("missing node")
+		}
+		return true, nil
+	}, func(err error) {
+		t.Fatalf("err: %s", err)
+	})
+
+	// Plan a maintenance window starting later
+	plan, _, err := client.Maintenance().Register(&api.MaintenancePlan{
+		Datacenters: []string{"dc1"},
+		BatchSize:   1,
+		StartTime:   time.Now().Add(time.Hour),
+	}, nil)
+	require.Nil(err)
+
+	// Cancel it by prefix
+	ui := new(cli.MockUi)
+	cmd := &NodeMaintenanceCancelCommand{Meta: Meta{Ui: ui}}
+	code := cmd.Run([]string{"-address=" + url, plan.ID[:8]})
+	require.Equal(0, code, ui.ErrorWriter.String())
+	require.Contains(ui.OutputWriter.String(), "cancelled")
+
+	plan, _, err = client.Maintenance().Info(plan.ID, nil)
+	require.Nil(err)
+	require.Equal(api.MaintenancePlanStatusCancelled, plan.Status)
+}
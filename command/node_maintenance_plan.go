@@ -0,0 +1,215 @@
+package command
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/nomad/api"
+	flaghelper "github.com/hashicorp/nomad/helper/flag-helpers"
+	"github.com/posener/complete"
+)
+
+type NodeMaintenancePlanCommand struct {
+	Meta
+}
+
+func (c *NodeMaintenancePlanCommand) Help() string {
+	helpText := `
+Usage: nomad node maintenance plan [options]
+
+  Plan the maintenance of the nodes matching a selector. The nodes are drained
+  in batches once the maintenance window starts, in the order of their names.
+  The next batch of nodes is only drained once all the nodes of the previous
+  batch are drained and marked eligible again with "nomad node eligibility
+  -enable" when their maintenance is done. Nodes must match all the given
+  selector options and down nodes are ignored.
+
+General Options:
+
+  ` + generalOptionsUsage() + `
+
+Maintenance Plan Options:
+
+  -datacenter <datacenter>
+    Select the nodes of the datacenter. Can be specified multiple times.
+
+  -class <node class>
+    Select the nodes of the node class.
+
+  -meta <key>=<value>
+    Select the nodes with the given metadata. Can be specified multiple times.
+
+  -batch-size <n>
+    Number of nodes drained at the same time. Defaults to 1.
+
+  -start <time>
+    Start of the maintenance window, either as an RFC 3339 time or as a
+    duration from now. Defaults to now.
+
+  -deadline <duration>
+    Set the deadline by which all allocations must be moved off each node.
+    Remaining allocations after the deadline are forced removed from the node.
+    If unspecified, a default deadline of one hour is applied.
+
+  -no-deadline
+    No deadline allows the allocations to drain off the nodes without being
+    force stopped after a certain deadline.
+
+  -ignore-system
+    Ignore system allows the drains to complete without stopping system job
+    allocations.
+
+  -verbose
+    Display full information.
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *NodeMaintenancePlanCommand) Synopsis() string {
+	return "Plan the maintenance of nodes drained in batches"
+}
+
+func (c *NodeMaintenancePlanCommand) AutocompleteFlags() complete.Flags {
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
+		complete.Flags{
+			"-datacenter":    complete.PredictAnything,
+			"-class":         complete.PredictAnything,
+			"-meta":          complete.PredictAnything,
+			"-batch-size":    complete.PredictAnything,
+			"-start":         complete.PredictAnything,
+			"-deadline":      complete.PredictAnything,
+			"-no-deadline":   complete.PredictNothing,
+			"-ignore-system": complete.PredictNothing,
+			"-verbose":       complete.PredictNothing,
+		})
+}
+
+func (c *NodeMaintenancePlanCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (c *NodeMaintenancePlanCommand) Name() string { return "node maintenance plan" }
+
+func (c *NodeMaintenancePlanCommand) Run(args []string) int {
+	var noDeadline, ignoreSystem, verbose bool
+	var class, start, deadline string
+	var datacenters, meta []string
+	var batchSize int
+
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
+	flags.Usage = func() { c.Ui.Output(c.Help()) }
+	flags.Var((*flaghelper.StringFlag)(&datacenters), "datacenter", "")
+	flags.StringVar(&class, "class", "", "")
+	flags.Var((*flaghelper.StringFlag)(&meta), "meta", "")
+	flags.IntVar(&batchSize, "batch-size", 1, "")
+	flags.StringVar(&start, "start", "", "")
+	flags.StringVar(&deadline, "deadline", "", "")
+	flags.BoolVar(&noDeadline, "no-deadline", false, "")
+	flags.BoolVar(&ignoreSystem, "ignore-system", false, "")
+	flags.BoolVar(&verbose, "verbose", false, "")
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	// Check that we got no arguments
+	if l := len(flags.Args()); l != 0 {
+		c.Ui.Error("This command takes no arguments")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
+	if len(datacenters) == 0 && class == "" && len(meta) == 0 {
+		c.Ui.Error("At least one of -datacenter, -class or -meta must be specified")
+		return 1
+	}
+	if noDeadline && deadline != "" {
+		c.Ui.Error("-no-deadline can't be combined with -deadline")
+		return 1
+	}
+
+	plan := &api.MaintenancePlan{
+		Datacenters:      datacenters,
+		NodeClass:        class,
+		BatchSize:        batchSize,
+		Deadline:         defaultDrainDuration,
+		IgnoreSystemJobs: ignoreSystem,
+	}
+
+	// Build the meta
+	if len(meta) != 0 {
+		plan.Meta = make(map[string]string, len(meta))
+		for _, m := range meta {
+			split := strings.SplitN(m, "=", 2)
+			if len(split) != 2 {
+				c.Ui.Error(fmt.Sprintf("Error parsing meta value: %v", m))
+				return 1
+			}
+			plan.Meta[split[0]] = split[1]
+		}
+	}
+
+	if start != "" {
+		startTime, err := parseMaintenanceStart(start, time.Now())
+		if err != nil {
+			c.Ui.Error(err.Error())
+			return 1
+		}
+		plan.StartTime = startTime
+	}
+
+	if noDeadline {
+		plan.Deadline = 0
+	} else if deadline != "" {
+		d, err := time.ParseDuration(deadline)
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("Failed to parse deadline %q: %v", deadline, err))
+			return 1
+		}
+		if d <= 0 {
+			c.Ui.Error("A positive drain deadline must be given")
+			return 1
+		}
+		plan.Deadline = d
+	}
+
+	// Truncate the id unless full length is requested
+	length := shortId
+	if verbose {
+		length = fullId
+	}
+
+	// Get the HTTP client
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+
+	plan, _, err = client.Maintenance().Register(plan, nil)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error creating maintenance plan: %s", err))
+		return 1
+	}
+
+	c.Ui.Output(c.Colorize().Color(formatMaintenancePlan(plan, length)))
+	return 0
+}
+
+// parseMaintenanceStart parses the start of a maintenance window given either
+// as an RFC 3339 time or as a duration from now.
+func parseMaintenanceStart(start string, now time.Time) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, start); err == nil {
+		return t, nil
+	}
+
+	d, err := time.ParseDuration(start)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("Failed to parse start %q: must be an RFC 3339 time or a duration", start)
+	}
+	if d < 0 {
+		return time.Time{}, fmt.Errorf("Start duration must not be negative: %v", d)
+	}
+	return now.Add(d), nil
+}
@@ -0,0 +1,127 @@
+package command
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/nomad/testutil"
+	"github.com/mitchellh/cli"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNodeMaintenancePlanCommand_Implements(t *testing.T) {
+	t.Parallel()
+	var _ cli.Command = &NodeMaintenancePlanCommand{}
+}
+
+func TestNodeMaintenancePlanCommand_Fails(t *testing.T) {
+	t.Parallel()
+	ui := new(cli.MockUi)
+	cmd := &NodeMaintenancePlanCommand{Meta: Meta{Ui: ui}}
+
+	// Fails on misuse
+	if code := cmd.Run([]string{"some", "bad", "args"}); code != 1 {
+		t.Fatalf("expected exit code 1, got: %d", code)
+	}
+	if out := ui.ErrorWriter.String(); !strings.Contains(out, commandErrorText(cmd)) {
+		t.Fatalf("expected help output, got: %s", out)
+	}
+	ui.ErrorWriter.Reset()
+
+	// Fails without a node selector
+	if code := cmd.Run([]string{"-batch-size=2"}); code != 1 {
+		t.Fatalf("expected exit code 1, got: %d", code)
+	}
+	if out := ui.ErrorWriter.String(); !strings.Contains(out, "At least one of -datacenter, -class or -meta") {
+		t.Fatalf("expected selector error, got: %s", out)
+	}
+	ui.ErrorWriter.Reset()
+
+	// Fails if both deadline and no-deadline are specified
+	if code := cmd.Run([]string{"-datacenter=dc1", "-deadline=1h", "-no-deadline"}); code != 1 {
+		t.Fatalf("expected exit code 1, got: %d", code)
+	}
+	if out := ui.ErrorWriter.String(); !strings.Contains(out, "-no-deadline can't be combined with -deadline") {
+		t.Fatalf("expected deadline error, got: %s", out)
+	}
+	ui.ErrorWriter.Reset()
+
+	// Fails on an invalid start
+	if code := cmd.Run([]string{"-datacenter=dc1", "-start=tomorrow"}); code != 1 {
+		t.Fatalf("expected exit code 1, got: %d", code)
+	}
+	if out := ui.ErrorWriter.String(); !strings.Contains(out, "Failed to parse start") {
+		t.Fatalf("expected start error, got: %s", out)
+	}
+	ui.ErrorWriter.Reset()
+
+	// Fails on connection failure
+	if code := cmd.Run([]string{"-address=nope", "-datacenter=dc1"}); code != 1 {
+		t.Fatalf("expected exit code 1, got: %d", code)
+	}
+	if out := ui.ErrorWriter.String(); !strings.Contains(out, "Error creating maintenance plan") {
+		t.Fatalf("expected failed plan creation error, got: %s", out)
+	}
+}
+
+func TestNodeMaintenancePlanCommand_Run(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	srv, client, url := testServer(t, true, nil)
+	defer srv.Shutdown()
+
+	// Wait for a node to appear
+	var nodeID string
+	testutil.WaitForResult(func() (bool, error) {
+		nodes, _, err := client.Nodes().List(nil)
+		if err != nil {
+			return false, err
+		}
+		if len(nodes) == 0 {
+			return false, fmt.Errorf("missing node")
+		}
+		nodeID = nodes[0].ID
+		return true, nil
+	}, func(err error) {
+		t.Fatalf("err: %s", err)
+	})
+
+	// Plan a maintenance window starting later
+	ui := new(cli.MockUi)
+	cmd := &NodeMaintenancePlanCommand{Meta: Meta{Ui: ui}}
+	code := cmd.Run([]string{"-address=" + url, "-datacenter=dc1", "-start=1h", "-no-deadline"})
+	require.Equal(0, code, ui.ErrorWriter.String())
+
+	out := ui.OutputWriter.String()
+	require.Contains(out, api.MaintenancePlanStatusPending)
+	require.Contains(out, nodeID[:8])
+
+	plans, _, err := client.Maintenance().List(nil)
+	require.Nil(err)
+	require.Len(plans, 1)
+	require.Zero(plans[0].Deadline)
+	require.True(plans[0].StartTime.After(time.Now().Add(50 * time.Minute)))
+}
+
+func TestNodeMaintenancePlanCommand_ParseStart(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	now := time.Now()
+
+	start, err := parseMaintenanceStart("2h", now)
+	require.Nil(err)
+	require.Equal(now.Add(2*time.Hour), start)
+
+	start, err = parseMaintenanceStart("2020-01-02T15:04:05Z", now)
+	require.Nil(err)
+	require.Equal(time.Date(2020, 1, 2, 15, 4, 5, 0, time.UTC), start)
+
+	_, err = parseMaintenanceStart("-1h", now)
+	require.Error(err)
+
+	_, err = parseMaintenanceStart("tomorrow", now)
+	require.Error(err)
+}
@@ -0,0 +1,258 @@
+package command
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/posener/complete"
+)
+
+type NodeMaintenanceStatusCommand struct {
+	Meta
+}
+
+func (c *NodeMaintenanceStatusCommand) Help() string {
+	helpText := `
+Usage: nomad node maintenance status [options] [<plan id>]
+
+  Display the status of a maintenance plan and of each of its nodes. If no
+  plan ID is given, a list of all the maintenance plans is displayed.
+
+General Options:
+
+  ` + generalOptionsUsage() + `
+
+Maintenance Status Options:
+
+  -verbose
+    Display full information.
+
+  -json
+    Output the maintenance plans in JSON format.
+
+  -t
+    Format and display the maintenance plans using a Go template.
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *NodeMaintenanceStatusCommand) Synopsis() string {
+	return "Display the status of maintenance plans"
+}
+
+func (c *NodeMaintenanceStatusCommand) AutocompleteFlags() complete.Flags {
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
+		complete.Flags{
+			"-verbose": complete.PredictNothing,
+			"-json":    complete.PredictNothing,
+			"-t":       complete.PredictAnything,
+		})
+}
+
+func (c *NodeMaintenanceStatusCommand) AutocompleteArgs() complete.Predictor {
+	return maintenancePlanPredictor(&c.Meta)
+}
+
+func (c *NodeMaintenanceStatusCommand) Name() string { return "node maintenance status" }
+
+func (c *NodeMaintenanceStatusCommand) Run(args []string) int {
+	var json, verbose bool
+	var tmpl string
+
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
+	flags.Usage = func() { c.Ui.Output(c.Help()) }
+	flags.BoolVar(&verbose, "verbose", false, "")
+	flags.BoolVar(&json, "json", false, "")
+	flags.StringVar(&tmpl, "t", "", "")
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	// Check that we got either a single plan or none
+	args = flags.Args()
+	if l := len(args); l > 1 {
+		c.Ui.Error("This command takes either one or no arguments")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
+	// Truncate the id unless full length is requested
+	length := shortId
+	if verbose {
+		length = fullId
+	}
+
+	// Get the HTTP client
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+
+	var out interface{}
+	var formatted string
+	if len(args) == 0 {
+		plans, _, err := client.Maintenance().List(nil)
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("Error retrieving maintenance plans: %s", err))
+			return 1
+		}
+		out, formatted = plans, formatMaintenancePlans(plans, length)
+	} else {
+		plan, possible, err := getMaintenancePlan(client.Maintenance(), args[0])
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("Error retrieving maintenance plan: %s", err))
+			return 1
+		}
+		if len(possible) != 0 {
+			c.Ui.Error(fmt.Sprintf("Prefix matched multiple maintenance plans\n\n%s", formatMaintenancePlans(possible, length)))
+			return 1
+		}
+		out, formatted = plan, formatMaintenancePlan(plan, length)
+	}
+
+	if json || len(tmpl) > 0 {
+		formatted, err = Format(json, tmpl, out)
+		if err != nil {
+			c.Ui.Error(err.Error())
+			return 1
+		}
+
+		c.Ui.Output(formatted)
+		return 0
+	}
+
+	c.Ui.Output(c.Colorize().Color(formatted))
+	return 0
+}
+
+// maintenancePlanPredictor returns a predictor of the IDs of the maintenance
+// plans
+func maintenancePlanPredictor(m *Meta) complete.Predictor {
+	return complete.PredictFunc(func(a complete.Args) []string {
+		client, err := m.Client()
+		if err != nil {
+			return nil
+		}
+
+		plans, _, err := client.Maintenance().PrefixList(a.Last)
+		if err != nil {
+			return []string{}
+		}
+
+		matches := make([]string, len(plans))
+		for i, plan := range plans {
+			matches[i] = plan.ID
+		}
+		return matches
+	})
+}
+
+// getMaintenancePlan looks up a maintenance plan by its ID or ID prefix,
+// returning the possible plans when the prefix matches several of them.
+func getMaintenancePlan(client *api.Maintenance, planID string) (match *api.MaintenancePlan, possible []*api.MaintenancePlan, err error) {
+	// First attempt an immediate lookup if we have a proper length
+	if len(planID) == 36 {
+		plan, _, err := client.Info(planID, nil)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return plan, nil, nil
+	}
+
+	planID = strings.Replace(planID, "-", "", -1)
+	if len(planID) == 1 {
+		return nil, nil, fmt.Errorf("Identifier must contain at least two characters.")
+	}
+	if len(planID)%2 == 1 {
+		// Identifiers must be of even length, so we strip off the last byte
+		// to provide a consistent user experience.
+		planID = planID[:len(planID)-1]
+	}
+
+	// Have to do a prefix lookup
+	plans, _, err := client.PrefixList(planID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	l := len(plans)
+	switch {
+	case l == 0:
+		return nil, nil, fmt.Errorf("Maintenance plan ID %q matched no maintenance plans", planID)
+	case l == 1:
+		return plans[0], nil, nil
+	default:
+		return nil, plans, nil
+	}
+}
+
+func formatMaintenancePlans(plans []*api.MaintenancePlan, uuidLength int) string {
+	if len(plans) == 0 {
+		return "No maintenance plans found"
+	}
+
+	rows := make([]string, len(plans)+1)
+	rows[0] = "ID|Start Time|Nodes|Status|Description"
+	for i, plan := range plans {
+		rows[i+1] = fmt.Sprintf("%s|%s|%d|%s|%s",
+			limit(plan.ID, uuidLength),
+			formatTime(plan.StartTime),
+			len(plan.Nodes),
+			plan.Status,
+			plan.StatusDescription)
+	}
+	return formatList(rows)
+}
+
+func formatMaintenancePlan(plan *api.MaintenancePlan, uuidLength int) string {
+	deadline := "none"
+	if plan.Deadline > 0 {
+		deadline = plan.Deadline.String()
+	}
+
+	// Format the high-level elements
+	high := []string{
+		fmt.Sprintf("ID|%s", limit(plan.ID, uuidLength)),
+		fmt.Sprintf("Status|%s", plan.Status),
+		fmt.Sprintf("Description|%s", plan.StatusDescription),
+		fmt.Sprintf("Start Time|%s", formatTime(plan.StartTime)),
+		fmt.Sprintf("Batch Size|%d", plan.BatchSize),
+		fmt.Sprintf("Drain Deadline|%s", deadline),
+		fmt.Sprintf("Ignore System Jobs|%v", plan.IgnoreSystemJobs),
+	}
+	if len(plan.Datacenters) != 0 {
+		high = append(high, fmt.Sprintf("Datacenters|%s", strings.Join(plan.Datacenters, ",")))
+	}
+	if plan.NodeClass != "" {
+		high = append(high, fmt.Sprintf("Node Class|%s", plan.NodeClass))
+	}
+	if len(plan.Meta) != 0 {
+		meta := make([]string, 0, len(plan.Meta))
+		for k, v := range plan.Meta {
+			meta = append(meta, fmt.Sprintf("%s=%s", k, v))
+		}
+		sort.Strings(meta)
+		high = append(high, fmt.Sprintf("Meta|%s", strings.Join(meta, ",")))
+	}
+
+	base := formatKV(high)
+	if len(plan.Nodes) == 0 {
+		return base
+	}
+
+	rows := make([]string, len(plan.Nodes)+1)
+	rows[0] = "Batch|Node ID|Node Name|Status"
+	for i, n := range plan.Nodes {
+		rows[i+1] = fmt.Sprintf("%d|%s|%s|%s",
+			n.Batch+1,
+			limit(n.NodeID, uuidLength),
+			n.Name,
+			n.Status)
+	}
+	return base + "\n\n[bold]Nodes[reset]\n" + formatList(rows)
+}
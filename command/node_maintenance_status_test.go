@@ -0,0 +1,99 @@
+package command
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/mitchellh/cli"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNodeMaintenanceStatusCommand_Implements(t *testing.T) {
+	t.Parallel()
+	var _ cli.Command = &NodeMaintenanceStatusCommand{}
+}
+
+func TestNodeMaintenanceStatusCommand_Fails(t *testing.T) {
+	t.Parallel()
+	srv, _, url := testServer(t, false, nil)
+	defer srv.Shutdown()
+
+	ui := new(cli.MockUi)
+	cmd := &NodeMaintenanceStatusCommand{Meta: Meta{Ui: ui}}
+
+	// Fails on misuse
+	if code := cmd.Run([]string{"some", "bad", "args"}); code != 1 {
+		t.Fatalf("expected exit code 1, got: %d", code)
+	}
+	if out := ui.ErrorWriter.String(); !strings.Contains(out, commandErrorText(cmd)) {
+		t.Fatalf("expected help output, got: %s", out)
+	}
+	ui.ErrorWriter.Reset()
+
+	// Fails on connection failure
+	if code := cmd.Run([]string{"-address=nope"}); code != 1 {
+		t.Fatalf("expected exit code 1, got: %d", code)
+	}
+	if out := ui.ErrorWriter.String(); !strings.Contains(out, "Error retrieving maintenance plans") {
+		t.Fatalf("expected failed query error, got: %s", out)
+	}
+	ui.ErrorWriter.Reset()
+
+	// Fails on non-existent plan
+	if code := cmd.Run([]string{"-address=" + url, "12345678-abcd-efab-cdef-123456789abc"}); code != 1 {
+		t.Fatalf("expected exit 1, got: %d", code)
+	}
+	if out := ui.ErrorWriter.String(); !strings.Contains(out, "Error retrieving maintenance plan") {
+		t.Fatalf("expected not found error, got: %s", out)
+	}
+	ui.ErrorWriter.Reset()
+
+	// Lists no plans
+	if code := cmd.Run([]string{"-address=" + url}); code != 0 {
+		t.Fatalf("expected exit 0, got: %d", code)
+	}
+	if out := ui.OutputWriter.String(); !strings.Contains(out, "No maintenance plans found") {
+		t.Fatalf("expected empty list, got: %s", out)
+	}
+}
+
+func TestNodeMaintenanceStatusCommand_Format(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	plan := &api.MaintenancePlan{
+		ID:                "12345678-abcd-efab-cdef-123456789abc",
+		Datacenters:       []string{"dc1"},
+		BatchSize:         1,
+		Status:            api.MaintenancePlanStatusRunning,
+		StatusDescription: "Draining batch 2 of 2",
+		Nodes: []*api.MaintenancePlanNode{
+			{
+				NodeID: "aaaaaaaa-abcd-efab-cdef-123456789abc",
+				Name:   "a",
+				Batch:  0,
+				Status: api.MaintenanceNodeStatusComplete,
+			},
+			{
+				NodeID: "bbbbbbbb-abcd-efab-cdef-123456789abc",
+				Name:   "b",
+				Batch:  1,
+				Status: api.MaintenanceNodeStatusDraining,
+			},
+		},
+	}
+
+	out := formatMaintenancePlans([]*api.MaintenancePlan{plan}, shortId)
+	require.Contains(out, "12345678")
+	require.Contains(out, "Draining batch 2 of 2")
+	require.NotContains(out, plan.ID)
+
+	out = formatMaintenancePlan(plan, fullId)
+	require.Contains(out, plan.ID)
+	require.Contains(out, "dc1")
+	require.Contains(out, "aaaaaaaa-abcd-efab-cdef-123456789abc")
+	require.Contains(out, api.MaintenanceNodeStatusDraining)
+
+	require.Equal("No maintenance plans found", formatMaintenancePlans(nil, shortId))
+}
@@ -314,6 +314,9 @@ func formatDrain(n *api.Node) string {
 		if n.DrainStrategy.IgnoreSystemJobs {
 			b.WriteString("; ignoring system jobs")
 		}
+		if n.DrainStrategy.OrderByPriority {
+			b.WriteString("; ordered by priority")
+		}
 		return b.String()
 	}
 
@@ -0,0 +1,50 @@
+package command
+
+import (
+	"strings"
+
+	"github.com/mitchellh/cli"
+)
+
+type VarCommand struct {
+	Meta
+}
+
+func (f *VarCommand) Help() string {
+	helpText := `
+Usage: nomad var <subcommand> [options] [args]
+
+  This command groups subcommands for interacting with variables. Variables
+  are key/value pairs stored encrypted by the servers. Tasks can read the
+  variables under "nomad/jobs/<job ID>" in their templates.
+
+  Create or update a variable:
+
+      $ nomad var put <path> <key>=<value>
+
+  Read a variable:
+
+      $ nomad var get <path>
+
+  List variables:
+
+      $ nomad var list [<prefix>]
+
+  Delete a variable:
+
+      $ nomad var purge <path>
+
+  Please see the individual subcommand help for detailed usage information.
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (f *VarCommand) Synopsis() string {
+	return "Interact with variables"
+}
+
+func (f *VarCommand) Name() string { return "var" }
+
+func (f *VarCommand) Run(args []string) int {
+	return cli.RunResultHelp
+}
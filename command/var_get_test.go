@@ -0,0 +1,58 @@
+package command
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/mitchellh/cli"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVarGetCommand_Implements(t *testing.T) {
+	t.Parallel()
+	var _ cli.Command = &VarGetCommand{}
+}
+
+func TestVarGetCommand(t *testing.T) {
+	require := require.New(t)
+	t.Parallel()
+
+	srv, client, url := testServer(t, false, nil)
+	defer srv.Shutdown()
+	waitForKeyring(t, srv)
+
+	v := api.NewVariable("shared/db")
+	v.Items["user"] = "admin"
+	v.Items["password"] = "hunter2"
+	_, _, err := client.Variables().Update(v, nil)
+	require.NoError(err)
+
+	ui := new(cli.MockUi)
+	cmd := &VarGetCommand{Meta: Meta{Ui: ui, flagAddress: url}}
+
+	// Missing variables fail
+	code := cmd.Run([]string{"-address=" + url, "shared/other"})
+	require.Equal(1, code)
+	require.Contains(ui.ErrorWriter.String(), "not found")
+
+	code = cmd.Run([]string{"-address=" + url, "shared/db"})
+	require.Equal(0, code, ui.ErrorWriter.String())
+	out := ui.OutputWriter.String()
+	require.Contains(out, "shared/db")
+	require.Contains(out, "hunter2")
+	ui.OutputWriter.Reset()
+
+	// Output a single item
+	code = cmd.Run([]string{"-address=" + url, "-item=user", "shared/db"})
+	require.Equal(0, code, ui.ErrorWriter.String())
+	require.Equal("admin\n", ui.OutputWriter.String())
+	ui.OutputWriter.Reset()
+
+	code = cmd.Run([]string{"-address=" + url, "-item=missing", "shared/db"})
+	require.Equal(1, code)
+
+	// Output JSON
+	code = cmd.Run([]string{"-address=" + url, "-json", "shared/db"})
+	require.Equal(0, code, ui.ErrorWriter.String())
+	require.Contains(ui.OutputWriter.String(), `"password": "hunter2"`)
+}
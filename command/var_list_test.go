@@ -0,0 +1,53 @@
+package command
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/mitchellh/cli"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVarListCommand_Implements(t *testing.T) {
+	t.Parallel()
+	var _ cli.Command = &VarListCommand{}
+}
+
+func TestVarListCommand(t *testing.T) {
+	require := require.New(t)
+	t.Parallel()
+
+	srv, client, url := testServer(t, false, nil)
+	defer srv.Shutdown()
+	waitForKeyring(t, srv)
+
+	ui := new(cli.MockUi)
+	cmd := &VarListCommand{Meta: Meta{Ui: ui, flagAddress: url}}
+
+	code := cmd.Run([]string{"-address=" + url})
+	require.Equal(0, code, ui.ErrorWriter.String())
+	require.Contains(ui.OutputWriter.String(), "No variables found")
+	ui.OutputWriter.Reset()
+
+	for _, path := range []string{"shared/db", "nomad/jobs/example"} {
+		v := api.NewVariable(path)
+		v.Items["secret"] = "hunter2"
+		_, _, err := client.Variables().Update(v, nil)
+		require.NoError(err)
+	}
+
+	code = cmd.Run([]string{"-address=" + url})
+	require.Equal(0, code, ui.ErrorWriter.String())
+	out := ui.OutputWriter.String()
+	require.Contains(out, "shared/db")
+	require.Contains(out, "nomad/jobs/example")
+	require.NotContains(out, "hunter2")
+	ui.OutputWriter.Reset()
+
+	// Filter by prefix
+	code = cmd.Run([]string{"-address=" + url, "shared"})
+	require.Equal(0, code, ui.ErrorWriter.String())
+	out = ui.OutputWriter.String()
+	require.Contains(out, "shared/db")
+	require.NotContains(out, "nomad/jobs/example")
+}
@@ -0,0 +1,44 @@
+package command
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/mitchellh/cli"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVarPurgeCommand_Implements(t *testing.T) {
+	t.Parallel()
+	var _ cli.Command = &VarPurgeCommand{}
+}
+
+func TestVarPurgeCommand(t *testing.T) {
+	require := require.New(t)
+	t.Parallel()
+
+	srv, client, url := testServer(t, false, nil)
+	defer srv.Shutdown()
+	waitForKeyring(t, srv)
+
+	v := api.NewVariable("shared/db")
+	v.Items["user"] = "admin"
+	v, _, err := client.Variables().Update(v, nil)
+	require.NoError(err)
+
+	ui := new(cli.MockUi)
+	cmd := &VarPurgeCommand{Meta: Meta{Ui: ui, flagAddress: url}}
+
+	// A stale check index fails
+	code := cmd.Run([]string{"-address=" + url, fmt.Sprintf("-check-index=%d", v.ModifyIndex-1), "shared/db"})
+	require.Equal(1, code)
+	require.Contains(ui.ErrorWriter.String(), "doesn't match modify index")
+
+	code = cmd.Run([]string{"-address=" + url, fmt.Sprintf("-check-index=%d", v.ModifyIndex), "shared/db"})
+	require.Equal(0, code, ui.ErrorWriter.String())
+	require.Contains(ui.OutputWriter.String(), `Successfully purged variable "shared/db"`)
+
+	_, _, err = client.Variables().Read("shared/db", nil)
+	require.Equal(api.ErrVariableNotFound, err)
+}
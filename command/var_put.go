@@ -0,0 +1,116 @@
+package command
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/posener/complete"
+)
+
+type VarPutCommand struct {
+	Meta
+}
+
+func (c *VarPutCommand) Help() string {
+	helpText := `
+Usage: nomad var put [options] <path> <key>=<value> [<key>=<value>...]
+
+  Put is used to create or update a variable. The items of an existing
+  variable are replaced by the given ones.
+
+General Options:
+
+  ` + generalOptionsUsage() + `
+
+Put Options:
+
+  -check-index <index>
+    Only update the variable if its modify index, shown as "Check Index" by
+    "nomad var get", matches the given one. An index of 0 only creates the
+    variable if it doesn't exist yet.
+`
+
+	return strings.TrimSpace(helpText)
+}
+
+func (c *VarPutCommand) AutocompleteFlags() complete.Flags {
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
+		complete.Flags{
+			"-check-index": complete.PredictAnything,
+		})
+}
+
+func (c *VarPutCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (c *VarPutCommand) Synopsis() string {
+	return "Create or update a variable"
+}
+
+func (c *VarPutCommand) Name() string { return "var put" }
+
+func (c *VarPutCommand) Run(args []string) int {
+	var checkIndexStr string
+
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
+	flags.Usage = func() { c.Ui.Output(c.Help()) }
+	flags.StringVar(&checkIndexStr, "check-index", "", "")
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	// Parse the check-index
+	checkIndex, checked, err := parseCheckIndex(checkIndexStr)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error parsing check-index value %q: %v", checkIndexStr, err))
+		return 1
+	}
+
+	// Check that we got a path and at least one item
+	args = flags.Args()
+	if l := len(args); l < 2 {
+		c.Ui.Error("This command takes at least two arguments: <path> <key>=<value>")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
+	v := api.NewVariable(args[0])
+	for _, arg := range args[1:] {
+		parts := strings.SplitN(arg, "=", 2)
+		if len(parts) != 2 || parts[0] == "" {
+			c.Ui.Error(fmt.Sprintf("Invalid item %q: must be formatted as <key>=<value>", arg))
+			return 1
+		}
+		v.Items[parts[0]] = parts[1]
+	}
+
+	// Get the HTTP client
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+
+	// Write the variable
+	if checked {
+		v.ModifyIndex = checkIndex
+		v, _, err = client.Variables().CheckedUpdate(v, nil)
+	} else {
+		v, _, err = client.Variables().Update(v, nil)
+	}
+	if err != nil {
+		if conflict, ok := err.(api.ErrCASConflict); ok {
+			c.Ui.Error(fmt.Sprintf("Error writing variable: check index %d doesn't match modify index %d",
+				conflict.CheckIndex, conflict.Conflict.ModifyIndex))
+			return 1
+		}
+		c.Ui.Error(fmt.Sprintf("Error writing variable: %s", err))
+		return 1
+	}
+
+	c.Ui.Output(fmt.Sprintf("Successfully wrote variable %q with check index %d", v.Path, v.ModifyIndex))
+	return 0
+}
@@ -0,0 +1,68 @@
+package command
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/command/agent"
+	"github.com/hashicorp/nomad/testutil"
+	"github.com/mitchellh/cli"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVarPutCommand_Implements(t *testing.T) {
+	t.Parallel()
+	var _ cli.Command = &VarPutCommand{}
+}
+
+func TestVarPutCommand(t *testing.T) {
+	require := require.New(t)
+	t.Parallel()
+
+	srv, client, url := testServer(t, false, nil)
+	defer srv.Shutdown()
+	waitForKeyring(t, srv)
+
+	ui := new(cli.MockUi)
+	cmd := &VarPutCommand{Meta: Meta{Ui: ui, flagAddress: url}}
+
+	// Items must be key=value pairs
+	code := cmd.Run([]string{"-address=" + url, "shared/db", "user"})
+	require.Equal(1, code)
+	require.Contains(ui.ErrorWriter.String(), "Invalid item")
+	ui.ErrorWriter.Reset()
+
+	// Create the variable only if it doesn't exist
+	code = cmd.Run([]string{"-address=" + url, "-check-index=0", "shared/db", "user=admin", "password=a=b"})
+	require.Equal(0, code, ui.ErrorWriter.String())
+	require.Contains(ui.OutputWriter.String(), `Successfully wrote variable "shared/db"`)
+
+	v, _, err := client.Variables().Read("shared/db", nil)
+	require.NoError(err)
+	require.Equal("admin", v.Items["user"])
+	require.Equal("a=b", v.Items["password"])
+
+	// Creating it again fails its check
+	code = cmd.Run([]string{"-address=" + url, "-check-index=0", "shared/db", "user=other"})
+	require.Equal(1, code)
+	require.Contains(ui.ErrorWriter.String(), "doesn't match modify index")
+
+	// An unchecked put replaces the items
+	code = cmd.Run([]string{"-address=" + url, "shared/db", "user=other"})
+	require.Equal(0, code, ui.ErrorWriter.String())
+
+	v, _, err = client.Variables().Read("shared/db", nil)
+	require.NoError(err)
+	require.Equal(map[string]string{"user": "other"}, map[string]string(v.Items))
+}
+
+// waitForKeyring waits for the leader to initialize the keyring which
+// encrypts variables
+func waitForKeyring(t *testing.T, srv *agent.TestAgent) {
+	state := srv.Agent.Server().State()
+	testutil.WaitForResult(func() (bool, error) {
+		key, err := state.ActiveRootKey(nil)
+		return key != nil, err
+	}, func(err error) {
+		t.Fatalf("keyring not initialized: %v", err)
+	})
+}
@@ -219,6 +219,7 @@ func (d *Driver) RecoverTask(handle *drivers.TaskHandle) error {
 
 	d.tasks.Set(handle.Config.ID, h)
 	go h.run()
+	go h.runCpusetFixer()
 
 	return nil
 }
@@ -352,6 +353,7 @@ CREATE:
 
 	d.tasks.Set(cfg.ID, h)
 	go h.run()
+	go h.runCpusetFixer()
 
 	return handle, net, nil
 }
@@ -685,8 +687,9 @@ func (d *Driver) createContainerConfig(task *drivers.TaskConfig, driverConfig *T
 	}
 
 	hostConfig := &docker.HostConfig{
-		Memory:    task.Resources.LinuxResources.MemoryLimitBytes,
-		CPUShares: task.Resources.LinuxResources.CPUShares,
+		Memory:     task.Resources.LinuxResources.MemoryLimitBytes,
+		CPUShares:  task.Resources.LinuxResources.CPUShares,
+		CPUSetCPUs: task.Resources.LinuxResources.CpusetCPUs,
 
 		// Binds are used to mount a host volume into the container. We mount a
 		// local directory for storage and a shared alloc directory that can be
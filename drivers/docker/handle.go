@@ -2,7 +2,9 @@ package docker
 
 import (
 	"fmt"
+	"io/ioutil"
 	"os"
+	"path/filepath"
 	"strings"
 	"sync"
 	"syscall"
@@ -18,6 +20,10 @@ import (
 	"golang.org/x/net/context"
 )
 
+// cpusetFixerInterval is how often the cpuset of a container is compared to
+// the cpuset cgroup the client manages for its task
+const cpusetFixerInterval = 10 * time.Second
+
 type taskHandle struct {
 	client                *docker.Client
 	waitClient            *docker.Client
@@ -178,6 +184,47 @@ func (h *taskHandle) shutdownLogger() {
 	h.dloggerPluginClient.Kill()
 }
 
+// runCpusetFixer keeps the cpuset of the container in line with the cpuset
+// cgroup the client manages for the task, so that the container is moved off
+// of the cores reserved by other allocations while it runs.
+func (h *taskHandle) runCpusetFixer() {
+	if h.task.Resources == nil || h.task.Resources.LinuxResources == nil {
+		return
+	}
+	lr := h.task.Resources.LinuxResources
+	if lr.CpusetCgroupPath == "" {
+		return
+	}
+
+	current := lr.CpusetCPUs
+	ticker := time.NewTicker(cpusetFixerInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-h.doneCh:
+			return
+		case <-ticker.C:
+		}
+
+		raw, err := ioutil.ReadFile(filepath.Join(lr.CpusetCgroupPath, "cpuset.cpus"))
+		if err != nil {
+			h.logger.Debug("failed to read task cpuset", "path", lr.CpusetCgroupPath, "error", err)
+			continue
+		}
+
+		cpus := strings.TrimSpace(string(raw))
+		if cpus == "" || cpus == current {
+			continue
+		}
+
+		if err := h.client.UpdateContainer(h.containerID, docker.UpdateContainerOptions{CpusetCpus: cpus}); err != nil {
+			h.logger.Warn("failed to update container cpuset", "cpuset", cpus, "error", err)
+			continue
+		}
+		current = cpus
+	}
+}
+
 func (h *taskHandle) run() {
 	defer h.shutdownLogger()
 
@@ -201,7 +201,7 @@ func (l *LibcontainerExecutor) Launch(command *ExecCommand) (*ProcessState, erro
 	l.systemCpuStats = stats.NewCpuStats()
 
 	// Starts the task
-	if err := container.Run(process); err != nil {
+	if err := l.runProcess(container, process, command); err != nil {
 		container.Destroy()
 		return nil, err
 	}
@@ -234,6 +234,34 @@ func (l *LibcontainerExecutor) Launch(command *ExecCommand) (*ProcessState, erro
 	}, nil
 }
 
+// runProcess starts the task process in the container. If the client manages
+// the cpuset cgroup of the task, the process joins it before executing the
+// user command so that its cpuset follows the cores reserved on the client.
+func (l *LibcontainerExecutor) runProcess(container libcontainer.Container, process *libcontainer.Process, command *ExecCommand) error {
+	var cpusetCgroup string
+	if command.Resources != nil && command.Resources.LinuxResources != nil {
+		cpusetCgroup = command.Resources.LinuxResources.CpusetCgroupPath
+	}
+	if cpusetCgroup == "" {
+		return container.Run(process)
+	}
+
+	if err := container.Start(process); err != nil {
+		return err
+	}
+
+	pid, err := process.Pid()
+	if err != nil {
+		return err
+	}
+
+	if err := cgroups.WriteCgroupProc(cpusetCgroup, pid); err != nil {
+		return fmt.Errorf("failed to join cpuset cgroup %q: %v", cpusetCgroup, err)
+	}
+
+	return container.Exec()
+}
+
 func (l *LibcontainerExecutor) getAllPids() (map[int]*nomadPid, error) {
 	pids, err := l.container.Processes()
 	if err != nil {
@@ -697,6 +725,11 @@ func configureCgroups(cfg *lconfigs.Config, command *ExecCommand) error {
 	// Set the relative CPU shares for this cgroup.
 	cfg.Cgroups.Resources.CpuShares = uint64(cpuShares)
 
+	// Confine the task to its cpuset if one was given
+	if lr := command.Resources.LinuxResources; lr != nil && lr.CpusetCPUs != "" {
+		cfg.Cgroups.Resources.CpusetCpus = lr.CpusetCPUs
+	}
+
 	return nil
 }
 
@@ -15,9 +15,11 @@ import (
 	"github.com/hashicorp/nomad/client/taskenv"
 	"github.com/hashicorp/nomad/client/testutil"
 	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/hashicorp/nomad/helper/uuid"
 	"github.com/hashicorp/nomad/nomad/mock"
 	"github.com/hashicorp/nomad/plugins/drivers"
 	tu "github.com/hashicorp/nomad/testutil"
+	"github.com/opencontainers/runc/libcontainer/cgroups"
 	lconfigs "github.com/opencontainers/runc/libcontainer/configs"
 	"github.com/stretchr/testify/require"
 	"golang.org/x/sys/unix"
@@ -161,6 +163,58 @@ ld.so.conf.d/`
 	}, func(err error) { t.Error(err) })
 }
 
+// TestExecutor_CpusetCgroup asserts the task process joins the cpuset cgroup
+// managed by the client
+func TestExecutor_CpusetCgroup(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	testutil.ExecCompatible(t)
+
+	mount, err := cgroups.FindCgroupMountpoint("", "cpuset")
+	require.NoError(err)
+
+	// Create a cpuset cgroup inheriting the cpus and mems of the root
+	name := "nomad-test-" + uuid.Generate()[:8]
+	cgroup := filepath.Join(mount, name)
+	require.NoError(os.Mkdir(cgroup, 0755))
+	defer os.Remove(cgroup)
+	for _, file := range []string{"cpuset.mems", "cpuset.cpus"} {
+		raw, err := ioutil.ReadFile(filepath.Join(mount, file))
+		require.NoError(err)
+		require.NoError(ioutil.WriteFile(filepath.Join(cgroup, file), raw, 0644))
+	}
+
+	testExecCmd := testExecutorCommandWithChroot(t)
+	execCmd, allocDir := testExecCmd.command, testExecCmd.allocDir
+	execCmd.Cmd = "/bin/cat"
+	execCmd.Args = []string{"/proc/self/cgroup"}
+	execCmd.Resources.LinuxResources = &drivers.LinuxResources{
+		CpusetCgroupPath: cgroup,
+	}
+	defer allocDir.Destroy()
+
+	execCmd.ResourceLimits = true
+
+	executor := NewExecutorWithIsolation(testlog.HCLogger(t))
+	defer executor.Shutdown("SIGKILL", 0)
+
+	ps, err := executor.Launch(execCmd)
+	require.NoError(err)
+	require.NotZero(ps.Pid)
+
+	state, err := executor.Wait(context.Background())
+	require.NoError(err)
+	require.Zero(state.ExitCode)
+
+	tu.WaitForResult(func() (bool, error) {
+		output := testExecCmd.stdout.String()
+		if !strings.Contains(output, ":cpuset:/"+name+"\n") {
+			return false, fmt.Errorf("task did not join cpuset cgroup %q: %v", name, output)
+		}
+		return true, nil
+	}, func(err error) { t.Error(err) })
+}
+
 func TestUniversalExecutor_LookupTaskBin(t *testing.T) {
 	t.Parallel()
 	require := require.New(t)
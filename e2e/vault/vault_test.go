@@ -203,14 +203,16 @@ func testVaultCompatibility(t *testing.T, vault string, version string) {
 
 	// Create a Nomad agent using the created vault
 	nomad := agent.NewTestAgent(t, t.Name(), func(c *agent.Config) {
-		if c.Vault == nil {
-			c.Vault = &config.VaultConfig{}
+		vc := c.GetDefaultVault()
+		if vc == nil {
+			vc = &config.VaultConfig{}
+			c.Vaults = append(c.Vaults, vc)
 		}
-		c.Vault.Enabled = helper.BoolToPtr(true)
-		c.Vault.Token = token
-		c.Vault.Role = "nomad-cluster"
-		c.Vault.AllowUnauthenticated = helper.BoolToPtr(true)
-		c.Vault.Addr = v.HTTPAddr
+		vc.Enabled = helper.BoolToPtr(true)
+		vc.Token = token
+		vc.Role = "nomad-cluster"
+		vc.AllowUnauthenticated = helper.BoolToPtr(true)
+		vc.Addr = v.HTTPAddr
 	})
 	defer nomad.Shutdown()
 
@@ -40,6 +40,13 @@ func Parse(r io.Reader) (*api.Job, error) {
 	}
 	buf.Reset()
 
+	return ParseAST(root)
+}
+
+// ParseAST parses the job spec from an already parsed HCL syntax tree. It is
+// used by parsers that produce the HCL1 syntax tree from another format, such
+// as the HCL2 job specification parser.
+func ParseAST(root *ast.File) (*api.Job, error) {
 	// Top-level item should be a list
 	list, ok := root.Node.(*ast.ObjectList)
 	if !ok {
@@ -314,6 +321,7 @@ func parseGroups(result *api.Job, list *ast.ObjectList) error {
 			"vault",
 			"migrate",
 			"spread",
+			"max_client_disconnect",
 		}
 		if err := helper.CheckHCLKeys(listVal, valid); err != nil {
 			return multierror.Prefix(err, fmt.Sprintf("'%s' ->", n))
@@ -337,7 +345,15 @@ func parseGroups(result *api.Job, list *ast.ObjectList) error {
 		// Build the group with the basic decode
 		var g api.TaskGroup
 		g.Name = helper.StringToPtr(n)
-		if err := mapstructure.WeakDecode(m, &g); err != nil {
+		dec, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+			DecodeHook:       mapstructure.StringToTimeDurationHookFunc(),
+			WeaklyTypedInput: true,
+			Result:           &g,
+		})
+		if err != nil {
+			return err
+		}
+		if err := dec.Decode(m); err != nil {
 			return err
 		}
 
@@ -1410,6 +1426,7 @@ func parseResources(result *api.Resources, list *ast.ObjectList) error {
 	// Check for invalid keys
 	valid := []string{
 		"cpu",
+		"cores",
 		"iops", // COMPAT(0.10): Remove after one release to allow it to be removed from jobspecs
 		"disk",
 		"memory",
@@ -1603,11 +1620,18 @@ func parseUpdate(result **api.UpdateStrategy, list *ast.ObjectList) error {
 		"progress_deadline",
 		"auto_revert",
 		"canary",
+		"auto_promote",
+		"promote_after",
+		"steps",
+		"analysis",
 	}
 	if err := helper.CheckHCLKeys(o.Val, valid); err != nil {
 		return err
 	}
 
+	delete(m, "steps")
+	delete(m, "analysis")
+
 	dec, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
 		DecodeHook:       mapstructure.StringToTimeDurationHookFunc(),
 		WeaklyTypedInput: true,
@@ -1616,7 +1640,164 @@ func parseUpdate(result **api.UpdateStrategy, list *ast.ObjectList) error {
 	if err != nil {
 		return err
 	}
-	return dec.Decode(m)
+	if err := dec.Decode(m); err != nil {
+		return err
+	}
+
+	if ot, ok := o.Val.(*ast.ObjectType); ok {
+		// Parse the rollout steps
+		if so := ot.List.Filter("steps"); len(so.Items) > 0 {
+			if *result == nil {
+				*result = &api.UpdateStrategy{}
+			}
+			if err := parseUpdateSteps(&(*result).Steps, so); err != nil {
+				return multierror.Prefix(err, "steps ->")
+			}
+		}
+
+		// Parse the metric analysis
+		if ao := ot.List.Filter("analysis"); len(ao.Items) > 0 {
+			if *result == nil {
+				*result = &api.UpdateStrategy{}
+			}
+			if err := parseAnalysis(&(*result).Analysis, ao); err != nil {
+				return multierror.Prefix(err, "analysis ->")
+			}
+		}
+	}
+
+	return nil
+}
+
+func parseUpdateSteps(result *[]*api.UpdateStep, list *ast.ObjectList) error {
+	if len(list.Items) > 1 {
+		return fmt.Errorf("only one 'steps' list allowed")
+	}
+
+	// Steps should be a list of objects
+	lt, ok := list.Items[0].Val.(*ast.ListType)
+	if !ok {
+		return fmt.Errorf("steps should be a list of objects")
+	}
+
+	for idx, n := range lt.List {
+		if _, ok := n.(*ast.ObjectType); !ok {
+			return fmt.Errorf("step[%d] should be an object", idx)
+		}
+
+		// Check for invalid keys
+		valid := []string{
+			"canary",
+			"percent",
+			"pause",
+		}
+		if err := helper.CheckHCLKeys(n, valid); err != nil {
+			return multierror.Prefix(err, fmt.Sprintf("step[%d] ->", idx))
+		}
+
+		var m map[string]interface{}
+		if err := hcl.DecodeObject(&m, n); err != nil {
+			return err
+		}
+
+		var step api.UpdateStep
+		dec, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+			DecodeHook:       mapstructure.StringToTimeDurationHookFunc(),
+			WeaklyTypedInput: true,
+			Result:           &step,
+		})
+		if err != nil {
+			return err
+		}
+		if err := dec.Decode(m); err != nil {
+			return multierror.Prefix(err, fmt.Sprintf("step[%d] ->", idx))
+		}
+
+		*result = append(*result, &step)
+	}
+
+	return nil
+}
+
+func parseAnalysis(result **api.DeploymentAnalysis, list *ast.ObjectList) error {
+	list = list.Elem()
+	if len(list.Items) > 1 {
+		return fmt.Errorf("only one 'analysis' block allowed")
+	}
+
+	// Get our resource object
+	o := list.Items[0]
+
+	// Value should be an object
+	var listVal *ast.ObjectList
+	if ot, ok := o.Val.(*ast.ObjectType); ok {
+		listVal = ot.List
+	} else {
+		return fmt.Errorf("analysis should be an object")
+	}
+
+	// Check for invalid keys
+	valid := []string{
+		"address",
+		"interval",
+		"failure_limit",
+		"metric",
+	}
+	if err := helper.CheckHCLKeys(o.Val, valid); err != nil {
+		return err
+	}
+
+	var m map[string]interface{}
+	if err := hcl.DecodeObject(&m, o.Val); err != nil {
+		return err
+	}
+	delete(m, "metric")
+
+	var analysis api.DeploymentAnalysis
+	dec, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		DecodeHook:       mapstructure.StringToTimeDurationHookFunc(),
+		WeaklyTypedInput: true,
+		Result:           &analysis,
+	})
+	if err != nil {
+		return err
+	}
+	if err := dec.Decode(m); err != nil {
+		return err
+	}
+
+	// Parse the metrics
+	for idx, mo := range listVal.Filter("metric").Items {
+		if l := len(mo.Keys); l == 0 {
+			return multierror.Prefix(fmt.Errorf("missing metric name"), fmt.Sprintf("metric[%d] ->", idx))
+		} else if l > 1 {
+			return multierror.Prefix(fmt.Errorf("only one name may be specified"), fmt.Sprintf("metric[%d] ->", idx))
+		}
+		name := mo.Keys[0].Token.Value().(string)
+
+		valid := []string{
+			"query",
+			"min",
+			"max",
+		}
+		if err := helper.CheckHCLKeys(mo.Val, valid); err != nil {
+			return multierror.Prefix(err, fmt.Sprintf("metric %q ->", name))
+		}
+
+		var mm map[string]interface{}
+		if err := hcl.DecodeObject(&mm, mo.Val); err != nil {
+			return err
+		}
+
+		metric := &api.AnalysisMetric{Name: name}
+		if err := mapstructure.WeakDecode(mm, metric); err != nil {
+			return multierror.Prefix(err, fmt.Sprintf("metric %q ->", name))
+		}
+		analysis.Metrics = append(analysis.Metrics, metric)
+	}
+
+	*result = &analysis
+	return nil
 }
 
 func parseMigrate(result **api.MigrateStrategy, list *ast.ObjectList) error {
@@ -1675,6 +1856,9 @@ func parsePeriodic(result **api.PeriodicConfig, list *ast.ObjectList) error {
 		"cron",
 		"prohibit_overlap",
 		"time_zone",
+		"catchup",
+		"catchup_lookback",
+		"exclude",
 	}
 	if err := helper.CheckHCLKeys(o.Val, valid); err != nil {
 		return err
@@ -1688,15 +1872,35 @@ func parsePeriodic(result **api.PeriodicConfig, list *ast.ObjectList) error {
 		m["Enabled"] = enabled
 	}
 
-	// If "cron" is provided, set the type to "cron" and store the spec.
+	// If "cron" is provided, set the type to "cron" and store the spec. A
+	// list of cron expressions stores the first as the spec and the rest as
+	// additional specs.
 	if cron, ok := m["cron"]; ok {
 		m["SpecType"] = api.PeriodicSpecCron
-		m["Spec"] = cron
+		if specs, ok := cron.([]interface{}); ok {
+			if len(specs) == 0 {
+				return fmt.Errorf("periodic.cron must contain at least one cron expression")
+			}
+			m["Spec"] = specs[0]
+			if len(specs) > 1 {
+				m["Specs"] = specs[1:]
+			}
+		} else {
+			m["Spec"] = cron
+		}
 	}
 
 	// Build the constraint
 	var p api.PeriodicConfig
-	if err := mapstructure.WeakDecode(m, &p); err != nil {
+	dec, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		DecodeHook:       mapstructure.StringToTimeDurationHookFunc(),
+		WeaklyTypedInput: true,
+		Result:           &p,
+	})
+	if err != nil {
+		return err
+	}
+	if err := dec.Decode(m); err != nil {
 		return err
 	}
 	*result = &p
@@ -1726,6 +1930,8 @@ func parseVault(result *api.Vault, list *ast.ObjectList) error {
 	// Check for invalid keys
 	valid := []string{
 		"policies",
+		"cluster",
+		"namespace",
 		"env",
 		"change_mode",
 		"change_signal",
@@ -1765,6 +1971,7 @@ func parseParameterizedJob(result **api.ParameterizedJobConfig, list *ast.Object
 		"payload",
 		"meta_required",
 		"meta_optional",
+		"max_concurrent",
 	}
 	if err := helper.CheckHCLKeys(o.Val, valid); err != nil {
 		return err
@@ -164,6 +164,8 @@ func TestParse(t *testing.T) {
 							ProgressDeadline: helper.TimeToPtr(1 * time.Minute),
 							AutoRevert:       helper.BoolToPtr(false),
 							Canary:           helper.IntToPtr(2),
+							AutoPromote:      helper.BoolToPtr(true),
+							PromoteAfter:     helper.TimeToPtr(5 * time.Minute),
 						},
 						Migrate: &api.MigrateStrategy{
 							MaxParallel:     helper.IntToPtr(2),
@@ -326,6 +328,8 @@ func TestParse(t *testing.T) {
 								},
 								Vault: &api.Vault{
 									Policies:     []string{"foo", "bar"},
+									Cluster:      helper.StringToPtr("secondary"),
+									Namespace:    helper.StringToPtr("ops"),
 									Env:          helper.BoolToPtr(false),
 									ChangeMode:   helper.StringToPtr(structs.VaultChangeModeSignal),
 									ChangeSignal: helper.StringToPtr("SIGUSR1"),
@@ -457,6 +461,36 @@ func TestParse(t *testing.T) {
 			false,
 		},
 
+		{
+			"periodic-multi.hcl",
+			&api.Job{
+				ID:   helper.StringToPtr("foo"),
+				Name: helper.StringToPtr("foo"),
+				Periodic: &api.PeriodicConfig{
+					SpecType: helper.StringToPtr(api.PeriodicSpecCron),
+					Spec:     helper.StringToPtr("0 9 * * 1-5"),
+					Specs:    []string{"0 12 * * 0,6"},
+					Exclude:  []string{"2019-12-25", "* * * 1 1"},
+				},
+			},
+			false,
+		},
+
+		{
+			"periodic-catchup.hcl",
+			&api.Job{
+				ID:   helper.StringToPtr("foo"),
+				Name: helper.StringToPtr("foo"),
+				Periodic: &api.PeriodicConfig{
+					SpecType:        helper.StringToPtr(api.PeriodicSpecCron),
+					Spec:            helper.StringToPtr("*/5 * * *"),
+					Catchup:         helper.StringToPtr(api.PeriodicCatchupRunAll),
+					CatchupLookback: helper.TimeToPtr(time.Hour),
+				},
+			},
+			false,
+		},
+
 		{
 			"specify-job.hcl",
 			&api.Job{
@@ -638,9 +672,10 @@ func TestParse(t *testing.T) {
 				Name: helper.StringToPtr("parameterized_job"),
 
 				ParameterizedJob: &api.ParameterizedJobConfig{
-					Payload:      "required",
-					MetaRequired: []string{"foo", "bar"},
-					MetaOptional: []string{"baz", "bam"},
+					Payload:       "required",
+					MetaRequired:  []string{"foo", "bar"},
+					MetaOptional:  []string{"baz", "bam"},
+					MaxConcurrent: 10,
 				},
 
 				TaskGroups: []*api.TaskGroup{
@@ -869,6 +904,109 @@ func TestParse(t *testing.T) {
 			},
 			false,
 		},
+		{
+			"tg-max-client-disconnect.hcl",
+			&api.Job{
+				ID:   helper.StringToPtr("foo"),
+				Name: helper.StringToPtr("foo"),
+				TaskGroups: []*api.TaskGroup{
+					{
+						Name:                helper.StringToPtr("bar"),
+						MaxClientDisconnect: helper.TimeToPtr(1 * time.Hour),
+						Tasks: []*api.Task{
+							{
+								Name:   "bar",
+								Driver: "raw_exec",
+								Config: map[string]interface{}{
+									"command": "bash",
+								},
+							},
+						},
+					},
+				},
+			},
+			false,
+		},
+		{
+			"update-analysis.hcl",
+			&api.Job{
+				ID:   helper.StringToPtr("foo"),
+				Name: helper.StringToPtr("foo"),
+				Update: &api.UpdateStrategy{
+					Canary: helper.IntToPtr(1),
+					Analysis: &api.DeploymentAnalysis{
+						Address:      helper.StringToPtr("http://prometheus.service.consul:9090"),
+						Interval:     helper.TimeToPtr(1 * time.Minute),
+						FailureLimit: helper.IntToPtr(2),
+						Metrics: []*api.AnalysisMetric{
+							{
+								Name:  "error-rate",
+								Query: `sum(rate(http_errors{job="${NOMAD_JOB_ID}"}[1m]))`,
+								Max:   helper.Float64ToPtr(0.05),
+							},
+							{
+								Name:  "requests",
+								Query: "sum(rate(http_requests[1m]))",
+								Min:   helper.Float64ToPtr(10),
+							},
+						},
+					},
+				},
+				TaskGroups: []*api.TaskGroup{
+					{
+						Name: helper.StringToPtr("bar"),
+						Tasks: []*api.Task{
+							{
+								Name:   "bar",
+								Driver: "raw_exec",
+								Config: map[string]interface{}{
+									"command": "bash",
+								},
+							},
+						},
+					},
+				},
+			},
+			false,
+		},
+		{
+			"update-steps.hcl",
+			&api.Job{
+				ID:   helper.StringToPtr("foo"),
+				Name: helper.StringToPtr("foo"),
+				TaskGroups: []*api.TaskGroup{
+					{
+						Name: helper.StringToPtr("bar"),
+						Update: &api.UpdateStrategy{
+							MaxParallel: helper.IntToPtr(2),
+							Steps: []*api.UpdateStep{
+								{
+									Canary: helper.IntToPtr(1),
+									Pause:  helper.TimeToPtr(10 * time.Minute),
+								},
+								{
+									Percent: helper.IntToPtr(25),
+									Pause:   helper.TimeToPtr(30 * time.Minute),
+								},
+								{
+									Percent: helper.IntToPtr(100),
+								},
+							},
+						},
+						Tasks: []*api.Task{
+							{
+								Name:   "bar",
+								Driver: "raw_exec",
+								Config: map[string]interface{}{
+									"command": "bash",
+								},
+							},
+						},
+					},
+				},
+			},
+			false,
+		},
 	}
 
 	for _, tc := range cases {
@@ -85,6 +85,8 @@ job "binstore-storagelocker" {
         progress_deadline = "1m"
         auto_revert = false
         canary = 2
+        auto_promote = true
+        promote_after = "5m"
     }
 
     migrate {
@@ -279,6 +281,8 @@ job "binstore-storagelocker" {
 
       vault {
         policies = ["foo", "bar"]
+        cluster = "secondary"
+        namespace = "ops"
         env = false
         change_mode = "signal"
         change_signal = "SIGUSR1"
@@ -3,6 +3,7 @@ job "parameterized_job" {
         payload = "required"
         meta_required = ["foo", "bar"]
         meta_optional = ["baz", "bam"]
+        max_concurrent = 10
     }
     group "foo" {
         task "bar" {
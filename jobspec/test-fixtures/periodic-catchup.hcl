@@ -0,0 +1,7 @@
+job "foo" {
+    periodic {
+        cron = "*/5 * * *"
+        catchup = "run_all"
+        catchup_lookback = "1h"
+    }
+}
@@ -0,0 +1,6 @@
+job "foo" {
+    periodic {
+        cron    = ["0 9 * * 1-5", "0 12 * * 0,6"]
+        exclude = ["2019-12-25", "* * * 1 1"]
+    }
+}
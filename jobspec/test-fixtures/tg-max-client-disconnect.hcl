@@ -0,0 +1,12 @@
+job "foo" {
+  group "bar" {
+    max_client_disconnect = "1h"
+
+    task "bar" {
+      driver = "raw_exec"
+      config {
+        command = "bash"
+      }
+    }
+  }
+}
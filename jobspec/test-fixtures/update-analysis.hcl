@@ -0,0 +1,30 @@
+job "foo" {
+  update {
+    canary = 1
+
+    analysis {
+      address       = "http://prometheus.service.consul:9090"
+      interval      = "1m"
+      failure_limit = 2
+
+      metric "error-rate" {
+        query = "sum(rate(http_errors{job=\"${NOMAD_JOB_ID}\"}[1m]))"
+        max   = 0.05
+      }
+
+      metric "requests" {
+        query = "sum(rate(http_requests[1m]))"
+        min   = 10
+      }
+    }
+  }
+
+  group "bar" {
+    task "bar" {
+      driver = "raw_exec"
+      config {
+        command = "bash"
+      }
+    }
+  }
+}
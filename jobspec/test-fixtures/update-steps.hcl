@@ -0,0 +1,21 @@
+job "foo" {
+  group "bar" {
+    update {
+      max_parallel = 2
+
+      steps = [
+        { canary = 1, pause = "10m" },
+        { percent = 25, pause = "30m" },
+        { percent = 100 },
+      ]
+    }
+
+    task "bar" {
+      driver = "raw_exec"
+
+      config {
+        command = "bash"
+      }
+    }
+  }
+}
@@ -0,0 +1,540 @@
+package jobspec2
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/hcl/hcl/ast"
+	"github.com/hashicorp/hcl/hcl/token"
+	"github.com/hashicorp/hcl2/hcl"
+	"github.com/hashicorp/hcl2/hcl/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/convert"
+)
+
+// converter evaluates an HCL2 body and converts it to the HCL1 syntax tree
+// decoded by the jobspec package.
+//
+// References to variables that are not defined in the evaluation context are
+// left untouched when they are interpolated into strings, so that Nomad's
+// runtime interpolation such as "${attr.kernel.name}" or "${NOMAD_TASK_DIR}"
+// keeps working.
+type converter struct {
+	filename string
+	src      []byte
+}
+
+// evalLocals evaluates the attributes of the locals blocks. Locals may refer
+// to each other, so they are evaluated once all the locals they refer to are.
+func (c *converter) evalLocals(blocks hclsyntax.Blocks, ctx *hcl.EvalContext) (map[string]cty.Value, hcl.Diagnostics) {
+	var diags hcl.Diagnostics
+	pending := map[string]*hclsyntax.Attribute{}
+
+	for _, block := range blocks {
+		if len(block.Labels) != 0 || len(block.Body.Blocks) != 0 {
+			diags = append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Invalid locals block",
+				Detail:   "A locals block has no labels and may only contain attributes.",
+				Subject:  block.DefRange().Ptr(),
+			})
+			continue
+		}
+
+		for name, attr := range block.Body.Attributes {
+			if existing, ok := pending[name]; ok {
+				diags = append(diags, &hcl.Diagnostic{
+					Severity: hcl.DiagError,
+					Summary:  "Duplicate local value",
+					Detail:   fmt.Sprintf("A local value named %q was already defined at %s.", name, existing.NameRange),
+					Subject:  attr.NameRange.Ptr(),
+				})
+				continue
+			}
+			pending[name] = attr
+		}
+	}
+	if diags.HasErrors() {
+		return nil, diags
+	}
+
+	locals := map[string]cty.Value{}
+	for len(pending) > 0 {
+		progress := false
+		for _, name := range sortedAttributeNames(pending) {
+			attr := pending[name]
+			if dependsOnPending(attr.Expr, pending) {
+				continue
+			}
+
+			child := ctx.NewChild()
+			child.Variables = map[string]cty.Value{"local": cty.ObjectVal(locals)}
+			val, moreDiags := c.eval(attr.Expr, child)
+			diags = append(diags, moreDiags...)
+			if moreDiags.HasErrors() {
+				return nil, diags
+			}
+
+			locals[name] = val
+			delete(pending, name)
+			progress = true
+		}
+
+		if !progress {
+			names := sortedAttributeNames(pending)
+			return nil, append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Cycle in local values",
+				Detail:   fmt.Sprintf("The local values %s refer to each other.", strings.Join(names, ", ")),
+				Subject:  pending[names[0]].NameRange.Ptr(),
+			})
+		}
+	}
+
+	return locals, diags
+}
+
+// dependsOnPending returns whether the expression refers to a local value
+// that has not been evaluated yet.
+func dependsOnPending(expr hcl.Expression, pending map[string]*hclsyntax.Attribute) bool {
+	for _, traversal := range expr.Variables() {
+		if traversal.RootName() != "local" || len(traversal) < 2 {
+			continue
+		}
+		if attr, ok := traversal[1].(hcl.TraverseAttr); ok {
+			if _, ok := pending[attr.Name]; ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// convertBody evaluates the body and returns the equivalent HCL1 object list.
+// Attributes and blocks keep their order in the source.
+func (c *converter) convertBody(body *hclsyntax.Body, ctx *hcl.EvalContext) (*ast.ObjectList, hcl.Diagnostics) {
+	type positioned struct {
+		offset int
+		items  []*ast.ObjectItem
+	}
+
+	var diags hcl.Diagnostics
+	var all []positioned
+
+	for name, attr := range body.Attributes {
+		val, moreDiags := c.eval(attr.Expr, ctx)
+		diags = append(diags, moreDiags...)
+		if moreDiags.HasErrors() || val.IsNull() {
+			continue
+		}
+
+		node, err := c.node(val, attr.Expr.Range())
+		if err != nil {
+			diags = append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Invalid value",
+				Detail:   fmt.Sprintf("Invalid value for %q: %v.", name, err),
+				Subject:  attr.Expr.Range().Ptr(),
+			})
+			continue
+		}
+
+		pos := c.pos(attr.NameRange)
+		item := &ast.ObjectItem{
+			Keys:   []*ast.ObjectKey{{Token: token.Token{Type: token.IDENT, Text: name, Pos: pos}}},
+			Assign: c.pos(attr.EqualsRange),
+			Val:    node,
+		}
+		all = append(all, positioned{attr.SrcRange.Start.Byte, []*ast.ObjectItem{item}})
+	}
+
+	for _, block := range body.Blocks {
+		var items []*ast.ObjectItem
+		var moreDiags hcl.Diagnostics
+		if block.Type == "dynamic" {
+			items, moreDiags = c.expandDynamic(block, ctx)
+		} else {
+			var item *ast.ObjectItem
+			item, moreDiags = c.convertBlock(block.Type, block.Labels, block.Body, block.DefRange(), ctx)
+			if item != nil {
+				items = append(items, item)
+			}
+		}
+		diags = append(diags, moreDiags...)
+		all = append(all, positioned{block.Range().Start.Byte, items})
+	}
+
+	sort.SliceStable(all, func(i, j int) bool { return all[i].offset < all[j].offset })
+
+	list := &ast.ObjectList{}
+	for _, p := range all {
+		list.Items = append(list.Items, p.items...)
+	}
+	return list, diags
+}
+
+// convertBlock converts a block with the given type, labels and body.
+func (c *converter) convertBlock(typ string, labels []string, body *hclsyntax.Body, rng hcl.Range, ctx *hcl.EvalContext) (*ast.ObjectItem, hcl.Diagnostics) {
+	list, diags := c.convertBody(body, ctx)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+
+	pos := c.pos(rng)
+	keys := []*ast.ObjectKey{{Token: token.Token{Type: token.IDENT, Text: typ, Pos: pos}}}
+	for _, label := range labels {
+		keys = append(keys, &ast.ObjectKey{Token: stringToken(label, pos)})
+	}
+
+	return &ast.ObjectItem{
+		Keys: keys,
+		Val: &ast.ObjectType{
+			Lbrace: pos,
+			Rbrace: c.pos(body.EndRange),
+			List:   list,
+		},
+	}, diags
+}
+
+// expandDynamic expands a dynamic block into one block per element of its
+// for_each collection.
+func (c *converter) expandDynamic(block *hclsyntax.Block, ctx *hcl.EvalContext) ([]*ast.ObjectItem, hcl.Diagnostics) {
+	if len(block.Labels) != 1 {
+		return nil, hcl.Diagnostics{{
+			Severity: hcl.DiagError,
+			Summary:  "Invalid dynamic block",
+			Detail:   "A dynamic block must have exactly one label: the type of the blocks to generate.",
+			Subject:  block.DefRange().Ptr(),
+		}}
+	}
+	blockType := block.Labels[0]
+
+	content, diags := block.Body.Content(&hcl.BodySchema{
+		Attributes: []hcl.AttributeSchema{
+			{Name: "for_each", Required: true},
+			{Name: "iterator"},
+			{Name: "labels"},
+		},
+		Blocks: []hcl.BlockHeaderSchema{
+			{Type: "content"},
+		},
+	})
+	if diags.HasErrors() {
+		return nil, diags
+	}
+
+	var contentBody *hclsyntax.Body
+	for _, b := range block.Body.Blocks {
+		if b.Type != "content" {
+			continue
+		}
+		if contentBody != nil || len(b.Labels) != 0 {
+			return nil, append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Invalid dynamic block",
+				Detail:   "A dynamic block must have exactly one content block without labels.",
+				Subject:  b.DefRange().Ptr(),
+			})
+		}
+		contentBody = b.Body
+	}
+	if contentBody == nil {
+		return nil, append(diags, &hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Missing content block",
+			Detail:   "A dynamic block must have a content block.",
+			Subject:  block.DefRange().Ptr(),
+		})
+	}
+
+	iterator := blockType
+	if attr, ok := content.Attributes["iterator"]; ok {
+		traversal, moreDiags := hcl.AbsTraversalForExpr(attr.Expr)
+		diags = append(diags, moreDiags...)
+		if moreDiags.HasErrors() {
+			return nil, diags
+		}
+		if len(traversal) != 1 {
+			return nil, append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Invalid dynamic iterator name",
+				Detail:   "The iterator of a dynamic block must be a single identifier.",
+				Subject:  attr.Expr.Range().Ptr(),
+			})
+		}
+		iterator = traversal.RootName()
+	}
+
+	forEachAttr := content.Attributes["for_each"]
+	forEach, moreDiags := forEachAttr.Expr.Value(ctx)
+	diags = append(diags, moreDiags...)
+	if moreDiags.HasErrors() {
+		return nil, diags
+	}
+	if !forEach.IsWhollyKnown() || forEach.IsNull() || !forEach.CanIterateElements() {
+		return nil, append(diags, &hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Invalid dynamic for_each value",
+			Detail:   fmt.Sprintf("Cannot use a %s value in for_each. A known collection value is required.", forEach.Type().FriendlyName()),
+			Subject:  forEachAttr.Expr.Range().Ptr(),
+		})
+	}
+
+	var items []*ast.ObjectItem
+	for it := forEach.ElementIterator(); it.Next(); {
+		key, value := it.Element()
+
+		child := ctx.NewChild()
+		child.Variables = map[string]cty.Value{
+			iterator: cty.ObjectVal(map[string]cty.Value{
+				"key":   key,
+				"value": value,
+			}),
+		}
+
+		var labels []string
+		if attr, ok := content.Attributes["labels"]; ok {
+			val, moreDiags := attr.Expr.Value(child)
+			diags = append(diags, moreDiags...)
+			if moreDiags.HasErrors() {
+				return nil, diags
+			}
+
+			val, err := convert.Convert(val, cty.List(cty.String))
+			if err != nil || !val.IsWhollyKnown() || val.IsNull() {
+				return nil, append(diags, &hcl.Diagnostic{
+					Severity: hcl.DiagError,
+					Summary:  "Invalid dynamic block labels",
+					Detail:   "The labels of a dynamic block must be a list of strings.",
+					Subject:  attr.Expr.Range().Ptr(),
+				})
+			}
+			for _, label := range val.AsValueSlice() {
+				if label.IsNull() {
+					return nil, append(diags, &hcl.Diagnostic{
+						Severity: hcl.DiagError,
+						Summary:  "Invalid dynamic block labels",
+						Detail:   "The labels of a dynamic block must not be null.",
+						Subject:  attr.Expr.Range().Ptr(),
+					})
+				}
+				labels = append(labels, label.AsString())
+			}
+		}
+
+		item, moreDiags := c.convertBlock(blockType, labels, contentBody, block.DefRange(), child)
+		diags = append(diags, moreDiags...)
+		if moreDiags.HasErrors() {
+			return nil, diags
+		}
+		items = append(items, item)
+	}
+
+	return items, diags
+}
+
+// eval evaluates the expression. Strings, and lists and objects built from
+// them, are evaluated piecewise so that interpolations of undefined variables
+// are kept as is for Nomad to interpolate at runtime.
+func (c *converter) eval(expr hclsyntax.Expression, ctx *hcl.EvalContext) (cty.Value, hcl.Diagnostics) {
+	switch e := expr.(type) {
+	case *hclsyntax.TemplateWrapExpr:
+		if s, ok := c.runtimeInterpolation(e.Wrapped, ctx); ok {
+			return cty.StringVal(s), nil
+		}
+		return e.Value(ctx)
+
+	case *hclsyntax.TemplateExpr:
+		var diags hcl.Diagnostics
+		var buf strings.Builder
+		for _, part := range e.Parts {
+			if s, ok := c.runtimeInterpolation(part, ctx); ok {
+				buf.WriteString(s)
+				continue
+			}
+
+			val, moreDiags := part.Value(ctx)
+			diags = append(diags, moreDiags...)
+			if moreDiags.HasErrors() {
+				continue
+			}
+
+			str, err := convert.Convert(val, cty.String)
+			if err != nil || str.IsNull() || !str.IsKnown() {
+				diags = append(diags, &hcl.Diagnostic{
+					Severity: hcl.DiagError,
+					Summary:  "Invalid template interpolation value",
+					Detail:   fmt.Sprintf("Cannot include a %s value in a string template.", val.Type().FriendlyName()),
+					Subject:  part.Range().Ptr(),
+				})
+				continue
+			}
+			buf.WriteString(str.AsString())
+		}
+		return cty.StringVal(buf.String()), diags
+
+	case *hclsyntax.TupleConsExpr:
+		var diags hcl.Diagnostics
+		vals := make([]cty.Value, 0, len(e.Exprs))
+		for _, elem := range e.Exprs {
+			val, moreDiags := c.eval(elem, ctx)
+			diags = append(diags, moreDiags...)
+			vals = append(vals, val)
+		}
+		if diags.HasErrors() {
+			return cty.DynamicVal, diags
+		}
+		return cty.TupleVal(vals), diags
+
+	case *hclsyntax.ObjectConsExpr:
+		var diags hcl.Diagnostics
+		vals := make(map[string]cty.Value, len(e.Items))
+		for _, item := range e.Items {
+			key, moreDiags := item.KeyExpr.Value(ctx)
+			diags = append(diags, moreDiags...)
+			if moreDiags.HasErrors() {
+				continue
+			}
+
+			key, err := convert.Convert(key, cty.String)
+			if err != nil || key.IsNull() || !key.IsKnown() {
+				diags = append(diags, &hcl.Diagnostic{
+					Severity: hcl.DiagError,
+					Summary:  "Invalid object key",
+					Detail:   "The key of an object must be a string.",
+					Subject:  item.KeyExpr.Range().Ptr(),
+				})
+				continue
+			}
+
+			val, moreDiags := c.eval(item.ValueExpr, ctx)
+			diags = append(diags, moreDiags...)
+			vals[key.AsString()] = val
+		}
+		if diags.HasErrors() {
+			return cty.DynamicVal, diags
+		}
+		return cty.ObjectVal(vals), diags
+	}
+
+	return expr.Value(ctx)
+}
+
+// runtimeInterpolation returns the source of the interpolation if the
+// expression is a reference to a variable that is not defined in the
+// evaluation context.
+func (c *converter) runtimeInterpolation(expr hclsyntax.Expression, ctx *hcl.EvalContext) (string, bool) {
+	e, ok := expr.(*hclsyntax.ScopeTraversalExpr)
+	if !ok || isDefined(ctx, e.Traversal.RootName()) {
+		return "", false
+	}
+
+	rng := e.SrcRange
+	if rng.Filename != c.filename || rng.Start.Byte < 0 || rng.End.Byte > len(c.src) || rng.Start.Byte > rng.End.Byte {
+		return "", false
+	}
+	return "${" + string(c.src[rng.Start.Byte:rng.End.Byte]) + "}", true
+}
+
+// isDefined returns whether the variable is defined in the context or any of
+// its parents.
+func isDefined(ctx *hcl.EvalContext, name string) bool {
+	for ; ctx != nil; ctx = ctx.Parent() {
+		if _, ok := ctx.Variables[name]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// node converts a value to an HCL1 syntax tree node.
+func (c *converter) node(val cty.Value, rng hcl.Range) (ast.Node, error) {
+	if !val.IsWhollyKnown() {
+		return nil, fmt.Errorf("value is not known")
+	}
+	if val.IsNull() {
+		return nil, fmt.Errorf("null values are not allowed in lists and objects")
+	}
+
+	pos := c.pos(rng)
+	ty := val.Type()
+	switch {
+	case ty == cty.String:
+		return &ast.LiteralType{Token: stringToken(val.AsString(), pos)}, nil
+
+	case ty == cty.Bool:
+		return &ast.LiteralType{Token: token.Token{Type: token.BOOL, Text: strconv.FormatBool(val.True()), Pos: pos}}, nil
+
+	case ty == cty.Number:
+		bf := val.AsBigFloat()
+		if bf.IsInt() {
+			if i, acc := bf.Int64(); acc == big.Exact {
+				return &ast.LiteralType{Token: token.Token{Type: token.NUMBER, Text: strconv.FormatInt(i, 10), Pos: pos}}, nil
+			}
+		}
+		f, _ := bf.Float64()
+		return &ast.LiteralType{Token: token.Token{Type: token.FLOAT, Text: strconv.FormatFloat(f, 'g', -1, 64), Pos: pos}}, nil
+
+	case ty.IsListType() || ty.IsSetType() || ty.IsTupleType():
+		list := &ast.ListType{Lbrack: pos, Rbrack: pos}
+		for it := val.ElementIterator(); it.Next(); {
+			_, elem := it.Element()
+			n, err := c.node(elem, rng)
+			if err != nil {
+				return nil, err
+			}
+			list.Add(n)
+		}
+		return list, nil
+
+	case ty.IsMapType() || ty.IsObjectType():
+		list := &ast.ObjectList{}
+		for it := val.ElementIterator(); it.Next(); {
+			key, elem := it.Element()
+			if elem.IsNull() {
+				continue
+			}
+			n, err := c.node(elem, rng)
+			if err != nil {
+				return nil, err
+			}
+			list.Add(&ast.ObjectItem{
+				Keys:   []*ast.ObjectKey{{Token: stringToken(key.AsString(), pos)}},
+				Assign: pos,
+				Val:    n,
+			})
+		}
+		return &ast.ObjectType{Lbrace: pos, Rbrace: pos, List: list}, nil
+	}
+
+	return nil, fmt.Errorf("unsupported value type %s", ty.FriendlyName())
+}
+
+// pos returns the HCL1 position for the start of the range.
+func (c *converter) pos(rng hcl.Range) token.Pos {
+	return token.Pos{
+		Filename: rng.Filename,
+		Offset:   rng.Start.Byte,
+		Line:     rng.Start.Line,
+		Column:   rng.Start.Column,
+	}
+}
+
+// stringToken returns a string literal token. The token is quoted with Go's
+// quoting rules so that it is unquoted verbatim, including any runtime
+// interpolations.
+func stringToken(s string, pos token.Pos) token.Token {
+	return token.Token{Type: token.STRING, Text: strconv.Quote(s), Pos: pos, JSON: true}
+}
+
+func sortedAttributeNames(attrs map[string]*hclsyntax.Attribute) []string {
+	names := make([]string, 0, len(attrs))
+	for name := range attrs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,230 @@
+package jobspec2
+
+import (
+	"encoding/base64"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/hashicorp/hcl2/hcl"
+	"github.com/hashicorp/hcl2/hcl/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/convert"
+	"github.com/zclconf/go-cty/cty/function"
+	"github.com/zclconf/go-cty/cty/function/stdlib"
+)
+
+// Functions returns the functions available in job specifications. Relative
+// paths given to the file and templatefile functions are resolved against
+// baseDir. If allowFS is false, these functions return an error.
+func Functions(baseDir string, allowFS bool) map[string]function.Function {
+	funcs := map[string]function.Function{
+		"abs":             stdlib.AbsoluteFunc,
+		"base64decode":    Base64DecodeFunc,
+		"base64encode":    Base64EncodeFunc,
+		"coalesce":        stdlib.CoalesceFunc,
+		"concat":          stdlib.ConcatFunc,
+		"csvdecode":       stdlib.CSVDecodeFunc,
+		"file":            MakeFileFunc(baseDir, allowFS),
+		"format":          stdlib.FormatFunc,
+		"formatlist":      stdlib.FormatListFunc,
+		"join":            JoinFunc,
+		"jsondecode":      stdlib.JSONDecodeFunc,
+		"jsonencode":      stdlib.JSONEncodeFunc,
+		"length":          stdlib.LengthFunc,
+		"lower":           stdlib.LowerFunc,
+		"max":             stdlib.MaxFunc,
+		"min":             stdlib.MinFunc,
+		"replace":         ReplaceFunc,
+		"reverse":         stdlib.ReverseFunc,
+		"setintersection": stdlib.SetIntersectionFunc,
+		"setsubtract":     stdlib.SetSubtractFunc,
+		"setunion":        stdlib.SetUnionFunc,
+		"split":           SplitFunc,
+		"strlen":          stdlib.StrlenFunc,
+		"substr":          stdlib.SubstrFunc,
+		"trimspace":       TrimSpaceFunc,
+		"upper":           stdlib.UpperFunc,
+	}
+
+	// templatefile renders templates with the other functions, but may not
+	// call itself.
+	templateFuncs := make(map[string]function.Function, len(funcs))
+	for name, f := range funcs {
+		templateFuncs[name] = f
+	}
+	funcs["templatefile"] = MakeTemplateFileFunc(baseDir, allowFS, templateFuncs)
+
+	return funcs
+}
+
+// Base64DecodeFunc decodes a base64 encoded string.
+var Base64DecodeFunc = function.New(&function.Spec{
+	Params: []function.Parameter{
+		{Name: "str", Type: cty.String},
+	},
+	Type: function.StaticReturnType(cty.String),
+	Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+		decoded, err := base64.StdEncoding.DecodeString(args[0].AsString())
+		if err != nil {
+			return cty.UnknownVal(cty.String), fmt.Errorf("failed to decode base64 data: %v", err)
+		}
+		if !utf8.Valid(decoded) {
+			return cty.UnknownVal(cty.String), fmt.Errorf("the decoded data is not valid UTF-8")
+		}
+		return cty.StringVal(string(decoded)), nil
+	},
+})
+
+// Base64EncodeFunc encodes a string with base64.
+var Base64EncodeFunc = function.New(&function.Spec{
+	Params: []function.Parameter{
+		{Name: "str", Type: cty.String},
+	},
+	Type: function.StaticReturnType(cty.String),
+	Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+		return cty.StringVal(base64.StdEncoding.EncodeToString([]byte(args[0].AsString()))), nil
+	},
+})
+
+// JoinFunc concatenates the elements of a list of strings with a separator.
+var JoinFunc = function.New(&function.Spec{
+	Params: []function.Parameter{
+		{Name: "separator", Type: cty.String},
+		{Name: "list", Type: cty.List(cty.String)},
+	},
+	Type: function.StaticReturnType(cty.String),
+	Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+		var elems []string
+		for _, v := range args[1].AsValueSlice() {
+			if v.IsNull() {
+				return cty.UnknownVal(cty.String), fmt.Errorf("cannot join a null string")
+			}
+			elems = append(elems, v.AsString())
+		}
+		return cty.StringVal(strings.Join(elems, args[0].AsString())), nil
+	},
+})
+
+// SplitFunc splits a string into a list of strings around a separator.
+var SplitFunc = function.New(&function.Spec{
+	Params: []function.Parameter{
+		{Name: "separator", Type: cty.String},
+		{Name: "str", Type: cty.String},
+	},
+	Type: function.StaticReturnType(cty.List(cty.String)),
+	Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+		parts := strings.Split(args[1].AsString(), args[0].AsString())
+		vals := make([]cty.Value, len(parts))
+		for i, part := range parts {
+			vals[i] = cty.StringVal(part)
+		}
+		return cty.ListVal(vals), nil
+	},
+})
+
+// ReplaceFunc replaces all occurrences of a substring in a string.
+var ReplaceFunc = function.New(&function.Spec{
+	Params: []function.Parameter{
+		{Name: "str", Type: cty.String},
+		{Name: "substr", Type: cty.String},
+		{Name: "replace", Type: cty.String},
+	},
+	Type: function.StaticReturnType(cty.String),
+	Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+		return cty.StringVal(strings.Replace(args[0].AsString(), args[1].AsString(), args[2].AsString(), -1)), nil
+	},
+})
+
+// TrimSpaceFunc removes leading and trailing whitespace from a string.
+var TrimSpaceFunc = function.New(&function.Spec{
+	Params: []function.Parameter{
+		{Name: "str", Type: cty.String},
+	},
+	Type: function.StaticReturnType(cty.String),
+	Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+		return cty.StringVal(strings.TrimSpace(args[0].AsString())), nil
+	},
+})
+
+// MakeFileFunc returns a function that reads the content of a file.
+func MakeFileFunc(baseDir string, allowFS bool) function.Function {
+	return function.New(&function.Spec{
+		Params: []function.Parameter{
+			{Name: "path", Type: cty.String},
+		},
+		Type: function.StaticReturnType(cty.String),
+		Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+			src, err := readFile(baseDir, allowFS, args[0].AsString())
+			if err != nil {
+				return cty.UnknownVal(cty.String), err
+			}
+			return cty.StringVal(string(src)), nil
+		},
+	})
+}
+
+// MakeTemplateFileFunc returns a function that renders a template file with
+// the given variables. The template may call the given functions.
+func MakeTemplateFileFunc(baseDir string, allowFS bool, funcs map[string]function.Function) function.Function {
+	return function.New(&function.Spec{
+		Params: []function.Parameter{
+			{Name: "path", Type: cty.String},
+			{Name: "vars", Type: cty.DynamicPseudoType},
+		},
+		Type: function.StaticReturnType(cty.String),
+		Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+			path, vars := args[0].AsString(), args[1]
+
+			ty := vars.Type()
+			if !ty.IsObjectType() && !ty.IsMapType() {
+				return cty.UnknownVal(cty.String), fmt.Errorf("template variables must be an object or a map")
+			}
+			if vars.IsNull() || !vars.IsWhollyKnown() {
+				return cty.UnknownVal(cty.String), fmt.Errorf("template variables must be known")
+			}
+
+			src, err := readFile(baseDir, allowFS, path)
+			if err != nil {
+				return cty.UnknownVal(cty.String), err
+			}
+
+			expr, diags := hclsyntax.ParseTemplate(src, path, hcl.Pos{Line: 1, Column: 1})
+			if diags.HasErrors() {
+				return cty.UnknownVal(cty.String), diags
+			}
+
+			ctx := &hcl.EvalContext{
+				Variables: vars.AsValueMap(),
+				Functions: funcs,
+			}
+			val, diags := expr.Value(ctx)
+			if diags.HasErrors() {
+				return cty.UnknownVal(cty.String), diags
+			}
+			return convert.Convert(val, cty.String)
+		},
+	})
+}
+
+// readFile reads a UTF-8 encoded file for the file functions.
+func readFile(baseDir string, allowFS bool, path string) ([]byte, error) {
+	if !allowFS {
+		return nil, fmt.Errorf("filesystem functions are disabled")
+	}
+
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(baseDir, path)
+	}
+
+	src, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %q: %v", path, err)
+	}
+	if !utf8.Valid(src) {
+		return nil, fmt.Errorf("the file %q is not valid UTF-8", path)
+	}
+	return src, nil
+}
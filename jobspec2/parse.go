@@ -0,0 +1,176 @@
+package jobspec2
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/hashicorp/hcl/hcl/ast"
+	"github.com/hashicorp/hcl2/hcl"
+	"github.com/hashicorp/hcl2/hcl/hclsyntax"
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/nomad/jobspec"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// ParseConfig configures how an HCL2 job specification is parsed.
+type ParseConfig struct {
+	// Path is the path of the job file. It is used in error messages and to
+	// resolve the directory for file functions if BaseDir is not set.
+	Path string
+
+	// BaseDir is the directory relative paths passed to the file and
+	// templatefile functions are resolved against.
+	BaseDir string
+
+	// Body is the content of the job specification.
+	Body []byte
+
+	// AllowFS enables the functions that read from the filesystem. It must
+	// not be set when parsing job specifications on behalf of remote users.
+	AllowFS bool
+
+	// ArgVars are variable values in the "name=value" form, as passed with the
+	// -var command line flag. They take precedence over all other values.
+	ArgVars []string
+
+	// VarFiles are paths to variable definition files, as passed with the
+	// -var-file command line flag.
+	VarFiles []string
+
+	// VarContent is the content of a variable definition file.
+	VarContent string
+
+	// Envs are environment variables in the "KEY=value" form. Variables named
+	// NOMAD_VAR_<name> set the value of the variable <name>.
+	Envs []string
+}
+
+// Parse parses the HCL2 job specification from the given io.Reader, without
+// any variable values besides defaults.
+func Parse(path string, r io.Reader) (*api.Job, error) {
+	var buf bytes.Buffer
+	if _, err := io.Copy(&buf, r); err != nil {
+		return nil, err
+	}
+
+	return ParseWithConfig(&ParseConfig{
+		Path:    path,
+		BaseDir: filepath.Dir(path),
+		Body:    buf.Bytes(),
+		AllowFS: true,
+	})
+}
+
+// ParseFile parses the given path as an HCL2 job specification.
+func ParseFile(path string) (*api.Job, error) {
+	path, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return Parse(path, f)
+}
+
+// ParseWithConfig parses an HCL2 job specification. Variables, locals and
+// dynamic blocks are evaluated and the result is decoded with the same rules
+// as HCL1 job specifications. Job specifications in JSON are parsed by the
+// HCL1 parser.
+func ParseWithConfig(args *ParseConfig) (*api.Job, error) {
+	if isJSON(args.Body) {
+		return jobspec.Parse(bytes.NewReader(args.Body))
+	}
+
+	baseDir := args.BaseDir
+	if baseDir == "" {
+		baseDir = filepath.Dir(args.Path)
+	}
+
+	file, diags := hclsyntax.ParseConfig(args.Body, args.Path, hcl.Pos{Line: 1, Column: 1})
+	if diags.HasErrors() {
+		return nil, diags
+	}
+	body, ok := file.Body.(*hclsyntax.Body)
+	if !ok {
+		return nil, fmt.Errorf("error parsing: unexpected body type %T", file.Body)
+	}
+
+	c := &converter{
+		filename: args.Path,
+		src:      args.Body,
+	}
+
+	// Split the variable and locals definitions from the job itself
+	rest := &hclsyntax.Body{
+		Attributes: body.Attributes,
+		SrcRange:   body.SrcRange,
+		EndRange:   body.EndRange,
+	}
+	var variableBlocks, localsBlocks hclsyntax.Blocks
+	for _, block := range body.Blocks {
+		switch block.Type {
+		case "variable":
+			variableBlocks = append(variableBlocks, block)
+		case "locals":
+			localsBlocks = append(localsBlocks, block)
+		default:
+			rest.Blocks = append(rest.Blocks, block)
+		}
+	}
+
+	ctx := &hcl.EvalContext{
+		Variables: map[string]cty.Value{},
+		Functions: Functions(baseDir, args.AllowFS),
+	}
+
+	variables, diags := decodeVariables(variableBlocks)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+	values, diags := variables.values(args)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+	if diags := variables.validate(values, ctx.Functions); diags.HasErrors() {
+		return nil, diags
+	}
+	ctx.Variables["var"] = cty.ObjectVal(values)
+
+	locals, diags := c.evalLocals(localsBlocks, ctx)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+	ctx.Variables["local"] = cty.ObjectVal(locals)
+
+	list, diags := c.convertBody(rest, ctx)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+
+	return jobspec.ParseAST(&ast.File{Node: list})
+}
+
+// readVarFile returns the content of a variable definition file.
+func readVarFile(path string) ([]byte, error) {
+	src, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading variable file %q: %v", path, err)
+	}
+	return src, nil
+}
+
+// isJSON returns whether the content looks like a JSON document rather than
+// HCL native syntax.
+func isJSON(src []byte) bool {
+	src = bytes.TrimSpace(src)
+	return len(src) > 0 && src[0] == '{'
+}
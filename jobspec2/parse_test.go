@@ -0,0 +1,164 @@
+package jobspec2
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/nomad/helper"
+	"github.com/hashicorp/nomad/jobspec"
+	"github.com/kr/pretty"
+	"github.com/stretchr/testify/require"
+)
+
+func parseFixture(t *testing.T, name string, config *ParseConfig) (*api.Job, error) {
+	path, err := filepath.Abs(filepath.Join("./test-fixtures", name))
+	require.NoError(t, err)
+
+	src, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+
+	config.Path = path
+	config.Body = src
+	return ParseWithConfig(config)
+}
+
+// TestParse_HCL1Fixtures asserts that the HCL1 job specification fixtures
+// parse to the same job with the HCL2 parser.
+func TestParse_HCL1Fixtures(t *testing.T) {
+	files, err := filepath.Glob("../jobspec/test-fixtures/*.hcl")
+	require.NoError(t, err)
+	require.NotEmpty(t, files)
+
+	// These fixtures separate arguments with commas, which is not valid HCL2
+	skip := map[string]bool{
+		"reschedule-job.hcl":           true,
+		"reschedule-job-unlimited.hcl": true,
+	}
+
+	for _, file := range files {
+		if skip[filepath.Base(file)] {
+			continue
+		}
+
+		expected, expectedErr := jobspec.ParseFile(file)
+		actual, err := ParseFile(file)
+		if expectedErr != nil {
+			require.Error(t, err, file)
+			continue
+		}
+		require.NoError(t, err, file)
+
+		if !reflect.DeepEqual(expected, actual) {
+			for _, d := range pretty.Diff(expected, actual) {
+				t.Log(d)
+			}
+			t.Fatalf("file: %s", file)
+		}
+	}
+}
+
+func TestParse_Variables(t *testing.T) {
+	require := require.New(t)
+
+	job, err := parseFixture(t, "variables.hcl", &ParseConfig{
+		VarFiles: []string{"test-fixtures/variables.vars.hcl"},
+		Envs:     []string{"NOMAD_VAR_datacenters=[\"dc2\", \"dc3\"]", "NOMAD_VAR_unknown=x"},
+		ArgVars:  []string{"count=3"},
+	})
+	require.NoError(err)
+
+	require.Equal([]string{"dc2", "dc3"}, job.Datacenters)
+	require.Len(job.TaskGroups, 1)
+	require.Equal(3, *job.TaskGroups[0].Count)
+
+	task := job.TaskGroups[0].Tasks[0]
+	require.Equal("redis:latest", task.Config["image"])
+	require.Equal([]interface{}{"--dir", "${NOMAD_TASK_DIR}/data"}, task.Config["args"])
+	require.Equal(map[string]string{"KERNEL": "${attr.kernel.name}"}, task.Env)
+}
+
+func TestParse_Variables_Errors(t *testing.T) {
+	cases := []struct {
+		Name   string
+		Config *ParseConfig
+		Err    string
+	}{
+		{
+			Name:   "required",
+			Config: &ParseConfig{},
+			Err:    `The variable "image" is required`,
+		},
+		{
+			Name:   "undeclared",
+			Config: &ParseConfig{ArgVars: []string{"image=redis", "tag=3"}},
+			Err:    `undeclared variable "tag"`,
+		},
+		{
+			Name:   "type",
+			Config: &ParseConfig{ArgVars: []string{"image=redis", "count=many"}},
+			Err:    "not compatible with the variable's type constraint",
+		},
+		{
+			Name:   "validation",
+			Config: &ParseConfig{ArgVars: []string{"image=redis", "count=0"}},
+			Err:    "The count must be positive.",
+		},
+		{
+			Name:   "var content",
+			Config: &ParseConfig{VarContent: `image = "redis"` + "\n" + `other = 1`},
+			Err:    `undeclared variable "other"`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			_, err := parseFixture(t, "variables.hcl", tc.Config)
+			require.Error(t, err)
+			require.Contains(t, err.Error(), tc.Err)
+		})
+	}
+}
+
+func TestParse_Dynamic(t *testing.T) {
+	require := require.New(t)
+
+	job, err := parseFixture(t, "dynamic.hcl", &ParseConfig{})
+	require.NoError(err)
+
+	task := job.TaskGroups[0].Tasks[0]
+	require.Equal([]api.Port{
+		{Label: "admin", Value: 9090},
+		{Label: "http", Value: 8080},
+	}, task.Resources.Networks[0].ReservedPorts)
+
+	require.Len(task.Services, 1)
+	checks := task.Services[0].Checks
+	require.Len(checks, 2)
+	require.Equal("/health", checks[0].Path)
+	require.Equal("/ready", checks[1].Path)
+}
+
+func TestParse_Functions(t *testing.T) {
+	require := require.New(t)
+
+	job, err := parseFixture(t, "functions.hcl", &ParseConfig{
+		BaseDir: "test-fixtures",
+		AllowFS: true,
+	})
+	require.NoError(err)
+
+	require.Equal([]string{"dc1", "dc2"}, job.Datacenters)
+	require.Equal(map[string]string{"owner": "OPS", "ports": "[80,443]"}, job.Meta)
+
+	task := job.TaskGroups[0].Tasks[0]
+	require.Equal("server", task.Config["command"])
+	require.Equal(helper.StringToPtr("listen = 8080\n"), task.Templates[0].EmbeddedTmpl)
+
+	// Filesystem functions are rejected unless explicitly allowed
+	_, err = parseFixture(t, "functions.hcl", &ParseConfig{BaseDir: "test-fixtures"})
+	require.Error(err)
+	require.Contains(err.Error(), "filesystem functions are disabled")
+}
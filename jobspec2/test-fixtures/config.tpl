@@ -0,0 +1 @@
+listen = ${port}
@@ -0,0 +1,51 @@
+locals {
+  ports = {
+    http  = 8080
+    admin = 9090
+  }
+
+  checks = ["/health", "/ready"]
+}
+
+job "example" {
+  datacenters = ["dc1"]
+
+  group "web" {
+    task "server" {
+      driver = "exec"
+
+      config {
+        command = "server"
+      }
+
+      resources {
+        network {
+          dynamic "port" {
+            for_each = local.ports
+            labels   = [port.key]
+
+            content {
+              static = port.value
+            }
+          }
+        }
+      }
+
+      service {
+        port = "http"
+
+        dynamic "check" {
+          for_each = local.checks
+          iterator = path
+
+          content {
+            type     = "http"
+            path     = path.value
+            interval = "10s"
+            timeout  = "2s"
+          }
+        }
+      }
+    }
+  }
+}
@@ -0,0 +1,23 @@
+job "example" {
+  datacenters = split(",", "dc1,dc2")
+
+  meta {
+    owner = upper("ops")
+    ports = jsonencode([80, 443])
+  }
+
+  group "web" {
+    task "server" {
+      driver = "exec"
+
+      config {
+        command = trimspace(file("command.txt"))
+      }
+
+      template {
+        data        = templatefile("config.tpl", { port = 8080 })
+        destination = "local/config"
+      }
+    }
+  }
+}
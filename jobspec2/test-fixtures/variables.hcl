@@ -0,0 +1,45 @@
+variable "datacenters" {
+  type    = list(string)
+  default = ["dc1"]
+}
+
+variable "count" {
+  type        = number
+  description = "The number of cache instances"
+  default     = 1
+
+  validation {
+    condition     = var.count > 0
+    error_message = "The count must be positive."
+  }
+}
+
+variable "image" {
+  type = string
+}
+
+locals {
+  image_ref = "${var.image}:${local.tag}"
+  tag       = "latest"
+}
+
+job "example" {
+  datacenters = var.datacenters
+
+  group "cache" {
+    count = var.count
+
+    task "redis" {
+      driver = "docker"
+
+      config {
+        image = local.image_ref
+        args  = ["--dir", "${NOMAD_TASK_DIR}/data"]
+      }
+
+      env {
+        KERNEL = "${attr.kernel.name}"
+      }
+    }
+  }
+}
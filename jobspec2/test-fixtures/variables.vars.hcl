@@ -0,0 +1,2 @@
+image = "redis"
+count = 2
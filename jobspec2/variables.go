@@ -0,0 +1,442 @@
+package jobspec2
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl2/ext/typeexpr"
+	"github.com/hashicorp/hcl2/hcl"
+	"github.com/hashicorp/hcl2/hcl/hclsyntax"
+	hcljson "github.com/hashicorp/hcl2/hcl/json"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/convert"
+	"github.com/zclconf/go-cty/cty/function"
+)
+
+// envVarPrefix is the prefix of environment variables that set the value of
+// job specification variables.
+const envVarPrefix = "NOMAD_VAR_"
+
+// variable is a variable declared with a variable block.
+type variable struct {
+	Name        string
+	Description string
+
+	// Type is the type constraint of the variable. It is
+	// cty.DynamicPseudoType if the variable accepts any type.
+	Type cty.Type
+
+	// Default is the default value of the variable. It is only set if
+	// HasDefault is true, otherwise the variable is required.
+	Default    cty.Value
+	HasDefault bool
+
+	Validations []*validation
+
+	DeclRange hcl.Range
+}
+
+// validation is a custom validation rule of a variable.
+type validation struct {
+	Condition    hcl.Expression
+	ErrorMessage string
+
+	DeclRange hcl.Range
+}
+
+// variables are the declared variables of a job specification by name.
+type variables map[string]*variable
+
+// decodeVariables decodes the variable blocks of a job specification.
+func decodeVariables(blocks hclsyntax.Blocks) (variables, hcl.Diagnostics) {
+	var diags hcl.Diagnostics
+	vars := variables{}
+
+	for _, block := range blocks {
+		if len(block.Labels) != 1 {
+			diags = append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Invalid variable block",
+				Detail:   "A variable block must have exactly one label: the name of the variable.",
+				Subject:  block.DefRange().Ptr(),
+			})
+			continue
+		}
+
+		name := block.Labels[0]
+		if !hclsyntax.ValidIdentifier(name) {
+			diags = append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Invalid variable name",
+				Detail:   "A name must start with a letter and may contain only letters, digits, underscores, and dashes.",
+				Subject:  block.LabelRanges[0].Ptr(),
+			})
+			continue
+		}
+		if existing, ok := vars[name]; ok {
+			diags = append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Duplicate variable",
+				Detail:   fmt.Sprintf("A variable named %q was already declared at %s.", name, existing.DeclRange),
+				Subject:  block.DefRange().Ptr(),
+			})
+			continue
+		}
+
+		v, moreDiags := decodeVariable(name, block)
+		diags = append(diags, moreDiags...)
+		if v != nil {
+			vars[name] = v
+		}
+	}
+
+	return vars, diags
+}
+
+func decodeVariable(name string, block *hclsyntax.Block) (*variable, hcl.Diagnostics) {
+	v := &variable{
+		Name:      name,
+		Type:      cty.DynamicPseudoType,
+		DeclRange: block.DefRange(),
+	}
+
+	content, diags := block.Body.Content(&hcl.BodySchema{
+		Attributes: []hcl.AttributeSchema{
+			{Name: "description"},
+			{Name: "type"},
+			{Name: "default"},
+		},
+		Blocks: []hcl.BlockHeaderSchema{
+			{Type: "validation"},
+		},
+	})
+	if diags.HasErrors() {
+		return nil, diags
+	}
+
+	if attr, ok := content.Attributes["description"]; ok {
+		val, moreDiags := attr.Expr.Value(nil)
+		diags = append(diags, moreDiags...)
+		if !moreDiags.HasErrors() {
+			if val.Type() != cty.String || val.IsNull() {
+				diags = append(diags, &hcl.Diagnostic{
+					Severity: hcl.DiagError,
+					Summary:  "Invalid variable description",
+					Detail:   "The description of a variable must be a string.",
+					Subject:  attr.Expr.Range().Ptr(),
+				})
+			} else {
+				v.Description = val.AsString()
+			}
+		}
+	}
+
+	if attr, ok := content.Attributes["type"]; ok {
+		ty, moreDiags := typeexpr.TypeConstraint(attr.Expr)
+		diags = append(diags, moreDiags...)
+		if !moreDiags.HasErrors() {
+			v.Type = ty
+		}
+	}
+
+	if attr, ok := content.Attributes["default"]; ok {
+		val, moreDiags := attr.Expr.Value(nil)
+		diags = append(diags, moreDiags...)
+		if !moreDiags.HasErrors() {
+			val, err := convert.Convert(val, v.Type)
+			if err != nil {
+				diags = append(diags, &hcl.Diagnostic{
+					Severity: hcl.DiagError,
+					Summary:  "Invalid default value for variable",
+					Detail:   fmt.Sprintf("This default value is not compatible with the variable's type constraint: %s.", err),
+					Subject:  attr.Expr.Range().Ptr(),
+				})
+			} else {
+				v.Default = val
+				v.HasDefault = true
+			}
+		}
+	}
+
+	for _, block := range content.Blocks {
+		vv, moreDiags := decodeValidation(block)
+		diags = append(diags, moreDiags...)
+		if vv != nil {
+			v.Validations = append(v.Validations, vv)
+		}
+	}
+
+	return v, diags
+}
+
+func decodeValidation(block *hcl.Block) (*validation, hcl.Diagnostics) {
+	content, diags := block.Body.Content(&hcl.BodySchema{
+		Attributes: []hcl.AttributeSchema{
+			{Name: "condition", Required: true},
+			{Name: "error_message", Required: true},
+		},
+	})
+	if diags.HasErrors() {
+		return nil, diags
+	}
+
+	v := &validation{
+		Condition: content.Attributes["condition"].Expr,
+		DeclRange: block.DefRange,
+	}
+
+	msgAttr := content.Attributes["error_message"]
+	msg, moreDiags := msgAttr.Expr.Value(nil)
+	diags = append(diags, moreDiags...)
+	if moreDiags.HasErrors() {
+		return nil, diags
+	}
+	if msg.Type() != cty.String || msg.IsNull() || msg.AsString() == "" {
+		diags = append(diags, &hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Invalid validation error message",
+			Detail:   "The error message of a validation rule must be a non-empty string.",
+			Subject:  msgAttr.Expr.Range().Ptr(),
+		})
+		return nil, diags
+	}
+	v.ErrorMessage = msg.AsString()
+
+	return v, diags
+}
+
+// values returns the value of every declared variable. Values are taken from,
+// in increasing order of precedence: the variable defaults, environment
+// variables, variable files, the variable file content and command line
+// arguments.
+func (vars variables) values(args *ParseConfig) (map[string]cty.Value, hcl.Diagnostics) {
+	var diags hcl.Diagnostics
+	values := map[string]cty.Value{}
+
+	for name, v := range vars {
+		if v.HasDefault {
+			values[name] = v.Default
+		}
+	}
+
+	for _, env := range args.Envs {
+		if !strings.HasPrefix(env, envVarPrefix) {
+			continue
+		}
+		parts := strings.SplitN(strings.TrimPrefix(env, envVarPrefix), "=", 2)
+		if len(parts) != 2 {
+			continue
+		}
+
+		// Environment variables may be set for other job files, so unknown
+		// variables are ignored.
+		v, ok := vars[parts[0]]
+		if !ok {
+			continue
+		}
+
+		val, moreDiags := v.parseString(parts[1], fmt.Sprintf("%s%s", envVarPrefix, parts[0]))
+		diags = append(diags, moreDiags...)
+		if !moreDiags.HasErrors() {
+			values[v.Name] = val
+		}
+	}
+
+	for _, path := range args.VarFiles {
+		src, err := readVarFile(path)
+		if err != nil {
+			diags = append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Failed to read variable file",
+				Detail:   err.Error(),
+			})
+			continue
+		}
+		diags = append(diags, vars.decodeVarFile(path, src, values)...)
+	}
+
+	if args.VarContent != "" {
+		diags = append(diags, vars.decodeVarFile("variables.hcl", []byte(args.VarContent), values)...)
+	}
+
+	for _, arg := range args.ArgVars {
+		parts := strings.SplitN(arg, "=", 2)
+		if len(parts) != 2 {
+			diags = append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Invalid variable argument",
+				Detail:   fmt.Sprintf("The argument %q must be in the name=value form.", arg),
+			})
+			continue
+		}
+
+		v, ok := vars[parts[0]]
+		if !ok {
+			diags = append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Undefined variable",
+				Detail:   fmt.Sprintf("A value was given for the undeclared variable %q.", parts[0]),
+			})
+			continue
+		}
+
+		val, moreDiags := v.parseString(parts[1], fmt.Sprintf("-var %s", parts[0]))
+		diags = append(diags, moreDiags...)
+		if !moreDiags.HasErrors() {
+			values[v.Name] = val
+		}
+	}
+
+	for _, name := range vars.names() {
+		if _, ok := values[name]; !ok {
+			diags = append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Unset variable",
+				Detail:   fmt.Sprintf("The variable %q is required and has no default value.", name),
+				Subject:  vars[name].DeclRange.Ptr(),
+			})
+		}
+	}
+
+	return values, diags
+}
+
+// decodeVarFile decodes the variable values of a variable file into values.
+// Files with the .json extension use the JSON syntax.
+func (vars variables) decodeVarFile(path string, src []byte, values map[string]cty.Value) hcl.Diagnostics {
+	var file *hcl.File
+	var diags hcl.Diagnostics
+	if filepath.Ext(path) == ".json" {
+		file, diags = hcljson.Parse(src, path)
+	} else {
+		file, diags = hclsyntax.ParseConfig(src, path, hcl.Pos{Line: 1, Column: 1})
+	}
+	if diags.HasErrors() {
+		return diags
+	}
+
+	attrs, diags := file.Body.JustAttributes()
+	if diags.HasErrors() {
+		return diags
+	}
+
+	for name, attr := range attrs {
+		v, ok := vars[name]
+		if !ok {
+			diags = append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Undefined variable",
+				Detail:   fmt.Sprintf("A value was given for the undeclared variable %q.", name),
+				Subject:  attr.NameRange.Ptr(),
+			})
+			continue
+		}
+
+		val, moreDiags := attr.Expr.Value(nil)
+		diags = append(diags, moreDiags...)
+		if moreDiags.HasErrors() {
+			continue
+		}
+
+		val, err := convert.Convert(val, v.Type)
+		if err != nil {
+			diags = append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Invalid value for variable",
+				Detail:   fmt.Sprintf("The value of %q is not compatible with the variable's type constraint: %s.", name, err),
+				Subject:  attr.Expr.Range().Ptr(),
+			})
+			continue
+		}
+		values[name] = val
+	}
+
+	return diags
+}
+
+// parseString returns the value of the variable from a string given on the
+// command line or the environment. Values of variables with a primitive or
+// unconstrained type are taken literally; others are parsed as HCL
+// expressions.
+func (v *variable) parseString(raw, source string) (cty.Value, hcl.Diagnostics) {
+	var val cty.Value
+	if v.Type == cty.DynamicPseudoType || v.Type.IsPrimitiveType() {
+		val = cty.StringVal(raw)
+	} else {
+		expr, diags := hclsyntax.ParseExpression([]byte(raw), source, hcl.Pos{Line: 1, Column: 1})
+		if diags.HasErrors() {
+			return cty.NilVal, diags
+		}
+		val, diags = expr.Value(nil)
+		if diags.HasErrors() {
+			return cty.NilVal, diags
+		}
+	}
+
+	val, err := convert.Convert(val, v.Type)
+	if err != nil {
+		return cty.NilVal, hcl.Diagnostics{{
+			Severity: hcl.DiagError,
+			Summary:  "Invalid value for variable",
+			Detail:   fmt.Sprintf("The value given by %s is not compatible with the variable's type constraint: %s.", source, err),
+		}}
+	}
+	return val, nil
+}
+
+// validate evaluates the validation rules of every variable.
+func (vars variables) validate(values map[string]cty.Value, funcs map[string]function.Function) hcl.Diagnostics {
+	var diags hcl.Diagnostics
+
+	for _, name := range vars.names() {
+		v := vars[name]
+		ctx := &hcl.EvalContext{
+			Variables: map[string]cty.Value{
+				"var": cty.ObjectVal(map[string]cty.Value{name: values[name]}),
+			},
+			Functions: funcs,
+		}
+
+		for _, rule := range v.Validations {
+			result, moreDiags := rule.Condition.Value(ctx)
+			diags = append(diags, moreDiags...)
+			if moreDiags.HasErrors() {
+				continue
+			}
+
+			result, err := convert.Convert(result, cty.Bool)
+			if err != nil || result.IsNull() || !result.IsKnown() {
+				diags = append(diags, &hcl.Diagnostic{
+					Severity: hcl.DiagError,
+					Summary:  "Invalid validation result",
+					Detail:   "The condition of a validation rule must return either true or false.",
+					Subject:  rule.Condition.Range().Ptr(),
+				})
+				continue
+			}
+
+			if result.False() {
+				diags = append(diags, &hcl.Diagnostic{
+					Severity: hcl.DiagError,
+					Summary:  "Invalid value for variable",
+					Detail:   fmt.Sprintf("%s\n\nThis was checked by the validation rule at %s.", rule.ErrorMessage, rule.DeclRange),
+					Subject:  v.DeclRange.Ptr(),
+				})
+			}
+		}
+	}
+
+	return diags
+}
+
+// names returns the sorted names of the variables.
+func (vars variables) names() []string {
+	names := make([]string, 0, len(vars))
+	for name := range vars {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,146 @@
+// Package auth maps the claims of a verified identity token to the values used
+// by ACL binding rules.
+package auth
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Identity is the set of values mapped from the claims of a login token. Scalar
+// claims are addressed as "value.<name>" and list claims as "list.<name>" by
+// selectors and bind names.
+type Identity struct {
+	Values map[string]string
+	Lists  map[string][]string
+}
+
+// NewIdentity maps the claims to an identity. Both mappings are keyed by the
+// claim to read, which is either a top level claim name or a JSON pointer such
+// as "/groups/0", and map to the name the claim is addressed by. Claims which
+// are missing or can not be converted are skipped.
+func NewIdentity(claimMappings, listClaimMappings map[string]string, claims map[string]interface{}) *Identity {
+	id := &Identity{
+		Values: make(map[string]string, len(claimMappings)),
+		Lists:  make(map[string][]string, len(listClaimMappings)),
+	}
+
+	for claim, name := range claimMappings {
+		raw, ok := lookupClaim(claims, claim)
+		if !ok {
+			continue
+		}
+		if v, ok := stringifyClaim(raw); ok {
+			id.Values[name] = v
+		}
+	}
+
+	for claim, name := range listClaimMappings {
+		raw, ok := lookupClaim(claims, claim)
+		if !ok {
+			continue
+		}
+
+		var list []string
+		switch raw := raw.(type) {
+		case []interface{}:
+			for _, item := range raw {
+				if v, ok := stringifyClaim(item); ok {
+					list = append(list, v)
+				}
+			}
+		default:
+			if v, ok := stringifyClaim(raw); ok {
+				list = []string{v}
+			}
+		}
+		id.Lists[name] = list
+	}
+	return id
+}
+
+// lookupClaim returns the claim addressed by a name or JSON pointer
+func lookupClaim(claims map[string]interface{}, claim string) (interface{}, bool) {
+	if !strings.HasPrefix(claim, "/") {
+		v, ok := claims[claim]
+		return v, ok
+	}
+
+	var cur interface{} = claims
+	for _, part := range strings.Split(claim[1:], "/") {
+		part = strings.Replace(strings.Replace(part, "~1", "/", -1), "~0", "~", -1)
+		switch c := cur.(type) {
+		case map[string]interface{}:
+			v, ok := c[part]
+			if !ok {
+				return nil, false
+			}
+			cur = v
+		case []interface{}:
+			i, err := strconv.Atoi(part)
+			if err != nil || i < 0 || i >= len(c) {
+				return nil, false
+			}
+			cur = c[i]
+		default:
+			return nil, false
+		}
+	}
+	return cur, true
+}
+
+// stringifyClaim converts a scalar claim to its string form
+func stringifyClaim(raw interface{}) (string, bool) {
+	switch v := raw.(type) {
+	case string:
+		return v, true
+	case json.Number:
+		return v.String(), true
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), true
+	case bool:
+		return strconv.FormatBool(v), true
+	default:
+		return "", false
+	}
+}
+
+// bindNameVar matches the interpolated variables of a bind name
+var bindNameVar = regexp.MustCompile(`\$\{([^}]*)\}`)
+
+// InterpolateBindName replaces the "${value.<name>}" variables in the bind name
+// with the values of the identity. Referencing a missing value is an error.
+func (id *Identity) InterpolateBindName(bindName string) (string, error) {
+	var err error
+	out := bindNameVar.ReplaceAllStringFunc(bindName, func(match string) string {
+		name := strings.TrimSpace(match[2 : len(match)-1])
+		if !strings.HasPrefix(name, valuePrefix) {
+			err = fmt.Errorf("invalid bind name variable %q", name)
+			return ""
+		}
+		v, ok := id.Values[strings.TrimPrefix(name, valuePrefix)]
+		if !ok {
+			err = fmt.Errorf("bind name variable %q has no value", name)
+			return ""
+		}
+		return v
+	})
+	if err != nil {
+		return "", err
+	}
+	return out, nil
+}
+
+// ValidateBindName checks that the variables of a bind name are well formed
+func ValidateBindName(bindName string) error {
+	for _, match := range bindNameVar.FindAllStringSubmatch(bindName, -1) {
+		name := strings.TrimSpace(match[1])
+		if !strings.HasPrefix(name, valuePrefix) || len(name) == len(valuePrefix) {
+			return fmt.Errorf("invalid bind name variable %q", name)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,244 @@
+// Package jwt verifies the signature and registered claims of JSON Web Tokens
+// using a static set of public keys.
+package jwt
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	_ "crypto/sha256"
+	_ "crypto/sha512"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrMalformedToken is returned when a token can not be decoded
+	ErrMalformedToken = errors.New("malformed token")
+
+	// ErrInvalidSignature is returned when no key verifies the token signature
+	ErrInvalidSignature = errors.New("failed to verify token signature")
+)
+
+// header is the decoded JOSE header of a token
+type header struct {
+	Algorithm string `json:"alg"`
+	KeyID     string `json:"kid"`
+}
+
+// algorithm describes a supported signing algorithm
+type algorithm struct {
+	hash crypto.Hash
+	rsa  bool
+}
+
+// algorithms are the supported signing algorithms keyed by their JOSE name
+var algorithms = map[string]algorithm{
+	"RS256": {hash: crypto.SHA256, rsa: true},
+	"RS384": {hash: crypto.SHA384, rsa: true},
+	"RS512": {hash: crypto.SHA512, rsa: true},
+	"ES256": {hash: crypto.SHA256},
+	"ES384": {hash: crypto.SHA384},
+	"ES512": {hash: crypto.SHA512},
+}
+
+// Verify checks the signature of the token against the key set and returns
+// the decoded claims. The registered claims are not validated, use
+// ValidateClaims to do so.
+func (k *KeySet) Verify(token string) (map[string]interface{}, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, ErrMalformedToken
+	}
+
+	var hdr header
+	if err := decodeSegment(parts[0], &hdr); err != nil {
+		return nil, fmt.Errorf("%v: invalid header: %v", ErrMalformedToken, err)
+	}
+	alg, ok := algorithms[hdr.Algorithm]
+	if !ok {
+		return nil, fmt.Errorf("unsupported signing algorithm %q", hdr.Algorithm)
+	}
+
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("%v: invalid signature encoding: %v", ErrMalformedToken, err)
+	}
+
+	h := alg.hash.New()
+	h.Write([]byte(parts[0] + "." + parts[1]))
+	digest := h.Sum(nil)
+
+	verified := false
+	for _, key := range k.keys {
+		if hdr.KeyID != "" && key.id != "" && key.id != hdr.KeyID {
+			continue
+		}
+		if verifySignature(alg, key.pub, digest, sig) {
+			verified = true
+			break
+		}
+	}
+	if !verified {
+		return nil, ErrInvalidSignature
+	}
+
+	var claims map[string]interface{}
+	if err := decodeSegment(parts[1], &claims); err != nil {
+		return nil, fmt.Errorf("%v: invalid claims: %v", ErrMalformedToken, err)
+	}
+	return claims, nil
+}
+
+// DecodeClaims decodes the claims of the token into out without verifying its
+// signature. It must only be used on tokens received from a trusted source.
+func DecodeClaims(token string, out interface{}) error {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return ErrMalformedToken
+	}
+	if err := decodeSegment(parts[1], out); err != nil {
+		return fmt.Errorf("%v: invalid claims: %v", ErrMalformedToken, err)
+	}
+	return nil
+}
+
+// verifySignature returns whether the signature of the digest is valid for
+// the given public key.
+func verifySignature(alg algorithm, pub crypto.PublicKey, digest, sig []byte) bool {
+	switch pub := pub.(type) {
+	case *rsa.PublicKey:
+		if !alg.rsa {
+			return false
+		}
+		return rsa.VerifyPKCS1v15(pub, alg.hash, digest, sig) == nil
+	case *ecdsa.PublicKey:
+		if alg.rsa {
+			return false
+		}
+
+		// ECDSA signatures are the fixed size concatenation of R and S
+		size := (pub.Curve.Params().BitSize + 7) / 8
+		if len(sig) != 2*size {
+			return false
+		}
+		r := new(big.Int).SetBytes(sig[:size])
+		s := new(big.Int).SetBytes(sig[size:])
+		return ecdsa.Verify(pub, digest, r, s)
+	default:
+		return false
+	}
+}
+
+// decodeSegment decodes a base64url encoded JSON segment of a token. Numbers
+// are decoded as json.Number to preserve their exact value.
+func decodeSegment(seg string, out interface{}) error {
+	raw, err := base64.RawURLEncoding.DecodeString(seg)
+	if err != nil {
+		return err
+	}
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	return dec.Decode(out)
+}
+
+// Expected is the set of expectations used to validate the registered claims
+// of a token.
+type Expected struct {
+	// Issuer is the required value of the "iss" claim if set
+	Issuer string
+
+	// Audiences is the set of values of which at least one must be present
+	// in the "aud" claim if set
+	Audiences []string
+
+	// ClockSkewLeeway is the duration of leeway allowed when validating the
+	// time based claims
+	ClockSkewLeeway time.Duration
+
+	// Now is the time to validate against. Defaults to the current time.
+	Now time.Time
+}
+
+// ValidateClaims validates the registered claims "exp", "nbf", "iss" and "aud"
+// against the expectations.
+func ValidateClaims(claims map[string]interface{}, expected Expected) error {
+	now := expected.Now
+	if now.IsZero() {
+		now = time.Now()
+	}
+
+	if exp, ok, err := timeClaim(claims, "exp"); err != nil {
+		return err
+	} else if ok && now.After(exp.Add(expected.ClockSkewLeeway)) {
+		return errors.New("token is expired")
+	}
+	if nbf, ok, err := timeClaim(claims, "nbf"); err != nil {
+		return err
+	} else if ok && now.Add(expected.ClockSkewLeeway).Before(nbf) {
+		return errors.New("token is not yet valid")
+	}
+
+	if expected.Issuer != "" {
+		iss, _ := claims["iss"].(string)
+		if iss != expected.Issuer {
+			return fmt.Errorf("invalid issuer %q", iss)
+		}
+	}
+
+	if len(expected.Audiences) != 0 {
+		var audiences []string
+		switch aud := claims["aud"].(type) {
+		case string:
+			audiences = []string{aud}
+		case []interface{}:
+			for _, a := range aud {
+				if s, ok := a.(string); ok {
+					audiences = append(audiences, s)
+				}
+			}
+		}
+
+		found := false
+		for _, a := range audiences {
+			for _, e := range expected.Audiences {
+				if a == e {
+					found = true
+				}
+			}
+		}
+		if !found {
+			return errors.New("token audience does not match any bound audience")
+		}
+	}
+	return nil
+}
+
+// timeClaim returns the value of a NumericDate claim and whether it is set
+func timeClaim(claims map[string]interface{}, name string) (time.Time, bool, error) {
+	raw, ok := claims[name]
+	if !ok {
+		return time.Time{}, false, nil
+	}
+
+	var secs float64
+	switch v := raw.(type) {
+	case json.Number:
+		f, err := v.Float64()
+		if err != nil {
+			return time.Time{}, false, fmt.Errorf("invalid %q claim: %v", name, err)
+		}
+		secs = f
+	case float64:
+		secs = v
+	default:
+		return time.Time{}, false, fmt.Errorf("invalid %q claim", name)
+	}
+	return time.Unix(0, int64(secs*float64(time.Second))), true, nil
+}
@@ -0,0 +1,209 @@
+package jwt
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeySet_Verify_PEM(t *testing.T) {
+	require := require.New(t)
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(err)
+	other, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(err)
+
+	pubPEM, err := EncodePublicKeyPEM(key.Public())
+	require.NoError(err)
+	set, err := ParsePublicKeysPEM([]string{pubPEM})
+	require.NoError(err)
+	require.Equal(1, set.Len())
+
+	token, err := Sign(map[string]interface{}{"sub": "alice", "n": 12345678901234}, "", key)
+	require.NoError(err)
+
+	claims, err := set.Verify(token)
+	require.NoError(err)
+	require.Equal("alice", claims["sub"])
+	require.Equal("12345678901234", claims["n"].(interface{ String() string }).String())
+
+	// A token signed by another key is rejected
+	token, err = Sign(map[string]interface{}{"sub": "alice"}, "", other)
+	require.NoError(err)
+	_, err = set.Verify(token)
+	require.Equal(ErrInvalidSignature, err)
+
+	// A tampered token is rejected
+	parts := strings.Split(token, ".")
+	parts[1] = parts[1] + "a"
+	_, err = set.Verify(strings.Join(parts, "."))
+	require.Error(err)
+
+	// Malformed tokens are rejected
+	_, err = set.Verify("foo.bar")
+	require.Equal(ErrMalformedToken, err)
+
+	// Invalid PEM is rejected
+	_, err = ParsePublicKeysPEM([]string{"not a key"})
+	require.Error(err)
+}
+
+func TestKeySet_Verify_JWKS(t *testing.T) {
+	require := require.New(t)
+
+	k1, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(err)
+	k2, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(err)
+
+	raw, err := MarshalJWKS(map[string]crypto.PublicKey{
+		"k1": k1.Public(),
+		"k2": k2.Public(),
+	})
+	require.NoError(err)
+	set, err := ParseJWKS(raw)
+	require.NoError(err)
+	require.Equal(2, set.Len())
+
+	for kid, key := range map[string]crypto.Signer{"k1": k1, "k2": k2, "": k1} {
+		token, err := Sign(map[string]interface{}{"sub": "bob"}, kid, key)
+		require.NoError(err)
+		claims, err := set.Verify(token)
+		require.NoError(err)
+		require.Equal("bob", claims["sub"])
+	}
+
+	// A token whose key ID names another key is rejected
+	token, err := Sign(map[string]interface{}{"sub": "bob"}, "k2", k1)
+	require.NoError(err)
+	_, err = set.Verify(token)
+	require.Equal(ErrInvalidSignature, err)
+
+	// A set without signing keys is rejected
+	_, err = ParseJWKS([]byte(`{"keys":[{"kty":"oct","k":"c2VjcmV0"}]}`))
+	require.Error(err)
+}
+
+func TestKeySet_Verify_NewKeySet(t *testing.T) {
+	require := require.New(t)
+
+	k1, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(err)
+	k2, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(err)
+
+	set := NewKeySet(map[string]crypto.PublicKey{"k1": k1.Public()})
+	require.Equal(1, set.Len())
+
+	token, err := Sign(map[string]interface{}{"sub": "bob"}, "k1", k1)
+	require.NoError(err)
+	claims, err := set.Verify(token)
+	require.NoError(err)
+	require.Equal("bob", claims["sub"])
+
+	// A token signed by a key outside the set is rejected
+	token, err = Sign(map[string]interface{}{"sub": "bob"}, "k2", k2)
+	require.NoError(err)
+	_, err = set.Verify(token)
+	require.Equal(ErrInvalidSignature, err)
+}
+
+func TestDecodeClaims(t *testing.T) {
+	require := require.New(t)
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(err)
+	token, err := Sign(map[string]interface{}{"sub": "bob", "exp": 1000}, "k1", key)
+	require.NoError(err)
+
+	var claims struct {
+		Subject string `json:"sub"`
+		Expiry  int64  `json:"exp"`
+	}
+	require.NoError(DecodeClaims(token, &claims))
+	require.Equal("bob", claims.Subject)
+	require.Equal(int64(1000), claims.Expiry)
+
+	require.Equal(ErrMalformedToken, DecodeClaims("foo", &claims))
+}
+
+func TestValidateClaims(t *testing.T) {
+	now := time.Unix(1000000, 0)
+
+	cases := []struct {
+		name     string
+		claims   map[string]interface{}
+		expected Expected
+		err      string
+	}{
+		{
+			name:   "no claims",
+			claims: map[string]interface{}{},
+		},
+		{
+			name:     "expired",
+			claims:   map[string]interface{}{"exp": float64(999990)},
+			expected: Expected{Now: now},
+			err:      "expired",
+		},
+		{
+			name:     "expired within leeway",
+			claims:   map[string]interface{}{"exp": float64(999990)},
+			expected: Expected{Now: now, ClockSkewLeeway: time.Minute},
+		},
+		{
+			name:     "not yet valid",
+			claims:   map[string]interface{}{"nbf": float64(1000010)},
+			expected: Expected{Now: now},
+			err:      "not yet valid",
+		},
+		{
+			name:     "issuer mismatch",
+			claims:   map[string]interface{}{"iss": "other"},
+			expected: Expected{Now: now, Issuer: "nomad"},
+			err:      "invalid issuer",
+		},
+		{
+			name:     "audience string",
+			claims:   map[string]interface{}{"iss": "nomad", "aud": "a"},
+			expected: Expected{Now: now, Issuer: "nomad", Audiences: []string{"a", "b"}},
+		},
+		{
+			name:     "audience list",
+			claims:   map[string]interface{}{"aud": []interface{}{"c", "b"}},
+			expected: Expected{Now: now, Audiences: []string{"a", "b"}},
+		},
+		{
+			name:     "audience mismatch",
+			claims:   map[string]interface{}{"aud": "c"},
+			expected: Expected{Now: now, Audiences: []string{"a", "b"}},
+			err:      "audience",
+		},
+		{
+			name:     "invalid exp",
+			claims:   map[string]interface{}{"exp": "soon"},
+			expected: Expected{Now: now},
+			err:      "invalid \"exp\" claim",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			err := ValidateClaims(c.claims, c.expected)
+			if c.err == "" {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), c.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,236 @@
+package jwt
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+// KeySet is a set of public keys used to verify token signatures
+type KeySet struct {
+	keys []publicKey
+}
+
+// publicKey is a public key with its optional key ID
+type publicKey struct {
+	id  string
+	pub crypto.PublicKey
+}
+
+// Len returns the number of keys in the set
+func (k *KeySet) Len() int {
+	return len(k.keys)
+}
+
+// Merge adds the keys of the other set to this set
+func (k *KeySet) Merge(other *KeySet) {
+	k.keys = append(k.keys, other.keys...)
+}
+
+// NewKeySet returns a key set of the public keys, keyed by their key ID
+func NewKeySet(keys map[string]crypto.PublicKey) *KeySet {
+	set := &KeySet{}
+	for id, pub := range keys {
+		set.keys = append(set.keys, publicKey{id: id, pub: pub})
+	}
+	return set
+}
+
+// ParsePublicKeysPEM parses a set of PEM encoded RSA or ECDSA public keys or
+// certificates into a key set.
+func ParsePublicKeysPEM(pems []string) (*KeySet, error) {
+	set := &KeySet{}
+	for i, p := range pems {
+		block, _ := pem.Decode([]byte(p))
+		if block == nil {
+			return nil, fmt.Errorf("key %d: no PEM block found", i)
+		}
+
+		var pub crypto.PublicKey
+		switch block.Type {
+		case "CERTIFICATE":
+			cert, err := x509.ParseCertificate(block.Bytes)
+			if err != nil {
+				return nil, fmt.Errorf("key %d: %v", i, err)
+			}
+			pub = cert.PublicKey
+		case "RSA PUBLIC KEY":
+			key, err := x509.ParsePKCS1PublicKey(block.Bytes)
+			if err != nil {
+				return nil, fmt.Errorf("key %d: %v", i, err)
+			}
+			pub = key
+		default:
+			key, err := x509.ParsePKIXPublicKey(block.Bytes)
+			if err != nil {
+				return nil, fmt.Errorf("key %d: %v", i, err)
+			}
+			pub = key
+		}
+
+		switch pub.(type) {
+		case *rsa.PublicKey, *ecdsa.PublicKey:
+		default:
+			return nil, fmt.Errorf("key %d: unsupported key type %T", i, pub)
+		}
+		set.keys = append(set.keys, publicKey{pub: pub})
+	}
+	return set, nil
+}
+
+// jsonWebKey is the JSON encoding of a public JSON Web Key
+type jsonWebKey struct {
+	KeyType string `json:"kty"`
+	KeyID   string `json:"kid,omitempty"`
+	Use     string `json:"use,omitempty"`
+
+	// RSA parameters
+	N string `json:"n,omitempty"`
+	E string `json:"e,omitempty"`
+
+	// ECDSA parameters
+	Curve string `json:"crv,omitempty"`
+	X     string `json:"x,omitempty"`
+	Y     string `json:"y,omitempty"`
+}
+
+// jsonWebKeySet is the JSON encoding of a JSON Web Key Set
+type jsonWebKeySet struct {
+	Keys []jsonWebKey `json:"keys"`
+}
+
+// ParseJWKS parses a JSON Web Key Set document into a key set. Keys which are
+// not used for signatures or are of an unsupported type are skipped.
+func ParseJWKS(data []byte) (*KeySet, error) {
+	var jwks jsonWebKeySet
+	if err := json.Unmarshal(data, &jwks); err != nil {
+		return nil, fmt.Errorf("failed to decode JWKS: %v", err)
+	}
+
+	set := &KeySet{}
+	for i, jwk := range jwks.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+
+		var pub crypto.PublicKey
+		switch jwk.KeyType {
+		case "RSA":
+			n, err := decodeBigInt(jwk.N)
+			if err != nil {
+				return nil, fmt.Errorf("key %d: invalid modulus: %v", i, err)
+			}
+			e, err := decodeBigInt(jwk.E)
+			if err != nil {
+				return nil, fmt.Errorf("key %d: invalid exponent: %v", i, err)
+			}
+			if !e.IsInt64() {
+				return nil, fmt.Errorf("key %d: invalid exponent", i)
+			}
+			pub = &rsa.PublicKey{N: n, E: int(e.Int64())}
+		case "EC":
+			curve, err := curveByName(jwk.Curve)
+			if err != nil {
+				return nil, fmt.Errorf("key %d: %v", i, err)
+			}
+			x, err := decodeBigInt(jwk.X)
+			if err != nil {
+				return nil, fmt.Errorf("key %d: invalid x coordinate: %v", i, err)
+			}
+			y, err := decodeBigInt(jwk.Y)
+			if err != nil {
+				return nil, fmt.Errorf("key %d: invalid y coordinate: %v", i, err)
+			}
+			if !curve.IsOnCurve(x, y) {
+				return nil, fmt.Errorf("key %d: point is not on curve %s", i, jwk.Curve)
+			}
+			pub = &ecdsa.PublicKey{Curve: curve, X: x, Y: y}
+		default:
+			continue
+		}
+		set.keys = append(set.keys, publicKey{id: jwk.KeyID, pub: pub})
+	}
+
+	if len(set.keys) == 0 {
+		return nil, errors.New("JWKS contains no supported signing keys")
+	}
+	return set, nil
+}
+
+// MarshalJWKS encodes the public keys, keyed by their key ID, as a JSON Web
+// Key Set document.
+func MarshalJWKS(keys map[string]crypto.PublicKey) ([]byte, error) {
+	var jwks jsonWebKeySet
+	for id, pub := range keys {
+		switch pub := pub.(type) {
+		case *rsa.PublicKey:
+			jwks.Keys = append(jwks.Keys, jsonWebKey{
+				KeyType: "RSA",
+				KeyID:   id,
+				Use:     "sig",
+				N:       encodeBigInt(pub.N),
+				E:       encodeBigInt(big.NewInt(int64(pub.E))),
+			})
+		case *ecdsa.PublicKey:
+			size := (pub.Curve.Params().BitSize + 7) / 8
+			jwks.Keys = append(jwks.Keys, jsonWebKey{
+				KeyType: "EC",
+				KeyID:   id,
+				Use:     "sig",
+				Curve:   pub.Curve.Params().Name,
+				X:       base64.RawURLEncoding.EncodeToString(padBytes(pub.X.Bytes(), size)),
+				Y:       base64.RawURLEncoding.EncodeToString(padBytes(pub.Y.Bytes(), size)),
+			})
+		default:
+			return nil, fmt.Errorf("unsupported key type %T", pub)
+		}
+	}
+	return json.Marshal(jwks)
+}
+
+// curveByName returns the elliptic curve with the given JOSE name
+func curveByName(name string) (elliptic.Curve, error) {
+	switch name {
+	case "P-256":
+		return elliptic.P256(), nil
+	case "P-384":
+		return elliptic.P384(), nil
+	case "P-521":
+		return elliptic.P521(), nil
+	default:
+		return nil, fmt.Errorf("unsupported curve %q", name)
+	}
+}
+
+func decodeBigInt(s string) (*big.Int, error) {
+	if s == "" {
+		return nil, errors.New("missing value")
+	}
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Int).SetBytes(b), nil
+}
+
+func encodeBigInt(i *big.Int) string {
+	return base64.RawURLEncoding.EncodeToString(i.Bytes())
+}
+
+// padBytes left pads b with zeros to the given size
+func padBytes(b []byte, size int) []byte {
+	if len(b) >= size {
+		return b
+	}
+	out := make([]byte, size)
+	copy(out[size-len(b):], b)
+	return out
+}
@@ -0,0 +1,130 @@
+package oidc
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/nomad/lib/auth/jwt"
+)
+
+const (
+	// DefaultKeyCacheTTL is how long a discovered key set is used before it is
+	// discovered again
+	DefaultKeyCacheTTL = 15 * time.Minute
+
+	// DefaultKeyCacheMinRefresh is the minimum time between two discoveries
+	// of the key set of a provider, which limits the requests made to the
+	// provider by tokens signed with unknown keys
+	DefaultKeyCacheMinRefresh = time.Minute
+)
+
+// KeyCache caches the key sets discovered from OpenID Connect providers so
+// that verifying a token doesn't query the provider every time. Key sets are
+// discovered again once they expire, or earlier if a token is signed by a key
+// missing from the set, as happens when the provider rotates its keys.
+type KeyCache struct {
+	ttl        time.Duration
+	minRefresh time.Duration
+
+	// now is used to stub out time in tests
+	now func() time.Time
+
+	entries map[string]*keyCacheEntry
+	l       sync.Mutex
+}
+
+// keyCacheEntry is the key set discovered from a provider
+type keyCacheEntry struct {
+	keys    *jwt.KeySet
+	fetched time.Time
+
+	// l serializes the discoveries of the provider
+	l sync.Mutex
+}
+
+// NewKeyCache returns a key cache keeping key sets for the given TTL and
+// discovering them at most once per minRefresh.
+func NewKeyCache(ttl, minRefresh time.Duration) *KeyCache {
+	return &KeyCache{
+		ttl:        ttl,
+		minRefresh: minRefresh,
+		now:        time.Now,
+		entries:    make(map[string]*keyCacheEntry),
+	}
+}
+
+// Verify verifies the signature of the token using the key set of the issuer
+// and returns its claims. The key set is discovered with the given CA
+// certificates if it isn't cached, has expired or is missing the signing key
+// of the token.
+func (c *KeyCache) Verify(ctx context.Context, issuer string, caPEMs []string, token string) (map[string]interface{}, error) {
+	entry := c.entry(issuer, caPEMs)
+
+	keys, fresh, err := c.keys(ctx, entry, issuer, caPEMs, false)
+	if err != nil {
+		return nil, err
+	}
+	claims, err := keys.Verify(token)
+	if err != jwt.ErrInvalidSignature || fresh {
+		return claims, err
+	}
+
+	// The provider may have rotated its keys since they were discovered
+	keys, fresh, err = c.keys(ctx, entry, issuer, caPEMs, true)
+	if err != nil {
+		return nil, err
+	}
+	if !fresh {
+		return nil, jwt.ErrInvalidSignature
+	}
+	return keys.Verify(token)
+}
+
+// entry returns the cache entry of the issuer and CA certificates
+func (c *KeyCache) entry(issuer string, caPEMs []string) *keyCacheEntry {
+	h := sha256.New()
+	h.Write([]byte(issuer))
+	for _, ca := range caPEMs {
+		h.Write([]byte{0})
+		h.Write([]byte(ca))
+	}
+	id := hex.EncodeToString(h.Sum(nil))
+
+	c.l.Lock()
+	defer c.l.Unlock()
+	entry, ok := c.entries[id]
+	if !ok {
+		entry = &keyCacheEntry{}
+		c.entries[id] = entry
+	}
+	return entry
+}
+
+// keys returns the key set of the entry, discovering it if it is missing or
+// expired, or if a refresh is requested and the minimum refresh interval has
+// passed. It also returns whether the key set was discovered by this call.
+func (c *KeyCache) keys(ctx context.Context, entry *keyCacheEntry, issuer string, caPEMs []string, refresh bool) (*jwt.KeySet, bool, error) {
+	entry.l.Lock()
+	defer entry.l.Unlock()
+
+	now := c.now()
+	age := now.Sub(entry.fetched)
+	if entry.keys != nil && age < c.ttl && (!refresh || age < c.minRefresh) {
+		return entry.keys, false, nil
+	}
+
+	client, err := NewHTTPClient(caPEMs)
+	if err != nil {
+		return nil, false, err
+	}
+	keys, err := Discover(ctx, client, issuer)
+	if err != nil {
+		return nil, false, err
+	}
+	entry.keys = keys
+	entry.fetched = now
+	return keys, true, nil
+}
@@ -0,0 +1,102 @@
+// Package oidc discovers the signing keys of an OpenID Connect provider so that
+// the ID tokens it issues can be verified.
+package oidc
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	cleanhttp "github.com/hashicorp/go-cleanhttp"
+	"github.com/hashicorp/nomad/lib/auth/jwt"
+)
+
+const (
+	// discoveryPath is the well known path of the provider configuration
+	// relative to the issuer
+	discoveryPath = "/.well-known/openid-configuration"
+
+	// maxResponseSize is the maximum size of a discovery or JWKS response
+	maxResponseSize = 1 << 20
+)
+
+// providerConfig is the subset of the provider configuration used to
+// discover the signing keys
+type providerConfig struct {
+	Issuer  string `json:"issuer"`
+	JWKSURI string `json:"jwks_uri"`
+}
+
+// NewHTTPClient returns a client used to query the provider. If CA
+// certificates are given they replace the system roots.
+func NewHTTPClient(caPEMs []string) (*http.Client, error) {
+	transport := cleanhttp.DefaultTransport()
+	if len(caPEMs) != 0 {
+		pool := x509.NewCertPool()
+		for i, ca := range caPEMs {
+			if !pool.AppendCertsFromPEM([]byte(ca)) {
+				return nil, fmt.Errorf("invalid CA certificate %d", i)
+			}
+		}
+		transport.TLSClientConfig = &tls.Config{RootCAs: pool}
+	}
+	return &http.Client{
+		Transport: transport,
+		Timeout:   10 * time.Second,
+	}, nil
+}
+
+// Discover fetches the provider configuration of the issuer and returns the
+// key set it publishes. The issuer in the configuration must match the
+// requested issuer.
+func Discover(ctx context.Context, client *http.Client, issuer string) (*jwt.KeySet, error) {
+	var config providerConfig
+	url := strings.TrimSuffix(issuer, "/") + discoveryPath
+	if err := getJSON(ctx, client, url, &config); err != nil {
+		return nil, fmt.Errorf("failed to fetch provider configuration: %v", err)
+	}
+	if config.Issuer != issuer {
+		return nil, fmt.Errorf("issuer %q does not match discovery URL %q", config.Issuer, issuer)
+	}
+	if config.JWKSURI == "" {
+		return nil, fmt.Errorf("provider configuration is missing jwks_uri")
+	}
+
+	var raw json.RawMessage
+	if err := getJSON(ctx, client, config.JWKSURI, &raw); err != nil {
+		return nil, fmt.Errorf("failed to fetch provider keys: %v", err)
+	}
+	return jwt.ParseJWKS(raw)
+}
+
+// getJSON decodes the JSON response of a GET request to the URL into out
+func getJSON(ctx context.Context, client *http.Client, url string, out interface{}) error {
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return err
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected response code %d from %s", resp.StatusCode, url)
+	}
+	return json.Unmarshal(body, out)
+}
@@ -0,0 +1,98 @@
+package oidc
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/lib/auth/jwt"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiscover(t *testing.T) {
+	require := require.New(t)
+
+	p := NewTestProvider(t)
+	defer p.Stop()
+
+	client, err := NewHTTPClient(nil)
+	require.NoError(err)
+
+	keys, err := Discover(context.Background(), client, p.Issuer())
+	require.NoError(err)
+	require.Equal(1, keys.Len())
+
+	token := p.SignIDToken(t, map[string]interface{}{"sub": "alice"})
+	claims, err := keys.Verify(token)
+	require.NoError(err)
+	require.Equal("alice", claims["sub"])
+	require.Equal(p.Issuer(), claims["iss"])
+
+	// The issuer must match the discovery URL
+	_, err = Discover(context.Background(), client, p.Issuer()+"/")
+	require.Error(err)
+	require.Contains(err.Error(), "does not match")
+}
+
+func TestDiscover_Unavailable(t *testing.T) {
+	require := require.New(t)
+
+	server := httptest.NewServer(http.NotFoundHandler())
+	defer server.Close()
+
+	client, err := NewHTTPClient(nil)
+	require.NoError(err)
+
+	_, err = Discover(context.Background(), client, server.URL)
+	require.Error(err)
+	require.Contains(err.Error(), "404")
+
+	// Invalid CA certificates are rejected
+	_, err = NewHTTPClient([]string{"not a certificate"})
+	require.Error(err)
+}
+
+func TestKeyCache(t *testing.T) {
+	require := require.New(t)
+
+	p := NewTestProvider(t)
+	defer p.Stop()
+
+	now := time.Now()
+	cache := NewKeyCache(time.Hour, time.Minute)
+	cache.now = func() time.Time { return now }
+
+	// The key set is discovered once and then cached
+	for i := 0; i < 3; i++ {
+		token := p.SignIDToken(t, map[string]interface{}{"sub": "alice"})
+		claims, err := cache.Verify(context.Background(), p.Issuer(), nil, token)
+		require.NoError(err)
+		require.Equal("alice", claims["sub"])
+	}
+	require.Equal(1, p.KeyFetches())
+
+	// Tokens signed by a rotated key are verified after a refresh
+	p.RotateKey(t)
+	now = now.Add(2 * time.Minute)
+	token := p.SignIDToken(t, map[string]interface{}{"sub": "bob"})
+	claims, err := cache.Verify(context.Background(), p.Issuer(), nil, token)
+	require.NoError(err)
+	require.Equal("bob", claims["sub"])
+	require.Equal(2, p.KeyFetches())
+
+	// Refreshes are rate limited
+	p.RotateKey(t)
+	token = p.SignIDToken(t, map[string]interface{}{"sub": "carol"})
+	_, err = cache.Verify(context.Background(), p.Issuer(), nil, token)
+	require.Equal(jwt.ErrInvalidSignature, err)
+	require.Equal(2, p.KeyFetches())
+
+	// Expired key sets are discovered again
+	now = now.Add(2 * time.Hour)
+	claims, err = cache.Verify(context.Background(), p.Issuer(), nil, token)
+	require.NoError(err)
+	require.Equal("carol", claims["sub"])
+	require.Equal(3, p.KeyFetches())
+}
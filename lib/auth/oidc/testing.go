@@ -0,0 +1,99 @@
+package oidc
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+
+	"github.com/hashicorp/nomad/lib/auth/jwt"
+	testing "github.com/mitchellh/go-testing-interface"
+)
+
+// TestProvider is a local stand-in for an OpenID Connect provider. It serves
+// the discovery document and key set of a generated signing key, and signs ID
+// tokens with it.
+type TestProvider struct {
+	server *httptest.Server
+
+	key        *rsa.PrivateKey
+	keyID      string
+	keyVersion int
+	jwks       []byte
+	keyFetches int
+	l          sync.Mutex
+}
+
+// NewTestProvider starts a test provider. Stop must be called to shut it down.
+func NewTestProvider(t testing.T) *TestProvider {
+	p := &TestProvider{}
+	p.RotateKey(t)
+	mux := http.NewServeMux()
+	mux.HandleFunc(discoveryPath, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"issuer":%q,"jwks_uri":%q}`, p.Issuer(), p.Issuer()+"/keys")
+	})
+	mux.HandleFunc("/keys", func(w http.ResponseWriter, r *http.Request) {
+		p.l.Lock()
+		defer p.l.Unlock()
+		p.keyFetches++
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(p.jwks)
+	})
+	p.server = httptest.NewServer(mux)
+	return p
+}
+
+// RotateKey replaces the signing key of the provider with a new one
+func (p *TestProvider) RotateKey(t testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+
+	p.l.Lock()
+	defer p.l.Unlock()
+	p.keyVersion++
+	keyID := fmt.Sprintf("test-key-%d", p.keyVersion)
+	jwks, err := jwt.MarshalJWKS(map[string]crypto.PublicKey{keyID: key.Public()})
+	if err != nil {
+		t.Fatalf("failed to encode key set: %v", err)
+	}
+	p.key, p.keyID, p.jwks = key, keyID, jwks
+}
+
+// KeyFetches returns the number of times the key set was fetched
+func (p *TestProvider) KeyFetches() int {
+	p.l.Lock()
+	defer p.l.Unlock()
+	return p.keyFetches
+}
+
+// Issuer returns the issuer URL of the provider, which is also its discovery
+// URL.
+func (p *TestProvider) Issuer() string {
+	return p.server.URL
+}
+
+// SignIDToken returns an ID token with the given claims signed by the
+// provider. The issuer claim is set if missing.
+func (p *TestProvider) SignIDToken(t testing.T, claims map[string]interface{}) string {
+	if _, ok := claims["iss"]; !ok {
+		claims["iss"] = p.Issuer()
+	}
+	p.l.Lock()
+	defer p.l.Unlock()
+	token, err := jwt.Sign(claims, p.keyID, p.key)
+	if err != nil {
+		t.Fatalf("failed to sign token: %v", err)
+	}
+	return token
+}
+
+// Stop shuts down the provider
+func (p *TestProvider) Stop() {
+	p.server.Close()
+}
@@ -0,0 +1,314 @@
+package auth
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+const (
+	valuePrefix = "value."
+	listPrefix  = "list."
+)
+
+// Selector is a parsed boolean expression evaluated against an identity.
+//
+// Expressions are built from the following clauses:
+//
+//	value.<name> == "<string>"
+//	value.<name> != "<string>"
+//	"<string>" in list.<name>
+//	"<string>" not in list.<name>
+//
+// which may be combined with "and", "or", "not" and parentheses. The empty
+// selector matches every identity.
+type Selector struct {
+	root node
+}
+
+// node is an evaluable node of a selector expression
+type node interface {
+	eval(id *Identity) bool
+}
+
+type andNode struct{ left, right node }
+
+func (n andNode) eval(id *Identity) bool { return n.left.eval(id) && n.right.eval(id) }
+
+type orNode struct{ left, right node }
+
+func (n orNode) eval(id *Identity) bool { return n.left.eval(id) || n.right.eval(id) }
+
+type notNode struct{ inner node }
+
+func (n notNode) eval(id *Identity) bool { return !n.inner.eval(id) }
+
+type equalNode struct {
+	name  string
+	value string
+}
+
+func (n equalNode) eval(id *Identity) bool {
+	v, ok := id.Values[n.name]
+	return ok && v == n.value
+}
+
+type inNode struct {
+	name  string
+	value string
+}
+
+func (n inNode) eval(id *Identity) bool {
+	for _, v := range id.Lists[n.name] {
+		if v == n.value {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseSelector parses a selector expression
+func ParseSelector(expr string) (*Selector, error) {
+	tokens, err := tokenize(expr)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return &Selector{}, nil
+	}
+
+	p := &parser{tokens: tokens}
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if !p.done() {
+		return nil, fmt.Errorf("unexpected %q", p.peek().text)
+	}
+	return &Selector{root: root}, nil
+}
+
+// Match returns whether the identity matches the selector
+func (s *Selector) Match(id *Identity) bool {
+	if s.root == nil {
+		return true
+	}
+	return s.root.eval(id)
+}
+
+// tokenKind is the kind of a lexical token
+type tokenKind int
+
+const (
+	tokenIdent tokenKind = iota
+	tokenString
+	tokenOperator
+)
+
+type token struct {
+	kind tokenKind
+	text string
+}
+
+// tokenize splits an expression into identifiers, quoted strings and
+// operators
+func tokenize(expr string) ([]token, error) {
+	var tokens []token
+	for i := 0; i < len(expr); {
+		c := expr[i]
+		switch {
+		case unicode.IsSpace(rune(c)):
+			i++
+		case c == '(' || c == ')':
+			tokens = append(tokens, token{tokenOperator, string(c)})
+			i++
+		case c == '=' || c == '!':
+			if i+1 >= len(expr) || expr[i+1] != '=' {
+				return nil, fmt.Errorf("invalid operator at position %d", i)
+			}
+			tokens = append(tokens, token{tokenOperator, expr[i : i+2]})
+			i += 2
+		case c == '"':
+			end := i + 1
+			for ; end < len(expr) && expr[end] != '"'; end++ {
+				if expr[end] == '\\' {
+					end++
+				}
+			}
+			if end >= len(expr) {
+				return nil, fmt.Errorf("unterminated string at position %d", i)
+			}
+			s, err := strconv.Unquote(expr[i : end+1])
+			if err != nil {
+				return nil, fmt.Errorf("invalid string at position %d: %v", i, err)
+			}
+			tokens = append(tokens, token{tokenString, s})
+			i = end + 1
+		case isIdentChar(c):
+			end := i
+			for end < len(expr) && isIdentChar(expr[end]) {
+				end++
+			}
+			tokens = append(tokens, token{tokenIdent, expr[i:end]})
+			i = end
+		default:
+			return nil, fmt.Errorf("unexpected character %q at position %d", c, i)
+		}
+	}
+	return tokens, nil
+}
+
+func isIdentChar(c byte) bool {
+	return c == '_' || c == '-' || c == '.' || c == '/' ||
+		(c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+}
+
+// parser is a recursive descent parser of selector expressions
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *parser) done() bool {
+	return p.pos >= len(p.tokens)
+}
+
+func (p *parser) peek() token {
+	if p.done() {
+		return token{}
+	}
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() (token, error) {
+	if p.done() {
+		return token{}, fmt.Errorf("unexpected end of selector")
+	}
+	t := p.tokens[p.pos]
+	p.pos++
+	return t, nil
+}
+
+// keyword returns whether the next token is the given keyword, consuming it
+// if so
+func (p *parser) keyword(word string) bool {
+	if t := p.peek(); t.kind == tokenIdent && t.text == word {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *parser) parseOr() (node, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.keyword("or") {
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = orNode{left, right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseAnd() (node, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.keyword("and") {
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = andNode{left, right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseUnary() (node, error) {
+	if p.keyword("not") {
+		inner, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return notNode{inner}, nil
+	}
+
+	if t := p.peek(); t.kind == tokenOperator && t.text == "(" {
+		p.pos++
+		inner, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if t, err := p.next(); err != nil {
+			return nil, err
+		} else if t.kind != tokenOperator || t.text != ")" {
+			return nil, fmt.Errorf("expected \")\" but found %q", t.text)
+		}
+		return inner, nil
+	}
+	return p.parseClause()
+}
+
+func (p *parser) parseClause() (node, error) {
+	first, err := p.next()
+	if err != nil {
+		return nil, err
+	}
+
+	switch first.kind {
+	case tokenIdent:
+		// value.<name> == "<string>"
+		if !strings.HasPrefix(first.text, valuePrefix) || len(first.text) == len(valuePrefix) {
+			return nil, fmt.Errorf("invalid selector %q: must reference a value", first.text)
+		}
+		op, err := p.next()
+		if err != nil {
+			return nil, err
+		}
+		if op.kind != tokenOperator || (op.text != "==" && op.text != "!=") {
+			return nil, fmt.Errorf("expected \"==\" or \"!=\" but found %q", op.text)
+		}
+		value, err := p.next()
+		if err != nil {
+			return nil, err
+		}
+		if value.kind != tokenString {
+			return nil, fmt.Errorf("expected a quoted string but found %q", value.text)
+		}
+
+		var n node = equalNode{name: strings.TrimPrefix(first.text, valuePrefix), value: value.text}
+		if op.text == "!=" {
+			n = notNode{n}
+		}
+		return n, nil
+
+	case tokenString:
+		// "<string>" [not] in list.<name>
+		negate := p.keyword("not")
+		if !p.keyword("in") {
+			return nil, fmt.Errorf("expected \"in\" but found %q", p.peek().text)
+		}
+		list, err := p.next()
+		if err != nil {
+			return nil, err
+		}
+		if list.kind != tokenIdent || !strings.HasPrefix(list.text, listPrefix) || len(list.text) == len(listPrefix) {
+			return nil, fmt.Errorf("invalid selector %q: must reference a list", list.text)
+		}
+
+		var n node = inNode{name: strings.TrimPrefix(list.text, listPrefix), value: first.text}
+		if negate {
+			n = notNode{n}
+		}
+		return n, nil
+
+	default:
+		return nil, fmt.Errorf("unexpected %q", first.text)
+	}
+}
@@ -0,0 +1,141 @@
+package auth
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewIdentity(t *testing.T) {
+	require := require.New(t)
+
+	claims := map[string]interface{}{
+		"sub":    "alice",
+		"admin":  true,
+		"uid":    json.Number("1001"),
+		"groups": []interface{}{"dev", "ops", 3.5},
+		"team":   "infra",
+		"nested": map[string]interface{}{
+			"a/b": map[string]interface{}{"env": "prod"},
+		},
+		"object": map[string]interface{}{},
+	}
+
+	id := NewIdentity(
+		map[string]string{
+			"sub":              "user",
+			"admin":            "admin",
+			"uid":              "uid",
+			"/nested/a~1b/env": "env",
+			"object":           "object",
+			"missing":          "missing",
+		},
+		map[string]string{
+			"groups":    "groups",
+			"team":      "teams",
+			"/groups/1": "second",
+		},
+		claims)
+
+	require.Equal(map[string]string{
+		"user":  "alice",
+		"admin": "true",
+		"uid":   "1001",
+		"env":   "prod",
+	}, id.Values)
+	require.Equal(map[string][]string{
+		"groups": {"dev", "ops", "3.5"},
+		"teams":  {"infra"},
+		"second": {"ops"},
+	}, id.Lists)
+}
+
+func TestSelector(t *testing.T) {
+	id := &Identity{
+		Values: map[string]string{"user": "alice", "env": "prod"},
+		Lists:  map[string][]string{"groups": {"dev", "ops"}},
+	}
+
+	cases := []struct {
+		selector string
+		match    bool
+	}{
+		{``, true},
+		{`value.user == "alice"`, true},
+		{`value.user == "bob"`, false},
+		{`value.user != "bob"`, true},
+		{`value.missing == ""`, false},
+		{`value.missing != "x"`, true},
+		{`"ops" in list.groups`, true},
+		{`"admin" in list.groups`, false},
+		{`"admin" not in list.groups`, true},
+		{`"ops" in list.missing`, false},
+		{`value.user == "alice" and "dev" in list.groups`, true},
+		{`value.user == "bob" or "dev" in list.groups`, true},
+		{`value.user == "bob" or value.env == "dev" and "dev" in list.groups`, false},
+		{`(value.user == "bob" or value.env == "prod") and "dev" in list.groups`, true},
+		{`not value.user == "alice"`, false},
+		{`not (value.user == "bob")`, true},
+		{`value.user == "al\"ice"`, false},
+	}
+
+	for _, c := range cases {
+		t.Run(c.selector, func(t *testing.T) {
+			s, err := ParseSelector(c.selector)
+			require.NoError(t, err)
+			require.Equal(t, c.match, s.Match(id))
+		})
+	}
+}
+
+func TestSelector_Invalid(t *testing.T) {
+	cases := []string{
+		`value.user`,
+		`value.user = "alice"`,
+		`value.user == alice`,
+		`user == "alice"`,
+		`value. == "alice"`,
+		`list.groups == "dev"`,
+		`"dev" in value.user`,
+		`"dev" list.groups`,
+		`(value.user == "alice"`,
+		`value.user == "alice")`,
+		`value.user == "alice" and`,
+		`value.user == "alice`,
+		`value.user == "alice" &&`,
+	}
+
+	for _, c := range cases {
+		t.Run(c, func(t *testing.T) {
+			_, err := ParseSelector(c)
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestIdentity_InterpolateBindName(t *testing.T) {
+	require := require.New(t)
+
+	id := &Identity{
+		Values: map[string]string{"user": "alice", "team": "infra"},
+	}
+
+	out, err := id.InterpolateBindName("static")
+	require.NoError(err)
+	require.Equal("static", out)
+
+	out, err = id.InterpolateBindName("${value.team}-${ value.user }")
+	require.NoError(err)
+	require.Equal("infra-alice", out)
+
+	_, err = id.InterpolateBindName("${value.missing}")
+	require.Error(err)
+
+	_, err = id.InterpolateBindName("${list.groups}")
+	require.Error(err)
+
+	require.NoError(ValidateBindName("${value.team}-admin"))
+	require.Error(ValidateBindName("${list.groups}"))
+	require.Error(ValidateBindName("${value.}"))
+}
@@ -0,0 +1,171 @@
+package cpuset
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// CPUSet is a set like object that provides methods helpful when working with
+// cpus with systems such as the Linux cpuset cgroup subsystem. A CPUSet is
+// immutable and can be safely accessed concurrently.
+type CPUSet struct {
+	cpus map[uint16]struct{}
+}
+
+// New initializes a new CPUSet with 0 or more containing cpus
+func New(cpus ...uint16) CPUSet {
+	cpuset := CPUSet{
+		cpus: make(map[uint16]struct{}, len(cpus)),
+	}
+
+	for _, v := range cpus {
+		cpuset.cpus[v] = struct{}{}
+	}
+
+	return cpuset
+}
+
+// Size returns to the number of cpus contained in the CPUSet
+func (c CPUSet) Size() int {
+	return len(c.cpus)
+}
+
+// ToSlice returns a sorted slice of uint16 CPU IDs contained in the CPUSet.
+func (c CPUSet) ToSlice() []uint16 {
+	cpus := make([]uint16, 0, len(c.cpus))
+	for k := range c.cpus {
+		cpus = append(cpus, k)
+	}
+	sort.Slice(cpus, func(i, j int) bool { return cpus[i] < cpus[j] })
+	return cpus
+}
+
+// Union returns a new set that is the union of this CPUSet and the supplied
+// other. Ex. [0,1,2,3].Union([2,3,4,5]) = [0,1,2,3,4,5]
+func (c CPUSet) Union(other CPUSet) CPUSet {
+	s := New()
+	for k := range c.cpus {
+		s.cpus[k] = struct{}{}
+	}
+	for k := range other.cpus {
+		s.cpus[k] = struct{}{}
+	}
+	return s
+}
+
+// Difference returns a new set that is the difference of this CPUSet and the
+// supplied other. Ex. [0,1,2,3].Difference([2,3,4]) = [0,1]
+func (c CPUSet) Difference(other CPUSet) CPUSet {
+	s := New()
+	for k := range c.cpus {
+		s.cpus[k] = struct{}{}
+	}
+	for k := range other.cpus {
+		delete(s.cpus, k)
+	}
+	return s
+}
+
+// Intersect returns a new set that is the intersection of this CPUSet and the
+// supplied other. Ex. [0,1,2,3].Intersect([2,3,4]) = [2,3]
+func (c CPUSet) Intersect(other CPUSet) CPUSet {
+	s := New()
+	for k := range c.cpus {
+		if _, ok := other.cpus[k]; ok {
+			s.cpus[k] = struct{}{}
+		}
+	}
+	return s
+}
+
+// IsSubsetOf returns true if all cpus of the this CPUSet are present in the
+// other CPUSet.
+func (c CPUSet) IsSubsetOf(other CPUSet) bool {
+	for cpu := range c.cpus {
+		if _, ok := other.cpus[cpu]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// Equals tests the equality of the elements in the CPUSet
+func (c CPUSet) Equals(other CPUSet) bool {
+	return c.Size() == other.Size() && c.IsSubsetOf(other)
+}
+
+// String returns the CPUSet in the list format used by the cpuset cgroup
+// subsystem, e.g. "0-3,5,7-8".
+func (c CPUSet) String() string {
+	cpus := c.ToSlice()
+	if len(cpus) == 0 {
+		return ""
+	}
+
+	var ranges []string
+	start, end := cpus[0], cpus[0]
+	flush := func() {
+		if start == end {
+			ranges = append(ranges, strconv.Itoa(int(start)))
+		} else {
+			ranges = append(ranges, fmt.Sprintf("%d-%d", start, end))
+		}
+	}
+	for _, cpu := range cpus[1:] {
+		if cpu == end+1 {
+			end = cpu
+			continue
+		}
+		flush()
+		start, end = cpu, cpu
+	}
+	flush()
+
+	return strings.Join(ranges, ",")
+}
+
+// Parse parses a string in the cpuset cgroup list format ("0-3,5,7-8") into a
+// CPUSet. An empty string results in an empty CPUSet.
+func Parse(s string) (CPUSet, error) {
+	cpuset := New()
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return cpuset, nil
+	}
+
+	for _, set := range strings.Split(s, ",") {
+		set = strings.TrimSpace(set)
+		if set == "" {
+			continue
+		}
+
+		if strings.Contains(set, "-") {
+			parts := strings.SplitN(set, "-", 2)
+			lower, err := strconv.ParseUint(parts[0], 10, 16)
+			if err != nil {
+				return New(), fmt.Errorf("invalid cpuset range %q: %v", set, err)
+			}
+			upper, err := strconv.ParseUint(parts[1], 10, 16)
+			if err != nil {
+				return New(), fmt.Errorf("invalid cpuset range %q: %v", set, err)
+			}
+			if lower > upper {
+				return New(), fmt.Errorf("invalid cpuset range %q: lower bound greater than upper bound", set)
+			}
+			for v := lower; v <= upper; v++ {
+				cpuset.cpus[uint16(v)] = struct{}{}
+			}
+			continue
+		}
+
+		v, err := strconv.ParseUint(set, 10, 16)
+		if err != nil {
+			return New(), fmt.Errorf("invalid cpu %q: %v", set, err)
+		}
+		cpuset.cpus[uint16(v)] = struct{}{}
+	}
+
+	return cpuset, nil
+}
@@ -0,0 +1,75 @@
+package cpuset
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCPUSet_Size(t *testing.T) {
+	require.Equal(t, 0, New().Size())
+	require.Equal(t, 3, New(0, 1, 2).Size())
+	require.Equal(t, 2, New(1, 1, 2).Size())
+}
+
+func TestCPUSet_ToSlice(t *testing.T) {
+	require.Equal(t, []uint16{}, New().ToSlice())
+	require.Equal(t, []uint16{0, 2, 5}, New(5, 0, 2).ToSlice())
+}
+
+func TestCPUSet_SetOperations(t *testing.T) {
+	a := New(0, 1, 2, 3)
+	b := New(2, 3, 4, 5)
+
+	require.Equal(t, []uint16{0, 1, 2, 3, 4, 5}, a.Union(b).ToSlice())
+	require.Equal(t, []uint16{0, 1}, a.Difference(b).ToSlice())
+	require.Equal(t, []uint16{2, 3}, a.Intersect(b).ToSlice())
+
+	require.True(t, New(1, 2).IsSubsetOf(a))
+	require.False(t, New(1, 4).IsSubsetOf(a))
+	require.True(t, New().IsSubsetOf(a))
+
+	require.True(t, New(3, 2, 1, 0).Equals(a))
+	require.False(t, a.Equals(b))
+}
+
+func TestCPUSet_String(t *testing.T) {
+	cases := []struct {
+		set      CPUSet
+		expected string
+	}{
+		{New(), ""},
+		{New(0), "0"},
+		{New(0, 1, 2, 3), "0-3"},
+		{New(0, 1, 2, 3, 5, 7, 8), "0-3,5,7-8"},
+		{New(9, 1, 3, 2), "1-3,9"},
+	}
+
+	for _, c := range cases {
+		require.Equal(t, c.expected, c.set.String())
+	}
+}
+
+func TestCPUSet_Parse(t *testing.T) {
+	cases := []struct {
+		input    string
+		expected []uint16
+	}{
+		{"", []uint16{}},
+		{"0", []uint16{0}},
+		{"0-3", []uint16{0, 1, 2, 3}},
+		{"0-3,5,7-8", []uint16{0, 1, 2, 3, 5, 7, 8}},
+		{" 1, 3 ,2 ", []uint16{1, 2, 3}},
+	}
+
+	for _, c := range cases {
+		set, err := Parse(c.input)
+		require.NoError(t, err, c.input)
+		require.Equal(t, c.expected, set.ToSlice(), c.input)
+	}
+
+	for _, bad := range []string{"a", "3-1", "0-a", "-1"} {
+		_, err := Parse(bad)
+		require.Error(t, err, bad)
+	}
+}
@@ -1,6 +1,9 @@
 package nomad
 
 import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
 	"fmt"
 	"io/ioutil"
 	"os"
@@ -11,10 +14,13 @@ import (
 
 	msgpackrpc "github.com/hashicorp/net-rpc-msgpackrpc"
 	"github.com/hashicorp/nomad/helper/uuid"
+	"github.com/hashicorp/nomad/lib/auth/jwt"
+	"github.com/hashicorp/nomad/lib/auth/oidc"
 	"github.com/hashicorp/nomad/nomad/mock"
 	"github.com/hashicorp/nomad/nomad/structs"
 	"github.com/hashicorp/nomad/testutil"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestACLEndpoint_GetPolicy(t *testing.T) {
@@ -230,6 +236,46 @@ func TestACLEndpoint_GetPolicies_TokenSubset(t *testing.T) {
 	}
 }
 
+func TestACLEndpoint_GetPolicies_RoleSubset(t *testing.T) {
+	t.Parallel()
+	s1, _ := TestACLServer(t, nil)
+	defer s1.Shutdown()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	// Create a token granted a policy through a role
+	policy := mock.ACLPolicy()
+	policy2 := mock.ACLPolicy()
+	s1.fsm.State().UpsertACLPolicies(1000, []*structs.ACLPolicy{policy, policy2})
+
+	role := mock.ACLRole()
+	role.Policies = []string{policy.Name}
+	s1.fsm.State().UpsertACLRoles(1000, []*structs.ACLRole{role})
+
+	token := mock.ACLToken()
+	token.Policies = nil
+	token.Roles = []string{role.Name}
+	s1.fsm.State().UpsertACLTokens(1000, []*structs.ACLToken{token})
+
+	// Lookup the policy of the role
+	get := &structs.ACLPolicySetRequest{
+		Names: []string{policy.Name},
+		QueryOptions: structs.QueryOptions{
+			Region:    "global",
+			AuthToken: token.SecretID,
+		},
+	}
+	var resp structs.ACLPolicySetResponse
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "ACL.GetPolicies", get, &resp))
+	require.Equal(t, policy, resp.Policies[policy.Name])
+
+	// Lookup non-associated policy
+	get.Names = []string{policy2.Name}
+	resp = structs.ACLPolicySetResponse{}
+	err := msgpackrpc.CallWithCodec(codec, "ACL.GetPolicies", get, &resp)
+	require.EqualError(t, err, structs.ErrPermissionDenied.Error())
+}
+
 func TestACLEndpoint_GetPolicies_Blocking(t *testing.T) {
 	t.Parallel()
 	s1, root := TestACLServer(t, nil)
@@ -519,6 +565,489 @@ func TestACLEndpoint_UpsertPolicies_Invalid(t *testing.T) {
 	}
 }
 
+func TestACLEndpoint_UpsertDeleteRoles(t *testing.T) {
+	t.Parallel()
+	s1, root := TestACLServer(t, nil)
+	defer s1.Shutdown()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	policy := mock.ACLPolicy()
+	s1.fsm.State().UpsertACLPolicies(1000, []*structs.ACLPolicy{policy})
+
+	// Roles must reference existing policies
+	role := mock.ACLRole()
+	role.Policies = []string{policy.Name, "missing"}
+	req := &structs.ACLRoleUpsertRequest{
+		Roles: []*structs.ACLRole{role},
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			AuthToken: root.SecretID,
+		},
+	}
+	var resp structs.GenericResponse
+	err := msgpackrpc.CallWithCodec(codec, "ACL.UpsertRoles", req, &resp)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "cannot find policy missing")
+
+	// Non-management tokens cannot upsert roles
+	role.Policies = []string{policy.Name}
+	req.AuthToken = mock.CreateToken(t, s1.fsm.State(), 1001, []string{policy.Name}).SecretID
+	err = msgpackrpc.CallWithCodec(codec, "ACL.UpsertRoles", req, &resp)
+	require.EqualError(t, err, structs.ErrPermissionDenied.Error())
+
+	req.AuthToken = root.SecretID
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "ACL.UpsertRoles", req, &resp))
+	require.NotZero(t, resp.Index)
+
+	out, err := s1.fsm.State().ACLRoleByName(nil, role.Name)
+	require.NoError(t, err)
+	require.Equal(t, role.Policies, out.Policies)
+	require.NotEmpty(t, out.Hash)
+
+	// Delete the role
+	del := &structs.ACLRoleDeleteRequest{
+		Names: []string{role.Name},
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			AuthToken: root.SecretID,
+		},
+	}
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "ACL.DeleteRoles", del, &resp))
+
+	out, err = s1.fsm.State().ACLRoleByName(nil, role.Name)
+	require.NoError(t, err)
+	require.Nil(t, out)
+}
+
+func TestACLEndpoint_UpsertDeleteAuthMethods(t *testing.T) {
+	t.Parallel()
+	s1, root := TestACLServer(t, nil)
+	defer s1.Shutdown()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	// Auth methods are validated
+	method := mock.ACLAuthMethod()
+	method.Config.JWTValidationPubKeys = nil
+	req := &structs.ACLAuthMethodUpsertRequest{
+		AuthMethods: []*structs.ACLAuthMethod{method},
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			AuthToken: root.SecretID,
+		},
+	}
+	var resp structs.GenericResponse
+	err := msgpackrpc.CallWithCodec(codec, "ACL.UpsertAuthMethods", req, &resp)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "requires validation public keys")
+
+	// Non-management tokens cannot upsert auth methods
+	method = mock.ACLAuthMethod()
+	method.TokenLocality = ""
+	method.MaxTokenTTL = 0
+	req.AuthMethods = []*structs.ACLAuthMethod{method}
+	req.AuthToken = mock.CreatePolicyAndToken(t, s1.fsm.State(), 1001, "read", mock.NamespacePolicy("default", "read", nil)).SecretID
+	err = msgpackrpc.CallWithCodec(codec, "ACL.UpsertAuthMethods", req, &resp)
+	require.EqualError(t, err, structs.ErrPermissionDenied.Error())
+
+	req.AuthToken = root.SecretID
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "ACL.UpsertAuthMethods", req, &resp))
+	require.NotZero(t, resp.Index)
+
+	// The defaults are set
+	out, err := s1.fsm.State().ACLAuthMethodByName(nil, method.Name)
+	require.NoError(t, err)
+	require.Equal(t, structs.ACLAuthMethodTokenLocalityLocal, out.TokenLocality)
+	require.Equal(t, structs.DefaultACLAuthMethodMaxTokenTTL, out.MaxTokenTTL)
+	require.NotEmpty(t, out.Hash)
+
+	// Binding rules must reference an existing auth method
+	rule := mock.ACLBindingRule()
+	rule.ID = ""
+	ruleReq := &structs.ACLBindingRuleUpsertRequest{
+		BindingRules: []*structs.ACLBindingRule{rule},
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			AuthToken: root.SecretID,
+		},
+	}
+	var ruleResp structs.ACLBindingRuleUpsertResponse
+	err = msgpackrpc.CallWithCodec(codec, "ACL.UpsertBindingRules", ruleReq, &ruleResp)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "cannot find auth method")
+
+	// Create a binding rule, which is assigned an ID
+	rule.AuthMethod = method.Name
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "ACL.UpsertBindingRules", ruleReq, &ruleResp))
+	require.Len(t, ruleResp.BindingRules, 1)
+	created := ruleResp.BindingRules[0]
+	require.NotEmpty(t, created.ID)
+	require.NotZero(t, created.CreateIndex)
+
+	// Updating an unknown binding rule fails
+	update := *created
+	update.ID = uuid.Generate()
+	ruleReq.BindingRules = []*structs.ACLBindingRule{&update}
+	err = msgpackrpc.CallWithCodec(codec, "ACL.UpsertBindingRules", ruleReq, &ruleResp)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "cannot find binding rule")
+
+	// Deleting the auth method deletes its binding rules
+	del := &structs.ACLAuthMethodDeleteRequest{
+		Names: []string{method.Name},
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			AuthToken: root.SecretID,
+		},
+	}
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "ACL.DeleteAuthMethods", del, &resp))
+
+	out, err = s1.fsm.State().ACLAuthMethodByName(nil, method.Name)
+	require.NoError(t, err)
+	require.Nil(t, out)
+	outRule, err := s1.fsm.State().ACLBindingRuleByID(nil, created.ID)
+	require.NoError(t, err)
+	require.Nil(t, outRule)
+}
+
+func TestACLEndpoint_GetListAuthMethods(t *testing.T) {
+	t.Parallel()
+	s1, root := TestACLServer(t, nil)
+	defer s1.Shutdown()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	m1, m2 := mock.ACLAuthMethod(), mock.ACLAuthMethod()
+	s1.fsm.State().UpsertACLAuthMethods(1000, []*structs.ACLAuthMethod{m1, m2})
+	rule := mock.ACLBindingRule()
+	rule.AuthMethod = m1.Name
+	s1.fsm.State().UpsertACLBindingRules(1001, []*structs.ACLBindingRule{rule})
+
+	// Anyone can list the auth methods
+	listReq := &structs.ACLAuthMethodListRequest{
+		QueryOptions: structs.QueryOptions{Region: "global"},
+	}
+	var listResp structs.ACLAuthMethodListResponse
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "ACL.ListAuthMethods", listReq, &listResp))
+	require.Len(t, listResp.AuthMethods, 2)
+	require.Equal(t, uint64(1000), listResp.Index)
+
+	// Reading the config of an auth method requires a management token
+	getReq := &structs.ACLAuthMethodSpecificRequest{
+		Name:         m1.Name,
+		QueryOptions: structs.QueryOptions{Region: "global"},
+	}
+	var getResp structs.SingleACLAuthMethodResponse
+	err := msgpackrpc.CallWithCodec(codec, "ACL.GetAuthMethod", getReq, &getResp)
+	require.EqualError(t, err, structs.ErrPermissionDenied.Error())
+
+	getReq.AuthToken = root.SecretID
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "ACL.GetAuthMethod", getReq, &getResp))
+	require.Equal(t, m1, getResp.AuthMethod)
+
+	setReq := &structs.ACLAuthMethodSetRequest{
+		Names:        []string{m1.Name, m2.Name, "missing"},
+		QueryOptions: structs.QueryOptions{Region: "global", AuthToken: root.SecretID},
+	}
+	var setResp structs.ACLAuthMethodSetResponse
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "ACL.GetAuthMethods", setReq, &setResp))
+	require.Len(t, setResp.AuthMethods, 2)
+
+	// Binding rules can only be read with a management token
+	ruleListReq := &structs.ACLBindingRuleListRequest{
+		QueryOptions: structs.QueryOptions{Region: "global"},
+	}
+	var ruleListResp structs.ACLBindingRuleListResponse
+	err = msgpackrpc.CallWithCodec(codec, "ACL.ListBindingRules", ruleListReq, &ruleListResp)
+	require.EqualError(t, err, structs.ErrPermissionDenied.Error())
+
+	ruleListReq.AuthToken = root.SecretID
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "ACL.ListBindingRules", ruleListReq, &ruleListResp))
+	require.Len(t, ruleListResp.BindingRules, 1)
+	require.Equal(t, rule.ID, ruleListResp.BindingRules[0].ID)
+
+	ruleGetReq := &structs.ACLBindingRuleSpecificRequest{
+		ID:           rule.ID,
+		QueryOptions: structs.QueryOptions{Region: "global", AuthToken: root.SecretID},
+	}
+	var ruleGetResp structs.SingleACLBindingRuleResponse
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "ACL.GetBindingRule", ruleGetReq, &ruleGetResp))
+	require.Equal(t, rule, ruleGetResp.BindingRule)
+
+	ruleSetReq := &structs.ACLBindingRuleSetRequest{
+		IDs:          []string{rule.ID},
+		QueryOptions: structs.QueryOptions{Region: "global", AuthToken: root.SecretID},
+	}
+	var ruleSetResp structs.ACLBindingRuleSetResponse
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "ACL.GetBindingRules", ruleSetReq, &ruleSetResp))
+	require.Equal(t, rule, ruleSetResp.BindingRules[rule.ID])
+}
+
+func TestACLEndpoint_Login_JWT(t *testing.T) {
+	t.Parallel()
+	s1, _ := TestACLServer(t, nil)
+	defer s1.Shutdown()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+	state := s1.fsm.State()
+
+	// Create an auth method validating tokens signed by a local key
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	pubKey, err := jwt.EncodePublicKeyPEM(key.Public())
+	require.NoError(t, err)
+
+	method := mock.ACLAuthMethod()
+	method.Config.JWTValidationPubKeys = []string{pubKey}
+	method.Config.ClaimMappings = map[string]string{"team": "team"}
+	method.MaxTokenTTL = 10 * time.Minute
+	require.NoError(t, state.UpsertACLAuthMethods(1000, []*structs.ACLAuthMethod{method}))
+
+	// Bind the token to a policy named after the team, and to a role for
+	// members of the ops group
+	policy := mock.ACLPolicy()
+	policy.Name = "team-infra"
+	require.NoError(t, state.UpsertACLPolicies(1001, []*structs.ACLPolicy{policy}))
+	role := mock.ACLRole()
+	require.NoError(t, state.UpsertACLRoles(1002, []*structs.ACLRole{role}))
+
+	r1 := mock.ACLBindingRule()
+	r1.AuthMethod = method.Name
+	r1.Selector = ""
+	r1.BindName = "team-${value.team}"
+	r2 := mock.ACLBindingRule()
+	r2.AuthMethod = method.Name
+	r2.Selector = `"ops" in list.groups`
+	r2.BindType = structs.ACLBindingRuleBindTypeRole
+	r2.BindName = role.Name
+	require.NoError(t, state.UpsertACLBindingRules(1003, []*structs.ACLBindingRule{r1, r2}))
+
+	sign := func(claims map[string]interface{}) string {
+		token, err := jwt.Sign(claims, "", key)
+		require.NoError(t, err)
+		return token
+	}
+	now := time.Now()
+	claims := map[string]interface{}{
+		"iss":    method.Config.BoundIssuer,
+		"aud":    "nomad",
+		"exp":    now.Add(time.Minute).Unix(),
+		"team":   "infra",
+		"groups": []string{"dev", "ops"},
+	}
+
+	req := &structs.ACLLoginRequest{
+		AuthMethodName: method.Name,
+		LoginToken:     sign(claims),
+		WriteRequest:   structs.WriteRequest{Region: "global"},
+	}
+	var resp structs.ACLLoginResponse
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "ACL.Login", req, &resp))
+	require.NotNil(t, resp.Token)
+	require.Equal(t, structs.ACLClientToken, resp.Token.Type)
+	require.Equal(t, []string{policy.Name}, resp.Token.Policies)
+	require.Equal(t, []string{role.Name}, resp.Token.Roles)
+	require.False(t, resp.Token.Global)
+	require.NotNil(t, resp.Token.ExpirationTime)
+	require.WithinDuration(t, now.Add(method.MaxTokenTTL), *resp.Token.ExpirationTime, time.Minute)
+	require.NotZero(t, resp.Token.CreateIndex)
+
+	// The token can be used
+	acl, err := s1.ResolveToken(resp.Token.SecretID)
+	require.NoError(t, err)
+	require.NotNil(t, acl)
+
+	// Without the ops group only the policy is bound
+	claims["groups"] = []string{"dev"}
+	req.LoginToken = sign(claims)
+	resp = structs.ACLLoginResponse{}
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "ACL.Login", req, &resp))
+	require.Equal(t, []string{policy.Name}, resp.Token.Policies)
+	require.Empty(t, resp.Token.Roles)
+
+	// Logins binding nothing are rejected
+	claims["team"] = "other"
+	req.LoginToken = sign(claims)
+	err = msgpackrpc.CallWithCodec(codec, "ACL.Login", req, &resp)
+	require.EqualError(t, err, structs.ErrPermissionDenied.Error())
+
+	// Invalid tokens are rejected
+	claims["team"] = "infra"
+	claims["aud"] = "other"
+	req.LoginToken = sign(claims)
+	err = msgpackrpc.CallWithCodec(codec, "ACL.Login", req, &resp)
+	require.EqualError(t, err, structs.ErrPermissionDenied.Error())
+
+	claims["aud"] = "nomad"
+	claims["exp"] = now.Add(-time.Minute).Unix()
+	req.LoginToken = sign(claims)
+	err = msgpackrpc.CallWithCodec(codec, "ACL.Login", req, &resp)
+	require.EqualError(t, err, structs.ErrPermissionDenied.Error())
+
+	other, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	claims["exp"] = now.Add(time.Minute).Unix()
+	req.LoginToken, err = jwt.Sign(claims, "", other)
+	require.NoError(t, err)
+	err = msgpackrpc.CallWithCodec(codec, "ACL.Login", req, &resp)
+	require.EqualError(t, err, structs.ErrPermissionDenied.Error())
+
+	// Unknown auth methods are rejected
+	req.AuthMethodName = "missing"
+	err = msgpackrpc.CallWithCodec(codec, "ACL.Login", req, &resp)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "not found")
+}
+
+func TestACLEndpoint_Login_OIDC(t *testing.T) {
+	t.Parallel()
+	s1, _ := TestACLServer(t, nil)
+	defer s1.Shutdown()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+	state := s1.fsm.State()
+
+	provider := oidc.NewTestProvider(t)
+	defer provider.Stop()
+
+	method := mock.ACLAuthMethod()
+	method.Type = structs.ACLAuthMethodTypeOIDC
+	method.TokenLocality = structs.ACLAuthMethodTokenLocalityGlobal
+	method.Config = &structs.ACLAuthMethodConfig{
+		OIDCDiscoveryURL:  provider.Issuer(),
+		BoundAudiences:    []string{"nomad"},
+		ListClaimMappings: map[string]string{"groups": "groups"},
+	}
+	require.NoError(t, method.Validate())
+	require.NoError(t, state.UpsertACLAuthMethods(1000, []*structs.ACLAuthMethod{method}))
+
+	policy := mock.ACLPolicy()
+	require.NoError(t, state.UpsertACLPolicies(1001, []*structs.ACLPolicy{policy}))
+	rule := mock.ACLBindingRule()
+	rule.AuthMethod = method.Name
+	rule.BindName = policy.Name
+	require.NoError(t, state.UpsertACLBindingRules(1002, []*structs.ACLBindingRule{rule}))
+
+	req := &structs.ACLLoginRequest{
+		AuthMethodName: method.Name,
+		LoginToken: provider.SignIDToken(t, map[string]interface{}{
+			"aud":    "nomad",
+			"exp":    time.Now().Add(time.Minute).Unix(),
+			"groups": []string{"engineering"},
+		}),
+		WriteRequest: structs.WriteRequest{Region: "global"},
+	}
+	var resp structs.ACLLoginResponse
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "ACL.Login", req, &resp))
+	require.Equal(t, []string{policy.Name}, resp.Token.Policies)
+	require.True(t, resp.Token.Global)
+
+	// ID tokens must be issued by the provider
+	req.LoginToken = provider.SignIDToken(t, map[string]interface{}{
+		"iss":    "https://other.example.com",
+		"aud":    "nomad",
+		"groups": []string{"engineering"},
+	})
+	err := msgpackrpc.CallWithCodec(codec, "ACL.Login", req, &resp)
+	require.EqualError(t, err, structs.ErrPermissionDenied.Error())
+
+	// ID tokens must be issued for Nomad
+	req.LoginToken = provider.SignIDToken(t, map[string]interface{}{
+		"aud":    "other",
+		"exp":    time.Now().Add(time.Minute).Unix(),
+		"groups": []string{"engineering"},
+	})
+	err = msgpackrpc.CallWithCodec(codec, "ACL.Login", req, &resp)
+	require.EqualError(t, err, structs.ErrPermissionDenied.Error())
+
+	// The provider keys were only discovered once
+	require.Equal(t, 1, provider.KeyFetches())
+
+	// Auth methods without audiences accept no tokens
+	noAudiences := *method
+	config := *method.Config
+	config.BoundAudiences = nil
+	noAudiences.Config = &config
+	require.NoError(t, state.UpsertACLAuthMethods(1003, []*structs.ACLAuthMethod{&noAudiences}))
+	req.LoginToken = provider.SignIDToken(t, map[string]interface{}{
+		"aud":    "nomad",
+		"exp":    time.Now().Add(time.Minute).Unix(),
+		"groups": []string{"engineering"},
+	})
+	err = msgpackrpc.CallWithCodec(codec, "ACL.Login", req, &resp)
+	require.EqualError(t, err, structs.ErrPermissionDenied.Error())
+}
+
+func TestACLEndpoint_GetListRoles(t *testing.T) {
+	t.Parallel()
+	s1, root := TestACLServer(t, nil)
+	defer s1.Shutdown()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	r1 := mock.ACLRole()
+	r2 := mock.ACLRole()
+	s1.fsm.State().UpsertACLRoles(1000, []*structs.ACLRole{r1, r2})
+
+	token := mock.ACLToken()
+	token.Roles = []string{r1.Name}
+	s1.fsm.State().UpsertACLTokens(1001, []*structs.ACLToken{token})
+
+	// A management token lists every role
+	list := &structs.ACLRoleListRequest{
+		QueryOptions: structs.QueryOptions{
+			Region:    "global",
+			AuthToken: root.SecretID,
+		},
+	}
+	var listResp structs.ACLRoleListResponse
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "ACL.ListRoles", list, &listResp))
+	require.Len(t, listResp.Roles, 2)
+	require.Equal(t, uint64(1000), listResp.Index)
+
+	// A client token only lists its roles
+	list.AuthToken = token.SecretID
+	listResp = structs.ACLRoleListResponse{}
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "ACL.ListRoles", list, &listResp))
+	require.Len(t, listResp.Roles, 1)
+	require.Equal(t, r1.Name, listResp.Roles[0].Name)
+
+	// A client token can get its roles only
+	get := &structs.ACLRoleSpecificRequest{
+		Name: r1.Name,
+		QueryOptions: structs.QueryOptions{
+			Region:    "global",
+			AuthToken: token.SecretID,
+		},
+	}
+	var getResp structs.SingleACLRoleResponse
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "ACL.GetRole", get, &getResp))
+	require.Equal(t, r1, getResp.Role)
+
+	get.Name = r2.Name
+	getResp = structs.SingleACLRoleResponse{}
+	err := msgpackrpc.CallWithCodec(codec, "ACL.GetRole", get, &getResp)
+	require.EqualError(t, err, structs.ErrPermissionDenied.Error())
+
+	// Same for sets of roles
+	set := &structs.ACLRoleSetRequest{
+		Names: []string{r1.Name},
+		QueryOptions: structs.QueryOptions{
+			Region:    "global",
+			AuthToken: token.SecretID,
+		},
+	}
+	var setResp structs.ACLRoleSetResponse
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "ACL.GetRoles", set, &setResp))
+	require.Equal(t, r1, setResp.Roles[r1.Name])
+
+	set.Names = []string{r1.Name, r2.Name}
+	setResp = structs.ACLRoleSetResponse{}
+	err = msgpackrpc.CallWithCodec(codec, "ACL.GetRoles", set, &setResp)
+	require.EqualError(t, err, structs.ErrPermissionDenied.Error())
+}
+
 func TestACLEndpoint_GetToken(t *testing.T) {
 	t.Parallel()
 	s1, root := TestACLServer(t, nil)
@@ -1089,6 +1618,65 @@ func TestACLEndpoint_UpsertTokens(t *testing.T) {
 	assert.Equal(t, created, out)
 }
 
+func TestACLEndpoint_UpsertTokens_Expiration(t *testing.T) {
+	t.Parallel()
+	s1, root := TestACLServer(t, nil)
+	defer s1.Shutdown()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	// Create a token expiring after an hour
+	p1 := mock.ACLToken()
+	p1.AccessorID = ""
+	p1.ExpirationTTL = time.Hour
+	req := &structs.ACLTokenUpsertRequest{
+		Tokens: []*structs.ACLToken{p1},
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			AuthToken: root.SecretID,
+		},
+	}
+	var resp structs.ACLTokenUpsertResponse
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "ACL.UpsertTokens", req, &resp))
+
+	created := resp.Tokens[0]
+	require.NotNil(t, created.ExpirationTime)
+	require.Equal(t, created.CreateTime.Add(time.Hour), *created.ExpirationTime)
+
+	// Updating the token without an expiration keeps the existing one
+	update := *created
+	update.ExpirationTime = nil
+	update.ExpirationTTL = 0
+	update.Name = "updated"
+	req.Tokens = []*structs.ACLToken{&update}
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "ACL.UpsertTokens", req, &resp))
+
+	out, err := s1.fsm.State().ACLTokenByAccessorID(nil, created.AccessorID)
+	require.NoError(t, err)
+	require.Equal(t, "updated", out.Name)
+	require.NotNil(t, out.ExpirationTime)
+	require.True(t, created.ExpirationTime.Equal(*out.ExpirationTime))
+
+	// Changing the expiration of an existing token is not allowed
+	update = *out
+	later := out.ExpirationTime.Add(time.Hour)
+	update.ExpirationTime = &later
+	req.Tokens = []*structs.ACLToken{&update}
+	err = msgpackrpc.CallWithCodec(codec, "ACL.UpsertTokens", req, &resp)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "cannot change expiration time")
+
+	// Creating an already expired token is not allowed
+	p2 := mock.ACLToken()
+	p2.AccessorID = ""
+	past := time.Now().UTC().Add(-1 * time.Hour)
+	p2.ExpirationTime = &past
+	req.Tokens = []*structs.ACLToken{p2}
+	err = msgpackrpc.CallWithCodec(codec, "ACL.UpsertTokens", req, &resp)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "expiration time is in the past")
+}
+
 func TestACLEndpoint_UpsertTokens_Invalid(t *testing.T) {
 	t.Parallel()
 	s1, root := TestACLServer(t, nil)
@@ -0,0 +1,148 @@
+package nomad
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/hashicorp/nomad/lib/auth"
+	"github.com/hashicorp/nomad/lib/auth/jwt"
+	"github.com/hashicorp/nomad/nomad/state"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+const (
+	// oidcDiscoveryTimeout is the maximum time spent discovering the signing
+	// keys of an OIDC provider during a login. Discovered keys are cached by
+	// the server.
+	oidcDiscoveryTimeout = 10 * time.Second
+)
+
+// validateLoginToken verifies the signature and registered claims of a login
+// token against the configuration of the auth method and returns its claims.
+func (s *Server) validateLoginToken(method *structs.ACLAuthMethod, loginToken string) (map[string]interface{}, error) {
+	config := method.Config
+	if config == nil {
+		return nil, fmt.Errorf("auth method %q has no config", method.Name)
+	}
+
+	expected := jwt.Expected{
+		Issuer:          config.BoundIssuer,
+		Audiences:       config.BoundAudiences,
+		ClockSkewLeeway: config.ClockSkewLeeway,
+	}
+
+	var claims map[string]interface{}
+	var err error
+	switch method.Type {
+	case structs.ACLAuthMethodTypeJWT:
+		var keys *jwt.KeySet
+		if keys, err = jwtValidationKeys(config); err != nil {
+			return nil, err
+		}
+		claims, err = keys.Verify(loginToken)
+
+	case structs.ACLAuthMethodTypeOIDC:
+		// ID tokens must be issued by the provider for Nomad. Auth methods
+		// created before audiences were required are rejected rather than
+		// accepting tokens issued for any client of the provider.
+		if len(expected.Audiences) == 0 {
+			return nil, fmt.Errorf("auth method %q has no bound audiences", method.Name)
+		}
+		if expected.Issuer == "" {
+			expected.Issuer = config.OIDCDiscoveryURL
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), oidcDiscoveryTimeout)
+		defer cancel()
+		claims, err = s.oidcKeys.Verify(ctx, config.OIDCDiscoveryURL, config.DiscoveryCaPem, loginToken)
+
+	default:
+		return nil, fmt.Errorf("unsupported auth method type %q", method.Type)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := jwt.ValidateClaims(claims, expected); err != nil {
+		return nil, err
+	}
+	return claims, nil
+}
+
+// jwtValidationKeys returns the static keys of a JWT auth method
+func jwtValidationKeys(config *structs.ACLAuthMethodConfig) (*jwt.KeySet, error) {
+	keys, err := jwt.ParsePublicKeysPEM(config.JWTValidationPubKeys)
+	if err != nil {
+		return nil, err
+	}
+
+	if config.JWKS != "" {
+		jwks, err := jwt.ParseJWKS([]byte(config.JWKS))
+		if err != nil {
+			return nil, err
+		}
+		keys.Merge(jwks)
+	}
+	return keys, nil
+}
+
+// loginBindings evaluates the binding rules of the auth method against the
+// identity of a login and returns the names of the policies and roles to grant
+// the created token. Bindings to policies or roles which do not exist are
+// skipped.
+func loginBindings(snap *state.StateSnapshot, authMethod string, identity *auth.Identity) (policies, roles []string, err error) {
+	iter, err := snap.ACLBindingRulesByAuthMethod(nil, authMethod)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	policySet := make(map[string]struct{})
+	roleSet := make(map[string]struct{})
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		rule := raw.(*structs.ACLBindingRule)
+
+		selector, err := auth.ParseSelector(rule.Selector)
+		if err != nil {
+			return nil, nil, fmt.Errorf("binding rule %s has invalid selector: %v", rule.ID, err)
+		}
+		if !selector.Match(identity) {
+			continue
+		}
+
+		// Rules referencing claims the identity does not have are skipped
+		name, err := identity.InterpolateBindName(rule.BindName)
+		if err != nil {
+			continue
+		}
+
+		switch rule.BindType {
+		case structs.ACLBindingRuleBindTypePolicy:
+			policy, err := snap.ACLPolicyByName(nil, name)
+			if err != nil {
+				return nil, nil, err
+			}
+			if policy != nil {
+				policySet[name] = struct{}{}
+			}
+		case structs.ACLBindingRuleBindTypeRole:
+			role, err := snap.ACLRoleByName(nil, name)
+			if err != nil {
+				return nil, nil, err
+			}
+			if role != nil {
+				roleSet[name] = struct{}{}
+			}
+		}
+	}
+
+	for name := range policySet {
+		policies = append(policies, name)
+	}
+	for name := range roleSet {
+		roles = append(roles, name)
+	}
+	sort.Strings(policies)
+	sort.Strings(roles)
+	return policies, roles, nil
+}
@@ -2,6 +2,7 @@ package nomad
 
 import (
 	"testing"
+	"time"
 
 	lru "github.com/hashicorp/golang-lru"
 	"github.com/hashicorp/nomad/acl"
@@ -11,6 +12,7 @@ import (
 	"github.com/hashicorp/nomad/nomad/structs"
 	"github.com/hashicorp/nomad/testutil"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestResolveACLToken(t *testing.T) {
@@ -92,6 +94,80 @@ func TestResolveACLToken(t *testing.T) {
 	}
 }
 
+func TestResolveACLToken_Expired(t *testing.T) {
+	t.Parallel()
+
+	state := state.TestStateStore(t)
+	cache, err := lru.New2Q(16)
+	require.NoError(t, err)
+
+	// Create an expired and a not yet expired token
+	past := time.Now().UTC().Add(-1 * time.Minute)
+	future := time.Now().UTC().Add(time.Hour)
+	expired := mock.ACLManagementToken()
+	expired.ExpirationTime = &past
+	valid := mock.ACLManagementToken()
+	valid.ExpirationTime = &future
+	require.NoError(t, state.UpsertACLTokens(100, []*structs.ACLToken{expired, valid}))
+
+	snap, err := state.Snapshot()
+	require.NoError(t, err)
+
+	aclObj, err := resolveTokenFromSnapshotCache(snap, cache, expired.SecretID)
+	require.Equal(t, structs.ErrTokenExpired, err)
+	require.Nil(t, aclObj)
+
+	aclObj, err = resolveTokenFromSnapshotCache(snap, cache, valid.SecretID)
+	require.NoError(t, err)
+	require.True(t, aclObj.IsManagement())
+}
+
+func TestResolveACLToken_Roles(t *testing.T) {
+	t.Parallel()
+
+	state := state.TestStateStore(t)
+	cache, err := lru.New2Q(16)
+	require.NoError(t, err)
+
+	// Create a token granted a policy directly and another through a role
+	readPolicy := mock.ACLPolicy()
+	readPolicy.Rules = mock.NamespacePolicy("default", acl.PolicyRead, nil)
+	nodePolicy := mock.ACLPolicy()
+	nodePolicy.Rules = mock.NodePolicy(acl.PolicyWrite)
+	require.NoError(t, state.UpsertACLPolicies(100, []*structs.ACLPolicy{readPolicy, nodePolicy}))
+
+	role := mock.ACLRole()
+	role.Policies = []string{nodePolicy.Name, readPolicy.Name}
+	require.NoError(t, state.UpsertACLRoles(110, []*structs.ACLRole{role}))
+
+	token := mock.ACLToken()
+	token.Policies = []string{readPolicy.Name}
+	token.Roles = []string{role.Name, "missing"}
+	require.NoError(t, state.UpsertACLTokens(120, []*structs.ACLToken{token}))
+
+	snap, err := state.Snapshot()
+	require.NoError(t, err)
+
+	aclObj, err := resolveTokenFromSnapshotCache(snap, cache, token.SecretID)
+	require.NoError(t, err)
+	require.True(t, aclObj.AllowNamespaceOperation("default", acl.NamespaceCapabilityListJobs))
+	require.True(t, aclObj.AllowNodeWrite())
+
+	// Removing the policy from the role revokes it from the token
+	role2 := &structs.ACLRole{
+		Name:     role.Name,
+		Policies: []string{readPolicy.Name},
+	}
+	require.NoError(t, state.UpsertACLRoles(130, []*structs.ACLRole{role2}))
+	snap, err = state.Snapshot()
+	require.NoError(t, err)
+
+	aclObj, err = resolveTokenFromSnapshotCache(snap, cache, token.SecretID)
+	require.NoError(t, err)
+	require.True(t, aclObj.AllowNamespaceOperation("default", acl.NamespaceCapabilityListJobs))
+	require.False(t, aclObj.AllowNodeWrite())
+}
+
 func TestResolveACLToken_LeaderToken(t *testing.T) {
 	t.Parallel()
 	assert := assert.New(t)
@@ -107,3 +183,37 @@ func TestResolveACLToken_LeaderToken(t *testing.T) {
 		assert.True(token.IsManagement())
 	}
 }
+
+func TestResolveSecretToken(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	s1, _ := TestACLServer(t, nil)
+	defer s1.Shutdown()
+	testutil.WaitForLeader(t, s1.RPC)
+
+	token := mock.ACLToken()
+	require.NoError(s1.State().UpsertACLTokens(100, []*structs.ACLToken{token}))
+
+	// Blank secrets resolve to the anonymous token
+	out, err := s1.ResolveSecretToken("")
+	require.NoError(err)
+	require.Equal(structs.AnonymousACLToken, out)
+
+	out, err = s1.ResolveSecretToken(token.SecretID)
+	require.NoError(err)
+	require.Equal(token.AccessorID, out.AccessorID)
+
+	out, err = s1.ResolveSecretToken(uuid.Generate())
+	require.Equal(structs.ErrTokenNotFound, err)
+	require.Nil(out)
+
+	// Expired tokens are not resolved
+	past := time.Now().UTC().Add(-1 * time.Minute)
+	expired := mock.ACLToken()
+	expired.ExpirationTime = &past
+	require.NoError(s1.State().UpsertACLTokens(101, []*structs.ACLToken{expired}))
+
+	out, err = s1.ResolveSecretToken(expired.SecretID)
+	require.Equal(structs.ErrTokenExpired, err)
+	require.Nil(out)
+}
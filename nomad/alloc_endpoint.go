@@ -86,6 +86,9 @@ func (a *Alloc) GetAlloc(args *structs.AllocSpecificRequest,
 	}
 	defer metrics.MeasureSince([]string{"nomad", "alloc", "get_alloc"}, time.Now())
 
+	// Only the node running the allocation is handed its workload identities
+	var nodeID string
+
 	// Check namespace read-job permissions
 	if aclObj, err := a.srv.ResolveToken(args.AuthToken); err != nil {
 		// If ResolveToken had an unexpected error return that
@@ -107,8 +110,12 @@ func (a *Alloc) GetAlloc(args *structs.AllocSpecificRequest,
 		if node == nil {
 			return structs.ErrTokenNotFound
 		}
+		nodeID = node.ID
 	} else if aclObj != nil && !aclObj.AllowNsOp(args.RequestNamespace(), acl.NamespaceCapabilityReadJob) {
 		return structs.ErrPermissionDenied
+	} else if aclObj == nil {
+		// ACLs are disabled, but the request may come from a node
+		nodeID = a.nodeIDBySecret(args.AuthToken)
 	}
 
 	// Setup the blocking query
@@ -123,6 +130,9 @@ func (a *Alloc) GetAlloc(args *structs.AllocSpecificRequest,
 			}
 
 			// Setup the output
+			if out != nil && (nodeID == "" || out.NodeID != nodeID) {
+				out = out.Sanitize()
+			}
 			reply.Alloc = out
 			if out != nil {
 				reply.Index = out.ModifyIndex
@@ -142,6 +152,19 @@ func (a *Alloc) GetAlloc(args *structs.AllocSpecificRequest,
 	return a.srv.blockingRPC(&opts)
 }
 
+// nodeIDBySecret returns the ID of the node with the given secret ID, or an
+// empty string if there is none.
+func (a *Alloc) nodeIDBySecret(secretID string) string {
+	if secretID == "" {
+		return ""
+	}
+	node, err := a.srv.fsm.State().NodeBySecretID(nil, secretID)
+	if err != nil || node == nil {
+		return ""
+	}
+	return node.ID
+}
+
 // GetAllocs is used to lookup a set of allocations
 func (a *Alloc) GetAllocs(args *structs.AllocsGetRequest,
 	reply *structs.AllocsGetResponse) error {
@@ -150,6 +173,9 @@ func (a *Alloc) GetAllocs(args *structs.AllocsGetRequest,
 	}
 	defer metrics.MeasureSince([]string{"nomad", "alloc", "get_allocs"}, time.Now())
 
+	// Only the node running an allocation is handed its workload identities
+	nodeID := a.nodeIDBySecret(args.AuthToken)
+
 	allocs := make([]*structs.Allocation, len(args.AllocIDs))
 
 	// Setup the blocking query. We wait for at least one of the requested
@@ -174,6 +200,9 @@ func (a *Alloc) GetAllocs(args *structs.AllocsGetRequest,
 				}
 
 				// Store the pointer
+				if nodeID == "" || out.NodeID != nodeID {
+					out = out.Sanitize()
+				}
 				allocs[i] = out
 
 				// Check if we have passed the minimum index
@@ -207,6 +236,61 @@ func (a *Alloc) GetAllocs(args *structs.AllocsGetRequest,
 	return a.srv.blockingRPC(&opts)
 }
 
+// SignIdentities is used by clients to renew the workload identities of the
+// tasks of a running allocation before they expire. Only the node running the
+// allocation may renew its identities.
+func (a *Alloc) SignIdentities(args *structs.AllocSpecificRequest, reply *structs.AllocSignIdentitiesResponse) error {
+	if done, err := a.srv.forward("Alloc.SignIdentities", args, args, reply); done {
+		return err
+	}
+	defer metrics.MeasureSince([]string{"nomad", "alloc", "sign_identities"}, time.Now())
+
+	nodeID := a.nodeIDBySecret(args.AuthToken)
+	if nodeID == "" {
+		return structs.ErrPermissionDenied
+	}
+
+	snap, err := a.srv.State().Snapshot()
+	if err != nil {
+		return err
+	}
+	alloc, err := snap.AllocByID(nil, args.AllocID)
+	if err != nil {
+		return err
+	}
+	if alloc == nil || alloc.NodeID != nodeID {
+		return structs.ErrPermissionDenied
+	}
+	if alloc.TerminalStatus() {
+		return fmt.Errorf("allocation %q is terminal", args.AllocID)
+	}
+	tg := alloc.Job.LookupTaskGroup(alloc.TaskGroup)
+	if tg == nil {
+		return fmt.Errorf("task group %q of allocation %q not found", alloc.TaskGroup, args.AllocID)
+	}
+
+	key, signer, err := a.srv.activeSigner()
+	if err != nil {
+		return err
+	}
+	if key == nil {
+		return fmt.Errorf("keyring has not been initialized yet")
+	}
+	identities, err := signTaskIdentities(key, signer, alloc, tg, time.Now().UTC())
+	if err != nil {
+		return err
+	}
+
+	reply.SignedIdentities = identities
+	reply.SigningKeyID = key.KeyID
+	reply.Index, err = snap.Index("allocs")
+	if err != nil {
+		return err
+	}
+	a.srv.setQueryMeta(&reply.QueryMeta)
+	return nil
+}
+
 // Stop is used to stop an allocation and migrate it to another node.
 func (a *Alloc) Stop(args *structs.AllocStopRequest, reply *structs.AllocStopResponse) error {
 	if done, err := a.srv.forward("Alloc.Stop", args, args, reply); done {
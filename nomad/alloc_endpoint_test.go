@@ -429,6 +429,121 @@ func TestAllocEndpoint_GetAllocs(t *testing.T) {
 	}
 }
 
+func TestAllocEndpoint_GetAllocs_SignedIdentities(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	s1 := TestServer(t, nil)
+	defer s1.Shutdown()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	// Create an alloc with a workload identity on a node
+	node := mock.Node()
+	alloc := mock.Alloc()
+	alloc.NodeID = node.ID
+	alloc.SignedIdentities = map[string]string{"web": "a.b.c"}
+	state := s1.fsm.State()
+	require.NoError(state.UpsertNode(998, node))
+	state.UpsertJobSummary(999, mock.JobSummary(alloc.JobID))
+	require.NoError(state.UpsertAllocs(1000, []*structs.Allocation{alloc}))
+
+	// Other callers don't see the identities
+	get := &structs.AllocSpecificRequest{
+		AllocID:      alloc.ID,
+		QueryOptions: structs.QueryOptions{Region: "global"},
+	}
+	var resp structs.SingleAllocResponse
+	require.NoError(msgpackrpc.CallWithCodec(codec, "Alloc.GetAlloc", get, &resp))
+	require.Nil(resp.Alloc.SignedIdentities)
+
+	getAllocs := &structs.AllocsGetRequest{
+		AllocIDs:     []string{alloc.ID},
+		QueryOptions: structs.QueryOptions{Region: "global"},
+	}
+	var allocsResp structs.AllocsGetResponse
+	require.NoError(msgpackrpc.CallWithCodec(codec, "Alloc.GetAllocs", getAllocs, &allocsResp))
+	require.Len(allocsResp.Allocs, 1)
+	require.Nil(allocsResp.Allocs[0].SignedIdentities)
+
+	// The node running the alloc does
+	get.AuthToken = node.SecretID
+	resp = structs.SingleAllocResponse{}
+	require.NoError(msgpackrpc.CallWithCodec(codec, "Alloc.GetAlloc", get, &resp))
+	require.Equal(alloc.SignedIdentities, resp.Alloc.SignedIdentities)
+
+	getAllocs.AuthToken = node.SecretID
+	allocsResp = structs.AllocsGetResponse{}
+	require.NoError(msgpackrpc.CallWithCodec(codec, "Alloc.GetAllocs", getAllocs, &allocsResp))
+	require.Len(allocsResp.Allocs, 1)
+	require.Equal(alloc.SignedIdentities, allocsResp.Allocs[0].SignedIdentities)
+
+	// The state store is left untouched
+	out, err := state.AllocByID(nil, alloc.ID)
+	require.NoError(err)
+	require.Equal(alloc.SignedIdentities, out.SignedIdentities)
+}
+
+func TestAllocEndpoint_SignIdentities(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	s1 := TestServer(t, func(c *Config) {
+		c.Build = "0.9.2+unittest"
+	})
+	defer s1.Shutdown()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+	key := waitForActiveRootKey(t, s1)
+
+	// Create an alloc on a node
+	node := mock.Node()
+	other := mock.Node()
+	alloc := mock.Alloc()
+	alloc.NodeID = node.ID
+	state := s1.fsm.State()
+	require.NoError(state.UpsertNode(997, node))
+	require.NoError(state.UpsertNode(998, other))
+	state.UpsertJobSummary(999, mock.JobSummary(alloc.JobID))
+	require.NoError(state.UpsertAllocs(1000, []*structs.Allocation{alloc}))
+
+	// The node running the alloc gets identities that verify
+	req := &structs.AllocSpecificRequest{
+		AllocID: alloc.ID,
+		QueryOptions: structs.QueryOptions{
+			Region:    "global",
+			AuthToken: node.SecretID,
+		},
+	}
+	var resp structs.AllocSignIdentitiesResponse
+	require.NoError(msgpackrpc.CallWithCodec(codec, "Alloc.SignIdentities", req, &resp))
+	require.Equal(key.KeyID, resp.SigningKeyID)
+	token := resp.SignedIdentities["web"]
+	require.NotEmpty(token)
+
+	claims, err := s1.resolveIdentityClaims(token)
+	require.NoError(err)
+	require.Equal(alloc.ID, claims.AllocationID)
+	require.Equal("web", claims.Task)
+	require.NotZero(claims.Expiry)
+
+	// Other nodes and callers without a node secret are rejected
+	req.AuthToken = other.SecretID
+	err = msgpackrpc.CallWithCodec(codec, "Alloc.SignIdentities", req, &resp)
+	require.EqualError(err, structs.ErrPermissionDenied.Error())
+
+	req.AuthToken = ""
+	err = msgpackrpc.CallWithCodec(codec, "Alloc.SignIdentities", req, &resp)
+	require.EqualError(err, structs.ErrPermissionDenied.Error())
+
+	// Terminal allocs are rejected
+	stopped := alloc.Copy()
+	stopped.DesiredStatus = structs.AllocDesiredStatusStop
+	require.NoError(state.UpsertAllocs(1001, []*structs.Allocation{stopped}))
+	req.AuthToken = node.SecretID
+	err = msgpackrpc.CallWithCodec(codec, "Alloc.SignIdentities", req, &resp)
+	require.Error(err)
+	require.Contains(err.Error(), "terminal")
+}
+
 func TestAllocEndpoint_GetAllocs_Blocking(t *testing.T) {
 	t.Parallel()
 	s1 := TestServer(t, nil)
@@ -184,6 +184,26 @@ type Config struct {
 	// for GC. This gives users some time to view terminal deployments.
 	DeploymentGCThreshold time.Duration
 
+	// ACLTokenExpirationGCInterval is how often we dispatch a job to GC
+	// expired ACL tokens.
+	ACLTokenExpirationGCInterval time.Duration
+
+	// ACLTokenExpirationGCThreshold is how long after its expiration an ACL
+	// token must be before it is eligible for GC.
+	ACLTokenExpirationGCThreshold time.Duration
+
+	// RootKeyGCInterval is how often we dispatch a job to rotate the active
+	// root key of the keyring and GC inactive root keys
+	RootKeyGCInterval time.Duration
+
+	// RootKeyGCThreshold is how long after its deactivation an unused root
+	// key is eligible for GC
+	RootKeyGCThreshold time.Duration
+
+	// RootKeyRotationThreshold is how old the active root key must be before
+	// it is rotated
+	RootKeyRotationThreshold time.Duration
+
 	// EvalNackTimeout controls how long we allow a sub-scheduler to
 	// work on an evaluation before we consider it failed and Nack it.
 	// This allows that evaluation to be handed to another sub-scheduler
@@ -242,8 +262,9 @@ type Config struct {
 	// ConsulConfig is this Agent's Consul configuration
 	ConsulConfig *config.ConsulConfig
 
-	// VaultConfig is this Agent's Vault configuration
-	VaultConfig *config.VaultConfig
+	// VaultConfigs are this Agent's Vault configurations, indexed by the name
+	// of the Vault cluster
+	VaultConfigs map[string]*config.VaultConfig
 
 	// RPCHoldTimeout is how long an RPC can be "held" before it is errored.
 	// This is used to paper over a loss of leadership by instead holding RPCs,
@@ -272,6 +293,16 @@ type Config struct {
 	// SentinelConfig is this Agent's Sentinel configuration
 	SentinelConfig *config.SentinelConfig
 
+	// JobValidationRules are the glob patterns of the files declaring the
+	// rules jobs are validated against when they are registered, planned or
+	// validated.
+	JobValidationRules []string
+
+	// DeploymentAnalysisAddresses are the addresses the metric analyses of
+	// deployments are allowed to query. Jobs with analyses of other
+	// addresses are rejected.
+	DeploymentAnalysisAddresses []string
+
 	// StatsCollectionInterval is the interval at which the Nomad server
 	// publishes metrics which are periodic in nature like updating gauges
 	StatsCollectionInterval time.Duration
@@ -321,6 +352,11 @@ func (c *Config) CheckVersion() error {
 	return nil
 }
 
+// GetDefaultVault returns the configuration of the default Vault cluster
+func (c *Config) GetDefaultVault() *config.VaultConfig {
+	return c.VaultConfigs[config.VaultDefaultCluster]
+}
+
 // DefaultConfig returns the default configuration
 func DefaultConfig() *Config {
 	hostname, err := os.Hostname()
@@ -350,6 +386,11 @@ func DefaultConfig() *Config {
 		NodeGCThreshold:                  24 * time.Hour,
 		DeploymentGCInterval:             5 * time.Minute,
 		DeploymentGCThreshold:            1 * time.Hour,
+		ACLTokenExpirationGCInterval:     5 * time.Minute,
+		ACLTokenExpirationGCThreshold:    1 * time.Hour,
+		RootKeyGCInterval:                10 * time.Minute,
+		RootKeyGCThreshold:               1 * time.Hour,
+		RootKeyRotationThreshold:         30 * 24 * time.Hour,
 		EvalNackTimeout:                  60 * time.Second,
 		EvalDeliveryLimit:                3,
 		EvalNackInitialReenqueueDelay:    1 * time.Second,
@@ -361,7 +402,7 @@ func DefaultConfig() *Config {
 		HeartbeatGrace:                   10 * time.Second,
 		FailoverHeartbeatTTL:             300 * time.Second,
 		ConsulConfig:                     config.DefaultConsulConfig(),
-		VaultConfig:                      config.DefaultVaultConfig(),
+		VaultConfigs:                     map[string]*config.VaultConfig{config.VaultDefaultCluster: config.DefaultVaultConfig()},
 		RPCHoldTimeout:                   5 * time.Second,
 		StatsCollectionInterval:          1 * time.Minute,
 		TLSConfig:                        &config.TLSConfig{},
@@ -49,6 +49,12 @@ func (c *CoreScheduler) Process(eval *structs.Evaluation) error {
 		return c.jobGC(eval)
 	case structs.CoreJobDeploymentGC:
 		return c.deploymentGC(eval)
+	case structs.CoreJobLocalTokenExpiredGC:
+		return c.expiredACLTokenGC(eval, false)
+	case structs.CoreJobGlobalTokenExpiredGC:
+		return c.expiredACLTokenGC(eval, true)
+	case structs.CoreJobRootKeyRotateOrGC:
+		return c.rootKeyRotateOrGC(eval)
 	case structs.CoreJobForceGC:
 		return c.forceGC(eval)
 	default:
@@ -67,6 +73,15 @@ func (c *CoreScheduler) forceGC(eval *structs.Evaluation) error {
 	if err := c.deploymentGC(eval); err != nil {
 		return err
 	}
+	if err := c.expiredACLTokenGC(eval, false); err != nil {
+		return err
+	}
+	if err := c.expiredACLTokenGC(eval, true); err != nil {
+		return err
+	}
+	if err := c.rootKeyGC(eval); err != nil {
+		return err
+	}
 
 	// Node GC must occur after the others to ensure the allocations are
 	// cleared.
@@ -144,7 +159,7 @@ OUTER:
 
 	// Fast-path the nothing case
 	if len(gcEval) == 0 && len(gcAlloc) == 0 && len(gcJob) == 0 {
-		return nil
+		return c.dispatchBlobGC(nil, oldThreshold, eval.LeaderACL)
 	}
 	c.logger.Debug("job GC found eligible objects",
 		"jobs", len(gcJob), "evals", len(gcEval), "allocs", len(gcAlloc))
@@ -155,7 +170,100 @@ OUTER:
 	}
 
 	// Reap the jobs
-	return c.jobReap(gcJob, eval.LeaderACL)
+	if err := c.jobReap(gcJob, eval.LeaderACL); err != nil {
+		return err
+	}
+
+	// Reap the dispatch payloads of the reaped jobs
+	return c.dispatchBlobGC(gcJob, oldThreshold, eval.LeaderACL)
+}
+
+// dispatchBlobGC is used to garbage collect the dispatch blobs no longer
+// referenced by any job once the jobs being reaped are gone, along with the
+// chunks of blobs whose upload never completed. Blobs are only collected once
+// they haven't been used by a dispatch since the threshold index.
+func (c *CoreScheduler) dispatchBlobGC(reaped []*structs.Job, thresholdIndex uint64, leaderACL string) error {
+	reapedJobs := make(map[structs.NamespacedID]struct{}, len(reaped))
+	for _, job := range reaped {
+		reapedJobs[structs.NamespacedID{ID: job.ID, Namespace: job.Namespace}] = struct{}{}
+	}
+
+	ws := memdb.NewWatchSet()
+	iter, err := c.snap.DispatchBlobs(ws)
+	if err != nil {
+		return err
+	}
+
+	var gcBlobs []string
+OUTER:
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		blob := raw.(*structs.DispatchBlob)
+		if blob.ModifyIndex > thresholdIndex {
+			continue
+		}
+
+		jobs, err := c.snap.JobsByPayloadBlob(ws, blob.ID)
+		if err != nil {
+			return err
+		}
+		for raw := jobs.Next(); raw != nil; raw = jobs.Next() {
+			job := raw.(*structs.Job)
+			if _, ok := reapedJobs[structs.NamespacedID{ID: job.ID, Namespace: job.Namespace}]; !ok {
+				continue OUTER
+			}
+		}
+		gcBlobs = append(gcBlobs, blob.ID)
+	}
+
+	// Collect the chunks of incomplete blobs
+	chunks, err := c.snap.DispatchBlobChunks(ws)
+	if err != nil {
+		return err
+	}
+	incomplete := make(map[string]struct{})
+	for raw := chunks.Next(); raw != nil; raw = chunks.Next() {
+		chunk := raw.(*structs.DispatchBlobChunk)
+		if _, ok := incomplete[chunk.BlobID]; ok || chunk.CreateIndex > thresholdIndex {
+			continue
+		}
+
+		blob, err := c.snap.DispatchBlobByID(ws, chunk.BlobID)
+		if err != nil {
+			return err
+		}
+		if blob == nil {
+			incomplete[chunk.BlobID] = struct{}{}
+			gcBlobs = append(gcBlobs, chunk.BlobID)
+		}
+	}
+
+	// Fast-path the nothing case
+	if len(gcBlobs) == 0 {
+		return nil
+	}
+	c.logger.Debug("job GC found eligible dispatch blobs", "blobs", len(gcBlobs))
+
+	for len(gcBlobs) != 0 {
+		n := len(gcBlobs)
+		if n > maxIdsPerReap {
+			n = maxIdsPerReap
+		}
+
+		req := &structs.DispatchBlobDeleteRequest{
+			BlobIDs: gcBlobs[:n],
+			WriteRequest: structs.WriteRequest{
+				Region:    c.srv.config.Region,
+				AuthToken: leaderACL,
+			},
+		}
+		var resp structs.GenericResponse
+		if err := c.srv.RPC("DispatchPayload.Delete", req, &resp); err != nil {
+			c.logger.Error("dispatch blob reap failed", "error", err)
+			return err
+		}
+		gcBlobs = gcBlobs[n:]
+	}
+	return nil
 }
 
 // jobReap contacts the leader and issues a reap on the passed jobs
@@ -293,11 +401,11 @@ func (c *CoreScheduler) gcEval(eval *structs.Evaluation, thresholdIndex uint64,
 		return false, nil, err
 	}
 
-	// If the eval is from a running "batch" job we don't want to garbage
-	// collect its allocations. If there is a long running batch job and its
-	// terminal allocations get GC'd the scheduler would re-run the
-	// allocations.
-	if eval.Type == structs.JobTypeBatch {
+	// If the eval is from a running "batch" or "sysbatch" job we don't want
+	// to garbage collect its allocations. If there is a long running batch
+	// job and its terminal allocations get GC'd the scheduler would re-run
+	// the allocations.
+	if eval.Type == structs.JobTypeBatch || eval.Type == structs.JobTypeSysBatch {
 		// Check if the job is running
 
 		// Can collect if:
@@ -614,6 +722,217 @@ func (c *CoreScheduler) partitionDeploymentReap(deployments []string) []*structs
 	return requests
 }
 
+// expiredACLTokenGC is used to garbage collect expired local or global ACL
+// tokens.
+func (c *CoreScheduler) expiredACLTokenGC(eval *structs.Evaluation, global bool) error {
+	// Expired tokens only exist if ACLs are enabled
+	if !c.srv.config.ACLEnabled {
+		return nil
+	}
+
+	// Global tokens are only deleted by the authoritative region
+	if global && c.srv.config.Region != c.srv.config.AuthoritativeRegion {
+		return nil
+	}
+
+	ws := memdb.NewWatchSet()
+	iter, err := c.snap.ACLTokensByGlobal(ws, global)
+	if err != nil {
+		return err
+	}
+
+	// Tokens are kept for the threshold after their expiration unless the GC
+	// is forced
+	now := time.Now().UTC()
+	if eval.JobID != structs.CoreJobForceGC {
+		now = now.Add(-1 * c.srv.config.ACLTokenExpirationGCThreshold)
+	}
+
+	var gcTokens []string
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		token := raw.(*structs.ACLToken)
+		if token.IsExpired(now) {
+			gcTokens = append(gcTokens, token.AccessorID)
+		}
+	}
+
+	// Fast-path the nothing case
+	if len(gcTokens) == 0 {
+		return nil
+	}
+	c.logger.Debug("expired ACL token GC found eligible tokens", "tokens", len(gcTokens), "global", global)
+	return c.expiredACLTokenReap(gcTokens, eval.LeaderACL)
+}
+
+// expiredACLTokenReap contacts the leader and issues a reap on the passed
+// ACL tokens.
+func (c *CoreScheduler) expiredACLTokenReap(tokens []string, leaderACL string) error {
+	for _, req := range c.partitionExpiredACLTokenReap(tokens, leaderACL) {
+		var resp structs.GenericResponse
+		if err := c.srv.RPC("ACL.DeleteTokens", req, &resp); err != nil {
+			c.logger.Error("expired ACL token reap failed", "error", err)
+			return err
+		}
+	}
+
+	return nil
+}
+
+// partitionExpiredACLTokenReap returns a list of ACLTokenDeleteRequest to
+// make, ensuring a single request does not contain too many tokens. This is
+// necessary to ensure that the Raft transaction does not become too large.
+func (c *CoreScheduler) partitionExpiredACLTokenReap(tokens []string, leaderACL string) []*structs.ACLTokenDeleteRequest {
+	var requests []*structs.ACLTokenDeleteRequest
+	for submitted := 0; submitted < len(tokens); submitted += maxIdsPerReap {
+		end := submitted + maxIdsPerReap
+		if end > len(tokens) {
+			end = len(tokens)
+		}
+		requests = append(requests, &structs.ACLTokenDeleteRequest{
+			AccessorIDs: tokens[submitted:end],
+			WriteRequest: structs.WriteRequest{
+				Region:    c.srv.config.Region,
+				AuthToken: leaderACL,
+			},
+		})
+	}
+
+	return requests
+}
+
+// rootKeyRotateOrGC is used to rotate the active root key once it reaches the
+// rotation threshold and to garbage collect inactive root keys.
+func (c *CoreScheduler) rootKeyRotateOrGC(eval *structs.Evaluation) error {
+	if err := c.rootKeyRotate(eval); err != nil {
+		return err
+	}
+	return c.rootKeyGC(eval)
+}
+
+// rootKeyRotate rotates the active root key if it is older than the rotation
+// threshold.
+func (c *CoreScheduler) rootKeyRotate(eval *structs.Evaluation) error {
+	ws := memdb.NewWatchSet()
+	key, err := c.snap.ActiveRootKey(ws)
+	if err != nil {
+		return err
+	}
+
+	// The leader initializes the keyring, there is nothing to rotate yet
+	if key == nil {
+		return nil
+	}
+
+	cutoff := time.Now().UTC().Add(-1 * c.srv.config.RootKeyRotationThreshold)
+	if time.Unix(0, key.CreateTime).After(cutoff) {
+		return nil
+	}
+
+	c.logger.Debug("rotating root key", "key_id", key.KeyID)
+	req := &structs.KeyringRotateRootKeyRequest{
+		WriteRequest: structs.WriteRequest{
+			Region:    c.srv.config.Region,
+			AuthToken: eval.LeaderACL,
+		},
+	}
+	var resp structs.KeyringRotateRootKeyResponse
+	if err := c.srv.RPC("Keyring.Rotate", req, &resp); err != nil {
+		c.logger.Error("root key rotation failed", "error", err)
+		return err
+	}
+	return nil
+}
+
+// rootKeyGC is used to garbage collect inactive root keys which are no longer
+// used by any non-terminal allocation or variable.
+func (c *CoreScheduler) rootKeyGC(eval *structs.Evaluation) error {
+	ws := memdb.NewWatchSet()
+	iter, err := c.snap.RootKeys(ws)
+	if err != nil {
+		return err
+	}
+
+	var oldThreshold uint64
+	if eval.JobID == structs.CoreJobForceGC {
+		// The GC was forced, so set the threshold to its maximum so everything
+		// will GC.
+		oldThreshold = math.MaxUint64
+		c.logger.Debug("forced root key GC")
+	} else {
+		// Compute the old threshold limit for GC using the FSM
+		// time table.  This is a rough mapping of a time to the
+		// Raft index it belongs to.
+		tt := c.srv.fsm.TimeTable()
+		cutoff := time.Now().UTC().Add(-1 * c.srv.config.RootKeyGCThreshold)
+		oldThreshold = tt.NearestIndex(cutoff)
+		c.logger.Debug("root key GC scanning before cutoff index",
+			"index", oldThreshold, "root_key_gc_threshold", c.srv.config.RootKeyGCThreshold)
+	}
+
+	// Collect the inactive keys that were deactivated long enough ago
+	var candidates []string
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		key := raw.(*structs.RootKey)
+		if key.IsActive() || key.ModifyIndex > oldThreshold {
+			continue
+		}
+		candidates = append(candidates, key.KeyID)
+	}
+
+	// Fast-path the nothing case
+	if len(candidates) == 0 {
+		return nil
+	}
+
+	// Keep the keys that signed the identities of live allocations
+	inUse := make(map[string]struct{})
+	allocs, err := c.snap.Allocs(ws)
+	if err != nil {
+		return err
+	}
+	for raw := allocs.Next(); raw != nil; raw = allocs.Next() {
+		alloc := raw.(*structs.Allocation)
+		if alloc.SigningKeyID != "" && !alloc.TerminalStatus() {
+			inUse[alloc.SigningKeyID] = struct{}{}
+		}
+	}
+
+	var gcKeys []string
+	for _, id := range candidates {
+		if _, ok := inUse[id]; ok {
+			continue
+		}
+
+		// Keep the keys that encrypted variables
+		vars, err := c.snap.VariablesByKeyID(ws, id)
+		if err != nil {
+			return err
+		}
+		if vars.Next() != nil {
+			continue
+		}
+		gcKeys = append(gcKeys, id)
+	}
+	if len(gcKeys) == 0 {
+		return nil
+	}
+
+	c.logger.Debug("root key GC found eligible keys", "keys", len(gcKeys))
+	req := &structs.KeyringDeleteRootKeyRequest{
+		KeyIDs: gcKeys,
+		WriteRequest: structs.WriteRequest{
+			Region:    c.srv.config.Region,
+			AuthToken: eval.LeaderACL,
+		},
+	}
+	var resp structs.GenericResponse
+	if err := c.srv.RPC("Keyring.Delete", req, &resp); err != nil {
+		c.logger.Error("root key reap failed", "error", err)
+		return err
+	}
+	return nil
+}
+
 // allocGCEligible returns if the allocation is eligible to be garbage collected
 // according to its terminal status and its reschedule trackers
 func allocGCEligible(a *structs.Allocation, job *structs.Job, gcTime time.Time, thresholdIndex uint64) bool {
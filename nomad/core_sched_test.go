@@ -1397,6 +1397,70 @@ func TestCoreScheduler_JobGC_OneShot(t *testing.T) {
 }
 
 // This test ensures that stopped jobs are GCd
+func TestCoreScheduler_JobGC_SysBatch(t *testing.T) {
+	t.Parallel()
+	s1 := TestServer(t, nil)
+	defer s1.Shutdown()
+	testutil.WaitForLeader(t, s1.RPC)
+	require := require.New(t)
+
+	// COMPAT Remove in 0.6: Reset the FSM time table since we reconcile which sets index 0
+	s1.fsm.timetable.table = make([]TimeTableEntry, 1, 10)
+
+	// Insert job.
+	state := s1.fsm.State()
+	job := mock.SysBatchJob()
+	require.NoError(state.UpsertJob(1000, job))
+
+	// Insert a complete eval
+	eval := mock.Eval()
+	eval.JobID = job.ID
+	eval.Type = structs.JobTypeSysBatch
+	eval.Status = structs.EvalStatusComplete
+	require.NoError(state.UpsertEvals(1001, []*structs.Evaluation{eval}))
+
+	// Insert a completed alloc
+	alloc := mock.Alloc()
+	alloc.Job = job
+	alloc.JobID = job.ID
+	alloc.EvalID = eval.ID
+	alloc.DesiredStatus = structs.AllocDesiredStatusRun
+	alloc.ClientStatus = structs.AllocClientStatusComplete
+	require.NoError(state.UpsertAllocs(1002, []*structs.Allocation{alloc}))
+
+	// The job is dead once all of its allocs are terminal
+	ws := memdb.NewWatchSet()
+	out, err := state.JobByID(ws, job.Namespace, job.ID)
+	require.NoError(err)
+	require.Equal(structs.JobStatusDead, out.Status)
+
+	// Update the time tables to make this work
+	tt := s1.fsm.TimeTable()
+	tt.Witness(2000, time.Now().UTC().Add(-1*s1.config.JobGCThreshold))
+
+	// Create a core scheduler
+	snap, err := state.Snapshot()
+	require.NoError(err)
+	core := NewCoreScheduler(s1, snap)
+
+	// Attempt the GC
+	gc := s1.coreJobEval(structs.CoreJobJobGC, 2000)
+	require.NoError(core.Process(gc))
+
+	// Should be gone like a batch job
+	out, err = state.JobByID(ws, job.Namespace, job.ID)
+	require.NoError(err)
+	require.Nil(out)
+
+	outE, err := state.EvalByID(ws, eval.ID)
+	require.NoError(err)
+	require.Nil(outE)
+
+	outA, err := state.AllocByID(ws, alloc.ID)
+	require.NoError(err)
+	require.Nil(outA)
+}
+
 func TestCoreScheduler_JobGC_Stopped(t *testing.T) {
 	t.Parallel()
 	s1 := TestServer(t, nil)
@@ -1567,6 +1631,87 @@ func TestCoreScheduler_JobGC_Force(t *testing.T) {
 	}
 }
 
+func TestCoreScheduler_JobGC_DispatchBlobs(t *testing.T) {
+	t.Parallel()
+	s1 := TestServer(t, nil)
+	defer s1.Shutdown()
+	testutil.WaitForLeader(t, s1.RPC)
+	require := require.New(t)
+
+	// COMPAT Remove in 0.6: Reset the FSM time table since we reconcile which sets index 0
+	s1.fsm.timetable.table = make([]TimeTableEntry, 1, 10)
+
+	state := s1.fsm.State()
+	upsertBlob := func(index uint64, payload string, complete bool) *structs.DispatchBlob {
+		blob, chunks := structs.NewDispatchBlob([]byte(payload))
+		for _, chunk := range chunks {
+			require.NoError(state.UpsertDispatchBlobChunk(index, chunk))
+		}
+		if complete {
+			require.NoError(state.UpsertDispatchBlob(index, blob))
+		}
+		return blob
+	}
+
+	// Insert the blob of a dead dispatched job, the blob of a running job, an
+	// unreferenced blob, a blob whose upload never completed and a recently
+	// used unreferenced blob
+	reaped := upsertBlob(1000, "reaped", true)
+	running := upsertBlob(1001, "running", true)
+	unreferenced := upsertBlob(1002, "unreferenced", true)
+	incomplete := upsertBlob(1003, "incomplete", false)
+	recent := upsertBlob(3000, "recent", true)
+
+	dead := mock.BatchJob()
+	dead.Status = structs.JobStatusDead
+	dead.PayloadBlob = reaped.ID
+	require.NoError(state.UpsertJob(1004, dead))
+
+	eval := mock.Eval()
+	eval.JobID = dead.ID
+	eval.Status = structs.EvalStatusComplete
+	require.NoError(state.UpsertEvals(1005, []*structs.Evaluation{eval}))
+
+	live := mock.Job()
+	live.PayloadBlob = running.ID
+	require.NoError(state.UpsertJob(1006, live))
+
+	// Update the time tables to make this work
+	tt := s1.fsm.TimeTable()
+	tt.Witness(2000, time.Now().UTC().Add(-1*s1.config.JobGCThreshold))
+
+	// Create a core scheduler
+	snap, err := state.Snapshot()
+	require.NoError(err)
+	core := NewCoreScheduler(s1, snap)
+	require.NoError(core.Process(s1.coreJobEval(structs.CoreJobJobGC, 3001)))
+
+	// The dead job and the unused blobs were reaped
+	out, err := state.JobByID(nil, dead.Namespace, dead.ID)
+	require.NoError(err)
+	require.Nil(out)
+
+	for _, blob := range []*structs.DispatchBlob{reaped, unreferenced} {
+		out, err := state.DispatchBlobByID(nil, blob.ID)
+		require.NoError(err)
+		require.Nil(out)
+	}
+	chunk, err := state.DispatchBlobChunkByID(nil, incomplete.ID, 0)
+	require.NoError(err)
+	require.Nil(chunk)
+
+	// The blobs still in use or used recently were kept
+	for _, blob := range []*structs.DispatchBlob{running, recent} {
+		out, err := state.DispatchBlobByID(nil, blob.ID)
+		require.NoError(err)
+		require.NotNil(out)
+
+		chunk, err := state.DispatchBlobChunkByID(nil, blob.ID, 0)
+		require.NoError(err)
+		require.NotNil(chunk)
+	}
+}
+
 // This test ensures parameterized jobs only get gc'd when stopped
 func TestCoreScheduler_JobGC_Parameterized(t *testing.T) {
 	t.Parallel()
@@ -1771,6 +1916,155 @@ func TestCoreScheduler_DeploymentGC(t *testing.T) {
 	assert.NotNil(out3, "Terminal Deployment With Allocs")
 }
 
+func TestCoreScheduler_RootKeyRotateOrGC(t *testing.T) {
+	t.Parallel()
+	s1, _ := TestACLServer(t, nil)
+	defer s1.Shutdown()
+	testutil.WaitForLeader(t, s1.RPC)
+	require := require.New(t)
+
+	// COMPAT Remove in 0.6: Reset the FSM time table since we reconcile which sets index 0
+	s1.fsm.timetable.table = make([]TimeTableEntry, 1, 10)
+
+	// Insert three inactive keys, one of which signed a running allocation
+	// and one of which encrypted a variable, and an active key past the
+	// rotation threshold
+	state := s1.fsm.State()
+	inUse, unused, active := mock.RootKey(), mock.RootKey(), mock.RootKey()
+	varKey := mock.RootKey()
+	active.CreateTime = time.Now().UTC().Add(-2 * s1.config.RootKeyRotationThreshold).UnixNano()
+	require.NoError(state.UpsertRootKey(999, varKey))
+	require.NoError(state.UpsertRootKey(1000, inUse))
+	require.NoError(state.UpsertRootKey(1001, unused))
+	require.NoError(state.UpsertRootKey(1002, active))
+
+	alloc := mock.Alloc()
+	alloc.SigningKeyID = inUse.KeyID
+	require.NoError(state.UpsertAllocs(1003, []*structs.Allocation{alloc}))
+
+	v := mock.VariableEncrypted()
+	v.KeyID = varKey.KeyID
+	_, err := state.VarSet(1004, &structs.VarApplyStateRequest{Op: structs.VarOpSet, Var: v})
+	require.NoError(err)
+
+	// Update the time tables to make this work
+	tt := s1.fsm.TimeTable()
+	tt.Witness(2000, time.Now().UTC().Add(-1*s1.config.RootKeyGCThreshold))
+
+	// Create a core scheduler
+	snap, err := state.Snapshot()
+	require.NoError(err)
+	core := NewCoreScheduler(s1, snap)
+	require.NoError(core.Process(s1.coreJobEval(structs.CoreJobRootKeyRotateOrGC, 2000)))
+
+	// The active key was rotated
+	out, err := state.ActiveRootKey(nil)
+	require.NoError(err)
+	require.NotEqual(active.KeyID, out.KeyID)
+	out, err = state.RootKeyByID(nil, active.KeyID)
+	require.NoError(err)
+	require.False(out.IsActive())
+
+	// Only the unused inactive key was collected
+	out, err = state.RootKeyByID(nil, inUse.KeyID)
+	require.NoError(err)
+	require.NotNil(out)
+	out, err = state.RootKeyByID(nil, varKey.KeyID)
+	require.NoError(err)
+	require.NotNil(out)
+	out, err = state.RootKeyByID(nil, unused.KeyID)
+	require.NoError(err)
+	require.Nil(out)
+
+	// Once the allocation is terminal and the variable deleted a forced GC
+	// reaps their keys and the recently rotated one
+	alloc = alloc.Copy()
+	alloc.DesiredStatus = structs.AllocDesiredStatusStop
+	alloc.ClientStatus = structs.AllocClientStatusComplete
+	require.NoError(state.UpsertAllocs(3000, []*structs.Allocation{alloc}))
+	_, err = state.VarSet(3001, &structs.VarApplyStateRequest{Op: structs.VarOpDelete, Var: v})
+	require.NoError(err)
+
+	snap, err = state.Snapshot()
+	require.NoError(err)
+	core = NewCoreScheduler(s1, snap)
+	require.NoError(core.Process(s1.coreJobEval(structs.CoreJobForceGC, 3002)))
+
+	iter, err := state.RootKeys(nil)
+	require.NoError(err)
+	var keys []*structs.RootKey
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		keys = append(keys, raw.(*structs.RootKey))
+	}
+	require.Len(keys, 1)
+	require.True(keys[0].IsActive())
+}
+
+func TestCoreScheduler_ExpiredACLTokenGC(t *testing.T) {
+	t.Parallel()
+	s1, _ := TestACLServer(t, nil)
+	defer s1.Shutdown()
+	testutil.WaitForLeader(t, s1.RPC)
+	require := require.New(t)
+
+	// Insert a local and a global token expired past the threshold, a
+	// recently expired one and one that never expires
+	now := time.Now().UTC()
+	old := now.Add(-2 * s1.config.ACLTokenExpirationGCThreshold)
+	recent := now.Add(-1 * time.Minute)
+
+	local := mock.ACLToken()
+	local.ExpirationTime = &old
+	global := mock.ACLToken()
+	global.Global = true
+	global.ExpirationTime = &old
+	recentlyExpired := mock.ACLToken()
+	recentlyExpired.ExpirationTime = &recent
+	noExpiration := mock.ACLToken()
+
+	state := s1.fsm.State()
+	require.NoError(state.UpsertACLTokens(1000, []*structs.ACLToken{
+		local, global, recentlyExpired, noExpiration}))
+
+	// Run the local and global GC
+	snap, err := state.Snapshot()
+	require.NoError(err)
+	core := NewCoreScheduler(s1, snap)
+	require.NoError(core.Process(s1.coreJobEval(structs.CoreJobLocalTokenExpiredGC, 2000)))
+	require.NoError(core.Process(s1.coreJobEval(structs.CoreJobGlobalTokenExpiredGC, 2000)))
+
+	for _, tc := range []struct {
+		token *structs.ACLToken
+		gced  bool
+	}{
+		{local, true},
+		{global, true},
+		{recentlyExpired, false},
+		{noExpiration, false},
+	} {
+		out, err := state.ACLTokenByAccessorID(nil, tc.token.AccessorID)
+		require.NoError(err)
+		if tc.gced {
+			require.Nil(out, "token %s", tc.token.Name)
+		} else {
+			require.NotNil(out, "token %s", tc.token.Name)
+		}
+	}
+
+	// A forced GC reaps recently expired tokens
+	snap, err = state.Snapshot()
+	require.NoError(err)
+	core = NewCoreScheduler(s1, snap)
+	require.NoError(core.Process(s1.coreJobEval(structs.CoreJobForceGC, 2001)))
+
+	out, err := state.ACLTokenByAccessorID(nil, recentlyExpired.AccessorID)
+	require.NoError(err)
+	require.Nil(out)
+	out, err = state.ACLTokenByAccessorID(nil, noExpiration.AccessorID)
+	require.NoError(err)
+	require.NotNil(out)
+}
+
 func TestCoreScheduler_DeploymentGC_Force(t *testing.T) {
 	t.Parallel()
 	for _, withAcl := range []bool{false, true} {
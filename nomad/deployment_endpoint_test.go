@@ -8,6 +8,7 @@ import (
 	msgpackrpc "github.com/hashicorp/net-rpc-msgpackrpc"
 	"github.com/hashicorp/nomad/acl"
 	"github.com/hashicorp/nomad/helper"
+	"github.com/hashicorp/nomad/helper/uuid"
 	"github.com/hashicorp/nomad/nomad/mock"
 	"github.com/hashicorp/nomad/nomad/structs"
 	"github.com/hashicorp/nomad/testutil"
@@ -235,6 +236,17 @@ func TestDeploymentEndpoint_Fail_ACL(t *testing.T) {
 		assert.Equal(err.Error(), structs.ErrPermissionDenied.Error())
 	}
 
+	// Try with a token granted a different deployment capability
+	{
+		otherToken := mock.CreatePolicyAndToken(t, state, 1005, "test-other",
+			mock.NamespacePolicy(structs.DefaultNamespace, "", []string{acl.NamespaceCapabilityDeploymentPromote}))
+		req.AuthToken = otherToken.SecretID
+		var resp structs.DeploymentUpdateResponse
+		err := msgpackrpc.CallWithCodec(codec, "Deployment.Fail", req, &resp)
+		assert.NotNil(err)
+		assert.Equal(err.Error(), structs.ErrPermissionDenied.Error())
+	}
+
 	// Try with an invalid token
 	{
 		req.AuthToken = invalidToken.SecretID
@@ -573,6 +585,17 @@ func TestDeploymentEndpoint_Promote_ACL(t *testing.T) {
 		assert.Equal(err.Error(), structs.ErrPermissionDenied.Error())
 	}
 
+	// Try with a token granted a different deployment capability
+	{
+		otherToken := mock.CreatePolicyAndToken(t, state, 1005, "test-other",
+			mock.NamespacePolicy(structs.DefaultNamespace, "", []string{acl.NamespaceCapabilityDeploymentFail}))
+		req.AuthToken = otherToken.SecretID
+		var resp structs.DeploymentUpdateResponse
+		err := msgpackrpc.CallWithCodec(codec, "Deployment.Promote", req, &resp)
+		assert.NotNil(err)
+		assert.Equal(err.Error(), structs.ErrPermissionDenied.Error())
+	}
+
 	// Try with an invalid token
 	{
 		req.AuthToken = invalidToken.SecretID
@@ -610,6 +633,36 @@ func TestDeploymentEndpoint_Promote_ACL(t *testing.T) {
 		assert.Contains(dout.TaskGroups, "web", "should have web group")
 		assert.True(dout.TaskGroups["web"].Promoted, "web group should be promoted")
 	}
+
+	// Promote another deployment with a token granted the deployment-promote
+	// capability
+	{
+		j2 := j.Copy()
+		j2.ID = uuid.Generate()
+		d2 := mock.Deployment()
+		d2.TaskGroups["web"].DesiredCanaries = 1
+		d2.JobID = j2.ID
+		a2 := mock.Alloc()
+		d2.TaskGroups[a2.TaskGroup].PlacedCanaries = []string{a2.ID}
+		a2.DeploymentID = d2.ID
+		a2.DeploymentStatus = &structs.AllocDeploymentStatus{
+			Healthy: helper.BoolToPtr(true),
+		}
+		assert.Nil(state.UpsertJob(1010, j2), "UpsertJob")
+		assert.Nil(state.UpsertDeployment(1011, d2), "UpsertDeployment")
+		assert.Nil(state.UpsertAllocs(1012, []*structs.Allocation{a2}), "UpsertAllocs")
+
+		promoteToken := mock.CreatePolicyAndToken(t, state, 1013, "test-promote",
+			mock.NamespacePolicy(structs.DefaultNamespace, "", []string{acl.NamespaceCapabilityDeploymentPromote}))
+		req.DeploymentID = d2.ID
+		req.AuthToken = promoteToken.SecretID
+		var resp structs.DeploymentUpdateResponse
+		assert.Nil(msgpackrpc.CallWithCodec(codec, "Deployment.Promote", req, &resp), "RPC")
+
+		dout, err := state.DeploymentByID(nil, d2.ID)
+		assert.Nil(err, "DeploymentByID failed")
+		assert.True(dout.TaskGroups["web"].Promoted, "web group should be promoted")
+	}
 }
 
 func TestDeploymentEndpoint_SetAllocHealth(t *testing.T) {
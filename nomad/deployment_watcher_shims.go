@@ -53,3 +53,8 @@ func (d *deploymentWatcherRaftShim) UpdateAllocDesiredTransition(req *structs.Al
 	fsmErrIntf, index, raftErr := d.apply(structs.AllocUpdateDesiredTransitionRequestType, req)
 	return d.convertApplyErrors(fsmErrIntf, index, raftErr)
 }
+
+func (d *deploymentWatcherRaftShim) UpdateDeploymentAnalysis(req *structs.DeploymentAnalysisUpdateRequest) (uint64, error) {
+	fsmErrIntf, index, raftErr := d.apply(structs.DeploymentAnalysisUpdateRequestType, req)
+	return d.convertApplyErrors(fsmErrIntf, index, raftErr)
+}
@@ -0,0 +1,277 @@
+package deploymentwatcher
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+const (
+	// analysisQueryTimeout is the timeout of a single metric query
+	analysisQueryTimeout = 10 * time.Second
+)
+
+var (
+	// analysisClient is the HTTP client metrics are queried with. Redirects
+	// are not followed so that an allowed address can't redirect the
+	// queries to another one.
+	analysisClient = &http.Client{
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+)
+
+// analysisAddressAllowed returns whether the address of a metric analysis is
+// allowed by the addresses configured by the operator. An address is allowed
+// if it has the scheme and host of an allowed address and its path is equal
+// to or below the allowed path.
+func analysisAddressAllowed(address string, allowed []string) bool {
+	u, err := url.Parse(address)
+	if err != nil || u.Opaque != "" || u.User != nil || u.RawQuery != "" || u.Fragment != "" {
+		return false
+	}
+
+	// Relative paths could escape the allowed path
+	if strings.Contains(u.Path, "..") {
+		return false
+	}
+
+	path := strings.TrimSuffix(u.Path, "/")
+	for _, a := range allowed {
+		au, err := url.Parse(a)
+		if err != nil || au.Host == "" {
+			continue
+		}
+		if !strings.EqualFold(u.Scheme, au.Scheme) || !strings.EqualFold(u.Host, au.Host) {
+			continue
+		}
+
+		prefix := strings.TrimSuffix(au.Path, "/")
+		if path == prefix || strings.HasPrefix(path, prefix+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// watchAnalysis starts the metric analysis of the task groups of the
+// deployment that have an analysis configured.
+func (w *deploymentWatcher) watchAnalysis() {
+	d := w.getDeployment()
+	for _, tg := range w.j.TaskGroups {
+		if tg.Update == nil || tg.Update.Analysis == nil {
+			continue
+		}
+		if _, ok := d.TaskGroups[tg.Name]; !ok {
+			continue
+		}
+
+		go w.analyzeGroup(tg.Name, tg.Update.Analysis)
+	}
+}
+
+// analysisResult is the result of a metric analysis of a task group
+type analysisResult struct {
+	group        string
+	failureLimit int
+
+	// err is the reason the analysis failed or nil if it succeeded
+	err error
+}
+
+// analyzeGroup periodically queries the metrics of the task group's analysis
+// while its deployment is in progress and hands the results to the watch
+// loop, which fails the deployment once the analysis failed as many
+// consecutive times as its failure limit.
+func (w *deploymentWatcher) analyzeGroup(group string, analysis *structs.DeploymentAnalysis) {
+	ticker := time.NewTicker(analysis.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-w.ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		// Only analyze running deployments of groups that are still being
+		// rolled out, which includes unpromoted canaries.
+		d := w.getDeployment()
+		if !d.Active() {
+			return
+		}
+		if d.Status != structs.DeploymentStatusRunning || w.doneGroups(d)[group] {
+			continue
+		}
+
+		res := &analysisResult{
+			group:        group,
+			failureLimit: analysis.FailureLimit,
+			err:          w.runAnalysis(group, analysis),
+		}
+		select {
+		case <-w.ctx.Done():
+			return
+		case w.analysisCh <- res:
+		}
+	}
+}
+
+// handleAnalysisResult records the result of a metric analysis and returns
+// whether the deployment should be failed. The consecutive failures of each
+// group are tracked in failures, which is initialized from the deployment so
+// that the count survives a leader election, and are written to the
+// deployment whenever they change.
+func (w *deploymentWatcher) handleAnalysisResult(res *analysisResult, failures map[string]int) bool {
+	state, ok := w.getDeployment().TaskGroups[res.group]
+	if !ok {
+		return false
+	}
+
+	prev, ok := failures[res.group]
+	if !ok {
+		prev = state.AnalysisFailures
+	}
+
+	count := 0
+	if res.err != nil {
+		count = prev + 1
+		w.logger.Warn("metric analysis failed", "task_group", res.group,
+			"failures", count, "failure_limit", res.failureLimit, "error", res.err)
+		if count >= res.failureLimit {
+			return true
+		}
+	}
+
+	failures[res.group] = count
+	if count == prev {
+		return false
+	}
+
+	req := &structs.DeploymentAnalysisUpdateRequest{
+		DeploymentID: w.deploymentID,
+		TaskGroup:    res.group,
+		Failures:     count,
+	}
+	if _, err := w.upsertDeploymentAnalysis(req); err != nil {
+		w.logger.Error("failed to update metric analysis failures", "task_group", res.group, "error", err)
+	}
+	return false
+}
+
+// runAnalysis queries the metrics of the analysis and returns an error if any
+// of them could not be queried or is out of its thresholds.
+func (w *deploymentWatcher) runAnalysis(group string, analysis *structs.DeploymentAnalysis) error {
+	if !w.AnalysisAddressAllowed(analysis.Address) {
+		return fmt.Errorf("address %q is not allowed by the server's deployment_analysis_addresses", analysis.Address)
+	}
+
+	vars := strings.NewReplacer(
+		"${NOMAD_JOB_ID}", w.j.ID,
+		"${NOMAD_GROUP_NAME}", group,
+		"${NOMAD_DEPLOYMENT_ID}", w.deploymentID,
+	)
+
+	for _, metric := range analysis.Metrics {
+		value, err := w.queryMetric(analysis.Address, vars.Replace(metric.Query))
+		if err != nil {
+			return fmt.Errorf("metric %q could not be queried: %v", metric.Name, err)
+		}
+
+		if metric.Min != nil && value < *metric.Min {
+			return fmt.Errorf("metric %q is %v, below the minimum of %v", metric.Name, value, *metric.Min)
+		}
+		if metric.Max != nil && value > *metric.Max {
+			return fmt.Errorf("metric %q is %v, above the maximum of %v", metric.Name, value, *metric.Max)
+		}
+	}
+
+	return nil
+}
+
+// queryResponse is the response of the instant query endpoint of a Prometheus
+// compatible HTTP API.
+type queryResponse struct {
+	Status string `json:"status"`
+	Error  string `json:"error"`
+	Data   struct {
+		ResultType string          `json:"resultType"`
+		Result     json.RawMessage `json:"result"`
+	} `json:"data"`
+}
+
+// queryMetric runs an instant query against the Prometheus compatible HTTP
+// API at the address. The query must return a scalar or a vector with a
+// single sample.
+func (w *deploymentWatcher) queryMetric(address, query string) (float64, error) {
+	ctx, cancel := context.WithTimeout(w.ctx, analysisQueryTimeout)
+	defer cancel()
+
+	u := strings.TrimSuffix(address, "/") + "/api/v1/query?" + url.Values{"query": []string{query}}.Encode()
+	req, err := http.NewRequest("GET", u, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	resp, err := analysisClient.Do(req.WithContext(ctx))
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	var qr queryResponse
+	if err := json.NewDecoder(resp.Body).Decode(&qr); err != nil {
+		return 0, fmt.Errorf("unexpected response with status code %d: %v", resp.StatusCode, err)
+	}
+	if qr.Status != "success" {
+		return 0, fmt.Errorf("query failed: %s", qr.Error)
+	}
+
+	// Samples are encoded as [<timestamp>, "<value>"]
+	var sample []interface{}
+	switch qr.Data.ResultType {
+	case "scalar":
+		if err := json.Unmarshal(qr.Data.Result, &sample); err != nil {
+			return 0, err
+		}
+	case "vector":
+		var vector []struct {
+			Value []interface{} `json:"value"`
+		}
+		if err := json.Unmarshal(qr.Data.Result, &vector); err != nil {
+			return 0, err
+		}
+		if len(vector) != 1 {
+			return 0, fmt.Errorf("query returned %d samples, expected one", len(vector))
+		}
+		sample = vector[0].Value
+	default:
+		return 0, fmt.Errorf("unsupported result type %q", qr.Data.ResultType)
+	}
+
+	if len(sample) != 2 {
+		return 0, fmt.Errorf("invalid sample %v", sample)
+	}
+	s, ok := sample[1].(string)
+	if !ok {
+		return 0, fmt.Errorf("invalid sample value %v", sample[1])
+	}
+
+	value, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid sample value: %v", err)
+	}
+	if math.IsNaN(value) {
+		return 0, fmt.Errorf("query returned NaN")
+	}
+	return value, nil
+}
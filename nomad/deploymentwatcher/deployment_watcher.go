@@ -50,6 +50,14 @@ type deploymentTriggers interface {
 	// upsertDeploymentAllocHealth is used to set the health of allocations in a
 	// deployment
 	upsertDeploymentAllocHealth(req *structs.ApplyDeploymentAllocHealthRequest) (uint64, error)
+
+	// upsertDeploymentAnalysis is used to record the consecutive failed
+	// metric analyses of a task group
+	upsertDeploymentAnalysis(req *structs.DeploymentAnalysisUpdateRequest) (uint64, error)
+
+	// AnalysisAddressAllowed returns whether a metric analysis is allowed to
+	// query the address
+	AnalysisAddressAllowed(address string) bool
 }
 
 // deploymentWatcher is used to watch a single deployment and trigger the
@@ -71,6 +79,10 @@ type deploymentWatcher struct {
 	// deploymentUpdateCh is triggered when there is an updated deployment
 	deploymentUpdateCh chan struct{}
 
+	// analysisCh receives the results of the metric analyses of the task
+	// groups, which are handled by the watch loop
+	analysisCh chan *analysisResult
+
 	// d is the deployment being watched
 	d *structs.Deployment
 
@@ -107,6 +119,7 @@ func newDeploymentWatcher(parent context.Context, queryLimiter *rate.Limiter,
 		queryLimiter:       queryLimiter,
 		deploymentID:       d.ID,
 		deploymentUpdateCh: make(chan struct{}, 1),
+		analysisCh:         make(chan *analysisResult),
 		d:                  d,
 		j:                  j,
 		state:              state,
@@ -119,6 +132,9 @@ func newDeploymentWatcher(parent context.Context, queryLimiter *rate.Limiter,
 	// Start the long lived watcher that scans for allocation updates
 	go w.watch()
 
+	// Start the metric analysis of the task groups
+	w.watchAnalysis()
+
 	return w
 }
 
@@ -372,10 +388,28 @@ func (w *deploymentWatcher) watch() {
 		deadlineTimer = time.NewTimer(currentDeadline.Sub(time.Now()))
 	}
 
+	// Create the timer used to automatically promote the canaries and advance
+	// the rollout steps once they have been healthy for long enough.
+	promoteTimer := time.NewTimer(0)
+	if !promoteTimer.Stop() {
+		<-promoteTimer.C
+	}
+	defer promoteTimer.Stop()
+
+	// advancedSteps tracks the step each task group was advanced to until the
+	// watched deployment reflects it, so that a step is only advanced once.
+	advancedSteps := make(map[string]int)
+
 	allocIndex := uint64(1)
 	var updates *allocUpdates
+	var allocs []*structs.AllocListStub
+
+	// analysisFailures tracks the consecutive failed analyses of each task
+	// group since they were last read from the deployment
+	analysisFailures := make(map[string]int)
 
 	rollback, deadlineHit := false, false
+	var analysisFailure *analysisResult
 
 FAIL:
 	for {
@@ -430,6 +464,22 @@ FAIL:
 				}
 			}
 
+			w.handlePromotions(allocs, promoteTimer, advancedSteps)
+
+		case <-promoteTimer.C:
+			w.handlePromotions(allocs, promoteTimer, advancedSteps)
+
+		case res := <-w.analysisCh:
+			// The analysis of a task group failed too many times, so fail
+			// the deployment and roll back if the group has auto revert set
+			if w.handleAnalysisResult(res, analysisFailures) {
+				analysisFailure = res
+				if state, ok := w.getDeployment().TaskGroups[res.group]; ok {
+					rollback = state.AutoRevert
+				}
+				break FAIL
+			}
+
 		case updates = <-w.getAllocsCh(allocIndex):
 			if err := updates.err; err != nil {
 				if err == context.Canceled || w.ctx.Err() == context.Canceled {
@@ -440,6 +490,7 @@ FAIL:
 				return
 			}
 			allocIndex = updates.index
+			allocs = updates.allocs
 
 			// We have allocation changes for this deployment so determine the
 			// steps to take.
@@ -464,12 +515,16 @@ FAIL:
 			if res.createEval || len(res.allowReplacements) != 0 {
 				w.createBatchedUpdate(res.allowReplacements, allocIndex)
 			}
+
+			w.handlePromotions(allocs, promoteTimer, advancedSteps)
 		}
 	}
 
 	// Change the deployments status to failed
 	desc := structs.DeploymentStatusDescriptionFailedAllocations
-	if deadlineHit {
+	if analysisFailure != nil {
+		desc = structs.DeploymentStatusDescriptionAnalysisFailure(analysisFailure.group, analysisFailure.err.Error())
+	} else if deadlineHit {
 		desc = structs.DeploymentStatusDescriptionProgressDeadline
 	}
 
@@ -499,6 +554,143 @@ FAIL:
 	}
 }
 
+// handlePromotions promotes the canaries of the deployment if they have been
+// healthy for the bake time of their groups and advances the rollout steps
+// whose allocations have been healthy for the step's pause. If promotions are
+// still pending, the promote timer is reset to fire once the earliest is due.
+func (w *deploymentWatcher) handlePromotions(allocs []*structs.AllocListStub, promoteTimer *time.Timer, advancedSteps map[string]int) {
+	d := w.getDeployment()
+	now := time.Now()
+
+	var next time.Time
+	pending := func(at time.Time) {
+		if next.IsZero() || at.Before(next) {
+			next = at
+		}
+	}
+
+	if promoteAt, ok := autoPromoteTime(d, allocs); ok {
+		if promoteAt.After(now) {
+			pending(promoteAt)
+		} else {
+			w.autoPromote(d)
+		}
+	}
+
+	for group, advanceAt := range stepAdvanceTimes(d, allocs) {
+		// Skip steps whose advancement is not yet reflected by the deployment
+		dstate := d.TaskGroups[group]
+		if dstate.CurrentStep < advancedSteps[group] {
+			continue
+		}
+
+		if advanceAt.After(now) {
+			pending(advanceAt)
+			continue
+		}
+
+		if err := w.advanceStep(d, group); err != nil {
+			w.logger.Error("failed to advance rollout step", "task_group", group, "error", err)
+			continue
+		}
+		advancedSteps[group] = dstate.CurrentStep + 1
+	}
+
+	if next.IsZero() {
+		return
+	}
+
+	if !promoteTimer.Stop() {
+		select {
+		case <-promoteTimer.C:
+		default:
+		}
+	}
+	promoteTimer.Reset(next.Sub(now))
+}
+
+// autoPromote promotes the canaries of the task groups of the deployment that
+// have auto promote set.
+func (w *deploymentWatcher) autoPromote(d *structs.Deployment) {
+	// Record the longest bake time of the promoted groups
+	var groups []string
+	var bakeTime time.Duration
+	for name, dstate := range d.TaskGroups {
+		if dstate.DesiredCanaries == 0 || dstate.Promoted || len(dstate.Steps) != 0 {
+			continue
+		}
+
+		groups = append(groups, name)
+		if dstate.PromoteAfter > bakeTime {
+			bakeTime = dstate.PromoteAfter
+		}
+	}
+
+	areq := &structs.ApplyDeploymentPromoteRequest{
+		DeploymentPromoteRequest: structs.DeploymentPromoteRequest{
+			DeploymentID: w.deploymentID,
+			Groups:       groups,
+		},
+		Eval:              w.getEval(),
+		StatusDescription: structs.DeploymentStatusDescriptionAutoPromoted(bakeTime),
+	}
+
+	w.logger.Debug("automatically promoting canaries", "bake_time", bakeTime)
+	if _, err := w.upsertDeploymentPromotion(areq); err != nil {
+		w.logger.Error("failed to automatically promote deployment", "error", err)
+	}
+}
+
+// autoPromoteTime returns the time at which the canaries of the deployment
+// may be promoted automatically. False is returned if the deployment can not
+// be promoted automatically, either because a group requiring promotion does
+// not have auto promote set or because not all of its canaries are healthy.
+// Task groups with rollout steps are advanced by their steps instead.
+func autoPromoteTime(d *structs.Deployment, allocs []*structs.AllocListStub) (time.Time, bool) {
+	if d == nil || d.Status != structs.DeploymentStatusRunning || !d.HasAutoPromote() {
+		return time.Time{}, false
+	}
+
+	// Index the healthy, running canaries by their ID
+	healthy := make(map[string]*structs.AllocListStub, len(allocs))
+	for _, alloc := range allocs {
+		if alloc.DesiredStatus != structs.AllocDesiredStatusRun ||
+			alloc.ClientStatus != structs.AllocClientStatusRunning ||
+			!alloc.DeploymentStatus.IsCanary() || !alloc.DeploymentStatus.IsHealthy() {
+			continue
+		}
+		healthy[alloc.ID] = alloc
+	}
+
+	var promoteAt time.Time
+	autoPromote := false
+	for _, dstate := range d.TaskGroups {
+		if dstate.DesiredCanaries == 0 || dstate.Promoted || len(dstate.Steps) != 0 {
+			continue
+		}
+
+		autoPromote = true
+		have := 0
+		for _, id := range dstate.PlacedCanaries {
+			alloc, ok := healthy[id]
+			if !ok {
+				continue
+			}
+
+			have++
+			if at := alloc.DeploymentStatus.Timestamp.Add(dstate.PromoteAfter); at.After(promoteAt) {
+				promoteAt = at
+			}
+		}
+
+		if have < dstate.DesiredCanaries {
+			return time.Time{}, false
+		}
+	}
+
+	return promoteAt, autoPromote
+}
+
 // allocUpdateResult is used to return the desired actions given the newest set
 // of allocations for the deployment.
 type allocUpdateResult struct {
@@ -52,6 +52,10 @@ type DeploymentRaftEndpoints interface {
 	// UpdateAllocDesiredTransition is used to update the desired transition
 	// for allocations.
 	UpdateAllocDesiredTransition(req *structs.AllocUpdateDesiredTransitionRequest) (uint64, error)
+
+	// UpdateDeploymentAnalysis is used to record the consecutive failed
+	// metric analyses of a task group
+	UpdateDeploymentAnalysis(req *structs.DeploymentAnalysisUpdateRequest) (uint64, error)
 }
 
 // Watcher is used to watch deployments and their allocations created
@@ -82,6 +86,10 @@ type Watcher struct {
 	// allocation desired transition updates
 	allocUpdateBatcher *AllocUpdateBatcher
 
+	// analysisAddresses are the addresses the metric analyses of deployments
+	// are allowed to query
+	analysisAddresses []string
+
 	// ctx and exitFn are used to cancel the watcher
 	ctx    context.Context
 	exitFn context.CancelFunc
@@ -126,6 +134,22 @@ func (w *Watcher) SetEnabled(enabled bool, state *state.StateStore) {
 	}
 }
 
+// SetAnalysisAddresses sets the addresses the metric analyses of deployments
+// are allowed to query. Analyses of other addresses fail.
+func (w *Watcher) SetAnalysisAddresses(addresses []string) {
+	w.l.Lock()
+	defer w.l.Unlock()
+	w.analysisAddresses = addresses
+}
+
+// AnalysisAddressAllowed returns whether a metric analysis is allowed to query
+// the address.
+func (w *Watcher) AnalysisAddressAllowed(address string) bool {
+	w.l.RLock()
+	defer w.l.RUnlock()
+	return analysisAddressAllowed(address, w.analysisAddresses)
+}
+
 // flush is used to clear the state of the watcher
 func (w *Watcher) flush() {
 	// Stop all the watchers and clear it
@@ -392,3 +416,9 @@ func (w *Watcher) upsertDeploymentPromotion(req *structs.ApplyDeploymentPromoteR
 func (w *Watcher) upsertDeploymentAllocHealth(req *structs.ApplyDeploymentAllocHealthRequest) (uint64, error) {
 	return w.raft.UpdateDeploymentAllocHealth(req)
 }
+
+// upsertDeploymentAnalysis commits the consecutive failed metric analyses of a
+// task group to Raft
+func (w *Watcher) upsertDeploymentAnalysis(req *structs.DeploymentAnalysisUpdateRequest) (uint64, error) {
+	return w.raft.UpdateDeploymentAnalysis(req)
+}
@@ -0,0 +1,88 @@
+package deploymentwatcher
+
+import (
+	"time"
+
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// stepAdvanceTimes returns the time at which the rollout step in progress of
+// each task group of the deployment may be advanced. Task groups whose step
+// has not yet placed all of its allocations or whose allocations are not all
+// healthy are omitted.
+func stepAdvanceTimes(d *structs.Deployment, allocs []*structs.AllocListStub) map[string]time.Time {
+	if d == nil || d.Status != structs.DeploymentStatusRunning {
+		return nil
+	}
+
+	// Index the healthy, running allocations by their ID
+	healthy := make(map[string]*structs.AllocListStub, len(allocs))
+	for _, alloc := range allocs {
+		if alloc.DesiredStatus != structs.AllocDesiredStatusRun ||
+			alloc.ClientStatus != structs.AllocClientStatusRunning ||
+			!alloc.DeploymentStatus.IsHealthy() {
+			continue
+		}
+		healthy[alloc.ID] = alloc
+	}
+
+	times := make(map[string]time.Time)
+	for group, dstate := range d.TaskGroups {
+		step := dstate.Step()
+		if step == nil {
+			continue
+		}
+
+		// Determine the allocations that make up the step
+		var need int
+		var stepAllocs []*structs.AllocListStub
+		if step.Canary != 0 {
+			need = dstate.DesiredCanaries
+			for _, id := range dstate.PlacedCanaries {
+				if alloc, ok := healthy[id]; ok {
+					stepAllocs = append(stepAllocs, alloc)
+				}
+			}
+		} else {
+			need = step.Target(dstate.DesiredTotal)
+			for _, alloc := range healthy {
+				if alloc.TaskGroup == group {
+					stepAllocs = append(stepAllocs, alloc)
+				}
+			}
+		}
+
+		if len(stepAllocs) < need {
+			continue
+		}
+
+		// The step's pause starts once its last allocation is healthy
+		var healthyAt time.Time
+		for _, alloc := range stepAllocs {
+			if alloc.DeploymentStatus.Timestamp.After(healthyAt) {
+				healthyAt = alloc.DeploymentStatus.Timestamp
+			}
+		}
+		times[group] = healthyAt.Add(step.Pause)
+	}
+
+	return times
+}
+
+// advanceStep advances the task group of the deployment to its next rollout
+// step by promoting it, which promotes the canaries of a canary step.
+func (w *deploymentWatcher) advanceStep(d *structs.Deployment, group string) error {
+	dstate := d.TaskGroups[group]
+	areq := &structs.ApplyDeploymentPromoteRequest{
+		DeploymentPromoteRequest: structs.DeploymentPromoteRequest{
+			DeploymentID: w.deploymentID,
+			Groups:       []string{group},
+		},
+		Eval:              w.getEval(),
+		StatusDescription: structs.DeploymentStatusDescriptionStepAdvanced(group, dstate.CurrentStep+2, len(dstate.Steps)),
+	}
+
+	w.logger.Debug("advancing rollout step", "task_group", group, "step", dstate.CurrentStep+1)
+	_, err := w.upsertDeploymentPromotion(areq)
+	return err
+}
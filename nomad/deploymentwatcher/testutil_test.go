@@ -174,6 +174,12 @@ func matchDeploymentPromoteRequest(c *matchDeploymentPromoteRequestConfig) func(
 		return true
 	}
 }
+func (m *mockBackend) UpdateDeploymentAnalysis(req *structs.DeploymentAnalysisUpdateRequest) (uint64, error) {
+	m.Called(req)
+	i := m.nextIndex()
+	return i, m.state.UpdateDeploymentAnalysis(i, req)
+}
+
 func (m *mockBackend) UpdateDeploymentAllocHealth(req *structs.ApplyDeploymentAllocHealthRequest) (uint64, error) {
 	m.Called(req)
 	i := m.nextIndex()
@@ -0,0 +1,53 @@
+package nomad
+
+import (
+	"sync"
+
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// dispatchLocks holds a lock per parameterized job, so that dispatches of
+// different parameterized jobs aren't serialized with each other. Locks are
+// removed once no one holds or waits for them.
+type dispatchLocks struct {
+	locks map[structs.NamespacedID]*dispatchLock
+	l     sync.Mutex
+}
+
+// dispatchLock is the lock of a parameterized job along with the number of
+// callers holding or waiting for it
+type dispatchLock struct {
+	sync.Mutex
+	refs int
+}
+
+// newDispatchLocks returns a new set of dispatch locks
+func newDispatchLocks() *dispatchLocks {
+	return &dispatchLocks{
+		locks: make(map[structs.NamespacedID]*dispatchLock),
+	}
+}
+
+// Lock locks the parameterized job and returns the function unlocking it
+func (d *dispatchLocks) Lock(parentID structs.NamespacedID) func() {
+	d.l.Lock()
+	lock, ok := d.locks[parentID]
+	if !ok {
+		lock = &dispatchLock{}
+		d.locks[parentID] = lock
+	}
+	lock.refs++
+	d.l.Unlock()
+
+	lock.Lock()
+	return func() {
+		lock.Unlock()
+
+		d.l.Lock()
+		defer d.l.Unlock()
+		lock.refs--
+		if lock.refs == 0 {
+			delete(d.locks, parentID)
+		}
+	}
+}
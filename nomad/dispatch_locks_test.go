@@ -0,0 +1,53 @@
+package nomad
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/testutil"
+)
+
+func TestDispatchLocks(t *testing.T) {
+	t.Parallel()
+	locks := newDispatchLocks()
+
+	job1 := structs.NamespacedID{ID: "job1", Namespace: structs.DefaultNamespace}
+	job2 := structs.NamespacedID{ID: "job2", Namespace: structs.DefaultNamespace}
+
+	// Locking another job doesn't block
+	unlock1 := locks.Lock(job1)
+	unlock2 := locks.Lock(job2)
+	unlock2()
+
+	// Locking the same job blocks until it is unlocked
+	locked := make(chan struct{})
+	go func() {
+		unlock := locks.Lock(job1)
+		close(locked)
+		unlock()
+	}()
+
+	select {
+	case <-locked:
+		t.Fatalf("job locked twice")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	unlock1()
+	select {
+	case <-locked:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("job not locked after unlock")
+	}
+
+	// Unused locks are removed
+	testutil.WaitForResult(func() (bool, error) {
+		locks.l.Lock()
+		defer locks.l.Unlock()
+		return len(locks.locks) == 0, fmt.Errorf("%d locks left", len(locks.locks))
+	}, func(err error) {
+		t.Fatal(err)
+	})
+}
@@ -0,0 +1,153 @@
+package nomad
+
+import (
+	"fmt"
+	"time"
+
+	metrics "github.com/armon/go-metrics"
+	log "github.com/hashicorp/go-hclog"
+	memdb "github.com/hashicorp/go-memdb"
+	"github.com/hashicorp/nomad/acl"
+	"github.com/hashicorp/nomad/nomad/state"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// DispatchPayload endpoint is used to read the dispatch payloads stored in
+// blobs and to garbage collect the blobs
+type DispatchPayload struct {
+	srv    *Server
+	logger log.Logger
+}
+
+// Read returns a chunk of the payload of a dispatched job stored in a blob.
+// Workload identities may only read the payload of their own job, and clients
+// authenticating with their node secret ID only the payload of the job of an
+// allocation on the node.
+func (d *DispatchPayload) Read(args *structs.DispatchPayloadReadRequest, reply *structs.DispatchPayloadReadResponse) error {
+	if done, err := d.srv.forward("DispatchPayload.Read", args, args, reply); done {
+		return err
+	}
+	defer metrics.MeasureSince([]string{"nomad", "dispatch_payload", "read"}, time.Now())
+
+	ns := args.RequestNamespace()
+	aclObj, claims, err := d.srv.resolveTokenOrIdentity(args.AuthToken)
+	if err == structs.ErrTokenNotFound {
+		// Attempt to lookup AuthToken as a Node.SecretID since clients
+		// fetch payloads for tasks without a workload identity
+		if err := d.authorizeNode(args); err != nil {
+			return err
+		}
+	} else if err != nil {
+		return err
+	} else if claims != nil {
+		if claims.Namespace != ns || claims.JobID != args.JobID {
+			return structs.ErrPermissionDenied
+		}
+	} else if aclObj != nil && !aclObj.AllowNsOp(ns, acl.NamespaceCapabilityReadJob) {
+		return structs.ErrPermissionDenied
+	}
+
+	// Setup the blocking query
+	opts := blockingOptions{
+		queryOpts: &args.QueryOptions,
+		queryMeta: &reply.QueryMeta,
+		run: func(ws memdb.WatchSet, state *state.StateStore) error {
+			job, err := state.JobByID(ws, ns, args.JobID)
+			if err != nil {
+				return err
+			}
+			if job == nil {
+				return fmt.Errorf("job %q not found", args.JobID)
+			}
+			if job.PayloadBlob == "" {
+				return fmt.Errorf("job %q has no dispatch payload blob", args.JobID)
+			}
+
+			blob, err := state.DispatchBlobByID(ws, job.PayloadBlob)
+			if err != nil {
+				return err
+			}
+			if blob == nil {
+				return fmt.Errorf("dispatch payload blob %q not found", job.PayloadBlob)
+			}
+			if int(args.Chunk) >= blob.Chunks {
+				return fmt.Errorf("dispatch payload blob %q has %d chunks", blob.ID, blob.Chunks)
+			}
+
+			chunk, err := state.DispatchBlobChunkByID(ws, blob.ID, args.Chunk)
+			if err != nil {
+				return err
+			}
+			if chunk == nil {
+				return fmt.Errorf("dispatch payload blob %q is missing chunk %d", blob.ID, args.Chunk)
+			}
+
+			reply.Blob = blob
+			reply.Data = chunk.Data
+			reply.Index = blob.ModifyIndex
+
+			// Set the query response
+			d.srv.setQueryMeta(&reply.QueryMeta)
+			return nil
+		}}
+	return d.srv.blockingRPC(&opts)
+}
+
+// authorizeNode returns an error unless the auth token of the request is the
+// secret ID of a node running an allocation of the job being read.
+func (d *DispatchPayload) authorizeNode(args *structs.DispatchPayloadReadRequest) error {
+	snap, err := d.srv.fsm.State().Snapshot()
+	if err != nil {
+		return err
+	}
+	node, err := snap.NodeBySecretID(nil, args.AuthToken)
+	if err != nil {
+		return err
+	}
+	if node == nil {
+		return structs.ErrTokenNotFound
+	}
+
+	if args.AllocID == "" {
+		return structs.ErrPermissionDenied
+	}
+	alloc, err := snap.AllocByID(nil, args.AllocID)
+	if err != nil {
+		return err
+	}
+	if alloc == nil || alloc.NodeID != node.ID ||
+		alloc.Namespace != args.RequestNamespace() || alloc.JobID != args.JobID {
+		return structs.ErrPermissionDenied
+	}
+	return nil
+}
+
+// Delete removes a set of dispatch blobs and their chunks. It is used by the
+// job garbage collector.
+func (d *DispatchPayload) Delete(args *structs.DispatchBlobDeleteRequest, reply *structs.GenericResponse) error {
+	if done, err := d.srv.forward("DispatchPayload.Delete", args, args, reply); done {
+		return err
+	}
+	defer metrics.MeasureSince([]string{"nomad", "dispatch_payload", "delete"}, time.Now())
+
+	// Check management level permissions
+	if acl, err := d.srv.ResolveToken(args.AuthToken); err != nil {
+		return err
+	} else if acl != nil && !acl.IsManagement() {
+		return structs.ErrPermissionDenied
+	}
+
+	// Validate non-zero set of blobs
+	if len(args.BlobIDs) == 0 {
+		return fmt.Errorf("must specify as least one dispatch blob")
+	}
+
+	// Update via Raft
+	_, index, err := d.srv.raftApply(structs.DispatchBlobDeleteRequestType, args)
+	if err != nil {
+		return err
+	}
+
+	reply.Index = index
+	return nil
+}
@@ -0,0 +1,172 @@
+package nomad
+
+import (
+	"testing"
+
+	"github.com/golang/snappy"
+	msgpackrpc "github.com/hashicorp/net-rpc-msgpackrpc"
+	"github.com/hashicorp/nomad/acl"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDispatchPayloadEndpoint_Read(t *testing.T) {
+	t.Parallel()
+	s1, root := TestACLServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+		c.Build = "0.9.2+unittest"
+	})
+	defer s1.Shutdown()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+	waitForActiveRootKey(t, s1)
+	state := s1.fsm.State()
+
+	// Dispatch a job with a payload stored in a blob
+	job := mock.BatchJob()
+	job.ParameterizedJob = &structs.ParameterizedJobConfig{}
+	require.NoError(t, state.UpsertJob(1000, job))
+
+	payload := make([]byte, structs.DispatchBlobChunkSize+100)
+	for i := range payload {
+		payload[i] = byte(i)
+	}
+	dispatchReq := &structs.JobDispatchRequest{
+		JobID:   job.ID,
+		Payload: payload,
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			Namespace: job.Namespace,
+			AuthToken: root.SecretID,
+		},
+	}
+	var dispatchResp structs.JobDispatchResponse
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "Job.Dispatch", dispatchReq, &dispatchResp))
+
+	child, err := state.JobByID(nil, job.Namespace, dispatchResp.DispatchedJobID)
+	require.NoError(t, err)
+	require.NotEmpty(t, child.PayloadBlob)
+	require.Contains(t, child.Constraints, dispatchBlobConstraint)
+
+	// Sign the identity of an allocation of the dispatched job
+	alloc := mock.BatchAlloc()
+	alloc.Job = child
+	alloc.JobID = child.ID
+	require.NoError(t, s1.signAllocIdentities(alloc.Job, []*structs.Allocation{alloc}))
+	require.NoError(t, state.UpsertJobSummary(1001, mock.JobSummary(alloc.JobID)))
+	require.NoError(t, state.UpsertAllocs(1002, []*structs.Allocation{alloc}))
+	identity := alloc.SignedIdentities["web"]
+	require.NotEmpty(t, identity)
+
+	node := mock.Node()
+	node.ID = alloc.NodeID
+	require.NoError(t, state.UpsertNode(1003, node))
+	otherNode := mock.Node()
+	require.NoError(t, state.UpsertNode(1004, otherNode))
+
+	readAlloc := func(jobID, allocID string, chunk uint, token string) (*structs.DispatchPayloadReadResponse, error) {
+		req := &structs.DispatchPayloadReadRequest{
+			JobID:   jobID,
+			Chunk:   chunk,
+			AllocID: allocID,
+			QueryOptions: structs.QueryOptions{
+				Region:    "global",
+				Namespace: job.Namespace,
+				AuthToken: token,
+			},
+		}
+		var resp structs.DispatchPayloadReadResponse
+		err := msgpackrpc.CallWithCodec(codec, "DispatchPayload.Read", req, &resp)
+		return &resp, err
+	}
+	read := func(jobID string, chunk uint, token string) (*structs.DispatchPayloadReadResponse, error) {
+		return readAlloc(jobID, "", chunk, token)
+	}
+
+	// The identity can read all the chunks of the payload of its job
+	var out []byte
+	for i := uint(0); i < 2; i++ {
+		resp, err := read(child.ID, i, identity)
+		require.NoError(t, err)
+		require.Equal(t, child.PayloadBlob, resp.Blob.ID)
+		require.Equal(t, 2, resp.Blob.Chunks)
+
+		data, err := snappy.Decode(nil, resp.Data)
+		require.NoError(t, err)
+		out = append(out, data...)
+	}
+	require.Equal(t, payload, out)
+
+	// Reading past the last chunk fails
+	_, err = read(child.ID, 2, identity)
+	require.Error(t, err)
+
+	// The identity can't read the payload of other jobs
+	_, err = read(job.ID, 0, identity)
+	require.EqualError(t, err, structs.ErrPermissionDenied.Error())
+
+	// Tokens need the read-job capability
+	_, err = read(child.ID, 0, "")
+	require.EqualError(t, err, structs.ErrPermissionDenied.Error())
+
+	// The node of an allocation of the job can read the payload with its
+	// secret ID, for tasks without an identity
+	resp, err := readAlloc(child.ID, alloc.ID, 0, node.SecretID)
+	require.NoError(t, err)
+	require.Equal(t, child.PayloadBlob, resp.Blob.ID)
+
+	// Other nodes, jobs and requests without the allocation are denied
+	_, err = readAlloc(child.ID, alloc.ID, 0, otherNode.SecretID)
+	require.EqualError(t, err, structs.ErrPermissionDenied.Error())
+	_, err = readAlloc(job.ID, alloc.ID, 0, node.SecretID)
+	require.EqualError(t, err, structs.ErrPermissionDenied.Error())
+	_, err = readAlloc(child.ID, "", 0, node.SecretID)
+	require.EqualError(t, err, structs.ErrPermissionDenied.Error())
+
+	token := mock.CreatePolicyAndToken(t, state, 1005, "test-valid",
+		mock.NamespacePolicy(structs.DefaultNamespace, "", []string{acl.NamespaceCapabilityReadJob}))
+	_, err = read(child.ID, 0, token.SecretID)
+	require.NoError(t, err)
+}
+
+func TestDispatchPayloadEndpoint_Delete(t *testing.T) {
+	t.Parallel()
+	s1, root := TestACLServer(t, nil)
+	defer s1.Shutdown()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+	state := s1.fsm.State()
+
+	// Create a blob
+	blob, chunks := structs.NewDispatchBlob([]byte("hello world"))
+	for _, chunk := range chunks {
+		require.NoError(t, state.UpsertDispatchBlobChunk(1000, chunk))
+	}
+	require.NoError(t, state.UpsertDispatchBlob(1001, blob))
+
+	req := &structs.DispatchBlobDeleteRequest{
+		BlobIDs: []string{blob.ID},
+		WriteRequest: structs.WriteRequest{
+			Region: "global",
+		},
+	}
+
+	// Deleting requires a management token
+	var resp structs.GenericResponse
+	err := msgpackrpc.CallWithCodec(codec, "DispatchPayload.Delete", req, &resp)
+	require.EqualError(t, err, structs.ErrPermissionDenied.Error())
+
+	req.AuthToken = root.SecretID
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "DispatchPayload.Delete", req, &resp))
+	require.NotZero(t, resp.Index)
+
+	out, err := state.DispatchBlobByID(nil, blob.ID)
+	require.NoError(t, err)
+	require.Nil(t, out)
+
+	chunk, err := state.DispatchBlobChunkByID(nil, blob.ID, 0)
+	require.NoError(t, err)
+	require.Nil(t, chunk)
+}
@@ -0,0 +1,99 @@
+package drainer
+
+import (
+	"sort"
+
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// lowestDrainPriority returns the lowest priority of the jobs with
+// allocations left to drain on a node, or false if there are none. System and
+// sysbatch allocations are stopped once the others are drained, and batch
+// allocations are never migrated but run until the deadline, so they are
+// ignored rather than holding back the higher priority jobs.
+func lowestDrainPriority(allocs []*structs.Allocation) (int, bool) {
+	lowest, found := 0, false
+	for _, alloc := range allocs {
+		if alloc.TerminalStatus() || alloc.Job.IsNodeBound() || alloc.Job.Type == structs.JobTypeBatch {
+			continue
+		}
+
+		if !found || alloc.Job.Priority < lowest {
+			lowest = alloc.Job.Priority
+			found = true
+		}
+	}
+
+	return lowest, found
+}
+
+// NodeDrainProgress returns the progress of the drain of a node given the
+// allocations on the node. The node must have a drain strategy.
+func NodeDrainProgress(node *structs.Node, allocs []*structs.Allocation) *structs.DrainProgress {
+	strategy := node.DrainStrategy
+	lowest, ordered := 0, false
+	if strategy.OrderByPriority {
+		lowest, ordered = lowestDrainPriority(allocs)
+	}
+
+	progress := &structs.DrainProgress{}
+	blocked := make(map[structs.NamespacedID]*structs.DrainBlockedJob)
+	for _, alloc := range allocs {
+		// Nothing to do on a terminal allocation
+		if alloc.TerminalStatus() {
+			continue
+		}
+
+		// Skip system if configured to
+		nodeBound := alloc.Job.IsNodeBound()
+		if nodeBound && strategy.IgnoreSystemJobs {
+			continue
+		}
+
+		progress.AllocsRemaining++
+		if alloc.DesiredTransition.ShouldMigrate() {
+			progress.AllocsMigrating++
+			continue
+		}
+
+		// Determine why the allocation isn't migrated yet
+		var reason string
+		switch {
+		case nodeBound:
+			reason = structs.DrainBlockedReasonSystem
+		case alloc.Job.Type == structs.JobTypeBatch:
+			reason = structs.DrainBlockedReasonBatch
+		case ordered && alloc.Job.Priority > lowest:
+			reason = structs.DrainBlockedReasonPriority
+		default:
+			reason = structs.DrainBlockedReasonMigrate
+		}
+
+		jns := structs.NamespacedID{Namespace: alloc.Namespace, ID: alloc.JobID}
+		job, ok := blocked[jns]
+		if !ok {
+			job = &structs.DrainBlockedJob{
+				Namespace: alloc.Namespace,
+				JobID:     alloc.JobID,
+				Priority:  alloc.Job.Priority,
+				Reason:    reason,
+			}
+			blocked[jns] = job
+			progress.BlockedJobs = append(progress.BlockedJobs, job)
+		}
+		job.Allocs++
+	}
+
+	sort.Slice(progress.BlockedJobs, func(i, j int) bool {
+		a, b := progress.BlockedJobs[i], progress.BlockedJobs[j]
+		if a.Priority != b.Priority {
+			return a.Priority < b.Priority
+		}
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		return a.JobID < b.JobID
+	})
+
+	return progress
+}
@@ -0,0 +1,78 @@
+package drainer
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/helper"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNodeDrainProgress(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	node := mock.Node()
+	node.DrainStrategy = &structs.DrainStrategy{
+		DrainSpec: structs.DrainSpec{
+			OrderByPriority: true,
+		},
+	}
+
+	low := mock.Job()
+	low.Priority = 20
+	high := mock.Job()
+	high.Priority = 80
+	batch := mock.BatchJob()
+	batch.Priority = 10
+	system := mock.SystemJob()
+
+	newAlloc := func(job *structs.Job) *structs.Allocation {
+		a := mock.Alloc()
+		a.Job = job
+		a.JobID = job.ID
+		a.NodeID = node.ID
+		return a
+	}
+
+	migrating := newAlloc(low)
+	migrating.DesiredTransition.Migrate = helper.BoolToPtr(true)
+	complete := newAlloc(high)
+	complete.ClientStatus = structs.AllocClientStatusComplete
+
+	allocs := []*structs.Allocation{
+		migrating,
+		newAlloc(low),
+		newAlloc(high),
+		newAlloc(high),
+		newAlloc(batch),
+		newAlloc(system),
+		complete,
+	}
+
+	progress := NodeDrainProgress(node, allocs)
+	require.Equal(6, progress.AllocsRemaining)
+	require.Equal(1, progress.AllocsMigrating)
+	require.Len(progress.BlockedJobs, 4)
+
+	// Blocked jobs are ordered by priority
+	blocked := make(map[string]*structs.DrainBlockedJob)
+	for i, job := range progress.BlockedJobs {
+		if i > 0 {
+			require.True(progress.BlockedJobs[i-1].Priority <= job.Priority)
+		}
+		blocked[job.JobID] = job
+	}
+	require.Equal(structs.DrainBlockedReasonMigrate, blocked[low.ID].Reason)
+	require.Equal(structs.DrainBlockedReasonBatch, blocked[batch.ID].Reason)
+	require.Equal(structs.DrainBlockedReasonPriority, blocked[high.ID].Reason)
+	require.Equal(structs.DrainBlockedReasonSystem, blocked[system.ID].Reason)
+	require.Equal(2, blocked[high.ID].Allocs)
+
+	// System allocs aren't drained when ignored
+	node.DrainStrategy.IgnoreSystemJobs = true
+	progress = NodeDrainProgress(node, allocs)
+	require.Equal(5, progress.AllocsRemaining)
+	require.Len(progress.BlockedJobs, 3)
+}
@@ -46,6 +46,7 @@ const (
 type RaftApplier interface {
 	AllocUpdateDesiredTransition(allocs map[string]*structs.DesiredTransition, evals []*structs.Evaluation) (uint64, error)
 	NodesDrainComplete(nodes []string, event *structs.NodeEvent) (uint64, error)
+	MaintenancePlanUpdate(plan *structs.MaintenancePlan, drains map[string]*structs.DrainUpdate, events map[string]*structs.NodeEvent) (uint64, error)
 }
 
 // NodeTracker is the interface to notify an object that is tracking draining
@@ -147,6 +148,9 @@ type NodeDrainer struct {
 	deadlineNotifier        DrainDeadlineNotifier
 	deadlineNotifierFactory DrainDeadlineNotifierFactory
 
+	// planWatcher executes the maintenance plans draining nodes in batches.
+	planWatcher *maintenancePlanWatcher
+
 	// state is the state that is watched for state changes.
 	state *state.StateStore
 
@@ -217,6 +221,7 @@ func (n *NodeDrainer) flush(state *state.StateStore) {
 	n.jobWatcher = n.jobFactory(n.ctx, n.queryLimiter, n.state, n.logger)
 	n.nodeWatcher = n.nodeFactory(n.ctx, n.queryLimiter, n.state, n.logger, n)
 	n.deadlineNotifier = n.deadlineNotifierFactory(n.ctx)
+	n.planWatcher = NewMaintenancePlanWatcher(n.ctx, n.queryLimiter, n.state, n.logger, n.raft)
 	n.nodes = make(map[string]*drainingNode, 32)
 }
 
@@ -66,9 +66,9 @@ func (n *drainingNode) IsDone() (bool, error) {
 	}
 
 	for _, alloc := range allocs {
-		// System jobs are only stopped after a node is done draining
-		// everything else, so ignore them here.
-		if alloc.Job.Type == structs.JobTypeSystem {
+		// System and sysbatch jobs are only stopped after a node is done
+		// draining everything else, so ignore them here.
+		if alloc.Job.IsNodeBound() {
 			continue
 		}
 
@@ -109,7 +109,7 @@ func (n *drainingNode) RemainingAllocs() ([]*structs.Allocation, error) {
 		}
 
 		// Skip system if configured to
-		if alloc.Job.Type == structs.JobTypeSystem && ignoreSystem {
+		if alloc.Job.IsNodeBound() && ignoreSystem {
 			continue
 		}
 
@@ -139,7 +139,7 @@ func (n *drainingNode) DrainingJobs() ([]structs.NamespacedID, error) {
 	jobIDs := make(map[structs.NamespacedID]struct{})
 	var jobs []structs.NamespacedID
 	for _, alloc := range allocs {
-		if alloc.TerminalStatus() || alloc.Job.Type == structs.JobTypeSystem {
+		if alloc.TerminalStatus() || alloc.Job.IsNodeBound() {
 			continue
 		}
 
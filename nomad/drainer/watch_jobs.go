@@ -206,8 +206,8 @@ func (w *drainingJobWatcher) watch() {
 				continue
 			}
 
-			// Ignore any system jobs
-			if job.Type == structs.JobTypeSystem {
+			// Ignore any system and sysbatch jobs
+			if job.IsNodeBound() {
 				w.deregisterJob(job.ID, job.Namespace)
 				continue
 			}
@@ -333,12 +333,15 @@ func handleJob(snap *state.StateSnapshot, job *structs.Job, allocs []*structs.Al
 // handleTaskGroup takes the state of a draining task group and computes the
 // desired actions. For batch jobs we only notify when they have been migrated
 // and never mark them for drain. Batch jobs are allowed to complete up until
-// the deadline, after which they are force killed.
+// the deadline, after which they are force killed. On nodes drained by
+// priority, allocations are only marked for drain once no lower priority job
+// has allocations left on the node.
 func handleTaskGroup(snap *state.StateSnapshot, batch bool, tg *structs.TaskGroup,
 	allocs []*structs.Allocation, lastHandledIndex uint64, result *jobResult) error {
 
 	// Determine how many allocations can be drained
 	drainingNodes := make(map[string]bool, 4)
+	lowestPriority := make(map[string]int, 4)
 	healthy := 0
 	remainingDrainingAlloc := false
 	var drainable []*structs.Allocation
@@ -356,6 +359,18 @@ func handleTaskGroup(snap *state.StateSnapshot, batch bool, tg *structs.TaskGrou
 			// Check if the node exists and whether it has a drain strategy
 			onDrainingNode = node != nil && node.DrainStrategy != nil
 			drainingNodes[alloc.NodeID] = onDrainingNode
+
+			// Capture the lowest priority left to drain if the node is
+			// drained by priority
+			if onDrainingNode && node.DrainStrategy.OrderByPriority {
+				nodeAllocs, err := snap.AllocsByNode(nil, node.ID)
+				if err != nil {
+					return err
+				}
+				if priority, ok := lowestDrainPriority(nodeAllocs); ok {
+					lowestPriority[node.ID] = priority
+				}
+			}
 		}
 
 		// Check if the alloc should be considered migrated. A migrated
@@ -386,6 +401,11 @@ func handleTaskGroup(snap *state.StateSnapshot, batch bool, tg *structs.TaskGrou
 		// for this job.
 		remainingDrainingAlloc = true
 
+		// Wait for the lower priority jobs to drain first
+		if priority, ok := lowestPriority[alloc.NodeID]; ok && alloc.Job.Priority > priority {
+			continue
+		}
+
 		// If we haven't marked this allocation for migration already, capture
 		// it as eligible for draining.
 		if !batch && !alloc.DesiredTransition.ShouldMigrate() {
@@ -665,6 +665,144 @@ func TestHandleTaskGroup_Migrations(t *testing.T) {
 	require.True(res.done)
 }
 
+// This test asserts that on nodes drained by priority, the allocations of a
+// job are only drained once lower priority jobs are drained
+func TestHandleTaskGroup_OrderByPriority(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	// Create a node draining by priority
+	state := state.TestStateStore(t)
+	n := mock.Node()
+	n.DrainStrategy = &structs.DrainStrategy{
+		DrainSpec: structs.DrainSpec{
+			Deadline:        5 * time.Minute,
+			OrderByPriority: true,
+		},
+		ForceDeadline: time.Now().Add(1 * time.Minute),
+	}
+	require.Nil(state.UpsertNode(100, n))
+
+	// Create a low and a high priority job
+	low := mock.Job()
+	low.Priority = 20
+	low.TaskGroups[0].Count = 1
+	require.Nil(state.UpsertJob(101, low))
+
+	high := mock.Job()
+	high.Priority = 80
+	high.TaskGroups[0].Count = 2
+	require.Nil(state.UpsertJob(102, high))
+
+	// Create healthy allocs for both jobs on the node
+	newAlloc := func(job *structs.Job) *structs.Allocation {
+		a := mock.Alloc()
+		a.Job = job
+		a.JobID = job.ID
+		a.TaskGroup = job.TaskGroups[0].Name
+		a.NodeID = n.ID
+		a.DeploymentStatus = &structs.AllocDeploymentStatus{
+			Healthy: helper.BoolToPtr(true),
+		}
+		return a
+	}
+	lowAlloc := newAlloc(low)
+	highAllocs := []*structs.Allocation{newAlloc(high), newAlloc(high)}
+	require.Nil(state.UpsertAllocs(103, append([]*structs.Allocation{lowAlloc}, highAllocs...)))
+
+	snap, err := state.Snapshot()
+	require.Nil(err)
+
+	// The high priority job waits for the low priority job
+	res := newJobResult()
+	require.Nil(handleTaskGroup(snap, false, high.TaskGroups[0], highAllocs, 102, res))
+	require.Empty(res.drain)
+	require.False(res.done)
+
+	// The low priority job is drained
+	res = newJobResult()
+	require.Nil(handleTaskGroup(snap, false, low.TaskGroups[0], []*structs.Allocation{lowAlloc}, 102, res))
+	require.Len(res.drain, 1)
+
+	// Once the low priority alloc stops the high priority job is drained
+	lowAlloc = lowAlloc.Copy()
+	lowAlloc.ClientStatus = structs.AllocClientStatusComplete
+	require.Nil(state.UpdateAllocsFromClient(104, []*structs.Allocation{lowAlloc}))
+
+	snap, err = state.Snapshot()
+	require.Nil(err)
+
+	res = newJobResult()
+	require.Nil(handleTaskGroup(snap, false, high.TaskGroups[0], highAllocs, 104, res))
+	require.Len(res.drain, 1)
+	require.False(res.done)
+}
+
+// This test asserts that on nodes drained by priority, lower priority batch
+// allocations, which are never migrated, don't hold back other jobs
+func TestHandleTaskGroup_OrderByPriority_Batch(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	// Create a node draining by priority
+	state := state.TestStateStore(t)
+	n := mock.Node()
+	n.DrainStrategy = &structs.DrainStrategy{
+		DrainSpec: structs.DrainSpec{
+			Deadline:        5 * time.Minute,
+			OrderByPriority: true,
+		},
+		ForceDeadline: time.Now().Add(1 * time.Minute),
+	}
+	require.Nil(state.UpsertNode(100, n))
+
+	// Create a low priority batch job and a high priority service job
+	batch := mock.BatchJob()
+	batch.Priority = 20
+	require.Nil(state.UpsertJob(101, batch))
+
+	high := mock.Job()
+	high.Priority = 80
+	high.TaskGroups[0].Count = 2
+	require.Nil(state.UpsertJob(102, high))
+
+	batchAlloc := mock.Alloc()
+	batchAlloc.Job = batch
+	batchAlloc.JobID = batch.ID
+	batchAlloc.TaskGroup = batch.TaskGroups[0].Name
+	batchAlloc.NodeID = n.ID
+	batchAlloc.ClientStatus = structs.AllocClientStatusRunning
+
+	var highAllocs []*structs.Allocation
+	for i := 0; i < 2; i++ {
+		a := mock.Alloc()
+		a.Job = high
+		a.JobID = high.ID
+		a.TaskGroup = high.TaskGroups[0].Name
+		a.NodeID = n.ID
+		a.DeploymentStatus = &structs.AllocDeploymentStatus{
+			Healthy: helper.BoolToPtr(true),
+		}
+		highAllocs = append(highAllocs, a)
+	}
+	require.Nil(state.UpsertAllocs(103, append([]*structs.Allocation{batchAlloc}, highAllocs...)))
+
+	snap, err := state.Snapshot()
+	require.Nil(err)
+
+	// The high priority job is drained while the batch alloc runs
+	res := newJobResult()
+	require.Nil(handleTaskGroup(snap, false, high.TaskGroups[0], highAllocs, 102, res))
+	require.Len(res.drain, 1)
+	require.False(res.done)
+
+	// The batch alloc is left to run until the deadline
+	res = newJobResult()
+	require.Nil(handleTaskGroup(snap, true, batch.TaskGroups[0], []*structs.Allocation{batchAlloc}, 102, res))
+	require.Empty(res.drain)
+	require.False(res.done)
+}
+
 // This test asserts that handle task group works when an allocation is on a
 // garbage collected node
 func TestHandleTaskGroup_GarbageCollectedNode(t *testing.T) {
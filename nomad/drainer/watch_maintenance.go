@@ -0,0 +1,301 @@
+package drainer
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	log "github.com/hashicorp/go-hclog"
+	memdb "github.com/hashicorp/go-memdb"
+
+	"github.com/hashicorp/nomad/nomad/state"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"golang.org/x/time/rate"
+)
+
+const (
+	// NodeDrainEventMaintenancePlan is used to indicate that the node drain
+	// was started by a maintenance plan.
+	NodeDrainEventMaintenancePlan = "Node drain strategy set by maintenance plan"
+
+	// NodeDrainEventDetailMaintenancePlan is the key of the ID of the
+	// maintenance plan starting the node drain
+	NodeDrainEventDetailMaintenancePlan = "maintenance_plan_id"
+)
+
+// maintenancePlanWatcher executes the active maintenance plans. It drains the
+// nodes of the current batch of each plan and moves to the next batch once
+// all the nodes of the batch are drained and marked eligible again.
+type maintenancePlanWatcher struct {
+	ctx    context.Context
+	logger log.Logger
+
+	// state is the state that is watched for state changes.
+	state *state.StateStore
+
+	// limiter is used to limit the rate of blocking queries
+	limiter *rate.Limiter
+
+	// raft is a shim around the raft messages necessary for draining
+	raft RaftApplier
+}
+
+// NewMaintenancePlanWatcher returns a new maintenance plan watcher.
+func NewMaintenancePlanWatcher(ctx context.Context, limiter *rate.Limiter, state *state.StateStore, logger log.Logger, raft RaftApplier) *maintenancePlanWatcher {
+	w := &maintenancePlanWatcher{
+		ctx:     ctx,
+		limiter: limiter,
+		logger:  logger.Named("maintenance_watcher"),
+		state:   state,
+		raft:    raft,
+	}
+
+	go w.watch()
+	return w
+}
+
+// watch is the long lived watching routine that progresses the active
+// maintenance plans whenever a plan or a node changes, or the maintenance
+// window of a pending plan starts.
+func (w *maintenancePlanWatcher) watch() {
+	pindex := uint64(1)
+	var nextStart time.Time
+	for {
+		// Stop blocking once the maintenance window of a pending plan starts
+		ctx, cancel := w.ctx, context.CancelFunc(func() {})
+		if !nextStart.IsZero() {
+			ctx, cancel = context.WithDeadline(w.ctx, nextStart)
+		}
+
+		w.logger.Trace("getting maintenance plans at index", "index", pindex)
+		plans, index, err := w.getPlans(ctx, pindex)
+		windowStarted := ctx.Err() == context.DeadlineExceeded
+		cancel()
+		if err != nil {
+			if w.ctx.Err() != nil {
+				w.logger.Trace("shutting down")
+				return
+			}
+
+			// Reevaluate the plans without blocking
+			if windowStarted {
+				pindex, nextStart = 0, time.Time{}
+				continue
+			}
+
+			w.logger.Error("error watching maintenance plans at index", "index", pindex, "error", err)
+			select {
+			case <-w.ctx.Done():
+				w.logger.Trace("shutting down")
+				return
+			case <-time.After(stateReadErrorDelay):
+				continue
+			}
+		}
+
+		// update index for next run
+		pindex = index
+
+		now := time.Now()
+		nextStart = time.Time{}
+		for _, plan := range plans {
+			if plan.Status == structs.MaintenancePlanStatusPending && now.Before(plan.StartTime) {
+				if nextStart.IsZero() || plan.StartTime.Before(nextStart) {
+					nextStart = plan.StartTime
+				}
+				continue
+			}
+
+			if err := w.handlePlan(now, plan); err != nil {
+				w.logger.Error("failed to update maintenance plan", "plan_id", plan.ID, "error", err)
+			}
+		}
+	}
+}
+
+// handlePlan progresses an active maintenance plan
+func (w *maintenancePlanWatcher) handlePlan(now time.Time, plan *structs.MaintenancePlan) error {
+	updated, drains, events, err := maintenancePlanUpdate(w.state, now, plan)
+	if err != nil {
+		return err
+	}
+	if updated == nil {
+		w.logger.Trace("no changes for maintenance plan", "plan_id", plan.ID)
+		return nil
+	}
+
+	w.logger.Debug("updating maintenance plan", "plan_id", plan.ID, "status", updated.Status, "num_drains", len(drains))
+	_, err = w.raft.MaintenancePlanUpdate(updated, drains, events)
+	return err
+}
+
+// getPlans returns the active maintenance plans, blocking until the plans or
+// the nodes are after the given index.
+func (w *maintenancePlanWatcher) getPlans(ctx context.Context, minIndex uint64) ([]*structs.MaintenancePlan, uint64, error) {
+	if err := w.limiter.Wait(ctx); err != nil {
+		return nil, 0, err
+	}
+
+	resp, index, err := w.state.BlockingQuery(w.getPlansImpl, minIndex, ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return resp.([]*structs.MaintenancePlan), index, nil
+}
+
+// getPlansImpl is used to get the active maintenance plans from the state
+// store, watching the nodes of their current batch.
+func (w *maintenancePlanWatcher) getPlansImpl(ws memdb.WatchSet, state *state.StateStore) (interface{}, uint64, error) {
+	iter, err := state.MaintenancePlans(ws)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var resp []*structs.MaintenancePlan
+	for {
+		raw := iter.Next()
+		if raw == nil {
+			break
+		}
+
+		plan := raw.(*structs.MaintenancePlan)
+		if !plan.Active() {
+			continue
+		}
+		resp = append(resp, plan)
+
+		// Watch the nodes of the current batch
+		batch := plan.CurrentBatch()
+		for _, n := range plan.Nodes {
+			if n.Batch != batch {
+				continue
+			}
+			if _, err := state.NodeByID(ws, n.NodeID); err != nil {
+				return nil, 0, err
+			}
+		}
+	}
+
+	index, err := state.Index("maintenance_plans")
+	if err != nil {
+		return nil, 0, err
+	}
+	nodeIndex, err := state.Index("nodes")
+	if err != nil {
+		return nil, 0, err
+	}
+	if nodeIndex > index {
+		index = nodeIndex
+	}
+
+	return resp, index, nil
+}
+
+// maintenancePlanUpdate computes the update of an active maintenance plan
+// given the state of its nodes. It returns the updated copy of the plan along
+// with the drains to start on the nodes of the current batch, or a nil plan
+// if nothing changed.
+func maintenancePlanUpdate(state *state.StateStore, now time.Time, plan *structs.MaintenancePlan) (
+	*structs.MaintenancePlan, map[string]*structs.DrainUpdate, map[string]*structs.NodeEvent, error) {
+
+	updated := plan.Copy()
+	changed := false
+	if updated.Status == structs.MaintenancePlanStatusPending {
+		if now.Before(updated.StartTime) {
+			return nil, nil, nil, nil
+		}
+		updated.Status = structs.MaintenancePlanStatusRunning
+		changed = true
+	}
+
+	drains := make(map[string]*structs.DrainUpdate)
+	events := make(map[string]*structs.NodeEvent)
+	for {
+		batch := updated.CurrentBatch()
+		if batch == -1 {
+			updated.Status = structs.MaintenancePlanStatusComplete
+			updated.StatusDescription = structs.MaintenancePlanDescriptionComplete
+			changed = true
+			break
+		}
+
+		done := true
+		for _, n := range updated.Nodes {
+			if n.Batch != batch || n.Done() {
+				continue
+			}
+
+			node, err := state.NodeByID(nil, n.NodeID)
+			if err != nil {
+				return nil, nil, nil, err
+			}
+
+			// Skip the nodes removed from the cluster
+			if node == nil {
+				n.Status = structs.MaintenanceNodeStatusSkipped
+				changed = true
+				continue
+			}
+
+			status := n.Status
+			switch {
+			case status == structs.MaintenanceNodeStatusPending:
+				// Nodes already draining are left with their drain strategy
+				if node.DrainStrategy == nil {
+					drains[node.ID] = &structs.DrainUpdate{
+						DrainStrategy: maintenanceDrainStrategy(now, updated),
+					}
+					events[node.ID] = structs.NewNodeEvent().
+						SetSubsystem(structs.NodeEventSubsystemDrain).
+						SetMessage(NodeDrainEventMaintenancePlan).
+						AddDetail(NodeDrainEventDetailMaintenancePlan, updated.ID)
+				}
+				status = structs.MaintenanceNodeStatusDraining
+			case node.DrainStrategy != nil:
+				status = structs.MaintenanceNodeStatusDraining
+			case node.SchedulingEligibility == structs.NodeSchedulingEligible:
+				status = structs.MaintenanceNodeStatusComplete
+			default:
+				status = structs.MaintenanceNodeStatusDrained
+			}
+
+			if status != n.Status {
+				n.Status = status
+				changed = true
+			}
+			if !n.Done() {
+				done = false
+			}
+		}
+
+		if !done {
+			desc := fmt.Sprintf("Draining batch %d of %d", batch+1, updated.Batches())
+			if desc != updated.StatusDescription {
+				updated.StatusDescription = desc
+				changed = true
+			}
+			break
+		}
+	}
+
+	if !changed {
+		return nil, nil, nil, nil
+	}
+	return updated, drains, events, nil
+}
+
+// maintenanceDrainStrategy returns the drain strategy of the nodes drained by
+// a maintenance plan
+func maintenanceDrainStrategy(now time.Time, plan *structs.MaintenancePlan) *structs.DrainStrategy {
+	strategy := &structs.DrainStrategy{
+		DrainSpec: structs.DrainSpec{
+			Deadline:         plan.Deadline,
+			IgnoreSystemJobs: plan.IgnoreSystemJobs,
+		},
+	}
+	if plan.Deadline > 0 {
+		strategy.ForceDeadline = now.Add(plan.Deadline)
+	}
+	return strategy
+}
@@ -0,0 +1,126 @@
+package drainer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/state"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMaintenancePlanUpdate(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	state := state.TestStateStore(t)
+
+	// Plan the maintenance of three nodes, two at a time
+	n1, n2, n3 := mock.Node(), mock.Node(), mock.Node()
+	plan := mock.MaintenancePlan()
+	plan.BatchSize = 2
+	plan.StartTime = time.Now().Add(time.Hour)
+	for i, node := range []*structs.Node{n1, n2, n3} {
+		require.Nil(state.UpsertNode(uint64(100+i), node))
+		plan.Nodes = append(plan.Nodes, &structs.MaintenancePlanNode{
+			NodeID: node.ID,
+			Batch:  i / plan.BatchSize,
+			Status: structs.MaintenanceNodeStatusPending,
+		})
+	}
+	require.Nil(state.UpsertMaintenancePlan(200, plan, nil, nil))
+
+	// applyUpdate computes the update of the plan and applies it
+	applyUpdate := func(index uint64, now time.Time) (*structs.MaintenancePlan, map[string]*structs.DrainUpdate) {
+		t.Helper()
+		current, err := state.MaintenancePlanByID(nil, plan.ID)
+		require.Nil(err)
+		updated, drains, events, err := maintenancePlanUpdate(state, now, current)
+		require.Nil(err)
+		if updated != nil {
+			require.Nil(state.UpsertMaintenancePlan(index, updated, drains, events))
+		}
+		return updated, drains
+	}
+
+	// Nothing happens before the maintenance window
+	updated, _ := applyUpdate(201, time.Now())
+	require.Nil(updated)
+
+	// The first batch is drained once the window starts
+	now := plan.StartTime.Add(time.Second)
+	updated, drains := applyUpdate(202, now)
+	require.NotNil(updated)
+	require.Equal(structs.MaintenancePlanStatusRunning, updated.Status)
+	require.Equal("Draining batch 1 of 2", updated.StatusDescription)
+	require.Len(drains, 2)
+	require.Contains(drains, n1.ID)
+	require.Contains(drains, n2.ID)
+	require.Equal(now.Add(time.Hour), drains[n1.ID].DrainStrategy.ForceDeadline)
+	require.Equal(structs.MaintenanceNodeStatusDraining, updated.Nodes[0].Status)
+	require.Equal(structs.MaintenanceNodeStatusDraining, updated.Nodes[1].Status)
+	require.Equal(structs.MaintenanceNodeStatusPending, updated.Nodes[2].Status)
+
+	out, err := state.NodeByID(nil, n1.ID)
+	require.Nil(err)
+	require.NotNil(out.DrainStrategy)
+	require.Equal(NodeDrainEventMaintenancePlan, out.Events[len(out.Events)-1].Message)
+
+	// Nothing changes while the nodes are draining
+	updated, _ = applyUpdate(203, now)
+	require.Nil(updated)
+
+	// The first node is drained but not eligible yet
+	require.Nil(state.UpdateNodeDrain(204, n1.ID, nil, false, nil))
+	updated, drains = applyUpdate(205, now)
+	require.NotNil(updated)
+	require.Empty(drains)
+	require.Equal(structs.MaintenanceNodeStatusDrained, updated.Nodes[0].Status)
+
+	// The second batch starts once both nodes are eligible again
+	require.Nil(state.UpdateNodeEligibility(206, n1.ID, structs.NodeSchedulingEligible, nil))
+	require.Nil(state.UpdateNodeDrain(207, n2.ID, nil, true, nil))
+	updated, drains = applyUpdate(208, now)
+	require.NotNil(updated)
+	require.Equal("Draining batch 2 of 2", updated.StatusDescription)
+	require.Equal(structs.MaintenanceNodeStatusComplete, updated.Nodes[0].Status)
+	require.Equal(structs.MaintenanceNodeStatusComplete, updated.Nodes[1].Status)
+	require.Equal(structs.MaintenanceNodeStatusDraining, updated.Nodes[2].Status)
+	require.Len(drains, 1)
+	require.Contains(drains, n3.ID)
+
+	// The plan completes once the last node is removed from the cluster
+	require.Nil(state.DeleteNode(209, n3.ID))
+	updated, _ = applyUpdate(210, now)
+	require.NotNil(updated)
+	require.Equal(structs.MaintenanceNodeStatusSkipped, updated.Nodes[2].Status)
+	require.Equal(structs.MaintenancePlanStatusComplete, updated.Status)
+	require.Equal(structs.MaintenancePlanDescriptionComplete, updated.StatusDescription)
+}
+
+func TestMaintenancePlanUpdate_AlreadyDraining(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	state := state.TestStateStore(t)
+
+	// A node already draining keeps its drain strategy
+	node := mock.Node()
+	node.DrainStrategy = &structs.DrainStrategy{
+		DrainSpec: structs.DrainSpec{
+			Deadline: -1,
+		},
+	}
+	require.Nil(state.UpsertNode(100, node))
+
+	plan := mock.MaintenancePlan()
+	plan.Nodes = []*structs.MaintenancePlanNode{
+		{NodeID: node.ID, Status: structs.MaintenanceNodeStatusPending},
+	}
+
+	updated, drains, events, err := maintenancePlanUpdate(state, time.Now(), plan)
+	require.Nil(err)
+	require.NotNil(updated)
+	require.Empty(drains)
+	require.Empty(events)
+	require.Equal(structs.MaintenanceNodeStatusDraining, updated.Nodes[0].Status)
+}
@@ -37,6 +37,17 @@ func (d drainerShim) AllocUpdateDesiredTransition(allocs map[string]*structs.Des
 	return d.convertApplyErrors(resp, index, err)
 }
 
+func (d drainerShim) MaintenancePlanUpdate(plan *structs.MaintenancePlan, drains map[string]*structs.DrainUpdate, events map[string]*structs.NodeEvent) (uint64, error) {
+	args := &structs.MaintenancePlanUpsertRequest{
+		Plan:         plan,
+		NodeDrains:   drains,
+		NodeEvents:   events,
+		WriteRequest: structs.WriteRequest{Region: d.s.config.Region},
+	}
+	resp, index, err := d.s.raftApply(structs.MaintenancePlanUpsertRequestType, args)
+	return d.convertApplyErrors(resp, index, err)
+}
+
 // convertApplyErrors parses the results of a raftApply and returns the index at
 // which it was applied and any error that occurred. Raft Apply returns two
 // separate errors, Raft library errors and user returned errors from the FSM.
@@ -45,6 +45,14 @@ const (
 	ACLPolicySnapshot
 	ACLTokenSnapshot
 	SchedulerConfigSnapshot
+	ACLRoleSnapshot
+	ACLAuthMethodSnapshot
+	ACLBindingRuleSnapshot
+	RootKeySnapshot
+	VariablesSnapshot
+	DispatchBlobSnapshot
+	DispatchBlobChunkSnapshot
+	MaintenancePlanSnapshot
 )
 
 // LogApplier is the definition of a function that can apply a Raft log
@@ -229,6 +237,24 @@ func (n *nomadFSM) Apply(log *raft.Log) interface{} {
 		return n.applyACLPolicyUpsert(buf[1:], log.Index)
 	case structs.ACLPolicyDeleteRequestType:
 		return n.applyACLPolicyDelete(buf[1:], log.Index)
+	case structs.ACLRoleUpsertRequestType:
+		return n.applyACLRoleUpsert(buf[1:], log.Index)
+	case structs.ACLRoleDeleteRequestType:
+		return n.applyACLRoleDelete(buf[1:], log.Index)
+	case structs.ACLAuthMethodUpsertRequestType:
+		return n.applyACLAuthMethodUpsert(buf[1:], log.Index)
+	case structs.ACLAuthMethodDeleteRequestType:
+		return n.applyACLAuthMethodDelete(buf[1:], log.Index)
+	case structs.ACLBindingRuleUpsertRequestType:
+		return n.applyACLBindingRuleUpsert(buf[1:], log.Index)
+	case structs.ACLBindingRuleDeleteRequestType:
+		return n.applyACLBindingRuleDelete(buf[1:], log.Index)
+	case structs.RootKeyUpsertRequestType:
+		return n.applyRootKeyUpsert(buf[1:], log.Index)
+	case structs.RootKeyDeleteRequestType:
+		return n.applyRootKeyDelete(buf[1:], log.Index)
+	case structs.VarApplyStateRequestType:
+		return n.applyVariableOperation(buf[1:], log.Index)
 	case structs.ACLTokenUpsertRequestType:
 		return n.applyACLTokenUpsert(buf[1:], log.Index)
 	case structs.ACLTokenDeleteRequestType:
@@ -249,6 +275,20 @@ func (n *nomadFSM) Apply(log *raft.Log) interface{} {
 		return n.applyBatchDrainUpdate(buf[1:], log.Index)
 	case structs.SchedulerConfigRequestType:
 		return n.applySchedulerConfigUpdate(buf[1:], log.Index)
+	case structs.PeriodicLaunchEventsRequestType:
+		return n.applyPeriodicLaunchEvents(buf[1:], log.Index)
+	case structs.DispatchBlobChunkUpsertRequestType:
+		return n.applyDispatchBlobChunkUpsert(buf[1:], log.Index)
+	case structs.DispatchBlobUpsertRequestType:
+		return n.applyDispatchBlobUpsert(buf[1:], log.Index)
+	case structs.DispatchBlobDeleteRequestType:
+		return n.applyDispatchBlobDelete(buf[1:], log.Index)
+	case structs.JobDispatchReleaseRequestType:
+		return n.applyJobDispatchRelease(buf[1:], log.Index)
+	case structs.MaintenancePlanUpsertRequestType:
+		return n.applyMaintenancePlanUpsert(buf[1:], log.Index)
+	case structs.DeploymentAnalysisUpdateRequestType:
+		return n.applyDeploymentAnalysisUpdate(buf[1:], log.Index)
 	}
 
 	// Check enterprise only message types.
@@ -418,6 +458,16 @@ func (n *nomadFSM) applyUpsertJob(buf []byte, index uint64) interface{} {
 	 */
 	req.Job.Canonicalize()
 
+	// Create a watch set
+	ws := memdb.NewWatchSet()
+
+	// Lookup the existing job to detect a periodic job being re-enabled.
+	existingJob, err := n.state.JobByID(ws, req.Namespace, req.Job.ID)
+	if err != nil {
+		n.logger.Error("JobByID lookup failed", "error", err)
+		return err
+	}
+
 	if err := n.state.UpsertJob(index, req.Job); err != nil {
 		n.logger.Error("UpsertJob failed", "error", err)
 		return err
@@ -431,9 +481,6 @@ func (n *nomadFSM) applyUpsertJob(buf []byte, index uint64) interface{} {
 		return fmt.Errorf("failed adding job to periodic dispatcher: %v", err)
 	}
 
-	// Create a watch set
-	ws := memdb.NewWatchSet()
-
 	// If it is an active periodic job, record the time it was inserted. This is
 	// necessary for recovering during leader election. It is possible that from
 	// the time it is added to when it was suppose to launch, leader election
@@ -458,6 +505,11 @@ func (n *nomadFSM) applyUpsertJob(buf []byte, index uint64) interface{} {
 				n.logger.Error("UpsertPeriodicLaunch failed", "error", err)
 				return err
 			}
+		} else if existingJob != nil && !existingJob.IsPeriodicActive() {
+			// The job was re-enabled so catch up on the launches missed while
+			// it was disabled. Launching goes through Raft, so the leader's
+			// dispatcher does it outside of the FSM.
+			n.periodicDispatcher.QueueCatchUp(req.Job, prevLaunch.Launch)
 		}
 	}
 
@@ -480,11 +532,24 @@ func (n *nomadFSM) applyUpsertJob(buf []byte, index uint64) interface{} {
 				return err
 			}
 
+			prevLaunch, err := n.state.PeriodicLaunchByID(ws, req.Namespace, parentID)
+			if err != nil {
+				n.logger.Error("PeriodicLaunchByID failed", "error", err)
+				return err
+			}
+
 			launch := &structs.PeriodicLaunch{
 				ID:        parentID,
 				Namespace: req.Namespace,
-				Launch:    t,
 			}
+			if prevLaunch != nil {
+				launch = prevLaunch.Copy()
+			}
+			launch.Launch = t
+			launch.AddEvents(&structs.PeriodicLaunchEvent{
+				Launch: t,
+				Status: structs.PeriodicLaunchStatusLaunched,
+			})
 			if err := n.state.UpsertPeriodicLaunch(index, launch); err != nil {
 				n.logger.Error("UpsertPeriodicLaunch failed", "error", err)
 				return err
@@ -894,6 +959,23 @@ func (n *nomadFSM) applyDeploymentAllocHealth(buf []byte, index uint64) interfac
 	return nil
 }
 
+// applyDeploymentAnalysisUpdate is used to record the consecutive failed
+// metric analyses of a task group of a deployment
+func (n *nomadFSM) applyDeploymentAnalysisUpdate(buf []byte, index uint64) interface{} {
+	defer metrics.MeasureSince([]string{"nomad", "fsm", "apply_deployment_analysis_update"}, time.Now())
+	var req structs.DeploymentAnalysisUpdateRequest
+	if err := structs.Decode(buf, &req); err != nil {
+		panic(fmt.Errorf("failed to decode request: %v", err))
+	}
+
+	if err := n.state.UpdateDeploymentAnalysis(index, &req); err != nil {
+		n.logger.Error("UpdateDeploymentAnalysis failed", "error", err)
+		return err
+	}
+
+	return nil
+}
+
 // applyDeploymentDelete is used to delete a set of deployments
 func (n *nomadFSM) applyDeploymentDelete(buf []byte, index uint64) interface{} {
 	defer metrics.MeasureSince([]string{"nomad", "fsm", "apply_deployment_delete"}, time.Now())
@@ -956,6 +1038,143 @@ func (n *nomadFSM) applyACLPolicyDelete(buf []byte, index uint64) interface{} {
 	return nil
 }
 
+// applyACLRoleUpsert is used to upsert a set of roles
+func (n *nomadFSM) applyACLRoleUpsert(buf []byte, index uint64) interface{} {
+	defer metrics.MeasureSince([]string{"nomad", "fsm", "apply_acl_role_upsert"}, time.Now())
+	var req structs.ACLRoleUpsertRequest
+	if err := structs.Decode(buf, &req); err != nil {
+		panic(fmt.Errorf("failed to decode request: %v", err))
+	}
+
+	if err := n.state.UpsertACLRoles(index, req.Roles); err != nil {
+		n.logger.Error("UpsertACLRoles failed", "error", err)
+		return err
+	}
+	return nil
+}
+
+// applyACLRoleDelete is used to delete a set of roles
+func (n *nomadFSM) applyACLRoleDelete(buf []byte, index uint64) interface{} {
+	defer metrics.MeasureSince([]string{"nomad", "fsm", "apply_acl_role_delete"}, time.Now())
+	var req structs.ACLRoleDeleteRequest
+	if err := structs.Decode(buf, &req); err != nil {
+		panic(fmt.Errorf("failed to decode request: %v", err))
+	}
+
+	if err := n.state.DeleteACLRoles(index, req.Names); err != nil {
+		n.logger.Error("DeleteACLRoles failed", "error", err)
+		return err
+	}
+	return nil
+}
+
+// applyACLAuthMethodUpsert is used to upsert a set of auth methods
+func (n *nomadFSM) applyACLAuthMethodUpsert(buf []byte, index uint64) interface{} {
+	defer metrics.MeasureSince([]string{"nomad", "fsm", "apply_acl_auth_method_upsert"}, time.Now())
+	var req structs.ACLAuthMethodUpsertRequest
+	if err := structs.Decode(buf, &req); err != nil {
+		panic(fmt.Errorf("failed to decode request: %v", err))
+	}
+
+	if err := n.state.UpsertACLAuthMethods(index, req.AuthMethods); err != nil {
+		n.logger.Error("UpsertACLAuthMethods failed", "error", err)
+		return err
+	}
+	return nil
+}
+
+// applyACLAuthMethodDelete is used to delete a set of auth methods
+func (n *nomadFSM) applyACLAuthMethodDelete(buf []byte, index uint64) interface{} {
+	defer metrics.MeasureSince([]string{"nomad", "fsm", "apply_acl_auth_method_delete"}, time.Now())
+	var req structs.ACLAuthMethodDeleteRequest
+	if err := structs.Decode(buf, &req); err != nil {
+		panic(fmt.Errorf("failed to decode request: %v", err))
+	}
+
+	if err := n.state.DeleteACLAuthMethods(index, req.Names); err != nil {
+		n.logger.Error("DeleteACLAuthMethods failed", "error", err)
+		return err
+	}
+	return nil
+}
+
+// applyACLBindingRuleUpsert is used to upsert a set of binding rules
+func (n *nomadFSM) applyACLBindingRuleUpsert(buf []byte, index uint64) interface{} {
+	defer metrics.MeasureSince([]string{"nomad", "fsm", "apply_acl_binding_rule_upsert"}, time.Now())
+	var req structs.ACLBindingRuleUpsertRequest
+	if err := structs.Decode(buf, &req); err != nil {
+		panic(fmt.Errorf("failed to decode request: %v", err))
+	}
+
+	if err := n.state.UpsertACLBindingRules(index, req.BindingRules); err != nil {
+		n.logger.Error("UpsertACLBindingRules failed", "error", err)
+		return err
+	}
+	return nil
+}
+
+// applyACLBindingRuleDelete is used to delete a set of binding rules
+func (n *nomadFSM) applyACLBindingRuleDelete(buf []byte, index uint64) interface{} {
+	defer metrics.MeasureSince([]string{"nomad", "fsm", "apply_acl_binding_rule_delete"}, time.Now())
+	var req structs.ACLBindingRuleDeleteRequest
+	if err := structs.Decode(buf, &req); err != nil {
+		panic(fmt.Errorf("failed to decode request: %v", err))
+	}
+
+	if err := n.state.DeleteACLBindingRules(index, req.IDs); err != nil {
+		n.logger.Error("DeleteACLBindingRules failed", "error", err)
+		return err
+	}
+	return nil
+}
+
+// applyRootKeyUpsert is used to upsert a root key of the keyring
+func (n *nomadFSM) applyRootKeyUpsert(buf []byte, index uint64) interface{} {
+	defer metrics.MeasureSince([]string{"nomad", "fsm", "apply_root_key_upsert"}, time.Now())
+	var req structs.KeyringUpsertRootKeyRequest
+	if err := structs.Decode(buf, &req); err != nil {
+		panic(fmt.Errorf("failed to decode request: %v", err))
+	}
+
+	if err := n.state.UpsertRootKey(index, req.RootKey); err != nil {
+		n.logger.Error("UpsertRootKey failed", "error", err)
+		return err
+	}
+	return nil
+}
+
+// applyRootKeyDelete is used to delete a set of root keys of the keyring
+func (n *nomadFSM) applyRootKeyDelete(buf []byte, index uint64) interface{} {
+	defer metrics.MeasureSince([]string{"nomad", "fsm", "apply_root_key_delete"}, time.Now())
+	var req structs.KeyringDeleteRootKeyRequest
+	if err := structs.Decode(buf, &req); err != nil {
+		panic(fmt.Errorf("failed to decode request: %v", err))
+	}
+
+	if err := n.state.DeleteRootKeys(index, req.KeyIDs); err != nil {
+		n.logger.Error("DeleteRootKeys failed", "error", err)
+		return err
+	}
+	return nil
+}
+
+// applyVariableOperation is used to upsert or delete a variable. It returns
+// the result of the operation, which may be a check-and-set conflict.
+func (n *nomadFSM) applyVariableOperation(buf []byte, index uint64) interface{} {
+	defer metrics.MeasureSince([]string{"nomad", "fsm", "apply_variable_operation"}, time.Now())
+	var req structs.VarApplyStateRequest
+	if err := structs.Decode(buf, &req); err != nil {
+		panic(fmt.Errorf("failed to decode request: %v", err))
+	}
+
+	resp, err := n.state.VarSet(index, &req)
+	if err != nil {
+		n.logger.Error("VarSet failed", "error", err)
+		return err
+	}
+	return resp
+}
+
 // applyACLTokenUpsert is used to upsert a set of policies
 func (n *nomadFSM) applyACLTokenUpsert(buf []byte, index uint64) interface{} {
 	defer metrics.MeasureSince([]string{"nomad", "fsm", "apply_acl_token_upsert"}, time.Now())
@@ -1035,6 +1254,108 @@ func (n *nomadFSM) applySchedulerConfigUpdate(buf []byte, index uint64) interfac
 	return n.state.SchedulerSetConfig(index, &req.Config)
 }
 
+// applyPeriodicLaunchEvents is used to record launch events of a periodic job
+func (n *nomadFSM) applyPeriodicLaunchEvents(buf []byte, index uint64) interface{} {
+	defer metrics.MeasureSince([]string{"nomad", "fsm", "apply_periodic_launch_events"}, time.Now())
+	var req structs.PeriodicLaunchEventsRequest
+	if err := structs.Decode(buf, &req); err != nil {
+		panic(fmt.Errorf("failed to decode request: %v", err))
+	}
+
+	if err := n.state.UpsertPeriodicLaunchEvents(index, req.Namespace, req.JobID, req.Events); err != nil {
+		n.logger.Error("UpsertPeriodicLaunchEvents failed", "error", err)
+		return err
+	}
+	return nil
+}
+
+// applyDispatchBlobChunkUpsert is used to write a chunk of a dispatch blob
+func (n *nomadFSM) applyDispatchBlobChunkUpsert(buf []byte, index uint64) interface{} {
+	defer metrics.MeasureSince([]string{"nomad", "fsm", "apply_dispatch_blob_chunk_upsert"}, time.Now())
+	var req structs.DispatchBlobChunkUpsertRequest
+	if err := structs.Decode(buf, &req); err != nil {
+		panic(fmt.Errorf("failed to decode request: %v", err))
+	}
+
+	if err := n.state.UpsertDispatchBlobChunk(index, req.Chunk); err != nil {
+		n.logger.Error("UpsertDispatchBlobChunk failed", "error", err)
+		return err
+	}
+	return nil
+}
+
+// applyDispatchBlobUpsert is used to upsert a dispatch blob
+func (n *nomadFSM) applyDispatchBlobUpsert(buf []byte, index uint64) interface{} {
+	defer metrics.MeasureSince([]string{"nomad", "fsm", "apply_dispatch_blob_upsert"}, time.Now())
+	var req structs.DispatchBlobUpsertRequest
+	if err := structs.Decode(buf, &req); err != nil {
+		panic(fmt.Errorf("failed to decode request: %v", err))
+	}
+
+	if err := n.state.UpsertDispatchBlob(index, req.Blob); err != nil {
+		n.logger.Error("UpsertDispatchBlob failed", "error", err)
+		return err
+	}
+	return nil
+}
+
+// applyDispatchBlobDelete is used to delete a set of dispatch blobs
+func (n *nomadFSM) applyDispatchBlobDelete(buf []byte, index uint64) interface{} {
+	defer metrics.MeasureSince([]string{"nomad", "fsm", "apply_dispatch_blob_delete"}, time.Now())
+	var req structs.DispatchBlobDeleteRequest
+	if err := structs.Decode(buf, &req); err != nil {
+		panic(fmt.Errorf("failed to decode request: %v", err))
+	}
+
+	if err := n.state.DeleteDispatchBlobs(index, req.BlobIDs); err != nil {
+		n.logger.Error("DeleteDispatchBlobs failed", "error", err)
+		return err
+	}
+	return nil
+}
+
+// applyJobDispatchRelease is used to release queued dispatched jobs and
+// enqueue the evaluations scheduling them
+func (n *nomadFSM) applyJobDispatchRelease(buf []byte, index uint64) interface{} {
+	defer metrics.MeasureSince([]string{"nomad", "fsm", "apply_job_dispatch_release"}, time.Now())
+	var req structs.JobDispatchReleaseRequest
+	if err := structs.Decode(buf, &req); err != nil {
+		panic(fmt.Errorf("failed to decode request: %v", err))
+	}
+
+	if err := n.state.ReleaseDispatchedJobs(index, req.Jobs, req.Evals); err != nil {
+		n.logger.Error("ReleaseDispatchedJobs failed", "error", err)
+		return err
+	}
+
+	// Only handle the evaluations of the jobs that were still queued
+	for _, eval := range req.Evals {
+		existing, err := n.state.EvalByID(nil, eval.ID)
+		if err != nil {
+			n.logger.Error("looking up released eval failed", "eval_id", eval.ID, "error", err)
+			return err
+		}
+		n.handleUpsertedEval(existing)
+	}
+	return nil
+}
+
+// applyMaintenancePlanUpsert is used to write a maintenance plan and start
+// the drains of its nodes
+func (n *nomadFSM) applyMaintenancePlanUpsert(buf []byte, index uint64) interface{} {
+	defer metrics.MeasureSince([]string{"nomad", "fsm", "apply_maintenance_plan_upsert"}, time.Now())
+	var req structs.MaintenancePlanUpsertRequest
+	if err := structs.Decode(buf, &req); err != nil {
+		panic(fmt.Errorf("failed to decode request: %v", err))
+	}
+
+	if err := n.state.UpsertMaintenancePlan(index, req.Plan, req.NodeDrains, req.NodeEvents); err != nil {
+		n.logger.Error("UpsertMaintenancePlan failed", "error", err)
+		return err
+	}
+	return nil
+}
+
 func (n *nomadFSM) Snapshot() (raft.FSMSnapshot, error) {
 	// Create a new snapshot
 	snap, err := n.state.Snapshot()
@@ -1246,6 +1567,78 @@ func (n *nomadFSM) Restore(old io.ReadCloser) error {
 				return err
 			}
 
+		case ACLRoleSnapshot:
+			role := new(structs.ACLRole)
+			if err := dec.Decode(role); err != nil {
+				return err
+			}
+			if err := restore.ACLRoleRestore(role); err != nil {
+				return err
+			}
+
+		case ACLAuthMethodSnapshot:
+			method := new(structs.ACLAuthMethod)
+			if err := dec.Decode(method); err != nil {
+				return err
+			}
+			if err := restore.ACLAuthMethodRestore(method); err != nil {
+				return err
+			}
+
+		case ACLBindingRuleSnapshot:
+			rule := new(structs.ACLBindingRule)
+			if err := dec.Decode(rule); err != nil {
+				return err
+			}
+			if err := restore.ACLBindingRuleRestore(rule); err != nil {
+				return err
+			}
+
+		case RootKeySnapshot:
+			key := new(structs.RootKey)
+			if err := dec.Decode(key); err != nil {
+				return err
+			}
+			if err := restore.RootKeyRestore(key); err != nil {
+				return err
+			}
+
+		case VariablesSnapshot:
+			v := new(structs.VariableEncrypted)
+			if err := dec.Decode(v); err != nil {
+				return err
+			}
+			if err := restore.VariableRestore(v); err != nil {
+				return err
+			}
+
+		case DispatchBlobSnapshot:
+			blob := new(structs.DispatchBlob)
+			if err := dec.Decode(blob); err != nil {
+				return err
+			}
+			if err := restore.DispatchBlobRestore(blob); err != nil {
+				return err
+			}
+
+		case DispatchBlobChunkSnapshot:
+			chunk := new(structs.DispatchBlobChunk)
+			if err := dec.Decode(chunk); err != nil {
+				return err
+			}
+			if err := restore.DispatchBlobChunkRestore(chunk); err != nil {
+				return err
+			}
+
+		case MaintenancePlanSnapshot:
+			plan := new(structs.MaintenancePlan)
+			if err := dec.Decode(plan); err != nil {
+				return err
+			}
+			if err := restore.MaintenancePlanRestore(plan); err != nil {
+				return err
+			}
+
 		case ACLTokenSnapshot:
 			token := new(structs.ACLToken)
 			if err := dec.Decode(token); err != nil {
@@ -1548,10 +1941,38 @@ func (s *nomadSnapshot) Persist(sink raft.SnapshotSink) error {
 		sink.Cancel()
 		return err
 	}
+	if err := s.persistACLRoles(sink, encoder); err != nil {
+		sink.Cancel()
+		return err
+	}
+	if err := s.persistACLAuthMethods(sink, encoder); err != nil {
+		sink.Cancel()
+		return err
+	}
+	if err := s.persistACLBindingRules(sink, encoder); err != nil {
+		sink.Cancel()
+		return err
+	}
 	if err := s.persistACLTokens(sink, encoder); err != nil {
 		sink.Cancel()
 		return err
 	}
+	if err := s.persistRootKeys(sink, encoder); err != nil {
+		sink.Cancel()
+		return err
+	}
+	if err := s.persistVariables(sink, encoder); err != nil {
+		sink.Cancel()
+		return err
+	}
+	if err := s.persistDispatchBlobs(sink, encoder); err != nil {
+		sink.Cancel()
+		return err
+	}
+	if err := s.persistMaintenancePlans(sink, encoder); err != nil {
+		sink.Cancel()
+		return err
+	}
 	if err := s.persistEnterpriseTables(sink, encoder); err != nil {
 		sink.Cancel()
 		return err
@@ -1864,6 +2285,90 @@ func (s *nomadSnapshot) persistACLPolicies(sink raft.SnapshotSink,
 	return nil
 }
 
+func (s *nomadSnapshot) persistACLRoles(sink raft.SnapshotSink,
+	encoder *codec.Encoder) error {
+	// Get all the roles
+	ws := memdb.NewWatchSet()
+	roles, err := s.snap.ACLRoles(ws)
+	if err != nil {
+		return err
+	}
+
+	for {
+		// Get the next item
+		raw := roles.Next()
+		if raw == nil {
+			break
+		}
+
+		// Prepare the request struct
+		role := raw.(*structs.ACLRole)
+
+		// Write out a role registration
+		sink.Write([]byte{byte(ACLRoleSnapshot)})
+		if err := encoder.Encode(role); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *nomadSnapshot) persistACLAuthMethods(sink raft.SnapshotSink,
+	encoder *codec.Encoder) error {
+	// Get all the auth methods
+	ws := memdb.NewWatchSet()
+	methods, err := s.snap.ACLAuthMethods(ws)
+	if err != nil {
+		return err
+	}
+
+	for {
+		// Get the next item
+		raw := methods.Next()
+		if raw == nil {
+			break
+		}
+
+		// Prepare the request struct
+		method := raw.(*structs.ACLAuthMethod)
+
+		// Write out an auth method registration
+		sink.Write([]byte{byte(ACLAuthMethodSnapshot)})
+		if err := encoder.Encode(method); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *nomadSnapshot) persistACLBindingRules(sink raft.SnapshotSink,
+	encoder *codec.Encoder) error {
+	// Get all the binding rules
+	ws := memdb.NewWatchSet()
+	rules, err := s.snap.ACLBindingRules(ws)
+	if err != nil {
+		return err
+	}
+
+	for {
+		// Get the next item
+		raw := rules.Next()
+		if raw == nil {
+			break
+		}
+
+		// Prepare the request struct
+		rule := raw.(*structs.ACLBindingRule)
+
+		// Write out a binding rule registration
+		sink.Write([]byte{byte(ACLBindingRuleSnapshot)})
+		if err := encoder.Encode(rule); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (s *nomadSnapshot) persistACLTokens(sink raft.SnapshotSink,
 	encoder *codec.Encoder) error {
 	// Get all the policies
@@ -1892,6 +2397,141 @@ func (s *nomadSnapshot) persistACLTokens(sink raft.SnapshotSink,
 	return nil
 }
 
+func (s *nomadSnapshot) persistRootKeys(sink raft.SnapshotSink,
+	encoder *codec.Encoder) error {
+	// Get all the root keys
+	ws := memdb.NewWatchSet()
+	keys, err := s.snap.RootKeys(ws)
+	if err != nil {
+		return err
+	}
+
+	for {
+		// Get the next item
+		raw := keys.Next()
+		if raw == nil {
+			break
+		}
+
+		// Prepare the request struct
+		key := raw.(*structs.RootKey)
+
+		// Write out a root key registration
+		sink.Write([]byte{byte(RootKeySnapshot)})
+		if err := encoder.Encode(key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *nomadSnapshot) persistVariables(sink raft.SnapshotSink,
+	encoder *codec.Encoder) error {
+	// Get all the variables
+	ws := memdb.NewWatchSet()
+	vars, err := s.snap.Variables(ws)
+	if err != nil {
+		return err
+	}
+
+	for {
+		// Get the next item
+		raw := vars.Next()
+		if raw == nil {
+			break
+		}
+
+		// Prepare the request struct
+		v := raw.(*structs.VariableEncrypted)
+
+		// Write out a variable registration
+		sink.Write([]byte{byte(VariablesSnapshot)})
+		if err := encoder.Encode(v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *nomadSnapshot) persistDispatchBlobs(sink raft.SnapshotSink,
+	encoder *codec.Encoder) error {
+	// Get all the dispatch blobs
+	ws := memdb.NewWatchSet()
+	blobs, err := s.snap.DispatchBlobs(ws)
+	if err != nil {
+		return err
+	}
+
+	for {
+		// Get the next item
+		raw := blobs.Next()
+		if raw == nil {
+			break
+		}
+
+		// Prepare the request struct
+		blob := raw.(*structs.DispatchBlob)
+
+		// Write out a dispatch blob registration
+		sink.Write([]byte{byte(DispatchBlobSnapshot)})
+		if err := encoder.Encode(blob); err != nil {
+			return err
+		}
+	}
+
+	// Get all the chunks of the dispatch blobs
+	chunks, err := s.snap.DispatchBlobChunks(ws)
+	if err != nil {
+		return err
+	}
+
+	for {
+		// Get the next item
+		raw := chunks.Next()
+		if raw == nil {
+			break
+		}
+
+		// Prepare the request struct
+		chunk := raw.(*structs.DispatchBlobChunk)
+
+		// Write out a dispatch blob chunk registration
+		sink.Write([]byte{byte(DispatchBlobChunkSnapshot)})
+		if err := encoder.Encode(chunk); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *nomadSnapshot) persistMaintenancePlans(sink raft.SnapshotSink,
+	encoder *codec.Encoder) error {
+	// Get all the maintenance plans
+	ws := memdb.NewWatchSet()
+	plans, err := s.snap.MaintenancePlans(ws)
+	if err != nil {
+		return err
+	}
+
+	for {
+		// Get the next item
+		raw := plans.Next()
+		if raw == nil {
+			break
+		}
+
+		// Prepare the request struct
+		plan := raw.(*structs.MaintenancePlan)
+
+		// Write out a maintenance plan registration
+		sink.Write([]byte{byte(MaintenancePlanSnapshot)})
+		if err := encoder.Encode(plan); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (s *nomadSnapshot) persistSchedulerConfig(sink raft.SnapshotSink,
 	encoder *codec.Encoder) error {
 	// Get scheduler config
@@ -614,6 +614,202 @@ func TestFSM_RegisterJob(t *testing.T) {
 	}
 }
 
+func TestFSM_RegisterJob_PeriodicReenabled(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	fsm := testFSM(t)
+	dispatcher, m := testPeriodicDispatcher(t)
+	fsm.periodicDispatcher = dispatcher
+
+	now := time.Now().Round(time.Second)
+	job := testPeriodicJob(now.Add(-time.Hour), now.Add(time.Hour))
+	job.Stop = true
+	require.NoError(fsm.State().UpsertJob(1, job))
+	require.NoError(fsm.State().UpsertPeriodicLaunch(2, &structs.PeriodicLaunch{
+		ID:        job.ID,
+		Namespace: job.Namespace,
+		Launch:    now.Add(-2 * time.Hour),
+	}))
+
+	// Re-enable the job
+	job = job.Copy()
+	job.Stop = false
+	req := structs.JobRegisterRequest{
+		Job: job,
+		WriteRequest: structs.WriteRequest{
+			Namespace: job.Namespace,
+		},
+	}
+	buf, err := structs.Encode(structs.JobRegisterRequestType, req)
+	require.NoError(err)
+	require.Nil(fsm.Apply(makeLog(buf)))
+
+	// The launch missed while the job was stopped is caught up
+	testutil.WaitForResult(func() (bool, error) {
+		launches, err := m.LaunchTimes(dispatcher, job.Namespace, job.ID)
+		if err != nil {
+			return false, err
+		}
+		if len(launches) != 1 || !launches[0].Equal(now.Add(-time.Hour)) {
+			return false, fmt.Errorf("bad launches: %v", launches)
+		}
+		return true, nil
+	}, func(err error) {
+		t.Fatal(err)
+	})
+}
+
+func TestFSM_PeriodicLaunchEvents(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	fsm := testFSM(t)
+
+	job := mock.PeriodicJob()
+	now := time.Now().Round(time.Second)
+	req := structs.PeriodicLaunchEventsRequest{
+		JobID: job.ID,
+		Events: []*structs.PeriodicLaunchEvent{
+			{Launch: now, Status: structs.PeriodicLaunchStatusSkipped},
+		},
+		WriteRequest: structs.WriteRequest{
+			Namespace: job.Namespace,
+		},
+	}
+	buf, err := structs.Encode(structs.PeriodicLaunchEventsRequestType, req)
+	require.NoError(err)
+	require.Nil(fsm.Apply(makeLog(buf)))
+
+	out, err := fsm.State().PeriodicLaunchByID(nil, job.Namespace, job.ID)
+	require.NoError(err)
+	require.NotNil(out)
+	require.True(now.Equal(out.Launch))
+	require.Len(out.History, 1)
+	require.Equal(structs.PeriodicLaunchStatusSkipped, out.History[0].Status)
+}
+
+func TestFSM_DispatchBlobs(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	fsm := testFSM(t)
+
+	blob, chunks := structs.NewDispatchBlob([]byte("hello world"))
+
+	// A blob can't be upserted before its chunks
+	buf, err := structs.Encode(structs.DispatchBlobUpsertRequestType, structs.DispatchBlobUpsertRequest{
+		Blob: blob,
+	})
+	require.NoError(err)
+	resp := fsm.Apply(makeLog(buf))
+	require.Error(resp.(error))
+
+	for _, chunk := range chunks {
+		buf, err := structs.Encode(structs.DispatchBlobChunkUpsertRequestType, structs.DispatchBlobChunkUpsertRequest{
+			Chunk: chunk,
+		})
+		require.NoError(err)
+		require.Nil(fsm.Apply(makeLog(buf)))
+	}
+
+	// Retry upserting the blob once its chunks are written
+	require.Nil(fsm.Apply(makeLog(buf)))
+
+	out, err := fsm.State().DispatchBlobByID(nil, blob.ID)
+	require.NoError(err)
+	require.NotNil(out)
+	require.Equal(blob.Size, out.Size)
+
+	// Delete the blob
+	buf, err = structs.Encode(structs.DispatchBlobDeleteRequestType, structs.DispatchBlobDeleteRequest{
+		BlobIDs: []string{blob.ID},
+	})
+	require.NoError(err)
+	require.Nil(fsm.Apply(makeLog(buf)))
+
+	out, err = fsm.State().DispatchBlobByID(nil, blob.ID)
+	require.NoError(err)
+	require.Nil(out)
+}
+
+func TestFSM_JobDispatchRelease(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	fsm := testFSM(t)
+	fsm.evalBroker.SetEnabled(true)
+
+	// Queue a dispatched job
+	job := mock.BatchJob()
+	job.ParentID = "parent"
+	job.Dispatched = true
+	job.DispatchQueued = true
+	require.NoError(fsm.State().UpsertJob(1, job))
+
+	eval := mock.Eval()
+	eval.JobID = job.ID
+	req := structs.JobDispatchReleaseRequest{
+		Jobs:  []structs.NamespacedID{*job.NamespacedID()},
+		Evals: []*structs.Evaluation{eval},
+	}
+	buf, err := structs.Encode(structs.JobDispatchReleaseRequestType, req)
+	require.NoError(err)
+	require.Nil(fsm.Apply(makeLog(buf)))
+
+	out, err := fsm.State().JobByID(nil, job.Namespace, job.ID)
+	require.NoError(err)
+	require.False(out.DispatchQueued)
+
+	// The evaluation is enqueued
+	evalOut, err := fsm.State().EvalByID(nil, eval.ID)
+	require.NoError(err)
+	require.NotNil(evalOut)
+	require.Equal(1, fsm.evalBroker.Stats().TotalReady)
+
+	// Releasing the job again doesn't enqueue another evaluation
+	req.Evals = []*structs.Evaluation{mock.Eval()}
+	req.Evals[0].JobID = job.ID
+	buf, err = structs.Encode(structs.JobDispatchReleaseRequestType, req)
+	require.NoError(err)
+	require.Nil(fsm.Apply(makeLog(buf)))
+	require.Equal(1, fsm.evalBroker.Stats().TotalReady)
+}
+
+func TestFSM_MaintenancePlanUpsert(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	fsm := testFSM(t)
+
+	node := mock.Node()
+	require.NoError(fsm.State().UpsertNode(1, node))
+
+	plan := mock.MaintenancePlan()
+	plan.Status = structs.MaintenancePlanStatusRunning
+	plan.Nodes = []*structs.MaintenancePlanNode{
+		{NodeID: node.ID, Name: node.Name, Status: structs.MaintenanceNodeStatusDraining},
+	}
+	req := structs.MaintenancePlanUpsertRequest{
+		Plan: plan,
+		NodeDrains: map[string]*structs.DrainUpdate{
+			node.ID: {DrainStrategy: &structs.DrainStrategy{DrainSpec: structs.DrainSpec{Deadline: time.Hour}}},
+		},
+	}
+	buf, err := structs.Encode(structs.MaintenancePlanUpsertRequestType, req)
+	require.NoError(err)
+	require.Nil(fsm.Apply(makeLog(buf)))
+
+	out, err := fsm.State().MaintenancePlanByID(nil, plan.ID)
+	require.NoError(err)
+	require.NotNil(out)
+	require.Equal(structs.MaintenanceNodeStatusDraining, out.Nodes[0].Status)
+
+	nodeOut, err := fsm.State().NodeByID(nil, node.ID)
+	require.NoError(err)
+	require.NotNil(nodeOut.DrainStrategy)
+
+	// Applying the same update again fails as the plan was modified
+	resp := fsm.Apply(makeLog(buf))
+	require.NotNil(resp)
+	require.Contains(resp.(error).Error(), "was modified")
+}
+
 func TestFSM_RegisterPeriodicJob_NonLeader(t *testing.T) {
 	t.Parallel()
 	fsm := testFSM(t)
@@ -2215,6 +2411,159 @@ func TestFSM_DeleteACLPolicies(t *testing.T) {
 	assert.Nil(t, out)
 }
 
+func TestFSM_UpsertDeleteACLRoles(t *testing.T) {
+	t.Parallel()
+	fsm := testFSM(t)
+
+	role := mock.ACLRole()
+	buf, err := structs.Encode(structs.ACLRoleUpsertRequestType, structs.ACLRoleUpsertRequest{
+		Roles: []*structs.ACLRole{role},
+	})
+	require.NoError(t, err)
+	require.Nil(t, fsm.Apply(makeLog(buf)))
+
+	// Verify we are registered
+	out, err := fsm.State().ACLRoleByName(nil, role.Name)
+	require.NoError(t, err)
+	require.NotNil(t, out)
+
+	buf, err = structs.Encode(structs.ACLRoleDeleteRequestType, structs.ACLRoleDeleteRequest{
+		Names: []string{role.Name},
+	})
+	require.NoError(t, err)
+	require.Nil(t, fsm.Apply(makeLog(buf)))
+
+	// Verify we are NOT registered
+	out, err = fsm.State().ACLRoleByName(nil, role.Name)
+	require.NoError(t, err)
+	require.Nil(t, out)
+}
+
+func TestFSM_UpsertDeleteACLAuthMethods(t *testing.T) {
+	t.Parallel()
+	fsm := testFSM(t)
+
+	method := mock.ACLAuthMethod()
+	buf, err := structs.Encode(structs.ACLAuthMethodUpsertRequestType, structs.ACLAuthMethodUpsertRequest{
+		AuthMethods: []*structs.ACLAuthMethod{method},
+	})
+	require.NoError(t, err)
+	require.Nil(t, fsm.Apply(makeLog(buf)))
+
+	rule := mock.ACLBindingRule()
+	rule.AuthMethod = method.Name
+	buf, err = structs.Encode(structs.ACLBindingRuleUpsertRequestType, structs.ACLBindingRuleUpsertRequest{
+		BindingRules: []*structs.ACLBindingRule{rule},
+	})
+	require.NoError(t, err)
+	require.Nil(t, fsm.Apply(makeLog(buf)))
+
+	// Verify we are registered
+	out, err := fsm.State().ACLAuthMethodByName(nil, method.Name)
+	require.NoError(t, err)
+	require.NotNil(t, out)
+	outRule, err := fsm.State().ACLBindingRuleByID(nil, rule.ID)
+	require.NoError(t, err)
+	require.NotNil(t, outRule)
+
+	buf, err = structs.Encode(structs.ACLBindingRuleDeleteRequestType, structs.ACLBindingRuleDeleteRequest{
+		IDs: []string{rule.ID},
+	})
+	require.NoError(t, err)
+	require.Nil(t, fsm.Apply(makeLog(buf)))
+
+	buf, err = structs.Encode(structs.ACLAuthMethodDeleteRequestType, structs.ACLAuthMethodDeleteRequest{
+		Names: []string{method.Name},
+	})
+	require.NoError(t, err)
+	require.Nil(t, fsm.Apply(makeLog(buf)))
+
+	// Verify we are NOT registered
+	out, err = fsm.State().ACLAuthMethodByName(nil, method.Name)
+	require.NoError(t, err)
+	require.Nil(t, out)
+	outRule, err = fsm.State().ACLBindingRuleByID(nil, rule.ID)
+	require.NoError(t, err)
+	require.Nil(t, outRule)
+}
+
+func TestFSM_UpsertDeleteRootKeys(t *testing.T) {
+	t.Parallel()
+	fsm := testFSM(t)
+
+	key1 := mock.RootKey()
+	buf, err := structs.Encode(structs.RootKeyUpsertRequestType, structs.KeyringUpsertRootKeyRequest{
+		RootKey: key1,
+	})
+	require.NoError(t, err)
+	require.Nil(t, fsm.Apply(makeLog(buf)))
+
+	key2 := mock.RootKey()
+	buf, err = structs.Encode(structs.RootKeyUpsertRequestType, structs.KeyringUpsertRootKeyRequest{
+		RootKey: key2,
+	})
+	require.NoError(t, err)
+	require.Nil(t, fsm.Apply(makeLog(buf)))
+
+	// Verify the second key replaced the first
+	out, err := fsm.State().ActiveRootKey(nil)
+	require.NoError(t, err)
+	require.Equal(t, key2.KeyID, out.KeyID)
+
+	buf, err = structs.Encode(structs.RootKeyDeleteRequestType, structs.KeyringDeleteRootKeyRequest{
+		KeyIDs: []string{key1.KeyID},
+	})
+	require.NoError(t, err)
+	require.Nil(t, fsm.Apply(makeLog(buf)))
+
+	// Verify we are NOT registered
+	out, err = fsm.State().RootKeyByID(nil, key1.KeyID)
+	require.NoError(t, err)
+	require.Nil(t, out)
+}
+
+func TestFSM_VariableOperation(t *testing.T) {
+	t.Parallel()
+	fsm := testFSM(t)
+
+	v := mock.VariableEncrypted()
+	buf, err := structs.Encode(structs.VarApplyStateRequestType, structs.VarApplyStateRequest{
+		Op:  structs.VarOpSet,
+		Var: v,
+	})
+	require.NoError(t, err)
+	resp, ok := fsm.Apply(makeLog(buf)).(*structs.VarApplyStateResponse)
+	require.True(t, ok)
+	require.False(t, resp.IsConflict())
+
+	out, err := fsm.State().VarGet(nil, v.Namespace, v.Path)
+	require.NoError(t, err)
+	require.Equal(t, v.Data, out.Data)
+
+	// A check-and-set with a stale index returns a conflict
+	buf, err = structs.Encode(structs.VarApplyStateRequestType, structs.VarApplyStateRequest{
+		Op:  structs.VarOpDeleteCAS,
+		Var: v,
+	})
+	require.NoError(t, err)
+	resp, ok = fsm.Apply(makeLog(buf)).(*structs.VarApplyStateResponse)
+	require.True(t, ok)
+	require.True(t, resp.IsConflict())
+
+	buf, err = structs.Encode(structs.VarApplyStateRequestType, structs.VarApplyStateRequest{
+		Op:  structs.VarOpDelete,
+		Var: v,
+	})
+	require.NoError(t, err)
+	resp, ok = fsm.Apply(makeLog(buf)).(*structs.VarApplyStateResponse)
+	require.True(t, ok)
+	require.False(t, resp.IsConflict())
+
+	out, err = fsm.State().VarGet(nil, v.Namespace, v.Path)
+	require.NoError(t, err)
+	require.Nil(t, out)
+}
+
 func TestFSM_BootstrapACLTokens(t *testing.T) {
 	t.Parallel()
 	fsm := testFSM(t)
@@ -2659,6 +3008,47 @@ func TestFSM_SnapshotRestore_ACLPolicy(t *testing.T) {
 	assert.Equal(t, p2, out2)
 }
 
+func TestFSM_SnapshotRestore_ACLRoles(t *testing.T) {
+	t.Parallel()
+	// Add some state
+	fsm := testFSM(t)
+	state := fsm.State()
+	r1 := mock.ACLRole()
+	r2 := mock.ACLRole()
+	state.UpsertACLRoles(1000, []*structs.ACLRole{r1, r2})
+
+	// Verify the contents
+	fsm2 := testSnapshotRestore(t, fsm)
+	state2 := fsm2.State()
+	out1, _ := state2.ACLRoleByName(nil, r1.Name)
+	out2, _ := state2.ACLRoleByName(nil, r2.Name)
+	require.Equal(t, r1, out1)
+	require.Equal(t, r2, out2)
+}
+
+func TestFSM_SnapshotRestore_ACLAuthMethods(t *testing.T) {
+	t.Parallel()
+	// Add some state
+	fsm := testFSM(t)
+	state := fsm.State()
+	m1 := mock.ACLAuthMethod()
+	m2 := mock.ACLAuthMethod()
+	state.UpsertACLAuthMethods(1000, []*structs.ACLAuthMethod{m1, m2})
+	r1 := mock.ACLBindingRule()
+	r1.AuthMethod = m1.Name
+	state.UpsertACLBindingRules(1001, []*structs.ACLBindingRule{r1})
+
+	// Verify the contents
+	fsm2 := testSnapshotRestore(t, fsm)
+	state2 := fsm2.State()
+	out1, _ := state2.ACLAuthMethodByName(nil, m1.Name)
+	out2, _ := state2.ACLAuthMethodByName(nil, m2.Name)
+	outRule, _ := state2.ACLBindingRuleByID(nil, r1.ID)
+	require.Equal(t, m1, out1)
+	require.Equal(t, m2, out2)
+	require.Equal(t, r1, outRule)
+}
+
 func TestFSM_SnapshotRestore_ACLTokens(t *testing.T) {
 	t.Parallel()
 	// Add some state
@@ -2678,6 +3068,92 @@ func TestFSM_SnapshotRestore_ACLTokens(t *testing.T) {
 	assert.Equal(t, tk2, out2)
 }
 
+func TestFSM_SnapshotRestore_RootKeys(t *testing.T) {
+	t.Parallel()
+	// Add some state
+	fsm := testFSM(t)
+	state := fsm.State()
+	k1 := mock.RootKey()
+	k2 := mock.RootKey()
+	state.UpsertRootKey(1000, k1)
+	state.UpsertRootKey(1001, k2)
+
+	// Verify the contents
+	fsm2 := testSnapshotRestore(t, fsm)
+	state2 := fsm2.State()
+	out1, _ := state2.RootKeyByID(nil, k1.KeyID)
+	out2, _ := state2.RootKeyByID(nil, k2.KeyID)
+	require.False(t, out1.IsActive())
+	require.Equal(t, k1.PublicKey, out1.PublicKey)
+	require.Equal(t, k2, out2)
+}
+
+func TestFSM_SnapshotRestore_DispatchBlobs(t *testing.T) {
+	t.Parallel()
+	// Add some state
+	fsm := testFSM(t)
+	state := fsm.State()
+	blob, chunks := structs.NewDispatchBlob([]byte("hello world"))
+	state.UpsertDispatchBlobChunk(1000, chunks[0])
+	state.UpsertDispatchBlob(1001, blob)
+	_, orphans := structs.NewDispatchBlob([]byte("incomplete"))
+	state.UpsertDispatchBlobChunk(1002, orphans[0])
+
+	// Verify the contents
+	fsm2 := testSnapshotRestore(t, fsm)
+	state2 := fsm2.State()
+	out, _ := state2.DispatchBlobByID(nil, blob.ID)
+	require.Equal(t, blob, out)
+	chunk, _ := state2.DispatchBlobChunkByID(nil, blob.ID, 0)
+	require.Equal(t, chunks[0], chunk)
+	orphan, _ := state2.DispatchBlobChunkByID(nil, orphans[0].BlobID, 0)
+	require.Equal(t, orphans[0], orphan)
+}
+
+func TestFSM_SnapshotRestore_MaintenancePlans(t *testing.T) {
+	t.Parallel()
+	// Add some state
+	fsm := testFSM(t)
+	state := fsm.State()
+	p1 := mock.MaintenancePlan()
+	p1.Nodes = []*structs.MaintenancePlanNode{
+		{NodeID: uuid.Generate(), Name: "foo", Status: structs.MaintenanceNodeStatusPending},
+	}
+	p2 := mock.MaintenancePlan()
+	p2.Status = structs.MaintenancePlanStatusCancelled
+	state.UpsertMaintenancePlan(1000, p1, nil, nil)
+	state.UpsertMaintenancePlan(1001, p2, nil, nil)
+
+	// Verify the contents
+	fsm2 := testSnapshotRestore(t, fsm)
+	state2 := fsm2.State()
+	out1, _ := state2.MaintenancePlanByID(nil, p1.ID)
+	out2, _ := state2.MaintenancePlanByID(nil, p2.ID)
+	require.Equal(t, p1, out1)
+	require.Equal(t, p2, out2)
+}
+
+func TestFSM_SnapshotRestore_Variables(t *testing.T) {
+	t.Parallel()
+	// Add some state
+	fsm := testFSM(t)
+	state := fsm.State()
+	v1 := mock.VariableEncrypted()
+	v2 := mock.VariableEncrypted()
+	state.VarSet(1000, &structs.VarApplyStateRequest{Op: structs.VarOpSet, Var: v1})
+	state.VarSet(1001, &structs.VarApplyStateRequest{Op: structs.VarOpSet, Var: v2})
+
+	// Verify the contents
+	fsm2 := testSnapshotRestore(t, fsm)
+	state2 := fsm2.State()
+	out1, _ := state2.VarGet(nil, v1.Namespace, v1.Path)
+	out2, _ := state2.VarGet(nil, v2.Namespace, v2.Path)
+	require.Equal(t, v1.Data, out1.Data)
+	require.Equal(t, v1.KeyID, out1.KeyID)
+	require.Equal(t, uint64(1000), out1.ModifyIndex)
+	require.Equal(t, v2.Data, out2.Data)
+}
+
 func TestFSM_SnapshotRestore_SchedulerConfiguration(t *testing.T) {
 	t.Parallel()
 	// Add some state
@@ -10,6 +10,7 @@ import (
 	memdb "github.com/hashicorp/go-memdb"
 
 	"github.com/hashicorp/consul/lib"
+	"github.com/hashicorp/nomad/nomad/state"
 	"github.com/hashicorp/nomad/nomad/structs"
 )
 
@@ -21,6 +22,11 @@ const (
 	// NodeHeartbeatEventMissed is the event used when the Nodes heartbeat is
 	// missed.
 	NodeHeartbeatEventMissed = "Node heartbeat missed"
+
+	// NodeHeartbeatEventDisconnectTimeout is the event used when a
+	// disconnected node did not reconnect within the max_client_disconnect of
+	// its allocations.
+	NodeHeartbeatEventDisconnectTimeout = "Node disconnect timeout exceeded"
 )
 
 var (
@@ -80,7 +86,20 @@ func (h *nodeHeartbeater) initializeHeartbeatTimers() error {
 		if node.TerminalStatus() {
 			continue
 		}
-		h.resetHeartbeatTimerLocked(node.ID, h.config.FailoverHeartbeatTTL)
+
+		// Disconnected nodes keep their allocations for at least as long
+		// as they are allowed to be disconnected
+		ttl := h.config.FailoverHeartbeatTTL
+		if node.Status == structs.NodeStatusDisconnected {
+			disconnect, err := maxClientDisconnect(snap, node.ID)
+			if err != nil {
+				return err
+			}
+			if disconnect > ttl {
+				ttl = disconnect
+			}
+		}
+		h.resetHeartbeatTimerLocked(node.ID, ttl)
 	}
 	return nil
 }
@@ -152,11 +171,26 @@ func (h *nodeHeartbeater) invalidateHeartbeat(id string) {
 
 	h.logger.Warn("node TTL expired", "node_id", id)
 
+	// A node running allocations that may survive a disconnect is marked
+	// disconnected until the longest max_client_disconnect expires, after
+	// which it is marked down.
+	status := structs.NodeStatusDown
+	message := NodeHeartbeatEventMissed
+	disconnect, err := h.disconnectTTL(id)
+	if err != nil {
+		h.logger.Error("failed to determine node disconnect timeout", "node_id", id, "error", err)
+	}
+	if disconnect > 0 {
+		status = structs.NodeStatusDisconnected
+	} else if disconnect < 0 {
+		message = NodeHeartbeatEventDisconnectTimeout
+	}
+
 	// Make a request to update the node status
 	req := structs.NodeUpdateStatusRequest{
 		NodeID:    id,
-		Status:    structs.NodeStatusDown,
-		NodeEvent: structs.NewNodeEvent().SetSubsystem(structs.NodeEventSubsystemCluster).SetMessage(NodeHeartbeatEventMissed),
+		Status:    status,
+		NodeEvent: structs.NewNodeEvent().SetSubsystem(structs.NodeEventSubsystemCluster).SetMessage(message),
 		WriteRequest: structs.WriteRequest{
 			Region: h.config.Region,
 		},
@@ -164,7 +198,61 @@ func (h *nodeHeartbeater) invalidateHeartbeat(id string) {
 	var resp structs.NodeUpdateResponse
 	if err := h.staticEndpoints.Node.UpdateStatus(&req, &resp); err != nil {
 		h.logger.Error("update node status failed", "error", err)
+		return
+	}
+
+	// Track the disconnect window of the node. If it does not reconnect in
+	// time the heartbeat is invalidated again and the node marked down.
+	if status == structs.NodeStatusDisconnected {
+		h.heartbeatTimersLock.Lock()
+		h.resetHeartbeatTimerLocked(id, disconnect)
+		h.heartbeatTimersLock.Unlock()
+	}
+}
+
+// disconnectTTL returns how long the node may remain disconnected before it
+// is marked down. It returns zero if the node should be marked down
+// immediately because none of its allocations allow a disconnect, and a
+// negative duration if the node was already disconnected.
+func (h *nodeHeartbeater) disconnectTTL(id string) (time.Duration, error) {
+	snap, err := h.fsm.State().Snapshot()
+	if err != nil {
+		return 0, err
+	}
+
+	node, err := snap.NodeByID(nil, id)
+	if err != nil || node == nil {
+		return 0, err
+	}
+	if node.Status == structs.NodeStatusDisconnected {
+		return -1, nil
+	}
+
+	return maxClientDisconnect(snap, id)
+}
+
+// maxClientDisconnect returns the longest max_client_disconnect of the
+// non-terminal allocations running on the node.
+func maxClientDisconnect(snap *state.StateSnapshot, nodeID string) (time.Duration, error) {
+	allocs, err := snap.AllocsByNodeTerminal(nil, nodeID, false)
+	if err != nil {
+		return 0, err
+	}
+
+	var max time.Duration
+	for _, alloc := range allocs {
+		if alloc.Job == nil {
+			continue
+		}
+		tg := alloc.Job.LookupTaskGroup(alloc.TaskGroup)
+		if tg == nil || tg.MaxClientDisconnect == nil {
+			continue
+		}
+		if *tg.MaxClientDisconnect > max {
+			max = *tg.MaxClientDisconnect
+		}
 	}
+	return max, nil
 }
 
 // clearHeartbeatTimer is used to clear the heartbeat time for
@@ -7,6 +7,7 @@ import (
 
 	memdb "github.com/hashicorp/go-memdb"
 	msgpackrpc "github.com/hashicorp/net-rpc-msgpackrpc"
+	"github.com/hashicorp/nomad/helper"
 	"github.com/hashicorp/nomad/nomad/mock"
 	"github.com/hashicorp/nomad/nomad/structs"
 	"github.com/hashicorp/nomad/testutil"
@@ -162,6 +163,53 @@ func TestHeartbeat_InvalidateHeartbeat(t *testing.T) {
 	require.Equal(NodeHeartbeatEventMissed, out.Events[1].Message)
 }
 
+func TestHeartbeat_InvalidateHeartbeat_Disconnected(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	s1 := TestServer(t, nil)
+	defer s1.Shutdown()
+	testutil.WaitForLeader(t, s1.RPC)
+
+	// Create a node running an alloc that tolerates client disconnects
+	node := mock.Node()
+	state := s1.fsm.State()
+	require.NoError(state.UpsertNode(1, node))
+
+	job := mock.Job()
+	job.TaskGroups[0].MaxClientDisconnect = helper.TimeToPtr(time.Minute)
+	require.NoError(state.UpsertJob(2, job))
+
+	alloc := mock.Alloc()
+	alloc.Job = job
+	alloc.JobID = job.ID
+	alloc.NodeID = node.ID
+	require.NoError(state.UpsertAllocs(3, []*structs.Allocation{alloc}))
+
+	// This should mark the node disconnected and track the disconnect window
+	s1.invalidateHeartbeat(node.ID)
+
+	ws := memdb.NewWatchSet()
+	out, err := state.NodeByID(ws, node.ID)
+	require.NoError(err)
+	require.Equal(structs.NodeStatusDisconnected, out.Status)
+	require.Len(out.Events, 2)
+	require.Equal(NodeHeartbeatEventMissed, out.Events[1].Message)
+
+	s1.heartbeatTimersLock.Lock()
+	_, ok := s1.heartbeatTimers[node.ID]
+	s1.heartbeatTimersLock.Unlock()
+	require.True(ok)
+
+	// Not reconnecting in time should mark the node down
+	s1.invalidateHeartbeat(node.ID)
+
+	out, err = state.NodeByID(ws, node.ID)
+	require.NoError(err)
+	require.Equal(structs.NodeStatusDown, out.Status)
+	require.Len(out.Events, 3)
+	require.Equal(NodeHeartbeatEventDisconnectTimeout, out.Events[2].Message)
+}
+
 func TestHeartbeat_ClearHeartbeatTimer(t *testing.T) {
 	t.Parallel()
 	s1 := TestServer(t, nil)
@@ -19,6 +19,7 @@ import (
 	"github.com/hashicorp/nomad/helper/uuid"
 	"github.com/hashicorp/nomad/nomad/state"
 	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/nomad/structs/config"
 	"github.com/hashicorp/nomad/scheduler"
 )
 
@@ -28,8 +29,13 @@ const (
 	RegisterEnforceIndexErrPrefix = "Enforcing job modify index"
 
 	// DispatchPayloadSizeLimit is the maximum size of the uncompressed input
-	// data payload.
+	// data payload stored on the dispatched job. Larger payloads are stored
+	// in dispatch blobs, up to structs.DispatchBlobPayloadSizeLimit.
 	DispatchPayloadSizeLimit = 16 * 1024
+
+	// DispatchIdempotencyTTL is how long a dispatch idempotency token returns
+	// the job that was dispatched with it.
+	DispatchIdempotencyTTL = 15 * time.Minute
 )
 
 var (
@@ -41,6 +47,15 @@ var (
 		Operand: structs.ConstraintVersion,
 	}
 
+	// dispatchBlobConstraint is the implicit constraint added to dispatched
+	// jobs whose payload is stored in a blob, since older clients can't fetch
+	// it. The pre-release allows development builds of the first version.
+	dispatchBlobConstraint = &structs.Constraint{
+		LTarget: "${attr.nomad.version}",
+		RTarget: ">= 0.9.2-dev",
+		Operand: structs.ConstraintVersion,
+	}
+
 	// allowRescheduleTransition is the transition that allows failed
 	// allocations to be force rescheduled. We create a one off
 	// variable to avoid creating a new object for every request.
@@ -74,7 +89,7 @@ func (j *Job) Register(args *structs.JobRegisterRequest, reply *structs.JobRegis
 	setImplicitConstraints(args.Job)
 
 	// Validate the job and capture any warnings
-	err, warnings := validateJob(args.Job)
+	err, warnings := j.validateJob(args.Job)
 	if err != nil {
 		return err
 	}
@@ -82,12 +97,17 @@ func (j *Job) Register(args *structs.JobRegisterRequest, reply *structs.JobRegis
 	// Set the warning message
 	reply.Warnings = structs.MergeMultierrorWarnings(warnings, canonicalizeWarnings)
 
-	// Check job submission permissions
+	// Check job submission permissions. Tokens with only the job-scale
+	// capability may change the count of the task groups of an existing job.
+	scaleOnly := false
 	if aclObj, err := j.srv.ResolveToken(args.AuthToken); err != nil {
 		return err
 	} else if aclObj != nil {
 		if !aclObj.AllowNsOp(args.RequestNamespace(), acl.NamespaceCapabilitySubmitJob) {
-			return structs.ErrPermissionDenied
+			if !aclObj.AllowNsOp(args.RequestNamespace(), acl.NamespaceCapabilityScaleJob) {
+				return structs.ErrPermissionDenied
+			}
+			scaleOnly = true
 		}
 		// Check if override is set and we do not have permissions
 		if args.PolicyOverride {
@@ -110,6 +130,10 @@ func (j *Job) Register(args *structs.JobRegisterRequest, reply *structs.JobRegis
 		return err
 	}
 
+	if scaleOnly && !isJobScale(existingJob, args.Job) {
+		return structs.ErrPermissionDenied
+	}
+
 	// If EnforceIndex set, check it before trying to apply
 	if args.EnforceIndex {
 		jmi := args.JobModifyIndex
@@ -130,12 +154,18 @@ func (j *Job) Register(args *structs.JobRegisterRequest, reply *structs.JobRegis
 		return err
 	}
 
-	// Ensure that the job has permissions for the requested Vault tokens
-	policies := args.Job.VaultPolicies()
-	if len(policies) != 0 {
-		vconf := j.srv.config.VaultConfig
+	// Ensure that the job has permissions for the requested Vault tokens of
+	// each Vault cluster
+	for cluster, policies := range structs.VaultPoliciesByCluster(args.Job.VaultPolicies()) {
+		vconf, ok := j.srv.config.VaultConfigs[cluster]
+		if !ok {
+			return fmt.Errorf("Vault cluster %q not configured and Vault policies requested", cluster)
+		}
 		if !vconf.IsEnabled() {
-			return fmt.Errorf("Vault not enabled and Vault policies requested")
+			if cluster == config.VaultDefaultCluster {
+				return fmt.Errorf("Vault not enabled and Vault policies requested")
+			}
+			return fmt.Errorf("Vault cluster %q not enabled and Vault policies requested", cluster)
 		}
 
 		// Have to check if the user has permissions
@@ -145,7 +175,7 @@ func (j *Job) Register(args *structs.JobRegisterRequest, reply *structs.JobRegis
 			}
 
 			vault := j.srv.vault
-			s, err := vault.LookupToken(context.Background(), args.Job.VaultToken)
+			s, err := vault.LookupToken(context.Background(), cluster, args.Job.VaultToken)
 			if err != nil {
 				return err
 			}
@@ -157,8 +187,7 @@ func (j *Job) Register(args *structs.JobRegisterRequest, reply *structs.JobRegis
 
 			// If we are given a root token it can access all policies
 			if !lib.StrContains(allowedPolicies, "root") {
-				flatPolicies := structs.VaultPoliciesSet(policies)
-				subset, offending := helper.SliceStringIsSubset(allowedPolicies, flatPolicies)
+				subset, offending := helper.SliceStringIsSubset(allowedPolicies, policies)
 				if !subset {
 					return fmt.Errorf("Passed Vault Token doesn't allow access to the following policies: %s",
 						strings.Join(offending, ", "))
@@ -261,16 +290,18 @@ func setImplicitConstraints(j *structs.Job) {
 			continue
 		}
 
-		found := false
-		for _, c := range tg.Constraints {
-			if c.Equals(vaultConstraint) {
-				found = true
-				break
+		for _, constraint := range vaultConstraints(policies[tg.Name]) {
+			found := false
+			for _, c := range tg.Constraints {
+				if c.Equals(constraint) {
+					found = true
+					break
+				}
 			}
-		}
 
-		if !found {
-			tg.Constraints = append(tg.Constraints, vaultConstraint)
+			if !found {
+				tg.Constraints = append(tg.Constraints, constraint)
+			}
 		}
 	}
 
@@ -300,6 +331,36 @@ func setImplicitConstraints(j *structs.Job) {
 	}
 }
 
+// vaultConstraints returns the Vault constraints of the tasks of a task
+// group, one per Vault cluster they request tokens from. Clusters other than
+// the default one are fingerprinted under "attr.vault.<cluster>".
+func vaultConstraints(tasks map[string]*structs.Vault) []*structs.Constraint {
+	clusters := make(map[string]struct{})
+	for _, v := range tasks {
+		clusters[v.GetCluster()] = struct{}{}
+	}
+
+	names := make([]string, 0, len(clusters))
+	for name := range clusters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	constraints := make([]*structs.Constraint, 0, len(names))
+	for _, name := range names {
+		if name == config.VaultDefaultCluster {
+			constraints = append(constraints, vaultConstraint)
+			continue
+		}
+		constraints = append(constraints, &structs.Constraint{
+			LTarget: fmt.Sprintf("${attr.vault.%s.version}", name),
+			RTarget: vaultConstraint.RTarget,
+			Operand: vaultConstraint.Operand,
+		})
+	}
+	return constraints
+}
+
 // getSignalConstraint builds a suitable constraint based on the required
 // signals
 func getSignalConstraint(signals []string) *structs.Constraint {
@@ -376,7 +437,7 @@ func (j *Job) Validate(args *structs.JobValidateRequest, reply *structs.JobValid
 	setImplicitConstraints(args.Job)
 
 	// Validate the job and capture any warnings
-	err, warnings := validateJob(args.Job)
+	err, warnings := j.validateJob(args.Job)
 	if err != nil {
 		if merr, ok := err.(*multierror.Error); ok {
 			for _, err := range merr.Errors {
@@ -1130,7 +1191,7 @@ func (j *Job) Plan(args *structs.JobPlanRequest, reply *structs.JobPlanResponse)
 	setImplicitConstraints(args.Job)
 
 	// Validate the job and capture any warnings
-	err, warnings := validateJob(args.Job)
+	err, warnings := j.validateJob(args.Job)
 	if err != nil {
 		return err
 	}
@@ -1266,15 +1327,29 @@ func (j *Job) Plan(args *structs.JobPlanRequest, reply *structs.JobPlanResponse)
 
 // validateJob validates a Job and task drivers and returns an error if there is
 // a validation problem or if the Job is of a type a user is not allowed to
-// submit.
-func validateJob(job *structs.Job) (invalid, warnings error) {
+// submit. The job is also validated by the server's job admission hooks.
+func (j *Job) validateJob(job *structs.Job) (invalid, warnings error) {
 	validationErrors := new(multierror.Error)
 	if err := job.Validate(); err != nil {
 		multierror.Append(validationErrors, err)
 	}
 
 	// Get any warnings
-	warnings = job.Warnings()
+	var mWarn multierror.Error
+	if err := job.Warnings(); err != nil {
+		multierror.Append(&mWarn, err)
+	}
+
+	for _, v := range j.srv.jobValidators {
+		err, warn := v.Validate(job)
+		if err != nil {
+			multierror.Append(validationErrors, err)
+		}
+		if warn != nil {
+			multierror.Append(&mWarn, warn)
+		}
+	}
+	warnings = mWarn.ErrorOrNil()
 
 	// TODO: Validate the driver configurations. These had to be removed in 0.9
 	//       to support driver plugins, but see issue: #XXXX for more info.
@@ -1290,6 +1365,25 @@ func validateJob(job *structs.Job) (invalid, warnings error) {
 	return validationErrors.ErrorOrNil(), warnings
 }
 
+// isJobScale returns whether the only change of the new job from the existing
+// job is the count of its task groups.
+func isJobScale(existing, new *structs.Job) bool {
+	if existing == nil || len(existing.TaskGroups) != len(new.TaskGroups) {
+		return false
+	}
+
+	scaled := existing.Copy()
+	for _, tg := range scaled.TaskGroups {
+		newTG := new.LookupTaskGroup(tg.Name)
+		if newTG == nil {
+			return false
+		}
+		tg.Count = newTG.Count
+	}
+	scaled.VaultToken = new.VaultToken
+	return !scaled.SpecChanged(new)
+}
+
 // validateJobUpdate ensures updates to a job are valid.
 func validateJobUpdate(old, new *structs.Job) error {
 	// Validate Dispatch not set on new Jobs
@@ -1373,6 +1467,33 @@ func (j *Job) Dispatch(args *structs.JobDispatchRequest, reply *structs.JobDispa
 		return err
 	}
 
+	// Idempotent dispatches are serialized so that concurrent retries can't
+	// both dispatch a job, and dispatches of jobs with a concurrency limit are
+	// serialized so that the limit is respected.
+	maxConcurrent := parameterizedJob.ParameterizedJob.MaxConcurrent
+	if args.IdempotencyToken != "" || maxConcurrent > 0 {
+		unlock := j.srv.dispatchLocks.Lock(*parameterizedJob.NamespacedID())
+		defer unlock()
+	}
+
+	// Return the job dispatched with the same idempotency token if there is
+	// one, unless it was dispatched with different input.
+	var requestHash string
+	if args.IdempotencyToken != "" {
+		requestHash = structs.DispatchRequestHash(args.Meta, args.Payload)
+		existing, err := j.idempotentDispatch(args.RequestNamespace(), parameterizedJob.ID, args.IdempotencyToken)
+		if err != nil {
+			return err
+		}
+		if existing != nil {
+			if existing.DispatchRequestHash != "" && existing.DispatchRequestHash != requestHash {
+				return fmt.Errorf("idempotency token %q was used to dispatch job %q with a different payload or meta",
+					args.IdempotencyToken, existing.ID)
+			}
+			return j.existingDispatchReply(existing, reply)
+		}
+	}
+
 	// Derive the child job and commit it via Raft
 	dispatchJob := parameterizedJob.Copy()
 	dispatchJob.ID = structs.DispatchedID(parameterizedJob.ID, time.Now())
@@ -1380,6 +1501,18 @@ func (j *Job) Dispatch(args *structs.JobDispatchRequest, reply *structs.JobDispa
 	dispatchJob.Name = dispatchJob.ID
 	dispatchJob.SetSubmitTime()
 	dispatchJob.Dispatched = true
+	dispatchJob.DispatchIdempotencyToken = args.IdempotencyToken
+	dispatchJob.DispatchRequestHash = requestHash
+
+	// Queue the job if the parameterized job has reached its concurrency
+	// limit. The leader releases queued jobs as running ones complete.
+	if maxConcurrent > 0 && !dispatchJob.IsPeriodic() {
+		queue, err := j.dispatchLimitReached(parameterizedJob)
+		if err != nil {
+			return err
+		}
+		dispatchJob.DispatchQueued = queue
+	}
 
 	// Merge in the meta data
 	for k, v := range args.Meta {
@@ -1389,8 +1522,19 @@ func (j *Job) Dispatch(args *structs.JobDispatchRequest, reply *structs.JobDispa
 		dispatchJob.Meta[k] = v
 	}
 
-	// Compress the payload
-	dispatchJob.Payload = snappy.Encode(nil, args.Payload)
+	// Compress the payload, or store it in a blob if it is too large to be
+	// stored on the job
+	if len(args.Payload) > DispatchPayloadSizeLimit {
+		blob, err := j.upsertDispatchBlob(args.Payload, args.WriteRequest)
+		if err != nil {
+			j.logger.Error("storing dispatch payload failed", "error", err)
+			return err
+		}
+		dispatchJob.PayloadBlob = blob.ID
+		dispatchJob.Constraints = append(dispatchJob.Constraints, dispatchBlobConstraint)
+	} else {
+		dispatchJob.Payload = snappy.Encode(nil, args.Payload)
+	}
 
 	regReq := &structs.JobRegisterRequest{
 		Job:          dispatchJob,
@@ -1411,9 +1555,10 @@ func (j *Job) Dispatch(args *structs.JobDispatchRequest, reply *structs.JobDispa
 	reply.JobCreateIndex = jobCreateIndex
 	reply.DispatchedJobID = dispatchJob.ID
 	reply.Index = jobCreateIndex
+	reply.Queued = dispatchJob.DispatchQueued
 
-	// If the job is periodic, we don't create an eval.
-	if !dispatchJob.IsPeriodic() {
+	// If the job is periodic or queued, we don't create an eval.
+	if !dispatchJob.IsPeriodic() && !dispatchJob.DispatchQueued {
 		// Create a new evaluation
 		eval := &structs.Evaluation{
 			ID:             uuid.Generate(),
@@ -1446,6 +1591,177 @@ func (j *Job) Dispatch(args *structs.JobDispatchRequest, reply *structs.JobDispa
 	return nil
 }
 
+// Dispatches is used to list the jobs dispatched from a parameterized job
+func (j *Job) Dispatches(args *structs.JobSpecificRequest, reply *structs.JobDispatchesResponse) error {
+	if done, err := j.srv.forward("Job.Dispatches", args, args, reply); done {
+		return err
+	}
+	defer metrics.MeasureSince([]string{"nomad", "job", "dispatches"}, time.Now())
+
+	// Check for read-job permissions
+	if aclObj, err := j.srv.ResolveToken(args.AuthToken); err != nil {
+		return err
+	} else if aclObj != nil && !aclObj.AllowNsOp(args.RequestNamespace(), acl.NamespaceCapabilityReadJob) {
+		return structs.ErrPermissionDenied
+	}
+
+	// Setup the blocking query
+	opts := blockingOptions{
+		queryOpts: &args.QueryOptions,
+		queryMeta: &reply.QueryMeta,
+		run: func(ws memdb.WatchSet, state *state.StateStore) error {
+			iter, err := state.JobsByIDPrefix(ws, args.RequestNamespace(), args.JobID+structs.DispatchLaunchSuffix)
+			if err != nil {
+				return err
+			}
+
+			var dispatches []*structs.JobDispatchStub
+			for raw := iter.Next(); raw != nil; raw = iter.Next() {
+				child := raw.(*structs.Job)
+				if child.ParentID != args.JobID {
+					continue
+				}
+				stub := child.DispatchStub()
+				if child.PayloadBlob != "" {
+					blob, err := state.DispatchBlobByID(ws, child.PayloadBlob)
+					if err != nil {
+						return err
+					}
+					if blob != nil {
+						stub.PayloadSize = blob.Size
+					}
+				}
+				dispatches = append(dispatches, stub)
+			}
+			reply.Dispatches = dispatches
+
+			// Use the last index that affected the jobs table
+			index, err := state.Index("jobs")
+			if err != nil {
+				return err
+			}
+			reply.Index = index
+
+			// Set the query response
+			j.srv.setQueryMeta(&reply.QueryMeta)
+			return nil
+		}}
+	return j.srv.blockingRPC(&opts)
+}
+
+// idempotentDispatch returns the job dispatched from the parameterized job
+// with the passed idempotency token within the idempotency TTL, or nil if
+// there is none.
+func (j *Job) idempotentDispatch(namespace, parentID, token string) (*structs.Job, error) {
+	snap, err := j.srv.fsm.State().Snapshot()
+	if err != nil {
+		return nil, err
+	}
+
+	iter, err := snap.JobsByIDPrefix(nil, namespace, parentID+structs.DispatchLaunchSuffix)
+	if err != nil {
+		return nil, err
+	}
+
+	cutoff := time.Now().Add(-DispatchIdempotencyTTL).UTC().UnixNano()
+	var existing *structs.Job
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		child := raw.(*structs.Job)
+		if child.ParentID != parentID || child.DispatchIdempotencyToken != token || child.SubmitTime < cutoff {
+			continue
+		}
+		if existing == nil || child.SubmitTime > existing.SubmitTime {
+			existing = child
+		}
+	}
+	return existing, nil
+}
+
+// dispatchLimitReached returns whether a job dispatched from the
+// parameterized job must be queued because of its concurrency limit. Jobs are
+// also queued while other dispatched jobs are waiting so that they are
+// released in order.
+func (j *Job) dispatchLimitReached(parent *structs.Job) (bool, error) {
+	summary, err := j.srv.fsm.State().JobSummaryByID(nil, parent.Namespace, parent.ID)
+	if err != nil {
+		return false, err
+	}
+	if summary == nil || summary.Children == nil {
+		return false, nil
+	}
+
+	children := summary.Children
+	if children.Queued > 0 {
+		return true, nil
+	}
+	return children.Pending+children.Running >= int64(parent.ParameterizedJob.MaxConcurrent), nil
+}
+
+// upsertDispatchBlob stores a dispatch payload in a blob. The chunks of the
+// blob are written in separate Raft entries before the blob itself, unless a
+// blob with the same content already exists.
+func (j *Job) upsertDispatchBlob(payload []byte, wr structs.WriteRequest) (*structs.DispatchBlob, error) {
+	blob, chunks := structs.NewDispatchBlob(payload)
+
+	existing, err := j.srv.fsm.State().DispatchBlobByID(nil, blob.ID)
+	if err != nil {
+		return nil, err
+	}
+	if existing == nil {
+		for _, chunk := range chunks {
+			req := &structs.DispatchBlobChunkUpsertRequest{
+				Chunk:        chunk,
+				WriteRequest: wr,
+			}
+			if _, _, err := j.srv.raftApply(structs.DispatchBlobChunkUpsertRequestType, req); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	// Upsert the blob even if it exists so that its modify index is refreshed
+	// and it isn't garbage collected before the dispatched job is registered
+	req := &structs.DispatchBlobUpsertRequest{
+		Blob:         blob,
+		WriteRequest: wr,
+	}
+	resp, _, err := j.srv.raftApply(structs.DispatchBlobUpsertRequestType, req)
+	if err != nil {
+		return nil, err
+	}
+	if respErr, ok := resp.(error); ok {
+		return nil, respErr
+	}
+	return blob, nil
+}
+
+// existingDispatchReply sets up the reply of a dispatch that returns an
+// already dispatched job.
+func (j *Job) existingDispatchReply(existing *structs.Job, reply *structs.JobDispatchResponse) error {
+	reply.DispatchedJobID = existing.ID
+	reply.JobCreateIndex = existing.CreateIndex
+	reply.Queued = existing.DispatchQueued
+	reply.Index = existing.ModifyIndex
+
+	// Return the latest evaluation of the job
+	evals, err := j.srv.fsm.State().EvalsByJob(nil, existing.Namespace, existing.ID)
+	if err != nil {
+		return err
+	}
+	for _, eval := range evals {
+		if eval.CreateIndex > reply.EvalCreateIndex {
+			reply.EvalID = eval.ID
+			reply.EvalCreateIndex = eval.CreateIndex
+		}
+	}
+	if reply.EvalCreateIndex > reply.Index {
+		reply.Index = reply.EvalCreateIndex
+	}
+
+	j.logger.Debug("returning existing dispatched job for idempotency token", "job_id", existing.ID)
+	return nil
+}
+
 // validateDispatchRequest returns whether the request is valid given the
 // parameterized job.
 func validateDispatchRequest(req *structs.JobDispatchRequest, job *structs.Job) error {
@@ -1458,8 +1774,8 @@ func validateDispatchRequest(req *structs.JobDispatchRequest, job *structs.Job)
 	}
 
 	// Check the payload doesn't exceed the size limit
-	if l := len(req.Payload); l > DispatchPayloadSizeLimit {
-		return fmt.Errorf("Payload exceeds maximum size; %d > %d", l, DispatchPayloadSizeLimit)
+	if l := len(req.Payload); l > structs.DispatchBlobPayloadSizeLimit {
+		return fmt.Errorf("Payload exceeds maximum size; %d > %d", l, structs.DispatchBlobPayloadSizeLimit)
 	}
 
 	// Check if the metadata is a set
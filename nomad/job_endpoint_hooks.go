@@ -0,0 +1,93 @@
+package nomad
+
+import (
+	"fmt"
+
+	multierror "github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/nomad/nomad/jobrules"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// jobValidator is an admission hook that validates jobs before they are
+// registered, planned or validated.
+type jobValidator interface {
+	// Name returns the name of the validator
+	Name() string
+
+	// Validate returns the errors that reject the job and the warnings that
+	// are returned to the submitter.
+	Validate(*structs.Job) (err, warnings error)
+}
+
+// setupJobValidators loads the job validation rules and sets up the job
+// admission hooks.
+func (s *Server) setupJobValidators() error {
+	if err := s.loadJobRules(s.config.JobValidationRules); err != nil {
+		return err
+	}
+
+	s.jobValidators = []jobValidator{
+		&jobRulesValidator{srv: s},
+		&jobAnalysisValidator{srv: s},
+	}
+	return nil
+}
+
+// loadJobRules parses the job validation rules of the files matching the
+// patterns and replaces the current rules. The current rules are kept if the
+// files can't be parsed.
+func (s *Server) loadJobRules(patterns []string) error {
+	rules, err := jobrules.ParseFiles(patterns)
+	if err != nil {
+		return err
+	}
+
+	s.jobRulesLock.Lock()
+	defer s.jobRulesLock.Unlock()
+	s.jobRules = jobrules.NewValidator(rules)
+	return nil
+}
+
+// jobRulesValidator validates jobs against the operator's job validation
+// rules.
+type jobRulesValidator struct {
+	srv *Server
+}
+
+func (v *jobRulesValidator) Name() string {
+	return "job-rules"
+}
+
+func (v *jobRulesValidator) Validate(job *structs.Job) (err, warnings error) {
+	v.srv.jobRulesLock.RLock()
+	rules := v.srv.jobRules
+	v.srv.jobRulesLock.RUnlock()
+
+	if rules == nil {
+		return nil, nil
+	}
+	return rules.Validate(job)
+}
+
+// jobAnalysisValidator rejects jobs whose deployment metric analyses query
+// addresses the operator hasn't allowed.
+type jobAnalysisValidator struct {
+	srv *Server
+}
+
+func (v *jobAnalysisValidator) Name() string {
+	return "deployment-analysis"
+}
+
+func (v *jobAnalysisValidator) Validate(job *structs.Job) (err, warnings error) {
+	var mErr multierror.Error
+	for _, tg := range job.TaskGroups {
+		if tg.Update == nil || tg.Update.Analysis == nil {
+			continue
+		}
+		if address := tg.Update.Analysis.Address; !v.srv.deploymentWatcher.AnalysisAddressAllowed(address) {
+			multierror.Append(&mErr, fmt.Errorf("Task group %q: analysis address %q is not allowed by the server's deployment_analysis_addresses", tg.Name, address))
+		}
+	}
+	return mErr.ErrorOrNil(), nil
+}
@@ -0,0 +1,197 @@
+package nomad
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	msgpackrpc "github.com/hashicorp/net-rpc-msgpackrpc"
+	"github.com/hashicorp/nomad/helper"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+const testJobRules = `
+rule "owner" {
+  description = "Jobs must have an owner"
+  condition   = contains(keys(job.Meta), "owner")
+}
+
+rule "memory" {
+  scope       = "task"
+  enforcement = "warning"
+  condition   = task.Resources.MemoryMB <= 256
+  message     = "task uses ${task.Resources.MemoryMB} MB of memory"
+}
+`
+
+// writeJobRules writes the rules to a file in a new directory and returns
+// the path of the file.
+func writeJobRules(t *testing.T, rules string) (string, func()) {
+	dir, err := ioutil.TempDir("", "nomad")
+	require.NoError(t, err)
+
+	path := filepath.Join(dir, "rules.hcl")
+	require.NoError(t, ioutil.WriteFile(path, []byte(rules), 0600))
+	return path, func() { os.RemoveAll(dir) }
+}
+
+func TestJobEndpoint_Register_JobRules(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	path, cleanup := writeJobRules(t, testJobRules)
+	defer cleanup()
+
+	s1 := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+		c.JobValidationRules = []string{path}
+	})
+	defer s1.Shutdown()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	// Jobs violating error rules are rejected
+	job := mock.Job()
+	delete(job.Meta, "owner")
+	job.TaskGroups[0].Tasks[0].Resources.MemoryMB = 512
+	req := &structs.JobRegisterRequest{
+		Job: job,
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			Namespace: job.Namespace,
+		},
+	}
+
+	var resp structs.JobRegisterResponse
+	err := msgpackrpc.CallWithCodec(codec, "Job.Register", req, &resp)
+	require.Error(err)
+	require.Contains(err.Error(), `rule "owner" failed`)
+
+	// Violations of warning rules are returned as warnings
+	job.Meta["owner"] = "platform"
+	require.NoError(msgpackrpc.CallWithCodec(codec, "Job.Register", req, &resp))
+	require.NotZero(resp.Index)
+	require.Contains(resp.Warnings, `rule "memory" failed`)
+	require.Contains(resp.Warnings, "task uses 512 MB of memory")
+}
+
+func TestJobEndpoint_Validate_JobRules(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	path, cleanup := writeJobRules(t, testJobRules)
+	defer cleanup()
+
+	s1 := TestServer(t, func(c *Config) {
+		c.JobValidationRules = []string{path}
+	})
+	defer s1.Shutdown()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	job := mock.Job()
+	delete(job.Meta, "owner")
+	job.TaskGroups[0].Tasks[0].Resources.MemoryMB = 512
+	req := &structs.JobValidateRequest{
+		Job: job,
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			Namespace: job.Namespace,
+		},
+	}
+
+	var resp structs.JobValidateResponse
+	require.NoError(msgpackrpc.CallWithCodec(codec, "Job.Validate", req, &resp))
+	require.Len(resp.ValidationErrors, 1)
+	require.Contains(resp.ValidationErrors[0], `rule "owner" failed for job "`+job.ID+`": Jobs must have an owner`)
+	require.Contains(resp.Warnings, `rule "memory" failed`)
+}
+
+func TestServer_Reload_JobRules(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	path, cleanup := writeJobRules(t, testJobRules)
+	defer cleanup()
+
+	s1 := TestServer(t, func(c *Config) {
+		c.JobValidationRules = []string{path}
+	})
+	defer s1.Shutdown()
+
+	job := mock.Job()
+	delete(job.Meta, "owner")
+	err, _ := s1.jobValidators[0].Validate(job)
+	require.Error(err)
+
+	// Invalid rules are rejected and the current rules are kept
+	require.NoError(ioutil.WriteFile(path, []byte(`rule "owner" {}`), 0600))
+	config := s1.GetConfig()
+	require.Error(s1.Reload(config))
+	err, _ = s1.jobValidators[0].Validate(job)
+	require.Error(err)
+
+	// Valid rules replace the current rules
+	require.NoError(ioutil.WriteFile(path, []byte(`rule "any" { condition = true }`), 0600))
+	require.NoError(s1.Reload(config))
+	err, _ = s1.jobValidators[0].Validate(job)
+	require.NoError(err)
+}
+
+func TestJobEndpoint_Register_AnalysisAddress(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	s1 := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+		c.DeploymentAnalysisAddresses = []string{"http://prometheus.service.consul:9090"}
+	})
+	defer s1.Shutdown()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	// Jobs querying addresses that aren't allowed are rejected
+	job := mock.Job()
+	job.TaskGroups[0].Update = structs.DefaultUpdateStrategy.Copy()
+	job.TaskGroups[0].Update.Analysis = &structs.DeploymentAnalysis{
+		Address:      "http://169.254.169.254",
+		Interval:     structs.DefaultAnalysisInterval,
+		FailureLimit: structs.DefaultAnalysisFailureLimit,
+		Metrics: []*structs.AnalysisMetric{
+			{
+				Name:  "errors",
+				Query: "errors",
+				Max:   helper.Float64ToPtr(0.05),
+			},
+		},
+	}
+	req := &structs.JobRegisterRequest{
+		Job: job,
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			Namespace: job.Namespace,
+		},
+	}
+
+	var resp structs.JobRegisterResponse
+	err := msgpackrpc.CallWithCodec(codec, "Job.Register", req, &resp)
+	require.Error(err)
+	require.Contains(err.Error(), `analysis address "http://169.254.169.254" is not allowed`)
+
+	// Allowed addresses are accepted
+	job.TaskGroups[0].Update.Analysis.Address = "http://prometheus.service.consul:9090"
+	var resp2 structs.JobRegisterResponse
+	require.NoError(msgpackrpc.CallWithCodec(codec, "Job.Register", req, &resp2))
+	require.NotZero(resp2.Index)
+
+	// The allowed addresses are reloaded
+	config := s1.GetConfig()
+	config.DeploymentAnalysisAddresses = nil
+	require.NoError(s1.Reload(config))
+	err, _ = s1.jobValidators[1].Validate(job)
+	require.Error(err)
+}
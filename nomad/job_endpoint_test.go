@@ -14,6 +14,7 @@ import (
 	"github.com/hashicorp/nomad/helper/uuid"
 	"github.com/hashicorp/nomad/nomad/mock"
 	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/nomad/structs/config"
 	"github.com/hashicorp/nomad/testutil"
 	"github.com/kr/pretty"
 	"github.com/stretchr/testify/require"
@@ -141,6 +142,61 @@ func TestJobEndpoint_Register_ACL(t *testing.T) {
 	}
 }
 
+func TestJobEndpoint_Register_ACL_ScaleJob(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	s1, root := TestACLServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+	})
+	defer s1.Shutdown()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+	state := s1.fsm.State()
+
+	scaleToken := mock.CreatePolicyAndToken(t, state, 1001, "test-scale",
+		mock.NamespacePolicy(structs.DefaultNamespace, "", []string{acl.NamespaceCapabilityScaleJob}))
+
+	// Registering a new job requires submit-job
+	job := mock.Job()
+	req := &structs.JobRegisterRequest{
+		Job: job,
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			Namespace: job.Namespace,
+			AuthToken: scaleToken.SecretID,
+		},
+	}
+	var resp structs.JobRegisterResponse
+	err := msgpackrpc.CallWithCodec(codec, "Job.Register", req, &resp)
+	require.Error(err)
+	require.Equal(structs.ErrPermissionDenied.Error(), err.Error())
+
+	req.AuthToken = root.SecretID
+	require.NoError(msgpackrpc.CallWithCodec(codec, "Job.Register", req, &resp))
+
+	// Changing the count of the job only requires job-scale
+	scaled := job.Copy()
+	scaled.TaskGroups[0].Count = 5
+	req.Job = scaled
+	req.AuthToken = scaleToken.SecretID
+	resp = structs.JobRegisterResponse{}
+	require.NoError(msgpackrpc.CallWithCodec(codec, "Job.Register", req, &resp))
+
+	out, err := state.JobByID(nil, job.Namespace, job.ID)
+	require.NoError(err)
+	require.Equal(5, out.TaskGroups[0].Count)
+
+	// Changing anything else requires submit-job
+	changed := scaled.Copy()
+	changed.TaskGroups[0].Count = 3
+	changed.Priority = 80
+	req.Job = changed
+	resp = structs.JobRegisterResponse{}
+	err = msgpackrpc.CallWithCodec(codec, "Job.Register", req, &resp)
+	require.Error(err)
+	require.Equal(structs.ErrPermissionDenied.Error(), err.Error())
+}
+
 func TestJobEndpoint_Register_InvalidNamespace(t *testing.T) {
 	t.Parallel()
 	s1 := TestServer(t, func(c *Config) {
@@ -586,7 +642,7 @@ func TestJobEndpoint_Register_Vault_Disabled(t *testing.T) {
 	s1 := TestServer(t, func(c *Config) {
 		c.NumSchedulers = 0 // Prevent automatic dequeue
 		f := false
-		c.VaultConfig.Enabled = &f
+		c.GetDefaultVault().Enabled = &f
 	})
 	defer s1.Shutdown()
 	codec := rpcClient(t, s1)
@@ -625,8 +681,8 @@ func TestJobEndpoint_Register_Vault_AllowUnauthenticated(t *testing.T) {
 
 	// Enable vault and allow authenticated
 	tr := true
-	s1.config.VaultConfig.Enabled = &tr
-	s1.config.VaultConfig.AllowUnauthenticated = &tr
+	s1.config.GetDefaultVault().Enabled = &tr
+	s1.config.GetDefaultVault().AllowUnauthenticated = &tr
 
 	// Replace the Vault Client on the server
 	s1.vault = &TestVaultClient{}
@@ -678,8 +734,8 @@ func TestJobEndpoint_Register_Vault_NoToken(t *testing.T) {
 
 	// Enable vault
 	tr, f := true, false
-	s1.config.VaultConfig.Enabled = &tr
-	s1.config.VaultConfig.AllowUnauthenticated = &f
+	s1.config.GetDefaultVault().Enabled = &tr
+	s1.config.GetDefaultVault().AllowUnauthenticated = &f
 
 	// Replace the Vault Client on the server
 	s1.vault = &TestVaultClient{}
@@ -718,8 +774,8 @@ func TestJobEndpoint_Register_Vault_Policies(t *testing.T) {
 
 	// Enable vault
 	tr, f := true, false
-	s1.config.VaultConfig.Enabled = &tr
-	s1.config.VaultConfig.AllowUnauthenticated = &f
+	s1.config.GetDefaultVault().Enabled = &tr
+	s1.config.GetDefaultVault().AllowUnauthenticated = &f
 
 	// Replace the Vault Client on the server
 	tvc := &TestVaultClient{}
@@ -1028,8 +1084,8 @@ func TestJobEndpoint_Revert_Vault_NoToken(t *testing.T) {
 
 	// Enable vault
 	tr, f := true, false
-	s1.config.VaultConfig.Enabled = &tr
-	s1.config.VaultConfig.AllowUnauthenticated = &f
+	s1.config.GetDefaultVault().Enabled = &tr
+	s1.config.GetDefaultVault().AllowUnauthenticated = &f
 
 	// Replace the Vault Client on the server
 	tvc := &TestVaultClient{}
@@ -1127,8 +1183,8 @@ func TestJobEndpoint_Revert_Vault_Policies(t *testing.T) {
 
 	// Enable vault
 	tr, f := true, false
-	s1.config.VaultConfig.Enabled = &tr
-	s1.config.VaultConfig.AllowUnauthenticated = &f
+	s1.config.GetDefaultVault().Enabled = &tr
+	s1.config.GetDefaultVault().AllowUnauthenticated = &f
 
 	// Replace the Vault Client on the server
 	tvc := &TestVaultClient{}
@@ -3974,8 +4030,8 @@ func TestJobEndpoint_ImplicitConstraints_Vault(t *testing.T) {
 
 	// Enable vault
 	tr, f := true, false
-	s1.config.VaultConfig.Enabled = &tr
-	s1.config.VaultConfig.AllowUnauthenticated = &f
+	s1.config.GetDefaultVault().Enabled = &tr
+	s1.config.GetDefaultVault().AllowUnauthenticated = &f
 
 	// Replace the Vault Client on the server
 	tvc := &TestVaultClient{}
@@ -4032,6 +4088,64 @@ func TestJobEndpoint_ImplicitConstraints_Vault(t *testing.T) {
 	}
 }
 
+func TestJobEndpoint_ImplicitConstraints_VaultCluster(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	s1 := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+	})
+	defer s1.Shutdown()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	// Configure a second Vault cluster
+	tr := true
+	s1.config.VaultConfigs["secondary"] = &config.VaultConfig{
+		Name:                 "secondary",
+		Enabled:              &tr,
+		AllowUnauthenticated: &tr,
+	}
+	s1.vault = &TestVaultClient{}
+
+	// Create the register request with a job asking for a vault policy of
+	// the second cluster
+	job := mock.Job()
+	job.TaskGroups[0].Tasks[0].Vault = &structs.Vault{
+		Policies:   []string{"foo"},
+		ChangeMode: structs.VaultChangeModeRestart,
+		Cluster:    "secondary",
+	}
+	req := &structs.JobRegisterRequest{
+		Job: job,
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			Namespace: job.Namespace,
+		},
+	}
+
+	var resp structs.JobRegisterResponse
+	require.NoError(msgpackrpc.CallWithCodec(codec, "Job.Register", req, &resp))
+
+	// Check that there is an implicit constraint on the second cluster
+	out, err := s1.fsm.State().JobByID(nil, job.Namespace, job.ID)
+	require.NoError(err)
+	require.NotNil(out)
+	require.Len(out.TaskGroups[0].Constraints, 1)
+	require.Equal("${attr.vault.secondary.version}", out.TaskGroups[0].Constraints[0].LTarget)
+
+	// Jobs can't request tokens of clusters that aren't configured
+	job = mock.Job()
+	job.TaskGroups[0].Tasks[0].Vault = &structs.Vault{
+		Policies:   []string{"foo"},
+		ChangeMode: structs.VaultChangeModeRestart,
+		Cluster:    "unknown",
+	}
+	req.Job = job
+	err = msgpackrpc.CallWithCodec(codec, "Job.Register", req, &resp)
+	require.Error(err)
+	require.Contains(err.Error(), `Vault cluster "unknown" not configured`)
+}
+
 func TestJobEndpoint_ImplicitConstraints_Signals(t *testing.T) {
 	t.Parallel()
 	s1 := TestServer(t, func(c *Config) {
@@ -4326,9 +4440,12 @@ func TestJobEndpoint_Dispatch(t *testing.T) {
 			"baz": "f3",
 		},
 	}
-	reqInputDataTooLarge := &structs.JobDispatchRequest{
+	reqInputDataLarge := &structs.JobDispatchRequest{
 		Payload: make([]byte, DispatchPayloadSizeLimit+100),
 	}
+	reqInputDataTooLarge := &structs.JobDispatchRequest{
+		Payload: make([]byte, structs.DispatchBlobPayloadSizeLimit+100),
+	}
 
 	type testCase struct {
 		name             string
@@ -4409,6 +4526,11 @@ func TestJobEndpoint_Dispatch(t *testing.T) {
 			err:              true,
 			errStr:           "unpermitted metadata keys",
 		},
+		{
+			name:             "optional input w/ large input",
+			parameterizedJob: d1,
+			dispatchReq:      reqInputDataLarge,
+		},
 		{
 			name:             "optional input w/ too big of input",
 			parameterizedJob: d1,
@@ -4537,3 +4659,342 @@ func TestJobEndpoint_Dispatch(t *testing.T) {
 		})
 	}
 }
+
+func TestJobEndpoint_Dispatch_PayloadBlob(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	s1 := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+	})
+	defer s1.Shutdown()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+	state := s1.fsm.State()
+
+	job := mock.BatchJob()
+	job.ParameterizedJob = &structs.ParameterizedJobConfig{
+		Payload: structs.DispatchPayloadRequired,
+	}
+	require.Nil(state.UpsertJob(1000, job))
+
+	// Dispatch a payload spanning multiple blob chunks
+	payload := make([]byte, 2*structs.DispatchBlobChunkSize+100)
+	for i := range payload {
+		payload[i] = byte(i)
+	}
+	req := &structs.JobDispatchRequest{
+		JobID:   job.ID,
+		Payload: payload,
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			Namespace: job.Namespace,
+		},
+	}
+	var resp1 structs.JobDispatchResponse
+	require.Nil(msgpackrpc.CallWithCodec(codec, "Job.Dispatch", req, &resp1))
+
+	// The payload is stored in a blob instead of the job
+	out, err := state.JobByID(nil, job.Namespace, resp1.DispatchedJobID)
+	require.Nil(err)
+	require.NotNil(out)
+	require.Empty(out.Payload)
+	require.NotEmpty(out.PayloadBlob)
+
+	blob, err := state.DispatchBlobByID(nil, out.PayloadBlob)
+	require.Nil(err)
+	require.NotNil(blob)
+	require.Equal(len(payload), blob.Size)
+	require.Equal(3, blob.Chunks)
+
+	// Dispatching the same payload reuses the blob
+	var resp2 structs.JobDispatchResponse
+	require.Nil(msgpackrpc.CallWithCodec(codec, "Job.Dispatch", req, &resp2))
+	require.NotEqual(resp1.DispatchedJobID, resp2.DispatchedJobID)
+
+	out2, err := state.JobByID(nil, job.Namespace, resp2.DispatchedJobID)
+	require.Nil(err)
+	require.Equal(out.PayloadBlob, out2.PayloadBlob)
+
+	blob2, err := state.DispatchBlobByID(nil, out.PayloadBlob)
+	require.Nil(err)
+	require.Equal(blob.CreateIndex, blob2.CreateIndex)
+	require.True(blob2.ModifyIndex > blob.ModifyIndex)
+
+	// The dispatch listing reports the size of the blob
+	get := &structs.JobSpecificRequest{
+		JobID: job.ID,
+		QueryOptions: structs.QueryOptions{
+			Region:    "global",
+			Namespace: job.Namespace,
+		},
+	}
+	var list structs.JobDispatchesResponse
+	require.Nil(msgpackrpc.CallWithCodec(codec, "Job.Dispatches", get, &list))
+	require.Len(list.Dispatches, 2)
+	for _, stub := range list.Dispatches {
+		require.Equal(len(payload), stub.PayloadSize)
+	}
+}
+
+func TestJobEndpoint_Dispatch_IdempotencyToken(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	s1 := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+	})
+	defer s1.Shutdown()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	// Create a parameterized job
+	job := mock.BatchJob()
+	job.ParameterizedJob = &structs.ParameterizedJobConfig{
+		MetaOptional: []string{"foo"},
+	}
+	regReq := &structs.JobRegisterRequest{
+		Job: job,
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			Namespace: job.Namespace,
+		},
+	}
+	var regResp structs.JobRegisterResponse
+	require.Nil(msgpackrpc.CallWithCodec(codec, "Job.Register", regReq, &regResp))
+
+	req := &structs.JobDispatchRequest{
+		JobID:            job.ID,
+		IdempotencyToken: "foo",
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			Namespace: job.Namespace,
+		},
+	}
+
+	// The first dispatch creates a new job
+	var resp1 structs.JobDispatchResponse
+	require.Nil(msgpackrpc.CallWithCodec(codec, "Job.Dispatch", req, &resp1))
+	require.NotEmpty(resp1.DispatchedJobID)
+	require.NotEmpty(resp1.EvalID)
+
+	// Dispatching with the same token returns the existing job
+	var resp2 structs.JobDispatchResponse
+	require.Nil(msgpackrpc.CallWithCodec(codec, "Job.Dispatch", req, &resp2))
+	require.Equal(resp1.DispatchedJobID, resp2.DispatchedJobID)
+	require.Equal(resp1.EvalID, resp2.EvalID)
+	require.Equal(resp1.JobCreateIndex, resp2.JobCreateIndex)
+
+	// Reusing the token with a different payload or meta is rejected
+	mismatched := *req
+	mismatched.Payload = []byte("other")
+	var resp5 structs.JobDispatchResponse
+	err := msgpackrpc.CallWithCodec(codec, "Job.Dispatch", &mismatched, &resp5)
+	require.Error(err)
+	require.Contains(err.Error(), "with a different payload or meta")
+
+	mismatched = *req
+	mismatched.Meta = map[string]string{"foo": "bar"}
+	err = msgpackrpc.CallWithCodec(codec, "Job.Dispatch", &mismatched, &resp5)
+	require.Error(err)
+	require.Contains(err.Error(), "with a different payload or meta")
+
+	// Dispatching with a different token creates a new job
+	req.IdempotencyToken = "bar"
+	var resp3 structs.JobDispatchResponse
+	require.Nil(msgpackrpc.CallWithCodec(codec, "Job.Dispatch", req, &resp3))
+	require.NotEqual(resp1.DispatchedJobID, resp3.DispatchedJobID)
+
+	state := s1.fsm.State()
+	out, err := state.JobByID(nil, job.Namespace, resp1.DispatchedJobID)
+	require.Nil(err)
+	require.NotNil(out)
+	require.Equal("foo", out.DispatchIdempotencyToken)
+	require.Equal(structs.DispatchRequestHash(nil, nil), out.DispatchRequestHash)
+
+	// A dispatch outside of the idempotency window creates a new job
+	out = out.Copy()
+	out.SubmitTime = time.Now().Add(-2 * DispatchIdempotencyTTL).UnixNano()
+	require.Nil(state.UpsertJob(regResp.Index+100, out))
+
+	req.IdempotencyToken = "foo"
+	var resp4 structs.JobDispatchResponse
+	require.Nil(msgpackrpc.CallWithCodec(codec, "Job.Dispatch", req, &resp4))
+	require.NotEqual(resp1.DispatchedJobID, resp4.DispatchedJobID)
+}
+
+func TestJobEndpoint_Dispatch_MaxConcurrent(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	s1 := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+	})
+	defer s1.Shutdown()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	// Create a parameterized job running one dispatched job at a time
+	job := mock.BatchJob()
+	job.ParameterizedJob = &structs.ParameterizedJobConfig{
+		MaxConcurrent: 1,
+	}
+	regReq := &structs.JobRegisterRequest{
+		Job: job,
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			Namespace: job.Namespace,
+		},
+	}
+	var regResp structs.JobRegisterResponse
+	require.Nil(msgpackrpc.CallWithCodec(codec, "Job.Register", regReq, &regResp))
+
+	req := &structs.JobDispatchRequest{
+		JobID: job.ID,
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			Namespace: job.Namespace,
+		},
+	}
+
+	// The first dispatched job is evaluated
+	var resp1 structs.JobDispatchResponse
+	require.Nil(msgpackrpc.CallWithCodec(codec, "Job.Dispatch", req, &resp1))
+	require.False(resp1.Queued)
+	require.NotEmpty(resp1.EvalID)
+
+	// The following dispatched jobs are queued
+	var resp2 structs.JobDispatchResponse
+	require.Nil(msgpackrpc.CallWithCodec(codec, "Job.Dispatch", req, &resp2))
+	require.True(resp2.Queued)
+	require.Empty(resp2.EvalID)
+
+	state := s1.fsm.State()
+	out, err := state.JobByID(nil, job.Namespace, resp2.DispatchedJobID)
+	require.Nil(err)
+	require.True(out.DispatchQueued)
+	require.Equal(structs.JobStatusQueued, out.Status)
+
+	evals, err := state.EvalsByJob(nil, job.Namespace, resp2.DispatchedJobID)
+	require.Nil(err)
+	require.Empty(evals)
+
+	summary, err := state.JobSummaryByID(nil, job.Namespace, job.ID)
+	require.Nil(err)
+	require.Equal(int64(1), summary.Children.Queued)
+	require.Equal(int64(1), summary.Children.Pending)
+
+	// Dispatching with an idempotency token returns the queued job
+	req.IdempotencyToken = "foo"
+	var resp3 structs.JobDispatchResponse
+	require.Nil(msgpackrpc.CallWithCodec(codec, "Job.Dispatch", req, &resp3))
+	require.True(resp3.Queued)
+
+	var resp4 structs.JobDispatchResponse
+	require.Nil(msgpackrpc.CallWithCodec(codec, "Job.Dispatch", req, &resp4))
+	require.Equal(resp3.DispatchedJobID, resp4.DispatchedJobID)
+	require.True(resp4.Queued)
+}
+
+func TestJobEndpoint_Dispatches(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	s1 := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+	})
+	defer s1.Shutdown()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+	state := s1.fsm.State()
+
+	// Create a parameterized job and an unrelated job sharing its prefix
+	job := mock.BatchJob()
+	job.ParameterizedJob = &structs.ParameterizedJobConfig{
+		Payload: structs.DispatchPayloadOptional,
+	}
+	require.Nil(state.UpsertJob(1000, job))
+
+	other := mock.BatchJob()
+	other.ID = job.ID + structs.DispatchLaunchSuffix + "other"
+	require.Nil(state.UpsertJob(1001, other))
+
+	req := &structs.JobDispatchRequest{
+		JobID:            job.ID,
+		Payload:          []byte("hello world"),
+		IdempotencyToken: "foo",
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			Namespace: job.Namespace,
+		},
+	}
+
+	var dispatchResp structs.JobDispatchResponse
+	require.Nil(msgpackrpc.CallWithCodec(codec, "Job.Dispatch", req, &dispatchResp))
+
+	// List the dispatched jobs
+	get := &structs.JobSpecificRequest{
+		JobID: job.ID,
+		QueryOptions: structs.QueryOptions{
+			Region:    "global",
+			Namespace: job.Namespace,
+		},
+	}
+	var resp structs.JobDispatchesResponse
+	require.Nil(msgpackrpc.CallWithCodec(codec, "Job.Dispatches", get, &resp))
+	require.Len(resp.Dispatches, 1)
+
+	stub := resp.Dispatches[0]
+	require.Equal(dispatchResp.DispatchedJobID, stub.ID)
+	require.Equal(job.ID, stub.ParentID)
+	require.Equal("foo", stub.IdempotencyToken)
+	require.Equal(len(req.Payload), stub.PayloadSize)
+	require.Equal(structs.JobStatusPending, stub.Status)
+	require.True(resp.Index >= dispatchResp.JobCreateIndex)
+}
+
+func TestJobEndpoint_Dispatches_Blocking(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	s1 := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+	})
+	defer s1.Shutdown()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+	state := s1.fsm.State()
+
+	job := mock.BatchJob()
+	job.ParameterizedJob = &structs.ParameterizedJobConfig{}
+	require.Nil(state.UpsertJob(100, job))
+
+	// Dispatch a job after a delay
+	child := job.Copy()
+	child.ID = job.ID + structs.DispatchLaunchSuffix + "123"
+	child.ParentID = job.ID
+	child.ParameterizedJob = nil
+	time.AfterFunc(100*time.Millisecond, func() {
+		if err := state.UpsertJob(200, child); err != nil {
+			t.Fatalf("err: %v", err)
+		}
+	})
+
+	get := &structs.JobSpecificRequest{
+		JobID: job.ID,
+		QueryOptions: structs.QueryOptions{
+			Region:        "global",
+			Namespace:     job.Namespace,
+			MinQueryIndex: 150,
+		},
+	}
+	start := time.Now()
+	var resp structs.JobDispatchesResponse
+	require.Nil(msgpackrpc.CallWithCodec(codec, "Job.Dispatches", get, &resp))
+	if elapsed := time.Since(start); elapsed < 100*time.Millisecond {
+		t.Fatalf("should block (returned in %s) %#v", elapsed, resp)
+	}
+	require.EqualValues(200, resp.Index)
+	require.Len(resp.Dispatches, 1)
+	require.Equal(child.ID, resp.Dispatches[0].ID)
+}
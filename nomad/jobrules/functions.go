@@ -0,0 +1,214 @@
+package jobrules
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/function"
+	"github.com/zclconf/go-cty/cty/function/stdlib"
+)
+
+// functions are the functions available in rule expressions.
+var functions = map[string]function.Function{
+	"alltrue":    AllTrueFunc,
+	"anytrue":    AnyTrueFunc,
+	"coalesce":   stdlib.CoalesceFunc,
+	"concat":     stdlib.ConcatFunc,
+	"contains":   ContainsFunc,
+	"format":     stdlib.FormatFunc,
+	"hasprefix":  HasPrefixFunc,
+	"hassuffix":  HasSuffixFunc,
+	"keys":       KeysFunc,
+	"length":     stdlib.LengthFunc,
+	"lookup":     LookupFunc,
+	"lower":      stdlib.LowerFunc,
+	"max":        stdlib.MaxFunc,
+	"min":        stdlib.MinFunc,
+	"regexmatch": RegexMatchFunc,
+	"upper":      stdlib.UpperFunc,
+}
+
+// AllTrueFunc returns whether all elements of a collection are true. It is
+// true for empty and null collections.
+var AllTrueFunc = function.New(&function.Spec{
+	Params: []function.Parameter{
+		{Name: "collection", Type: cty.DynamicPseudoType, AllowNull: true},
+	},
+	Type: function.StaticReturnType(cty.Bool),
+	Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+		return boolReduce(args[0], true)
+	},
+})
+
+// AnyTrueFunc returns whether any element of a collection is true. It is false
+// for empty and null collections.
+var AnyTrueFunc = function.New(&function.Spec{
+	Params: []function.Parameter{
+		{Name: "collection", Type: cty.DynamicPseudoType, AllowNull: true},
+	},
+	Type: function.StaticReturnType(cty.Bool),
+	Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+		return boolReduce(args[0], false)
+	},
+})
+
+// boolReduce returns all if all elements of the collection equal it, and the
+// negation of all otherwise.
+func boolReduce(collection cty.Value, all bool) (cty.Value, error) {
+	if collection.IsNull() {
+		return cty.BoolVal(all), nil
+	}
+	if !collection.CanIterateElements() {
+		return cty.UnknownVal(cty.Bool), fmt.Errorf("argument must be a collection, not %s", collection.Type().FriendlyName())
+	}
+
+	for it := collection.ElementIterator(); it.Next(); {
+		_, elem := it.Element()
+		if elem.IsNull() || elem.Type() != cty.Bool {
+			return cty.UnknownVal(cty.Bool), fmt.Errorf("all elements must be booleans")
+		}
+		if elem.True() != all {
+			return cty.BoolVal(!all), nil
+		}
+	}
+	return cty.BoolVal(all), nil
+}
+
+// ContainsFunc returns whether a collection contains a value. It is false for
+// null collections.
+var ContainsFunc = function.New(&function.Spec{
+	Params: []function.Parameter{
+		{Name: "collection", Type: cty.DynamicPseudoType, AllowNull: true},
+		{Name: "value", Type: cty.DynamicPseudoType, AllowNull: true},
+	},
+	Type: function.StaticReturnType(cty.Bool),
+	Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+		collection, value := args[0], args[1]
+		if collection.IsNull() {
+			return cty.False, nil
+		}
+		if !collection.CanIterateElements() {
+			return cty.UnknownVal(cty.Bool), fmt.Errorf("argument must be a collection, not %s", collection.Type().FriendlyName())
+		}
+
+		for it := collection.ElementIterator(); it.Next(); {
+			_, elem := it.Element()
+			if elem.Type().Equals(value.Type()) && elem.RawEquals(value) {
+				return cty.True, nil
+			}
+		}
+		return cty.False, nil
+	},
+})
+
+// HasPrefixFunc returns whether a string starts with a prefix.
+var HasPrefixFunc = function.New(&function.Spec{
+	Params: []function.Parameter{
+		{Name: "str", Type: cty.String},
+		{Name: "prefix", Type: cty.String},
+	},
+	Type: function.StaticReturnType(cty.Bool),
+	Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+		return cty.BoolVal(strings.HasPrefix(args[0].AsString(), args[1].AsString())), nil
+	},
+})
+
+// HasSuffixFunc returns whether a string ends with a suffix.
+var HasSuffixFunc = function.New(&function.Spec{
+	Params: []function.Parameter{
+		{Name: "str", Type: cty.String},
+		{Name: "suffix", Type: cty.String},
+	},
+	Type: function.StaticReturnType(cty.Bool),
+	Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+		return cty.BoolVal(strings.HasSuffix(args[0].AsString(), args[1].AsString())), nil
+	},
+})
+
+// KeysFunc returns the sorted keys of a map or object. It returns an empty
+// list for null values.
+var KeysFunc = function.New(&function.Spec{
+	Params: []function.Parameter{
+		{Name: "map", Type: cty.DynamicPseudoType, AllowNull: true},
+	},
+	Type: function.StaticReturnType(cty.List(cty.String)),
+	Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+		m := args[0]
+		if m.IsNull() {
+			return cty.ListValEmpty(cty.String), nil
+		}
+		if !m.Type().IsObjectType() && !m.Type().IsMapType() {
+			return cty.UnknownVal(retType), fmt.Errorf("argument must be a map or object, not %s", m.Type().FriendlyName())
+		}
+
+		var keys []string
+		for it := m.ElementIterator(); it.Next(); {
+			key, _ := it.Element()
+			keys = append(keys, key.AsString())
+		}
+		if len(keys) == 0 {
+			return cty.ListValEmpty(cty.String), nil
+		}
+
+		sort.Strings(keys)
+		vals := make([]cty.Value, len(keys))
+		for i, key := range keys {
+			vals[i] = cty.StringVal(key)
+		}
+		return cty.ListVal(vals), nil
+	},
+})
+
+// LookupFunc returns the value of a key of a map or object, or the default if
+// the key is not set or the map is null.
+var LookupFunc = function.New(&function.Spec{
+	Params: []function.Parameter{
+		{Name: "map", Type: cty.DynamicPseudoType, AllowNull: true},
+		{Name: "key", Type: cty.String},
+		{Name: "default", Type: cty.DynamicPseudoType, AllowNull: true},
+	},
+	Type: function.StaticReturnType(cty.DynamicPseudoType),
+	Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+		m, key, def := args[0], args[1].AsString(), args[2]
+		if m.IsNull() {
+			return def, nil
+		}
+
+		switch {
+		case m.Type().IsObjectType():
+			if m.Type().HasAttribute(key) {
+				if val := m.GetAttr(key); !val.IsNull() {
+					return val, nil
+				}
+			}
+		case m.Type().IsMapType():
+			if m.HasIndex(cty.StringVal(key)).True() {
+				if val := m.Index(cty.StringVal(key)); !val.IsNull() {
+					return val, nil
+				}
+			}
+		default:
+			return cty.DynamicVal, fmt.Errorf("argument must be a map or object, not %s", m.Type().FriendlyName())
+		}
+		return def, nil
+	},
+})
+
+// RegexMatchFunc returns whether a string matches a regular expression.
+var RegexMatchFunc = function.New(&function.Spec{
+	Params: []function.Parameter{
+		{Name: "pattern", Type: cty.String},
+		{Name: "str", Type: cty.String},
+	},
+	Type: function.StaticReturnType(cty.Bool),
+	Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+		re, err := regexp.Compile(args[0].AsString())
+		if err != nil {
+			return cty.UnknownVal(cty.Bool), fmt.Errorf("invalid regular expression: %v", err)
+		}
+		return cty.BoolVal(re.MatchString(args[1].AsString())), nil
+	},
+})
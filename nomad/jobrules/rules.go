@@ -0,0 +1,220 @@
+// Package jobrules implements operator defined validation rules for jobs.
+//
+// Rules are declared in HCL2 files and evaluate a condition expression over
+// the job, each of its task groups or each of its tasks:
+//
+//	rule "auto-revert" {
+//	  description = "Service jobs must revert failed deployments"
+//	  scope       = "group"
+//	  enforcement = "error"
+//	  condition   = job.Type != "service" || (group.Update != null ? group.Update.AutoRevert : false)
+//	}
+//
+// The job is available as its JSON representation in the job, group and task
+// variables.
+package jobrules
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+
+	"github.com/hashicorp/hcl2/hcl"
+	"github.com/hashicorp/hcl2/hcl/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+)
+
+const (
+	// EnforcementError rejects jobs that violate the rule
+	EnforcementError = "error"
+
+	// EnforcementWarning returns a warning for jobs that violate the rule
+	EnforcementWarning = "warning"
+)
+
+const (
+	// ScopeJob evaluates the rule once for the job
+	ScopeJob = "job"
+
+	// ScopeGroup evaluates the rule for each task group of the job
+	ScopeGroup = "group"
+
+	// ScopeTask evaluates the rule for each task of the job
+	ScopeTask = "task"
+)
+
+// Rule is a job validation rule.
+type Rule struct {
+	// Name is the unique name of the rule
+	Name string
+
+	// Description describes the rule and is used as the violation message if
+	// the rule has no message.
+	Description string
+
+	// Scope is the part of the job the rule is evaluated for: ScopeJob,
+	// ScopeGroup or ScopeTask.
+	Scope string
+
+	// Enforcement is whether violations are errors or warnings.
+	Enforcement string
+
+	// Condition is the expression that must be true for the job to satisfy
+	// the rule.
+	Condition hcl.Expression
+
+	// Message is an optional string expression describing a violation.
+	Message hcl.Expression
+
+	DeclRange hcl.Range
+}
+
+// Parse parses the rules declared in src. The filename is used in error
+// messages.
+func Parse(src []byte, filename string) ([]*Rule, error) {
+	file, diags := hclsyntax.ParseConfig(src, filename, hcl.Pos{Line: 1, Column: 1})
+	if diags.HasErrors() {
+		return nil, diags
+	}
+
+	content, diags := file.Body.Content(&hcl.BodySchema{
+		Blocks: []hcl.BlockHeaderSchema{
+			{Type: "rule", LabelNames: []string{"name"}},
+		},
+	})
+	if diags.HasErrors() {
+		return nil, diags
+	}
+
+	var rules []*Rule
+	for _, block := range content.Blocks {
+		rule, moreDiags := decodeRule(block)
+		diags = append(diags, moreDiags...)
+		if rule != nil {
+			rules = append(rules, rule)
+		}
+	}
+	if diags.HasErrors() {
+		return nil, diags
+	}
+
+	return rules, nil
+}
+
+// ParseFiles parses the rules of the files matching the given glob patterns.
+// Rule names must be unique across all files.
+func ParseFiles(patterns []string) ([]*Rule, error) {
+	var rules []*Rule
+	seen := map[string]*Rule{}
+
+	for _, pattern := range patterns {
+		paths, err := filepath.Glob(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid job validation rules path %q: %v", pattern, err)
+		}
+		if len(paths) == 0 {
+			return nil, fmt.Errorf("no job validation rules files match %q", pattern)
+		}
+		sort.Strings(paths)
+
+		for _, path := range paths {
+			src, err := ioutil.ReadFile(path)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read job validation rules file: %v", err)
+			}
+
+			fileRules, err := Parse(src, path)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse job validation rules file %q: %v", path, err)
+			}
+
+			for _, rule := range fileRules {
+				if existing, ok := seen[rule.Name]; ok {
+					return nil, fmt.Errorf("duplicate job validation rule %q at %s, previously declared at %s",
+						rule.Name, rule.DeclRange, existing.DeclRange)
+				}
+				seen[rule.Name] = rule
+				rules = append(rules, rule)
+			}
+		}
+	}
+
+	return rules, nil
+}
+
+func decodeRule(block *hcl.Block) (*Rule, hcl.Diagnostics) {
+	content, diags := block.Body.Content(&hcl.BodySchema{
+		Attributes: []hcl.AttributeSchema{
+			{Name: "description"},
+			{Name: "scope"},
+			{Name: "enforcement"},
+			{Name: "condition", Required: true},
+			{Name: "message"},
+		},
+	})
+	if diags.HasErrors() {
+		return nil, diags
+	}
+
+	rule := &Rule{
+		Name:        block.Labels[0],
+		Scope:       ScopeJob,
+		Enforcement: EnforcementError,
+		Condition:   content.Attributes["condition"].Expr,
+		DeclRange:   block.DefRange,
+	}
+	if attr, ok := content.Attributes["message"]; ok {
+		rule.Message = attr.Expr
+	}
+
+	strAttrs := []struct {
+		name    string
+		dst     *string
+		allowed []string
+	}{
+		{"description", &rule.Description, nil},
+		{"scope", &rule.Scope, []string{ScopeJob, ScopeGroup, ScopeTask}},
+		{"enforcement", &rule.Enforcement, []string{EnforcementError, EnforcementWarning}},
+	}
+	for _, a := range strAttrs {
+		attr, ok := content.Attributes[a.name]
+		if !ok {
+			continue
+		}
+
+		val, moreDiags := attr.Expr.Value(nil)
+		diags = append(diags, moreDiags...)
+		if moreDiags.HasErrors() {
+			continue
+		}
+		if val.Type() != cty.String || val.IsNull() || !isAllowed(val.AsString(), a.allowed) {
+			detail := fmt.Sprintf("The %s of a rule must be a string.", a.name)
+			if a.allowed != nil {
+				detail = fmt.Sprintf("The %s of a rule must be one of %q.", a.name, a.allowed)
+			}
+			diags = append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  fmt.Sprintf("Invalid rule %s", a.name),
+				Detail:   detail,
+				Subject:  attr.Expr.Range().Ptr(),
+			})
+			continue
+		}
+		*a.dst = val.AsString()
+	}
+
+	return rule, diags
+}
+
+func isAllowed(s string, allowed []string) bool {
+	if allowed == nil {
+		return true
+	}
+	for _, a := range allowed {
+		if s == a {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,169 @@
+package jobrules
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	multierror "github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/stretchr/testify/require"
+)
+
+const testRules = `
+rule "auto-revert" {
+  description = "Service jobs must revert failed deployments"
+  scope       = "group"
+  condition   = job.Type != "service" || (group.Update != null ? group.Update.AutoRevert : false)
+}
+
+rule "memory" {
+  scope     = "task"
+  condition = task.Resources.MemoryMB <= 256
+  message   = "task uses ${task.Resources.MemoryMB} MB of memory, at most 256 MB are allowed"
+}
+
+rule "registry" {
+  scope       = "task"
+  enforcement = "warning"
+  condition   = task.Driver != "docker" || hasprefix(lookup(task.Config, "image", ""), "registry.example.com/")
+}
+
+rule "owner" {
+  description = "Jobs must have an owner"
+  condition   = contains(keys(job.Meta), "owner")
+}
+`
+
+func TestParse(t *testing.T) {
+	require := require.New(t)
+
+	rules, err := Parse([]byte(testRules), "rules.hcl")
+	require.NoError(err)
+	require.Len(rules, 4)
+
+	require.Equal("auto-revert", rules[0].Name)
+	require.Equal(ScopeGroup, rules[0].Scope)
+	require.Equal(EnforcementError, rules[0].Enforcement)
+	require.Equal(ScopeJob, rules[3].Scope)
+	require.Equal(EnforcementWarning, rules[2].Enforcement)
+	require.NotNil(rules[1].Message)
+
+	cases := []struct {
+		Name string
+		Src  string
+		Err  string
+	}{
+		{
+			Name: "missing condition",
+			Src:  `rule "foo" {}`,
+			Err:  `"condition" is required`,
+		},
+		{
+			Name: "invalid scope",
+			Src:  "rule \"foo\" {\n  scope = \"node\"\n  condition = true\n}",
+			Err:  "Invalid rule scope",
+		},
+		{
+			Name: "invalid enforcement",
+			Src:  "rule \"foo\" {\n  enforcement = \"advisory\"\n  condition = true\n}",
+			Err:  "Invalid rule enforcement",
+		},
+		{
+			Name: "unknown attribute",
+			Src:  "rule \"foo\" {\n  when = true\n  condition = true\n}",
+			Err:  "Unsupported argument",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			_, err := Parse([]byte(tc.Src), "rules.hcl")
+			require.Error(err)
+			require.Contains(err.Error(), tc.Err)
+		})
+	}
+}
+
+func TestParseFiles(t *testing.T) {
+	require := require.New(t)
+
+	dir, err := ioutil.TempDir("", "nomad")
+	require.NoError(err)
+	defer os.RemoveAll(dir)
+
+	require.NoError(ioutil.WriteFile(filepath.Join(dir, "a.hcl"), []byte(testRules), 0600))
+	require.NoError(ioutil.WriteFile(filepath.Join(dir, "b.hcl"), []byte(`rule "b" { condition = true }`), 0600))
+
+	rules, err := ParseFiles([]string{filepath.Join(dir, "*.hcl")})
+	require.NoError(err)
+	require.Len(rules, 5)
+	require.Equal("b", rules[4].Name)
+
+	// Patterns must match files
+	_, err = ParseFiles([]string{filepath.Join(dir, "*.json")})
+	require.Error(err)
+
+	// Rule names must be unique across files
+	require.NoError(ioutil.WriteFile(filepath.Join(dir, "c.hcl"), []byte(`rule "memory" { condition = true }`), 0600))
+	_, err = ParseFiles([]string{filepath.Join(dir, "*.hcl")})
+	require.Error(err)
+	require.Contains(err.Error(), `duplicate job validation rule "memory"`)
+}
+
+func TestValidator_Validate(t *testing.T) {
+	require := require.New(t)
+
+	rules, err := Parse([]byte(testRules), "rules.hcl")
+	require.NoError(err)
+	v := NewValidator(rules)
+
+	// The mock job has no owner, no auto revert and a docker task with a
+	// public image
+	job := mock.Job()
+	delete(job.Meta, "owner")
+	job.TaskGroups[0].Tasks[0].Driver = "docker"
+	job.TaskGroups[0].Tasks[0].Config = map[string]interface{}{"image": "redis:3.2"}
+	job.TaskGroups[0].Tasks[0].Resources.MemoryMB = 512
+
+	errs, warnings := v.Validate(job)
+	require.Error(errs)
+	require.Len(errs.(*multierror.Error).Errors, 3)
+	require.Contains(errs.Error(), `rule "auto-revert" failed for job "`+job.ID+`", group "web": Service jobs must revert failed deployments`)
+	require.Contains(errs.Error(), `task "web": task uses 512 MB of memory, at most 256 MB are allowed`)
+	require.Contains(errs.Error(), `rule "owner" failed for job "`+job.ID+`": Jobs must have an owner`)
+
+	require.Error(warnings)
+	require.Len(warnings.(*multierror.Error).Errors, 1)
+	require.Contains(warnings.Error(), `rule "registry" failed`)
+
+	// Fix the job
+	job.Meta["owner"] = "platform"
+	job.TaskGroups[0].Update = structs.DefaultUpdateStrategy.Copy()
+	job.TaskGroups[0].Update.AutoRevert = true
+	job.TaskGroups[0].Tasks[0].Resources.MemoryMB = 256
+	job.TaskGroups[0].Tasks[0].Config["image"] = "registry.example.com/redis:3.2"
+
+	errs, warnings = v.Validate(job)
+	require.NoError(errs)
+	require.NoError(warnings)
+}
+
+func TestValidator_Validate_EvaluationError(t *testing.T) {
+	require := require.New(t)
+
+	rules, err := Parse([]byte(`
+rule "typo" {
+  condition = job.Typo == "service"
+}
+`), "rules.hcl")
+	require.NoError(err)
+
+	// Rules that can't be evaluated are violated
+	errs, _ := NewValidator(rules).Validate(mock.Job())
+	require.Error(errs)
+	require.Contains(errs.Error(), `rule "typo" failed`)
+	require.Contains(errs.Error(), "condition could not be evaluated")
+}
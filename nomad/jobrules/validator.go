@@ -0,0 +1,238 @@
+package jobrules
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	multierror "github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/hcl2/hcl"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/convert"
+)
+
+// Validator validates jobs against a set of rules.
+type Validator struct {
+	rules []*Rule
+}
+
+// NewValidator returns a validator for the given rules.
+func NewValidator(rules []*Rule) *Validator {
+	return &Validator{rules: rules}
+}
+
+// Rules returns the rules of the validator.
+func (v *Validator) Rules() []*Rule {
+	return v.rules
+}
+
+// Validate evaluates the rules against the job. Violations of rules enforced
+// as errors are returned as err, and violations of the other rules as
+// warnings.
+func (v *Validator) Validate(job *structs.Job) (err, warnings error) {
+	if len(v.rules) == 0 {
+		return nil, nil
+	}
+
+	jobVal, convErr := jobValue(job)
+	if convErr != nil {
+		return fmt.Errorf("failed to evaluate job validation rules: %v", convErr), nil
+	}
+
+	var mErr, mWarn multierror.Error
+	for _, rule := range v.rules {
+		for _, violation := range rule.evaluate(jobVal) {
+			if rule.Enforcement == EnforcementWarning {
+				multierror.Append(&mWarn, violation)
+			} else {
+				multierror.Append(&mErr, violation)
+			}
+		}
+	}
+
+	return mErr.ErrorOrNil(), mWarn.ErrorOrNil()
+}
+
+// jobValue returns the JSON representation of the job as a cty value.
+func jobValue(job *structs.Job) (cty.Value, error) {
+	buf, err := json.Marshal(job)
+	if err != nil {
+		return cty.NilVal, err
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(buf))
+	dec.UseNumber()
+	var raw interface{}
+	if err := dec.Decode(&raw); err != nil {
+		return cty.NilVal, err
+	}
+
+	return jsonValue(raw)
+}
+
+// jsonValue converts a decoded JSON value to a cty value. Objects become cty
+// objects and arrays become tuples, so elements may have different types.
+func jsonValue(raw interface{}) (cty.Value, error) {
+	switch v := raw.(type) {
+	case nil:
+		return cty.NullVal(cty.DynamicPseudoType), nil
+	case bool:
+		return cty.BoolVal(v), nil
+	case string:
+		return cty.StringVal(v), nil
+	case json.Number:
+		return cty.ParseNumberVal(v.String())
+	case []interface{}:
+		if len(v) == 0 {
+			return cty.EmptyTupleVal, nil
+		}
+		vals := make([]cty.Value, len(v))
+		for i, elem := range v {
+			val, err := jsonValue(elem)
+			if err != nil {
+				return cty.NilVal, err
+			}
+			vals[i] = val
+		}
+		return cty.TupleVal(vals), nil
+	case map[string]interface{}:
+		if len(v) == 0 {
+			return cty.EmptyObjectVal, nil
+		}
+		vals := make(map[string]cty.Value, len(v))
+		for key, elem := range v {
+			val, err := jsonValue(elem)
+			if err != nil {
+				return cty.NilVal, err
+			}
+			vals[key] = val
+		}
+		return cty.ObjectVal(vals), nil
+	}
+
+	return cty.NilVal, fmt.Errorf("unexpected JSON value of type %T", raw)
+}
+
+// scope is a part of the job a rule is evaluated for.
+type scope struct {
+	// desc describes the part of the job in violation messages
+	desc string
+
+	vars map[string]cty.Value
+}
+
+// evaluate returns the violations of the rule by the job.
+func (r *Rule) evaluate(job cty.Value) []error {
+	var violations []error
+	for _, s := range r.scopes(job) {
+		ctx := &hcl.EvalContext{
+			Variables: s.vars,
+			Functions: functions,
+		}
+
+		ok, msg := r.check(ctx)
+		if ok {
+			continue
+		}
+
+		if s.desc != "" {
+			msg = fmt.Sprintf("%s: %s", s.desc, msg)
+		}
+		violations = append(violations, fmt.Errorf("rule %q failed for %s", r.Name, msg))
+	}
+	return violations
+}
+
+// check evaluates the condition of the rule and returns the violation message
+// if it is not satisfied.
+func (r *Rule) check(ctx *hcl.EvalContext) (bool, string) {
+	result, diags := r.Condition.Value(ctx)
+	if diags.HasErrors() {
+		return false, fmt.Sprintf("condition could not be evaluated: %v", diags)
+	}
+
+	result, err := convert.Convert(result, cty.Bool)
+	if err != nil || result.IsNull() || !result.IsKnown() {
+		return false, "condition did not return a boolean"
+	}
+	if result.True() {
+		return true, ""
+	}
+
+	if r.Message != nil {
+		msg, diags := r.Message.Value(ctx)
+		if !diags.HasErrors() {
+			if msg, err := convert.Convert(msg, cty.String); err == nil && !msg.IsNull() && msg.IsKnown() {
+				return false, msg.AsString()
+			}
+		}
+	}
+	if r.Description != "" {
+		return false, r.Description
+	}
+	return false, "condition is false"
+}
+
+// scopes returns the parts of the job the rule is evaluated for.
+func (r *Rule) scopes(job cty.Value) []scope {
+	jobScope := scope{
+		desc: fmt.Sprintf("job %q", stringAttr(job, "ID")),
+		vars: map[string]cty.Value{"job": job},
+	}
+	if r.Scope == ScopeJob {
+		return []scope{jobScope}
+	}
+
+	var scopes []scope
+	for _, group := range elements(job, "TaskGroups") {
+		groupName := stringAttr(group, "Name")
+		if r.Scope == ScopeGroup {
+			scopes = append(scopes, scope{
+				desc: fmt.Sprintf("%s, group %q", jobScope.desc, groupName),
+				vars: map[string]cty.Value{"job": job, "group": group},
+			})
+			continue
+		}
+
+		for _, task := range elements(group, "Tasks") {
+			scopes = append(scopes, scope{
+				desc: fmt.Sprintf("%s, group %q, task %q", jobScope.desc, groupName, stringAttr(task, "Name")),
+				vars: map[string]cty.Value{"job": job, "group": group, "task": task},
+			})
+		}
+	}
+	return scopes
+}
+
+// elements returns the elements of the list attribute of the object.
+func elements(obj cty.Value, attr string) []cty.Value {
+	if obj.IsNull() || !obj.Type().IsObjectType() || !obj.Type().HasAttribute(attr) {
+		return nil
+	}
+
+	list := obj.GetAttr(attr)
+	if list.IsNull() || !list.CanIterateElements() {
+		return nil
+	}
+
+	var elems []cty.Value
+	for it := list.ElementIterator(); it.Next(); {
+		_, elem := it.Element()
+		elems = append(elems, elem)
+	}
+	return elems
+}
+
+// stringAttr returns the string attribute of the object, or an empty string.
+func stringAttr(obj cty.Value, attr string) string {
+	if obj.IsNull() || !obj.Type().IsObjectType() || !obj.Type().HasAttribute(attr) {
+		return ""
+	}
+
+	val := obj.GetAttr(attr)
+	if val.Type() != cty.String || val.IsNull() {
+		return ""
+	}
+	return val.AsString()
+}
@@ -0,0 +1,433 @@
+package nomad
+
+import (
+	"crypto"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	memdb "github.com/hashicorp/go-memdb"
+	version "github.com/hashicorp/go-version"
+	"github.com/hashicorp/nomad/acl"
+	"github.com/hashicorp/nomad/lib/auth/jwt"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// minKeyringVersion is the minimum version of all servers before the leader
+// initializes the keyring and allocations are given workload identities
+var minKeyringVersion = version.Must(version.NewVersion("0.9.2"))
+
+// keyringInitializeInterval is how often the leader retries initializing the
+// keyring until all servers are above the minimum version
+var keyringInitializeInterval = 1 * time.Second
+
+// keyringReplicationRetryInterval is how often servers retry fetching the key
+// material of root keys they are missing from the other servers
+var keyringReplicationRetryInterval = 5 * time.Second
+
+// initializeKeyring creates the first active root key if the keyring is
+// empty. It retries until it succeeds or leadership is lost.
+func (s *Server) initializeKeyring(stopCh chan struct{}) {
+	logger := s.logger.Named("core")
+	warned := false
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-stopCh:
+			return
+		case <-timer.C:
+		}
+
+		key, err := s.fsm.State().ActiveRootKey(nil)
+		if err != nil {
+			logger.Error("failed to lookup active root key", "error", err)
+			timer.Reset(keyringInitializeInterval)
+			continue
+		}
+		if key != nil {
+			return
+		}
+
+		if !ServersMeetMinimumVersion(s.Members(), minKeyringVersion, false) {
+			if !warned {
+				logger.Warn("can't initialize keyring until all servers are above minimum version", "min_version", minKeyringVersion)
+				warned = true
+			}
+			timer.Reset(keyringInitializeInterval)
+			continue
+		}
+
+		key, _, err = s.rotateRootKey()
+		if err != nil {
+			logger.Error("failed to initialize keyring", "error", err)
+			timer.Reset(keyringInitializeInterval)
+			continue
+		}
+		logger.Info("initialized keyring", "key_id", key.KeyID)
+		return
+	}
+}
+
+// rotateRootKey generates a new active root key and applies its metadata
+// through raft. The key material is written to the local keystore first so
+// that it can be replicated to the other servers as soon as they learn about
+// the key.
+func (s *Server) rotateRootKey() (*structs.RootKey, uint64, error) {
+	key, material, err := structs.NewRootKey()
+	if err != nil {
+		return nil, 0, err
+	}
+	if err := s.keystore.Put(material); err != nil {
+		return nil, 0, err
+	}
+
+	req := structs.KeyringUpsertRootKeyRequest{RootKey: key}
+	_, index, err := s.raftApply(structs.RootKeyUpsertRequestType, req)
+	if err != nil {
+		if err := s.keystore.Delete(key.KeyID); err != nil {
+			s.logger.Named("keyring").Warn("failed to delete unused root key", "key_id", key.KeyID, "error", err)
+		}
+		return nil, 0, fmt.Errorf("failed to apply root key: %v", err)
+	}
+	return key, index, nil
+}
+
+// rootKeyMaterial returns the key material of the root key from the keystore
+func (s *Server) rootKeyMaterial(key *structs.RootKey) (*structs.RootKeyMaterial, error) {
+	material := s.keystore.Get(key.KeyID)
+	if material == nil {
+		return nil, fmt.Errorf("key material of root key %q is not available yet", key.KeyID)
+	}
+	return material, nil
+}
+
+// replicateRootKeys runs on every server until it shuts down and keeps the
+// keystore in sync with the root keys in the state: the key material of new
+// root keys is fetched from the other servers and the material of deleted root
+// keys is removed.
+func (s *Server) replicateRootKeys() {
+	logger := s.logger.Named("keyring")
+
+	// seen are the root keys found in the state. Only the material of keys
+	// that were seen is deleted, as the leader writes the material of a new
+	// key to its keystore before applying the key.
+	seen := make(map[string]struct{})
+	for {
+		state := s.fsm.State()
+		ws := memdb.NewWatchSet()
+		ws.Add(state.AbandonCh())
+		ws.Add(s.shutdownCh)
+
+		missing := false
+		current := make(map[string]struct{})
+		iter, err := state.RootKeys(ws)
+		if err != nil {
+			logger.Error("failed to list root keys", "error", err)
+			missing = true
+		} else {
+			for raw := iter.Next(); raw != nil; raw = iter.Next() {
+				key := raw.(*structs.RootKey)
+				current[key.KeyID] = struct{}{}
+				seen[key.KeyID] = struct{}{}
+				if s.keystore.Get(key.KeyID) != nil {
+					continue
+				}
+				if err := s.fetchRootKey(key); err != nil {
+					logger.Warn("failed to replicate root key", "key_id", key.KeyID, "error", err)
+					missing = true
+					continue
+				}
+				logger.Debug("replicated root key", "key_id", key.KeyID)
+			}
+
+			for _, id := range s.keystore.KeyIDs() {
+				if _, ok := seen[id]; !ok {
+					continue
+				}
+				if _, ok := current[id]; ok {
+					continue
+				}
+				if err := s.keystore.Delete(id); err != nil {
+					logger.Error("failed to delete root key", "key_id", id, "error", err)
+					missing = true
+					continue
+				}
+				delete(seen, id)
+			}
+		}
+
+		var retryCh <-chan time.Time
+		if missing {
+			retryCh = time.After(keyringReplicationRetryInterval)
+		}
+		ws.Watch(retryCh)
+
+		select {
+		case <-s.shutdownCh:
+			return
+		default:
+		}
+	}
+}
+
+// fetchRootKey fetches the key material of the root key from the other
+// servers of the region and adds it to the keystore
+func (s *Server) fetchRootKey(key *structs.RootKey) error {
+	s.peerLock.RLock()
+	var peers []*serverParts
+	for _, peer := range s.peers[s.config.Region] {
+		if peer.ID != s.config.NodeID {
+			peers = append(peers, peer.Copy())
+		}
+	}
+	s.peerLock.RUnlock()
+
+	lastErr := fmt.Errorf("no server has the key material")
+	for _, peer := range peers {
+		args := structs.KeyringGetRootKeyRequest{
+			KeyID: key.KeyID,
+			QueryOptions: structs.QueryOptions{
+				Region:     s.config.Region,
+				AllowStale: true,
+			},
+		}
+		var resp structs.KeyringGetRootKeyResponse
+		if err := s.connPool.RPC(s.config.Region, peer.Addr, peer.MajorVersion, "Keyring.GetRootKey", &args, &resp); err != nil {
+			lastErr = fmt.Errorf("failed to fetch key material from %s: %v", peer.Name, err)
+			continue
+		}
+		if resp.Material == nil {
+			continue
+		}
+		if err := key.ValidateMaterial(resp.Material); err != nil {
+			lastErr = fmt.Errorf("invalid key material from %s: %v", peer.Name, err)
+			continue
+		}
+		return s.keystore.Put(resp.Material)
+	}
+	return lastErr
+}
+
+// signAllocIdentities signs a workload identity for each task of the
+// allocations that don't have one yet, using the active root key. The job is
+// used for allocations placed without their job attached.
+func (s *Server) signAllocIdentities(job *structs.Job, allocs []*structs.Allocation) error {
+	var unsigned []*structs.Allocation
+	for _, alloc := range allocs {
+		if alloc.SignedIdentities == nil {
+			unsigned = append(unsigned, alloc)
+		}
+	}
+	if len(unsigned) == 0 {
+		return nil
+	}
+
+	key, signer, err := s.activeSigner()
+	if err != nil {
+		return err
+	}
+	if key == nil {
+		// The keyring has not been initialized yet
+		return nil
+	}
+
+	now := time.Now().UTC()
+	for _, alloc := range unsigned {
+		allocJob := alloc.Job
+		if allocJob == nil {
+			allocJob = job
+		}
+		if allocJob == nil {
+			continue
+		}
+		tg := allocJob.LookupTaskGroup(alloc.TaskGroup)
+		if tg == nil {
+			continue
+		}
+
+		identities, err := signTaskIdentities(key, signer, alloc, tg, now)
+		if err != nil {
+			return err
+		}
+		alloc.SignedIdentities = identities
+		alloc.SigningKeyID = key.KeyID
+	}
+	return nil
+}
+
+// activeSigner returns the active root key and its signer. The key is nil if
+// the keyring has not been initialized yet.
+func (s *Server) activeSigner() (*structs.RootKey, crypto.Signer, error) {
+	key, err := s.fsm.State().ActiveRootKey(nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	if key == nil {
+		return nil, nil, nil
+	}
+	material, err := s.rootKeyMaterial(key)
+	if err != nil {
+		return nil, nil, err
+	}
+	signer, err := material.Signer()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to decode root key %q: %v", key.KeyID, err)
+	}
+	return key, signer, nil
+}
+
+// signTaskIdentities signs a workload identity for each task of the task
+// group of the allocation with the given root key.
+func signTaskIdentities(key *structs.RootKey, signer crypto.Signer, alloc *structs.Allocation,
+	tg *structs.TaskGroup, now time.Time) (map[string]string, error) {
+
+	identities := make(map[string]string, len(tg.Tasks))
+	for _, task := range tg.Tasks {
+		claims := structs.NewIdentityClaims(alloc, task.Name, now)
+		token, err := jwt.Sign(claims, key.KeyID, signer)
+		if err != nil {
+			return nil, fmt.Errorf("failed to sign identity of task %q: %v", task.Name, err)
+		}
+		identities[task.Name] = token
+	}
+	return identities, nil
+}
+
+// encryptVariable encrypts the items of the variable with the active root
+// key
+func (s *Server) encryptVariable(v *structs.VariableDecrypted) (*structs.VariableEncrypted, error) {
+	key, err := s.fsm.State().ActiveRootKey(nil)
+	if err != nil {
+		return nil, err
+	}
+	if key == nil {
+		return nil, fmt.Errorf("keyring has not been initialized yet")
+	}
+	material, err := s.rootKeyMaterial(key)
+	if err != nil {
+		return nil, err
+	}
+
+	plaintext, err := json.Marshal(v.Items)
+	if err != nil {
+		return nil, err
+	}
+	data, err := material.Encrypt(plaintext)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt variable: %v", err)
+	}
+
+	return &structs.VariableEncrypted{
+		VariableMetadata: v.VariableMetadata,
+		VariableData: structs.VariableData{
+			Data:  data,
+			KeyID: key.KeyID,
+		},
+	}, nil
+}
+
+// decryptVariable decrypts the items of the variable with the root key that
+// encrypted them
+func (s *Server) decryptVariable(v *structs.VariableEncrypted) (*structs.VariableDecrypted, error) {
+	key, err := s.fsm.State().RootKeyByID(nil, v.KeyID)
+	if err != nil {
+		return nil, err
+	}
+	if key == nil {
+		return nil, fmt.Errorf("root key %q of variable %q not found", v.KeyID, v.Path)
+	}
+	material, err := s.rootKeyMaterial(key)
+	if err != nil {
+		return nil, err
+	}
+
+	plaintext, err := material.Decrypt(v.Data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt variable %q: %v", v.Path, err)
+	}
+	var items structs.VariableItems
+	if err := json.Unmarshal(plaintext, &items); err != nil {
+		return nil, fmt.Errorf("failed to decode variable %q: %v", v.Path, err)
+	}
+
+	return &structs.VariableDecrypted{
+		VariableMetadata: v.VariableMetadata,
+		Items:            items,
+	}, nil
+}
+
+// resolveIdentityClaims verifies a workload identity against the keyring and
+// returns its claims. Identities of allocations that no longer exist or are
+// terminal are rejected.
+func (s *Server) resolveIdentityClaims(token string) (*structs.IdentityClaims, error) {
+	snap, err := s.fsm.State().Snapshot()
+	if err != nil {
+		return nil, err
+	}
+
+	iter, err := snap.RootKeys(nil)
+	if err != nil {
+		return nil, err
+	}
+	keys := make(map[string]crypto.PublicKey)
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		key := raw.(*structs.RootKey)
+		pub, err := x509.ParsePKIXPublicKey(key.PublicKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode root key %q: %v", key.KeyID, err)
+		}
+		keys[key.KeyID] = pub
+	}
+
+	raw, err := jwt.NewKeySet(keys).Verify(token)
+	if err != nil {
+		return nil, err
+	}
+	if err := jwt.ValidateClaims(raw, jwt.Expected{Issuer: structs.WorkloadIdentityIssuer}); err != nil {
+		return nil, err
+	}
+
+	// Decode the verified claims
+	buf, err := json.Marshal(raw)
+	if err != nil {
+		return nil, err
+	}
+	var claims structs.IdentityClaims
+	if err := json.Unmarshal(buf, &claims); err != nil {
+		return nil, err
+	}
+
+	alloc, err := snap.AllocByID(nil, claims.AllocationID)
+	if err != nil {
+		return nil, err
+	}
+	if alloc == nil || alloc.TerminalStatus() {
+		return nil, fmt.Errorf("allocation %q of workload identity is not running", claims.AllocationID)
+	}
+	return &claims, nil
+}
+
+// resolveTokenOrIdentity resolves either the ACL of a token or the claims of
+// a workload identity. Both are nil if ACLs are disabled and the token is not
+// a workload identity.
+func (s *Server) resolveTokenOrIdentity(token string) (*acl.ACL, *structs.IdentityClaims, error) {
+	// Workload identities are JWTs, which ACL secret IDs never are
+	if strings.Count(token, ".") == 2 {
+		claims, err := s.resolveIdentityClaims(token)
+		if err != nil {
+			s.logger.Debug("failed to resolve workload identity", "error", err)
+			return nil, nil, structs.ErrPermissionDenied
+		}
+		return nil, claims, nil
+	}
+
+	aclObj, err := s.ResolveToken(token)
+	if err != nil {
+		return nil, nil, err
+	}
+	return aclObj, nil, nil
+}
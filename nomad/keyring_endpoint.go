@@ -0,0 +1,194 @@
+package nomad
+
+import (
+	"fmt"
+	"net"
+	"time"
+
+	metrics "github.com/armon/go-metrics"
+	log "github.com/hashicorp/go-hclog"
+	memdb "github.com/hashicorp/go-memdb"
+	"github.com/hashicorp/nomad/nomad/state"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// Keyring endpoint is used to manage the root keys used to sign workload
+// identities
+type Keyring struct {
+	srv    *Server
+	logger log.Logger
+
+	// ctx provides context regarding the underlying connection
+	ctx *RPCContext
+}
+
+// Rotate generates a new active root key. The previously active key is kept
+// to verify the identities it signed.
+func (k *Keyring) Rotate(args *structs.KeyringRotateRootKeyRequest, reply *structs.KeyringRotateRootKeyResponse) error {
+	if done, err := k.srv.forward("Keyring.Rotate", args, args, reply); done {
+		return err
+	}
+	defer metrics.MeasureSince([]string{"nomad", "keyring", "rotate"}, time.Now())
+
+	// Check management level permissions
+	if acl, err := k.srv.ResolveToken(args.AuthToken); err != nil {
+		return err
+	} else if acl != nil && !acl.IsManagement() {
+		return structs.ErrPermissionDenied
+	}
+
+	key, index, err := k.srv.rotateRootKey()
+	if err != nil {
+		return err
+	}
+
+	reply.KeyID = key.KeyID
+	reply.Index = index
+	return nil
+}
+
+// Delete removes a set of inactive root keys
+func (k *Keyring) Delete(args *structs.KeyringDeleteRootKeyRequest, reply *structs.GenericResponse) error {
+	if done, err := k.srv.forward("Keyring.Delete", args, args, reply); done {
+		return err
+	}
+	defer metrics.MeasureSince([]string{"nomad", "keyring", "delete"}, time.Now())
+
+	// Check management level permissions
+	if acl, err := k.srv.ResolveToken(args.AuthToken); err != nil {
+		return err
+	} else if acl != nil && !acl.IsManagement() {
+		return structs.ErrPermissionDenied
+	}
+
+	// Validate non-zero set of keys
+	if len(args.KeyIDs) == 0 {
+		return fmt.Errorf("must specify as least one root key")
+	}
+
+	// The active key can only be replaced by a rotation
+	snap, err := k.srv.fsm.State().Snapshot()
+	if err != nil {
+		return err
+	}
+	for _, id := range args.KeyIDs {
+		key, err := snap.RootKeyByID(nil, id)
+		if err != nil {
+			return err
+		}
+		if key != nil && key.IsActive() {
+			return fmt.Errorf("active root key %q can not be deleted", id)
+		}
+	}
+
+	// Update via Raft
+	_, index, err := k.srv.raftApply(structs.RootKeyDeleteRequestType, args)
+	if err != nil {
+		return err
+	}
+
+	reply.Index = index
+	return nil
+}
+
+// ListPublic lists the public keys of the keyring. It requires no token so
+// that other systems can verify workload identities.
+func (k *Keyring) ListPublic(args *structs.KeyringListPublicRequest, reply *structs.KeyringListPublicResponse) error {
+	if done, err := k.srv.forward("Keyring.ListPublic", args, args, reply); done {
+		return err
+	}
+	defer metrics.MeasureSince([]string{"nomad", "keyring", "list_public"}, time.Now())
+
+	// Setup the blocking query
+	opts := blockingOptions{
+		queryOpts: &args.QueryOptions,
+		queryMeta: &reply.QueryMeta,
+		run: func(ws memdb.WatchSet, state *state.StateStore) error {
+			iter, err := state.RootKeys(ws)
+			if err != nil {
+				return err
+			}
+
+			reply.PublicKeys = nil
+			for raw := iter.Next(); raw != nil; raw = iter.Next() {
+				key := raw.(*structs.RootKey)
+				reply.PublicKeys = append(reply.PublicKeys, key.Public())
+			}
+
+			// Use the last index that affected the root key table
+			index, err := state.Index("root_keys")
+			if err != nil {
+				return err
+			}
+
+			// Ensure we never set the index to zero, otherwise a blocking query cannot be used.
+			// We floor the index at one, since realistically the first write must have a higher index.
+			if index == 0 {
+				index = 1
+			}
+			reply.Index = index
+			return nil
+		}}
+	return k.srv.blockingRPC(&opts)
+}
+
+// GetRootKey returns the key material of a root key from the keystore of the
+// server. It is used by the servers to replicate the key material, which is
+// not replicated through raft, so it is never forwarded and is only served to
+// the other servers of the region.
+func (k *Keyring) GetRootKey(args *structs.KeyringGetRootKeyRequest, reply *structs.KeyringGetRootKeyResponse) error {
+	defer metrics.MeasureSince([]string{"nomad", "keyring", "get_root_key"}, time.Now())
+
+	if !k.fromServer() {
+		return structs.ErrPermissionDenied
+	}
+
+	key, err := k.srv.fsm.State().RootKeyByID(nil, args.KeyID)
+	if err != nil {
+		return err
+	}
+	if key != nil {
+		reply.Material = k.srv.keystore.Get(key.KeyID)
+	}
+	k.srv.setQueryMeta(&reply.QueryMeta)
+	return nil
+}
+
+// fromServer returns whether the RPC was made by a server of the region. With
+// mutual TLS the caller must present a server certificate of the region,
+// otherwise it must connect from the address of a known server.
+func (k *Keyring) fromServer() bool {
+	if k.ctx == nil || k.ctx.Conn == nil {
+		return false
+	}
+
+	region := k.srv.Region()
+	if k.ctx.TLS && len(k.ctx.VerifiedChains) > 0 && len(k.ctx.VerifiedChains[0]) > 0 {
+		expected := fmt.Sprintf("server.%s.nomad", region)
+		cert := k.ctx.VerifiedChains[0][0]
+		for _, name := range cert.DNSNames {
+			if name == expected {
+				return true
+			}
+		}
+		return cert.Subject.CommonName == expected
+	}
+
+	host, _, err := net.SplitHostPort(k.ctx.Conn.RemoteAddr().String())
+	if err != nil {
+		return false
+	}
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return false
+	}
+
+	k.srv.peerLock.RLock()
+	defer k.srv.peerLock.RUnlock()
+	for _, peer := range k.srv.peers[region] {
+		if addr, ok := peer.Addr.(*net.TCPAddr); ok && addr.IP.Equal(ip) {
+			return true
+		}
+	}
+	return false
+}
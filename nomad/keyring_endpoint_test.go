@@ -0,0 +1,162 @@
+package nomad
+
+import (
+	"testing"
+	"time"
+
+	msgpackrpc "github.com/hashicorp/net-rpc-msgpackrpc"
+	"github.com/hashicorp/nomad/helper/uuid"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeyringEndpoint_RotateDelete(t *testing.T) {
+	t.Parallel()
+	s1, root := TestACLServer(t, func(c *Config) {
+		c.Build = "0.9.2+unittest"
+	})
+	defer s1.Shutdown()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	// The leader initializes the keyring
+	first := waitForActiveRootKey(t, s1)
+
+	// Non-management tokens cannot rotate the keyring
+	policy := mock.ACLPolicy()
+	s1.fsm.State().UpsertACLPolicies(1000, []*structs.ACLPolicy{policy})
+	token := mock.CreateToken(t, s1.fsm.State(), 1001, []string{policy.Name})
+
+	req := &structs.KeyringRotateRootKeyRequest{
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			AuthToken: token.SecretID,
+		},
+	}
+	var resp structs.KeyringRotateRootKeyResponse
+	err := msgpackrpc.CallWithCodec(codec, "Keyring.Rotate", req, &resp)
+	require.EqualError(t, err, structs.ErrPermissionDenied.Error())
+
+	req.AuthToken = root.SecretID
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "Keyring.Rotate", req, &resp))
+	require.NotEqual(t, uint64(0), resp.Index)
+	require.NotEqual(t, first.KeyID, resp.KeyID)
+
+	active, err := s1.fsm.State().ActiveRootKey(nil)
+	require.NoError(t, err)
+	require.Equal(t, resp.KeyID, active.KeyID)
+
+	// The active key can not be deleted
+	delReq := &structs.KeyringDeleteRootKeyRequest{
+		KeyIDs: []string{active.KeyID},
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			AuthToken: root.SecretID,
+		},
+	}
+	var delResp structs.GenericResponse
+	err = msgpackrpc.CallWithCodec(codec, "Keyring.Delete", delReq, &delResp)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "can not be deleted")
+
+	// Non-management tokens cannot delete keys
+	delReq.KeyIDs = []string{first.KeyID}
+	delReq.AuthToken = token.SecretID
+	err = msgpackrpc.CallWithCodec(codec, "Keyring.Delete", delReq, &delResp)
+	require.EqualError(t, err, structs.ErrPermissionDenied.Error())
+
+	delReq.AuthToken = root.SecretID
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "Keyring.Delete", delReq, &delResp))
+	require.NotEqual(t, uint64(0), delResp.Index)
+
+	out, err := s1.fsm.State().RootKeyByID(nil, first.KeyID)
+	require.NoError(t, err)
+	require.Nil(t, out)
+}
+
+func TestKeyringEndpoint_ListPublic(t *testing.T) {
+	t.Parallel()
+	s1, _ := TestACLServer(t, func(c *Config) {
+		c.Build = "0.9.2+unittest"
+	})
+	defer s1.Shutdown()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	key := waitForActiveRootKey(t, s1)
+
+	// Listing the public keys requires no token
+	req := &structs.KeyringListPublicRequest{
+		QueryOptions: structs.QueryOptions{Region: "global"},
+	}
+	var resp structs.KeyringListPublicResponse
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "Keyring.ListPublic", req, &resp))
+	require.Len(t, resp.PublicKeys, 1)
+	require.Equal(t, key.KeyID, resp.PublicKeys[0].KeyID)
+	require.Equal(t, structs.RootKeyAlgorithmES256, resp.PublicKeys[0].Algorithm)
+	require.NotEmpty(t, resp.PublicKeys[0].PublicKey)
+
+	// Rotating the keyring unblocks the query
+	time.AfterFunc(100*time.Millisecond, func() {
+		s1.rotateRootKey()
+	})
+	req.MinQueryIndex = resp.Index
+	start := time.Now()
+	var resp2 structs.KeyringListPublicResponse
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "Keyring.ListPublic", req, &resp2))
+	require.True(t, time.Since(start) >= 100*time.Millisecond)
+	require.True(t, resp2.Index > resp.Index)
+	require.Len(t, resp2.PublicKeys, 2)
+}
+
+func TestKeyringEndpoint_GetRootKey(t *testing.T) {
+	t.Parallel()
+	s1, root := TestACLServer(t, func(c *Config) {
+		c.Build = "0.9.2+unittest"
+	})
+	defer s1.Shutdown()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	key := waitForActiveRootKey(t, s1)
+
+	// The test connects from the address of the server, so it is served the
+	// key material without a token
+	req := &structs.KeyringGetRootKeyRequest{
+		KeyID:        key.KeyID,
+		QueryOptions: structs.QueryOptions{Region: "global"},
+	}
+	var resp structs.KeyringGetRootKeyResponse
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "Keyring.GetRootKey", req, &resp))
+	require.NotNil(t, resp.Material)
+	require.NoError(t, key.ValidateMaterial(resp.Material))
+
+	// Unknown keys have no material
+	req.KeyID = uuid.Generate()
+	var resp2 structs.KeyringGetRootKeyResponse
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "Keyring.GetRootKey", req, &resp2))
+	require.Nil(t, resp2.Material)
+
+	// Callers that aren't servers are denied, even with a management token
+	req.KeyID = key.KeyID
+	req.AuthToken = root.SecretID
+	var resp3 structs.KeyringGetRootKeyResponse
+	err := s1.RPC("Keyring.GetRootKey", req, &resp3)
+	require.EqualError(t, err, structs.ErrPermissionDenied.Error())
+	require.Nil(t, resp3.Material)
+}
+
+// waitForActiveRootKey waits for the leader to initialize the keyring
+func waitForActiveRootKey(t *testing.T, s *Server) *structs.RootKey {
+	var key *structs.RootKey
+	testutil.WaitForResult(func() (bool, error) {
+		var err error
+		key, err = s.fsm.State().ActiveRootKey(nil)
+		return key != nil, err
+	}, func(err error) {
+		t.Fatalf("keyring not initialized: %v", err)
+	})
+	return key
+}
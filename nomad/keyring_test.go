@@ -0,0 +1,121 @@
+package nomad
+
+import (
+	"crypto"
+	"crypto/x509"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/nomad/lib/auth/jwt"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServer_SignAllocIdentities(t *testing.T) {
+	t.Parallel()
+	s1 := TestServer(t, func(c *Config) {
+		c.Build = "0.9.2+unittest"
+	})
+	defer s1.Shutdown()
+	testutil.WaitForLeader(t, s1.RPC)
+
+	key := waitForActiveRootKey(t, s1)
+
+	// Allocations placed without their job use the plan's job
+	job := mock.Job()
+	alloc1 := mock.Alloc()
+	alloc1.Job = nil
+	alloc1.JobID = job.ID
+	alloc2 := mock.Alloc()
+	alloc2.SignedIdentities = map[string]string{"web": "existing"}
+	require.NoError(t, s1.signAllocIdentities(job, []*structs.Allocation{alloc1, alloc2}))
+
+	// Already signed allocations are left untouched
+	require.Equal(t, map[string]string{"web": "existing"}, alloc2.SignedIdentities)
+	require.Empty(t, alloc2.SigningKeyID)
+
+	require.Equal(t, key.KeyID, alloc1.SigningKeyID)
+	require.Len(t, alloc1.SignedIdentities, 1)
+	token := alloc1.SignedIdentities["web"]
+	require.NotEmpty(t, token)
+
+	// The identity verifies against the published keys
+	pub, err := x509.ParsePKIXPublicKey(key.PublicKey)
+	require.NoError(t, err)
+	jwks, err := jwt.MarshalJWKS(map[string]crypto.PublicKey{key.KeyID: pub})
+	require.NoError(t, err)
+	keySet, err := jwt.ParseJWKS(jwks)
+	require.NoError(t, err)
+
+	claims, err := keySet.Verify(token)
+	require.NoError(t, err)
+	require.Equal(t, alloc1.Namespace, claims["nomad_namespace"])
+	require.Equal(t, job.ID, claims["nomad_job_id"])
+	require.Equal(t, alloc1.TaskGroup, claims["nomad_task_group"])
+	require.Equal(t, "web", claims["nomad_task"])
+	require.Equal(t, alloc1.ID, claims["nomad_allocation_id"])
+	require.Equal(t, structs.WorkloadIdentityIssuer, claims["iss"])
+}
+
+func TestServer_SignAllocIdentities_NoKeyring(t *testing.T) {
+	t.Parallel()
+	s1 := TestServer(t, func(c *Config) {
+		c.DevDisableBootstrap = true
+	})
+	defer s1.Shutdown()
+
+	// Without a leader the keyring is never initialized
+	alloc := mock.Alloc()
+	require.NoError(t, s1.signAllocIdentities(nil, []*structs.Allocation{alloc}))
+	require.Nil(t, alloc.SignedIdentities)
+	require.Empty(t, alloc.SigningKeyID)
+}
+
+func TestServer_ReplicateRootKeys(t *testing.T) {
+	t.Parallel()
+	s1 := TestServer(t, func(c *Config) {
+		c.Build = "0.9.2+unittest"
+	})
+	defer s1.Shutdown()
+	s2 := TestServer(t, func(c *Config) {
+		c.Build = "0.9.2+unittest"
+		c.DevDisableBootstrap = true
+	})
+	defer s2.Shutdown()
+	TestJoin(t, s1, s2)
+	testutil.WaitForLeader(t, s1.RPC)
+	testutil.WaitForLeader(t, s2.RPC)
+
+	// The follower fetches the key material from the leader
+	first := waitForActiveRootKey(t, s1)
+	waitForRootKeyMaterial(t, s2, first.KeyID, true)
+	require.Equal(t, s1.keystore.Get(first.KeyID), s2.keystore.Get(first.KeyID))
+
+	// And the material of rotated keys
+	second, _, err := s1.rotateRootKey()
+	require.NoError(t, err)
+	waitForRootKeyMaterial(t, s2, second.KeyID, true)
+
+	// The material of deleted keys is removed from every server
+	req := structs.KeyringDeleteRootKeyRequest{KeyIDs: []string{first.KeyID}}
+	_, _, err = s1.raftApply(structs.RootKeyDeleteRequestType, req)
+	require.NoError(t, err)
+	waitForRootKeyMaterial(t, s1, first.KeyID, false)
+	waitForRootKeyMaterial(t, s2, first.KeyID, false)
+	require.NotNil(t, s2.keystore.Get(second.KeyID))
+}
+
+// waitForRootKeyMaterial waits for the keystore of the server to have, or not
+// have, the key material of the root key
+func waitForRootKeyMaterial(t *testing.T, s *Server, keyID string, exists bool) {
+	testutil.WaitForResult(func() (bool, error) {
+		if found := s.keystore.Get(keyID) != nil; found != exists {
+			return false, fmt.Errorf("key material of %q found: %v", keyID, found)
+		}
+		return true, nil
+	}, func(err error) {
+		t.Fatalf("err: %v", err)
+	})
+}
@@ -0,0 +1,148 @@
+package nomad
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	uuid "github.com/hashicorp/go-uuid"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+const (
+	// keystorePath is the directory of the server's data dir holding the
+	// key material of the root keys
+	keystorePath = "keystore"
+
+	// keystoreExt is the extension of the files of the keystore
+	keystoreExt = ".json"
+)
+
+// keystore holds the key material of the root keys of the keyring. Only the
+// metadata of the root keys is replicated through raft; each server keeps the
+// material in its own keystore, which is persisted to its data dir so that it
+// never lands in raft logs or snapshots.
+type keystore struct {
+	// dir is the directory the key material is written to. The keystore is
+	// only kept in memory if it is empty.
+	dir string
+
+	keys map[string]*structs.RootKeyMaterial
+	l    sync.RWMutex
+}
+
+// newKeystore returns a keystore persisted to the given directory, loading the
+// key material already written to it. An empty directory returns an in-memory
+// keystore.
+func newKeystore(dir string) (*keystore, error) {
+	k := &keystore{
+		dir:  dir,
+		keys: make(map[string]*structs.RootKeyMaterial),
+	}
+	if dir == "" {
+		return k, nil
+	}
+
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, fmt.Errorf("failed to create keystore dir: %v", err)
+	}
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read keystore dir: %v", err)
+	}
+	for _, file := range files {
+		name := file.Name()
+		if file.IsDir() || !strings.HasSuffix(name, keystoreExt) {
+			continue
+		}
+
+		buf, err := ioutil.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read root key %q: %v", name, err)
+		}
+		var material structs.RootKeyMaterial
+		if err := json.Unmarshal(buf, &material); err != nil {
+			return nil, fmt.Errorf("failed to decode root key %q: %v", name, err)
+		}
+		if material.KeyID+keystoreExt != name {
+			return nil, fmt.Errorf("root key %q has mismatched key ID %q", name, material.KeyID)
+		}
+		k.keys[material.KeyID] = &material
+	}
+	return k, nil
+}
+
+// Get returns the key material of the root key or nil if the keystore doesn't
+// have it
+func (k *keystore) Get(keyID string) *structs.RootKeyMaterial {
+	k.l.RLock()
+	defer k.l.RUnlock()
+	return k.keys[keyID]
+}
+
+// KeyIDs returns the IDs of the root keys in the keystore
+func (k *keystore) KeyIDs() []string {
+	k.l.RLock()
+	defer k.l.RUnlock()
+
+	ids := make([]string, 0, len(k.keys))
+	for id := range k.keys {
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+// Put adds the key material of a root key to the keystore, writing it to disk
+// before it can be used
+func (k *keystore) Put(material *structs.RootKeyMaterial) error {
+	// Key IDs are used as file names
+	if _, err := uuid.ParseUUID(material.KeyID); err != nil {
+		return fmt.Errorf("invalid root key ID %q", material.KeyID)
+	}
+
+	k.l.Lock()
+	defer k.l.Unlock()
+
+	if k.dir != "" {
+		buf, err := json.Marshal(material)
+		if err != nil {
+			return err
+		}
+
+		// The file is replaced atomically so that a crash never leaves a
+		// partially written key
+		path := filepath.Join(k.dir, material.KeyID+keystoreExt)
+		tmp := path + ".tmp"
+		if err := ioutil.WriteFile(tmp, buf, 0600); err != nil {
+			return fmt.Errorf("failed to write root key %q: %v", material.KeyID, err)
+		}
+		if err := os.Rename(tmp, path); err != nil {
+			return fmt.Errorf("failed to write root key %q: %v", material.KeyID, err)
+		}
+	}
+
+	k.keys[material.KeyID] = material.Copy()
+	return nil
+}
+
+// Delete removes the key material of a root key from the keystore
+func (k *keystore) Delete(keyID string) error {
+	k.l.Lock()
+	defer k.l.Unlock()
+
+	if _, ok := k.keys[keyID]; !ok {
+		return nil
+	}
+	if k.dir != "" {
+		path := filepath.Join(k.dir, keyID+keystoreExt)
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to delete root key %q: %v", keyID, err)
+		}
+	}
+	delete(k.keys, keyID)
+	return nil
+}
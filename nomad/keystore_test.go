@@ -0,0 +1,69 @@
+package nomad
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeystore(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	tmp, err := ioutil.TempDir("", "nomad-keystore")
+	require.NoError(err)
+	defer os.RemoveAll(tmp)
+	dir := filepath.Join(tmp, keystorePath)
+
+	ks, err := newKeystore(dir)
+	require.NoError(err)
+	require.Empty(ks.KeyIDs())
+
+	key, material := mock.RootKeyWithMaterial()
+	require.NoError(ks.Put(material))
+	require.Equal(material, ks.Get(key.KeyID))
+	require.Equal([]string{key.KeyID}, ks.KeyIDs())
+
+	// The material is only readable by the server
+	fi, err := os.Stat(filepath.Join(dir, key.KeyID+keystoreExt))
+	require.NoError(err)
+	require.Equal(os.FileMode(0600), fi.Mode().Perm())
+
+	// The material is loaded again on restart
+	ks2, err := newKeystore(dir)
+	require.NoError(err)
+	require.Equal(material, ks2.Get(key.KeyID))
+
+	// Key IDs are used as file names
+	bad := material.Copy()
+	bad.KeyID = "../foo"
+	require.Error(ks.Put(bad))
+
+	require.NoError(ks.Delete(key.KeyID))
+	require.Nil(ks.Get(key.KeyID))
+	require.NoError(ks.Delete(key.KeyID))
+	_, err = os.Stat(filepath.Join(dir, key.KeyID+keystoreExt))
+	require.True(os.IsNotExist(err))
+
+	ks3, err := newKeystore(dir)
+	require.NoError(err)
+	require.Empty(ks3.KeyIDs())
+}
+
+func TestKeystore_InMemory(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	ks, err := newKeystore("")
+	require.NoError(err)
+
+	key, material := mock.RootKeyWithMaterial()
+	require.NoError(ks.Put(material))
+	require.Equal(material, ks.Get(key.KeyID))
+	require.NoError(ks.Delete(key.KeyID))
+	require.Nil(ks.Get(key.KeyID))
+}
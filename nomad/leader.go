@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"math/rand"
 	"net"
+	"sort"
 	"sync"
 	"time"
 
@@ -38,6 +39,14 @@ const (
 	// possible loss of leadership event if we are unable to get a barrier
 	// while leader.
 	barrierWriteTimeout = 2 * time.Minute
+
+	// dispatchReleaseRateLimit is used to rate limit how often queued
+	// dispatched jobs are released
+	dispatchReleaseRateLimit rate.Limit = 10.0
+
+	// dispatchReleaseBackoff is the time to wait before retrying to release
+	// queued dispatched jobs after an error
+	dispatchReleaseBackoff = 5 * time.Second
 )
 
 var minAutopilotVersion = version.Must(version.NewVersion("0.8.0"))
@@ -242,6 +251,9 @@ func (s *Server) establishLeadership(stopCh chan struct{}) error {
 	// Scheduler periodic jobs
 	go s.schedulePeriodic(stopCh)
 
+	// Initialize the keyring used to sign workload identities
+	go s.initializeKeyring(stopCh)
+
 	// Reap any failed evaluations
 	go s.reapFailedEvaluations(stopCh)
 
@@ -254,6 +266,9 @@ func (s *Server) establishLeadership(stopCh chan struct{}) error {
 	// Periodically publish job summary metrics
 	go s.publishJobSummaryMetrics(stopCh)
 
+	// Release queued dispatched jobs as their concurrency limit allows
+	go s.releaseQueuedDispatches(stopCh)
+
 	// Setup the heartbeat timers. This is done both when starting up or when
 	// a leader fail over happens. Since the timers are maintained by the leader
 	// node, effectively this means all the timers are renewed at the time of failover.
@@ -281,6 +296,9 @@ func (s *Server) establishLeadership(stopCh chan struct{}) error {
 	// and we are not the authoritative region.
 	if s.config.ACLEnabled && s.config.Region != s.config.AuthoritativeRegion {
 		go s.replicateACLPolicies(stopCh)
+		go s.replicateACLRoles(stopCh)
+		go s.replicateACLAuthMethods(stopCh)
+		go s.replicateACLBindingRules(stopCh)
 		go s.replicateACLTokens(stopCh)
 	}
 
@@ -374,9 +392,9 @@ func (s *Server) restoreRevokingAccessors() error {
 
 // restorePeriodicDispatcher is used to restore all periodic jobs into the
 // periodic dispatcher. It also determines if a periodic job should have been
-// created during the leadership transition and force runs them. The periodic
-// dispatcher is maintained only by the leader, so it must be restored anytime a
-// leadership transition takes place.
+// created during the leadership transition and catches up on the missed
+// launches. The periodic dispatcher is maintained only by the leader, so it
+// must be restored anytime a leadership transition takes place.
 func (s *Server) restorePeriodicDispatcher() error {
 	logger := s.logger.Named("periodic")
 	ws := memdb.NewWatchSet()
@@ -385,7 +403,6 @@ func (s *Server) restorePeriodicDispatcher() error {
 		return fmt.Errorf("failed to get periodic jobs: %v", err)
 	}
 
-	now := time.Now()
 	for i := iter.Next(); i != nil; i = iter.Next() {
 		job := i.(*structs.Job)
 
@@ -400,7 +417,7 @@ func (s *Server) restorePeriodicDispatcher() error {
 			continue
 		}
 
-		// We do not need to force run the job since it isn't active.
+		// We do not need to catch up the job since it isn't active.
 		if !job.IsPeriodicActive() {
 			continue
 		}
@@ -417,25 +434,12 @@ func (s *Server) restorePeriodicDispatcher() error {
 				job.ID, job.Namespace)
 		}
 
-		// nextLaunch is the next launch that should occur.
-		nextLaunch, err := job.Periodic.Next(launch.Launch.In(job.Periodic.GetLocation()))
-		if err != nil {
-			logger.Error("failed to determine next periodic launch for job", "job", job.NamespacedID(), "error", err)
-			continue
-		}
-
-		// We skip force launching the job if  there should be no next launch
-		// (the zero case) or if the next launch time is in the future. If it is
-		// in the future, it will be handled by the periodic dispatcher.
-		if nextLaunch.IsZero() || !nextLaunch.Before(now) {
-			continue
-		}
-
-		if _, err := s.periodicDispatcher.ForceRun(job.Namespace, job.ID); err != nil {
-			logger.Error("force run of periodic job failed", "job", job.NamespacedID(), "error", err)
-			return fmt.Errorf("force run of periodic job %q failed: %v", job.NamespacedID(), err)
+		// Handle any launches that were missed during the leadership
+		// transition according to the job's catch-up policy.
+		if err := s.periodicDispatcher.CatchUp(job, launch.Launch); err != nil {
+			logger.Error("catch-up of periodic job failed", "job", job.NamespacedID(), "error", err)
+			return fmt.Errorf("catch-up of periodic job %q failed: %v", job.NamespacedID(), err)
 		}
-		logger.Debug("periodic job force runned during leadership establishment", "job", job.NamespacedID())
 	}
 
 	return nil
@@ -451,6 +455,10 @@ func (s *Server) schedulePeriodic(stopCh chan struct{}) {
 	defer jobGC.Stop()
 	deploymentGC := time.NewTicker(s.config.DeploymentGCInterval)
 	defer deploymentGC.Stop()
+	tokenExpiredGC := time.NewTicker(s.config.ACLTokenExpirationGCInterval)
+	defer tokenExpiredGC.Stop()
+	rootKeyGC := time.NewTicker(s.config.RootKeyGCInterval)
+	defer rootKeyGC.Stop()
 
 	// getLatest grabs the latest index from the state store. It returns true if
 	// the index was retrieved successfully.
@@ -483,6 +491,20 @@ func (s *Server) schedulePeriodic(stopCh chan struct{}) {
 			if index, ok := getLatest(); ok {
 				s.evalBroker.Enqueue(s.coreJobEval(structs.CoreJobDeploymentGC, index))
 			}
+		case <-tokenExpiredGC.C:
+			if !s.config.ACLEnabled {
+				continue
+			}
+			if index, ok := getLatest(); ok {
+				s.evalBroker.Enqueue(s.coreJobEval(structs.CoreJobLocalTokenExpiredGC, index))
+				if s.config.Region == s.config.AuthoritativeRegion {
+					s.evalBroker.Enqueue(s.coreJobEval(structs.CoreJobGlobalTokenExpiredGC, index))
+				}
+			}
+		case <-rootKeyGC.C:
+			if index, ok := getLatest(); ok {
+				s.evalBroker.Enqueue(s.coreJobEval(structs.CoreJobRootKeyRotateOrGC, index))
+			}
 		case <-stopCh:
 			return
 		}
@@ -600,6 +622,140 @@ func (s *Server) periodicUnblockFailedEvals(stopCh chan struct{}) {
 	}
 }
 
+// releaseQueuedDispatches releases the dispatched jobs queued because their
+// parameterized job reached its concurrency limit, as the running dispatched
+// jobs complete.
+func (s *Server) releaseQueuedDispatches(stopCh chan struct{}) {
+	limiter := rate.NewLimiter(dispatchReleaseRateLimit, int(dispatchReleaseRateLimit))
+	for {
+		select {
+		case <-stopCh:
+			return
+		default:
+		}
+
+		// Rate limit how often we attempt releases
+		limiter.Wait(context.Background())
+
+		ws, err := s.releaseDispatchedJobs()
+		if err != nil {
+			s.logger.Error("failed to release queued dispatched jobs", "error", err)
+			select {
+			case <-stopCh:
+				return
+			case <-time.After(dispatchReleaseBackoff):
+				continue
+			}
+		}
+
+		// Wait for the queued jobs or the summaries of their parameterized
+		// jobs to change
+		ws.Add(stopCh)
+		ws.Watch(nil)
+	}
+}
+
+// releaseDispatchedJobs releases the queued dispatched jobs allowed by the
+// concurrency limit of their parameterized job. It returns a watch set that
+// fires when releases should be reconsidered.
+func (s *Server) releaseDispatchedJobs() (memdb.WatchSet, error) {
+	ws := memdb.NewWatchSet()
+	state := s.fsm.State()
+	ws.Add(state.AbandonCh())
+
+	iter, err := state.JobsByDispatchQueued(ws)
+	if err != nil {
+		return nil, err
+	}
+
+	// Group the queued jobs by parameterized job
+	queued := make(map[structs.NamespacedID][]*structs.Job)
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		job := raw.(*structs.Job)
+		parentID := structs.NamespacedID{ID: job.ParentID, Namespace: job.Namespace}
+		queued[parentID] = append(queued[parentID], job)
+	}
+
+	for parentID, jobs := range queued {
+		if err := s.releaseParentDispatchedJobs(ws, parentID, jobs); err != nil {
+			return nil, err
+		}
+	}
+	return ws, nil
+}
+
+// releaseParentDispatchedJobs releases the queued dispatched jobs of a
+// parameterized job allowed by its concurrency limit.
+func (s *Server) releaseParentDispatchedJobs(ws memdb.WatchSet, parentID structs.NamespacedID, jobs []*structs.Job) error {
+	// Serialize with the dispatches of the parameterized job so the limit is
+	// respected
+	unlock := s.dispatchLocks.Lock(parentID)
+	defer unlock()
+
+	// Release the oldest jobs first
+	sort.Slice(jobs, func(i, j int) bool {
+		return jobs[i].CreateIndex < jobs[j].CreateIndex
+	})
+
+	state := s.fsm.State()
+	parent, err := state.JobByID(ws, parentID.Namespace, parentID.ID)
+	if err != nil {
+		return err
+	}
+
+	// Release all the jobs if the parameterized job no longer limits its
+	// dispatched jobs
+	release := jobs
+	if parent != nil && parent.IsParameterized() && parent.ParameterizedJob.MaxConcurrent > 0 {
+		summary, err := state.JobSummaryByID(ws, parentID.Namespace, parentID.ID)
+		if err != nil {
+			return err
+		}
+		active := int64(0)
+		if summary != nil && summary.Children != nil {
+			active = summary.Children.Pending + summary.Children.Running
+		}
+
+		available := int64(parent.ParameterizedJob.MaxConcurrent) - active
+		if available <= 0 {
+			return nil
+		}
+		if available < int64(len(release)) {
+			release = release[:available]
+		}
+	}
+
+	// Create the evaluations of the released jobs
+	req := structs.JobDispatchReleaseRequest{
+		Jobs:  make([]structs.NamespacedID, 0, len(release)),
+		Evals: make([]*structs.Evaluation, 0, len(release)),
+	}
+	for _, job := range release {
+		req.Jobs = append(req.Jobs, *job.NamespacedID())
+		req.Evals = append(req.Evals, &structs.Evaluation{
+			ID:             uuid.Generate(),
+			Namespace:      job.Namespace,
+			Priority:       job.Priority,
+			Type:           job.Type,
+			TriggeredBy:    structs.EvalTriggerJobRegister,
+			JobID:          job.ID,
+			JobModifyIndex: job.JobModifyIndex,
+			Status:         structs.EvalStatusPending,
+		})
+	}
+
+	resp, _, err := s.raftApply(structs.JobDispatchReleaseRequestType, &req)
+	if err != nil {
+		return err
+	}
+	if respErr, ok := resp.(error); ok {
+		return respErr
+	}
+
+	s.logger.Debug("released queued dispatched jobs", "job", parentID, "count", len(release))
+	return nil
+}
+
 // publishJobSummaryMetrics publishes the job summaries as metrics
 func (s *Server) publishJobSummaryMetrics(stopCh chan struct{}) {
 	timer := time.NewTimer(0)
@@ -1091,6 +1247,417 @@ func diffACLPolicies(state *state.StateStore, minIndex uint64, remoteList []*str
 	return
 }
 
+// replicateACLRoles is used to replicate ACL roles from
+// the authoritative region to this region.
+func (s *Server) replicateACLRoles(stopCh chan struct{}) {
+	req := structs.ACLRoleListRequest{
+		QueryOptions: structs.QueryOptions{
+			Region:     s.config.AuthoritativeRegion,
+			AllowStale: true,
+		},
+	}
+	limiter := rate.NewLimiter(replicationRateLimit, int(replicationRateLimit))
+	s.logger.Debug("starting ACL role replication from authoritative region", "authoritative_region", req.Region)
+
+START:
+	for {
+		select {
+		case <-stopCh:
+			return
+		default:
+			// Rate limit how often we attempt replication
+			limiter.Wait(context.Background())
+
+			// Fetch the list of roles
+			var resp structs.ACLRoleListResponse
+			req.AuthToken = s.ReplicationToken()
+			err := s.forwardRegion(s.config.AuthoritativeRegion,
+				"ACL.ListRoles", &req, &resp)
+			if err != nil {
+				s.logger.Error("failed to fetch roles from authoritative region", "error", err)
+				goto ERR_WAIT
+			}
+
+			// Perform a two-way diff
+			delete, update := diffACLRoles(s.State(), req.MinQueryIndex, resp.Roles)
+
+			// Delete roles that should not exist
+			if len(delete) > 0 {
+				args := &structs.ACLRoleDeleteRequest{
+					Names: delete,
+				}
+				_, _, err := s.raftApply(structs.ACLRoleDeleteRequestType, args)
+				if err != nil {
+					s.logger.Error("failed to delete roles", "error", err)
+					goto ERR_WAIT
+				}
+			}
+
+			// Fetch any outdated roles
+			var fetched []*structs.ACLRole
+			if len(update) > 0 {
+				req := structs.ACLRoleSetRequest{
+					Names: update,
+					QueryOptions: structs.QueryOptions{
+						Region:        s.config.AuthoritativeRegion,
+						AuthToken:     s.ReplicationToken(),
+						AllowStale:    true,
+						MinQueryIndex: resp.Index - 1,
+					},
+				}
+				var reply structs.ACLRoleSetResponse
+				if err := s.forwardRegion(s.config.AuthoritativeRegion,
+					"ACL.GetRoles", &req, &reply); err != nil {
+					s.logger.Error("failed to fetch roles from authoritative region", "error", err)
+					goto ERR_WAIT
+				}
+				for _, role := range reply.Roles {
+					fetched = append(fetched, role)
+				}
+			}
+
+			// Update local roles
+			if len(fetched) > 0 {
+				args := &structs.ACLRoleUpsertRequest{
+					Roles: fetched,
+				}
+				_, _, err := s.raftApply(structs.ACLRoleUpsertRequestType, args)
+				if err != nil {
+					s.logger.Error("failed to update roles", "error", err)
+					goto ERR_WAIT
+				}
+			}
+
+			// Update the minimum query index, blocks until there
+			// is a change.
+			req.MinQueryIndex = resp.Index
+		}
+	}
+
+ERR_WAIT:
+	select {
+	case <-time.After(s.config.ReplicationBackoff):
+		goto START
+	case <-stopCh:
+		return
+	}
+}
+
+// diffACLRoles is used to perform a two-way diff between the local
+// roles and the remote roles to determine which roles need to
+// be deleted or updated.
+func diffACLRoles(state *state.StateStore, minIndex uint64, remoteList []*structs.ACLRoleListStub) (delete []string, update []string) {
+	// Construct a set of the local and remote roles
+	local := make(map[string][]byte)
+	remote := make(map[string]struct{})
+
+	// Add all the local roles
+	iter, err := state.ACLRoles(nil)
+	if err != nil {
+		panic("failed to iterate local roles")
+	}
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		role := raw.(*structs.ACLRole)
+		local[role.Name] = role.Hash
+	}
+
+	// Iterate over the remote roles
+	for _, rr := range remoteList {
+		remote[rr.Name] = struct{}{}
+
+		// Check if the role is missing locally
+		if localHash, ok := local[rr.Name]; !ok {
+			update = append(update, rr.Name)
+
+			// Check if role is newer remotely and there is a hash mis-match.
+		} else if rr.ModifyIndex > minIndex && !bytes.Equal(localHash, rr.Hash) {
+			update = append(update, rr.Name)
+		}
+	}
+
+	// Check if role should be deleted
+	for lr := range local {
+		if _, ok := remote[lr]; !ok {
+			delete = append(delete, lr)
+		}
+	}
+	return
+}
+
+// replicateACLAuthMethods is used to replicate ACL auth methods from
+// the authoritative region to this region.
+func (s *Server) replicateACLAuthMethods(stopCh chan struct{}) {
+	req := structs.ACLAuthMethodListRequest{
+		QueryOptions: structs.QueryOptions{
+			Region:     s.config.AuthoritativeRegion,
+			AllowStale: true,
+		},
+	}
+	limiter := rate.NewLimiter(replicationRateLimit, int(replicationRateLimit))
+	s.logger.Debug("starting ACL auth method replication from authoritative region", "authoritative_region", req.Region)
+
+START:
+	for {
+		select {
+		case <-stopCh:
+			return
+		default:
+			// Rate limit how often we attempt replication
+			limiter.Wait(context.Background())
+
+			// Fetch the list of auth methods
+			var resp structs.ACLAuthMethodListResponse
+			req.AuthToken = s.ReplicationToken()
+			err := s.forwardRegion(s.config.AuthoritativeRegion,
+				"ACL.ListAuthMethods", &req, &resp)
+			if err != nil {
+				s.logger.Error("failed to fetch auth methods from authoritative region", "error", err)
+				goto ERR_WAIT
+			}
+
+			// Perform a two-way diff
+			delete, update := diffACLAuthMethods(s.State(), req.MinQueryIndex, resp.AuthMethods)
+
+			// Delete auth methods that should not exist
+			if len(delete) > 0 {
+				args := &structs.ACLAuthMethodDeleteRequest{
+					Names: delete,
+				}
+				_, _, err := s.raftApply(structs.ACLAuthMethodDeleteRequestType, args)
+				if err != nil {
+					s.logger.Error("failed to delete auth methods", "error", err)
+					goto ERR_WAIT
+				}
+			}
+
+			// Fetch any outdated auth methods
+			var fetched []*structs.ACLAuthMethod
+			if len(update) > 0 {
+				req := structs.ACLAuthMethodSetRequest{
+					Names: update,
+					QueryOptions: structs.QueryOptions{
+						Region:        s.config.AuthoritativeRegion,
+						AuthToken:     s.ReplicationToken(),
+						AllowStale:    true,
+						MinQueryIndex: resp.Index - 1,
+					},
+				}
+				var reply structs.ACLAuthMethodSetResponse
+				if err := s.forwardRegion(s.config.AuthoritativeRegion,
+					"ACL.GetAuthMethods", &req, &reply); err != nil {
+					s.logger.Error("failed to fetch auth methods from authoritative region", "error", err)
+					goto ERR_WAIT
+				}
+				for _, method := range reply.AuthMethods {
+					fetched = append(fetched, method)
+				}
+			}
+
+			// Update local auth methods
+			if len(fetched) > 0 {
+				args := &structs.ACLAuthMethodUpsertRequest{
+					AuthMethods: fetched,
+				}
+				_, _, err := s.raftApply(structs.ACLAuthMethodUpsertRequestType, args)
+				if err != nil {
+					s.logger.Error("failed to update auth methods", "error", err)
+					goto ERR_WAIT
+				}
+			}
+
+			// Update the minimum query index, blocks until there
+			// is a change.
+			req.MinQueryIndex = resp.Index
+		}
+	}
+
+ERR_WAIT:
+	select {
+	case <-time.After(s.config.ReplicationBackoff):
+		goto START
+	case <-stopCh:
+		return
+	}
+}
+
+// diffACLAuthMethods is used to perform a two-way diff between the local
+// auth methods and the remote auth methods to determine which
+// auth methods need to be deleted or updated.
+func diffACLAuthMethods(state *state.StateStore, minIndex uint64, remoteList []*structs.ACLAuthMethodListStub) (delete []string, update []string) {
+	// Construct a set of the local and remote auth methods
+	local := make(map[string][]byte)
+	remote := make(map[string]struct{})
+
+	// Add all the local auth methods
+	iter, err := state.ACLAuthMethods(nil)
+	if err != nil {
+		panic("failed to iterate local auth methods")
+	}
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		method := raw.(*structs.ACLAuthMethod)
+		local[method.Name] = method.Hash
+	}
+
+	// Iterate over the remote auth methods
+	for _, rr := range remoteList {
+		remote[rr.Name] = struct{}{}
+
+		// Check if the auth method is missing locally
+		if localHash, ok := local[rr.Name]; !ok {
+			update = append(update, rr.Name)
+
+			// Check if auth method is newer remotely and there is a hash mis-match.
+		} else if rr.ModifyIndex > minIndex && !bytes.Equal(localHash, rr.Hash) {
+			update = append(update, rr.Name)
+		}
+	}
+
+	// Check if auth method should be deleted
+	for lr := range local {
+		if _, ok := remote[lr]; !ok {
+			delete = append(delete, lr)
+		}
+	}
+	return
+}
+
+// replicateACLBindingRules is used to replicate ACL binding rules from
+// the authoritative region to this region.
+func (s *Server) replicateACLBindingRules(stopCh chan struct{}) {
+	req := structs.ACLBindingRuleListRequest{
+		QueryOptions: structs.QueryOptions{
+			Region:     s.config.AuthoritativeRegion,
+			AllowStale: true,
+		},
+	}
+	limiter := rate.NewLimiter(replicationRateLimit, int(replicationRateLimit))
+	s.logger.Debug("starting ACL binding rule replication from authoritative region", "authoritative_region", req.Region)
+
+START:
+	for {
+		select {
+		case <-stopCh:
+			return
+		default:
+			// Rate limit how often we attempt replication
+			limiter.Wait(context.Background())
+
+			// Fetch the list of binding rules
+			var resp structs.ACLBindingRuleListResponse
+			req.AuthToken = s.ReplicationToken()
+			err := s.forwardRegion(s.config.AuthoritativeRegion,
+				"ACL.ListBindingRules", &req, &resp)
+			if err != nil {
+				s.logger.Error("failed to fetch binding rules from authoritative region", "error", err)
+				goto ERR_WAIT
+			}
+
+			// Perform a two-way diff
+			delete, update := diffACLBindingRules(s.State(), req.MinQueryIndex, resp.BindingRules)
+
+			// Delete binding rules that should not exist
+			if len(delete) > 0 {
+				args := &structs.ACLBindingRuleDeleteRequest{
+					IDs: delete,
+				}
+				_, _, err := s.raftApply(structs.ACLBindingRuleDeleteRequestType, args)
+				if err != nil {
+					s.logger.Error("failed to delete binding rules", "error", err)
+					goto ERR_WAIT
+				}
+			}
+
+			// Fetch any outdated binding rules
+			var fetched []*structs.ACLBindingRule
+			if len(update) > 0 {
+				req := structs.ACLBindingRuleSetRequest{
+					IDs: update,
+					QueryOptions: structs.QueryOptions{
+						Region:        s.config.AuthoritativeRegion,
+						AuthToken:     s.ReplicationToken(),
+						AllowStale:    true,
+						MinQueryIndex: resp.Index - 1,
+					},
+				}
+				var reply structs.ACLBindingRuleSetResponse
+				if err := s.forwardRegion(s.config.AuthoritativeRegion,
+					"ACL.GetBindingRules", &req, &reply); err != nil {
+					s.logger.Error("failed to fetch binding rules from authoritative region", "error", err)
+					goto ERR_WAIT
+				}
+				for _, rule := range reply.BindingRules {
+					fetched = append(fetched, rule)
+				}
+			}
+
+			// Update local binding rules
+			if len(fetched) > 0 {
+				args := &structs.ACLBindingRuleUpsertRequest{
+					BindingRules: fetched,
+				}
+				_, _, err := s.raftApply(structs.ACLBindingRuleUpsertRequestType, args)
+				if err != nil {
+					s.logger.Error("failed to update binding rules", "error", err)
+					goto ERR_WAIT
+				}
+			}
+
+			// Update the minimum query index, blocks until there
+			// is a change.
+			req.MinQueryIndex = resp.Index
+		}
+	}
+
+ERR_WAIT:
+	select {
+	case <-time.After(s.config.ReplicationBackoff):
+		goto START
+	case <-stopCh:
+		return
+	}
+}
+
+// diffACLBindingRules is used to perform a two-way diff between the local
+// binding rules and the remote binding rules to determine which
+// binding rules need to be deleted or updated.
+func diffACLBindingRules(state *state.StateStore, minIndex uint64, remoteList []*structs.ACLBindingRuleListStub) (delete []string, update []string) {
+	// Construct a set of the local and remote binding rules
+	local := make(map[string][]byte)
+	remote := make(map[string]struct{})
+
+	// Add all the local binding rules
+	iter, err := state.ACLBindingRules(nil)
+	if err != nil {
+		panic("failed to iterate local binding rules")
+	}
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		rule := raw.(*structs.ACLBindingRule)
+		local[rule.ID] = rule.Hash
+	}
+
+	// Iterate over the remote binding rules
+	for _, rr := range remoteList {
+		remote[rr.ID] = struct{}{}
+
+		// Check if the binding rule is missing locally
+		if localHash, ok := local[rr.ID]; !ok {
+			update = append(update, rr.ID)
+
+			// Check if binding rule is newer remotely and there is a hash mis-match.
+		} else if rr.ModifyIndex > minIndex && !bytes.Equal(localHash, rr.Hash) {
+			update = append(update, rr.ID)
+		}
+	}
+
+	// Check if binding rule should be deleted
+	for lr := range local {
+		if _, ok := remote[lr]; !ok {
+			delete = append(delete, lr)
+		}
+	}
+	return
+}
+
 // replicateACLTokens is used to replicate global ACL tokens from
 // the authoritative region to this region.
 func (s *Server) replicateACLTokens(stopCh chan struct{}) {
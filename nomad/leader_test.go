@@ -549,6 +549,78 @@ func TestLeader_PeriodicDispatch(t *testing.T) {
 	})
 }
 
+func TestLeader_ReleaseQueuedDispatches(t *testing.T) {
+	t.Parallel()
+	s1 := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0
+	})
+	defer s1.Shutdown()
+	testutil.WaitForLeader(t, s1.RPC)
+	state := s1.fsm.State()
+
+	// Create a parameterized job running one dispatched job at a time
+	parent := mock.BatchJob()
+	parent.ParameterizedJob = &structs.ParameterizedJobConfig{MaxConcurrent: 1}
+	require.NoError(t, state.UpsertJob(1000, parent))
+
+	// Create a pending dispatched job and two queued ones
+	child1 := mock.BatchJob()
+	child1.ParentID = parent.ID
+	child1.Dispatched = true
+	require.NoError(t, state.UpsertJob(1001, child1))
+
+	eval := mock.Eval()
+	eval.JobID = child1.ID
+	require.NoError(t, state.UpsertEvals(1002, []*structs.Evaluation{eval}))
+
+	child2 := child1.Copy()
+	child2.ID = child1.ID + "-2"
+	child2.DispatchQueued = true
+	require.NoError(t, state.UpsertJob(1003, child2))
+
+	child3 := child1.Copy()
+	child3.ID = child1.ID + "-3"
+	child3.DispatchQueued = true
+	require.NoError(t, state.UpsertJob(1004, child3))
+
+	// Complete the first dispatched job
+	eval = eval.Copy()
+	eval.Status = structs.EvalStatusComplete
+	require.NoError(t, state.UpsertEvals(1005, []*structs.Evaluation{eval}))
+
+	// The oldest queued job is released
+	testutil.WaitForResult(func() (bool, error) {
+		out, err := state.JobByID(nil, child2.Namespace, child2.ID)
+		if err != nil {
+			return false, err
+		}
+		if out.DispatchQueued {
+			return false, fmt.Errorf("job %q still queued", child2.ID)
+		}
+		evals, err := state.EvalsByJob(nil, child2.Namespace, child2.ID)
+		if err != nil {
+			return false, err
+		}
+		if len(evals) != 1 {
+			return false, fmt.Errorf("expected 1 eval, got %d", len(evals))
+		}
+		return true, nil
+	}, func(err error) {
+		t.Fatalf("err: %v", err)
+	})
+
+	// The other queued job waits for the released one to complete
+	out, err := state.JobByID(nil, child3.Namespace, child3.ID)
+	require.NoError(t, err)
+	require.True(t, out.DispatchQueued)
+
+	summary, err := state.JobSummaryByID(nil, parent.Namespace, parent.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), summary.Children.Queued)
+	require.Equal(t, int64(1), summary.Children.Pending)
+	require.Equal(t, int64(1), summary.Children.Dead)
+}
+
 func TestLeader_ReapFailedEval(t *testing.T) {
 	s1 := TestServer(t, func(c *Config) {
 		c.NumSchedulers = 0
@@ -753,6 +825,172 @@ func TestLeader_DiffACLPolicies(t *testing.T) {
 	assert.Equal(t, []string{p3.Name, p4.Name}, update)
 }
 
+func TestLeader_ReplicateACLRoles(t *testing.T) {
+	t.Parallel()
+	s1, root := TestACLServer(t, func(c *Config) {
+		c.Region = "region1"
+		c.AuthoritativeRegion = "region1"
+		c.ACLEnabled = true
+	})
+	defer s1.Shutdown()
+	s2, _ := TestACLServer(t, func(c *Config) {
+		c.Region = "region2"
+		c.AuthoritativeRegion = "region1"
+		c.ACLEnabled = true
+		c.ReplicationBackoff = 20 * time.Millisecond
+		c.ReplicationToken = root.SecretID
+	})
+	defer s2.Shutdown()
+	TestJoin(t, s1, s2)
+	testutil.WaitForLeader(t, s1.RPC)
+	testutil.WaitForLeader(t, s2.RPC)
+
+	// Write a role to the authoritative region
+	r1 := mock.ACLRole()
+	require.NoError(t, s1.State().UpsertACLRoles(100, []*structs.ACLRole{r1}))
+
+	// Wait for the role to replicate
+	testutil.WaitForResult(func() (bool, error) {
+		out, err := s2.State().ACLRoleByName(nil, r1.Name)
+		return out != nil, err
+	}, func(err error) {
+		t.Fatalf("should replicate role")
+	})
+}
+
+func TestLeader_DiffACLRoles(t *testing.T) {
+	t.Parallel()
+
+	state := state.TestStateStore(t)
+
+	// Populate the local state
+	r1 := mock.ACLRole()
+	r2 := mock.ACLRole()
+	r3 := mock.ACLRole()
+	require.NoError(t, state.UpsertACLRoles(100, []*structs.ACLRole{r1, r2, r3}))
+
+	// Simulate a remote list
+	r2Stub := r2.Stub()
+	r2Stub.ModifyIndex = 50 // Ignored, same index
+	r3Stub := r3.Stub()
+	r3Stub.ModifyIndex = 100 // Updated, higher index
+	r3Stub.Hash = []byte{0, 1, 2, 3}
+	r4 := mock.ACLRole()
+	delete, update := diffACLRoles(state, 50, []*structs.ACLRoleListStub{
+		r2Stub,
+		r3Stub,
+		r4.Stub(),
+	})
+
+	// R1 does not exist on the remote side, should delete
+	require.Equal(t, []string{r1.Name}, delete)
+
+	// R2 is un-modified - ignore. R3 modified, R4 new.
+	require.Equal(t, []string{r3.Name, r4.Name}, update)
+}
+
+func TestLeader_ReplicateACLAuthMethods(t *testing.T) {
+	t.Parallel()
+	s1, root := TestACLServer(t, func(c *Config) {
+		c.Region = "region1"
+		c.AuthoritativeRegion = "region1"
+		c.ACLEnabled = true
+	})
+	defer s1.Shutdown()
+	s2, _ := TestACLServer(t, func(c *Config) {
+		c.Region = "region2"
+		c.AuthoritativeRegion = "region1"
+		c.ACLEnabled = true
+		c.ReplicationBackoff = 20 * time.Millisecond
+		c.ReplicationToken = root.SecretID
+	})
+	defer s2.Shutdown()
+	TestJoin(t, s1, s2)
+	testutil.WaitForLeader(t, s1.RPC)
+	testutil.WaitForLeader(t, s2.RPC)
+
+	// Write an auth method and binding rule to the authoritative region
+	m1 := mock.ACLAuthMethod()
+	require.NoError(t, s1.State().UpsertACLAuthMethods(100, []*structs.ACLAuthMethod{m1}))
+	r1 := mock.ACLBindingRule()
+	r1.AuthMethod = m1.Name
+	require.NoError(t, s1.State().UpsertACLBindingRules(101, []*structs.ACLBindingRule{r1}))
+
+	// Wait for them to replicate
+	testutil.WaitForResult(func() (bool, error) {
+		out, err := s2.State().ACLAuthMethodByName(nil, m1.Name)
+		if err != nil || out == nil {
+			return false, err
+		}
+		rule, err := s2.State().ACLBindingRuleByID(nil, r1.ID)
+		return rule != nil, err
+	}, func(err error) {
+		t.Fatalf("should replicate auth method and binding rule")
+	})
+}
+
+func TestLeader_DiffACLAuthMethods(t *testing.T) {
+	t.Parallel()
+
+	state := state.TestStateStore(t)
+
+	// Populate the local state
+	m1 := mock.ACLAuthMethod()
+	m2 := mock.ACLAuthMethod()
+	m3 := mock.ACLAuthMethod()
+	require.NoError(t, state.UpsertACLAuthMethods(100, []*structs.ACLAuthMethod{m1, m2, m3}))
+
+	// Simulate a remote list
+	m2Stub := m2.Stub()
+	m2Stub.ModifyIndex = 50 // Ignored, same index
+	m3Stub := m3.Stub()
+	m3Stub.ModifyIndex = 100 // Updated, higher index
+	m3Stub.Hash = []byte{0, 1, 2, 3}
+	m4 := mock.ACLAuthMethod()
+	delete, update := diffACLAuthMethods(state, 50, []*structs.ACLAuthMethodListStub{
+		m2Stub,
+		m3Stub,
+		m4.Stub(),
+	})
+
+	// M1 does not exist on the remote side, should delete
+	require.Equal(t, []string{m1.Name}, delete)
+
+	// M2 is un-modified - ignore. M3 modified, M4 new.
+	require.Equal(t, []string{m3.Name, m4.Name}, update)
+}
+
+func TestLeader_DiffACLBindingRules(t *testing.T) {
+	t.Parallel()
+
+	state := state.TestStateStore(t)
+
+	// Populate the local state
+	r1 := mock.ACLBindingRule()
+	r2 := mock.ACLBindingRule()
+	r3 := mock.ACLBindingRule()
+	require.NoError(t, state.UpsertACLBindingRules(100, []*structs.ACLBindingRule{r1, r2, r3}))
+
+	// Simulate a remote list
+	r2Stub := r2.Stub()
+	r2Stub.ModifyIndex = 50 // Ignored, same index
+	r3Stub := r3.Stub()
+	r3Stub.ModifyIndex = 100 // Updated, higher index
+	r3Stub.Hash = []byte{0, 1, 2, 3}
+	r4 := mock.ACLBindingRule()
+	delete, update := diffACLBindingRules(state, 50, []*structs.ACLBindingRuleListStub{
+		r2Stub,
+		r3Stub,
+		r4.Stub(),
+	})
+
+	// R1 does not exist on the remote side, should delete
+	require.Equal(t, []string{r1.ID}, delete)
+
+	// R2 is un-modified - ignore. R3 modified, R4 new.
+	require.Equal(t, []string{r3.ID, r4.ID}, update)
+}
+
 func TestLeader_ReplicateACLTokens(t *testing.T) {
 	t.Parallel()
 	s1, root := TestACLServer(t, func(c *Config) {
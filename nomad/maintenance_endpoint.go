@@ -0,0 +1,338 @@
+package nomad
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	metrics "github.com/armon/go-metrics"
+	log "github.com/hashicorp/go-hclog"
+	memdb "github.com/hashicorp/go-memdb"
+	"github.com/hashicorp/nomad/acl"
+	"github.com/hashicorp/nomad/helper/uuid"
+	"github.com/hashicorp/nomad/nomad/state"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// maintenancePlanCancelAttempts is how many times cancelling a maintenance plan
+// is attempted when the plan is concurrently updated by the drainer
+const maintenancePlanCancelAttempts = 5
+
+// Maintenance endpoint is used to manage the maintenance plans draining nodes
+// in batches. The plans are executed by the node drainer of the leader.
+type Maintenance struct {
+	srv    *Server
+	logger log.Logger
+}
+
+// Register is used to create a maintenance plan. The nodes of the plan are
+// selected when it is created.
+func (m *Maintenance) Register(args *structs.MaintenancePlanRegisterRequest, reply *structs.MaintenancePlanUpdateResponse) error {
+	if done, err := m.srv.forward("Maintenance.Register", args, args, reply); done {
+		return err
+	}
+	defer metrics.MeasureSince([]string{"nomad", "maintenance", "register"}, time.Now())
+
+	// Check node node-drain permissions
+	if aclObj, err := m.srv.ResolveToken(args.AuthToken); err != nil {
+		return err
+	} else if aclObj != nil && !aclObj.AllowNodeOperation(acl.NodeCapabilityDrain) {
+		return structs.ErrPermissionDenied
+	}
+
+	// Validate the arguments
+	plan := args.Plan
+	if plan == nil {
+		return fmt.Errorf("missing maintenance plan for registration")
+	}
+	if err := plan.Validate(); err != nil {
+		return err
+	}
+
+	plan.ID = uuid.Generate()
+	plan.Status = structs.MaintenancePlanStatusPending
+	plan.StatusDescription = structs.MaintenancePlanDescriptionPending
+	plan.CreateIndex, plan.ModifyIndex = 0, 0
+	if plan.StartTime.IsZero() {
+		plan.StartTime = time.Now()
+	}
+
+	snap, err := m.srv.fsm.State().Snapshot()
+	if err != nil {
+		return err
+	}
+	nodes, err := maintenancePlanNodes(snap, plan)
+	if err != nil {
+		return err
+	}
+	plan.Nodes = nodes
+
+	// Commit this update via Raft
+	req := &structs.MaintenancePlanUpsertRequest{
+		Plan:         plan,
+		WriteRequest: args.WriteRequest,
+	}
+	resp, index, err := m.srv.raftApply(structs.MaintenancePlanUpsertRequestType, req)
+	if err != nil {
+		m.logger.Error("maintenance plan registration failed", "error", err)
+		return err
+	}
+	if err, ok := resp.(error); ok && err != nil {
+		return err
+	}
+
+	plan.CreateIndex, plan.ModifyIndex = index, index
+	reply.Plan = plan
+	reply.Index = index
+	return nil
+}
+
+// maintenancePlanNodes returns the nodes selected by a new maintenance plan
+// ordered by name and assigned to their batch. Down nodes are ignored, and
+// nodes can't be selected by several active plans.
+func maintenancePlanNodes(snap *state.StateSnapshot, plan *structs.MaintenancePlan) ([]*structs.MaintenancePlanNode, error) {
+	// Collect the nodes still going through the maintenance of active plans
+	planned := make(map[string]string)
+	iter, err := snap.MaintenancePlans(nil)
+	if err != nil {
+		return nil, err
+	}
+	for {
+		raw := iter.Next()
+		if raw == nil {
+			break
+		}
+		active := raw.(*structs.MaintenancePlan)
+		if !active.Active() {
+			continue
+		}
+		for _, n := range active.Nodes {
+			if !n.Done() {
+				planned[n.NodeID] = active.ID
+			}
+		}
+	}
+
+	iter, err = snap.Nodes(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var selected []*structs.Node
+	for {
+		raw := iter.Next()
+		if raw == nil {
+			break
+		}
+		node := raw.(*structs.Node)
+		if node.Status == structs.NodeStatusDown || !plan.MatchesNode(node) {
+			continue
+		}
+		if planID, ok := planned[node.ID]; ok {
+			return nil, fmt.Errorf("node %q is part of active maintenance plan %q", node.ID, planID)
+		}
+		selected = append(selected, node)
+	}
+	if len(selected) == 0 {
+		return nil, fmt.Errorf("maintenance plan doesn't select any node")
+	}
+
+	sort.Slice(selected, func(i, j int) bool {
+		if selected[i].Name != selected[j].Name {
+			return selected[i].Name < selected[j].Name
+		}
+		return selected[i].ID < selected[j].ID
+	})
+
+	nodes := make([]*structs.MaintenancePlanNode, len(selected))
+	for i, node := range selected {
+		nodes[i] = &structs.MaintenancePlanNode{
+			NodeID: node.ID,
+			Name:   node.Name,
+			Batch:  i / plan.BatchSize,
+			Status: structs.MaintenanceNodeStatusPending,
+		}
+	}
+	return nodes, nil
+}
+
+// Cancel is used to cancel an active maintenance plan. The nodes already
+// draining are left draining.
+func (m *Maintenance) Cancel(args *structs.MaintenancePlanCancelRequest, reply *structs.MaintenancePlanUpdateResponse) error {
+	if done, err := m.srv.forward("Maintenance.Cancel", args, args, reply); done {
+		return err
+	}
+	defer metrics.MeasureSince([]string{"nomad", "maintenance", "cancel"}, time.Now())
+
+	// Check node node-drain permissions
+	if aclObj, err := m.srv.ResolveToken(args.AuthToken); err != nil {
+		return err
+	} else if aclObj != nil && !aclObj.AllowNodeOperation(acl.NodeCapabilityDrain) {
+		return structs.ErrPermissionDenied
+	}
+
+	// Validate the arguments
+	if args.PlanID == "" {
+		return fmt.Errorf("missing maintenance plan ID")
+	}
+
+	// The drainer of the leader updates the plan as it runs, which makes the
+	// cancellation fail if it happens in between, so retry it
+	var err error
+	for attempt := 0; attempt < maintenancePlanCancelAttempts; attempt++ {
+		var retry bool
+		retry, err = m.cancel(args, reply)
+		if !retry {
+			return err
+		}
+	}
+	return err
+}
+
+// cancel attempts to cancel a maintenance plan. It returns whether the plan
+// was modified since it was looked up, in which case it can be retried.
+func (m *Maintenance) cancel(args *structs.MaintenancePlanCancelRequest, reply *structs.MaintenancePlanUpdateResponse) (bool, error) {
+	// Lookup the plan
+	snap, err := m.srv.fsm.State().Snapshot()
+	if err != nil {
+		return false, err
+	}
+	plan, err := snap.MaintenancePlanByID(nil, args.PlanID)
+	if err != nil {
+		return false, err
+	}
+	if plan == nil {
+		return false, fmt.Errorf("maintenance plan %q not found", args.PlanID)
+	}
+	if !plan.Active() {
+		return false, fmt.Errorf("maintenance plan %q is already %s", args.PlanID, plan.Status)
+	}
+
+	plan = plan.Copy()
+	plan.Status = structs.MaintenancePlanStatusCancelled
+	plan.StatusDescription = structs.MaintenancePlanDescriptionCancelled
+
+	// Commit this update via Raft
+	req := &structs.MaintenancePlanUpsertRequest{
+		Plan:         plan,
+		WriteRequest: args.WriteRequest,
+	}
+	resp, index, err := m.srv.raftApply(structs.MaintenancePlanUpsertRequestType, req)
+	if err != nil {
+		m.logger.Error("maintenance plan cancellation failed", "error", err)
+		return false, err
+	}
+	if err, ok := resp.(error); ok && err != nil {
+		return true, err
+	}
+
+	plan.ModifyIndex = index
+	reply.Plan = plan
+	reply.Index = index
+	return false, nil
+}
+
+// GetPlan is used to request information about a specific maintenance plan
+func (m *Maintenance) GetPlan(args *structs.MaintenancePlanSpecificRequest, reply *structs.SingleMaintenancePlanResponse) error {
+	if done, err := m.srv.forward("Maintenance.GetPlan", args, args, reply); done {
+		return err
+	}
+	defer metrics.MeasureSince([]string{"nomad", "maintenance", "get_plan"}, time.Now())
+
+	// Check node read permissions
+	if aclObj, err := m.srv.ResolveToken(args.AuthToken); err != nil {
+		return err
+	} else if aclObj != nil && !aclObj.AllowNodeRead() {
+		return structs.ErrPermissionDenied
+	}
+
+	// Setup the blocking query
+	opts := blockingOptions{
+		queryOpts: &args.QueryOptions,
+		queryMeta: &reply.QueryMeta,
+		run: func(ws memdb.WatchSet, state *state.StateStore) error {
+			// Verify the arguments
+			if args.PlanID == "" {
+				return fmt.Errorf("missing maintenance plan ID")
+			}
+
+			// Look for the plan
+			out, err := state.MaintenancePlanByID(ws, args.PlanID)
+			if err != nil {
+				return err
+			}
+
+			// Setup the output
+			reply.Plan = out
+			if out != nil {
+				reply.Index = out.ModifyIndex
+			} else {
+				// Use the last index that affected the maintenance plans table
+				index, err := state.Index("maintenance_plans")
+				if err != nil {
+					return err
+				}
+				reply.Index = index
+			}
+
+			// Set the query response
+			m.srv.setQueryMeta(&reply.QueryMeta)
+			return nil
+		}}
+	return m.srv.blockingRPC(&opts)
+}
+
+// List is used to list the maintenance plans
+func (m *Maintenance) List(args *structs.MaintenancePlanListRequest, reply *structs.MaintenancePlanListResponse) error {
+	if done, err := m.srv.forward("Maintenance.List", args, args, reply); done {
+		return err
+	}
+	defer metrics.MeasureSince([]string{"nomad", "maintenance", "list"}, time.Now())
+
+	// Check node read permissions
+	if aclObj, err := m.srv.ResolveToken(args.AuthToken); err != nil {
+		return err
+	} else if aclObj != nil && !aclObj.AllowNodeRead() {
+		return structs.ErrPermissionDenied
+	}
+
+	// Setup the blocking query
+	opts := blockingOptions{
+		queryOpts: &args.QueryOptions,
+		queryMeta: &reply.QueryMeta,
+		run: func(ws memdb.WatchSet, state *state.StateStore) error {
+			// Capture all the plans
+			var err error
+			var iter memdb.ResultIterator
+			if prefix := args.QueryOptions.Prefix; prefix != "" {
+				iter, err = state.MaintenancePlansByIDPrefix(ws, prefix)
+			} else {
+				iter, err = state.MaintenancePlans(ws)
+			}
+			if err != nil {
+				return err
+			}
+
+			var plans []*structs.MaintenancePlan
+			for {
+				raw := iter.Next()
+				if raw == nil {
+					break
+				}
+				plans = append(plans, raw.(*structs.MaintenancePlan))
+			}
+			reply.Plans = plans
+
+			// Use the last index that affected the maintenance plans table
+			index, err := state.Index("maintenance_plans")
+			if err != nil {
+				return err
+			}
+			reply.Index = index
+
+			// Set the query response
+			m.srv.setQueryMeta(&reply.QueryMeta)
+			return nil
+		}}
+	return m.srv.blockingRPC(&opts)
+}
@@ -0,0 +1,383 @@
+package nomad
+
+import (
+	"fmt"
+	"testing"
+
+	msgpackrpc "github.com/hashicorp/net-rpc-msgpackrpc"
+	"github.com/hashicorp/nomad/acl"
+	"github.com/hashicorp/nomad/nomad/drainer"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMaintenance_Register(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	s1 := TestServer(t, nil)
+	defer s1.Shutdown()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	// Disable drainer to prevent the plan from progressing during test
+	s1.nodeDrainer.SetEnabled(false, nil)
+
+	// Create nodes in dc1, a node in dc2 and a down node in dc1
+	state := s1.fsm.State()
+	var nodes []*structs.Node
+	for i, name := range []string{"c", "a", "b"} {
+		node := mock.Node()
+		node.Name = name
+		require.Nil(state.UpsertNode(uint64(1000+i), node))
+		nodes = append(nodes, node)
+	}
+	other := mock.Node()
+	other.Datacenter = "dc2"
+	require.Nil(state.UpsertNode(1010, other))
+	down := mock.Node()
+	down.Status = structs.NodeStatusDown
+	require.Nil(state.UpsertNode(1011, down))
+
+	// Register the plan
+	plan := mock.MaintenancePlan()
+	plan.BatchSize = 2
+	req := &structs.MaintenancePlanRegisterRequest{
+		Plan:         plan,
+		WriteRequest: structs.WriteRequest{Region: "global"},
+	}
+	var resp structs.MaintenancePlanUpdateResponse
+	require.Nil(msgpackrpc.CallWithCodec(codec, "Maintenance.Register", req, &resp))
+	require.NotZero(resp.Index)
+	require.NotNil(resp.Plan)
+	require.Equal(resp.Index, resp.Plan.CreateIndex)
+
+	// Check for the plan in the FSM
+	out, err := state.MaintenancePlanByID(nil, resp.Plan.ID)
+	require.Nil(err)
+	require.NotNil(out)
+	require.Equal(structs.MaintenancePlanStatusPending, out.Status)
+	require.False(out.StartTime.IsZero())
+
+	// The nodes are ordered by name and split in batches
+	require.Len(out.Nodes, 3)
+	expected := []struct {
+		node  *structs.Node
+		batch int
+	}{{nodes[1], 0}, {nodes[2], 0}, {nodes[0], 1}}
+	for i, e := range expected {
+		require.Equal(e.node.ID, out.Nodes[i].NodeID)
+		require.Equal(e.node.Name, out.Nodes[i].Name)
+		require.Equal(e.batch, out.Nodes[i].Batch)
+		require.Equal(structs.MaintenanceNodeStatusPending, out.Nodes[i].Status)
+	}
+
+	// Registering another plan selecting the same nodes fails
+	req.Plan = mock.MaintenancePlan()
+	err = msgpackrpc.CallWithCodec(codec, "Maintenance.Register", req, &resp)
+	require.Error(err)
+	require.Contains(err.Error(), "is part of active maintenance plan")
+
+	// Registering a plan selecting no node fails
+	req.Plan = mock.MaintenancePlan()
+	req.Plan.Datacenters = []string{"dc3"}
+	err = msgpackrpc.CallWithCodec(codec, "Maintenance.Register", req, &resp)
+	require.Error(err)
+	require.Contains(err.Error(), "doesn't select any node")
+
+	// Registering an invalid plan fails
+	req.Plan = mock.MaintenancePlan()
+	req.Plan.Datacenters = []string{"dc2"}
+	req.Plan.BatchSize = 0
+	err = msgpackrpc.CallWithCodec(codec, "Maintenance.Register", req, &resp)
+	require.Error(err)
+	require.Contains(err.Error(), "Batch size")
+}
+
+func TestMaintenance_Cancel(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	s1 := TestServer(t, nil)
+	defer s1.Shutdown()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	// Disable drainer to prevent the plan from progressing during test
+	s1.nodeDrainer.SetEnabled(false, nil)
+
+	state := s1.fsm.State()
+	node := mock.Node()
+	require.Nil(state.UpsertNode(1000, node))
+	plan := mock.MaintenancePlan()
+	plan.Nodes = []*structs.MaintenancePlanNode{
+		{NodeID: node.ID, Name: node.Name, Status: structs.MaintenanceNodeStatusPending},
+	}
+	require.Nil(state.UpsertMaintenancePlan(1001, plan, nil, nil))
+
+	// Cancel the plan
+	req := &structs.MaintenancePlanCancelRequest{
+		PlanID:       plan.ID,
+		WriteRequest: structs.WriteRequest{Region: "global"},
+	}
+	var resp structs.MaintenancePlanUpdateResponse
+	require.Nil(msgpackrpc.CallWithCodec(codec, "Maintenance.Cancel", req, &resp))
+	require.NotZero(resp.Index)
+	require.Equal(structs.MaintenancePlanStatusCancelled, resp.Plan.Status)
+
+	out, err := state.MaintenancePlanByID(nil, plan.ID)
+	require.Nil(err)
+	require.Equal(structs.MaintenancePlanStatusCancelled, out.Status)
+	require.Equal(structs.MaintenancePlanDescriptionCancelled, out.StatusDescription)
+	require.Equal(resp.Index, out.ModifyIndex)
+
+	// Cancelling it again fails
+	err = msgpackrpc.CallWithCodec(codec, "Maintenance.Cancel", req, &resp)
+	require.Error(err)
+	require.Contains(err.Error(), "already cancelled")
+
+	// Cancelling an unknown plan fails
+	req.PlanID = "foo"
+	err = msgpackrpc.CallWithCodec(codec, "Maintenance.Cancel", req, &resp)
+	require.Error(err)
+	require.Contains(err.Error(), "not found")
+}
+
+func TestMaintenance_GetPlan_List(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	s1 := TestServer(t, nil)
+	defer s1.Shutdown()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	// Disable drainer to prevent the plans from progressing during test
+	s1.nodeDrainer.SetEnabled(false, nil)
+
+	state := s1.fsm.State()
+	plan1 := mock.MaintenancePlan()
+	plan1.ID = "aaaaaaaa-3350-4b4b-d185-0e1992ed43e9"
+	plan2 := mock.MaintenancePlan()
+	plan2.ID = "bbbbbbbb-3350-4b4b-d185-0e1992ed43e9"
+	require.Nil(state.UpsertMaintenancePlan(1000, plan1, nil, nil))
+	require.Nil(state.UpsertMaintenancePlan(1001, plan2, nil, nil))
+
+	// Lookup a plan
+	get := &structs.MaintenancePlanSpecificRequest{
+		PlanID:       plan1.ID,
+		QueryOptions: structs.QueryOptions{Region: "global"},
+	}
+	var resp structs.SingleMaintenancePlanResponse
+	require.Nil(msgpackrpc.CallWithCodec(codec, "Maintenance.GetPlan", get, &resp))
+	require.Equal(uint64(1000), resp.Index)
+	require.NotNil(resp.Plan)
+	require.Equal(plan1.ID, resp.Plan.ID)
+
+	// Lookup a missing plan
+	get.PlanID = "foo"
+	var resp2 structs.SingleMaintenancePlanResponse
+	require.Nil(msgpackrpc.CallWithCodec(codec, "Maintenance.GetPlan", get, &resp2))
+	require.Equal(uint64(1001), resp2.Index)
+	require.Nil(resp2.Plan)
+
+	// List the plans
+	list := &structs.MaintenancePlanListRequest{
+		QueryOptions: structs.QueryOptions{Region: "global"},
+	}
+	var resp3 structs.MaintenancePlanListResponse
+	require.Nil(msgpackrpc.CallWithCodec(codec, "Maintenance.List", list, &resp3))
+	require.Equal(uint64(1001), resp3.Index)
+	require.Len(resp3.Plans, 2)
+
+	// List the plans with a prefix
+	list.Prefix = "bbbb"
+	var resp4 structs.MaintenancePlanListResponse
+	require.Nil(msgpackrpc.CallWithCodec(codec, "Maintenance.List", list, &resp4))
+	require.Len(resp4.Plans, 1)
+	require.Equal(plan2.ID, resp4.Plans[0].ID)
+}
+
+func TestMaintenance_ACL(t *testing.T) {
+	t.Parallel()
+	s1, root := TestACLServer(t, nil)
+	defer s1.Shutdown()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+	require := require.New(t)
+
+	// Disable drainer to prevent the plans from progressing during test
+	s1.nodeDrainer.SetEnabled(false, nil)
+
+	state := s1.fsm.State()
+	require.Nil(state.UpsertNode(1000, mock.Node()))
+	plan := mock.MaintenancePlan()
+	require.Nil(state.UpsertMaintenancePlan(1001, plan, nil, nil))
+
+	// Create the policy and tokens
+	readToken := mock.CreatePolicyAndToken(t, state, 1003, "test-read", mock.NodePolicy(acl.PolicyRead))
+	drainToken := mock.CreatePolicyAndToken(t, state, 1005, "test-drain",
+		mock.NodeCapabilityPolicy(acl.PolicyRead, []string{acl.NodeCapabilityDrain}))
+	invalidToken := mock.CreatePolicyAndToken(t, state, 1007, "test-invalid", mock.NamespacePolicy(structs.DefaultNamespace, "", []string{acl.NamespaceCapabilityListJobs}))
+
+	// Reading requires the node read permissions
+	get := &structs.MaintenancePlanSpecificRequest{
+		PlanID:       plan.ID,
+		QueryOptions: structs.QueryOptions{Region: "global"},
+	}
+	list := &structs.MaintenancePlanListRequest{
+		QueryOptions: structs.QueryOptions{Region: "global"},
+	}
+	for _, token := range []string{"", invalidToken.SecretID} {
+		get.AuthToken, list.AuthToken = token, token
+		var resp structs.SingleMaintenancePlanResponse
+		err := msgpackrpc.CallWithCodec(codec, "Maintenance.GetPlan", get, &resp)
+		require.NotNil(err, "RPC")
+		require.Equal(err.Error(), structs.ErrPermissionDenied.Error())
+
+		var resp2 structs.MaintenancePlanListResponse
+		err = msgpackrpc.CallWithCodec(codec, "Maintenance.List", list, &resp2)
+		require.NotNil(err, "RPC")
+		require.Equal(err.Error(), structs.ErrPermissionDenied.Error())
+	}
+	for _, token := range []string{readToken.SecretID, root.SecretID} {
+		get.AuthToken, list.AuthToken = token, token
+		var resp structs.SingleMaintenancePlanResponse
+		require.Nil(msgpackrpc.CallWithCodec(codec, "Maintenance.GetPlan", get, &resp), "RPC")
+		require.NotNil(resp.Plan)
+
+		var resp2 structs.MaintenancePlanListResponse
+		require.Nil(msgpackrpc.CallWithCodec(codec, "Maintenance.List", list, &resp2), "RPC")
+		require.Len(resp2.Plans, 1)
+	}
+
+	// Registering and cancelling requires the node-drain capability
+	reg := &structs.MaintenancePlanRegisterRequest{
+		WriteRequest: structs.WriteRequest{Region: "global"},
+	}
+	cancel := &structs.MaintenancePlanCancelRequest{
+		PlanID:       plan.ID,
+		WriteRequest: structs.WriteRequest{Region: "global"},
+	}
+	for _, token := range []string{"", readToken.SecretID} {
+		reg.Plan = mock.MaintenancePlan()
+		reg.AuthToken, cancel.AuthToken = token, token
+		var resp structs.MaintenancePlanUpdateResponse
+		err := msgpackrpc.CallWithCodec(codec, "Maintenance.Register", reg, &resp)
+		require.NotNil(err, "RPC")
+		require.Equal(err.Error(), structs.ErrPermissionDenied.Error())
+
+		err = msgpackrpc.CallWithCodec(codec, "Maintenance.Cancel", cancel, &resp)
+		require.NotNil(err, "RPC")
+		require.Equal(err.Error(), structs.ErrPermissionDenied.Error())
+	}
+
+	// Try with a token granted the node-drain capability
+	cancel.AuthToken = drainToken.SecretID
+	{
+		var resp structs.MaintenancePlanUpdateResponse
+		require.Nil(msgpackrpc.CallWithCodec(codec, "Maintenance.Cancel", cancel, &resp), "RPC")
+	}
+
+	// Try with a root token
+	reg.Plan = mock.MaintenancePlan()
+	reg.AuthToken = root.SecretID
+	{
+		var resp structs.MaintenancePlanUpdateResponse
+		require.Nil(msgpackrpc.CallWithCodec(codec, "Maintenance.Register", reg, &resp), "RPC")
+	}
+}
+
+// TestMaintenance_Execute asserts that the leader drains the nodes of a plan
+// batch by batch.
+func TestMaintenance_Execute(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	s1 := TestServer(t, nil)
+	defer s1.Shutdown()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	// Register two nodes without allocations so their drain completes
+	var nodes []*structs.Node
+	for _, name := range []string{"a", "b"} {
+		node := mock.Node()
+		node.Name = name
+		reg := &structs.NodeRegisterRequest{
+			Node:         node,
+			WriteRequest: structs.WriteRequest{Region: "global"},
+		}
+		var resp structs.NodeUpdateResponse
+		require.Nil(msgpackrpc.CallWithCodec(codec, "Node.Register", reg, &resp))
+		nodes = append(nodes, node)
+	}
+
+	// Register the plan
+	req := &structs.MaintenancePlanRegisterRequest{
+		Plan:         mock.MaintenancePlan(),
+		WriteRequest: structs.WriteRequest{Region: "global"},
+	}
+	var resp structs.MaintenancePlanUpdateResponse
+	require.Nil(msgpackrpc.CallWithCodec(codec, "Maintenance.Register", req, &resp))
+	planID := resp.Plan.ID
+
+	state := s1.fsm.State()
+	waitForNodeStatuses := func(expected ...string) {
+		testutil.WaitForResult(func() (bool, error) {
+			plan, err := state.MaintenancePlanByID(nil, planID)
+			if err != nil {
+				return false, err
+			}
+			for i, status := range expected {
+				if actual := plan.Nodes[i].Status; actual != status {
+					return false, fmt.Errorf("node %d has status %q; want %q", i, actual, status)
+				}
+			}
+			return true, nil
+		}, func(err error) {
+			t.Fatal(err)
+		})
+	}
+	markEligible := func(node *structs.Node) {
+		req := &structs.NodeUpdateEligibilityRequest{
+			NodeID:       node.ID,
+			Eligibility:  structs.NodeSchedulingEligible,
+			WriteRequest: structs.WriteRequest{Region: "global"},
+		}
+		var resp structs.NodeEligibilityUpdateResponse
+		require.Nil(msgpackrpc.CallWithCodec(codec, "Node.UpdateEligibility", req, &resp))
+	}
+
+	// The first node is drained while the second waits
+	waitForNodeStatuses(structs.MaintenanceNodeStatusDrained, structs.MaintenanceNodeStatusPending)
+	out, err := state.NodeByID(nil, nodes[0].ID)
+	require.Nil(err)
+	require.Equal(structs.NodeSchedulingIneligible, out.SchedulingEligibility)
+	require.Equal(drainer.NodeDrainEventMaintenancePlan, out.Events[1].Message)
+
+	// Marking the first node eligible drains the second one
+	markEligible(nodes[0])
+	waitForNodeStatuses(structs.MaintenanceNodeStatusComplete, structs.MaintenanceNodeStatusDrained)
+
+	// Marking the second node eligible completes the plan
+	markEligible(nodes[1])
+	testutil.WaitForResult(func() (bool, error) {
+		plan, err := state.MaintenancePlanByID(nil, planID)
+		if err != nil {
+			return false, err
+		}
+		if plan.Status != structs.MaintenancePlanStatusComplete {
+			return false, fmt.Errorf("plan has status %q", plan.Status)
+		}
+		return true, nil
+	}, func(err error) {
+		t.Fatal(err)
+	})
+
+	// Both nodes were only drained once
+	for _, node := range nodes {
+		out, err := state.NodeByID(nil, node.ID)
+		require.Nil(err)
+		require.Nil(out.DrainStrategy)
+		require.Equal(structs.NodeSchedulingEligible, out.SchedulingEligibility)
+	}
+}
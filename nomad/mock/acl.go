@@ -48,6 +48,38 @@ func NodePolicy(policy string) string {
 	return fmt.Sprintf("node {\n\tpolicy = %q\n}\n", policy)
 }
 
+// NodeCapabilityPolicy is a helper for generating the hcl for a given node
+// policy and capabilities. Either policy or capabilities may be empty but not
+// both.
+func NodeCapabilityPolicy(policy string, capabilities []string) string {
+	return capabilityPolicy("node", policy, capabilities)
+}
+
+// OperatorCapabilityPolicy is a helper for generating the hcl for a given
+// operator policy and capabilities. Either policy or capabilities may be empty
+// but not both.
+func OperatorCapabilityPolicy(policy string, capabilities []string) string {
+	return capabilityPolicy("operator", policy, capabilities)
+}
+
+// capabilityPolicy generates the hcl of a stanza with a policy and
+// capabilities
+func capabilityPolicy(stanza, policy string, capabilities []string) string {
+	policyHCL := stanza + " {"
+	if policy != "" {
+		policyHCL += fmt.Sprintf("\n\tpolicy = %q", policy)
+	}
+	if len(capabilities) != 0 {
+		quoted := make([]string, len(capabilities))
+		for i, s := range capabilities {
+			quoted[i] = strconv.Quote(s)
+		}
+		policyHCL += fmt.Sprintf("\n\tcapabilities = [%v]", strings.Join(quoted, ","))
+	}
+	policyHCL += "\n}\n"
+	return policyHCL
+}
+
 // QuotaPolicy is a helper for generating the hcl for a given quota policy.
 func QuotaPolicy(policy string) string {
 	return fmt.Sprintf("quota {\n\tpolicy = %q\n}\n", policy)
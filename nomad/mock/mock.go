@@ -388,6 +388,20 @@ func SystemJob() *structs.Job {
 	return job
 }
 
+func SysBatchJob() *structs.Job {
+	job := SystemJob()
+	job.ID = fmt.Sprintf("mock-sysbatch-%s", uuid.Generate())
+	job.Type = structs.JobTypeSysBatch
+	job.TaskGroups[0].RestartPolicy = &structs.RestartPolicy{
+		Attempts: 3,
+		Interval: 10 * time.Minute,
+		Delay:    1 * time.Minute,
+		Mode:     structs.RestartPolicyModeFail,
+	}
+	job.Canonicalize()
+	return job
+}
+
 func PeriodicJob() *structs.Job {
 	job := Job()
 	job.Type = structs.JobTypeBatch
@@ -679,6 +693,19 @@ func Deployment() *structs.Deployment {
 	}
 }
 
+// MaintenancePlan returns a pending maintenance plan of the nodes of dc1. The
+// plan has no start time so its maintenance window is open.
+func MaintenancePlan() *structs.MaintenancePlan {
+	return &structs.MaintenancePlan{
+		ID:                uuid.Generate(),
+		Datacenters:       []string{"dc1"},
+		BatchSize:         1,
+		Deadline:          time.Hour,
+		Status:            structs.MaintenancePlanStatusPending,
+		StatusDescription: structs.MaintenancePlanDescriptionPending,
+	}
+}
+
 func Plan() *structs.Plan {
 	return &structs.Plan{
 		Priority: 50,
@@ -711,6 +738,103 @@ func ACLPolicy() *structs.ACLPolicy {
 	return ap
 }
 
+// authMethodPubKey is the public key used by mock JWT auth methods
+const authMethodPubKey = `-----BEGIN PUBLIC KEY-----
+MFkwEwYHKoZIzj0CAQYIKoZIzj0DAQcDQgAEqLGi7PUTlQbaFFdfl7fIaAYS/T1x
+EsNguy/IftOlGXtbei9pWboSA7l/gf5MeZE+kM7ksd8Wfo6ABlMbgJBCsw==
+-----END PUBLIC KEY-----
+`
+
+func ACLAuthMethod() *structs.ACLAuthMethod {
+	method := &structs.ACLAuthMethod{
+		Name:          fmt.Sprintf("auth-method-%s", uuid.Generate()[:8]),
+		Type:          structs.ACLAuthMethodTypeJWT,
+		TokenLocality: structs.ACLAuthMethodTokenLocalityLocal,
+		MaxTokenTTL:   time.Hour,
+		Config: &structs.ACLAuthMethodConfig{
+			JWTValidationPubKeys: []string{authMethodPubKey},
+			BoundIssuer:          "https://issuer.example.com",
+			BoundAudiences:       []string{"nomad"},
+			ClaimMappings:        map[string]string{"sub": "user"},
+			ListClaimMappings:    map[string]string{"groups": "groups"},
+		},
+		CreateIndex: 10,
+		ModifyIndex: 20,
+	}
+	method.SetHash()
+	return method
+}
+
+func ACLBindingRule() *structs.ACLBindingRule {
+	rule := &structs.ACLBindingRule{
+		ID:          uuid.Generate(),
+		Description: "Super cool binding rule!",
+		AuthMethod:  "auth-method",
+		Selector:    `"engineering" in list.groups`,
+		BindType:    structs.ACLBindingRuleBindTypePolicy,
+		BindName:    "engineering",
+		CreateIndex: 10,
+		ModifyIndex: 20,
+	}
+	rule.SetHash()
+	return rule
+}
+
+func ACLRole() *structs.ACLRole {
+	role := &structs.ACLRole{
+		Name:        fmt.Sprintf("role-%s", uuid.Generate()),
+		Description: "Super cool role!",
+		Policies:    []string{"foo", "bar"},
+		CreateIndex: 10,
+		ModifyIndex: 20,
+	}
+	role.SetHash()
+	return role
+}
+
+func RootKey() *structs.RootKey {
+	key, _ := RootKeyWithMaterial()
+	return key
+}
+
+// RootKeyWithMaterial returns a new active root key and its key material
+func RootKeyWithMaterial() (*structs.RootKey, *structs.RootKeyMaterial) {
+	key, material, err := structs.NewRootKey()
+	if err != nil {
+		panic(err)
+	}
+	return key, material
+}
+
+// Variable returns a decrypted variable of the default namespace
+func Variable() *structs.VariableDecrypted {
+	return &structs.VariableDecrypted{
+		VariableMetadata: structs.VariableMetadata{
+			Namespace: structs.DefaultNamespace,
+			Path:      "nomad/jobs/example/" + uuid.Generate(),
+		},
+		Items: structs.VariableItems{
+			"user":     "admin",
+			"password": uuid.Generate(),
+		},
+	}
+}
+
+// VariableEncrypted returns a variable of the default namespace with
+// arbitrary encrypted data
+func VariableEncrypted() *structs.VariableEncrypted {
+	return &structs.VariableEncrypted{
+		VariableMetadata: structs.VariableMetadata{
+			Namespace: structs.DefaultNamespace,
+			Path:      "nomad/jobs/example/" + uuid.Generate(),
+		},
+		VariableData: structs.VariableData{
+			Data:  []byte(uuid.Generate()),
+			KeyID: uuid.Generate(),
+		},
+	}
+}
+
 func ACLToken() *structs.ACLToken {
 	tk := &structs.ACLToken{
 		AccessorID:  uuid.Generate(),
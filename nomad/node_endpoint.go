@@ -17,6 +17,7 @@ import (
 
 	"github.com/hashicorp/nomad/acl"
 	"github.com/hashicorp/nomad/helper/uuid"
+	"github.com/hashicorp/nomad/nomad/drainer"
 	"github.com/hashicorp/nomad/nomad/state"
 	"github.com/hashicorp/nomad/nomad/structs"
 	"github.com/hashicorp/raft"
@@ -375,8 +376,9 @@ func (n *Node) UpdateStatus(args *structs.NodeUpdateStatusRequest, reply *struct
 	var index uint64
 	if node.Status != args.Status {
 		// Attach an event if we are updating the node status to ready when it
-		// is down via a heartbeat
-		if node.Status == structs.NodeStatusDown && args.NodeEvent == nil {
+		// is down or disconnected via a heartbeat
+		if (node.Status == structs.NodeStatusDown || node.Status == structs.NodeStatusDisconnected) &&
+			args.NodeEvent == nil {
 			args.NodeEvent = structs.NewNodeEvent().
 				SetSubsystem(structs.NodeEventSubsystemCluster).
 				SetMessage(NodeHeartbeatEventReregistered)
@@ -404,6 +406,9 @@ func (n *Node) UpdateStatus(args *structs.NodeUpdateStatusRequest, reply *struct
 
 	// Check if we need to setup a heartbeat
 	switch args.Status {
+	case structs.NodeStatusDisconnected:
+		// The heartbeater tracks how long the node may remain disconnected
+		// and keeps any Vault tokens of its allocations valid in the meantime
 	case structs.NodeStatusDown:
 		// Determine if there are any Vault accessors on the node
 		accessors, err := n.srv.State().VaultAccessorsByNode(ws, args.NodeID)
@@ -445,7 +450,8 @@ func (n *Node) UpdateStatus(args *structs.NodeUpdateStatusRequest, reply *struct
 func transitionedToReady(newStatus, oldStatus string) bool {
 	initToReady := oldStatus == structs.NodeStatusInit && newStatus == structs.NodeStatusReady
 	terminalToReady := oldStatus == structs.NodeStatusDown && newStatus == structs.NodeStatusReady
-	return initToReady || terminalToReady
+	disconnectedToReady := oldStatus == structs.NodeStatusDisconnected && newStatus == structs.NodeStatusReady
+	return initToReady || terminalToReady || disconnectedToReady
 }
 
 // UpdateDrain is used to update the drain mode of a client node
@@ -456,10 +462,10 @@ func (n *Node) UpdateDrain(args *structs.NodeUpdateDrainRequest,
 	}
 	defer metrics.MeasureSince([]string{"nomad", "client", "update_drain"}, time.Now())
 
-	// Check node write permissions
+	// Check node node-drain permissions
 	if aclObj, err := n.srv.ResolveToken(args.AuthToken); err != nil {
 		return err
-	} else if aclObj != nil && !aclObj.AllowNodeWrite() {
+	} else if aclObj != nil && !aclObj.AllowNodeOperation(acl.NodeCapabilityDrain) {
 		return structs.ErrPermissionDenied
 	}
 
@@ -499,6 +505,11 @@ func (n *Node) UpdateDrain(args *structs.NodeUpdateDrainRequest,
 		args.DrainStrategy.ForceDeadline = time.Now().Add(args.DrainStrategy.Deadline)
 	}
 
+	// The drain progress is computed when read and never stored
+	if args.DrainStrategy != nil {
+		args.DrainStrategy.Progress = nil
+	}
+
 	// Construct the node event
 	args.NodeEvent = structs.NewNodeEvent().SetSubsystem(structs.NodeEventSubsystemDrain)
 	if node.DrainStrategy == nil && args.DrainStrategy != nil {
@@ -544,10 +555,10 @@ func (n *Node) UpdateEligibility(args *structs.NodeUpdateEligibilityRequest,
 	}
 	defer metrics.MeasureSince([]string{"nomad", "client", "update_eligibility"}, time.Now())
 
-	// Check node write permissions
+	// Check node node-eligibility permissions
 	if aclObj, err := n.srv.ResolveToken(args.AuthToken); err != nil {
 		return err
-	} else if aclObj != nil && !aclObj.AllowNodeWrite() {
+	} else if aclObj != nil && !aclObj.AllowNodeOperation(acl.NodeCapabilityEligibility) {
 		return structs.ErrPermissionDenied
 	}
 
@@ -814,7 +825,7 @@ func (n *Node) GetAllocs(args *structs.NodeSpecificRequest,
 				reply.Allocs = make([]*structs.Allocation, 0, n)
 				for _, alloc := range allocs {
 					if readNS(alloc.Namespace) {
-						reply.Allocs = append(reply.Allocs, alloc)
+						reply.Allocs = append(reply.Allocs, alloc.Sanitize())
 					}
 
 					// Get the max of all allocs since
@@ -844,6 +855,75 @@ func (n *Node) GetAllocs(args *structs.NodeSpecificRequest,
 	return n.srv.blockingRPC(&opts)
 }
 
+// DrainStatus is used to get the drain strategy of a node along with the
+// progress of the drain
+func (n *Node) DrainStatus(args *structs.NodeSpecificRequest,
+	reply *structs.NodeDrainStatusResponse) error {
+	if done, err := n.srv.forward("Node.DrainStatus", args, args, reply); done {
+		return err
+	}
+	defer metrics.MeasureSince([]string{"nomad", "client", "drain_status"}, time.Now())
+
+	// Check node read permissions
+	aclObj, err := n.srv.ResolveToken(args.AuthToken)
+	if err != nil {
+		return err
+	}
+	if aclObj != nil && !aclObj.AllowNodeRead() {
+		return structs.ErrPermissionDenied
+	}
+
+	// Verify the arguments
+	if args.NodeID == "" {
+		return fmt.Errorf("missing node ID")
+	}
+
+	// Setup the blocking query
+	opts := blockingOptions{
+		queryOpts: &args.QueryOptions,
+		queryMeta: &reply.QueryMeta,
+		run: func(ws memdb.WatchSet, state *state.StateStore) error {
+			node, err := state.NodeByID(ws, args.NodeID)
+			if err != nil {
+				return err
+			}
+			if node == nil {
+				return fmt.Errorf("node %q not found", args.NodeID)
+			}
+
+			allocs, err := state.AllocsByNode(ws, args.NodeID)
+			if err != nil {
+				return err
+			}
+
+			reply.NodeID = node.ID
+			reply.DrainStrategy = nil
+			reply.Index = node.ModifyIndex
+			for _, alloc := range allocs {
+				reply.Index = maxUint64(reply.Index, alloc.ModifyIndex)
+			}
+
+			if node.DrainStrategy != nil {
+				reply.DrainStrategy = node.DrainStrategy.Copy()
+				progress := drainer.NodeDrainProgress(node, allocs)
+
+				// Only expose the blocked jobs of readable namespaces
+				blocked := progress.BlockedJobs[:0]
+				for _, job := range progress.BlockedJobs {
+					if aclObj == nil || aclObj.AllowNsOp(job.Namespace, acl.NamespaceCapabilityReadJob) {
+						blocked = append(blocked, job)
+					}
+				}
+				progress.BlockedJobs = blocked
+				reply.DrainStrategy.Progress = progress
+			}
+
+			n.srv.setQueryMeta(&reply.QueryMeta)
+			return nil
+		}}
+	return n.srv.blockingRPC(&opts)
+}
+
 // GetClientAllocs is used to request a lightweight list of alloc modify indexes
 // per allocation.
 func (n *Node) GetClientAllocs(args *structs.NodeSpecificRequest,
@@ -1037,6 +1117,26 @@ func (n *Node) UpdateAlloc(args *structs.AllocUpdateRequest, reply *structs.Gene
 				}
 			}
 		}
+
+		// Add an evaluation if this alloc was marked unknown while its node
+		// was disconnected so the scheduler can reconcile it with any
+		// replacement
+		if alloc.ClientStatus != structs.AllocClientStatusUnknown {
+			existingAlloc, _ := n.srv.State().AllocByID(nil, alloc.ID)
+			if existingAlloc != nil && existingAlloc.Job != nil &&
+				existingAlloc.ClientStatus == structs.AllocClientStatusUnknown {
+				eval := &structs.Evaluation{
+					ID:          uuid.Generate(),
+					Namespace:   existingAlloc.Namespace,
+					TriggeredBy: structs.EvalTriggerReconnect,
+					JobID:       existingAlloc.JobID,
+					Type:        existingAlloc.Job.Type,
+					Priority:    existingAlloc.Job.Priority,
+					Status:      structs.EvalStatusPending,
+				}
+				evals = append(evals, eval)
+			}
+		}
 	}
 
 	// Add this to the batch
@@ -1227,6 +1327,20 @@ func (n *Node) createNodeEvals(nodeID string, nodeIndex uint64) ([]string, uint6
 		sysJobs = append(sysJobs, job.(*structs.Job))
 	}
 
+	sysBatchJobsIter, err := snap.JobsByScheduler(ws, "sysbatch")
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to find sysbatch jobs for '%s': %v", nodeID, err)
+	}
+
+	for raw := sysBatchJobsIter.Next(); raw != nil; raw = sysBatchJobsIter.Next() {
+		// Periodic and parameterized jobs only run through their children
+		job := raw.(*structs.Job)
+		if job.IsPeriodic() || job.IsParameterized() {
+			continue
+		}
+		sysJobs = append(sysJobs, job)
+	}
+
 	// Fast-path if nothing to do
 	if len(allocs) == 0 && len(sysJobs) == 0 {
 		return nil, 0, nil
@@ -1477,12 +1591,15 @@ func (n *Node) DeriveVaultToken(args *structs.DeriveVaultTokenRequest,
 	for task, secret := range results {
 		w := secret.WrapInfo
 		tokens[task] = w.Token
+		taskVault := tg[task]
 		accessor := &structs.VaultAccessor{
 			Accessor:    w.WrappedAccessor,
 			Task:        task,
 			NodeID:      alloc.NodeID,
 			AllocID:     alloc.ID,
 			CreationTTL: w.TTL,
+			Cluster:     taskVault.GetCluster(),
+			Namespace:   taskVault.Namespace,
 		}
 
 		accessors = append(accessors, accessor)
@@ -11,10 +11,12 @@ import (
 	memdb "github.com/hashicorp/go-memdb"
 	msgpackrpc "github.com/hashicorp/net-rpc-msgpackrpc"
 	"github.com/hashicorp/nomad/acl"
+	"github.com/hashicorp/nomad/helper"
 	"github.com/hashicorp/nomad/helper/uuid"
 	"github.com/hashicorp/nomad/nomad/mock"
 	"github.com/hashicorp/nomad/nomad/state"
 	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/nomad/structs/config"
 	"github.com/hashicorp/nomad/testutil"
 	vapi "github.com/hashicorp/vault/api"
 	"github.com/stretchr/testify/assert"
@@ -976,6 +978,15 @@ func TestClientEndpoint_UpdateDrain_ACL(t *testing.T) {
 		require.Nil(msgpackrpc.CallWithCodec(codec, "Node.UpdateDrain", dereg, &resp), "RPC")
 	}
 
+	// Try with a token only granted the node-drain capability
+	drainToken := mock.CreatePolicyAndToken(t, state, 1005, "test-drain",
+		mock.NodeCapabilityPolicy(acl.PolicyRead, []string{acl.NodeCapabilityDrain}))
+	dereg.AuthToken = drainToken.SecretID
+	{
+		var resp structs.NodeDrainUpdateResponse
+		require.Nil(msgpackrpc.CallWithCodec(codec, "Node.UpdateDrain", dereg, &resp), "RPC")
+	}
+
 	// Try with a invalid token
 	dereg.AuthToken = invalidToken.SecretID
 	{
@@ -993,6 +1004,162 @@ func TestClientEndpoint_UpdateDrain_ACL(t *testing.T) {
 	}
 }
 
+func TestClientEndpoint_DrainStatus(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	s1 := TestServer(t, nil)
+	defer s1.Shutdown()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	// Disable drainer to prevent drain from completing during test
+	s1.nodeDrainer.SetEnabled(false, nil)
+
+	// Create a node that isn't draining
+	node := mock.Node()
+	state := s1.fsm.State()
+	require.Nil(state.UpsertNode(1000, node))
+
+	// Lookup the drain status and expect no drain strategy
+	get := &structs.NodeSpecificRequest{
+		NodeID:       node.ID,
+		QueryOptions: structs.QueryOptions{Region: "global"},
+	}
+	var resp structs.NodeDrainStatusResponse
+	require.Nil(msgpackrpc.CallWithCodec(codec, "Node.DrainStatus", get, &resp))
+	require.Equal(uint64(1000), resp.Index)
+	require.Equal(node.ID, resp.NodeID)
+	require.Nil(resp.DrainStrategy)
+
+	// Place a service and a batch allocation on the node and drain it
+	alloc := mock.Alloc()
+	alloc.NodeID = node.ID
+	alloc.Job.Priority = 70
+	batch := mock.BatchAlloc()
+	batch.NodeID = node.ID
+	batch.Job.Priority = 30
+	state.UpsertJobSummary(1001, mock.JobSummary(alloc.JobID))
+	state.UpsertJobSummary(1002, mock.JobSummary(batch.JobID))
+	require.Nil(state.UpsertAllocs(1003, []*structs.Allocation{alloc, batch}))
+
+	strategy := &structs.DrainStrategy{
+		DrainSpec: structs.DrainSpec{
+			Deadline:        10 * time.Second,
+			OrderByPriority: true,
+		},
+	}
+	require.Nil(state.UpdateNodeDrain(1004, node.ID, strategy, false, nil))
+
+	get.MinQueryIndex = 1000
+	var resp2 structs.NodeDrainStatusResponse
+	require.Nil(msgpackrpc.CallWithCodec(codec, "Node.DrainStatus", get, &resp2))
+	require.Equal(uint64(1004), resp2.Index)
+	require.NotNil(resp2.DrainStrategy)
+	require.True(resp2.DrainStrategy.OrderByPriority)
+
+	progress := resp2.DrainStrategy.Progress
+	require.NotNil(progress)
+	require.Equal(2, progress.AllocsRemaining)
+	require.Zero(progress.AllocsMigrating)
+	require.Len(progress.BlockedJobs, 2)
+	require.Equal(batch.JobID, progress.BlockedJobs[0].JobID)
+	require.Equal(structs.DrainBlockedReasonBatch, progress.BlockedJobs[0].Reason)
+	require.Equal(alloc.JobID, progress.BlockedJobs[1].JobID)
+	require.Equal(structs.DrainBlockedReasonPriority, progress.BlockedJobs[1].Reason)
+
+	// Marking the batch allocation for migration should unblock the query
+	time.AfterFunc(100*time.Millisecond, func() {
+		transitions := map[string]*structs.DesiredTransition{
+			batch.ID: {Migrate: helper.BoolToPtr(true)},
+		}
+		if err := state.UpdateAllocsDesiredTransitions(1005, transitions, nil); err != nil {
+			t.Errorf("err: %v", err)
+		}
+	})
+
+	get.MinQueryIndex = 1004
+	var resp3 structs.NodeDrainStatusResponse
+	require.Nil(msgpackrpc.CallWithCodec(codec, "Node.DrainStatus", get, &resp3))
+	require.Equal(uint64(1005), resp3.Index)
+	require.Equal(2, resp3.DrainStrategy.Progress.AllocsRemaining)
+	require.Equal(1, resp3.DrainStrategy.Progress.AllocsMigrating)
+	require.Len(resp3.DrainStrategy.Progress.BlockedJobs, 1)
+
+	// Lookup a missing node
+	get.NodeID = uuid.Generate()
+	get.MinQueryIndex = 0
+	err := msgpackrpc.CallWithCodec(codec, "Node.DrainStatus", get, &resp3)
+	require.Error(err)
+	require.Contains(err.Error(), "not found")
+}
+
+func TestClientEndpoint_DrainStatus_ACL(t *testing.T) {
+	t.Parallel()
+	s1, root := TestACLServer(t, nil)
+	defer s1.Shutdown()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+	require := require.New(t)
+
+	// Create a draining node with an allocation
+	node := mock.Node()
+	node.DrainStrategy = &structs.DrainStrategy{
+		DrainSpec: structs.DrainSpec{
+			Deadline: 10 * time.Second,
+		},
+	}
+	state := s1.fsm.State()
+	require.Nil(state.UpsertNode(1, node), "UpsertNode")
+
+	alloc := mock.Alloc()
+	alloc.NodeID = node.ID
+	state.UpsertJobSummary(2, mock.JobSummary(alloc.JobID))
+	require.Nil(state.UpsertAllocs(3, []*structs.Allocation{alloc}), "UpsertAllocs")
+
+	// Create the policy and tokens
+	validToken := mock.CreatePolicyAndToken(t, state, 1001, "test-valid", mock.NodePolicy(acl.PolicyRead))
+	invalidToken := mock.CreatePolicyAndToken(t, state, 1003, "test-invalid", mock.NodePolicy(acl.PolicyDeny))
+
+	// Lookup the drain status without a token and expect failure
+	get := &structs.NodeSpecificRequest{
+		NodeID:       node.ID,
+		QueryOptions: structs.QueryOptions{Region: "global"},
+	}
+	{
+		var resp structs.NodeDrainStatusResponse
+		err := msgpackrpc.CallWithCodec(codec, "Node.DrainStatus", get, &resp)
+		require.NotNil(err, "RPC")
+		require.Equal(err.Error(), structs.ErrPermissionDenied.Error())
+	}
+
+	// Try with a valid token and expect the blocked jobs to be hidden
+	get.AuthToken = validToken.SecretID
+	{
+		var resp structs.NodeDrainStatusResponse
+		require.Nil(msgpackrpc.CallWithCodec(codec, "Node.DrainStatus", get, &resp), "RPC")
+		require.Equal(1, resp.DrainStrategy.Progress.AllocsRemaining)
+		require.Empty(resp.DrainStrategy.Progress.BlockedJobs)
+	}
+
+	// Try with a invalid token
+	get.AuthToken = invalidToken.SecretID
+	{
+		var resp structs.NodeDrainStatusResponse
+		err := msgpackrpc.CallWithCodec(codec, "Node.DrainStatus", get, &resp)
+		require.NotNil(err, "RPC")
+		require.Equal(err.Error(), structs.ErrPermissionDenied.Error())
+	}
+
+	// Try with a root token
+	get.AuthToken = root.SecretID
+	{
+		var resp structs.NodeDrainStatusResponse
+		require.Nil(msgpackrpc.CallWithCodec(codec, "Node.DrainStatus", get, &resp), "RPC")
+		require.Len(resp.DrainStrategy.Progress.BlockedJobs, 1)
+		require.Equal(alloc.JobID, resp.DrainStrategy.Progress.BlockedJobs[0].JobID)
+	}
+}
+
 // This test ensures that Nomad marks client state of allocations which are in
 // pending/running state to lost when a node is marked as down.
 func TestClientEndpoint_Drain_Down(t *testing.T) {
@@ -1220,6 +1387,26 @@ func TestClientEndpoint_UpdateEligibility_ACL(t *testing.T) {
 		require.Nil(msgpackrpc.CallWithCodec(codec, "Node.UpdateEligibility", dereg, &resp), "RPC")
 	}
 
+	// Try with a token granted the node-eligibility capability
+	eligibilityToken := mock.CreatePolicyAndToken(t, state, 1005, "test-eligibility",
+		mock.NodeCapabilityPolicy("", []string{acl.NodeCapabilityEligibility}))
+	dereg.AuthToken = eligibilityToken.SecretID
+	{
+		var resp structs.NodeEligibilityUpdateResponse
+		require.Nil(msgpackrpc.CallWithCodec(codec, "Node.UpdateEligibility", dereg, &resp), "RPC")
+	}
+
+	// Try with a token only granted the node-drain capability
+	drainToken := mock.CreatePolicyAndToken(t, state, 1007, "test-drain",
+		mock.NodeCapabilityPolicy("", []string{acl.NodeCapabilityDrain}))
+	dereg.AuthToken = drainToken.SecretID
+	{
+		var resp structs.NodeEligibilityUpdateResponse
+		err := msgpackrpc.CallWithCodec(codec, "Node.UpdateEligibility", dereg, &resp)
+		require.NotNil(err, "RPC")
+		require.Equal(err.Error(), structs.ErrPermissionDenied.Error())
+	}
+
 	// Try with a invalid token
 	dereg.AuthToken = invalidToken.SecretID
 	{
@@ -2830,8 +3017,8 @@ func TestClientEndpoint_DeriveVaultToken(t *testing.T) {
 
 	// Enable vault and allow authenticated
 	tr := true
-	s1.config.VaultConfig.Enabled = &tr
-	s1.config.VaultConfig.AllowUnauthenticated = &tr
+	s1.config.GetDefaultVault().Enabled = &tr
+	s1.config.GetDefaultVault().AllowUnauthenticated = &tr
 
 	// Replace the Vault Client on the server
 	tvc := &TestVaultClient{}
@@ -2905,6 +3092,7 @@ func TestClientEndpoint_DeriveVaultToken(t *testing.T) {
 		NodeID:      alloc.NodeID,
 		Accessor:    accessor,
 		CreationTTL: ttl,
+		Cluster:     config.VaultDefaultCluster,
 	}
 
 	if !reflect.DeepEqual(expected, va) {
@@ -2922,8 +3110,8 @@ func TestClientEndpoint_DeriveVaultToken_VaultError(t *testing.T) {
 
 	// Enable vault and allow authenticated
 	tr := true
-	s1.config.VaultConfig.Enabled = &tr
-	s1.config.VaultConfig.AllowUnauthenticated = &tr
+	s1.config.GetDefaultVault().Enabled = &tr
+	s1.config.GetDefaultVault().AllowUnauthenticated = &tr
 
 	// Replace the Vault Client on the server
 	tvc := &TestVaultClient{}
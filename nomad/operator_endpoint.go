@@ -3,10 +3,13 @@ package nomad
 import (
 	"fmt"
 	"net"
+	"time"
 
+	metrics "github.com/armon/go-metrics"
 	log "github.com/hashicorp/go-hclog"
 
 	"github.com/hashicorp/consul/agent/consul/autopilot"
+	"github.com/hashicorp/nomad/acl"
 	"github.com/hashicorp/nomad/nomad/structs"
 	"github.com/hashicorp/raft"
 	"github.com/hashicorp/serf/serf"
@@ -296,11 +299,11 @@ func (op *Operator) SchedulerSetConfiguration(args *structs.SchedulerSetConfigRe
 		return err
 	}
 
-	// This action requires operator write access.
+	// This action requires the scheduler-config-write operator capability.
 	rule, err := op.srv.ResolveToken(args.AuthToken)
 	if err != nil {
 		return err
-	} else if rule != nil && !rule.AllowOperatorWrite() {
+	} else if rule != nil && !rule.AllowOperatorOperation(acl.OperatorCapabilitySchedulerConfigWrite) {
 		return structs.ErrPermissionDenied
 	}
 
@@ -355,3 +358,49 @@ func (op *Operator) SchedulerGetConfiguration(args *structs.GenericRequest, repl
 
 	return nil
 }
+
+// SchedulerSimulate is used to simulate scheduling the jobs of the cluster
+// against hypothetical changes to its nodes. The simulation runs the real
+// schedulers against a copy of the state and has no side effects.
+func (op *Operator) SchedulerSimulate(args *structs.SchedulerSimulateRequest, reply *structs.SchedulerSimulateResponse) error {
+	if done, err := op.srv.forward("Operator.SchedulerSimulate", args, args, reply); done {
+		return err
+	}
+	defer metrics.MeasureSince([]string{"nomad", "operator", "scheduler_simulate"}, time.Now())
+
+	// This action requires operator read access.
+	rule, err := op.srv.ResolveToken(args.AuthToken)
+	if err != nil {
+		return err
+	} else if rule != nil && !rule.AllowOperatorRead() {
+		return structs.ErrPermissionDenied
+	}
+
+	// Acquire a snapshot of the state to modify
+	snap, err := op.srv.fsm.State().Snapshot()
+	if err != nil {
+		return err
+	}
+	index, err := snap.LatestIndex()
+	if err != nil {
+		return err
+	}
+
+	sim := newSchedulerSimulation(snap, index, op.logger)
+	if reply.UtilizationBefore, err = sim.utilization(); err != nil {
+		return err
+	}
+	if err := sim.apply(args); err != nil {
+		return err
+	}
+	if reply.Jobs, err = sim.run(); err != nil {
+		return err
+	}
+	if reply.UtilizationAfter, err = sim.utilization(); err != nil {
+		return err
+	}
+
+	reply.Index = index
+	op.srv.setQueryMeta(&reply.QueryMeta)
+	return nil
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/hashicorp/consul/lib/freeport"
 	msgpackrpc "github.com/hashicorp/net-rpc-msgpackrpc"
 	"github.com/hashicorp/nomad/acl"
+	"github.com/hashicorp/nomad/helper/uuid"
 	"github.com/hashicorp/nomad/nomad/mock"
 	"github.com/hashicorp/nomad/nomad/structs"
 	"github.com/hashicorp/nomad/testutil"
@@ -486,6 +487,15 @@ func TestOperator_SchedulerSetConfiguration_ACL(t *testing.T) {
 		require.Equal(err.Error(), structs.ErrPermissionDenied.Error())
 	}
 
+	// Try with a token granted the scheduler-config-write capability
+	{
+		validToken := mock.CreatePolicyAndToken(t, state, 1003, "test-valid",
+			mock.OperatorCapabilityPolicy(acl.PolicyRead, []string{acl.OperatorCapabilitySchedulerConfigWrite}))
+		arg.AuthToken = validToken.SecretID
+		err := msgpackrpc.CallWithCodec(codec, "Operator.SchedulerSetConfiguration", &arg, &reply)
+		require.Nil(err)
+	}
+
 	// Try with root token, should succeed
 	{
 		arg.AuthToken = root.SecretID
@@ -494,3 +504,135 @@ func TestOperator_SchedulerSetConfiguration_ACL(t *testing.T) {
 	}
 
 }
+
+func TestOperator_SchedulerSimulate(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	s1 := TestServer(t, nil)
+	defer s1.Shutdown()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	// Create two nodes each running an alloc of the job
+	state := s1.fsm.State()
+	node1, node2 := mock.Node(), mock.Node()
+	require.NoError(state.UpsertNode(1000, node1))
+	require.NoError(state.UpsertNode(1001, node2))
+
+	job := mock.Job()
+	job.TaskGroups[0].Count = 2
+	require.NoError(state.UpsertJob(1002, job))
+
+	var allocs []*structs.Allocation
+	for i, node := range []*structs.Node{node1, node2} {
+		alloc := mock.Alloc()
+		alloc.Job = job
+		alloc.JobID = job.ID
+		alloc.NodeID = node.ID
+		alloc.Name = structs.AllocName(job.ID, job.TaskGroups[0].Name, uint(i))
+		allocs = append(allocs, alloc)
+	}
+	require.NoError(state.UpsertAllocs(1003, allocs))
+
+	// Removing a node replaces its alloc on the other one
+	arg := structs.SchedulerSimulateRequest{
+		RemoveNodeIDs: []string{node1.ID},
+		QueryOptions: structs.QueryOptions{
+			Region: s1.config.Region,
+		},
+	}
+	var reply structs.SchedulerSimulateResponse
+	require.NoError(msgpackrpc.CallWithCodec(codec, "Operator.SchedulerSimulate", &arg, &reply))
+	require.NotZero(reply.Index)
+	require.Len(reply.Jobs, 1)
+	require.Equal(job.ID, reply.Jobs[0].JobID)
+	require.Equal(1, reply.Jobs[0].Placements)
+	require.Equal(1, reply.Jobs[0].Stops)
+	require.Empty(reply.Jobs[0].FailedTGAllocs)
+	require.Equal(2, reply.UtilizationBefore.Nodes)
+	require.Equal(1, reply.UtilizationAfter.Nodes)
+	require.Equal(reply.UtilizationBefore.AllocatedCPU, reply.UtilizationAfter.AllocatedCPU)
+
+	// Removing the datacenter leaves nowhere to place the allocs
+	arg.RemoveNodeIDs = nil
+	arg.RemoveDatacenters = []string{node1.Datacenter}
+	reply = structs.SchedulerSimulateResponse{}
+	require.NoError(msgpackrpc.CallWithCodec(codec, "Operator.SchedulerSimulate", &arg, &reply))
+	require.Len(reply.Jobs, 1)
+	require.Contains(reply.Jobs[0].FailedTGAllocs, job.TaskGroups[0].Name)
+	require.Zero(reply.UtilizationAfter.Nodes)
+
+	// Adding nodes increases the capacity of the cluster
+	arg.RemoveDatacenters = nil
+	arg.AddNodes = []*structs.SimulateNodeAdd{{CloneNodeID: node1.ID, Count: 2}}
+	reply = structs.SchedulerSimulateResponse{}
+	require.NoError(msgpackrpc.CallWithCodec(codec, "Operator.SchedulerSimulate", &arg, &reply))
+	require.Empty(reply.Jobs)
+	require.Equal(4, reply.UtilizationAfter.Nodes)
+	require.Equal(2*reply.UtilizationBefore.CPU, reply.UtilizationAfter.CPU)
+
+	// The simulations do not modify the cluster
+	out, err := state.NodeByID(nil, node1.ID)
+	require.NoError(err)
+	require.Equal(structs.NodeStatusReady, out.Status)
+	outAllocs, err := state.AllocsByJob(nil, job.Namespace, job.ID, true)
+	require.NoError(err)
+	require.Len(outAllocs, 2)
+
+	// Removing an unknown node is an error
+	arg.AddNodes = nil
+	arg.RemoveNodeIDs = []string{uuid.Generate()}
+	err = msgpackrpc.CallWithCodec(codec, "Operator.SchedulerSimulate", &arg, &reply)
+	require.Error(err)
+	require.Contains(err.Error(), "not found")
+}
+
+func TestOperator_SchedulerSimulate_ACL(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	s1, root := TestACLServer(t, nil)
+	defer s1.Shutdown()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+	state := s1.fsm.State()
+
+	// Create ACL tokens
+	invalidToken := mock.CreatePolicyAndToken(t, state, 1001, "test-invalid", mock.NodePolicy(acl.PolicyWrite))
+	validToken := mock.CreatePolicyAndToken(t, state, 1003, "test-valid", `operator { policy = "read" }`)
+
+	arg := structs.SchedulerSimulateRequest{
+		QueryOptions: structs.QueryOptions{
+			Region: s1.config.Region,
+		},
+	}
+	var reply structs.SchedulerSimulateResponse
+
+	// Try with no token and expect permission denied
+	{
+		err := msgpackrpc.CallWithCodec(codec, "Operator.SchedulerSimulate", &arg, &reply)
+		require.NotNil(err)
+		require.Equal(err.Error(), structs.ErrPermissionDenied.Error())
+	}
+
+	// Try with an invalid token and expect permission denied
+	{
+		arg.AuthToken = invalidToken.SecretID
+		err := msgpackrpc.CallWithCodec(codec, "Operator.SchedulerSimulate", &arg, &reply)
+		require.NotNil(err)
+		require.Equal(err.Error(), structs.ErrPermissionDenied.Error())
+	}
+
+	// Try with an operator read token, should succeed
+	{
+		arg.AuthToken = validToken.SecretID
+		err := msgpackrpc.CallWithCodec(codec, "Operator.SchedulerSimulate", &arg, &reply)
+		require.Nil(err)
+	}
+
+	// Try with root token, should succeed
+	{
+		arg.AuthToken = root.SecretID
+		err := msgpackrpc.CallWithCodec(codec, "Operator.SchedulerSimulate", &arg, &reply)
+		require.Nil(err)
+	}
+}
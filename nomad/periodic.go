@@ -26,10 +26,15 @@ type PeriodicDispatch struct {
 	tracked map[structs.NamespacedID]*structs.Job
 	heap    *periodicHeap
 
-	updateCh chan struct{}
-	stopFn   context.CancelFunc
-	logger   log.Logger
-	l        sync.RWMutex
+	// catchUps holds the last launch of the re-enabled jobs whose missed
+	// launches the daemon has yet to catch up on
+	catchUps map[structs.NamespacedID]time.Time
+
+	updateCh  chan struct{}
+	catchUpCh chan struct{}
+	stopFn    context.CancelFunc
+	logger    log.Logger
+	l         sync.RWMutex
 }
 
 // JobEvalDispatcher is an interface to submit jobs and have evaluations created
@@ -41,6 +46,10 @@ type JobEvalDispatcher interface {
 
 	// RunningChildren returns whether the passed job has any running children.
 	RunningChildren(job *structs.Job) (bool, error)
+
+	// RecordLaunchEvents records launch events, such as skipped or caught-up
+	// launches, in the launch history of the passed periodic job.
+	RecordLaunchEvents(job *structs.Job, events []*structs.PeriodicLaunchEvent) error
 }
 
 // DispatchJob creates an evaluation for the passed job and commits both the
@@ -143,6 +152,23 @@ func (s *Server) RunningChildren(job *structs.Job) (bool, error) {
 	return false, nil
 }
 
+// RecordLaunchEvents commits the launch events of the passed periodic job to
+// the raft log.
+func (s *Server) RecordLaunchEvents(job *structs.Job, events []*structs.PeriodicLaunchEvent) error {
+	req := structs.PeriodicLaunchEventsRequest{
+		JobID:  job.ID,
+		Events: events,
+		WriteRequest: structs.WriteRequest{
+			Namespace: job.Namespace,
+		},
+	}
+	fsmErr, _, err := s.raftApply(structs.PeriodicLaunchEventsRequestType, req)
+	if err, ok := fsmErr.(error); ok && err != nil {
+		return err
+	}
+	return err
+}
+
 // NewPeriodicDispatch returns a periodic dispatcher that is used to track and
 // launch periodic jobs.
 func NewPeriodicDispatch(logger log.Logger, dispatcher JobEvalDispatcher) *PeriodicDispatch {
@@ -150,7 +176,9 @@ func NewPeriodicDispatch(logger log.Logger, dispatcher JobEvalDispatcher) *Perio
 		dispatcher: dispatcher,
 		tracked:    make(map[structs.NamespacedID]*structs.Job),
 		heap:       NewPeriodicHeap(),
+		catchUps:   make(map[structs.NamespacedID]time.Time),
 		updateCh:   make(chan struct{}, 1),
+		catchUpCh:  make(chan struct{}, 1),
 		logger:     logger.Named("periodic"),
 	}
 }
@@ -173,7 +201,7 @@ func (p *PeriodicDispatch) SetEnabled(enabled bool) {
 		// If we are transitioning from disabled to enabled, run the daemon.
 		ctx, cancel := context.WithCancel(context.Background())
 		p.stopFn = cancel
-		go p.run(ctx, p.updateCh)
+		go p.run(ctx, p.updateCh, p.catchUpCh)
 	}
 }
 
@@ -247,6 +275,26 @@ func (p *PeriodicDispatch) Add(job *structs.Job) error {
 	return nil
 }
 
+// QueueCatchUp queues the catch-up of the launches the periodic job missed
+// since lastLaunch while it was disabled. The catch-up goes through Raft, so it
+// is run by the dispatcher's daemon on the leader rather than by the caller,
+// which may be the FSM.
+func (p *PeriodicDispatch) QueueCatchUp(job *structs.Job, lastLaunch time.Time) {
+	p.l.Lock()
+	defer p.l.Unlock()
+
+	// Do nothing if not enabled
+	if !p.enabled {
+		return
+	}
+
+	p.catchUps[*job.NamespacedID()] = lastLaunch
+	select {
+	case p.catchUpCh <- struct{}{}:
+	default:
+	}
+}
+
 // Remove stops tracking the passed job. If the job is not tracked, it is a
 // no-op.
 func (p *PeriodicDispatch) Remove(namespace, jobID string) error {
@@ -311,6 +359,132 @@ func (p *PeriodicDispatch) ForceRun(namespace, jobID string) (*structs.Evaluatio
 	return p.createEval(job, time.Now().In(job.Periodic.GetLocation()))
 }
 
+// CatchUp handles the launches of the periodic job that were missed since
+// lastLaunch, either because there was no leader or because the job was
+// disabled. Depending on the job's catch-up policy the missed launches are
+// launched or skipped, and the outcome is recorded in the job's launch
+// history.
+func (p *PeriodicDispatch) CatchUp(job *structs.Job, lastLaunch time.Time) error {
+	p.l.RLock()
+	if !p.enabled {
+		p.l.RUnlock()
+		return nil
+	}
+	_, tracked := p.tracked[*job.NamespacedID()]
+	p.l.RUnlock()
+	if !tracked {
+		return nil
+	}
+
+	now := time.Now().In(job.Periodic.GetLocation())
+	missed, err := missedLaunches(job.Periodic, lastLaunch, now)
+	if err != nil {
+		return err
+	}
+	if len(missed) == 0 {
+		return nil
+	}
+
+	// Determine which of the missed launches should be run. run_all is only
+	// bounded by the lookback, so jobs registered without one before it was
+	// required are only caught up on once.
+	var run []time.Time
+	cfg := job.Periodic
+	catchup := cfg.GetCatchup()
+	if catchup == structs.PeriodicCatchupRunAll && cfg.CatchupLookback == 0 {
+		catchup = structs.PeriodicCatchupRunOnce
+	}
+	cutoff := time.Time{}
+	if cfg.CatchupLookback > 0 {
+		cutoff = now.Add(-cfg.CatchupLookback)
+	}
+	switch catchup {
+	case structs.PeriodicCatchupRunOnce:
+		if last := missed[len(missed)-1]; !last.Before(cutoff) {
+			run = missed[len(missed)-1:]
+		}
+	case structs.PeriodicCatchupRunAll:
+		for i, launch := range missed {
+			if !launch.Before(cutoff) {
+				run = missed[i:]
+				break
+			}
+		}
+	}
+
+	// Jobs that prohibit overlap only catch up on a single launch and only if
+	// nothing is running.
+	if len(run) != 0 && cfg.ProhibitOverlap {
+		running, err := p.dispatcher.RunningChildren(job)
+		if err != nil {
+			return fmt.Errorf("failed to determine if periodic job has running children: %v", err)
+		}
+		if running {
+			p.logger.Debug("skipping catch-up of periodic job because job prohibits overlap", "job", job.NamespacedID())
+			run = nil
+		} else {
+			run = run[len(run)-1:]
+		}
+	}
+
+	events := make([]*structs.PeriodicLaunchEvent, 0, len(missed))
+	for _, launch := range missed[:len(missed)-len(run)] {
+		events = append(events, &structs.PeriodicLaunchEvent{
+			Launch:      launch,
+			Status:      structs.PeriodicLaunchStatusSkipped,
+			Description: fmt.Sprintf("Launch missed and skipped by catch-up policy %q", catchup),
+		})
+	}
+	for _, launch := range run {
+		events = append(events, &structs.PeriodicLaunchEvent{
+			Launch:      launch,
+			Status:      structs.PeriodicLaunchStatusCaughtUp,
+			Description: fmt.Sprintf("Launch missed and caught up by catch-up policy %q", catchup),
+		})
+	}
+	if err := p.dispatcher.RecordLaunchEvents(job, events); err != nil {
+		return fmt.Errorf("failed to record missed launches: %v", err)
+	}
+
+	for _, launch := range run {
+		if _, err := p.createEval(job, launch); err != nil {
+			return err
+		}
+	}
+
+	p.logger.Debug("caught up on missed periodic launches", "job", job.NamespacedID(),
+		"missed", len(missed), "launched", len(run))
+	return nil
+}
+
+// maxMissedLaunches bounds the number of missed launches that are considered
+// when catching up a periodic job.
+const maxMissedLaunches = 1000
+
+// missedLaunches returns the launch times of the periodic config after
+// lastLaunch and up to now, oldest first. At most maxMissedLaunches of the
+// most recent launches are returned.
+func missedLaunches(cfg *structs.PeriodicConfig, lastLaunch, now time.Time) ([]time.Time, error) {
+	var missed []time.Time
+	next := lastLaunch.In(now.Location())
+	for {
+		var err error
+		next, err = cfg.Next(next)
+		if err != nil {
+			return nil, fmt.Errorf("failed to determine next periodic launch: %v", err)
+		}
+		if next.IsZero() || next.After(now) {
+			break
+		}
+
+		missed = append(missed, next)
+		if len(missed) > maxMissedLaunches {
+			missed = missed[1:]
+		}
+	}
+	return missed, nil
+}
+
 // shouldRun returns whether the long lived run function should run.
 func (p *PeriodicDispatch) shouldRun() bool {
 	p.l.RLock()
@@ -320,7 +494,7 @@ func (p *PeriodicDispatch) shouldRun() bool {
 
 // run is a long-lived function that waits till a job's periodic spec is met and
 // then creates an evaluation to run the job.
-func (p *PeriodicDispatch) run(ctx context.Context, updateCh <-chan struct{}) {
+func (p *PeriodicDispatch) run(ctx context.Context, updateCh, catchUpCh <-chan struct{}) {
 	var launchCh <-chan time.Time
 	for p.shouldRun() {
 		job, launch := p.nextLaunch()
@@ -337,12 +511,35 @@ func (p *PeriodicDispatch) run(ctx context.Context, updateCh <-chan struct{}) {
 			return
 		case <-updateCh:
 			continue
+		case <-catchUpCh:
+			p.runCatchUps()
 		case <-launchCh:
 			p.dispatch(job, launch)
 		}
 	}
 }
 
+// runCatchUps catches up on the missed launches of the queued jobs
+func (p *PeriodicDispatch) runCatchUps() {
+	p.l.Lock()
+	catchUps := p.catchUps
+	p.catchUps = make(map[structs.NamespacedID]time.Time)
+	p.l.Unlock()
+
+	for tuple, lastLaunch := range catchUps {
+		p.l.RLock()
+		job, tracked := p.tracked[tuple]
+		p.l.RUnlock()
+		if !tracked {
+			continue
+		}
+
+		if err := p.CatchUp(job, lastLaunch); err != nil {
+			p.logger.Error("periodic catch-up failed", "job", job.NamespacedID(), "error", err)
+		}
+	}
+}
+
 // dispatch creates an evaluation for the job and updates its next launchtime
 // based on the passed launch time.
 func (p *PeriodicDispatch) dispatch(job *structs.Job, launchTime time.Time) {
@@ -466,7 +663,9 @@ func (p *PeriodicDispatch) LaunchTime(jobID string) (time.Time, error) {
 // flush clears the state of the PeriodicDispatcher
 func (p *PeriodicDispatch) flush() {
 	p.updateCh = make(chan struct{}, 1)
+	p.catchUpCh = make(chan struct{}, 1)
 	p.tracked = make(map[structs.NamespacedID]*structs.Job)
+	p.catchUps = make(map[structs.NamespacedID]time.Time)
 	p.heap = NewPeriodicHeap()
 	p.stopFn = nil
 }
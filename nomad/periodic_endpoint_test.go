@@ -2,6 +2,7 @@ package nomad
 
 import (
 	"testing"
+	"time"
 
 	memdb "github.com/hashicorp/go-memdb"
 	msgpackrpc "github.com/hashicorp/net-rpc-msgpackrpc"
@@ -10,6 +11,7 @@ import (
 	"github.com/hashicorp/nomad/nomad/structs"
 	"github.com/hashicorp/nomad/testutil"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestPeriodicEndpoint_Force(t *testing.T) {
@@ -173,3 +175,47 @@ func TestPeriodicEndpoint_Force_NonPeriodic(t *testing.T) {
 		t.Fatalf("Force on non-periodic job should err")
 	}
 }
+
+func TestPeriodicEndpoint_History(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	s1 := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+	})
+	state := s1.fsm.State()
+	defer s1.Shutdown()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	// Create and insert a periodic job with a launch history.
+	job := mock.PeriodicJob()
+	require.NoError(state.UpsertJob(100, job))
+	now := time.Now().Round(time.Second)
+	events := []*structs.PeriodicLaunchEvent{
+		{Launch: now.Add(-time.Hour), Status: structs.PeriodicLaunchStatusSkipped},
+		{Launch: now, Status: structs.PeriodicLaunchStatusCaughtUp},
+	}
+	require.NoError(state.UpsertPeriodicLaunchEvents(101, job.Namespace, job.ID, events))
+
+	req := &structs.JobSpecificRequest{
+		JobID: job.ID,
+		QueryOptions: structs.QueryOptions{
+			Region:    "global",
+			Namespace: job.Namespace,
+		},
+	}
+	var resp structs.PeriodicHistoryResponse
+	require.NoError(msgpackrpc.CallWithCodec(codec, "Periodic.History", req, &resp))
+	require.EqualValues(101, resp.Index)
+	require.NotNil(resp.Launch)
+	require.Len(resp.Launch.History, 2)
+	require.Equal(structs.PeriodicLaunchStatusSkipped, resp.Launch.History[0].Status)
+	require.Equal(structs.PeriodicLaunchStatusCaughtUp, resp.Launch.History[1].Status)
+
+	// Lookup a job without launches
+	req.JobID = "foo"
+	var resp2 structs.PeriodicHistoryResponse
+	require.NoError(msgpackrpc.CallWithCodec(codec, "Periodic.History", req, &resp2))
+	require.Nil(resp2.Launch)
+	require.EqualValues(101, resp2.Index)
+}
@@ -16,15 +16,20 @@ import (
 	"github.com/hashicorp/nomad/nomad/structs"
 	"github.com/hashicorp/nomad/testutil"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 type MockJobEvalDispatcher struct {
-	Jobs map[structs.NamespacedID]*structs.Job
-	lock sync.Mutex
+	Jobs   map[structs.NamespacedID]*structs.Job
+	Events map[structs.NamespacedID][]*structs.PeriodicLaunchEvent
+	lock   sync.Mutex
 }
 
 func NewMockJobEvalDispatcher() *MockJobEvalDispatcher {
-	return &MockJobEvalDispatcher{Jobs: make(map[structs.NamespacedID]*structs.Job)}
+	return &MockJobEvalDispatcher{
+		Jobs:   make(map[structs.NamespacedID]*structs.Job),
+		Events: make(map[structs.NamespacedID][]*structs.PeriodicLaunchEvent),
+	}
 }
 
 func (m *MockJobEvalDispatcher) DispatchJob(job *structs.Job) (*structs.Evaluation, error) {
@@ -49,6 +54,17 @@ func (m *MockJobEvalDispatcher) RunningChildren(parent *structs.Job) (bool, erro
 	return false, nil
 }
 
+func (m *MockJobEvalDispatcher) RecordLaunchEvents(job *structs.Job, events []*structs.PeriodicLaunchEvent) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	tuple := structs.NamespacedID{
+		ID:        job.ID,
+		Namespace: job.Namespace,
+	}
+	m.Events[tuple] = append(m.Events[tuple], events...)
+	return nil
+}
+
 // LaunchTimes returns the launch times of child jobs in sorted order.
 func (m *MockJobEvalDispatcher) LaunchTimes(p *PeriodicDispatch, namespace, parentID string) ([]time.Time, error) {
 	m.lock.Lock()
@@ -384,6 +400,129 @@ func TestPeriodicDispatch_ForceRun_Tracked(t *testing.T) {
 	}
 }
 
+func TestPeriodicDispatch_CatchUp(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now().Round(1 * time.Second)
+	missed := []time.Time{
+		now.Add(-3 * time.Hour),
+		now.Add(-2 * time.Hour),
+		now.Add(-1 * time.Hour),
+	}
+	last := now.Add(-4 * time.Hour)
+
+	cases := []struct {
+		name     string
+		catchup  string
+		lookback time.Duration
+		overlap  bool
+		launched []time.Time
+	}{
+		{
+			name:    "skip",
+			catchup: structs.PeriodicCatchupSkip,
+		},
+		{
+			name:     "default",
+			launched: missed[2:],
+		},
+		{
+			name:     "run once",
+			catchup:  structs.PeriodicCatchupRunOnce,
+			launched: missed[2:],
+		},
+		{
+			name:     "run once outside lookback",
+			catchup:  structs.PeriodicCatchupRunOnce,
+			lookback: 30 * time.Minute,
+		},
+		{
+			name:     "run all",
+			catchup:  structs.PeriodicCatchupRunAll,
+			lookback: 150 * time.Minute,
+			launched: missed[1:],
+		},
+		{
+			name:     "run all without lookback",
+			catchup:  structs.PeriodicCatchupRunAll,
+			launched: missed[2:],
+		},
+		{
+			name:     "run all prohibit overlap",
+			catchup:  structs.PeriodicCatchupRunAll,
+			lookback: 150 * time.Minute,
+			overlap:  true,
+			launched: missed[2:],
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			require := require.New(t)
+			p, m := testPeriodicDispatcher(t)
+
+			job := testPeriodicJob(append(missed, now.Add(time.Hour))...)
+			job.Periodic.Catchup = c.catchup
+			job.Periodic.CatchupLookback = c.lookback
+			job.Periodic.ProhibitOverlap = c.overlap
+			require.NoError(p.Add(job))
+			require.NoError(p.CatchUp(job, last))
+
+			// Check the launched jobs
+			launches, err := m.LaunchTimes(p, job.Namespace, job.ID)
+			require.NoError(err)
+			require.Len(launches, len(c.launched))
+			for i, launch := range c.launched {
+				require.True(launch.Equal(launches[i]), "launch %d: %v != %v", i, launch, launches[i])
+			}
+
+			// Check the recorded events
+			events := m.Events[*job.NamespacedID()]
+			require.Len(events, len(missed))
+			for i, event := range events {
+				require.True(missed[i].Equal(event.Launch))
+				if i < len(missed)-len(c.launched) {
+					require.Equal(structs.PeriodicLaunchStatusSkipped, event.Status)
+				} else {
+					require.Equal(structs.PeriodicLaunchStatusCaughtUp, event.Status)
+				}
+			}
+		})
+	}
+}
+
+func TestPeriodicDispatch_CatchUp_NoMissed(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	p, m := testPeriodicDispatcher(t)
+
+	now := time.Now().Round(1 * time.Second)
+	job := testPeriodicJob(now.Add(-time.Hour), now.Add(time.Hour))
+	require.NoError(p.Add(job))
+	require.NoError(p.CatchUp(job, now.Add(-time.Minute)))
+
+	require.Empty(m.Jobs)
+	require.Empty(m.Events)
+}
+
+func TestPeriodicDispatch_QueueCatchUp_Disabled(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	p, m := testPeriodicDispatcher(t)
+
+	now := time.Now().Round(1 * time.Second)
+	job := testPeriodicJob(now.Add(-time.Hour), now.Add(time.Hour))
+	require.NoError(p.Add(job))
+
+	// Only the leader's dispatcher catches up
+	p.SetEnabled(false)
+	p.QueueCatchUp(job, now.Add(-2*time.Hour))
+	time.Sleep(100 * time.Millisecond)
+
+	require.Empty(m.Jobs)
+	require.Empty(m.Events)
+}
+
 func TestPeriodicDispatch_Run_DisallowOverlaps(t *testing.T) {
 	t.Parallel()
 	p, m := testPeriodicDispatcher(t)
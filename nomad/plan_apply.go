@@ -237,6 +237,15 @@ func (p *planner) applyPlan(plan *structs.Plan, result *structs.PlanResult, snap
 		}
 	}
 
+	// Sign the workload identities of the placed allocations. The plan fails
+	// rather than placing allocations without identities, so that its
+	// evaluation is retried.
+	for _, allocList := range result.NodeAllocation {
+		if err := p.signAllocIdentities(plan.Job, allocList); err != nil {
+			return nil, fmt.Errorf("failed to sign workload identities: %v", err)
+		}
+	}
+
 	var evals []*structs.Evaluation
 	for preemptedJobID := range preemptedJobIDs {
 		job, _ := p.State().JobByID(nil, preemptedJobID.Namespace, preemptedJobID.ID)
@@ -591,6 +600,13 @@ func evaluateNodePlan(snap *state.StateSnapshot, plan *structs.Plan, nodeID stri
 	// the Raft commit happens.
 	if node == nil {
 		return false, "node does not exist", nil
+	} else if node.Status == structs.NodeStatusDisconnected {
+		// The only valid updates for a disconnected node are marking its
+		// allocations as unknown
+		if isValidForDisconnectedNode(plan, nodeID) {
+			return true, "", nil
+		}
+		return false, "node is disconnected and contains invalid updates", nil
 	} else if node.Status != structs.NodeStatusReady {
 		return false, "node is not ready for placements", nil
 	} else if node.SchedulingEligibility == structs.NodeSchedulingIneligible {
@@ -628,3 +644,14 @@ func evaluateNodePlan(snap *state.StateSnapshot, plan *structs.Plan, nodeID stri
 	fit, reason, _, err := structs.AllocsFit(node, proposed, nil, true)
 	return fit, reason, err
 }
+
+// isValidForDisconnectedNode ensures that the plan only marks the allocations
+// of a disconnected node as unknown.
+func isValidForDisconnectedNode(plan *structs.Plan, nodeID string) bool {
+	for _, alloc := range plan.NodeAllocation[nodeID] {
+		if alloc.ClientStatus != structs.AllocClientStatusUnknown {
+			return false
+		}
+	}
+	return true
+}
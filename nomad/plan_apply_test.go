@@ -63,6 +63,99 @@ func testRegisterJob(t *testing.T, s *Server, j *structs.Job) {
 	}
 }
 
+func TestPlanApply_applyPlan_SignIdentities(t *testing.T) {
+	t.Parallel()
+	s1 := TestServer(t, func(c *Config) {
+		c.Build = "0.9.2+unittest"
+	})
+	defer s1.Shutdown()
+	testutil.WaitForLeader(t, s1.RPC)
+	key := waitForActiveRootKey(t, s1)
+
+	// Register node
+	node := mock.Node()
+	testRegisterNode(t, s1, node)
+
+	// Create an eval and an alloc placed without its job
+	alloc := mock.Alloc()
+	job := alloc.Job
+	alloc.Job = nil
+	s1.State().UpsertJobSummary(1000, mock.JobSummary(alloc.JobID))
+	eval := mock.Eval()
+	eval.JobID = alloc.JobID
+	require.NoError(t, s1.State().UpsertEvals(1001, []*structs.Evaluation{eval}))
+
+	planRes := &structs.PlanResult{
+		NodeAllocation: map[string][]*structs.Allocation{
+			node.ID: {alloc},
+		},
+	}
+	plan := &structs.Plan{
+		Job:    job,
+		EvalID: eval.ID,
+	}
+
+	// Apply the plan
+	snap, err := s1.State().Snapshot()
+	require.NoError(t, err)
+	future, err := s1.applyPlan(plan, planRes, snap)
+	require.NoError(t, err)
+	_, err = planWaitFuture(future)
+	require.NoError(t, err)
+
+	// The alloc has an identity for each of its tasks
+	out, err := s1.fsm.State().AllocByID(nil, alloc.ID)
+	require.NoError(t, err)
+	require.Equal(t, key.KeyID, out.SigningKeyID)
+	require.Len(t, out.SignedIdentities, len(job.TaskGroups[0].Tasks))
+	require.NotEmpty(t, out.SignedIdentities[job.TaskGroups[0].Tasks[0].Name])
+}
+
+func TestPlanApply_applyPlan_SignIdentitiesFailure(t *testing.T) {
+	t.Parallel()
+	s1 := TestServer(t, func(c *Config) {
+		c.Build = "0.9.2+unittest"
+	})
+	defer s1.Shutdown()
+	testutil.WaitForLeader(t, s1.RPC)
+
+	// Lose the key material of the active root key
+	key := waitForActiveRootKey(t, s1)
+	require.NoError(t, s1.keystore.Delete(key.KeyID))
+
+	// Register node
+	node := mock.Node()
+	testRegisterNode(t, s1, node)
+
+	alloc := mock.Alloc()
+	s1.State().UpsertJobSummary(1000, mock.JobSummary(alloc.JobID))
+	eval := mock.Eval()
+	eval.JobID = alloc.JobID
+	require.NoError(t, s1.State().UpsertEvals(1001, []*structs.Evaluation{eval}))
+
+	planRes := &structs.PlanResult{
+		NodeAllocation: map[string][]*structs.Allocation{
+			node.ID: {alloc},
+		},
+	}
+	plan := &structs.Plan{
+		Job:    alloc.Job,
+		EvalID: eval.ID,
+	}
+
+	// Applying the plan fails rather than placing the alloc without
+	// identities
+	snap, err := s1.State().Snapshot()
+	require.NoError(t, err)
+	_, err = s1.applyPlan(plan, planRes, snap)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "failed to sign workload identities")
+
+	out, err := s1.fsm.State().AllocByID(nil, alloc.ID)
+	require.NoError(t, err)
+	require.Nil(t, out)
+}
+
 // COMPAT 0.11: Tests the older unoptimized code path for applyPlan
 func TestPlanApply_applyPlan(t *testing.T) {
 	t.Parallel()
@@ -704,6 +797,42 @@ func TestPlanApply_EvalNodePlan_NodeNotReady(t *testing.T) {
 	}
 }
 
+func TestPlanApply_EvalNodePlan_NodeDisconnected(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	state := testStateStore(t)
+	node := mock.Node()
+	node.Status = structs.NodeStatusDisconnected
+	state.UpsertNode(1000, node)
+	snap, _ := state.Snapshot()
+
+	// Marking an allocation unknown is allowed
+	alloc := mock.Alloc()
+	alloc.NodeID = node.ID
+	alloc.ClientStatus = structs.AllocClientStatusUnknown
+	plan := &structs.Plan{
+		Job: alloc.Job,
+		NodeAllocation: map[string][]*structs.Allocation{
+			node.ID: {alloc},
+		},
+	}
+
+	fit, reason, err := evaluateNodePlan(snap, plan, node.ID)
+	require.NoError(err)
+	require.True(fit)
+	require.Empty(reason)
+
+	// Placing an allocation is not
+	placed := mock.Alloc()
+	placed.NodeID = node.ID
+	plan.NodeAllocation[node.ID] = append(plan.NodeAllocation[node.ID], placed)
+
+	fit, reason, err = evaluateNodePlan(snap, plan, node.ID)
+	require.NoError(err)
+	require.False(fit)
+	require.Equal("node is disconnected and contains invalid updates", reason)
+}
+
 func TestPlanApply_EvalNodePlan_NodeDrain(t *testing.T) {
 	t.Parallel()
 	state := testStateStore(t)
@@ -0,0 +1,345 @@
+package nomad
+
+import (
+	"fmt"
+	"sort"
+
+	log "github.com/hashicorp/go-hclog"
+	memdb "github.com/hashicorp/go-memdb"
+	"github.com/hashicorp/nomad/helper"
+	"github.com/hashicorp/nomad/helper/uuid"
+	"github.com/hashicorp/nomad/nomad/state"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/scheduler"
+)
+
+// schedulerSimulation applies hypothetical node changes to a state snapshot
+// and runs the schedulers of the jobs they affect against it. Plans are
+// applied to the snapshot by an in-memory planner so that each job is
+// scheduled against the placements of the previous ones.
+type schedulerSimulation struct {
+	snap   *state.StateSnapshot
+	index  uint64
+	logger log.Logger
+
+	// affected is the set of jobs affected by the node changes
+	affected map[structs.NamespacedID]struct{}
+}
+
+// newSchedulerSimulation returns a simulation modifying the given snapshot.
+// Changes are applied at an index above the given one.
+func newSchedulerSimulation(snap *state.StateSnapshot, index uint64, logger log.Logger) *schedulerSimulation {
+	return &schedulerSimulation{
+		snap:     snap,
+		index:    index + 1,
+		logger:   logger.Named("simulate"),
+		affected: make(map[structs.NamespacedID]struct{}),
+	}
+}
+
+// apply applies the node changes of the request to the snapshot and records
+// the jobs they affect.
+func (s *schedulerSimulation) apply(args *structs.SchedulerSimulateRequest) error {
+	ws := memdb.NewWatchSet()
+
+	// Determine the nodes to remove
+	remove := make(map[string]struct{}, len(args.RemoveNodeIDs))
+	for _, id := range args.RemoveNodeIDs {
+		node, err := s.snap.NodeByID(ws, id)
+		if err != nil {
+			return err
+		}
+		if node == nil {
+			return fmt.Errorf("node %q not found", id)
+		}
+		remove[id] = struct{}{}
+	}
+	if len(args.RemoveDatacenters) != 0 {
+		dcs := make(map[string]struct{}, len(args.RemoveDatacenters))
+		for _, dc := range args.RemoveDatacenters {
+			dcs[dc] = struct{}{}
+		}
+
+		iter, err := s.snap.Nodes(ws)
+		if err != nil {
+			return err
+		}
+		for raw := iter.Next(); raw != nil; raw = iter.Next() {
+			node := raw.(*structs.Node)
+			if _, ok := dcs[node.Datacenter]; ok {
+				remove[node.ID] = struct{}{}
+			}
+		}
+	}
+
+	// Removed nodes are marked down so that their allocations are lost
+	for id := range remove {
+		if err := s.snap.UpdateNodeStatus(s.index, id, structs.NodeStatusDown, nil); err != nil {
+			return err
+		}
+		if err := s.markNodeJobs(id); err != nil {
+			return err
+		}
+	}
+
+	// Drained nodes have all their allocations migrated at once
+	for _, id := range args.DrainNodeIDs {
+		if _, ok := remove[id]; ok {
+			continue
+		}
+		if err := s.drainNode(id); err != nil {
+			return err
+		}
+	}
+
+	// Added nodes may allow placing any job that is blocked as well as
+	// system jobs
+	if len(args.AddNodes) == 0 {
+		return nil
+	}
+	for _, add := range args.AddNodes {
+		if err := s.addNodes(add); err != nil {
+			return err
+		}
+	}
+	return s.markPlaceableJobs()
+}
+
+// drainNode marks the node as draining and all of its allocations for
+// migration.
+func (s *schedulerSimulation) drainNode(id string) error {
+	ws := memdb.NewWatchSet()
+	node, err := s.snap.NodeByID(ws, id)
+	if err != nil {
+		return err
+	}
+	if node == nil {
+		return fmt.Errorf("node %q not found", id)
+	}
+
+	if err := s.snap.UpdateNodeDrain(s.index, id, &structs.DrainStrategy{}, false, nil); err != nil {
+		return err
+	}
+
+	allocs, err := s.snap.AllocsByNodeTerminal(ws, id, false)
+	if err != nil {
+		return err
+	}
+	transitions := make(map[string]*structs.DesiredTransition, len(allocs))
+	for _, alloc := range allocs {
+		transitions[alloc.ID] = &structs.DesiredTransition{
+			Migrate: helper.BoolToPtr(true),
+		}
+	}
+	if err := s.snap.UpdateAllocsDesiredTransitions(s.index, transitions, nil); err != nil {
+		return err
+	}
+
+	return s.markNodeJobs(id)
+}
+
+// addNodes adds copies of an existing node to the snapshot.
+func (s *schedulerSimulation) addNodes(add *structs.SimulateNodeAdd) error {
+	if add.Count <= 0 {
+		return fmt.Errorf("count of nodes to add must be positive")
+	}
+
+	ws := memdb.NewWatchSet()
+	clone, err := s.snap.NodeByID(ws, add.CloneNodeID)
+	if err != nil {
+		return err
+	}
+	if clone == nil {
+		return fmt.Errorf("node %q not found", add.CloneNodeID)
+	}
+
+	for i := 0; i < add.Count; i++ {
+		node := clone.Copy()
+		node.ID = uuid.Generate()
+		node.SecretID = uuid.Generate()
+		node.Name = fmt.Sprintf("%s-simulated-%d", clone.Name, i)
+		node.Status = structs.NodeStatusReady
+		node.SchedulingEligibility = structs.NodeSchedulingEligible
+		node.Drain = false
+		node.DrainStrategy = nil
+		node.Events = nil
+		if add.Datacenter != "" {
+			node.Datacenter = add.Datacenter
+		}
+		if add.NodeClass != "" {
+			node.NodeClass = add.NodeClass
+		}
+		if err := node.ComputeClass(); err != nil {
+			return fmt.Errorf("failed to compute node class: %v", err)
+		}
+
+		if err := s.snap.UpsertNode(s.index, node); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// markNodeJobs records the jobs with non-terminal allocations on the node as
+// affected.
+func (s *schedulerSimulation) markNodeJobs(nodeID string) error {
+	allocs, err := s.snap.AllocsByNodeTerminal(nil, nodeID, false)
+	if err != nil {
+		return err
+	}
+	for _, alloc := range allocs {
+		s.affected[structs.NamespacedID{Namespace: alloc.Namespace, ID: alloc.JobID}] = struct{}{}
+	}
+	return nil
+}
+
+// markPlaceableJobs records the jobs that may be placed on new nodes as
+// affected: system jobs and jobs with blocked evaluations.
+func (s *schedulerSimulation) markPlaceableJobs() error {
+	ws := memdb.NewWatchSet()
+	iter, err := s.snap.Jobs(ws)
+	if err != nil {
+		return err
+	}
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		job := raw.(*structs.Job)
+		if job.IsNodeBound() {
+			s.affected[structs.NamespacedID{Namespace: job.Namespace, ID: job.ID}] = struct{}{}
+		}
+	}
+
+	iter, err = s.snap.Evals(ws)
+	if err != nil {
+		return err
+	}
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		eval := raw.(*structs.Evaluation)
+		if eval.Status == structs.EvalStatusBlocked {
+			s.affected[structs.NamespacedID{Namespace: eval.Namespace, ID: eval.JobID}] = struct{}{}
+		}
+	}
+	return nil
+}
+
+// run schedules every affected job in a deterministic order and returns the
+// result for each of them.
+func (s *schedulerSimulation) run() ([]*structs.SimulateJobResult, error) {
+	ids := make([]structs.NamespacedID, 0, len(s.affected))
+	for id := range s.affected {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		if ids[i].Namespace != ids[j].Namespace {
+			return ids[i].Namespace < ids[j].Namespace
+		}
+		return ids[i].ID < ids[j].ID
+	})
+
+	// Create an in-memory Planner that returns no errors and applies the
+	// submitted plans to the snapshot above the index of the node changes
+	planner := scheduler.NewHarnessAtIndex(nil, &s.snap.StateStore, s.index)
+
+	results := make([]*structs.SimulateJobResult, 0, len(ids))
+	for _, id := range ids {
+		job, err := s.snap.JobByID(nil, id.Namespace, id.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		// Periodic and parameterized jobs are only templates for their
+		// children
+		if job == nil || job.Stopped() || job.IsPeriodic() || job.IsParameterized() {
+			continue
+		}
+
+		result, err := s.schedule(planner, job)
+		if err != nil {
+			return nil, fmt.Errorf("failed to schedule job %q in namespace %q: %v", job.ID, job.Namespace, err)
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// schedule runs the scheduler of the job and summarizes its plans.
+func (s *schedulerSimulation) schedule(planner *scheduler.Harness, job *structs.Job) (*structs.SimulateJobResult, error) {
+	eval := &structs.Evaluation{
+		ID:             uuid.Generate(),
+		Namespace:      job.Namespace,
+		Priority:       job.Priority,
+		Type:           job.Type,
+		TriggeredBy:    structs.EvalTriggerNodeUpdate,
+		JobID:          job.ID,
+		JobModifyIndex: job.JobModifyIndex,
+		Status:         structs.EvalStatusPending,
+	}
+	if err := s.snap.UpsertEvals(s.index, []*structs.Evaluation{eval}); err != nil {
+		return nil, err
+	}
+
+	plans, evals := len(planner.Plans), len(planner.Evals)
+	sched, err := scheduler.NewScheduler(eval.Type, s.logger, s.snap, planner)
+	if err != nil {
+		return nil, err
+	}
+	if err := sched.Process(eval); err != nil {
+		return nil, err
+	}
+
+	result := &structs.SimulateJobResult{
+		Namespace: job.Namespace,
+		JobID:     job.ID,
+	}
+	for _, plan := range planner.Plans[plans:] {
+		for _, allocs := range plan.NodeAllocation {
+			result.Placements += len(allocs)
+		}
+		for _, allocs := range plan.NodeUpdate {
+			result.Stops += len(allocs)
+		}
+	}
+	for _, updated := range planner.Evals[evals:] {
+		if updated.ID == eval.ID {
+			result.FailedTGAllocs = updated.FailedTGAllocs
+			result.QueuedAllocations = updated.QueuedAllocations
+		}
+	}
+	return result, nil
+}
+
+// utilization returns the resources available on and used by allocations
+// of the ready and eligible nodes of the snapshot.
+func (s *schedulerSimulation) utilization() (*structs.ClusterUtilization, error) {
+	ws := memdb.NewWatchSet()
+	iter, err := s.snap.Nodes(ws)
+	if err != nil {
+		return nil, err
+	}
+
+	u := new(structs.ClusterUtilization)
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		node := raw.(*structs.Node)
+		if !node.Ready() {
+			continue
+		}
+
+		available := node.ComparableResources()
+		available.Subtract(node.ComparableReservedResources())
+		u.Nodes++
+		u.CPU += available.Flattened.Cpu.CpuShares
+		u.MemoryMB += available.Flattened.Memory.MemoryMB
+		u.DiskMB += available.Shared.DiskMB
+
+		allocs, err := s.snap.AllocsByNodeTerminal(ws, node.ID, false)
+		if err != nil {
+			return nil, err
+		}
+		for _, alloc := range allocs {
+			used := alloc.ComparableResources()
+			u.AllocatedCPU += used.Flattened.Cpu.CpuShares
+			u.AllocatedMemoryMB += used.Flattened.Memory.MemoryMB
+			u.AllocatedDiskMB += used.Shared.DiskMB
+		}
+	}
+	return u, nil
+}
@@ -0,0 +1,51 @@
+package nomad
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/state"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/stretchr/testify/require"
+)
+
+// TestSchedulerSimulation_PlanIndexes asserts the simulated plans are applied
+// above the indexes already present in the snapshot
+func TestSchedulerSimulation_PlanIndexes(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	s := state.TestStateStore(t)
+	node1, node2 := mock.Node(), mock.Node()
+	require.NoError(s.UpsertNode(1000, node1))
+	require.NoError(s.UpsertNode(1001, node2))
+
+	job := mock.Job()
+	job.TaskGroups[0].Count = 1
+	require.NoError(s.UpsertJob(1002, job))
+
+	alloc := mock.Alloc()
+	alloc.Job = job
+	alloc.JobID = job.ID
+	alloc.NodeID = node1.ID
+	require.NoError(s.UpsertAllocs(1003, []*structs.Allocation{alloc}))
+
+	snap, err := s.Snapshot()
+	require.NoError(err)
+	index, err := snap.LatestIndex()
+	require.NoError(err)
+
+	sim := newSchedulerSimulation(snap, index, testlog.HCLogger(t))
+	require.NoError(sim.apply(&structs.SchedulerSimulateRequest{RemoveNodeIDs: []string{node1.ID}}))
+	results, err := sim.run()
+	require.NoError(err)
+	require.Len(results, 1)
+	require.Equal(1, results[0].Placements)
+
+	allocs, err := snap.AllocsByNode(nil, node2.ID)
+	require.NoError(err)
+	require.Len(allocs, 1)
+	require.True(allocs[0].CreateIndex > sim.index,
+		"alloc created at index %d, not above %d", allocs[0].CreateIndex, sim.index)
+}
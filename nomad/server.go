@@ -26,8 +26,10 @@ import (
 	"github.com/hashicorp/nomad/helper/pool"
 	"github.com/hashicorp/nomad/helper/stats"
 	"github.com/hashicorp/nomad/helper/tlsutil"
+	"github.com/hashicorp/nomad/lib/auth/oidc"
 	"github.com/hashicorp/nomad/nomad/deploymentwatcher"
 	"github.com/hashicorp/nomad/nomad/drainer"
+	"github.com/hashicorp/nomad/nomad/jobrules"
 	"github.com/hashicorp/nomad/nomad/state"
 	"github.com/hashicorp/nomad/nomad/structs"
 	"github.com/hashicorp/nomad/nomad/structs/config"
@@ -187,6 +189,12 @@ type Server struct {
 	// periodicDispatcher is used to track and create evaluations for periodic jobs.
 	periodicDispatcher *PeriodicDispatch
 
+	// dispatchLocks serializes dispatches of parameterized jobs that use an
+	// idempotency token so that concurrent retries dispatch a single job, and
+	// the dispatches and releases of jobs with a concurrency limit. Each
+	// parameterized job has its own lock.
+	dispatchLocks *dispatchLocks
+
 	// planner is used to mange the submitted allocation plans that are waiting
 	// to be accessed by the leader
 	*planner
@@ -201,12 +209,28 @@ type Server struct {
 	// vault is the client for communicating with Vault.
 	vault VaultClient
 
+	// jobValidators are the admission hooks jobs are validated with.
+	jobValidators []jobValidator
+
+	// jobRules validates jobs against the operator's rules. It is replaced
+	// when the rules are reloaded.
+	jobRules     *jobrules.Validator
+	jobRulesLock sync.RWMutex
+
 	// Worker used for processing
 	workers []*Worker
 
 	// aclCache is used to maintain the parsed ACL objects
 	aclCache *lru.TwoQueueCache
 
+	// oidcKeys caches the signing keys of the OIDC providers used by auth
+	// methods
+	oidcKeys *oidc.KeyCache
+
+	// keystore holds the key material of the root keys of the keyring,
+	// which is not replicated through raft
+	keystore *keystore
+
 	// leaderAcl is the management ACL token that is valid when resolved by the
 	// current leader.
 	leaderAcl     string
@@ -230,20 +254,24 @@ type Server struct {
 
 // Holds the RPC endpoints
 type endpoints struct {
-	Status     *Status
-	Node       *Node
-	Job        *Job
-	Eval       *Eval
-	Plan       *Plan
-	Alloc      *Alloc
-	Deployment *Deployment
-	Region     *Region
-	Search     *Search
-	Periodic   *Periodic
-	System     *System
-	Operator   *Operator
-	ACL        *ACL
-	Enterprise *EnterpriseEndpoints
+	Status          *Status
+	Node            *Node
+	Job             *Job
+	Eval            *Eval
+	Plan            *Plan
+	Alloc           *Alloc
+	Deployment      *Deployment
+	DispatchPayload *DispatchPayload
+	Maintenance     *Maintenance
+	Region          *Region
+	Search          *Search
+	Periodic        *Periodic
+	System          *System
+	Operator        *Operator
+	ACL             *ACL
+	Keyring         *Keyring
+	Variables       *Variables
+	Enterprise      *EnterpriseEndpoints
 
 	// Client endpoints
 	ClientStats       *ClientStats
@@ -306,11 +334,25 @@ func NewServer(config *Config, consulCatalog consul.CatalogAPI) (*Server, error)
 		blockedEvals:  NewBlockedEvals(evalBroker, logger),
 		rpcTLS:        incomingTLS,
 		aclCache:      aclCache,
+		oidcKeys:      oidc.NewKeyCache(oidc.DefaultKeyCacheTTL, oidc.DefaultKeyCacheMinRefresh),
+		dispatchLocks: newDispatchLocks(),
 	}
 
 	s.shutdownCtx, s.shutdownCancel = context.WithCancel(context.Background())
 	s.shutdownCh = s.shutdownCtx.Done()
 
+	// Load the key material of the keyring
+	keystoreDir := ""
+	if !config.DevMode {
+		keystoreDir = filepath.Join(config.DataDir, keystorePath)
+	}
+	s.keystore, err = newKeystore(keystoreDir)
+	if err != nil {
+		s.Shutdown()
+		s.logger.Error("failed to setup keystore", "error", err)
+		return nil, fmt.Errorf("Failed to setup keystore: %v", err)
+	}
+
 	// Create the RPC handler
 	s.rpcHandler = newRpcHandler(s)
 
@@ -337,6 +379,13 @@ func NewServer(config *Config, consulCatalog consul.CatalogAPI) (*Server, error)
 		return nil, fmt.Errorf("Failed to setup Vault client: %v", err)
 	}
 
+	// Setup the job admission hooks
+	if err := s.setupJobValidators(); err != nil {
+		s.Shutdown()
+		s.logger.Error("failed to setup job validation rules", "error", err)
+		return nil, fmt.Errorf("Failed to setup job validation rules: %v", err)
+	}
+
 	// Initialize the RPC layer
 	if err := s.setupRPC(tlsWrap); err != nil {
 		s.Shutdown()
@@ -410,6 +459,9 @@ func NewServer(config *Config, consulCatalog consul.CatalogAPI) (*Server, error)
 	// Emit metrics
 	go s.heartbeatStats()
 
+	// Replicate the key material of the keyring from the other servers
+	go s.replicateRootKeys()
+
 	// Start enterprise background workers
 	s.startEnterpriseBackground()
 
@@ -689,11 +741,20 @@ func (s *Server) Reload(newConfig *Config) error {
 
 	// Handle the Vault reload. Vault should never be nil but just guard.
 	if s.vault != nil {
-		if err := s.vault.SetConfig(newConfig.VaultConfig); err != nil {
+		if err := s.vault.SetConfig(newConfig.VaultConfigs); err != nil {
 			multierror.Append(&mErr, err)
 		}
 	}
 
+	if s.deploymentWatcher != nil {
+		s.deploymentWatcher.SetAnalysisAddresses(newConfig.DeploymentAnalysisAddresses)
+	}
+
+	if err := s.loadJobRules(newConfig.JobValidationRules); err != nil {
+		s.logger.Error("error reloading job validation rules", "error", err)
+		multierror.Append(&mErr, err)
+	}
+
 	shouldReloadTLS, err := tlsutil.ShouldReloadRPCConnections(s.config.TLSConfig, newConfig.TLSConfig)
 	if err != nil {
 		s.logger.Error("error checking whether to reload TLS configuration", "error", err)
@@ -917,6 +978,7 @@ func (s *Server) setupDeploymentWatcher() error {
 		s.logger, raftShim,
 		deploymentwatcher.LimitStateQueriesPerSecond,
 		deploymentwatcher.CrossDeploymentUpdateBatchDuration)
+	s.deploymentWatcher.SetAnalysisAddresses(s.config.DeploymentAnalysisAddresses)
 
 	return nil
 }
@@ -938,9 +1000,9 @@ func (s *Server) setupNodeDrainer() {
 	s.nodeDrainer = drainer.NewNodeDrainer(c)
 }
 
-// setupVaultClient is used to set up the Vault API client.
+// setupVaultClient is used to set up the Vault API clients.
 func (s *Server) setupVaultClient() error {
-	v, err := NewVaultClient(s.config.VaultConfig, s.logger, s.purgeVaultAccessors)
+	v, err := NewVaultClients(s.config.VaultConfigs, s.logger, s.purgeVaultAccessors)
 	if err != nil {
 		return err
 	}
@@ -1018,8 +1080,11 @@ func (s *Server) setupRpcServer(server *rpc.Server, ctx *RPCContext) {
 		s.staticEndpoints.Alloc = &Alloc{srv: s, logger: s.logger.Named("alloc")}
 		s.staticEndpoints.Eval = &Eval{srv: s, logger: s.logger.Named("eval")}
 		s.staticEndpoints.Job = &Job{srv: s, logger: s.logger.Named("job")}
-		s.staticEndpoints.Node = &Node{srv: s, logger: s.logger.Named("client")} // Add but don't register
+		s.staticEndpoints.Keyring = &Keyring{srv: s, logger: s.logger.Named("keyring")} // Add but don't register
+		s.staticEndpoints.Node = &Node{srv: s, logger: s.logger.Named("client")}        // Add but don't register
 		s.staticEndpoints.Deployment = &Deployment{srv: s, logger: s.logger.Named("deployment")}
+		s.staticEndpoints.DispatchPayload = &DispatchPayload{srv: s, logger: s.logger.Named("dispatch_payload")}
+		s.staticEndpoints.Maintenance = &Maintenance{srv: s, logger: s.logger.Named("maintenance")}
 		s.staticEndpoints.Operator = &Operator{srv: s, logger: s.logger.Named("operator")}
 		s.staticEndpoints.Periodic = &Periodic{srv: s, logger: s.logger.Named("periodic")}
 		s.staticEndpoints.Plan = &Plan{srv: s, logger: s.logger.Named("plan")}
@@ -1027,6 +1092,7 @@ func (s *Server) setupRpcServer(server *rpc.Server, ctx *RPCContext) {
 		s.staticEndpoints.Status = &Status{srv: s, logger: s.logger.Named("status")}
 		s.staticEndpoints.System = &System{srv: s, logger: s.logger.Named("system")}
 		s.staticEndpoints.Search = &Search{srv: s, logger: s.logger.Named("search")}
+		s.staticEndpoints.Variables = &Variables{srv: s, logger: s.logger.Named("variables")}
 		s.staticEndpoints.Enterprise = NewEnterpriseEndpoints(s)
 
 		// Client endpoints
@@ -1045,6 +1111,8 @@ func (s *Server) setupRpcServer(server *rpc.Server, ctx *RPCContext) {
 	server.Register(s.staticEndpoints.Eval)
 	server.Register(s.staticEndpoints.Job)
 	server.Register(s.staticEndpoints.Deployment)
+	server.Register(s.staticEndpoints.DispatchPayload)
+	server.Register(s.staticEndpoints.Maintenance)
 	server.Register(s.staticEndpoints.Operator)
 	server.Register(s.staticEndpoints.Periodic)
 	server.Register(s.staticEndpoints.Plan)
@@ -1052,6 +1120,7 @@ func (s *Server) setupRpcServer(server *rpc.Server, ctx *RPCContext) {
 	server.Register(s.staticEndpoints.Status)
 	server.Register(s.staticEndpoints.System)
 	server.Register(s.staticEndpoints.Search)
+	server.Register(s.staticEndpoints.Variables)
 	s.staticEndpoints.Enterprise.Register(server)
 	server.Register(s.staticEndpoints.ClientStats)
 	server.Register(s.staticEndpoints.ClientAllocations)
@@ -1059,9 +1128,11 @@ func (s *Server) setupRpcServer(server *rpc.Server, ctx *RPCContext) {
 
 	// Create new dynamic endpoints and add them to the RPC server.
 	node := &Node{srv: s, ctx: ctx, logger: s.logger.Named("client")}
+	keyring := &Keyring{srv: s, ctx: ctx, logger: s.logger.Named("keyring")}
 
 	// Register the dynamic endpoints
 	server.Register(node)
+	server.Register(keyring)
 }
 
 // setupRaft is used to setup and initialize Raft
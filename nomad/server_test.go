@@ -220,8 +220,8 @@ func TestServer_Reload_Vault(t *testing.T) {
 
 	tr := true
 	config := DefaultConfig()
-	config.VaultConfig.Enabled = &tr
-	config.VaultConfig.Token = uuid.Generate()
+	config.GetDefaultVault().Enabled = &tr
+	config.GetDefaultVault().Token = uuid.Generate()
 
 	if err := s1.Reload(config); err != nil {
 		t.Fatalf("Reload failed: %v", err)
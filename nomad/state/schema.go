@@ -38,11 +38,19 @@ func init() {
 		jobVersionSchema,
 		deploymentSchema,
 		periodicLaunchTableSchema,
+		dispatchBlobTableSchema,
+		dispatchBlobChunkTableSchema,
+		maintenancePlanTableSchema,
 		evalTableSchema,
 		allocTableSchema,
 		vaultAccessorTableSchema,
 		aclPolicyTableSchema,
+		aclRoleTableSchema,
+		aclAuthMethodTableSchema,
+		aclBindingRuleTableSchema,
 		aclTokenTableSchema,
+		rootKeyTableSchema,
+		variablesTableSchema,
 		autopilotConfigTableSchema,
 		schedulerConfigTableSchema,
 	}...)
@@ -166,6 +174,22 @@ func jobTableSchema() *memdb.TableSchema {
 					Conditional: jobIsPeriodic,
 				},
 			},
+			"dispatch_queued": {
+				Name:         "dispatch_queued",
+				AllowMissing: false,
+				Unique:       false,
+				Indexer: &memdb.ConditionalIndex{
+					Conditional: jobIsDispatchQueued,
+				},
+			},
+			"payload_blob": {
+				Name:         "payload_blob",
+				AllowMissing: true,
+				Unique:       false,
+				Indexer: &memdb.StringFieldIndex{
+					Field: "PayloadBlob",
+				},
+			},
 		},
 	}
 }
@@ -258,9 +282,9 @@ func jobIsGCable(obj interface{}) (bool, error) {
 		return true, nil
 	}
 
-	// Otherwise, only batch jobs are eligible because they complete on their
-	// own without a user stopping them.
-	if j.Type != structs.JobTypeBatch {
+	// Otherwise, only batch and sysbatch jobs are eligible because they
+	// complete on their own without a user stopping them.
+	if j.Type != structs.JobTypeBatch && j.Type != structs.JobTypeSysBatch {
 		return false, nil
 	}
 
@@ -282,6 +306,17 @@ func jobIsPeriodic(obj interface{}) (bool, error) {
 	return false, nil
 }
 
+// jobIsDispatchQueued satisfies the ConditionalIndexFunc interface and creates
+// an index on whether a dispatched job is queued waiting to be released.
+func jobIsDispatchQueued(obj interface{}) (bool, error) {
+	j, ok := obj.(*structs.Job)
+	if !ok {
+		return false, fmt.Errorf("Unexpected type: %v", obj)
+	}
+
+	return j.DispatchQueued && !j.Stop, nil
+}
+
 // deploymentSchema returns the MemDB schema tracking a job's deployments
 func deploymentSchema() *memdb.TableSchema {
 	return &memdb.TableSchema{
@@ -361,6 +396,81 @@ func periodicLaunchTableSchema() *memdb.TableSchema {
 	}
 }
 
+// dispatchBlobTableSchema returns the MemDB schema for the dispatch blob
+// table. This table is used to store the dispatch payloads too large to be
+// stored on the dispatched jobs.
+func dispatchBlobTableSchema() *memdb.TableSchema {
+	return &memdb.TableSchema{
+		Name: "dispatch_blobs",
+		Indexes: map[string]*memdb.IndexSchema{
+			"id": {
+				Name:         "id",
+				AllowMissing: false,
+				Unique:       true,
+				Indexer: &memdb.StringFieldIndex{
+					Field: "ID",
+				},
+			},
+		},
+	}
+}
+
+// dispatchBlobChunkTableSchema returns the MemDB schema for the dispatch blob
+// chunk table. This table is used to store the chunks of the dispatch blobs.
+func dispatchBlobChunkTableSchema() *memdb.TableSchema {
+	return &memdb.TableSchema{
+		Name: "dispatch_blob_chunks",
+		Indexes: map[string]*memdb.IndexSchema{
+			"id": {
+				Name:         "id",
+				AllowMissing: false,
+				Unique:       true,
+
+				// Use a compound index so the tuple of (BlobID, Index) is
+				// uniquely identifying
+				Indexer: &memdb.CompoundIndex{
+					Indexes: []memdb.Indexer{
+						&memdb.StringFieldIndex{
+							Field: "BlobID",
+						},
+
+						&memdb.UintFieldIndex{
+							Field: "Index",
+						},
+					},
+				},
+			},
+			"blob_id": {
+				Name:         "blob_id",
+				AllowMissing: false,
+				Unique:       false,
+				Indexer: &memdb.StringFieldIndex{
+					Field: "BlobID",
+				},
+			},
+		},
+	}
+}
+
+// maintenancePlanTableSchema returns the MemDB schema for the maintenance
+// plan table. This table is used to store the plans draining nodes in
+// batches.
+func maintenancePlanTableSchema() *memdb.TableSchema {
+	return &memdb.TableSchema{
+		Name: "maintenance_plans",
+		Indexes: map[string]*memdb.IndexSchema{
+			"id": {
+				Name:         "id",
+				AllowMissing: false,
+				Unique:       true,
+				Indexer: &memdb.StringFieldIndex{
+					Field: "ID",
+				},
+			},
+		},
+	}
+}
+
 // evalTableSchema returns the MemDB schema for the eval table.
 // This table is used to store all the evaluations that are pending
 // or recently completed.
@@ -567,6 +677,124 @@ func aclPolicyTableSchema() *memdb.TableSchema {
 	}
 }
 
+// aclRoleTableSchema returns the MemDB schema for the role table.
+// This table is used to store the roles which are referenced by tokens
+func aclRoleTableSchema() *memdb.TableSchema {
+	return &memdb.TableSchema{
+		Name: "acl_role",
+		Indexes: map[string]*memdb.IndexSchema{
+			"id": {
+				Name:         "id",
+				AllowMissing: false,
+				Unique:       true,
+				Indexer: &memdb.StringFieldIndex{
+					Field: "Name",
+				},
+			},
+		},
+	}
+}
+
+// aclAuthMethodTableSchema returns the MemDB schema for the auth method table.
+// This table is used to store the auth methods used to login
+func aclAuthMethodTableSchema() *memdb.TableSchema {
+	return &memdb.TableSchema{
+		Name: "acl_auth_method",
+		Indexes: map[string]*memdb.IndexSchema{
+			"id": {
+				Name:         "id",
+				AllowMissing: false,
+				Unique:       true,
+				Indexer: &memdb.StringFieldIndex{
+					Field: "Name",
+				},
+			},
+		},
+	}
+}
+
+// aclBindingRuleTableSchema returns the MemDB schema for the binding rule
+// table. This table is used to store the rules which map a login to the
+// policies and roles of the created token
+func aclBindingRuleTableSchema() *memdb.TableSchema {
+	return &memdb.TableSchema{
+		Name: "acl_binding_rule",
+		Indexes: map[string]*memdb.IndexSchema{
+			"id": {
+				Name:         "id",
+				AllowMissing: false,
+				Unique:       true,
+				Indexer: &memdb.UUIDFieldIndex{
+					Field: "ID",
+				},
+			},
+			"auth_method": {
+				Name:         "auth_method",
+				AllowMissing: false,
+				Unique:       false,
+				Indexer: &memdb.StringFieldIndex{
+					Field: "AuthMethod",
+				},
+			},
+		},
+	}
+}
+
+// rootKeyTableSchema returns the MemDB schema for the root key table.
+// This table is used to store the keyring used to sign workload identities
+func rootKeyTableSchema() *memdb.TableSchema {
+	return &memdb.TableSchema{
+		Name: "root_keys",
+		Indexes: map[string]*memdb.IndexSchema{
+			"id": {
+				Name:         "id",
+				AllowMissing: false,
+				Unique:       true,
+				Indexer: &memdb.UUIDFieldIndex{
+					Field: "KeyID",
+				},
+			},
+		},
+	}
+}
+
+// variablesTableSchema returns the MemDB schema for the variables table.
+// This table is used to store the encrypted variables of jobs and users
+func variablesTableSchema() *memdb.TableSchema {
+	return &memdb.TableSchema{
+		Name: "variables",
+		Indexes: map[string]*memdb.IndexSchema{
+			"id": {
+				Name:         "id",
+				AllowMissing: false,
+				Unique:       true,
+
+				// Use a compound index so the tuple of (Namespace, Path) is
+				// uniquely identifying
+				Indexer: &memdb.CompoundIndex{
+					Indexes: []memdb.Indexer{
+						&memdb.StringFieldIndex{
+							Field: "Namespace",
+						},
+
+						&memdb.StringFieldIndex{
+							Field: "Path",
+						},
+					},
+				},
+			},
+			"key_id": {
+				Name:         "key_id",
+				AllowMissing: false,
+				Unique:       false,
+				Indexer: &memdb.StringFieldIndex{
+					Field: "KeyID",
+				},
+			},
+		},
+	}
+}
+
 // aclTokenTableSchema returns the MemDB schema for the tokens table.
 // This table is used to store the bearer tokens which are used to authenticate
 func aclTokenTableSchema() *memdb.TableSchema {
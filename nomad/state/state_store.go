@@ -1059,6 +1059,14 @@ func (s *StateStore) upsertJobImpl(index uint64, job *structs.Job, keepVersion b
 		if err != nil {
 			return fmt.Errorf("setting job status for %q failed: %v", job.ID, err)
 		}
+
+		// Move a dispatched job that is no longer queued, such as a stopped
+		// one, out of the queued children of its parent
+		if existingJob := existing.(*structs.Job); existingJob.Status == structs.JobStatusQueued && job.Status != structs.JobStatusQueued {
+			if err := s.setJobStatus(index, txn, existingJob, false, job.Status); err != nil {
+				return fmt.Errorf("setting job status for %q failed: %v", job.ID, err)
+			}
+		}
 	} else {
 		job.CreateIndex = index
 		job.ModifyIndex = index
@@ -1152,6 +1160,10 @@ func (s *StateStore) DeleteJobTxn(index uint64, namespace, jobID string, txn Txn
 					pSummary.Children.Running--
 					pSummary.Children.Dead++
 					modified = true
+				case structs.JobStatusQueued:
+					pSummary.Children.Queued--
+					pSummary.Children.Dead++
+					modified = true
 				case structs.JobStatusDead:
 				default:
 					return fmt.Errorf("unknown old job status %q", job.Status)
@@ -1530,6 +1542,36 @@ func (s *StateStore) JobsByGC(ws memdb.WatchSet, gc bool) (memdb.ResultIterator,
 	return iter, nil
 }
 
+// JobsByDispatchQueued returns an iterator over the dispatched jobs queued
+// by the max_concurrent limit of their parent, excluding stopped ones
+func (s *StateStore) JobsByDispatchQueued(ws memdb.WatchSet) (memdb.ResultIterator, error) {
+	txn := s.db.Txn(false)
+
+	iter, err := txn.Get("jobs", "dispatch_queued", true)
+	if err != nil {
+		return nil, err
+	}
+
+	ws.Add(iter.WatchCh())
+
+	return iter, nil
+}
+
+// JobsByPayloadBlob returns an iterator over the jobs whose dispatch payload
+// is stored in the given blob
+func (s *StateStore) JobsByPayloadBlob(ws memdb.WatchSet, blobID string) (memdb.ResultIterator, error) {
+	txn := s.db.Txn(false)
+
+	iter, err := txn.Get("jobs", "payload_blob", blobID)
+	if err != nil {
+		return nil, err
+	}
+
+	ws.Add(iter.WatchCh())
+
+	return iter, nil
+}
+
 // JobSummary returns a job summary object which matches a specific id.
 func (s *StateStore) JobSummaryByID(ws memdb.WatchSet, namespace, jobID string) (*structs.JobSummary, error) {
 	txn := s.db.Txn(false)
@@ -1625,6 +1667,47 @@ func (s *StateStore) UpsertPeriodicLaunch(index uint64, launch *structs.Periodic
 	return nil
 }
 
+// UpsertPeriodicLaunchEvents is used to record launch events in the launch
+// history of a periodic job, creating the launch if it doesn't exist.
+func (s *StateStore) UpsertPeriodicLaunchEvents(index uint64, namespace, jobID string, events []*structs.PeriodicLaunchEvent) error {
+	txn := s.db.Txn(true)
+	defer txn.Abort()
+
+	// COMPAT 0.7: Upgrade old objects that do not have namespaces
+	if namespace == "" {
+		namespace = structs.DefaultNamespace
+	}
+
+	existing, err := txn.First("periodic_launch", "id", namespace, jobID)
+	if err != nil {
+		return fmt.Errorf("periodic launch lookup failed: %v", err)
+	}
+
+	var launch *structs.PeriodicLaunch
+	if existing != nil {
+		launch = existing.(*structs.PeriodicLaunch).Copy()
+		launch.ModifyIndex = index
+	} else {
+		launch = &structs.PeriodicLaunch{
+			ID:          jobID,
+			Namespace:   namespace,
+			CreateIndex: index,
+			ModifyIndex: index,
+		}
+	}
+	launch.AddEvents(events...)
+
+	if err := txn.Insert("periodic_launch", launch); err != nil {
+		return fmt.Errorf("launch insert failed: %v", err)
+	}
+	if err := txn.Insert("index", &IndexEntry{"periodic_launch", index}); err != nil {
+		return fmt.Errorf("index update failed: %v", err)
+	}
+
+	txn.Commit()
+	return nil
+}
+
 // DeletePeriodicLaunch is used to delete the periodic launch
 func (s *StateStore) DeletePeriodicLaunch(index uint64, namespace, jobID string) error {
 	txn := s.db.Txn(true)
@@ -1703,6 +1786,315 @@ func (s *StateStore) PeriodicLaunches(ws memdb.WatchSet) (memdb.ResultIterator,
 	return iter, nil
 }
 
+// ReleaseDispatchedJobs is used to release queued dispatched jobs along with
+// the evaluations scheduling them. Jobs no longer queued, including stopped
+// ones, are skipped.
+func (s *StateStore) ReleaseDispatchedJobs(index uint64, jobs []structs.NamespacedID, evals []*structs.Evaluation) error {
+	txn := s.db.Txn(true)
+	defer txn.Abort()
+
+	released := make(map[structs.NamespacedID]struct{}, len(jobs))
+	for _, id := range jobs {
+		existing, err := txn.First("jobs", "id", id.Namespace, id.ID)
+		if err != nil {
+			return fmt.Errorf("job lookup failed: %v", err)
+		}
+		if existing == nil || !existing.(*structs.Job).DispatchQueued || existing.(*structs.Job).Stop {
+			continue
+		}
+
+		job := existing.(*structs.Job).Copy()
+		job.DispatchQueued = false
+		job.ModifyIndex = index
+		if err := txn.Insert("jobs", job); err != nil {
+			return fmt.Errorf("job insert failed: %v", err)
+		}
+		released[id] = struct{}{}
+	}
+	if err := txn.Insert("index", &IndexEntry{"jobs", index}); err != nil {
+		return fmt.Errorf("index update failed: %v", err)
+	}
+
+	// Only create the evaluations of the released jobs
+	var releasedEvals []*structs.Evaluation
+	for _, eval := range evals {
+		if _, ok := released[structs.NamespacedID{ID: eval.JobID, Namespace: eval.Namespace}]; ok {
+			releasedEvals = append(releasedEvals, eval)
+		}
+	}
+	if err := s.UpsertEvalsTxn(index, releasedEvals, txn); err != nil {
+		return err
+	}
+
+	txn.Commit()
+	return nil
+}
+
+// UpsertDispatchBlobChunk is used to write a chunk of a dispatch blob. The
+// blob is only visible once it is upserted after all its chunks.
+func (s *StateStore) UpsertDispatchBlobChunk(index uint64, chunk *structs.DispatchBlobChunk) error {
+	txn := s.db.Txn(true)
+	defer txn.Abort()
+
+	chunk.CreateIndex = index
+	if err := txn.Insert("dispatch_blob_chunks", chunk); err != nil {
+		return fmt.Errorf("dispatch blob chunk insert failed: %v", err)
+	}
+	if err := txn.Insert("index", &IndexEntry{"dispatch_blob_chunks", index}); err != nil {
+		return fmt.Errorf("index update failed: %v", err)
+	}
+
+	txn.Commit()
+	return nil
+}
+
+// UpsertDispatchBlob is used to create a dispatch blob once all its chunks
+// are written, or to refresh the modify index of an existing blob so it is
+// not garbage collected before the job reusing it is registered.
+func (s *StateStore) UpsertDispatchBlob(index uint64, blob *structs.DispatchBlob) error {
+	txn := s.db.Txn(true)
+	defer txn.Abort()
+
+	existing, err := txn.First("dispatch_blobs", "id", blob.ID)
+	if err != nil {
+		return fmt.Errorf("dispatch blob lookup failed: %v", err)
+	}
+	if existing != nil {
+		blob.CreateIndex = existing.(*structs.DispatchBlob).CreateIndex
+	} else {
+		blob.CreateIndex = index
+	}
+	blob.ModifyIndex = index
+
+	// Ensure all the chunks of the blob are written
+	for i := 0; i < blob.Chunks; i++ {
+		chunk, err := txn.First("dispatch_blob_chunks", "id", blob.ID, uint(i))
+		if err != nil {
+			return fmt.Errorf("dispatch blob chunk lookup failed: %v", err)
+		}
+		if chunk == nil {
+			return fmt.Errorf("dispatch blob %q is missing chunk %d", blob.ID, i)
+		}
+	}
+
+	if err := txn.Insert("dispatch_blobs", blob); err != nil {
+		return fmt.Errorf("dispatch blob insert failed: %v", err)
+	}
+	if err := txn.Insert("index", &IndexEntry{"dispatch_blobs", index}); err != nil {
+		return fmt.Errorf("index update failed: %v", err)
+	}
+
+	txn.Commit()
+	return nil
+}
+
+// DeleteDispatchBlobs is used to delete a set of dispatch blobs and their
+// chunks
+func (s *StateStore) DeleteDispatchBlobs(index uint64, blobIDs []string) error {
+	txn := s.db.Txn(true)
+	defer txn.Abort()
+
+	for _, id := range blobIDs {
+		if _, err := txn.DeleteAll("dispatch_blobs", "id", id); err != nil {
+			return fmt.Errorf("deleting dispatch blob failed: %v", err)
+		}
+		if _, err := txn.DeleteAll("dispatch_blob_chunks", "blob_id", id); err != nil {
+			return fmt.Errorf("deleting dispatch blob chunks failed: %v", err)
+		}
+	}
+	if err := txn.Insert("index", &IndexEntry{"dispatch_blobs", index}); err != nil {
+		return fmt.Errorf("index update failed: %v", err)
+	}
+	if err := txn.Insert("index", &IndexEntry{"dispatch_blob_chunks", index}); err != nil {
+		return fmt.Errorf("index update failed: %v", err)
+	}
+
+	txn.Commit()
+	return nil
+}
+
+// DispatchBlobByID is used to lookup a dispatch blob by its ID
+func (s *StateStore) DispatchBlobByID(ws memdb.WatchSet, id string) (*structs.DispatchBlob, error) {
+	txn := s.db.Txn(false)
+
+	watchCh, existing, err := txn.FirstWatch("dispatch_blobs", "id", id)
+	if err != nil {
+		return nil, fmt.Errorf("dispatch blob lookup failed: %v", err)
+	}
+	ws.Add(watchCh)
+
+	if existing != nil {
+		return existing.(*structs.DispatchBlob), nil
+	}
+	return nil, nil
+}
+
+// DispatchBlobs returns an iterator over all the dispatch blobs
+func (s *StateStore) DispatchBlobs(ws memdb.WatchSet) (memdb.ResultIterator, error) {
+	txn := s.db.Txn(false)
+
+	// Walk the entire table
+	iter, err := txn.Get("dispatch_blobs", "id")
+	if err != nil {
+		return nil, err
+	}
+
+	ws.Add(iter.WatchCh())
+
+	return iter, nil
+}
+
+// DispatchBlobChunkByID is used to lookup a chunk of a dispatch blob
+func (s *StateStore) DispatchBlobChunkByID(ws memdb.WatchSet, blobID string, chunk uint) (*structs.DispatchBlobChunk, error) {
+	txn := s.db.Txn(false)
+
+	watchCh, existing, err := txn.FirstWatch("dispatch_blob_chunks", "id", blobID, chunk)
+	if err != nil {
+		return nil, fmt.Errorf("dispatch blob chunk lookup failed: %v", err)
+	}
+	ws.Add(watchCh)
+
+	if existing != nil {
+		return existing.(*structs.DispatchBlobChunk), nil
+	}
+	return nil, nil
+}
+
+// DispatchBlobChunks returns an iterator over the chunks of all the dispatch
+// blobs
+func (s *StateStore) DispatchBlobChunks(ws memdb.WatchSet) (memdb.ResultIterator, error) {
+	txn := s.db.Txn(false)
+
+	// Walk the entire table
+	iter, err := txn.Get("dispatch_blob_chunks", "id")
+	if err != nil {
+		return nil, err
+	}
+
+	ws.Add(iter.WatchCh())
+
+	return iter, nil
+}
+
+// UpsertMaintenancePlan is used to create or update a maintenance plan along
+// with the drains of the nodes the update starts. An existing plan is only
+// updated from the version it was read at so the leader progressing a plan
+// can't override a concurrent cancellation.
+func (s *StateStore) UpsertMaintenancePlan(index uint64, plan *structs.MaintenancePlan,
+	drains map[string]*structs.DrainUpdate, events map[string]*structs.NodeEvent) error {
+	txn := s.db.Txn(true)
+	defer txn.Abort()
+
+	existing, err := txn.First("maintenance_plans", "id", plan.ID)
+	if err != nil {
+		return fmt.Errorf("maintenance plan lookup failed: %v", err)
+	}
+	if existing != nil {
+		existingPlan := existing.(*structs.MaintenancePlan)
+		if existingPlan.ModifyIndex != plan.ModifyIndex {
+			return fmt.Errorf("maintenance plan %q was modified at index %d", plan.ID, existingPlan.ModifyIndex)
+		}
+		plan.CreateIndex = existingPlan.CreateIndex
+	} else {
+		// Nodes can't be selected by several active plans. The nodes are
+		// selected before the plan is committed, so another plan may have
+		// selected them since.
+		if err := s.maintenancePlanNodesAvailable(txn, plan); err != nil {
+			return err
+		}
+		plan.CreateIndex = index
+	}
+	plan.ModifyIndex = index
+
+	if err := txn.Insert("maintenance_plans", plan); err != nil {
+		return fmt.Errorf("maintenance plan insert failed: %v", err)
+	}
+	if err := txn.Insert("index", &IndexEntry{"maintenance_plans", index}); err != nil {
+		return fmt.Errorf("index update failed: %v", err)
+	}
+
+	// Start the drains of the nodes
+	for nodeID, update := range drains {
+		if err := s.updateNodeDrainImpl(txn, index, nodeID, update.DrainStrategy, update.MarkEligible, events[nodeID]); err != nil {
+			return err
+		}
+	}
+
+	txn.Commit()
+	return nil
+}
+
+// maintenancePlanNodesAvailable returns an error if any node of the plan is
+// still going through the maintenance of another active plan
+func (s *StateStore) maintenancePlanNodesAvailable(txn *memdb.Txn, plan *structs.MaintenancePlan) error {
+	nodes := make(map[string]struct{}, len(plan.Nodes))
+	for _, n := range plan.Nodes {
+		nodes[n.NodeID] = struct{}{}
+	}
+
+	iter, err := txn.Get("maintenance_plans", "id")
+	if err != nil {
+		return fmt.Errorf("maintenance plan lookup failed: %v", err)
+	}
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		active := raw.(*structs.MaintenancePlan)
+		if active.ID == plan.ID || !active.Active() {
+			continue
+		}
+		for _, n := range active.Nodes {
+			if _, ok := nodes[n.NodeID]; ok && !n.Done() {
+				return fmt.Errorf("node %q is part of active maintenance plan %q", n.NodeID, active.ID)
+			}
+		}
+	}
+	return nil
+}
+
+// MaintenancePlanByID is used to lookup a maintenance plan by its ID
+func (s *StateStore) MaintenancePlanByID(ws memdb.WatchSet, id string) (*structs.MaintenancePlan, error) {
+	txn := s.db.Txn(false)
+
+	watchCh, existing, err := txn.FirstWatch("maintenance_plans", "id", id)
+	if err != nil {
+		return nil, fmt.Errorf("maintenance plan lookup failed: %v", err)
+	}
+	ws.Add(watchCh)
+
+	if existing != nil {
+		return existing.(*structs.MaintenancePlan), nil
+	}
+	return nil, nil
+}
+
+// MaintenancePlansByIDPrefix is used to lookup maintenance plans by prefix
+func (s *StateStore) MaintenancePlansByIDPrefix(ws memdb.WatchSet, prefix string) (memdb.ResultIterator, error) {
+	txn := s.db.Txn(false)
+
+	iter, err := txn.Get("maintenance_plans", "id_prefix", prefix)
+	if err != nil {
+		return nil, fmt.Errorf("maintenance plan lookup failed: %v", err)
+	}
+
+	ws.Add(iter.WatchCh())
+
+	return iter, nil
+}
+
+// MaintenancePlans returns an iterator over all the maintenance plans
+func (s *StateStore) MaintenancePlans(ws memdb.WatchSet) (memdb.ResultIterator, error) {
+	txn := s.db.Txn(false)
+
+	// Walk the entire table
+	iter, err := txn.Get("maintenance_plans", "id")
+	if err != nil {
+		return nil, err
+	}
+
+	ws.Add(iter.WatchCh())
+
+	return iter, nil
+}
+
 // UpsertEvals is used to upsert a set of evaluations
 func (s *StateStore) UpsertEvals(index uint64, evals []*structs.Evaluation) error {
 	txn := s.db.Txn(true)
@@ -2214,9 +2606,10 @@ func (s *StateStore) upsertAllocsImpl(index uint64, allocs []*structs.Allocation
 			// Keep the clients task states
 			alloc.TaskStates = exist.TaskStates
 
-			// If the scheduler is marking this allocation as lost we do not
-			// want to reuse the status of the existing allocation.
-			if alloc.ClientStatus != structs.AllocClientStatusLost {
+			// If the scheduler is marking this allocation as lost or unknown
+			// we do not want to reuse the status of the existing allocation.
+			if alloc.ClientStatus != structs.AllocClientStatusLost &&
+				alloc.ClientStatus != structs.AllocClientStatusUnknown {
 				alloc.ClientStatus = exist.ClientStatus
 				alloc.ClientDescription = exist.ClientDescription
 			}
@@ -2844,10 +3237,20 @@ func (s *StateStore) UpdateDeploymentPromotion(index uint64, req *structs.ApplyD
 		return err
 	}
 
-	// groupIndex is a map of groups being promoted
-	groupIndex := make(map[string]struct{}, len(req.Groups))
-	for _, g := range req.Groups {
-		groupIndex[g] = struct{}{}
+	// groupIndex is a map of groups being promoted. Promoting all groups
+	// only promotes the groups with canaries awaiting promotion, so that the
+	// rollout steps of other groups are only advanced when they are named.
+	groupIndex := make(map[string]struct{}, len(deployment.TaskGroups))
+	if req.All {
+		for tg, state := range deployment.TaskGroups {
+			if state.DesiredCanaries != 0 && !state.Promoted {
+				groupIndex[tg] = struct{}{}
+			}
+		}
+	} else {
+		for _, g := range req.Groups {
+			groupIndex[g] = struct{}{}
+		}
 	}
 
 	// canaryIndex is the set of placed canaries in the deployment
@@ -2879,7 +3282,7 @@ func (s *StateStore) UpdateDeploymentPromotion(index uint64, req *structs.ApplyD
 		}
 
 		// Check that the canary is part of a group being promoted
-		if _, ok := groupIndex[alloc.TaskGroup]; !req.All && !ok {
+		if _, ok := groupIndex[alloc.TaskGroup]; !ok {
 			continue
 		}
 
@@ -2895,12 +3298,14 @@ func (s *StateStore) UpdateDeploymentPromotion(index uint64, req *structs.ApplyD
 	// Determine if we have enough healthy allocations
 	var unhealthyErr multierror.Error
 	for tg, state := range deployment.TaskGroups {
-		if _, ok := groupIndex[tg]; !req.All && !ok {
+		if _, ok := groupIndex[tg]; !ok {
 			continue
 		}
 
+		// The canaries of task groups with steps were promoted when their
+		// canary step was advanced
 		need := state.DesiredCanaries
-		if need == 0 {
+		if need == 0 || (state.Promoted && len(state.Steps) != 0) {
 			continue
 		}
 
@@ -2909,25 +3314,48 @@ func (s *StateStore) UpdateDeploymentPromotion(index uint64, req *structs.ApplyD
 		}
 	}
 
-	if err := unhealthyErr.ErrorOrNil(); err != nil {
-		return err
-	}
-
-	// Update deployment
+	// Ensure the allocations of the rollout steps being advanced are healthy
+	for tg, state := range deployment.TaskGroups {
+		if _, ok := groupIndex[tg]; !ok {
+			continue
+		}
+
+		step := state.Step()
+		if step == nil || step.Canary != 0 {
+			continue
+		}
+
+		if need := step.Target(state.DesiredTotal); state.HealthyAllocs < need {
+			multierror.Append(&unhealthyErr, fmt.Errorf("Task group %q has %d/%d healthy allocations in step %d", tg, state.HealthyAllocs, need, state.CurrentStep+1))
+		}
+	}
+
+	if err := unhealthyErr.ErrorOrNil(); err != nil {
+		return err
+	}
+
+	// Update deployment
 	copy := deployment.Copy()
 	copy.ModifyIndex = index
 	for tg, status := range copy.TaskGroups {
-		_, ok := groupIndex[tg]
-		if !req.All && !ok {
+		if _, ok := groupIndex[tg]; !ok {
 			continue
 		}
 
 		status.Promoted = true
+
+		// Promoting a task group with steps advances its rollout
+		if status.Step() != nil {
+			status.CurrentStep++
+		}
 	}
 
 	// If the deployment no longer needs promotion, update its status
 	if !copy.RequiresPromotion() && copy.Status == structs.DeploymentStatusRunning {
 		copy.StatusDescription = structs.DeploymentStatusDescriptionRunning
+		if req.StatusDescription != "" {
+			copy.StatusDescription = req.StatusDescription
+		}
 	}
 
 	// Insert the deployment
@@ -3060,6 +3488,37 @@ func (s *StateStore) UpdateDeploymentAllocHealth(index uint64, req *structs.Appl
 	return nil
 }
 
+// UpdateDeploymentAnalysis is used to record the number of consecutive failed
+// metric analyses of a task group of a deployment
+func (s *StateStore) UpdateDeploymentAnalysis(index uint64, req *structs.DeploymentAnalysisUpdateRequest) error {
+	txn := s.db.Txn(true)
+	defer txn.Abort()
+
+	// Retrieve deployment and ensure it is not terminal and is active
+	ws := memdb.NewWatchSet()
+	deployment, err := s.deploymentByIDImpl(ws, req.DeploymentID, txn)
+	if err != nil {
+		return err
+	} else if deployment == nil {
+		return fmt.Errorf("Deployment ID %q couldn't be updated as it does not exist", req.DeploymentID)
+	} else if !deployment.Active() {
+		return fmt.Errorf("Deployment %q has terminal status %q:", deployment.ID, deployment.Status)
+	}
+
+	if _, ok := deployment.TaskGroups[req.TaskGroup]; !ok {
+		return fmt.Errorf("Deployment %q has no task group %q", deployment.ID, req.TaskGroup)
+	}
+
+	copy := deployment.Copy()
+	copy.TaskGroups[req.TaskGroup].AnalysisFailures = req.Failures
+	if err := s.upsertDeploymentImpl(index, copy, txn); err != nil {
+		return err
+	}
+
+	txn.Commit()
+	return nil
+}
+
 // LastIndex returns the greatest index value for all indexes
 func (s *StateStore) LatestIndex() (uint64, error) {
 	indexes, err := s.Indexes()
@@ -3199,6 +3658,8 @@ func (s *StateStore) ReconcileJobSummaries(index uint64) error {
 					summary.Children.Dead++
 				case structs.JobStatusRunning:
 					summary.Children.Running++
+				case structs.JobStatusQueued:
+					summary.Children.Queued++
 				}
 			}
 
@@ -3259,6 +3720,8 @@ func (s *StateStore) ReconcileJobSummaries(index uint64) error {
 				tg.Failed += 1
 			case structs.AllocClientStatusLost:
 				tg.Lost += 1
+			case structs.AllocClientStatusUnknown:
+				tg.Unknown += 1
 			case structs.AllocClientStatusComplete:
 				tg.Complete += 1
 			case structs.AllocClientStatusRunning:
@@ -3399,6 +3862,8 @@ func (s *StateStore) setJobStatus(index uint64, txn *memdb.Txn,
 					children.Running--
 				case structs.JobStatusDead:
 					children.Dead--
+				case structs.JobStatusQueued:
+					children.Queued--
 				default:
 					return fmt.Errorf("unknown old job status %q", oldStatus)
 				}
@@ -3412,6 +3877,8 @@ func (s *StateStore) setJobStatus(index uint64, txn *memdb.Txn,
 				children.Running++
 			case structs.JobStatusDead:
 				children.Dead++
+			case structs.JobStatusQueued:
+				children.Queued++
 			default:
 				return fmt.Errorf("unknown new job status %q", newStatus)
 			}
@@ -3448,6 +3915,16 @@ func (s *StateStore) getJobStatus(txn *memdb.Txn, job *structs.Job, evalDelete b
 		return structs.JobStatusRunning, nil
 	}
 
+	// Dispatched jobs held back by the max_concurrent limit of their parent
+	// are queued until the leader releases them
+	if job.DispatchQueued {
+		if job.Stop {
+			return structs.JobStatusDead, nil
+		}
+
+		return structs.JobStatusQueued, nil
+	}
+
 	allocs, err := txn.Get("allocs", "job", job.Namespace, job.ID)
 	if err != nil {
 		return "", err
@@ -3730,6 +4207,8 @@ func (s *StateStore) updateSummaryWithAlloc(index uint64, alloc *structs.Allocat
 			tgSummary.Complete += 1
 		case structs.AllocClientStatusLost:
 			tgSummary.Lost += 1
+		case structs.AllocClientStatusUnknown:
+			tgSummary.Unknown += 1
 		}
 
 		// Decrementing the count of the bin of the last state
@@ -3746,6 +4225,10 @@ func (s *StateStore) updateSummaryWithAlloc(index uint64, alloc *structs.Allocat
 			if tgSummary.Lost > 0 {
 				tgSummary.Lost -= 1
 			}
+		case structs.AllocClientStatusUnknown:
+			if tgSummary.Unknown > 0 {
+				tgSummary.Unknown -= 1
+			}
 		case structs.AllocClientStatusFailed, structs.AllocClientStatusComplete:
 		default:
 			s.logger.Error("invalid old client status for allocatio",
@@ -3897,6 +4380,313 @@ func (s *StateStore) ACLPolicies(ws memdb.WatchSet) (memdb.ResultIterator, error
 	return iter, nil
 }
 
+// UpsertACLRoles is used to create or update a set of ACL roles
+func (s *StateStore) UpsertACLRoles(index uint64, roles []*structs.ACLRole) error {
+	txn := s.db.Txn(true)
+	defer txn.Abort()
+
+	for _, role := range roles {
+		// Ensure the role hash is non-nil. This should be done outside the state store
+		// for performance reasons, but we check here for defense in depth.
+		if len(role.Hash) == 0 {
+			role.SetHash()
+		}
+
+		// Check if the role already exists
+		existing, err := txn.First("acl_role", "id", role.Name)
+		if err != nil {
+			return fmt.Errorf("role lookup failed: %v", err)
+		}
+
+		// Update all the indexes
+		if existing != nil {
+			role.CreateIndex = existing.(*structs.ACLRole).CreateIndex
+			role.ModifyIndex = index
+		} else {
+			role.CreateIndex = index
+			role.ModifyIndex = index
+		}
+
+		// Update the role
+		if err := txn.Insert("acl_role", role); err != nil {
+			return fmt.Errorf("upserting role failed: %v", err)
+		}
+	}
+
+	// Update the indexes table
+	if err := txn.Insert("index", &IndexEntry{"acl_role", index}); err != nil {
+		return fmt.Errorf("index update failed: %v", err)
+	}
+
+	txn.Commit()
+	return nil
+}
+
+// DeleteACLRoles deletes the roles with the given names
+func (s *StateStore) DeleteACLRoles(index uint64, names []string) error {
+	txn := s.db.Txn(true)
+	defer txn.Abort()
+
+	// Delete the role
+	for _, name := range names {
+		if _, err := txn.DeleteAll("acl_role", "id", name); err != nil {
+			return fmt.Errorf("deleting acl role failed: %v", err)
+		}
+	}
+	if err := txn.Insert("index", &IndexEntry{"acl_role", index}); err != nil {
+		return fmt.Errorf("index update failed: %v", err)
+	}
+	txn.Commit()
+	return nil
+}
+
+// ACLRoleByName is used to lookup a role by name
+func (s *StateStore) ACLRoleByName(ws memdb.WatchSet, name string) (*structs.ACLRole, error) {
+	txn := s.db.Txn(false)
+
+	watchCh, existing, err := txn.FirstWatch("acl_role", "id", name)
+	if err != nil {
+		return nil, fmt.Errorf("acl role lookup failed: %v", err)
+	}
+	ws.Add(watchCh)
+
+	if existing != nil {
+		return existing.(*structs.ACLRole), nil
+	}
+	return nil, nil
+}
+
+// ACLRoleByNamePrefix is used to lookup roles by prefix
+func (s *StateStore) ACLRoleByNamePrefix(ws memdb.WatchSet, prefix string) (memdb.ResultIterator, error) {
+	txn := s.db.Txn(false)
+
+	iter, err := txn.Get("acl_role", "id_prefix", prefix)
+	if err != nil {
+		return nil, fmt.Errorf("acl role lookup failed: %v", err)
+	}
+	ws.Add(iter.WatchCh())
+
+	return iter, nil
+}
+
+// ACLRoles returns an iterator over all the acl roles
+func (s *StateStore) ACLRoles(ws memdb.WatchSet) (memdb.ResultIterator, error) {
+	txn := s.db.Txn(false)
+
+	// Walk the entire table
+	iter, err := txn.Get("acl_role", "id")
+	if err != nil {
+		return nil, err
+	}
+	ws.Add(iter.WatchCh())
+	return iter, nil
+}
+
+// UpsertACLAuthMethods is used to create or update a set of ACL auth methods
+func (s *StateStore) UpsertACLAuthMethods(index uint64, methods []*structs.ACLAuthMethod) error {
+	txn := s.db.Txn(true)
+	defer txn.Abort()
+
+	for _, method := range methods {
+		// Ensure the auth method hash is non-nil. This should be done outside the state store
+		// for performance reasons, but we check here for defense in depth.
+		if len(method.Hash) == 0 {
+			method.SetHash()
+		}
+
+		// Check if the auth method already exists
+		existing, err := txn.First("acl_auth_method", "id", method.Name)
+		if err != nil {
+			return fmt.Errorf("auth method lookup failed: %v", err)
+		}
+
+		// Update all the indexes
+		if existing != nil {
+			method.CreateIndex = existing.(*structs.ACLAuthMethod).CreateIndex
+			method.ModifyIndex = index
+		} else {
+			method.CreateIndex = index
+			method.ModifyIndex = index
+		}
+
+		// Update the auth method
+		if err := txn.Insert("acl_auth_method", method); err != nil {
+			return fmt.Errorf("upserting auth method failed: %v", err)
+		}
+	}
+
+	// Update the indexes table
+	if err := txn.Insert("index", &IndexEntry{"acl_auth_method", index}); err != nil {
+		return fmt.Errorf("index update failed: %v", err)
+	}
+
+	txn.Commit()
+	return nil
+}
+
+// DeleteACLAuthMethods deletes the auth methods with the given names along
+// with their binding rules
+func (s *StateStore) DeleteACLAuthMethods(index uint64, names []string) error {
+	txn := s.db.Txn(true)
+	defer txn.Abort()
+
+	// Delete the auth method and its binding rules
+	rulesDeleted := false
+	for _, name := range names {
+		if _, err := txn.DeleteAll("acl_auth_method", "id", name); err != nil {
+			return fmt.Errorf("deleting acl auth method failed: %v", err)
+		}
+		num, err := txn.DeleteAll("acl_binding_rule", "auth_method", name)
+		if err != nil {
+			return fmt.Errorf("deleting acl binding rules failed: %v", err)
+		}
+		if num > 0 {
+			rulesDeleted = true
+		}
+	}
+	if err := txn.Insert("index", &IndexEntry{"acl_auth_method", index}); err != nil {
+		return fmt.Errorf("index update failed: %v", err)
+	}
+	if rulesDeleted {
+		if err := txn.Insert("index", &IndexEntry{"acl_binding_rule", index}); err != nil {
+			return fmt.Errorf("index update failed: %v", err)
+		}
+	}
+	txn.Commit()
+	return nil
+}
+
+// ACLAuthMethodByName is used to lookup an auth method by name
+func (s *StateStore) ACLAuthMethodByName(ws memdb.WatchSet, name string) (*structs.ACLAuthMethod, error) {
+	txn := s.db.Txn(false)
+
+	watchCh, existing, err := txn.FirstWatch("acl_auth_method", "id", name)
+	if err != nil {
+		return nil, fmt.Errorf("acl auth method lookup failed: %v", err)
+	}
+	ws.Add(watchCh)
+
+	if existing != nil {
+		return existing.(*structs.ACLAuthMethod), nil
+	}
+	return nil, nil
+}
+
+// ACLAuthMethods returns an iterator over all the acl auth methods
+func (s *StateStore) ACLAuthMethods(ws memdb.WatchSet) (memdb.ResultIterator, error) {
+	txn := s.db.Txn(false)
+
+	// Walk the entire table
+	iter, err := txn.Get("acl_auth_method", "id")
+	if err != nil {
+		return nil, err
+	}
+	ws.Add(iter.WatchCh())
+	return iter, nil
+}
+
+// UpsertACLBindingRules is used to create or update a set of ACL binding rules
+func (s *StateStore) UpsertACLBindingRules(index uint64, rules []*structs.ACLBindingRule) error {
+	txn := s.db.Txn(true)
+	defer txn.Abort()
+
+	for _, rule := range rules {
+		// Ensure the binding rule hash is non-nil. This should be done outside the state store
+		// for performance reasons, but we check here for defense in depth.
+		if len(rule.Hash) == 0 {
+			rule.SetHash()
+		}
+
+		// Check if the binding rule already exists
+		existing, err := txn.First("acl_binding_rule", "id", rule.ID)
+		if err != nil {
+			return fmt.Errorf("binding rule lookup failed: %v", err)
+		}
+
+		// Update all the indexes
+		if existing != nil {
+			rule.CreateIndex = existing.(*structs.ACLBindingRule).CreateIndex
+			rule.ModifyIndex = index
+		} else {
+			rule.CreateIndex = index
+			rule.ModifyIndex = index
+		}
+
+		// Update the binding rule
+		if err := txn.Insert("acl_binding_rule", rule); err != nil {
+			return fmt.Errorf("upserting binding rule failed: %v", err)
+		}
+	}
+
+	// Update the indexes table
+	if err := txn.Insert("index", &IndexEntry{"acl_binding_rule", index}); err != nil {
+		return fmt.Errorf("index update failed: %v", err)
+	}
+
+	txn.Commit()
+	return nil
+}
+
+// DeleteACLBindingRules deletes the binding rules with the given IDs
+func (s *StateStore) DeleteACLBindingRules(index uint64, ids []string) error {
+	txn := s.db.Txn(true)
+	defer txn.Abort()
+
+	// Delete the binding rule
+	for _, id := range ids {
+		if _, err := txn.DeleteAll("acl_binding_rule", "id", id); err != nil {
+			return fmt.Errorf("deleting acl binding rule failed: %v", err)
+		}
+	}
+	if err := txn.Insert("index", &IndexEntry{"acl_binding_rule", index}); err != nil {
+		return fmt.Errorf("index update failed: %v", err)
+	}
+	txn.Commit()
+	return nil
+}
+
+// ACLBindingRuleByID is used to lookup a binding rule by ID
+func (s *StateStore) ACLBindingRuleByID(ws memdb.WatchSet, id string) (*structs.ACLBindingRule, error) {
+	txn := s.db.Txn(false)
+
+	watchCh, existing, err := txn.FirstWatch("acl_binding_rule", "id", id)
+	if err != nil {
+		return nil, fmt.Errorf("acl binding rule lookup failed: %v", err)
+	}
+	ws.Add(watchCh)
+
+	if existing != nil {
+		return existing.(*structs.ACLBindingRule), nil
+	}
+	return nil, nil
+}
+
+// ACLBindingRulesByAuthMethod is used to lookup the binding rules of an auth
+// method
+func (s *StateStore) ACLBindingRulesByAuthMethod(ws memdb.WatchSet, authMethod string) (memdb.ResultIterator, error) {
+	txn := s.db.Txn(false)
+
+	iter, err := txn.Get("acl_binding_rule", "auth_method", authMethod)
+	if err != nil {
+		return nil, fmt.Errorf("acl binding rule lookup failed: %v", err)
+	}
+	ws.Add(iter.WatchCh())
+	return iter, nil
+}
+
+// ACLBindingRules returns an iterator over all the acl binding rules
+func (s *StateStore) ACLBindingRules(ws memdb.WatchSet) (memdb.ResultIterator, error) {
+	txn := s.db.Txn(false)
+
+	// Walk the entire table
+	iter, err := txn.Get("acl_binding_rule", "id")
+	if err != nil {
+		return nil, err
+	}
+	ws.Add(iter.WatchCh())
+	return iter, nil
+}
+
 // UpsertACLTokens is used to create or update a set of ACL tokens
 func (s *StateStore) UpsertACLTokens(index uint64, tokens []*structs.ACLToken) error {
 	txn := s.db.Txn(true)
@@ -4089,6 +4879,249 @@ func (s *StateStore) BootstrapACLTokens(index, resetIndex uint64, token *structs
 	return nil
 }
 
+// UpsertRootKey is used to create or update a root key. Upserting an active
+// key marks the previously active keys as inactive.
+func (s *StateStore) UpsertRootKey(index uint64, key *structs.RootKey) error {
+	txn := s.db.Txn(true)
+	defer txn.Abort()
+
+	existing, err := txn.First("root_keys", "id", key.KeyID)
+	if err != nil {
+		return fmt.Errorf("root key lookup failed: %v", err)
+	}
+	if existing != nil {
+		key.CreateIndex = existing.(*structs.RootKey).CreateIndex
+	} else {
+		key.CreateIndex = index
+	}
+	key.ModifyIndex = index
+
+	// Deactivate the other active keys
+	if key.IsActive() {
+		iter, err := txn.Get("root_keys", "id")
+		if err != nil {
+			return fmt.Errorf("root key lookup failed: %v", err)
+		}
+
+		var deactivated []*structs.RootKey
+		for raw := iter.Next(); raw != nil; raw = iter.Next() {
+			other := raw.(*structs.RootKey)
+			if other.KeyID == key.KeyID || !other.IsActive() {
+				continue
+			}
+			other = other.Copy()
+			other.State = structs.RootKeyStateInactive
+			other.ModifyIndex = index
+			deactivated = append(deactivated, other)
+		}
+		for _, other := range deactivated {
+			if err := txn.Insert("root_keys", other); err != nil {
+				return fmt.Errorf("root key update failed: %v", err)
+			}
+		}
+	}
+
+	if err := txn.Insert("root_keys", key); err != nil {
+		return fmt.Errorf("root key insert failed: %v", err)
+	}
+	if err := txn.Insert("index", &IndexEntry{"root_keys", index}); err != nil {
+		return fmt.Errorf("index update failed: %v", err)
+	}
+
+	txn.Commit()
+	return nil
+}
+
+// DeleteRootKeys is used to delete a set of root keys
+func (s *StateStore) DeleteRootKeys(index uint64, keyIDs []string) error {
+	txn := s.db.Txn(true)
+	defer txn.Abort()
+
+	for _, id := range keyIDs {
+		if _, err := txn.DeleteAll("root_keys", "id", id); err != nil {
+			return fmt.Errorf("deleting root key failed: %v", err)
+		}
+	}
+	if err := txn.Insert("index", &IndexEntry{"root_keys", index}); err != nil {
+		return fmt.Errorf("index update failed: %v", err)
+	}
+
+	txn.Commit()
+	return nil
+}
+
+// RootKeyByID is used to lookup a root key by its ID
+func (s *StateStore) RootKeyByID(ws memdb.WatchSet, id string) (*structs.RootKey, error) {
+	txn := s.db.Txn(false)
+
+	watchCh, existing, err := txn.FirstWatch("root_keys", "id", id)
+	if err != nil {
+		return nil, fmt.Errorf("root key lookup failed: %v", err)
+	}
+	ws.Add(watchCh)
+
+	if existing != nil {
+		return existing.(*structs.RootKey), nil
+	}
+	return nil, nil
+}
+
+// ActiveRootKey returns the active root key, or nil if there is none
+func (s *StateStore) ActiveRootKey(ws memdb.WatchSet) (*structs.RootKey, error) {
+	iter, err := s.RootKeys(ws)
+	if err != nil {
+		return nil, err
+	}
+
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		key := raw.(*structs.RootKey)
+		if key.IsActive() {
+			return key, nil
+		}
+	}
+	return nil, nil
+}
+
+// RootKeys returns an iterator over all the root keys
+func (s *StateStore) RootKeys(ws memdb.WatchSet) (memdb.ResultIterator, error) {
+	txn := s.db.Txn(false)
+
+	// Walk the entire table
+	iter, err := txn.Get("root_keys", "id")
+	if err != nil {
+		return nil, err
+	}
+	ws.Add(iter.WatchCh())
+	return iter, nil
+}
+
+// VarSet is used to upsert or delete a variable. Check-and-set operations
+// that don't match the modify index of the stored variable are not applied
+// and return the stored variable as a conflict.
+func (s *StateStore) VarSet(index uint64, req *structs.VarApplyStateRequest) (*structs.VarApplyStateResponse, error) {
+	txn := s.db.Txn(true)
+	defer txn.Abort()
+
+	v := req.Var
+	existingRaw, err := txn.First("variables", "id", v.Namespace, v.Path)
+	if err != nil {
+		return nil, fmt.Errorf("variable lookup failed: %v", err)
+	}
+	var existing *structs.VariableEncrypted
+	if existingRaw != nil {
+		existing = existingRaw.(*structs.VariableEncrypted)
+	}
+
+	// Check the modify index of check-and-set operations. An index of zero
+	// requires the variable to not exist.
+	switch req.Op {
+	case structs.VarOpCAS, structs.VarOpDeleteCAS:
+		switch {
+		case existing == nil && v.ModifyIndex == 0:
+		case existing != nil && existing.ModifyIndex == v.ModifyIndex:
+		default:
+			resp := &structs.VarApplyStateResponse{Result: structs.VarOpResultConflict}
+			if existing != nil {
+				resp.Conflict = existing.Copy()
+			} else {
+				resp.Conflict = &structs.VariableEncrypted{}
+			}
+			return resp, nil
+		}
+	}
+
+	resp := &structs.VarApplyStateResponse{Result: structs.VarOpResultOk}
+	switch req.Op {
+	case structs.VarOpSet, structs.VarOpCAS:
+		v = v.Copy()
+		if existing != nil {
+			v.CreateIndex = existing.CreateIndex
+			v.CreateTime = existing.CreateTime
+		} else {
+			v.CreateIndex = index
+		}
+		v.ModifyIndex = index
+
+		if err := txn.Insert("variables", v); err != nil {
+			return nil, fmt.Errorf("variable insert failed: %v", err)
+		}
+		meta := v.VariableMetadata
+		resp.Meta = &meta
+	case structs.VarOpDelete, structs.VarOpDeleteCAS:
+		if existing != nil {
+			if err := txn.Delete("variables", existing); err != nil {
+				return nil, fmt.Errorf("variable delete failed: %v", err)
+			}
+		}
+	default:
+		return nil, fmt.Errorf("unknown variable operation %q", req.Op)
+	}
+
+	if err := txn.Insert("index", &IndexEntry{"variables", index}); err != nil {
+		return nil, fmt.Errorf("index update failed: %v", err)
+	}
+
+	txn.Commit()
+	return resp, nil
+}
+
+// VarGet is used to lookup a variable by its namespace and path
+func (s *StateStore) VarGet(ws memdb.WatchSet, namespace, path string) (*structs.VariableEncrypted, error) {
+	txn := s.db.Txn(false)
+
+	watchCh, existing, err := txn.FirstWatch("variables", "id", namespace, path)
+	if err != nil {
+		return nil, fmt.Errorf("variable lookup failed: %v", err)
+	}
+	ws.Add(watchCh)
+
+	if existing != nil {
+		return existing.(*structs.VariableEncrypted), nil
+	}
+	return nil, nil
+}
+
+// VariablesByNamespacePrefix is used to lookup the variables of a namespace
+// by path prefix
+func (s *StateStore) VariablesByNamespacePrefix(ws memdb.WatchSet, namespace, prefix string) (memdb.ResultIterator, error) {
+	txn := s.db.Txn(false)
+
+	iter, err := txn.Get("variables", "id_prefix", namespace, prefix)
+	if err != nil {
+		return nil, fmt.Errorf("variable lookup failed: %v", err)
+	}
+	ws.Add(iter.WatchCh())
+
+	return iter, nil
+}
+
+// VariablesByKeyID is used to lookup the variables encrypted by a root key
+func (s *StateStore) VariablesByKeyID(ws memdb.WatchSet, keyID string) (memdb.ResultIterator, error) {
+	txn := s.db.Txn(false)
+
+	iter, err := txn.Get("variables", "key_id", keyID)
+	if err != nil {
+		return nil, fmt.Errorf("variable lookup failed: %v", err)
+	}
+	ws.Add(iter.WatchCh())
+
+	return iter, nil
+}
+
+// Variables returns an iterator over all the variables
+func (s *StateStore) Variables(ws memdb.WatchSet) (memdb.ResultIterator, error) {
+	txn := s.db.Txn(false)
+
+	// Walk the entire table
+	iter, err := txn.Get("variables", "id")
+	if err != nil {
+		return nil, err
+	}
+	ws.Add(iter.WatchCh())
+
+	return iter, nil
+}
+
 // SchedulerConfig is used to get the current Scheduler configuration.
 func (s *StateStore) SchedulerConfig() (uint64, *structs.SchedulerConfiguration, error) {
 	tx := s.db.Txn(false)
@@ -4329,6 +5362,30 @@ func (r *StateRestore) PeriodicLaunchRestore(launch *structs.PeriodicLaunch) err
 	return nil
 }
 
+// DispatchBlobRestore is used to restore a dispatch blob
+func (r *StateRestore) DispatchBlobRestore(blob *structs.DispatchBlob) error {
+	if err := r.txn.Insert("dispatch_blobs", blob); err != nil {
+		return fmt.Errorf("dispatch blob insert failed: %v", err)
+	}
+	return nil
+}
+
+// DispatchBlobChunkRestore is used to restore a chunk of a dispatch blob
+func (r *StateRestore) DispatchBlobChunkRestore(chunk *structs.DispatchBlobChunk) error {
+	if err := r.txn.Insert("dispatch_blob_chunks", chunk); err != nil {
+		return fmt.Errorf("dispatch blob chunk insert failed: %v", err)
+	}
+	return nil
+}
+
+// MaintenancePlanRestore is used to restore a maintenance plan
+func (r *StateRestore) MaintenancePlanRestore(plan *structs.MaintenancePlan) error {
+	if err := r.txn.Insert("maintenance_plans", plan); err != nil {
+		return fmt.Errorf("maintenance plan insert failed: %v", err)
+	}
+	return nil
+}
+
 // JobSummaryRestore is used to restore a job summary
 func (r *StateRestore) JobSummaryRestore(jobSummary *structs.JobSummary) error {
 	if err := r.txn.Insert("job_summary", jobSummary); err != nil {
@@ -4369,6 +5426,30 @@ func (r *StateRestore) ACLPolicyRestore(policy *structs.ACLPolicy) error {
 	return nil
 }
 
+// ACLRoleRestore is used to restore an ACL role
+func (r *StateRestore) ACLRoleRestore(role *structs.ACLRole) error {
+	if err := r.txn.Insert("acl_role", role); err != nil {
+		return fmt.Errorf("inserting acl role failed: %v", err)
+	}
+	return nil
+}
+
+// ACLAuthMethodRestore is used to restore an ACL auth method
+func (r *StateRestore) ACLAuthMethodRestore(method *structs.ACLAuthMethod) error {
+	if err := r.txn.Insert("acl_auth_method", method); err != nil {
+		return fmt.Errorf("inserting acl auth method failed: %v", err)
+	}
+	return nil
+}
+
+// ACLBindingRuleRestore is used to restore an ACL binding rule
+func (r *StateRestore) ACLBindingRuleRestore(rule *structs.ACLBindingRule) error {
+	if err := r.txn.Insert("acl_binding_rule", rule); err != nil {
+		return fmt.Errorf("inserting acl binding rule failed: %v", err)
+	}
+	return nil
+}
+
 // ACLTokenRestore is used to restore an ACL token
 func (r *StateRestore) ACLTokenRestore(token *structs.ACLToken) error {
 	if err := r.txn.Insert("acl_token", token); err != nil {
@@ -4377,6 +5458,22 @@ func (r *StateRestore) ACLTokenRestore(token *structs.ACLToken) error {
 	return nil
 }
 
+// RootKeyRestore is used to restore a root key
+func (r *StateRestore) RootKeyRestore(key *structs.RootKey) error {
+	if err := r.txn.Insert("root_keys", key); err != nil {
+		return fmt.Errorf("inserting root key failed: %v", err)
+	}
+	return nil
+}
+
+// VariableRestore is used to restore a variable
+func (r *StateRestore) VariableRestore(v *structs.VariableEncrypted) error {
+	if err := r.txn.Insert("variables", v); err != nil {
+		return fmt.Errorf("inserting variable failed: %v", err)
+	}
+	return nil
+}
+
 func (r *StateRestore) SchedulerConfigRestore(schedConfig *structs.SchedulerConfiguration) error {
 	if err := r.txn.Insert("scheduler_config", schedConfig); err != nil {
 		return fmt.Errorf("inserting scheduler config failed: %s", err)
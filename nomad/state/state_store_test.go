@@ -2356,6 +2356,50 @@ func TestStateStore_RestoreJob(t *testing.T) {
 	}
 }
 
+func TestStateStore_UpsertPeriodicLaunchEvents(t *testing.T) {
+	require := require.New(t)
+	state := testStateStore(t)
+	job := mock.PeriodicJob()
+	now := time.Now().Round(time.Second)
+
+	require.NoError(state.UpsertPeriodicLaunch(1000, &structs.PeriodicLaunch{
+		ID:        job.ID,
+		Namespace: job.Namespace,
+		Launch:    now.Add(-time.Hour),
+	}))
+
+	ws := memdb.NewWatchSet()
+	_, err := state.PeriodicLaunchByID(ws, job.Namespace, job.ID)
+	require.NoError(err)
+
+	events := []*structs.PeriodicLaunchEvent{
+		{Launch: now.Add(-2 * time.Minute), Status: structs.PeriodicLaunchStatusSkipped},
+		{Launch: now.Add(-time.Minute), Status: structs.PeriodicLaunchStatusCaughtUp},
+	}
+	require.NoError(state.UpsertPeriodicLaunchEvents(1001, job.Namespace, job.ID, events))
+	require.True(watchFired(ws))
+
+	out, err := state.PeriodicLaunchByID(nil, job.Namespace, job.ID)
+	require.NoError(err)
+	require.EqualValues(1000, out.CreateIndex)
+	require.EqualValues(1001, out.ModifyIndex)
+	require.Equal(now.Add(-time.Minute), out.Launch)
+	require.Equal(events, out.History)
+
+	index, err := state.Index("periodic_launch")
+	require.NoError(err)
+	require.EqualValues(1001, index)
+
+	// Recording events for a job without a launch creates it
+	other := mock.PeriodicJob()
+	require.NoError(state.UpsertPeriodicLaunchEvents(1002, other.Namespace, other.ID, events[:1]))
+	out, err = state.PeriodicLaunchByID(nil, other.Namespace, other.ID)
+	require.NoError(err)
+	require.EqualValues(1002, out.CreateIndex)
+	require.Equal(now.Add(-2*time.Minute), out.Launch)
+	require.Len(out.History, 1)
+}
+
 func TestStateStore_UpsertPeriodicLaunch(t *testing.T) {
 	state := testStateStore(t)
 	job := mock.Job()
@@ -5850,6 +5894,127 @@ func TestStateStore_UpsertDeploymentPromotion_Unhealthy(t *testing.T) {
 	require.Contains(err.Error(), `Task group "web" has 0/2 healthy allocations`)
 }
 
+// Test promoting a deployment advances the rollout steps of its task groups
+func TestStateStore_UpsertDeploymentPromotion_Steps(t *testing.T) {
+	state := testStateStore(t)
+	require := require.New(t)
+
+	// Create a job
+	j := mock.Job()
+	require.Nil(state.UpsertJob(1, j))
+
+	// Create a deployment in its first percentage step
+	d := mock.Deployment()
+	d.JobID = j.ID
+	d.TaskGroups["web"].Steps = []*structs.UpdateStep{
+		{Percent: 50},
+		{Percent: 100},
+	}
+	d.TaskGroups["web"].HealthyAllocs = 4
+	require.Nil(state.UpsertDeployment(2, d))
+
+	// Advancing the step fails while not enough allocations are healthy
+	req := &structs.ApplyDeploymentPromoteRequest{
+		DeploymentPromoteRequest: structs.DeploymentPromoteRequest{
+			DeploymentID: d.ID,
+			Groups:       []string{"web"},
+		},
+		StatusDescription: structs.DeploymentStatusDescriptionStepAdvanced("web", 2, 2),
+	}
+	err := state.UpdateDeploymentPromotion(3, req)
+	require.NotNil(err)
+	require.Contains(err.Error(), `Task group "web" has 4/5 healthy allocations in step 1`)
+
+	d.TaskGroups["web"].HealthyAllocs = 5
+	require.Nil(state.UpsertDeployment(4, d))
+	require.Nil(state.UpdateDeploymentPromotion(5, req))
+
+	ws := memdb.NewWatchSet()
+	out, err := state.DeploymentByID(ws, d.ID)
+	require.Nil(err)
+	require.Equal(1, out.TaskGroups["web"].CurrentStep)
+	require.Equal(req.StatusDescription, out.StatusDescription)
+
+	// Advancing past the last step completes the steps
+	req.StatusDescription = structs.DeploymentStatusDescriptionStepAdvanced("web", 3, 2)
+	d.TaskGroups["web"].HealthyAllocs = 10
+	d.TaskGroups["web"].CurrentStep = 1
+	require.Nil(state.UpsertDeployment(6, d))
+	require.Nil(state.UpdateDeploymentPromotion(7, req))
+
+	out, err = state.DeploymentByID(ws, d.ID)
+	require.Nil(err)
+	require.Equal(2, out.TaskGroups["web"].CurrentStep)
+	require.Nil(out.TaskGroups["web"].Step())
+	require.Equal(`Deployment is running - task group "web" completed its 2 rollout steps`, out.StatusDescription)
+}
+
+// Test that promoting all groups doesn't advance the rollout steps of groups
+// without canaries awaiting promotion
+func TestStateStore_UpsertDeploymentPromotion_All_Steps(t *testing.T) {
+	state := testStateStore(t)
+	require := require.New(t)
+
+	// Create a job with two task groups
+	j := mock.Job()
+	tg2 := j.TaskGroups[0].Copy()
+	tg2.Name = "foo"
+	j.TaskGroups = append(j.TaskGroups, tg2)
+	require.Nil(state.UpsertJob(1, j))
+
+	// Create a deployment whose "web" group has a canary awaiting promotion
+	// and whose "foo" group is in its first percentage step
+	d := mock.Deployment()
+	d.JobID = j.ID
+	d.TaskGroups = map[string]*structs.DeploymentState{
+		"web": {
+			DesiredTotal:    10,
+			DesiredCanaries: 1,
+		},
+		"foo": {
+			DesiredTotal: 10,
+			Steps: []*structs.UpdateStep{
+				{Percent: 50},
+				{Percent: 100},
+			},
+			HealthyAllocs: 5,
+		},
+	}
+
+	c := mock.Alloc()
+	c.JobID = j.ID
+	c.DeploymentID = d.ID
+	c.DeploymentStatus = &structs.AllocDeploymentStatus{
+		Healthy: helper.BoolToPtr(true),
+	}
+	d.TaskGroups["web"].PlacedCanaries = []string{c.ID}
+	require.Nil(state.UpsertDeployment(2, d))
+	require.Nil(state.UpsertAllocs(3, []*structs.Allocation{c}))
+
+	req := &structs.ApplyDeploymentPromoteRequest{
+		DeploymentPromoteRequest: structs.DeploymentPromoteRequest{
+			DeploymentID: d.ID,
+			All:          true,
+		},
+	}
+	require.Nil(state.UpdateDeploymentPromotion(4, req))
+
+	out, err := state.DeploymentByID(nil, d.ID)
+	require.Nil(err)
+	require.True(out.TaskGroups["web"].Promoted)
+	require.False(out.TaskGroups["foo"].Promoted)
+	require.Equal(0, out.TaskGroups["foo"].CurrentStep)
+
+	// Naming the group advances its step
+	req.All = false
+	req.Groups = []string{"foo"}
+	require.Nil(state.UpdateDeploymentPromotion(5, req))
+
+	out, err = state.DeploymentByID(nil, d.ID)
+	require.Nil(err)
+	require.Equal(1, out.TaskGroups["foo"].CurrentStep)
+}
+
 // Test promoting a deployment with no canaries
 func TestStateStore_UpsertDeploymentPromotion_NoCanaries(t *testing.T) {
 	state := testStateStore(t)
@@ -6282,6 +6447,42 @@ func TestStateStore_UpsertDeploymentAllocHealth(t *testing.T) {
 	}
 }
 
+func TestStateStore_UpdateDeploymentAnalysis(t *testing.T) {
+	require := require.New(t)
+	state := testStateStore(t)
+
+	d := mock.Deployment()
+	require.NoError(state.UpsertDeployment(1, d))
+
+	// The failures of the task group are recorded
+	req := &structs.DeploymentAnalysisUpdateRequest{
+		DeploymentID: d.ID,
+		TaskGroup:    "web",
+		Failures:     2,
+	}
+	require.NoError(state.UpdateDeploymentAnalysis(2, req))
+
+	out, err := state.DeploymentByID(nil, d.ID)
+	require.NoError(err)
+	require.Equal(2, out.TaskGroups["web"].AnalysisFailures)
+	require.EqualValues(2, out.ModifyIndex)
+
+	// Unknown task groups are rejected
+	req.TaskGroup = "foo"
+	require.Error(state.UpdateDeploymentAnalysis(3, req))
+
+	// Terminal deployments are rejected
+	update := &structs.DeploymentStatusUpdateRequest{
+		DeploymentUpdate: &structs.DeploymentStatusUpdate{
+			DeploymentID: d.ID,
+			Status:       structs.DeploymentStatusFailed,
+		},
+	}
+	require.NoError(state.UpdateDeploymentStatus(4, update))
+	req.TaskGroup = "web"
+	require.Error(state.UpdateDeploymentAnalysis(5, req))
+}
+
 func TestStateStore_UpsertVaultAccessors(t *testing.T) {
 	state := testStateStore(t)
 	a := mock.VaultAccessor()
@@ -6712,6 +6913,388 @@ func TestStateStore_ACLPolicyByNamePrefix(t *testing.T) {
 	assert.Equal(t, expect, out)
 }
 
+func TestStateStore_UpsertDeleteACLRoles(t *testing.T) {
+	state := testStateStore(t)
+	role := mock.ACLRole()
+	role2 := mock.ACLRole()
+
+	ws := memdb.NewWatchSet()
+	_, err := state.ACLRoleByName(ws, role.Name)
+	require.NoError(t, err)
+
+	require.NoError(t, state.UpsertACLRoles(1000, []*structs.ACLRole{role, role2}))
+	require.True(t, watchFired(ws))
+
+	ws = memdb.NewWatchSet()
+	out, err := state.ACLRoleByName(ws, role.Name)
+	require.NoError(t, err)
+	require.Equal(t, role, out)
+	require.Equal(t, uint64(1000), out.CreateIndex)
+
+	iter, err := state.ACLRoles(ws)
+	require.NoError(t, err)
+	count := 0
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		count++
+	}
+	require.Equal(t, 2, count)
+
+	iter, err = state.ACLRoleByNamePrefix(ws, role.Name[:10])
+	require.NoError(t, err)
+	require.NotNil(t, iter.Next())
+
+	index, err := state.Index("acl_role")
+	require.NoError(t, err)
+	require.Equal(t, uint64(1000), index)
+
+	// Updating keeps the create index
+	update := mock.ACLRole()
+	update.Name = role.Name
+	require.NoError(t, state.UpsertACLRoles(1001, []*structs.ACLRole{update}))
+	require.True(t, watchFired(ws))
+	out, err = state.ACLRoleByName(nil, role.Name)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1000), out.CreateIndex)
+	require.Equal(t, uint64(1001), out.ModifyIndex)
+
+	// Delete the roles
+	require.NoError(t, state.DeleteACLRoles(1002, []string{role.Name, role2.Name}))
+	out, err = state.ACLRoleByName(nil, role.Name)
+	require.NoError(t, err)
+	require.Nil(t, out)
+
+	index, err = state.Index("acl_role")
+	require.NoError(t, err)
+	require.Equal(t, uint64(1002), index)
+}
+
+func TestStateStore_UpsertDeleteRootKeys(t *testing.T) {
+	state := testStateStore(t)
+	key1 := mock.RootKey()
+
+	ws := memdb.NewWatchSet()
+	_, err := state.RootKeyByID(ws, key1.KeyID)
+	require.NoError(t, err)
+
+	require.NoError(t, state.UpsertRootKey(1000, key1))
+	require.True(t, watchFired(ws))
+
+	out, err := state.ActiveRootKey(nil)
+	require.NoError(t, err)
+	require.Equal(t, key1, out)
+	require.Equal(t, uint64(1000), out.CreateIndex)
+
+	// Upserting a new active key deactivates the previous one
+	key2 := mock.RootKey()
+	require.NoError(t, state.UpsertRootKey(1001, key2))
+
+	out, err = state.ActiveRootKey(nil)
+	require.NoError(t, err)
+	require.Equal(t, key2.KeyID, out.KeyID)
+
+	out, err = state.RootKeyByID(nil, key1.KeyID)
+	require.NoError(t, err)
+	require.False(t, out.IsActive())
+	require.Equal(t, uint64(1000), out.CreateIndex)
+	require.Equal(t, uint64(1001), out.ModifyIndex)
+
+	// The stored key was not modified in place
+	require.True(t, key1.IsActive())
+
+	iter, err := state.RootKeys(nil)
+	require.NoError(t, err)
+	count := 0
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		count++
+	}
+	require.Equal(t, 2, count)
+
+	// Delete the inactive key
+	require.NoError(t, state.DeleteRootKeys(1002, []string{key1.KeyID}))
+	out, err = state.RootKeyByID(nil, key1.KeyID)
+	require.NoError(t, err)
+	require.Nil(t, out)
+
+	index, err := state.Index("root_keys")
+	require.NoError(t, err)
+	require.Equal(t, uint64(1002), index)
+}
+
+func TestStateStore_UpsertDeleteDispatchBlobs(t *testing.T) {
+	state := testStateStore(t)
+	payload := make([]byte, structs.DispatchBlobChunkSize+1)
+	blob, chunks := structs.NewDispatchBlob(payload)
+	require.Len(t, chunks, 2)
+
+	ws := memdb.NewWatchSet()
+	_, err := state.DispatchBlobByID(ws, blob.ID)
+	require.NoError(t, err)
+
+	// The blob can't be upserted until all its chunks are written
+	require.NoError(t, state.UpsertDispatchBlobChunk(1000, chunks[0]))
+	require.Error(t, state.UpsertDispatchBlob(1001, blob.Copy()))
+	require.False(t, watchFired(ws))
+
+	require.NoError(t, state.UpsertDispatchBlobChunk(1002, chunks[1]))
+	require.NoError(t, state.UpsertDispatchBlob(1003, blob.Copy()))
+	require.True(t, watchFired(ws))
+
+	out, err := state.DispatchBlobByID(nil, blob.ID)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1003), out.CreateIndex)
+	require.Equal(t, uint64(1003), out.ModifyIndex)
+
+	// Upserting the blob again only refreshes its modify index
+	require.NoError(t, state.UpsertDispatchBlob(1004, blob.Copy()))
+	out, err = state.DispatchBlobByID(nil, blob.ID)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1003), out.CreateIndex)
+	require.Equal(t, uint64(1004), out.ModifyIndex)
+
+	chunk, err := state.DispatchBlobChunkByID(nil, blob.ID, 1)
+	require.NoError(t, err)
+	require.Equal(t, chunks[1].Data, chunk.Data)
+	require.Equal(t, uint64(1002), chunk.CreateIndex)
+
+	// Jobs can be looked up by the blob holding their payload
+	job := mock.BatchJob()
+	job.PayloadBlob = blob.ID
+	require.NoError(t, state.UpsertJob(1005, job))
+	require.NoError(t, state.UpsertJob(1006, mock.BatchJob()))
+
+	iter, err := state.JobsByPayloadBlob(nil, blob.ID)
+	require.NoError(t, err)
+	raw := iter.Next()
+	require.NotNil(t, raw)
+	require.Equal(t, job.ID, raw.(*structs.Job).ID)
+	require.Nil(t, iter.Next())
+
+	// Delete the blob along with its chunks
+	require.NoError(t, state.DeleteDispatchBlobs(1007, []string{blob.ID}))
+	out, err = state.DispatchBlobByID(nil, blob.ID)
+	require.NoError(t, err)
+	require.Nil(t, out)
+
+	iter, err = state.DispatchBlobChunks(nil)
+	require.NoError(t, err)
+	require.Nil(t, iter.Next())
+
+	index, err := state.Index("dispatch_blobs")
+	require.NoError(t, err)
+	require.Equal(t, uint64(1007), index)
+}
+
+func TestStateStore_VarSet(t *testing.T) {
+	state := testStateStore(t)
+	v := mock.VariableEncrypted()
+
+	ws := memdb.NewWatchSet()
+	_, err := state.VarGet(ws, v.Namespace, v.Path)
+	require.NoError(t, err)
+
+	resp, err := state.VarSet(1000, &structs.VarApplyStateRequest{Op: structs.VarOpSet, Var: v})
+	require.NoError(t, err)
+	require.False(t, resp.IsConflict())
+	require.Equal(t, uint64(1000), resp.Meta.CreateIndex)
+	require.Equal(t, uint64(1000), resp.Meta.ModifyIndex)
+	require.True(t, watchFired(ws))
+
+	out, err := state.VarGet(nil, v.Namespace, v.Path)
+	require.NoError(t, err)
+	require.Equal(t, v.Data, out.Data)
+	require.Equal(t, uint64(1000), out.ModifyIndex)
+
+	// Creating with check-and-set conflicts with the existing variable
+	update := v.Copy()
+	update.Data = []byte("updated")
+	resp, err = state.VarSet(1001, &structs.VarApplyStateRequest{Op: structs.VarOpCAS, Var: update})
+	require.NoError(t, err)
+	require.True(t, resp.IsConflict())
+	require.Equal(t, uint64(1000), resp.Conflict.ModifyIndex)
+	require.Equal(t, v.Data, resp.Conflict.Data)
+
+	// Updating with the current index succeeds and keeps the create index
+	update.ModifyIndex = 1000
+	resp, err = state.VarSet(1002, &structs.VarApplyStateRequest{Op: structs.VarOpCAS, Var: update})
+	require.NoError(t, err)
+	require.False(t, resp.IsConflict())
+
+	out, err = state.VarGet(nil, v.Namespace, v.Path)
+	require.NoError(t, err)
+	require.Equal(t, []byte("updated"), out.Data)
+	require.Equal(t, uint64(1000), out.CreateIndex)
+	require.Equal(t, uint64(1002), out.ModifyIndex)
+
+	// Deleting with a stale index conflicts
+	del := &structs.VariableEncrypted{VariableMetadata: v.VariableMetadata}
+	del.ModifyIndex = 1000
+	resp, err = state.VarSet(1003, &structs.VarApplyStateRequest{Op: structs.VarOpDeleteCAS, Var: del})
+	require.NoError(t, err)
+	require.True(t, resp.IsConflict())
+
+	// Lookup by prefix and key
+	v2 := mock.VariableEncrypted()
+	v2.Path = "other/path"
+	_, err = state.VarSet(1004, &structs.VarApplyStateRequest{Op: structs.VarOpSet, Var: v2})
+	require.NoError(t, err)
+
+	iter, err := state.VariablesByNamespacePrefix(nil, structs.DefaultNamespace, "nomad/jobs")
+	require.NoError(t, err)
+	var paths []string
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		paths = append(paths, raw.(*structs.VariableEncrypted).Path)
+	}
+	require.Equal(t, []string{v.Path}, paths)
+
+	iter, err = state.VariablesByKeyID(nil, v2.KeyID)
+	require.NoError(t, err)
+	require.NotNil(t, iter.Next())
+	require.Nil(t, iter.Next())
+
+	// Deleting with the current index succeeds
+	del.ModifyIndex = 1002
+	resp, err = state.VarSet(1005, &structs.VarApplyStateRequest{Op: structs.VarOpDeleteCAS, Var: del})
+	require.NoError(t, err)
+	require.False(t, resp.IsConflict())
+
+	out, err = state.VarGet(nil, v.Namespace, v.Path)
+	require.NoError(t, err)
+	require.Nil(t, out)
+
+	// Creating a missing variable with check-and-set requires a zero index
+	resp, err = state.VarSet(1006, &structs.VarApplyStateRequest{Op: structs.VarOpCAS, Var: update})
+	require.NoError(t, err)
+	require.True(t, resp.IsConflict())
+	require.Zero(t, resp.Conflict.ModifyIndex)
+
+	update.ModifyIndex = 0
+	resp, err = state.VarSet(1007, &structs.VarApplyStateRequest{Op: structs.VarOpCAS, Var: update})
+	require.NoError(t, err)
+	require.False(t, resp.IsConflict())
+	require.Equal(t, uint64(1007), resp.Meta.CreateIndex)
+
+	index, err := state.Index("variables")
+	require.NoError(t, err)
+	require.Equal(t, uint64(1007), index)
+}
+
+func TestStateStore_UpsertDeleteACLAuthMethods(t *testing.T) {
+	state := testStateStore(t)
+	method := mock.ACLAuthMethod()
+	method2 := mock.ACLAuthMethod()
+	rule := mock.ACLBindingRule()
+	rule.AuthMethod = method.Name
+	rule2 := mock.ACLBindingRule()
+	rule2.AuthMethod = method2.Name
+
+	ws := memdb.NewWatchSet()
+	_, err := state.ACLAuthMethodByName(ws, method.Name)
+	require.NoError(t, err)
+
+	require.NoError(t, state.UpsertACLAuthMethods(1000, []*structs.ACLAuthMethod{method, method2}))
+	require.True(t, watchFired(ws))
+	require.NoError(t, state.UpsertACLBindingRules(1001, []*structs.ACLBindingRule{rule, rule2}))
+
+	ws = memdb.NewWatchSet()
+	out, err := state.ACLAuthMethodByName(ws, method.Name)
+	require.NoError(t, err)
+	require.Equal(t, method, out)
+	require.Equal(t, uint64(1000), out.CreateIndex)
+
+	iter, err := state.ACLAuthMethods(ws)
+	require.NoError(t, err)
+	count := 0
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		count++
+	}
+	require.Equal(t, 2, count)
+
+	// Updating keeps the create index
+	update := mock.ACLAuthMethod()
+	update.Name = method.Name
+	require.NoError(t, state.UpsertACLAuthMethods(1002, []*structs.ACLAuthMethod{update}))
+	require.True(t, watchFired(ws))
+	out, err = state.ACLAuthMethodByName(nil, method.Name)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1000), out.CreateIndex)
+	require.Equal(t, uint64(1002), out.ModifyIndex)
+
+	// Deleting an auth method deletes its binding rules
+	require.NoError(t, state.DeleteACLAuthMethods(1003, []string{method.Name}))
+	out, err = state.ACLAuthMethodByName(nil, method.Name)
+	require.NoError(t, err)
+	require.Nil(t, out)
+
+	outRule, err := state.ACLBindingRuleByID(nil, rule.ID)
+	require.NoError(t, err)
+	require.Nil(t, outRule)
+	outRule, err = state.ACLBindingRuleByID(nil, rule2.ID)
+	require.NoError(t, err)
+	require.Equal(t, rule2, outRule)
+
+	for _, table := range []string{"acl_auth_method", "acl_binding_rule"} {
+		index, err := state.Index(table)
+		require.NoError(t, err)
+		require.Equal(t, uint64(1003), index)
+	}
+}
+
+func TestStateStore_UpsertDeleteACLBindingRules(t *testing.T) {
+	state := testStateStore(t)
+	rule := mock.ACLBindingRule()
+	rule2 := mock.ACLBindingRule()
+	rule2.AuthMethod = "other"
+
+	ws := memdb.NewWatchSet()
+	_, err := state.ACLBindingRuleByID(ws, rule.ID)
+	require.NoError(t, err)
+
+	require.NoError(t, state.UpsertACLBindingRules(1000, []*structs.ACLBindingRule{rule, rule2}))
+	require.True(t, watchFired(ws))
+
+	ws = memdb.NewWatchSet()
+	out, err := state.ACLBindingRuleByID(ws, rule.ID)
+	require.NoError(t, err)
+	require.Equal(t, rule, out)
+	require.Equal(t, uint64(1000), out.CreateIndex)
+
+	iter, err := state.ACLBindingRules(ws)
+	require.NoError(t, err)
+	count := 0
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		count++
+	}
+	require.Equal(t, 2, count)
+
+	iter, err = state.ACLBindingRulesByAuthMethod(ws, "other")
+	require.NoError(t, err)
+	raw := iter.Next()
+	require.NotNil(t, raw)
+	require.Equal(t, rule2.ID, raw.(*structs.ACLBindingRule).ID)
+	require.Nil(t, iter.Next())
+
+	// Updating keeps the create index
+	update := *rule
+	update.BindName = "updated"
+	require.NoError(t, state.UpsertACLBindingRules(1001, []*structs.ACLBindingRule{&update}))
+	require.True(t, watchFired(ws))
+	out, err = state.ACLBindingRuleByID(nil, rule.ID)
+	require.NoError(t, err)
+	require.Equal(t, "updated", out.BindName)
+	require.Equal(t, uint64(1000), out.CreateIndex)
+	require.Equal(t, uint64(1001), out.ModifyIndex)
+
+	// Delete the binding rules
+	require.NoError(t, state.DeleteACLBindingRules(1002, []string{rule.ID, rule2.ID}))
+	out, err = state.ACLBindingRuleByID(nil, rule.ID)
+	require.NoError(t, err)
+	require.Nil(t, out)
+
+	index, err := state.Index("acl_binding_rule")
+	require.NoError(t, err)
+	require.Equal(t, uint64(1002), index)
+}
+
 func TestStateStore_BootstrapACLTokens(t *testing.T) {
 	state := testStateStore(t)
 	tk1 := mock.ACLToken()
@@ -7281,3 +7864,183 @@ func (n AllocIDSort) Less(i, j int) bool {
 func (n AllocIDSort) Swap(i, j int) {
 	n[i], n[j] = n[j], n[i]
 }
+
+func TestStateStore_ReleaseDispatchedJobs(t *testing.T) {
+	state := testStateStore(t)
+	parent := mock.BatchJob()
+	parent.ParameterizedJob = &structs.ParameterizedJobConfig{MaxConcurrent: 1}
+	require.NoError(t, state.UpsertJob(1000, parent))
+
+	// Queue two dispatched jobs
+	child1 := mock.BatchJob()
+	child1.ParentID = parent.ID
+	child1.Dispatched = true
+	child1.DispatchQueued = true
+	require.NoError(t, state.UpsertJob(1001, child1))
+
+	child2 := child1.Copy()
+	child2.ID = uuid.Generate()
+	require.NoError(t, state.UpsertJob(1002, child2))
+
+	out, err := state.JobByID(nil, child1.Namespace, child1.ID)
+	require.NoError(t, err)
+	require.Equal(t, structs.JobStatusQueued, out.Status)
+
+	summary, err := state.JobSummaryByID(nil, parent.Namespace, parent.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), summary.Children.Queued)
+	require.Equal(t, int64(0), summary.Children.Pending)
+
+	ws := memdb.NewWatchSet()
+	iter, err := state.JobsByDispatchQueued(ws)
+	require.NoError(t, err)
+	queued := 0
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		queued++
+	}
+	require.Equal(t, 2, queued)
+
+	// Release the first job. Only the evaluation of the released job is
+	// created.
+	eval1 := mock.Eval()
+	eval1.JobID = child1.ID
+	eval2 := mock.Eval()
+	eval2.JobID = child2.ID
+	jobs := []structs.NamespacedID{*child1.NamespacedID()}
+	require.NoError(t, state.ReleaseDispatchedJobs(1003, jobs, []*structs.Evaluation{eval1, eval2}))
+	require.True(t, watchFired(ws))
+
+	out, err = state.JobByID(nil, child1.Namespace, child1.ID)
+	require.NoError(t, err)
+	require.False(t, out.DispatchQueued)
+	require.Equal(t, structs.JobStatusPending, out.Status)
+	require.Equal(t, uint64(1003), out.ModifyIndex)
+
+	evalOut, err := state.EvalByID(nil, eval1.ID)
+	require.NoError(t, err)
+	require.NotNil(t, evalOut)
+	evalOut, err = state.EvalByID(nil, eval2.ID)
+	require.NoError(t, err)
+	require.Nil(t, evalOut)
+
+	summary, err = state.JobSummaryByID(nil, parent.Namespace, parent.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), summary.Children.Queued)
+	require.Equal(t, int64(1), summary.Children.Pending)
+
+	// Releasing a job that is no longer queued is a no-op
+	eval3 := mock.Eval()
+	eval3.JobID = child1.ID
+	require.NoError(t, state.ReleaseDispatchedJobs(1004, jobs, []*structs.Evaluation{eval3}))
+	evalOut, err = state.EvalByID(nil, eval3.ID)
+	require.NoError(t, err)
+	require.Nil(t, evalOut)
+
+	// Stopping a queued job marks it dead
+	stopped := child2.Copy()
+	stopped.Stop = true
+	require.NoError(t, state.UpsertJob(1005, stopped))
+
+	out, err = state.JobByID(nil, child2.Namespace, child2.ID)
+	require.NoError(t, err)
+	require.Equal(t, structs.JobStatusDead, out.Status)
+
+	summary, err = state.JobSummaryByID(nil, parent.Namespace, parent.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(0), summary.Children.Queued)
+	require.Equal(t, int64(1), summary.Children.Dead)
+
+	// Releasing a stopped job is a no-op so it isn't counted as pending
+	eval4 := mock.Eval()
+	eval4.JobID = child2.ID
+	jobs = []structs.NamespacedID{*child2.NamespacedID()}
+	require.NoError(t, state.ReleaseDispatchedJobs(1006, jobs, []*structs.Evaluation{eval4}))
+	evalOut, err = state.EvalByID(nil, eval4.ID)
+	require.NoError(t, err)
+	require.Nil(t, evalOut)
+
+	out, err = state.JobByID(nil, child2.Namespace, child2.ID)
+	require.NoError(t, err)
+	require.Equal(t, structs.JobStatusDead, out.Status)
+
+	summary, err = state.JobSummaryByID(nil, parent.Namespace, parent.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), summary.Children.Pending)
+	require.Equal(t, int64(1), summary.Children.Dead)
+}
+
+func TestStateStore_UpsertMaintenancePlan(t *testing.T) {
+	state := testStateStore(t)
+	node := mock.Node()
+	require.NoError(t, state.UpsertNode(1000, node))
+
+	plan := mock.MaintenancePlan()
+	plan.Nodes = []*structs.MaintenancePlanNode{
+		{NodeID: node.ID, Name: node.Name, Status: structs.MaintenanceNodeStatusPending},
+	}
+
+	// Create a watchset so we can test that upsert fires the watch
+	ws := memdb.NewWatchSet()
+	_, err := state.MaintenancePlanByID(ws, plan.ID)
+	require.NoError(t, err)
+
+	require.NoError(t, state.UpsertMaintenancePlan(1001, plan, nil, nil))
+	require.True(t, watchFired(ws))
+
+	out, err := state.MaintenancePlanByID(nil, plan.ID)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1001), out.CreateIndex)
+	require.Equal(t, uint64(1001), out.ModifyIndex)
+
+	// Start draining the node along with the plan update
+	updated := out.Copy()
+	updated.Status = structs.MaintenancePlanStatusRunning
+	updated.Nodes[0].Status = structs.MaintenanceNodeStatusDraining
+	drains := map[string]*structs.DrainUpdate{
+		node.ID: {DrainStrategy: &structs.DrainStrategy{DrainSpec: structs.DrainSpec{Deadline: time.Hour}}},
+	}
+	events := map[string]*structs.NodeEvent{
+		node.ID: structs.NewNodeEvent().SetMessage("maintenance"),
+	}
+	require.NoError(t, state.UpsertMaintenancePlan(1002, updated, drains, events))
+
+	out, err = state.MaintenancePlanByID(nil, plan.ID)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1001), out.CreateIndex)
+	require.Equal(t, uint64(1002), out.ModifyIndex)
+	require.Equal(t, structs.MaintenanceNodeStatusDraining, out.Nodes[0].Status)
+
+	nodeOut, err := state.NodeByID(nil, node.ID)
+	require.NoError(t, err)
+	require.NotNil(t, nodeOut.DrainStrategy)
+	require.Equal(t, structs.NodeSchedulingIneligible, nodeOut.SchedulingEligibility)
+	require.Equal(t, "maintenance", nodeOut.Events[len(nodeOut.Events)-1].Message)
+	require.Equal(t, uint64(1002), nodeOut.ModifyIndex)
+
+	// Updates computed from a stale version of the plan are rejected
+	stale := updated.Copy()
+	stale.ModifyIndex = 1001
+	stale.Status = structs.MaintenancePlanStatusComplete
+	err = state.UpsertMaintenancePlan(1003, stale, nil, nil)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "was modified")
+
+	// New plans can't select the nodes of active plans
+	other := mock.MaintenancePlan()
+	other.Nodes = []*structs.MaintenancePlanNode{
+		{NodeID: node.ID, Name: node.Name, Status: structs.MaintenanceNodeStatusPending},
+	}
+	err = state.UpsertMaintenancePlan(1003, other, nil, nil)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "is part of active maintenance plan")
+
+	// Lookup by prefix
+	iter, err := state.MaintenancePlansByIDPrefix(nil, plan.ID[:4])
+	require.NoError(t, err)
+	require.Equal(t, plan.ID, iter.Next().(*structs.MaintenancePlan).ID)
+	require.Nil(t, iter.Next())
+
+	index, err := state.Index("maintenance_plans")
+	require.NoError(t, err)
+	require.Equal(t, uint64(1002), index)
+}
@@ -0,0 +1,187 @@
+package config
+
+import (
+	"time"
+
+	"github.com/hashicorp/nomad/helper"
+)
+
+const (
+	// AuditSinkTypeFile is the only supported audit sink type and writes
+	// events to a file on disk.
+	AuditSinkTypeFile = "file"
+
+	// AuditSinkFormatJSON is the only supported audit sink format and writes
+	// one JSON encoded event per line.
+	AuditSinkFormatJSON = "json"
+
+	// AuditDeliveryEnforced fails the request if the audit event can not be
+	// written to the sink.
+	AuditDeliveryEnforced = "enforced"
+
+	// AuditDeliveryBestEffort logs write failures but lets the request
+	// continue.
+	AuditDeliveryBestEffort = "best-effort"
+
+	// AuditFilterTypeHTTPEvent filters HTTP request events.
+	AuditFilterTypeHTTPEvent = "HTTPEvent"
+)
+
+// AuditConfig is the configuration specific to audit logging of HTTP requests
+type AuditConfig struct {
+	// Enabled controls whether audit logging is enabled.
+	Enabled *bool `hcl:"enabled"`
+
+	// Sinks are the set of destinations audit events are written to.
+	Sinks []*AuditSink `hcl:"sink"`
+
+	// Filters are used to exclude matching events from the audit log.
+	Filters []*AuditFilter `hcl:"filter"`
+
+	// ExtraKeysHCL is used by hcl to surface unexpected keys
+	ExtraKeysHCL []string `hcl:",unusedKeys" json:"-"`
+}
+
+// AuditSink is the configuration of a single audit log destination.
+type AuditSink struct {
+	// Name is a unique name for the sink.
+	Name string `hcl:",key"`
+
+	// Type is the type of the sink. Only "file" is supported.
+	Type string `hcl:"type"`
+
+	// DeliveryGuarantee is either "enforced" or "best-effort". Enforced
+	// sinks fail the request if the event can not be written.
+	DeliveryGuarantee string `hcl:"delivery_guarantee"`
+
+	// Format is the encoding of the events. Only "json" is supported.
+	Format string `hcl:"format"`
+
+	// Path is the file events are written to. Defaults to
+	// "<data_dir>/audit/audit.log".
+	Path string `hcl:"path"`
+
+	// RotateDuration is how long a file is written to before it is rotated.
+	// Zero disables time based rotation.
+	RotateDuration    time.Duration
+	RotateDurationHCL string `hcl:"rotate_duration" json:"-"`
+
+	// RotateBytes is the size a file may reach before it is rotated. Zero
+	// disables size based rotation.
+	RotateBytes int `hcl:"rotate_bytes"`
+
+	// RotateMaxFiles is the number of rotated files to keep. Zero keeps all
+	// rotated files.
+	RotateMaxFiles int `hcl:"rotate_max_files"`
+
+	// ExtraKeysHCL is used by hcl to surface unexpected keys
+	ExtraKeysHCL []string `hcl:",unusedKeys" json:"-"`
+}
+
+// AuditFilter excludes events matching all of its endpoints, stages and
+// operations from being written to the audit log.
+type AuditFilter struct {
+	// Name is a unique name for the filter.
+	Name string `hcl:",key"`
+
+	// Type is the type of events the filter applies to. Only "HTTPEvent" is
+	// supported.
+	Type string `hcl:"type"`
+
+	// Endpoints are glob patterns matched against the request path.
+	Endpoints []string `hcl:"endpoints"`
+
+	// Stages are the event stages to match, or "*" for all stages.
+	Stages []string `hcl:"stages"`
+
+	// Operations are the HTTP methods to match, or "*" for all methods.
+	Operations []string `hcl:"operations"`
+
+	// ExtraKeysHCL is used by hcl to surface unexpected keys
+	ExtraKeysHCL []string `hcl:",unusedKeys" json:"-"`
+}
+
+// DefaultAuditConfig returns the canonical defaults for the audit
+// configuration.
+func DefaultAuditConfig() *AuditConfig {
+	return &AuditConfig{
+		Enabled: helper.BoolToPtr(false),
+	}
+}
+
+// IsEnabled returns whether audit logging is enabled.
+func (a *AuditConfig) IsEnabled() bool {
+	return a != nil && a.Enabled != nil && *a.Enabled
+}
+
+// Copy returns a deep copy of the audit config.
+func (a *AuditConfig) Copy() *AuditConfig {
+	if a == nil {
+		return nil
+	}
+
+	nc := *a
+	if a.Enabled != nil {
+		nc.Enabled = helper.BoolToPtr(*a.Enabled)
+	}
+	if a.Sinks != nil {
+		nc.Sinks = make([]*AuditSink, len(a.Sinks))
+		for i, s := range a.Sinks {
+			ns := *s
+			nc.Sinks[i] = &ns
+		}
+	}
+	if a.Filters != nil {
+		nc.Filters = make([]*AuditFilter, len(a.Filters))
+		for i, f := range a.Filters {
+			nf := *f
+			nf.Endpoints = helper.CopySliceString(f.Endpoints)
+			nf.Stages = helper.CopySliceString(f.Stages)
+			nf.Operations = helper.CopySliceString(f.Operations)
+			nc.Filters[i] = &nf
+		}
+	}
+	return &nc
+}
+
+// Merge is used to merge two audit configs together. The settings from the
+// input always take precedence. Sinks and filters are replaced by name.
+func (a *AuditConfig) Merge(b *AuditConfig) *AuditConfig {
+	result := a.Copy()
+	if b == nil {
+		return result
+	}
+
+	if b.Enabled != nil {
+		result.Enabled = helper.BoolToPtr(*b.Enabled)
+	}
+
+	o := b.Copy()
+	for _, s := range o.Sinks {
+		replaced := false
+		for i, existing := range result.Sinks {
+			if existing.Name == s.Name {
+				result.Sinks[i] = s
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			result.Sinks = append(result.Sinks, s)
+		}
+	}
+	for _, f := range o.Filters {
+		replaced := false
+		for i, existing := range result.Filters {
+			if existing.Name == f.Name {
+				result.Filters[i] = f
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			result.Filters = append(result.Filters, f)
+		}
+	}
+	return result
+}
@@ -0,0 +1,94 @@
+package config
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestAuditConfig_Merge(t *testing.T) {
+	trueValue, falseValue := true, false
+
+	c1 := &AuditConfig{
+		Enabled: &falseValue,
+		Sinks: []*AuditSink{
+			{
+				Name: "file",
+				Type: "file",
+				Path: "/tmp/audit.log",
+			},
+			{
+				Name: "other",
+				Type: "file",
+				Path: "/tmp/other.log",
+			},
+		},
+		Filters: []*AuditFilter{
+			{
+				Name:      "metrics",
+				Type:      "HTTPEvent",
+				Endpoints: []string{"/v1/metrics"},
+			},
+		},
+	}
+
+	c2 := &AuditConfig{
+		Enabled: &trueValue,
+		Sinks: []*AuditSink{
+			{
+				Name:           "file",
+				Type:           "file",
+				Path:           "/opt/audit.log",
+				RotateDuration: time.Hour,
+			},
+		},
+		Filters: []*AuditFilter{
+			{
+				Name:       "reads",
+				Type:       "HTTPEvent",
+				Endpoints:  []string{"*"},
+				Operations: []string{"GET"},
+			},
+		},
+	}
+
+	e := &AuditConfig{
+		Enabled: &trueValue,
+		Sinks: []*AuditSink{
+			{
+				Name:           "file",
+				Type:           "file",
+				Path:           "/opt/audit.log",
+				RotateDuration: time.Hour,
+			},
+			{
+				Name: "other",
+				Type: "file",
+				Path: "/tmp/other.log",
+			},
+		},
+		Filters: []*AuditFilter{
+			{
+				Name:      "metrics",
+				Type:      "HTTPEvent",
+				Endpoints: []string{"/v1/metrics"},
+			},
+			{
+				Name:       "reads",
+				Type:       "HTTPEvent",
+				Endpoints:  []string{"*"},
+				Operations: []string{"GET"},
+			},
+		},
+	}
+
+	result := c1.Merge(c2)
+	if !reflect.DeepEqual(result, e) {
+		t.Fatalf("bad:\n%#v\n%#v", result, e)
+	}
+
+	// The inputs are not modified
+	if c1.Sinks[0].Path != "/tmp/audit.log" || len(c1.Filters) != 1 {
+		t.Fatalf("merge modified input: %#v", c1)
+	}
+}
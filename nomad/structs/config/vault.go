@@ -10,6 +10,11 @@ const (
 	// DefaultVaultConnectRetryIntv is the retry interval between trying to
 	// connect to Vault
 	DefaultVaultConnectRetryIntv = 30 * time.Second
+
+	// VaultDefaultCluster is the name of the Vault cluster configured by a
+	// vault block of the agents without a name, and used by tasks not
+	// selecting one.
+	VaultDefaultCluster = "default"
 )
 
 // VaultConfig contains the configuration information necessary to
@@ -20,8 +25,14 @@ const (
 // - Pass a token for the Nomad Server to derive sub-tokens.
 //
 // - Create child tokens with policy subsets of the Server's token.
+//
+// Agents may configure multiple Vault clusters, identified by their name.
 type VaultConfig struct {
 
+	// Name is the name of the Vault cluster that tasks use to select it. It
+	// defaults to "default".
+	Name string `hcl:"name"`
+
 	// Enabled enables or disables Vault support.
 	Enabled *bool `hcl:"enabled"`
 
@@ -84,6 +95,7 @@ type VaultConfig struct {
 // `vault` configuration.
 func DefaultVaultConfig() *VaultConfig {
 	return &VaultConfig{
+		Name:                VaultDefaultCluster,
 		Addr:                "https://vault.service.consul:8200",
 		ConnectionRetryIntv: DefaultVaultConnectRetryIntv,
 		AllowUnauthenticated: func(b bool) *bool {
@@ -107,6 +119,9 @@ func (a *VaultConfig) AllowsUnauthenticated() bool {
 func (a *VaultConfig) Merge(b *VaultConfig) *VaultConfig {
 	result := *a
 
+	if b.Name != "" {
+		result.Name = b.Name
+	}
 	if b.Token != "" {
 		result.Token = b.Token
 	}
@@ -153,6 +168,38 @@ func (a *VaultConfig) Merge(b *VaultConfig) *VaultConfig {
 	return &result
 }
 
+// VaultConfigSetMerge merges two sets of Vault configurations, matching them
+// by name. An empty name refers to the default cluster.
+func VaultConfigSetMerge(first, second []*VaultConfig) []*VaultConfig {
+	out := make([]*VaultConfig, 0, len(first)+len(second))
+	index := make(map[string]int, len(first))
+	for _, c := range first {
+		index[c.GetName()] = len(out)
+		out = append(out, c.Copy())
+	}
+
+	for _, c := range second {
+		if i, ok := index[c.GetName()]; ok {
+			out[i] = out[i].Merge(c)
+			continue
+		}
+
+		index[c.GetName()] = len(out)
+		out = append(out, c.Copy())
+	}
+
+	return out
+}
+
+// GetName returns the name of the Vault cluster, which defaults to the
+// default cluster.
+func (c *VaultConfig) GetName() string {
+	if c.Name == "" {
+		return VaultDefaultCluster
+	}
+	return c.Name
+}
+
 // ApiConfig() returns a usable Vault config that can be passed directly to
 // hashicorp/vault/api.
 func (c *VaultConfig) ApiConfig() (*vault.Config, error) {
@@ -199,9 +246,15 @@ func (a *VaultConfig) IsEqual(b *VaultConfig) bool {
 		return false
 	}
 
+	if a.Name != b.Name {
+		return false
+	}
 	if a.Token != b.Token {
 		return false
 	}
+	if a.Namespace != b.Namespace {
+		return false
+	}
 	if a.Role != b.Role {
 		return false
 	}
@@ -127,3 +127,26 @@ func TestVaultConfig_IsEqual(t *testing.T) {
 	}
 	require.False(c3.IsEqual(c4))
 }
+
+func TestVaultConfigSetMerge(t *testing.T) {
+	require := require.New(t)
+
+	trueValue := true
+	first := []*VaultConfig{
+		{Addr: "1", Token: "1"},
+		{Name: "secondary", Addr: "2"},
+	}
+	second := []*VaultConfig{
+		{Name: "default", Token: "3", Enabled: &trueValue},
+		{Name: "third", Addr: "4"},
+	}
+
+	out := VaultConfigSetMerge(first, second)
+	require.Len(out, 3)
+	require.Equal(&VaultConfig{Name: "default", Addr: "1", Token: "3", Enabled: &trueValue}, out[0])
+	require.Equal(first[1], out[1])
+	require.Equal(second[1], out[2])
+
+	// The inputs are not modified
+	require.Equal("1", first[0].Token)
+}
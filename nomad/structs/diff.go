@@ -62,7 +62,8 @@ func (j *Job) Diff(other *Job, contextual bool) (*JobDiff, error) {
 	diff := &JobDiff{Type: DiffTypeNone}
 	var oldPrimitiveFlat, newPrimitiveFlat map[string]string
 	filter := []string{"ID", "Status", "StatusDescription", "Version", "Stable", "CreateIndex",
-		"ModifyIndex", "JobModifyIndex", "Update", "SubmitTime"}
+		"ModifyIndex", "JobModifyIndex", "Update", "SubmitTime", "DispatchIdempotencyToken",
+		"DispatchRequestHash", "PayloadBlob", "DispatchQueued"}
 
 	if j == nil && other == nil {
 		return diff, nil
@@ -124,7 +125,7 @@ func (j *Job) Diff(other *Job, contextual bool) (*JobDiff, error) {
 	diff.TaskGroups = tgs
 
 	// Periodic diff
-	if pDiff := primitiveObjectDiff(j.Periodic, other.Periodic, nil, "Periodic", contextual); pDiff != nil {
+	if pDiff := periodicDiff(j.Periodic, other.Periodic, contextual); pDiff != nil {
 		diff.Objects = append(diff.Objects, pDiff)
 	}
 
@@ -228,6 +229,21 @@ func (tg *TaskGroup) Diff(other *TaskGroup, contextual bool) (*TaskGroupDiff, er
 	// Diff the primitive fields.
 	diff.Fields = fieldDiffs(oldPrimitiveFlat, newPrimitiveFlat, false)
 
+	// MaxClientDisconnect diff
+	if tg.MaxClientDisconnect != nil || other.MaxClientDisconnect != nil {
+		var oldDisconnect, newDisconnect string
+		if tg.MaxClientDisconnect != nil {
+			oldDisconnect = fmt.Sprintf("%d", *tg.MaxClientDisconnect)
+		}
+		if other.MaxClientDisconnect != nil {
+			newDisconnect = fmt.Sprintf("%d", *other.MaxClientDisconnect)
+		}
+		if fd := fieldDiff(oldDisconnect, newDisconnect, "MaxClientDisconnect", contextual); fd != nil {
+			diff.Fields = append(diff.Fields, fd)
+			sort.Sort(FieldDiffs(diff.Fields))
+		}
+	}
+
 	// Constraints diff
 	conDiff := primitiveObjectSetDiff(
 		interfaceSlice(tg.Constraints),
@@ -269,8 +285,7 @@ func (tg *TaskGroup) Diff(other *TaskGroup, contextual bool) (*TaskGroupDiff, er
 	}
 
 	// Update diff
-	// COMPAT: Remove "Stagger" in 0.7.0.
-	if uDiff := primitiveObjectDiff(tg.Update, other.Update, []string{"Stagger"}, "Update", contextual); uDiff != nil {
+	if uDiff := updateStrategyDiff(tg.Update, other.Update, contextual); uDiff != nil {
 		diff.Objects = append(diff.Objects, uDiff)
 	}
 
@@ -541,6 +556,211 @@ func (t TaskDiffs) Len() int           { return len(t) }
 func (t TaskDiffs) Swap(i, j int)      { t[i], t[j] = t[j], t[i] }
 func (t TaskDiffs) Less(i, j int) bool { return t[i].Name < t[j].Name }
 
+// updateStrategyDiff returns the diff of two update strategies, including
+// their metric analysis. If contextual diff is enabled, all fields will be
+// returned, even if no diff occurred.
+func updateStrategyDiff(old, new *UpdateStrategy, contextual bool) *ObjectDiff {
+	// COMPAT: Remove "Stagger" in 0.7.0.
+	filter := []string{"Stagger"}
+	diff := primitiveObjectDiff(old, new, filter, "Update", contextual)
+
+	var oldAnalysis, newAnalysis *DeploymentAnalysis
+	if old != nil {
+		oldAnalysis = old.Analysis
+	}
+	if new != nil {
+		newAnalysis = new.Analysis
+	}
+
+	var oldSteps, newSteps []*UpdateStep
+	if old != nil {
+		oldSteps = old.Steps
+	}
+	if new != nil {
+		newSteps = new.Steps
+	}
+
+	var objects []*ObjectDiff
+	if aDiff := analysisDiff(oldAnalysis, newAnalysis, contextual); aDiff != nil {
+		objects = append(objects, aDiff)
+	}
+	objects = append(objects, updateStepDiffs(oldSteps, newSteps, contextual)...)
+	if len(objects) == 0 {
+		return diff
+	}
+
+	// Only the analysis or steps changed
+	if diff == nil {
+		diff = &ObjectDiff{Type: DiffTypeEdited, Name: "Update"}
+		if contextual {
+			diff.Fields = fieldDiffs(flatmap.Flatten(old, filter, true), flatmap.Flatten(new, filter, true), contextual)
+		}
+	}
+	diff.Objects = append(diff.Objects, objects...)
+	return diff
+}
+
+// periodicDiff returns the diff of two periodic configs. If contextual diff is
+// enabled, all fields will be returned, even if no diff occurred.
+func periodicDiff(old, new *PeriodicConfig, contextual bool) *ObjectDiff {
+	diff := primitiveObjectDiff(old, new, nil, "Periodic", contextual)
+
+	var oldSpecs, newSpecs, oldExclude, newExclude []string
+	if old != nil {
+		oldSpecs, oldExclude = old.Specs, old.Exclude
+	}
+	if new != nil {
+		newSpecs, newExclude = new.Specs, new.Exclude
+	}
+
+	var objects []*ObjectDiff
+	if sDiff := stringSetDiff(oldSpecs, newSpecs, "Specs", contextual); sDiff != nil {
+		objects = append(objects, sDiff)
+	}
+	if eDiff := stringSetDiff(oldExclude, newExclude, "Exclude", contextual); eDiff != nil {
+		objects = append(objects, eDiff)
+	}
+	if len(objects) == 0 {
+		return diff
+	}
+
+	// Only the specs or exclusions changed
+	if diff == nil {
+		diff = &ObjectDiff{Type: DiffTypeEdited, Name: "Periodic"}
+		if contextual {
+			diff.Fields = fieldDiffs(flatmap.Flatten(old, nil, true), flatmap.Flatten(new, nil, true), contextual)
+		}
+	}
+	diff.Objects = append(diff.Objects, objects...)
+	return diff
+}
+
+// updateStepDiffs diffs the rollout steps of an update strategy by their
+// position. If contextual diff is enabled, all fields will be returned, even if
+// no diff occurred.
+func updateStepDiffs(old, new []*UpdateStep, contextual bool) []*ObjectDiff {
+	var diffs []*ObjectDiff
+	for i := 0; i < len(old) || i < len(new); i++ {
+		var oldStep, newStep interface{}
+		if i < len(old) {
+			oldStep = old[i]
+		}
+		if i < len(new) {
+			newStep = new[i]
+		}
+
+		if diff := primitiveObjectDiff(oldStep, newStep, nil, "Step", contextual); diff != nil {
+			diffs = append(diffs, diff)
+		}
+	}
+
+	return diffs
+}
+
+// analysisDiff returns the diff of two deployment analyses. If contextual diff
+// is enabled, all fields will be returned, even if no diff occurred.
+func analysisDiff(old, new *DeploymentAnalysis, contextual bool) *ObjectDiff {
+	diff := &ObjectDiff{Type: DiffTypeNone, Name: "Analysis"}
+	var oldPrimitiveFlat, newPrimitiveFlat map[string]string
+
+	if reflect.DeepEqual(old, new) {
+		return nil
+	} else if old == nil {
+		old = &DeploymentAnalysis{}
+		diff.Type = DiffTypeAdded
+		newPrimitiveFlat = flatmap.Flatten(new, nil, true)
+	} else if new == nil {
+		new = &DeploymentAnalysis{}
+		diff.Type = DiffTypeDeleted
+		oldPrimitiveFlat = flatmap.Flatten(old, nil, true)
+	} else {
+		diff.Type = DiffTypeEdited
+		oldPrimitiveFlat = flatmap.Flatten(old, nil, true)
+		newPrimitiveFlat = flatmap.Flatten(new, nil, true)
+	}
+
+	// Diff the primitive fields.
+	diff.Fields = fieldDiffs(oldPrimitiveFlat, newPrimitiveFlat, contextual)
+
+	// Metric diffs
+	if mDiffs := analysisMetricDiffs(old.Metrics, new.Metrics, contextual); mDiffs != nil {
+		diff.Objects = append(diff.Objects, mDiffs...)
+	}
+
+	return diff
+}
+
+// analysisMetricDiffs diffs a set of analysis metrics by their name. If
+// contextual diff is enabled, unchanged fields will be returned.
+func analysisMetricDiffs(old, new []*AnalysisMetric, contextual bool) []*ObjectDiff {
+	oldMap := make(map[string]*AnalysisMetric, len(old))
+	newMap := make(map[string]*AnalysisMetric, len(new))
+	for _, o := range old {
+		oldMap[o.Name] = o
+	}
+	for _, n := range new {
+		newMap[n.Name] = n
+	}
+
+	var diffs []*ObjectDiff
+	for name, oldMetric := range oldMap {
+		// Diff the same, deleted and edited
+		if diff := analysisMetricDiff(oldMetric, newMap[name], contextual); diff != nil {
+			diffs = append(diffs, diff)
+		}
+	}
+
+	for name, newMetric := range newMap {
+		// Diff the added
+		if _, ok := oldMap[name]; !ok {
+			if diff := analysisMetricDiff(nil, newMetric, contextual); diff != nil {
+				diffs = append(diffs, diff)
+			}
+		}
+	}
+
+	sort.Sort(ObjectDiffs(diffs))
+	return diffs
+}
+
+// analysisMetricDiff returns the diff of two analysis metrics. If contextual
+// diff is enabled, all fields will be returned, even if no diff occurred.
+func analysisMetricDiff(old, new *AnalysisMetric, contextual bool) *ObjectDiff {
+	diff := &ObjectDiff{Type: DiffTypeNone, Name: "Metric"}
+
+	if reflect.DeepEqual(old, new) {
+		return nil
+	} else if old == nil {
+		diff.Type = DiffTypeAdded
+	} else if new == nil {
+		diff.Type = DiffTypeDeleted
+	} else {
+		diff.Type = DiffTypeEdited
+	}
+
+	// The thresholds are pointers, so they are flattened explicitly
+	flat := func(m *AnalysisMetric) map[string]string {
+		if m == nil {
+			return nil
+		}
+
+		out := map[string]string{
+			"Name":  m.Name,
+			"Query": m.Query,
+		}
+		if m.Min != nil {
+			out["Min"] = fmt.Sprintf("%v", *m.Min)
+		}
+		if m.Max != nil {
+			out["Max"] = fmt.Sprintf("%v", *m.Max)
+		}
+		return out
+	}
+
+	diff.Fields = fieldDiffs(flat(old), flat(new), contextual)
+	return diff
+}
+
 // serviceDiff returns the diff of two service objects. If contextual diff is
 // enabled, all fields will be returned, even if no diff occurred.
 func serviceDiff(old, new *Service, contextual bool) *ObjectDiff {
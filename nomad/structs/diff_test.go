@@ -4,6 +4,8 @@ import (
 	"reflect"
 	"testing"
 	"time"
+
+	"github.com/hashicorp/nomad/helper"
 )
 
 func TestJobDiff(t *testing.T) {
@@ -451,6 +453,12 @@ func TestJobDiff(t *testing.T) {
 						Type: DiffTypeAdded,
 						Name: "Periodic",
 						Fields: []*FieldDiff{
+							{
+								Type: DiffTypeAdded,
+								Name: "CatchupLookback",
+								Old:  "",
+								New:  "0",
+							},
 							{
 								Type: DiffTypeAdded,
 								Name: "Enabled",
@@ -505,6 +513,12 @@ func TestJobDiff(t *testing.T) {
 						Type: DiffTypeDeleted,
 						Name: "Periodic",
 						Fields: []*FieldDiff{
+							{
+								Type: DiffTypeDeleted,
+								Name: "CatchupLookback",
+								Old:  "0",
+								New:  "",
+							},
 							{
 								Type: DiffTypeDeleted,
 								Name: "Enabled",
@@ -549,6 +563,7 @@ func TestJobDiff(t *testing.T) {
 					SpecType:        "foo",
 					ProhibitOverlap: false,
 					TimeZone:        "Europe/Minsk",
+					Catchup:         "run_once",
 				},
 			},
 			New: &Job{
@@ -558,6 +573,8 @@ func TestJobDiff(t *testing.T) {
 					SpecType:        "cron",
 					ProhibitOverlap: true,
 					TimeZone:        "America/Los_Angeles",
+					Catchup:         "run_all",
+					CatchupLookback: 1 * time.Hour,
 				},
 			},
 			Expected: &JobDiff{
@@ -567,6 +584,18 @@ func TestJobDiff(t *testing.T) {
 						Type: DiffTypeEdited,
 						Name: "Periodic",
 						Fields: []*FieldDiff{
+							{
+								Type: DiffTypeEdited,
+								Name: "Catchup",
+								Old:  "run_once",
+								New:  "run_all",
+							},
+							{
+								Type: DiffTypeEdited,
+								Name: "CatchupLookback",
+								Old:  "0",
+								New:  "3600000000000",
+							},
 							{
 								Type: DiffTypeEdited,
 								Name: "Enabled",
@@ -630,6 +659,18 @@ func TestJobDiff(t *testing.T) {
 						Type: DiffTypeEdited,
 						Name: "Periodic",
 						Fields: []*FieldDiff{
+							{
+								Type: DiffTypeNone,
+								Name: "Catchup",
+								Old:  "",
+								New:  "",
+							},
+							{
+								Type: DiffTypeNone,
+								Name: "CatchupLookback",
+								Old:  "0",
+								New:  "0",
+							},
 							{
 								Type: DiffTypeEdited,
 								Name: "Enabled",
@@ -665,6 +706,52 @@ func TestJobDiff(t *testing.T) {
 				},
 			},
 		},
+		{
+			// Periodic specs and exclusions edited
+			Old: &Job{
+				Periodic: &PeriodicConfig{
+					Spec:    "0 9 * * 1-5",
+					Specs:   []string{"0 12 * * 6"},
+					Exclude: []string{"2019-12-25"},
+				},
+			},
+			New: &Job{
+				Periodic: &PeriodicConfig{
+					Spec:    "0 9 * * 1-5",
+					Specs:   []string{"0 12 * * 0"},
+					Exclude: []string{"2019-12-25"},
+				},
+			},
+			Expected: &JobDiff{
+				Type: DiffTypeEdited,
+				Objects: []*ObjectDiff{
+					{
+						Type: DiffTypeEdited,
+						Name: "Periodic",
+						Objects: []*ObjectDiff{
+							{
+								Type: DiffTypeEdited,
+								Name: "Specs",
+								Fields: []*FieldDiff{
+									{
+										Type: DiffTypeAdded,
+										Name: "Specs",
+										Old:  "",
+										New:  "0 12 * * 0",
+									},
+									{
+										Type: DiffTypeDeleted,
+										Name: "Specs",
+										Old:  "0 12 * * 6",
+										New:  "",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
 		{
 			// Constraints edited
 			Old: &Job{
@@ -942,9 +1029,10 @@ func TestJobDiff(t *testing.T) {
 			Old: &Job{},
 			New: &Job{
 				ParameterizedJob: &ParameterizedJobConfig{
-					Payload:      DispatchPayloadRequired,
-					MetaOptional: []string{"foo"},
-					MetaRequired: []string{"bar"},
+					Payload:       DispatchPayloadRequired,
+					MetaOptional:  []string{"foo"},
+					MetaRequired:  []string{"bar"},
+					MaxConcurrent: 10,
 				},
 			},
 			Expected: &JobDiff{
@@ -954,6 +1042,12 @@ func TestJobDiff(t *testing.T) {
 						Type: DiffTypeAdded,
 						Name: "ParameterizedJob",
 						Fields: []*FieldDiff{
+							{
+								Type: DiffTypeAdded,
+								Name: "MaxConcurrent",
+								Old:  "",
+								New:  "10",
+							},
 							{
 								Type: DiffTypeAdded,
 								Name: "Payload",
@@ -995,9 +1089,10 @@ func TestJobDiff(t *testing.T) {
 			// Parameterized Job deleted
 			Old: &Job{
 				ParameterizedJob: &ParameterizedJobConfig{
-					Payload:      DispatchPayloadRequired,
-					MetaOptional: []string{"foo"},
-					MetaRequired: []string{"bar"},
+					Payload:       DispatchPayloadRequired,
+					MetaOptional:  []string{"foo"},
+					MetaRequired:  []string{"bar"},
+					MaxConcurrent: 10,
 				},
 			},
 			New: &Job{},
@@ -1008,6 +1103,12 @@ func TestJobDiff(t *testing.T) {
 						Type: DiffTypeDeleted,
 						Name: "ParameterizedJob",
 						Fields: []*FieldDiff{
+							{
+								Type: DiffTypeDeleted,
+								Name: "MaxConcurrent",
+								Old:  "10",
+								New:  "",
+							},
 							{
 								Type: DiffTypeDeleted,
 								Name: "Payload",
@@ -1049,16 +1150,18 @@ func TestJobDiff(t *testing.T) {
 			// Parameterized Job edited
 			Old: &Job{
 				ParameterizedJob: &ParameterizedJobConfig{
-					Payload:      DispatchPayloadRequired,
-					MetaOptional: []string{"foo"},
-					MetaRequired: []string{"bar"},
+					Payload:       DispatchPayloadRequired,
+					MetaOptional:  []string{"foo"},
+					MetaRequired:  []string{"bar"},
+					MaxConcurrent: 10,
 				},
 			},
 			New: &Job{
 				ParameterizedJob: &ParameterizedJobConfig{
-					Payload:      DispatchPayloadOptional,
-					MetaOptional: []string{"bam"},
-					MetaRequired: []string{"bang"},
+					Payload:       DispatchPayloadOptional,
+					MetaOptional:  []string{"bam"},
+					MetaRequired:  []string{"bang"},
+					MaxConcurrent: 20,
 				},
 			},
 			Expected: &JobDiff{
@@ -1068,6 +1171,12 @@ func TestJobDiff(t *testing.T) {
 						Type: DiffTypeEdited,
 						Name: "ParameterizedJob",
 						Fields: []*FieldDiff{
+							{
+								Type: DiffTypeEdited,
+								Name: "MaxConcurrent",
+								Old:  "10",
+								New:  "20",
+							},
 							{
 								Type: DiffTypeEdited,
 								Name: "Payload",
@@ -1122,16 +1231,18 @@ func TestJobDiff(t *testing.T) {
 			Contextual: true,
 			Old: &Job{
 				ParameterizedJob: &ParameterizedJobConfig{
-					Payload:      DispatchPayloadRequired,
-					MetaOptional: []string{"foo"},
-					MetaRequired: []string{"bar"},
+					Payload:       DispatchPayloadRequired,
+					MetaOptional:  []string{"foo"},
+					MetaRequired:  []string{"bar"},
+					MaxConcurrent: 10,
 				},
 			},
 			New: &Job{
 				ParameterizedJob: &ParameterizedJobConfig{
-					Payload:      DispatchPayloadOptional,
-					MetaOptional: []string{"foo"},
-					MetaRequired: []string{"bar"},
+					Payload:       DispatchPayloadOptional,
+					MetaOptional:  []string{"foo"},
+					MetaRequired:  []string{"bar"},
+					MaxConcurrent: 10,
 				},
 			},
 			Expected: &JobDiff{
@@ -1141,6 +1252,12 @@ func TestJobDiff(t *testing.T) {
 						Type: DiffTypeEdited,
 						Name: "ParameterizedJob",
 						Fields: []*FieldDiff{
+							{
+								Type: DiffTypeNone,
+								Name: "MaxConcurrent",
+								Old:  "10",
+								New:  "10",
+							},
 							{
 								Type: DiffTypeEdited,
 								Name: "Payload",
@@ -1977,6 +2094,12 @@ func TestTaskGroupDiff(t *testing.T) {
 						Type: DiffTypeDeleted,
 						Name: "Update",
 						Fields: []*FieldDiff{
+							{
+								Type: DiffTypeDeleted,
+								Name: "AutoPromote",
+								Old:  "false",
+								New:  "",
+							},
 							{
 								Type: DiffTypeDeleted,
 								Name: "AutoRevert",
@@ -2013,6 +2136,12 @@ func TestTaskGroupDiff(t *testing.T) {
 								Old:  "0",
 								New:  "",
 							},
+							{
+								Type: DiffTypeDeleted,
+								Name: "PromoteAfter",
+								Old:  "0",
+								New:  "",
+							},
 						},
 					},
 				},
@@ -2033,6 +2162,12 @@ func TestTaskGroupDiff(t *testing.T) {
 						Type: DiffTypeAdded,
 						Name: "Update",
 						Fields: []*FieldDiff{
+							{
+								Type: DiffTypeAdded,
+								Name: "AutoPromote",
+								Old:  "",
+								New:  "false",
+							},
 							{
 								Type: DiffTypeAdded,
 								Name: "AutoRevert",
@@ -2069,6 +2204,12 @@ func TestTaskGroupDiff(t *testing.T) {
 								Old:  "",
 								New:  "0",
 							},
+							{
+								Type: DiffTypeAdded,
+								Name: "PromoteAfter",
+								Old:  "",
+								New:  "0",
+							},
 						},
 					},
 				},
@@ -2096,6 +2237,8 @@ func TestTaskGroupDiff(t *testing.T) {
 					ProgressDeadline: 32 * time.Second,
 					AutoRevert:       false,
 					Canary:           1,
+					AutoPromote:      true,
+					PromoteAfter:     time.Minute,
 				},
 			},
 			Expected: &TaskGroupDiff{
@@ -2105,6 +2248,12 @@ func TestTaskGroupDiff(t *testing.T) {
 						Type: DiffTypeEdited,
 						Name: "Update",
 						Fields: []*FieldDiff{
+							{
+								Type: DiffTypeEdited,
+								Name: "AutoPromote",
+								Old:  "false",
+								New:  "true",
+							},
 							{
 								Type: DiffTypeEdited,
 								Name: "AutoRevert",
@@ -2147,6 +2296,184 @@ func TestTaskGroupDiff(t *testing.T) {
 								Old:  "29000000000",
 								New:  "32000000000",
 							},
+							{
+								Type: DiffTypeEdited,
+								Name: "PromoteAfter",
+								Old:  "0",
+								New:  "60000000000",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			// Update strategy analysis edited
+			Old: &TaskGroup{
+				Update: &UpdateStrategy{
+					Analysis: &DeploymentAnalysis{
+						Address:      "http://a:9090",
+						Interval:     time.Second,
+						FailureLimit: 1,
+						Metrics: []*AnalysisMetric{
+							{
+								Name:  "errors",
+								Query: "errors",
+								Max:   helper.Float64ToPtr(1),
+							},
+						},
+					},
+				},
+			},
+			New: &TaskGroup{
+				Update: &UpdateStrategy{
+					Analysis: &DeploymentAnalysis{
+						Address:      "http://b:9090",
+						Interval:     time.Second,
+						FailureLimit: 1,
+						Metrics: []*AnalysisMetric{
+							{
+								Name:  "errors",
+								Query: "errors",
+								Max:   helper.Float64ToPtr(2),
+							},
+							{
+								Name:  "requests",
+								Query: "requests",
+								Min:   helper.Float64ToPtr(10),
+							},
+						},
+					},
+				},
+			},
+			Expected: &TaskGroupDiff{
+				Type: DiffTypeEdited,
+				Objects: []*ObjectDiff{
+					{
+						Type: DiffTypeEdited,
+						Name: "Update",
+						Objects: []*ObjectDiff{
+							{
+								Type: DiffTypeEdited,
+								Name: "Analysis",
+								Fields: []*FieldDiff{
+									{
+										Type: DiffTypeEdited,
+										Name: "Address",
+										Old:  "http://a:9090",
+										New:  "http://b:9090",
+									},
+								},
+								Objects: []*ObjectDiff{
+									{
+										Type: DiffTypeEdited,
+										Name: "Metric",
+										Fields: []*FieldDiff{
+											{
+												Type: DiffTypeEdited,
+												Name: "Max",
+												Old:  "1",
+												New:  "2",
+											},
+										},
+									},
+									{
+										Type: DiffTypeAdded,
+										Name: "Metric",
+										Fields: []*FieldDiff{
+											{
+												Type: DiffTypeAdded,
+												Name: "Min",
+												Old:  "",
+												New:  "10",
+											},
+											{
+												Type: DiffTypeAdded,
+												Name: "Name",
+												Old:  "",
+												New:  "requests",
+											},
+											{
+												Type: DiffTypeAdded,
+												Name: "Query",
+												Old:  "",
+												New:  "requests",
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			// Update strategy steps edited
+			Old: &TaskGroup{
+				Update: &UpdateStrategy{
+					Steps: []*UpdateStep{
+						{Canary: 1},
+					},
+				},
+			},
+			New: &TaskGroup{
+				Update: &UpdateStrategy{
+					Steps: []*UpdateStep{
+						{Canary: 2, Pause: time.Minute},
+						{Percent: 100},
+					},
+				},
+			},
+			Expected: &TaskGroupDiff{
+				Type: DiffTypeEdited,
+				Objects: []*ObjectDiff{
+					{
+						Type: DiffTypeEdited,
+						Name: "Update",
+						Objects: []*ObjectDiff{
+							{
+								Type: DiffTypeEdited,
+								Name: "Step",
+								Fields: []*FieldDiff{
+									{
+										Type: DiffTypeEdited,
+										Name: "Canary",
+										Old:  "1",
+										New:  "2",
+									},
+									{
+										Type: DiffTypeEdited,
+										Name: "Pause",
+										Old:  "0",
+										New:  "60000000000",
+									},
+								},
+							},
+							{
+								Type: DiffTypeAdded,
+								Name: "Step",
+								Fields: []*FieldDiff{
+									{
+										Type: DiffTypeAdded,
+										Name: "Canary",
+										Old:  "",
+										New:  "0",
+									},
+									{
+										Type: DiffTypeAdded,
+										Name: "Pause",
+										Old:  "",
+										New:  "0",
+									},
+									{
+										Type: DiffTypeAdded,
+										Name: "Percent",
+										Old:  "",
+										New:  "100",
+									},
+								},
+							},
 						},
 					},
 				},
@@ -2184,6 +2511,12 @@ func TestTaskGroupDiff(t *testing.T) {
 						Type: DiffTypeEdited,
 						Name: "Update",
 						Fields: []*FieldDiff{
+							{
+								Type: DiffTypeNone,
+								Name: "AutoPromote",
+								Old:  "false",
+								New:  "false",
+							},
 							{
 								Type: DiffTypeNone,
 								Name: "AutoRevert",
@@ -2226,6 +2559,12 @@ func TestTaskGroupDiff(t *testing.T) {
 								Old:  "30000000000",
 								New:  "30000000000",
 							},
+							{
+								Type: DiffTypeNone,
+								Name: "PromoteAfter",
+								Old:  "0",
+								New:  "0",
+							},
 						},
 					},
 				},
@@ -3247,6 +3586,12 @@ func TestTaskDiff(t *testing.T) {
 								Old:  "100",
 								New:  "200",
 							},
+							{
+								Type: DiffTypeNone,
+								Name: "Cores",
+								Old:  "0",
+								New:  "0",
+							},
 							{
 								Type: DiffTypeEdited,
 								Name: "DiskMB",
@@ -3574,6 +3919,12 @@ func TestTaskDiff(t *testing.T) {
 								Old:  "100",
 								New:  "100",
 							},
+							{
+								Type: DiffTypeNone,
+								Name: "Cores",
+								Old:  "0",
+								New:  "0",
+							},
 							{
 								Type: DiffTypeNone,
 								Name: "DiskMB",
@@ -4968,12 +5319,24 @@ func TestTaskDiff(t *testing.T) {
 								Old:  "SIGUSR1",
 								New:  "SIGUSR1",
 							},
+							{
+								Type: DiffTypeNone,
+								Name: "Cluster",
+								Old:  "",
+								New:  "",
+							},
 							{
 								Type: DiffTypeNone,
 								Name: "Env",
 								Old:  "true",
 								New:  "true",
 							},
+							{
+								Type: DiffTypeNone,
+								Name: "Namespace",
+								Old:  "",
+								New:  "",
+							},
 						},
 						Objects: []*ObjectDiff{
 							{
@@ -0,0 +1,133 @@
+package structs
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+
+	"github.com/golang/snappy"
+)
+
+const (
+	// DispatchBlobChunkSize is the size of the chunks a dispatch payload blob
+	// is split into. Each chunk is written to Raft in its own log entry.
+	DispatchBlobChunkSize = 256 * 1024
+
+	// DispatchBlobPayloadSizeLimit is the maximum size of the uncompressed
+	// payload of a dispatched job stored as a blob.
+	DispatchBlobPayloadSizeLimit = 16 * 1024 * 1024
+)
+
+// DispatchBlob is a dispatch payload too large to be stored on the
+// dispatched job. Blobs are content-addressed, so dispatching the same
+// payload many times stores it once, and are garbage collected once no job
+// references them.
+type DispatchBlob struct {
+	// ID is the hex encoded SHA-256 of the uncompressed payload
+	ID string
+
+	// Size is the size of the uncompressed payload
+	Size int
+
+	// Chunks is the number of chunks the payload is split into
+	Chunks int
+
+	// Raft Indexes
+	CreateIndex uint64
+	ModifyIndex uint64
+}
+
+// Copy returns a copy of the blob
+func (b *DispatchBlob) Copy() *DispatchBlob {
+	if b == nil {
+		return nil
+	}
+	nb := new(DispatchBlob)
+	*nb = *b
+	return nb
+}
+
+// DispatchBlobChunk is a chunk of a dispatch payload blob
+type DispatchBlobChunk struct {
+	// BlobID is the ID of the blob the chunk belongs to
+	BlobID string
+
+	// Index is the position of the chunk in the blob
+	Index uint
+
+	// Data is the snappy compressed data of the chunk
+	Data []byte
+
+	// Raft Indexes
+	CreateIndex uint64
+}
+
+// NewDispatchBlob splits an uncompressed payload into a blob and its
+// compressed chunks.
+func NewDispatchBlob(payload []byte) (*DispatchBlob, []*DispatchBlobChunk) {
+	sum := sha256.Sum256(payload)
+	blob := &DispatchBlob{
+		ID:   hex.EncodeToString(sum[:]),
+		Size: len(payload),
+	}
+
+	var chunks []*DispatchBlobChunk
+	for start := 0; start < len(payload); start += DispatchBlobChunkSize {
+		end := start + DispatchBlobChunkSize
+		if end > len(payload) {
+			end = len(payload)
+		}
+		chunks = append(chunks, &DispatchBlobChunk{
+			BlobID: blob.ID,
+			Index:  uint(len(chunks)),
+			Data:   snappy.Encode(nil, payload[start:end]),
+		})
+	}
+	blob.Chunks = len(chunks)
+	return blob, chunks
+}
+
+// DispatchBlobChunkUpsertRequest is used to write a chunk of a dispatch
+// payload blob
+type DispatchBlobChunkUpsertRequest struct {
+	Chunk *DispatchBlobChunk
+	WriteRequest
+}
+
+// DispatchBlobUpsertRequest is used to make a dispatch payload blob visible
+// once all of its chunks are written
+type DispatchBlobUpsertRequest struct {
+	Blob *DispatchBlob
+	WriteRequest
+}
+
+// DispatchBlobDeleteRequest is used to delete dispatch payload blobs and
+// their chunks
+type DispatchBlobDeleteRequest struct {
+	BlobIDs []string
+	WriteRequest
+}
+
+// DispatchPayloadReadRequest is used to read a chunk of the payload of a
+// dispatched job stored as a blob
+type DispatchPayloadReadRequest struct {
+	JobID string
+	Chunk uint
+
+	// AllocID is the allocation the payload is read for. It is required
+	// when authenticating with the secret ID of the allocation's node.
+	AllocID string
+
+	QueryOptions
+}
+
+// DispatchPayloadReadResponse is used to return a chunk of the payload of a
+// dispatched job
+type DispatchPayloadReadResponse struct {
+	// Blob is the blob holding the payload
+	Blob *DispatchBlob
+
+	// Data is the snappy compressed data of the requested chunk
+	Data []byte
+
+	QueryMeta
+}
@@ -0,0 +1,47 @@
+package structs
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+
+	"github.com/golang/snappy"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewDispatchBlob(t *testing.T) {
+	require := require.New(t)
+
+	payload := make([]byte, 2*DispatchBlobChunkSize+10)
+	for i := range payload {
+		payload[i] = byte(i)
+	}
+	blob, chunks := NewDispatchBlob(payload)
+
+	sum := sha256.Sum256(payload)
+	require.Equal(hex.EncodeToString(sum[:]), blob.ID)
+	require.Equal(len(payload), blob.Size)
+	require.Equal(3, blob.Chunks)
+	require.Len(chunks, 3)
+
+	// The chunks reassemble into the payload
+	var out []byte
+	for i, chunk := range chunks {
+		require.Equal(blob.ID, chunk.BlobID)
+		require.Equal(uint(i), chunk.Index)
+
+		data, err := snappy.Decode(nil, chunk.Data)
+		require.NoError(err)
+		out = append(out, data...)
+	}
+	require.Equal(payload, out)
+
+	// The same payload is stored in the same blob
+	other, _ := NewDispatchBlob(payload)
+	require.Equal(blob.ID, other.ID)
+
+	// Payloads that fit in a single chunk
+	blob, chunks = NewDispatchBlob([]byte("hello world"))
+	require.Equal(1, blob.Chunks)
+	require.Len(chunks, 1)
+}
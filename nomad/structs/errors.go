@@ -10,6 +10,7 @@ const (
 	errNoLeader            = "No cluster leader"
 	errNoRegionPath        = "No path to region"
 	errTokenNotFound       = "ACL token not found"
+	errTokenExpired        = "ACL token expired"
 	errPermissionDenied    = "Permission denied"
 	errNoNodeConn          = "No path to node"
 	errUnknownMethod       = "Unknown rpc method"
@@ -29,6 +30,7 @@ var (
 	ErrNoLeader            = errors.New(errNoLeader)
 	ErrNoRegionPath        = errors.New(errNoRegionPath)
 	ErrTokenNotFound       = errors.New(errTokenNotFound)
+	ErrTokenExpired        = errors.New(errTokenExpired)
 	ErrPermissionDenied    = errors.New(errPermissionDenied)
 	ErrNoNodeConn          = errors.New(errNoNodeConn)
 	ErrUnknownMethod       = errors.New(errUnknownMethod)
@@ -53,6 +55,12 @@ func IsErrTokenNotFound(err error) bool {
 	return err != nil && strings.Contains(err.Error(), errTokenNotFound)
 }
 
+// IsErrTokenExpired returns whether the error is due to the passed token
+// having expired.
+func IsErrTokenExpired(err error) bool {
+	return err != nil && strings.Contains(err.Error(), errTokenExpired)
+}
+
 // IsErrPermissionDenied returns whether the error is due to the operation not
 // being allowed due to lack of permissions.
 func IsErrPermissionDenied(err error) bool {
@@ -13,6 +13,7 @@ import (
 	multierror "github.com/hashicorp/go-multierror"
 	lru "github.com/hashicorp/golang-lru"
 	"github.com/hashicorp/nomad/acl"
+	"github.com/hashicorp/nomad/lib/cpuset"
 	"golang.org/x/crypto/blake2b"
 )
 
@@ -107,13 +108,28 @@ func AllocsFit(node *Node, allocs []*Allocation, netIdx *NetworkIndex, checkDevi
 	used.Add(node.ComparableReservedResources())
 
 	// For each alloc, add the resources
+	reservedCores := cpuset.New()
+	coreOverlap := false
 	for _, alloc := range allocs {
 		// Do not consider the resource impact of terminal allocations
 		if alloc.TerminalStatus() {
 			continue
 		}
 
-		used.Add(alloc.ComparableResources())
+		cr := alloc.ComparableResources()
+		used.Add(cr)
+
+		// Reserved cores are exclusive so they may not be shared between
+		// allocations
+		cores := cpuset.New(cr.Flattened.Cpu.ReservedCores...)
+		if reservedCores.Intersect(cores).Size() != 0 {
+			coreOverlap = true
+		}
+		reservedCores = reservedCores.Union(cores)
+	}
+
+	if coreOverlap {
+		return false, "cores", used, nil
 	}
 
 	// Check that the node resources are a super set of those
@@ -272,6 +288,34 @@ func VaultPoliciesSet(policies map[string]map[string]*Vault) []string {
 	return flattened
 }
 
+// VaultPoliciesByCluster takes the structure returned by VaultPolicies and
+// returns the set of required policies of each Vault cluster
+func VaultPoliciesByCluster(policies map[string]map[string]*Vault) map[string][]string {
+	sets := make(map[string]map[string]struct{})
+
+	for _, tgp := range policies {
+		for _, tp := range tgp {
+			cluster := tp.GetCluster()
+			set, ok := sets[cluster]
+			if !ok {
+				set = make(map[string]struct{})
+				sets[cluster] = set
+			}
+			for _, p := range tp.Policies {
+				set[p] = struct{}{}
+			}
+		}
+	}
+
+	flattened := make(map[string][]string, len(sets))
+	for cluster, set := range sets {
+		for p := range set {
+			flattened[cluster] = append(flattened[cluster], p)
+		}
+	}
+	return flattened
+}
+
 // DenormalizeAllocationJobs is used to attach a job to all allocations that are
 // non-terminal and do not have a job already. This is useful in cases where the
 // job is normalized.
@@ -7,6 +7,7 @@ import (
 
 	lru "github.com/hashicorp/golang-lru"
 	"github.com/hashicorp/nomad/helper/uuid"
+	"github.com/hashicorp/nomad/nomad/structs/config"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -353,6 +354,74 @@ func TestAllocsFit(t *testing.T) {
 	require.EqualValues(3072, used.Flattened.Memory.MemoryMB)
 }
 
+func TestAllocsFit_Cores(t *testing.T) {
+	require := require.New(t)
+
+	n := &Node{
+		NodeResources: &NodeResources{
+			Cpu: NodeCpuResources{
+				CpuShares:          2000,
+				ReservableCpuCores: []uint16{0, 1},
+			},
+			Memory: NodeMemoryResources{
+				MemoryMB: 2048,
+			},
+		},
+	}
+
+	a1 := &Allocation{
+		AllocatedResources: &AllocatedResources{
+			Tasks: map[string]*AllocatedTaskResources{
+				"web": {
+					Cpu: AllocatedCpuResources{
+						CpuShares:     1000,
+						ReservedCores: []uint16{0},
+					},
+					Memory: AllocatedMemoryResources{
+						MemoryMB: 1024,
+					},
+				},
+			},
+		},
+	}
+
+	a2 := &Allocation{
+		AllocatedResources: &AllocatedResources{
+			Tasks: map[string]*AllocatedTaskResources{
+				"web": {
+					Cpu: AllocatedCpuResources{
+						CpuShares:     1000,
+						ReservedCores: []uint16{1},
+					},
+					Memory: AllocatedMemoryResources{
+						MemoryMB: 1024,
+					},
+				},
+			},
+		},
+	}
+
+	// Should fit both allocations on distinct cores
+	fit, _, used, err := AllocsFit(n, []*Allocation{a1, a2}, nil, false)
+	require.NoError(err)
+	require.True(fit)
+	require.EqualValues(2000, used.Flattened.Cpu.CpuShares)
+	require.Equal([]uint16{0, 1}, used.Flattened.Cpu.ReservedCores)
+
+	// Should not fit allocations sharing a core
+	fit, dim, _, err := AllocsFit(n, []*Allocation{a1, a1}, nil, false)
+	require.NoError(err)
+	require.False(fit)
+	require.Equal("cores", dim)
+
+	// Should not fit cores the node does not have
+	a2.AllocatedResources.Tasks["web"].Cpu.ReservedCores = []uint16{2}
+	fit, dim, _, err = AllocsFit(n, []*Allocation{a2}, nil, false)
+	require.NoError(err)
+	require.False(fit)
+	require.Equal("cores", dim)
+}
+
 func TestAllocsFit_TerminalAlloc(t *testing.T) {
 	require := require.New(t)
 
@@ -758,3 +827,20 @@ func TestGenerateMigrateToken(t *testing.T) {
 	assert.False(CompareMigrateToken(allocID, nodeSecret, token2))
 	assert.True(CompareMigrateToken("x", nodeSecret, token2))
 }
+
+func TestVaultPoliciesByCluster(t *testing.T) {
+	policies := map[string]map[string]*Vault{
+		"foo": {
+			"t1": {Policies: []string{"p1", "p2"}},
+			"t2": {Policies: []string{"p2"}, Cluster: config.VaultDefaultCluster},
+		},
+		"bar": {
+			"t3": {Policies: []string{"p3"}, Cluster: "secondary", Namespace: "ops"},
+		},
+	}
+
+	out := VaultPoliciesByCluster(policies)
+	require.Len(t, out, 2)
+	require.ElementsMatch(t, []string{"p1", "p2"}, out[config.VaultDefaultCluster])
+	require.ElementsMatch(t, []string{"p3"}, out["secondary"])
+}
@@ -0,0 +1,327 @@
+package structs
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/nomad/helper/uuid"
+)
+
+const (
+	// RootKeyStateActive is the state of the single root key used to sign
+	// new workload identities
+	RootKeyStateActive = "active"
+
+	// RootKeyStateInactive is the state of rotated root keys which are kept
+	// so the identities they signed can still be verified
+	RootKeyStateInactive = "inactive"
+
+	// RootKeyAlgorithmES256 signs with ECDSA using the P-256 curve and SHA-256
+	RootKeyAlgorithmES256 = "ES256"
+
+	// WorkloadIdentityIssuer is the issuer of workload identities
+	WorkloadIdentityIssuer = "nomad"
+
+	// WorkloadIdentityTTL is how long workload identities are valid for.
+	// Clients renew the identities of their running tasks before they
+	// expire.
+	WorkloadIdentityTTL = 24 * time.Hour
+
+	// rootKeyEncryptionKeySize is the size of the AES-256 key used to
+	// encrypt variables
+	rootKeyEncryptionKeySize = 32
+)
+
+// RootKey is the metadata of a key of the keyring the servers use to sign
+// workload identities and encrypt variables. Only one key is active at a
+// time; the leader periodically rotates it and garbage collects inactive keys
+// once no live allocation or variable depends on them.
+//
+// The metadata is replicated through raft but the key material is not: each
+// server keeps it in its own keystore and fetches it from its peers.
+type RootKey struct {
+	// KeyID is the UUID of the key and is used as the "kid" of the
+	// identities it signs
+	KeyID string
+
+	// Algorithm is the signing algorithm of the key
+	Algorithm string
+
+	// State is either active or inactive
+	State string
+
+	// PublicKey is the DER encoded PKIX public key used to verify the
+	// identities the key signed
+	PublicKey []byte
+
+	// CreateTime is the time the key was created, in nanoseconds
+	CreateTime int64
+
+	CreateIndex uint64
+	ModifyIndex uint64
+}
+
+// NewRootKey generates a new active root key, returning its metadata and its
+// key material
+func NewRootKey() (*RootKey, *RootKeyMaterial, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to generate root key: %v", err)
+	}
+	der, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to encode root key: %v", err)
+	}
+	pub, err := x509.MarshalPKIXPublicKey(key.Public())
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to encode root key: %v", err)
+	}
+	encKey := make([]byte, rootKeyEncryptionKeySize)
+	if _, err := rand.Read(encKey); err != nil {
+		return nil, nil, fmt.Errorf("failed to generate root key: %v", err)
+	}
+
+	meta := &RootKey{
+		KeyID:      uuid.Generate(),
+		Algorithm:  RootKeyAlgorithmES256,
+		State:      RootKeyStateActive,
+		PublicKey:  pub,
+		CreateTime: time.Now().UTC().UnixNano(),
+	}
+	material := &RootKeyMaterial{
+		KeyID:         meta.KeyID,
+		Algorithm:     meta.Algorithm,
+		PrivateKey:    der,
+		EncryptionKey: encKey,
+	}
+	return meta, material, nil
+}
+
+// IsActive returns whether the key signs new workload identities
+func (k *RootKey) IsActive() bool {
+	return k.State == RootKeyStateActive
+}
+
+// Public returns the public key of the root key
+func (k *RootKey) Public() *KeyringPublicKey {
+	return &KeyringPublicKey{
+		KeyID:      k.KeyID,
+		Algorithm:  k.Algorithm,
+		PublicKey:  k.PublicKey,
+		CreateTime: k.CreateTime,
+	}
+}
+
+// ValidateMaterial returns an error if the key material does not belong to
+// the root key. It is used to check material fetched from other servers.
+func (k *RootKey) ValidateMaterial(material *RootKeyMaterial) error {
+	if material.KeyID != k.KeyID {
+		return fmt.Errorf("key material of root key %q returned for %q", material.KeyID, k.KeyID)
+	}
+	if material.Algorithm != k.Algorithm {
+		return fmt.Errorf("root key %q has algorithm %q, key material has %q", k.KeyID, k.Algorithm, material.Algorithm)
+	}
+	signer, err := material.Signer()
+	if err != nil {
+		return fmt.Errorf("failed to decode root key %q: %v", k.KeyID, err)
+	}
+	der, err := x509.MarshalPKIXPublicKey(signer.Public())
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(der, k.PublicKey) {
+		return fmt.Errorf("key material does not match public key of root key %q", k.KeyID)
+	}
+	return nil
+}
+
+// Copy returns a copy of the root key
+func (k *RootKey) Copy() *RootKey {
+	if k == nil {
+		return nil
+	}
+	nk := *k
+	nk.PublicKey = make([]byte, len(k.PublicKey))
+	copy(nk.PublicKey, k.PublicKey)
+	return &nk
+}
+
+// RootKeyMaterial is the secret material of a root key. It is never written
+// to raft and is only exchanged between servers.
+type RootKeyMaterial struct {
+	// KeyID is the ID of the root key the material belongs to
+	KeyID string
+
+	// Algorithm is the signing algorithm of the key
+	Algorithm string
+
+	// PrivateKey is the DER encoded private key
+	PrivateKey []byte
+
+	// EncryptionKey is the AES-256 key used to encrypt variables with
+	// AES-GCM
+	EncryptionKey []byte
+}
+
+// Signer decodes the private key of the root key
+func (k *RootKeyMaterial) Signer() (*ecdsa.PrivateKey, error) {
+	if k.Algorithm != RootKeyAlgorithmES256 {
+		return nil, fmt.Errorf("unsupported root key algorithm %q", k.Algorithm)
+	}
+	return x509.ParseECPrivateKey(k.PrivateKey)
+}
+
+// aead returns the AES-GCM cipher of the encryption key
+func (k *RootKeyMaterial) aead() (cipher.AEAD, error) {
+	if len(k.EncryptionKey) != rootKeyEncryptionKeySize {
+		return nil, fmt.Errorf("root key %q has no encryption key", k.KeyID)
+	}
+	block, err := aes.NewCipher(k.EncryptionKey)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// Encrypt encrypts the plaintext with the encryption key. The random nonce
+// is prepended to the returned ciphertext.
+func (k *RootKeyMaterial) Encrypt(plaintext []byte) ([]byte, error) {
+	aead, err := k.aead()
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return aead.Seal(nonce, nonce, plaintext, []byte(k.KeyID)), nil
+}
+
+// Decrypt decrypts a ciphertext returned by Encrypt
+func (k *RootKeyMaterial) Decrypt(ciphertext []byte) ([]byte, error) {
+	aead, err := k.aead()
+	if err != nil {
+		return nil, err
+	}
+	if len(ciphertext) < aead.NonceSize() {
+		return nil, fmt.Errorf("ciphertext too short")
+	}
+	nonce, sealed := ciphertext[:aead.NonceSize()], ciphertext[aead.NonceSize():]
+	return aead.Open(nil, nonce, sealed, []byte(k.KeyID))
+}
+
+// Copy returns a copy of the key material
+func (k *RootKeyMaterial) Copy() *RootKeyMaterial {
+	if k == nil {
+		return nil
+	}
+	nk := *k
+	nk.PrivateKey = make([]byte, len(k.PrivateKey))
+	copy(nk.PrivateKey, k.PrivateKey)
+	nk.EncryptionKey = make([]byte, len(k.EncryptionKey))
+	copy(nk.EncryptionKey, k.EncryptionKey)
+	return &nk
+}
+
+// KeyringPublicKey is the public key of a root key used to verify the
+// workload identities it signed
+type KeyringPublicKey struct {
+	KeyID     string
+	Algorithm string
+
+	// PublicKey is the DER encoded PKIX public key
+	PublicKey []byte
+
+	CreateTime int64
+}
+
+// KeyringUpsertRootKeyRequest is used to upsert a root key. Upserting an
+// active key deactivates the previously active key.
+type KeyringUpsertRootKeyRequest struct {
+	RootKey *RootKey
+	WriteRequest
+}
+
+// KeyringDeleteRootKeyRequest is used to delete a set of inactive root keys
+type KeyringDeleteRootKeyRequest struct {
+	KeyIDs []string
+	WriteRequest
+}
+
+// KeyringGetRootKeyRequest is used by a server to fetch the key material of
+// a root key from another server
+type KeyringGetRootKeyRequest struct {
+	KeyID string
+	QueryOptions
+}
+
+// KeyringGetRootKeyResponse is the response to fetching the key material of a
+// root key. The material is nil if the server doesn't have it.
+type KeyringGetRootKeyResponse struct {
+	Material *RootKeyMaterial
+	QueryMeta
+}
+
+// KeyringRotateRootKeyRequest is used to generate a new active root key
+type KeyringRotateRootKeyRequest struct {
+	WriteRequest
+}
+
+// KeyringRotateRootKeyResponse is the response to a root key rotation
+type KeyringRotateRootKeyResponse struct {
+	KeyID string
+	WriteMeta
+}
+
+// KeyringListPublicRequest is used to list the public keys of the keyring
+type KeyringListPublicRequest struct {
+	QueryOptions
+}
+
+// KeyringListPublicResponse is the response to listing the public keys
+type KeyringListPublicResponse struct {
+	PublicKeys []*KeyringPublicKey
+	QueryMeta
+}
+
+// IdentityClaims are the claims of the workload identity signed for a task
+type IdentityClaims struct {
+	Namespace    string `json:"nomad_namespace"`
+	JobID        string `json:"nomad_job_id"`
+	TaskGroup    string `json:"nomad_task_group"`
+	Task         string `json:"nomad_task"`
+	AllocationID string `json:"nomad_allocation_id"`
+
+	// Registered claims
+	ID        string `json:"jti"`
+	Issuer    string `json:"iss"`
+	Subject   string `json:"sub"`
+	IssuedAt  int64  `json:"iat"`
+	NotBefore int64  `json:"nbf"`
+	Expiry    int64  `json:"exp"`
+}
+
+// NewIdentityClaims returns the workload identity claims of the task of the
+// allocation
+func NewIdentityClaims(alloc *Allocation, task string, now time.Time) *IdentityClaims {
+	return &IdentityClaims{
+		Namespace:    alloc.Namespace,
+		JobID:        alloc.JobID,
+		TaskGroup:    alloc.TaskGroup,
+		Task:         task,
+		AllocationID: alloc.ID,
+		ID:           uuid.Generate(),
+		Issuer:       WorkloadIdentityIssuer,
+		Subject:      fmt.Sprintf("%s:%s:%s:%s", alloc.Namespace, alloc.JobID, alloc.TaskGroup, task),
+		IssuedAt:     now.Unix(),
+		NotBefore:    now.Unix(),
+		Expiry:       now.Add(WorkloadIdentityTTL).Unix(),
+	}
+}
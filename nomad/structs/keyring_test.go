@@ -0,0 +1,119 @@
+package structs
+
+import (
+	"crypto/x509"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/helper/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRootKey(t *testing.T) {
+	require := require.New(t)
+
+	key, material, err := NewRootKey()
+	require.NoError(err)
+	require.True(key.IsActive())
+	require.Equal(RootKeyAlgorithmES256, key.Algorithm)
+	require.Equal(key.KeyID, material.KeyID)
+
+	signer, err := material.Signer()
+	require.NoError(err)
+
+	// The metadata holds the public key of the material
+	der, err := x509.MarshalPKIXPublicKey(signer.Public())
+	require.NoError(err)
+	require.Equal(der, key.PublicKey)
+
+	pub := key.Public()
+	require.Equal(key.KeyID, pub.KeyID)
+	require.Equal(key.PublicKey, pub.PublicKey)
+
+	// Copies don't share the keys
+	cp := key.Copy()
+	require.Equal(key, cp)
+	cp.PublicKey[0]++
+	require.NotEqual(key.PublicKey, cp.PublicKey)
+
+	mcp := material.Copy()
+	require.Equal(material, mcp)
+	mcp.PrivateKey[0]++
+	require.NotEqual(material.PrivateKey, mcp.PrivateKey)
+
+	other, err := material.Signer()
+	require.NoError(err)
+	require.Equal(signer, other)
+
+	require.NoError(key.ValidateMaterial(material))
+
+	// Material of another key is rejected
+	_, otherMaterial, err := NewRootKey()
+	require.NoError(err)
+	require.Error(key.ValidateMaterial(otherMaterial))
+	otherMaterial.KeyID = key.KeyID
+	require.Error(key.ValidateMaterial(otherMaterial))
+
+	material.Algorithm = "HS256"
+	_, err = material.Signer()
+	require.Error(err)
+	require.Error(key.ValidateMaterial(material))
+}
+
+func TestRootKey_EncryptDecrypt(t *testing.T) {
+	require := require.New(t)
+
+	_, key, err := NewRootKey()
+	require.NoError(err)
+	require.Len(key.EncryptionKey, 32)
+
+	plaintext := []byte("hello world")
+	ciphertext, err := key.Encrypt(plaintext)
+	require.NoError(err)
+	require.NotContains(string(ciphertext), "hello")
+
+	out, err := key.Decrypt(ciphertext)
+	require.NoError(err)
+	require.Equal(plaintext, out)
+
+	// Encrypting twice uses a different nonce
+	other, err := key.Encrypt(plaintext)
+	require.NoError(err)
+	require.NotEqual(ciphertext, other)
+
+	// The ciphertext is bound to the key ID
+	cp := key.Copy()
+	cp.KeyID = uuid.Generate()
+	_, err = cp.Decrypt(ciphertext)
+	require.Error(err)
+
+	// Tampered ciphertexts are rejected
+	ciphertext[len(ciphertext)-1]++
+	_, err = key.Decrypt(ciphertext)
+	require.Error(err)
+
+	_, err = key.Decrypt([]byte("short"))
+	require.Error(err)
+}
+
+func TestNewIdentityClaims(t *testing.T) {
+	alloc := &Allocation{
+		ID:        uuid.Generate(),
+		Namespace: "prod",
+		JobID:     "example",
+		TaskGroup: "cache",
+	}
+	now := time.Now()
+
+	claims := NewIdentityClaims(alloc, "redis", now)
+	require.Equal(t, "prod", claims.Namespace)
+	require.Equal(t, "example", claims.JobID)
+	require.Equal(t, "cache", claims.TaskGroup)
+	require.Equal(t, "redis", claims.Task)
+	require.Equal(t, alloc.ID, claims.AllocationID)
+	require.Equal(t, "prod:example:cache:redis", claims.Subject)
+	require.Equal(t, WorkloadIdentityIssuer, claims.Issuer)
+	require.Equal(t, now.Unix(), claims.IssuedAt)
+	require.Equal(t, now.Add(WorkloadIdentityTTL).Unix(), claims.Expiry)
+	require.NotEmpty(t, claims.ID)
+}
@@ -0,0 +1,238 @@
+package structs
+
+import (
+	"fmt"
+	"time"
+
+	multierror "github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/nomad/helper"
+)
+
+const (
+	// MaintenancePlanStatus* are the statuses of a maintenance plan
+	MaintenancePlanStatusPending   = "pending"
+	MaintenancePlanStatusRunning   = "running"
+	MaintenancePlanStatusComplete  = "complete"
+	MaintenancePlanStatusCancelled = "cancelled"
+
+	// MaintenancePlanDescription* are the status descriptions of a
+	// maintenance plan
+	MaintenancePlanDescriptionPending   = "Waiting for the maintenance window to start"
+	MaintenancePlanDescriptionComplete  = "All nodes completed maintenance"
+	MaintenancePlanDescriptionCancelled = "Cancelled by user"
+
+	// MaintenanceNodeStatus* are the statuses of a node in a maintenance
+	// plan. Nodes are drained and then wait to be marked eligible again once
+	// their maintenance is done. Nodes removed from the cluster before
+	// completing maintenance are skipped.
+	MaintenanceNodeStatusPending  = "pending"
+	MaintenanceNodeStatusDraining = "draining"
+	MaintenanceNodeStatusDrained  = "drained"
+	MaintenanceNodeStatusComplete = "complete"
+	MaintenanceNodeStatusSkipped  = "skipped"
+)
+
+// MaintenancePlan drains a set of nodes in batches. The next batch of nodes
+// is only drained once all the nodes of the previous batch are drained and
+// marked eligible again.
+type MaintenancePlan struct {
+	// ID is a unique identifier for the plan
+	ID string
+
+	// Datacenters, NodeClass and Meta select the nodes of the plan. A node
+	// must match all of the given criteria to be selected.
+	Datacenters []string
+	NodeClass   string
+	Meta        map[string]string
+
+	// BatchSize is the number of nodes drained at the same time
+	BatchSize int
+
+	// StartTime is the start of the maintenance window. No node is drained
+	// before it.
+	StartTime time.Time
+
+	// Deadline is the drain deadline of each node. A zero deadline lets
+	// allocations drain without being force stopped.
+	Deadline time.Duration
+
+	// IgnoreSystemJobs leaves the system jobs running on the drained nodes
+	IgnoreSystemJobs bool
+
+	// Status and StatusDescription describe the progress of the plan
+	Status            string
+	StatusDescription string
+
+	// Nodes are the nodes selected when the plan was created, in the order
+	// they are drained
+	Nodes []*MaintenancePlanNode
+
+	// Raft Indexes
+	CreateIndex uint64
+	ModifyIndex uint64
+}
+
+// MaintenancePlanNode is the maintenance status of a node of a plan
+type MaintenancePlanNode struct {
+	NodeID string
+	Name   string
+
+	// Batch is the index of the batch the node is drained with
+	Batch int
+
+	Status string
+}
+
+// Copy returns a deep copy of the plan
+func (p *MaintenancePlan) Copy() *MaintenancePlan {
+	if p == nil {
+		return nil
+	}
+	np := new(MaintenancePlan)
+	*np = *p
+	np.Datacenters = helper.CopySliceString(p.Datacenters)
+	np.Meta = helper.CopyMapStringString(p.Meta)
+	if p.Nodes != nil {
+		np.Nodes = make([]*MaintenancePlanNode, len(p.Nodes))
+		for i, n := range p.Nodes {
+			nn := *n
+			np.Nodes[i] = &nn
+		}
+	}
+	return np
+}
+
+// Validate validates the user specified parts of a maintenance plan
+func (p *MaintenancePlan) Validate() error {
+	var mErr multierror.Error
+	if len(p.Datacenters) == 0 && p.NodeClass == "" && len(p.Meta) == 0 {
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("Maintenance plan must select nodes by datacenter, node class or meta"))
+	}
+	if p.BatchSize < 1 {
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("Batch size must be at least 1: %d", p.BatchSize))
+	}
+	if p.Deadline < 0 {
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("Deadline must not be negative: %v", p.Deadline))
+	}
+	return mErr.ErrorOrNil()
+}
+
+// Active returns whether the plan still has nodes to drain
+func (p *MaintenancePlan) Active() bool {
+	switch p.Status {
+	case MaintenancePlanStatusPending, MaintenancePlanStatusRunning:
+		return true
+	default:
+		return false
+	}
+}
+
+// MatchesNode returns whether the node is selected by the plan
+func (p *MaintenancePlan) MatchesNode(node *Node) bool {
+	if len(p.Datacenters) != 0 {
+		if _, ok := helper.SliceStringToSet(p.Datacenters)[node.Datacenter]; !ok {
+			return false
+		}
+	}
+	if p.NodeClass != "" && p.NodeClass != node.NodeClass {
+		return false
+	}
+	for k, v := range p.Meta {
+		if actual, ok := node.Meta[k]; !ok || actual != v {
+			return false
+		}
+	}
+	return true
+}
+
+// Batches returns the number of batches of the plan
+func (p *MaintenancePlan) Batches() int {
+	batches := 0
+	for _, n := range p.Nodes {
+		if n.Batch >= batches {
+			batches = n.Batch + 1
+		}
+	}
+	return batches
+}
+
+// CurrentBatch returns the first batch with nodes that haven't completed
+// maintenance, or -1 if all the nodes are done.
+func (p *MaintenancePlan) CurrentBatch() int {
+	current := -1
+	for _, n := range p.Nodes {
+		if n.Done() {
+			continue
+		}
+		if current == -1 || n.Batch < current {
+			current = n.Batch
+		}
+	}
+	return current
+}
+
+// Done returns whether the node has completed or skipped its maintenance
+func (n *MaintenancePlanNode) Done() bool {
+	switch n.Status {
+	case MaintenanceNodeStatusComplete, MaintenanceNodeStatusSkipped:
+		return true
+	default:
+		return false
+	}
+}
+
+// MaintenancePlanRegisterRequest is used to create a maintenance plan
+type MaintenancePlanRegisterRequest struct {
+	Plan *MaintenancePlan
+	WriteRequest
+}
+
+// MaintenancePlanCancelRequest is used to cancel a maintenance plan
+type MaintenancePlanCancelRequest struct {
+	PlanID string
+	WriteRequest
+}
+
+// MaintenancePlanUpsertRequest is used to write a maintenance plan along with
+// the drains of the nodes it starts
+type MaintenancePlanUpsertRequest struct {
+	Plan *MaintenancePlan
+
+	// NodeDrains is a mapping of nodes to the drain started by the plan
+	NodeDrains map[string]*DrainUpdate
+
+	// NodeEvents is a mapping of the node to the event to add to the node
+	NodeEvents map[string]*NodeEvent
+
+	WriteRequest
+}
+
+// MaintenancePlanUpdateResponse is used to respond to a maintenance plan
+// creation or cancellation
+type MaintenancePlanUpdateResponse struct {
+	Plan *MaintenancePlan
+	WriteMeta
+}
+
+// MaintenancePlanSpecificRequest is used to read a maintenance plan
+type MaintenancePlanSpecificRequest struct {
+	PlanID string
+	QueryOptions
+}
+
+// SingleMaintenancePlanResponse is used to return a single maintenance plan
+type SingleMaintenancePlanResponse struct {
+	Plan *MaintenancePlan
+	QueryMeta
+}
+
+// MaintenancePlanListRequest is used to list the maintenance plans
+type MaintenancePlanListRequest struct {
+	QueryOptions
+}
+
+// MaintenancePlanListResponse is used for a list request
+type MaintenancePlanListResponse struct {
+	Plans []*MaintenancePlan
+	QueryMeta
+}
@@ -0,0 +1,75 @@
+package structs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMaintenancePlan_Validate(t *testing.T) {
+	plan := &MaintenancePlan{}
+	err := plan.Validate()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "must select nodes")
+	require.Contains(t, err.Error(), "Batch size must be at least 1")
+
+	plan.Meta = map[string]string{"rack": "r1"}
+	plan.BatchSize = 2
+	plan.Deadline = -1
+	err = plan.Validate()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "Deadline must not be negative")
+
+	plan.Deadline = 0
+	require.NoError(t, plan.Validate())
+}
+
+func TestMaintenancePlan_MatchesNode(t *testing.T) {
+	node := &Node{
+		Datacenter: "dc1",
+		NodeClass:  "large",
+		Meta:       map[string]string{"rack": "r1"},
+	}
+
+	cases := []struct {
+		name    string
+		plan    *MaintenancePlan
+		matches bool
+	}{
+		{"datacenter", &MaintenancePlan{Datacenters: []string{"dc2", "dc1"}}, true},
+		{"other datacenter", &MaintenancePlan{Datacenters: []string{"dc2"}}, false},
+		{"class", &MaintenancePlan{NodeClass: "large"}, true},
+		{"other class", &MaintenancePlan{Datacenters: []string{"dc1"}, NodeClass: "small"}, false},
+		{"meta", &MaintenancePlan{Meta: map[string]string{"rack": "r1"}}, true},
+		{"other meta", &MaintenancePlan{Meta: map[string]string{"rack": "r2"}}, false},
+		{"missing meta", &MaintenancePlan{Meta: map[string]string{"zone": "z1"}}, false},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			require.Equal(t, c.matches, c.plan.MatchesNode(node))
+		})
+	}
+}
+
+func TestMaintenancePlan_CurrentBatch(t *testing.T) {
+	plan := &MaintenancePlan{
+		Nodes: []*MaintenancePlanNode{
+			{NodeID: "a", Batch: 0, Status: MaintenanceNodeStatusComplete},
+			{NodeID: "b", Batch: 0, Status: MaintenanceNodeStatusSkipped},
+			{NodeID: "c", Batch: 1, Status: MaintenanceNodeStatusDrained},
+			{NodeID: "d", Batch: 2, Status: MaintenanceNodeStatusPending},
+		},
+	}
+	require.Equal(t, 3, plan.Batches())
+	require.Equal(t, 1, plan.CurrentBatch())
+
+	// The copy doesn't share the nodes
+	copied := plan.Copy()
+	copied.Nodes[2].Status = MaintenanceNodeStatusComplete
+	require.Equal(t, MaintenanceNodeStatusDrained, plan.Nodes[2].Status)
+	require.Equal(t, 2, copied.CurrentBatch())
+
+	copied.Nodes[3].Status = MaintenanceNodeStatusComplete
+	require.Equal(t, -1, copied.CurrentBatch())
+}
@@ -173,3 +173,93 @@ type SchedulerSetConfigRequest struct {
 	// WriteRequest holds the ACL token to go along with this request.
 	WriteRequest
 }
+
+// SchedulerSimulateRequest is used by the Operator endpoint to simulate
+// scheduling the jobs of the cluster against hypothetical changes to its
+// nodes. The simulation runs against a copy of the state and has no side
+// effects.
+type SchedulerSimulateRequest struct {
+	// AddNodes are the nodes to add to the cluster.
+	AddNodes []*SimulateNodeAdd
+
+	// RemoveNodeIDs are the IDs of the nodes to remove from the cluster.
+	// Allocations on removed nodes are lost.
+	RemoveNodeIDs []string
+
+	// RemoveDatacenters are the datacenters whose nodes are all removed
+	// from the cluster.
+	RemoveDatacenters []string
+
+	// DrainNodeIDs are the IDs of the nodes to drain. All allocations on
+	// drained nodes are migrated at once.
+	DrainNodeIDs []string
+
+	QueryOptions
+}
+
+// SimulateNodeAdd describes nodes to add to the cluster for a simulation.
+// The nodes are copies of an existing node so that they are fingerprinted
+// like real nodes.
+type SimulateNodeAdd struct {
+	// CloneNodeID is the ID of the node to copy.
+	CloneNodeID string
+
+	// Count is the number of nodes to add.
+	Count int
+
+	// Datacenter and NodeClass optionally override those of the copied
+	// node.
+	Datacenter string
+	NodeClass  string
+}
+
+// SchedulerSimulateResponse is the result of a scheduling simulation.
+type SchedulerSimulateResponse struct {
+	// Jobs are the results of scheduling each job affected by the changes.
+	Jobs []*SimulateJobResult
+
+	// UtilizationBefore and UtilizationAfter are the utilization of the
+	// schedulable nodes of the cluster before and after the simulation.
+	UtilizationBefore *ClusterUtilization
+	UtilizationAfter  *ClusterUtilization
+
+	QueryMeta
+}
+
+// SimulateJobResult is the result of scheduling a single job during a
+// simulation.
+type SimulateJobResult struct {
+	Namespace string
+	JobID     string
+
+	// Placements and Stops are the number of allocations placed and stopped
+	// by the scheduler.
+	Placements int
+	Stops      int
+
+	// FailedTGAllocs are the placement failures per task group.
+	FailedTGAllocs map[string]*AllocMetric
+
+	// QueuedAllocations is the number of allocations left unplaced per task
+	// group.
+	QueuedAllocations map[string]int
+}
+
+// ClusterUtilization is the resource usage of the schedulable nodes of a
+// cluster.
+type ClusterUtilization struct {
+	// Nodes is the number of ready and eligible nodes.
+	Nodes int
+
+	// CPU, MemoryMB and DiskMB are the resources of the nodes available to
+	// allocations.
+	CPU      int64
+	MemoryMB int64
+	DiskMB   int64
+
+	// AllocatedCPU, AllocatedMemoryMB and AllocatedDiskMB are the resources
+	// used by non-terminal allocations on those nodes.
+	AllocatedCPU      int64
+	AllocatedMemoryMB int64
+	AllocatedDiskMB   int64
+}
@@ -25,6 +25,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/golang/snappy"
 	"github.com/gorhill/cronexpr"
 	"github.com/hashicorp/consul/api"
 	hcodec "github.com/hashicorp/go-msgpack/codec"
@@ -34,7 +35,11 @@ import (
 	"github.com/hashicorp/nomad/helper"
 	"github.com/hashicorp/nomad/helper/args"
 	"github.com/hashicorp/nomad/helper/uuid"
+	"github.com/hashicorp/nomad/lib/auth"
+	"github.com/hashicorp/nomad/lib/auth/jwt"
+	"github.com/hashicorp/nomad/lib/cpuset"
 	"github.com/hashicorp/nomad/lib/kheap"
+	"github.com/hashicorp/nomad/nomad/structs/config"
 	psstructs "github.com/hashicorp/nomad/plugins/shared/structs"
 	"github.com/mitchellh/copystructure"
 	"github.com/ugorji/go/codec"
@@ -83,6 +88,22 @@ const (
 	NodeUpdateEligibilityRequestType
 	BatchNodeUpdateDrainRequestType
 	SchedulerConfigRequestType
+	ACLRoleUpsertRequestType
+	ACLRoleDeleteRequestType
+	ACLAuthMethodUpsertRequestType
+	ACLAuthMethodDeleteRequestType
+	ACLBindingRuleUpsertRequestType
+	ACLBindingRuleDeleteRequestType
+	RootKeyUpsertRequestType
+	RootKeyDeleteRequestType
+	VarApplyStateRequestType
+	PeriodicLaunchEventsRequestType
+	DispatchBlobChunkUpsertRequestType
+	DispatchBlobUpsertRequestType
+	DispatchBlobDeleteRequestType
+	JobDispatchReleaseRequestType
+	MaintenancePlanUpsertRequestType
+	DeploymentAnalysisUpdateRequestType
 )
 
 const (
@@ -539,6 +560,11 @@ type JobDispatchRequest struct {
 	JobID   string
 	Payload []byte
 	Meta    map[string]string
+
+	// IdempotencyToken makes the dispatch idempotent. If a child job was
+	// dispatched with the same token within the idempotency TTL, it is
+	// returned instead of dispatching a new child job.
+	IdempotencyToken string
 	WriteRequest
 }
 
@@ -769,6 +795,14 @@ type PeriodicForceRequest struct {
 	WriteRequest
 }
 
+// PeriodicLaunchEventsRequest is used to record launch events, such as
+// skipped or caught-up launches, for a periodic job.
+type PeriodicLaunchEventsRequest struct {
+	JobID  string
+	Events []*PeriodicLaunchEvent
+	WriteRequest
+}
+
 // ServerMembersResponse has the list of servers in a cluster
 type ServerMembersResponse struct {
 	ServerName   string
@@ -816,6 +850,11 @@ type VaultAccessor struct {
 	Accessor    string
 	CreationTTL int
 
+	// Cluster and Namespace are the Vault cluster and namespace the token was
+	// created in, and must be revoked from
+	Cluster   string
+	Namespace string
+
 	// Raft Indexes
 	CreateIndex uint64
 }
@@ -919,6 +958,22 @@ type ApplyDeploymentPromoteRequest struct {
 
 	// An optional evaluation to create after promoting the canaries
 	Eval *Evaluation
+
+	// StatusDescription is an optional status description to set once the
+	// deployment no longer requires promotion.
+	StatusDescription string
+}
+
+// DeploymentAnalysisUpdateRequest is used to record the number of consecutive
+// failed metric analyses of a task group of a deployment via Raft
+type DeploymentAnalysisUpdateRequest struct {
+	DeploymentID string
+	TaskGroup    string
+
+	// Failures is the number of consecutive failed analyses
+	Failures int
+
+	WriteRequest
 }
 
 // DeploymentPauseRequest is used to pause a deployment
@@ -1064,6 +1119,16 @@ type NodeClientAllocsResponse struct {
 	QueryMeta
 }
 
+// NodeDrainStatusResponse is used to return the drain status of a node
+type NodeDrainStatusResponse struct {
+	NodeID string
+
+	// DrainStrategy is the drain strategy of the node along with its
+	// progress, or nil if the node isn't draining
+	DrainStrategy *DrainStrategy
+	QueryMeta
+}
+
 // SingleNodeResponse is used to return a single node
 type SingleNodeResponse struct {
 	Node *Node
@@ -1093,9 +1158,31 @@ type JobDispatchResponse struct {
 	EvalID          string
 	EvalCreateIndex uint64
 	JobCreateIndex  uint64
+
+	// Queued is set when the dispatched job is queued by the max_concurrent
+	// limit of the parameterized job
+	Queued bool
 	WriteMeta
 }
 
+// JobDispatchReleaseRequest is used by the leader to release dispatched jobs
+// queued by the max_concurrent limit of their parameterized job
+type JobDispatchReleaseRequest struct {
+	// Jobs are the queued jobs to release
+	Jobs []NamespacedID
+
+	// Evals are the evaluations scheduling the released jobs
+	Evals []*Evaluation
+	WriteRequest
+}
+
+// JobDispatchesResponse is used to return the jobs dispatched from a
+// parameterized job
+type JobDispatchesResponse struct {
+	Dispatches []*JobDispatchStub
+	QueryMeta
+}
+
 // JobListResponse is used for a list request
 type JobListResponse struct {
 	Jobs []*JobListStub
@@ -1160,6 +1247,14 @@ type AllocsGetResponse struct {
 	QueryMeta
 }
 
+// AllocSignIdentitiesResponse is used to return renewed workload identities
+// of the tasks of an allocation
+type AllocSignIdentitiesResponse struct {
+	SignedIdentities map[string]string
+	SigningKeyID     string
+	QueryMeta
+}
+
 // JobAllocationsResponse is used to return the allocations for a job
 type JobAllocationsResponse struct {
 	Allocations []*AllocListStub
@@ -1242,6 +1337,13 @@ type PeriodicForceResponse struct {
 	WriteMeta
 }
 
+// PeriodicHistoryResponse is used to return the launch history of a periodic
+// job.
+type PeriodicHistoryResponse struct {
+	Launch *PeriodicLaunch
+	QueryMeta
+}
+
 // DeploymentUpdateResponse is used to respond to a deployment change. The
 // response will include the modify index of the deployment as well as details
 // of any triggered evaluation.
@@ -1351,9 +1453,10 @@ func (ne *NodeEvent) AddDetail(k, v string) *NodeEvent {
 }
 
 const (
-	NodeStatusInit  = "initializing"
-	NodeStatusReady = "ready"
-	NodeStatusDown  = "down"
+	NodeStatusInit         = "initializing"
+	NodeStatusReady        = "ready"
+	NodeStatusDown         = "down"
+	NodeStatusDisconnected = "disconnected"
 )
 
 // ShouldDrainNode checks if a given node status should trigger an
@@ -1362,7 +1465,7 @@ func ShouldDrainNode(status string) bool {
 	switch status {
 	case NodeStatusInit, NodeStatusReady:
 		return false
-	case NodeStatusDown:
+	case NodeStatusDown, NodeStatusDisconnected:
 		return true
 	default:
 		panic(fmt.Sprintf("unhandled node status %s", status))
@@ -1372,7 +1475,7 @@ func ShouldDrainNode(status string) bool {
 // ValidNodeStatus is used to check if a node status is valid
 func ValidNodeStatus(status string) bool {
 	switch status {
-	case NodeStatusInit, NodeStatusReady, NodeStatusDown:
+	case NodeStatusInit, NodeStatusReady, NodeStatusDown, NodeStatusDisconnected:
 		return true
 	default:
 		return false
@@ -1396,6 +1499,10 @@ type DrainSpec struct {
 	// IgnoreSystemJobs allows systems jobs to remain on the node even though it
 	// has been marked for draining.
 	IgnoreSystemJobs bool
+
+	// OrderByPriority drains the allocations of lower priority jobs before
+	// migrating the allocations of higher priority jobs.
+	OrderByPriority bool
 }
 
 // DrainStrategy describes a Node's drain behavior.
@@ -1406,6 +1513,10 @@ type DrainStrategy struct {
 	// ForceDeadline is the deadline time for the drain after which drains will
 	// be forced
 	ForceDeadline time.Time
+
+	// Progress is the progress of the drain. It is computed by the servers
+	// when the drain status of a node is read and is never stored.
+	Progress *DrainProgress
 }
 
 func (d *DrainStrategy) Copy() *DrainStrategy {
@@ -1415,6 +1526,7 @@ func (d *DrainStrategy) Copy() *DrainStrategy {
 
 	nd := new(DrainStrategy)
 	*nd = *d
+	nd.Progress = d.Progress.Copy()
 	return nd
 }
 
@@ -1456,11 +1568,76 @@ func (d *DrainStrategy) Equal(o *DrainStrategy) bool {
 		return false
 	} else if d.IgnoreSystemJobs != o.IgnoreSystemJobs {
 		return false
+	} else if d.OrderByPriority != o.OrderByPriority {
+		return false
 	}
 
 	return true
 }
 
+const (
+	// DrainBlockedReasonMigrate is used when the allocations of a job wait for
+	// replacements to become healthy within the migrate max_parallel limit.
+	DrainBlockedReasonMigrate = "waiting for healthy replacements within migrate max_parallel"
+
+	// DrainBlockedReasonBatch is used when batch allocations are left to
+	// complete until the drain deadline.
+	DrainBlockedReasonBatch = "waiting for batch allocations to complete"
+
+	// DrainBlockedReasonPriority is used when the allocations of a job wait for
+	// lower priority jobs to drain.
+	DrainBlockedReasonPriority = "waiting for lower priority jobs to drain"
+
+	// DrainBlockedReasonSystem is used when system allocations wait for the
+	// other allocations to drain.
+	DrainBlockedReasonSystem = "waiting for other allocations to drain"
+)
+
+// DrainProgress describes the progress of a node drain
+type DrainProgress struct {
+	// AllocsRemaining is the number of allocations left to drain
+	AllocsRemaining int
+
+	// AllocsMigrating is the number of remaining allocations marked for
+	// migration
+	AllocsMigrating int
+
+	// BlockedJobs are the jobs with remaining allocations that are not yet
+	// marked for migration, ordered by priority
+	BlockedJobs []*DrainBlockedJob
+}
+
+// Copy returns a copy of the drain progress
+func (p *DrainProgress) Copy() *DrainProgress {
+	if p == nil {
+		return nil
+	}
+
+	np := new(DrainProgress)
+	*np = *p
+	if p.BlockedJobs != nil {
+		np.BlockedJobs = make([]*DrainBlockedJob, len(p.BlockedJobs))
+		for i, j := range p.BlockedJobs {
+			nj := *j
+			np.BlockedJobs[i] = &nj
+		}
+	}
+	return np
+}
+
+// DrainBlockedJob is a job blocking the progress of a node drain
+type DrainBlockedJob struct {
+	Namespace string
+	JobID     string
+	Priority  int
+
+	// Allocs is the number of allocations of the job left to drain
+	Allocs int
+
+	// Reason describes why the allocations aren't migrated yet
+	Reason string
+}
+
 // Node is a representation of a schedulable client node
 type Node struct {
 	// ID is a unique identifier for the node. It can be constructed
@@ -1748,6 +1925,7 @@ type NodeListStub struct {
 // on a client
 type Resources struct {
 	CPU      int
+	Cores    int
 	MemoryMB int
 	DiskMB   int
 	IOPS     int // COMPAT(0.10): Only being used to issue warnings
@@ -1793,6 +1971,10 @@ func (r *Resources) Validate() error {
 		mErr.Errors = append(mErr.Errors, err)
 	}
 
+	if r.Cores < 0 {
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("Cores must be a positive value; got %d", r.Cores))
+	}
+
 	// Ensure the task isn't asking for disk resources
 	if r.DiskMB > 0 {
 		mErr.Errors = append(mErr.Errors, errors.New("Task can't ask for disk resources, they have to be specified at the task group level."))
@@ -1813,6 +1995,9 @@ func (r *Resources) Merge(other *Resources) {
 	if other.CPU != 0 {
 		r.CPU = other.CPU
 	}
+	if other.Cores != 0 {
+		r.Cores = other.Cores
+	}
 	if other.MemoryMB != 0 {
 		r.MemoryMB = other.MemoryMB
 	}
@@ -1836,6 +2021,7 @@ func (r *Resources) Equals(o *Resources) bool {
 		return false
 	}
 	return r.CPU == o.CPU &&
+		r.Cores == o.Cores &&
 		r.MemoryMB == o.MemoryMB &&
 		r.DiskMB == o.DiskMB &&
 		r.IOPS == o.IOPS &&
@@ -1970,6 +2156,7 @@ func (r *Resources) Add(delta *Resources) error {
 		return nil
 	}
 	r.CPU += delta.CPU
+	r.Cores += delta.Cores
 	r.MemoryMB += delta.MemoryMB
 	r.DiskMB += delta.DiskMB
 
@@ -2269,6 +2456,7 @@ func (n *NodeResources) Copy() *NodeResources {
 
 	newN := new(NodeResources)
 	*newN = *n
+	newN.Cpu.ReservableCpuCores = helper.CopySliceUint16(n.Cpu.ReservableCpuCores)
 
 	// Copy the networks
 	if n.Networks != nil {
@@ -2301,7 +2489,8 @@ func (n *NodeResources) Comparable() *ComparableResources {
 	c := &ComparableResources{
 		Flattened: AllocatedTaskResources{
 			Cpu: AllocatedCpuResources{
-				CpuShares: n.Cpu.CpuShares,
+				CpuShares:     n.Cpu.CpuShares,
+				ReservedCores: n.Cpu.ReservableCpuCores,
 			},
 			Memory: AllocatedMemoryResources{
 				MemoryMB: n.Memory.MemoryMB,
@@ -2409,6 +2598,10 @@ type NodeCpuResources struct {
 	// CpuShares is the CPU shares available. This is calculated by number of
 	// cores multiplied by the core frequency.
 	CpuShares int64
+
+	// ReservableCpuCores is the set of cpus which are available to be reserved
+	// exclusively by tasks requesting cores.
+	ReservableCpuCores []uint16
 }
 
 func (n *NodeCpuResources) Merge(o *NodeCpuResources) {
@@ -2419,6 +2612,20 @@ func (n *NodeCpuResources) Merge(o *NodeCpuResources) {
 	if o.CpuShares != 0 {
 		n.CpuShares = o.CpuShares
 	}
+
+	if len(o.ReservableCpuCores) != 0 {
+		n.ReservableCpuCores = o.ReservableCpuCores
+	}
+}
+
+// SharesPerCore returns the CPU shares provided by a single reservable core.
+// If the node did not fingerprint its cores, zero is returned.
+func (n *NodeCpuResources) SharesPerCore() int64 {
+	if len(n.ReservableCpuCores) == 0 {
+		return 0
+	}
+
+	return n.CpuShares / int64(len(n.ReservableCpuCores))
 }
 
 func (n *NodeCpuResources) Equals(o *NodeCpuResources) bool {
@@ -2434,6 +2641,10 @@ func (n *NodeCpuResources) Equals(o *NodeCpuResources) bool {
 		return false
 	}
 
+	if !cpuset.New(n.ReservableCpuCores...).Equals(cpuset.New(o.ReservableCpuCores...)) {
+		return false
+	}
+
 	return true
 }
 
@@ -2862,6 +3073,7 @@ func (a *AllocatedTaskResources) Copy() *AllocatedTaskResources {
 	}
 	newA := new(AllocatedTaskResources)
 	*newA = *a
+	newA.Cpu.ReservedCores = helper.CopySliceUint16(a.Cpu.ReservedCores)
 
 	// Copy the networks
 	if a.Networks != nil {
@@ -2924,7 +3136,8 @@ func (a *AllocatedTaskResources) Comparable() *ComparableResources {
 	ret := &ComparableResources{
 		Flattened: AllocatedTaskResources{
 			Cpu: AllocatedCpuResources{
-				CpuShares: a.Cpu.CpuShares,
+				CpuShares:     a.Cpu.CpuShares,
+				ReservedCores: a.Cpu.ReservedCores,
 			},
 			Memory: AllocatedMemoryResources{
 				MemoryMB: a.Memory.MemoryMB,
@@ -2974,6 +3187,9 @@ func (a *AllocatedSharedResources) Subtract(delta *AllocatedSharedResources) {
 // AllocatedCpuResources captures the allocated CPU resources.
 type AllocatedCpuResources struct {
 	CpuShares int64
+
+	// ReservedCores is the set of cpus exclusively reserved for the task.
+	ReservedCores []uint16
 }
 
 func (a *AllocatedCpuResources) Add(delta *AllocatedCpuResources) {
@@ -2982,6 +3198,10 @@ func (a *AllocatedCpuResources) Add(delta *AllocatedCpuResources) {
 	}
 
 	a.CpuShares += delta.CpuShares
+
+	if len(delta.ReservedCores) != 0 {
+		a.ReservedCores = cpuset.New(a.ReservedCores...).Union(cpuset.New(delta.ReservedCores...)).ToSlice()
+	}
 }
 
 func (a *AllocatedCpuResources) Subtract(delta *AllocatedCpuResources) {
@@ -2990,6 +3210,10 @@ func (a *AllocatedCpuResources) Subtract(delta *AllocatedCpuResources) {
 	}
 
 	a.CpuShares -= delta.CpuShares
+
+	if len(delta.ReservedCores) != 0 {
+		a.ReservedCores = cpuset.New(a.ReservedCores...).Difference(cpuset.New(delta.ReservedCores...)).ToSlice()
+	}
 }
 
 // AllocatedMemoryResources captures the allocated memory resources.
@@ -3119,6 +3343,9 @@ func (c *ComparableResources) Superset(other *ComparableResources) (bool, string
 	if c.Flattened.Cpu.CpuShares < other.Flattened.Cpu.CpuShares {
 		return false, "cpu"
 	}
+	if !cpuset.New(other.Flattened.Cpu.ReservedCores...).IsSubsetOf(cpuset.New(c.Flattened.Cpu.ReservedCores...)) {
+		return false, "cores"
+	}
 	if c.Flattened.Memory.MemoryMB < other.Flattened.Memory.MemoryMB {
 		return false, "memory"
 	}
@@ -3136,16 +3363,18 @@ func (c *ComparableResources) NetIndex(n *NetworkResource) int {
 const (
 	// JobTypeNomad is reserved for internal system tasks and is
 	// always handled by the CoreScheduler.
-	JobTypeCore    = "_core"
-	JobTypeService = "service"
-	JobTypeBatch   = "batch"
-	JobTypeSystem  = "system"
+	JobTypeCore     = "_core"
+	JobTypeService  = "service"
+	JobTypeBatch    = "batch"
+	JobTypeSystem   = "system"
+	JobTypeSysBatch = "sysbatch"
 )
 
 const (
 	JobStatusPending = "pending" // Pending means the job is waiting on scheduling
 	JobStatusRunning = "running" // Running means the job has non-terminal allocations
 	JobStatusDead    = "dead"    // Dead means all evaluation's and allocations are terminal
+	JobStatusQueued  = "queued"  // Queued means the dispatched job waits for its parent's max_concurrent limit
 )
 
 const (
@@ -3248,6 +3477,26 @@ type Job struct {
 	// Payload is the payload supplied when the job was dispatched.
 	Payload []byte
 
+	// DispatchQueued marks a dispatched job held back by the max_concurrent
+	// limit of its parent. Queued jobs have no evaluation until the leader
+	// releases them.
+	DispatchQueued bool
+
+	// PayloadBlob is the ID of the dispatch blob holding the payload when it
+	// is too large to be stored on the job. Clients fetch it when starting
+	// the tasks of the job.
+	PayloadBlob string
+
+	// DispatchIdempotencyToken is the idempotency token supplied when the job
+	// was dispatched. Repeated dispatches with the same token return this job
+	// instead of dispatching a new one.
+	DispatchIdempotencyToken string
+
+	// DispatchRequestHash is the hash of the meta and payload supplied when
+	// the job was dispatched with an idempotency token. Repeated dispatches
+	// with the same token but different input are rejected.
+	DispatchRequestHash string
+
 	// Meta is used to associate arbitrary metadata with this
 	// job. This is opaque to Nomad.
 	Meta map[string]string
@@ -3370,7 +3619,7 @@ func (j *Job) Validate() error {
 		mErr.Errors = append(mErr.Errors, errors.New("Job must be in a namespace"))
 	}
 	switch j.Type {
-	case JobTypeCore, JobTypeService, JobTypeBatch, JobTypeSystem:
+	case JobTypeCore, JobTypeService, JobTypeBatch, JobTypeSystem, JobTypeSysBatch:
 	case "":
 		mErr.Errors = append(mErr.Errors, errors.New("Missing job type"))
 	default:
@@ -3397,7 +3646,7 @@ func (j *Job) Validate() error {
 			mErr.Errors = append(mErr.Errors, outer)
 		}
 	}
-	if j.Type == JobTypeSystem {
+	if j.Type == JobTypeSystem || j.Type == JobTypeSysBatch {
 		if j.Affinities != nil {
 			mErr.Errors = append(mErr.Errors, fmt.Errorf("System jobs may not have an affinity stanza"))
 		}
@@ -3410,7 +3659,7 @@ func (j *Job) Validate() error {
 		}
 	}
 
-	if j.Type == JobTypeSystem {
+	if j.Type == JobTypeSystem || j.Type == JobTypeSysBatch {
 		if j.Spreads != nil {
 			mErr.Errors = append(mErr.Errors, fmt.Errorf("System jobs may not have a spread stanza"))
 		}
@@ -3449,11 +3698,11 @@ func (j *Job) Validate() error {
 		}
 	}
 
-	// Validate periodic is only used with batch jobs.
+	// Validate periodic is only used with batch and sysbatch jobs.
 	if j.IsPeriodic() && j.Periodic.Enabled {
-		if j.Type != JobTypeBatch {
+		if j.Type != JobTypeBatch && j.Type != JobTypeSysBatch {
 			mErr.Errors = append(mErr.Errors,
-				fmt.Errorf("Periodic can only be used with %q scheduler", JobTypeBatch))
+				fmt.Errorf("Periodic can only be used with %q or %q scheduler", JobTypeBatch, JobTypeSysBatch))
 		}
 
 		if err := j.Periodic.Validate(); err != nil {
@@ -3462,9 +3711,9 @@ func (j *Job) Validate() error {
 	}
 
 	if j.IsParameterized() {
-		if j.Type != JobTypeBatch {
+		if j.Type != JobTypeBatch && j.Type != JobTypeSysBatch {
 			mErr.Errors = append(mErr.Errors,
-				fmt.Errorf("Parameterized job can only be used with %q scheduler", JobTypeBatch))
+				fmt.Errorf("Parameterized job can only be used with %q or %q scheduler", JobTypeBatch, JobTypeSysBatch))
 		}
 
 		if err := j.ParameterizedJob.Validate(); err != nil {
@@ -3575,6 +3824,29 @@ func (j *Job) Stub(summary *JobSummary) *JobListStub {
 	}
 }
 
+// DispatchStub is used to return a summary of a job dispatched from a
+// parameterized job
+func (j *Job) DispatchStub() *JobDispatchStub {
+	payloadSize, err := snappy.DecodedLen(j.Payload)
+	if err != nil {
+		payloadSize = len(j.Payload)
+	}
+
+	return &JobDispatchStub{
+		ID:                j.ID,
+		ParentID:          j.ParentID,
+		Stop:              j.Stop,
+		Status:            j.Status,
+		StatusDescription: j.StatusDescription,
+		Meta:              helper.CopyMapStringString(j.Meta),
+		PayloadSize:       payloadSize,
+		IdempotencyToken:  j.DispatchIdempotencyToken,
+		SubmitTime:        j.SubmitTime,
+		CreateIndex:       j.CreateIndex,
+		ModifyIndex:       j.ModifyIndex,
+	}
+}
+
 // IsPeriodic returns whether a job is periodic.
 func (j *Job) IsPeriodic() bool {
 	return j.Periodic != nil
@@ -3591,6 +3863,12 @@ func (j *Job) IsParameterized() bool {
 	return j.ParameterizedJob != nil && !j.Dispatched
 }
 
+// IsNodeBound returns whether the job places its allocations on every
+// feasible node rather than a given count, as system and sysbatch jobs do.
+func (j *Job) IsNodeBound() bool {
+	return j.Type == JobTypeSystem || j.Type == JobTypeSysBatch
+}
+
 // VaultPolicies returns the set of Vault policies per task group, per task
 func (j *Job) VaultPolicies() map[string]map[string]*Vault {
 	policies := make(map[string]map[string]*Vault, len(j.TaskGroups))
@@ -3717,6 +3995,25 @@ type JobListStub struct {
 	SubmitTime        int64
 }
 
+// JobDispatchStub is used to return a subset of the information of a job
+// dispatched from a parameterized job
+type JobDispatchStub struct {
+	ID                string
+	ParentID          string
+	Stop              bool
+	Status            string
+	StatusDescription string
+	Meta              map[string]string
+
+	// PayloadSize is the size of the uncompressed dispatch payload.
+	PayloadSize int
+
+	IdempotencyToken string
+	SubmitTime       int64
+	CreateIndex      uint64
+	ModifyIndex      uint64
+}
+
 // JobSummary summarizes the state of the allocations of a job
 type JobSummary struct {
 	// JobID is the ID of the job the summary is for
@@ -3754,6 +4051,10 @@ type JobChildrenSummary struct {
 	Pending int64
 	Running int64
 	Dead    int64
+
+	// Queued is the number of dispatched jobs held back by the
+	// max_concurrent limit of the parameterized job
+	Queued int64
 }
 
 // Copy returns a new copy of a JobChildrenSummary
@@ -3776,6 +4077,7 @@ type TaskGroupSummary struct {
 	Running  int
 	Starting int
 	Lost     int
+	Unknown  int
 }
 
 const (
@@ -3845,6 +4147,22 @@ type UpdateStrategy struct {
 	// Canary is the number of canaries to deploy when a change to the task
 	// group is detected.
 	Canary int
+
+	// AutoPromote declares that the canaries should be promoted automatically
+	// once all canaries of the deployment are healthy.
+	AutoPromote bool
+
+	// PromoteAfter is the time the canaries must be healthy before they are
+	// promoted automatically.
+	PromoteAfter time.Duration
+
+	// Steps are the stages of a multi-step rollout. Each step is advanced
+	// once its allocations are healthy and its pause has elapsed.
+	Steps []*UpdateStep
+
+	// Analysis is the metric analysis the deployment of the task group is
+	// gated on.
+	Analysis *DeploymentAnalysis
 }
 
 func (u *UpdateStrategy) Copy() *UpdateStrategy {
@@ -3854,6 +4172,8 @@ func (u *UpdateStrategy) Copy() *UpdateStrategy {
 
 	copy := new(UpdateStrategy)
 	*copy = *u
+	copy.Steps = copyUpdateSteps(u.Steps)
+	copy.Analysis = u.Analysis.Copy()
 	return copy
 }
 
@@ -3893,6 +4213,253 @@ func (u *UpdateStrategy) Validate() error {
 	if u.Stagger <= 0 {
 		multierror.Append(&mErr, fmt.Errorf("Stagger must be greater than zero: %v", u.Stagger))
 	}
+	if u.PromoteAfter < 0 {
+		multierror.Append(&mErr, fmt.Errorf("Promote after may not be less than zero: %v", u.PromoteAfter))
+	}
+	if u.PromoteAfter > 0 && !u.AutoPromote {
+		multierror.Append(&mErr, fmt.Errorf("Promote after requires auto promote to be enabled"))
+	}
+	if len(u.Steps) != 0 {
+		if u.Canary != 0 {
+			multierror.Append(&mErr, fmt.Errorf("Canary count can not be set with steps, use a canary step instead"))
+		}
+		if u.AutoPromote {
+			multierror.Append(&mErr, fmt.Errorf("Auto promote can not be set with steps"))
+		}
+	}
+
+	lastPercent := 0
+	for i, step := range u.Steps {
+		if err := step.Validate(); err != nil {
+			multierror.Append(&mErr, fmt.Errorf("Step %d validation failed: %v", i+1, err))
+			continue
+		}
+		if step.Canary != 0 && i != 0 {
+			multierror.Append(&mErr, fmt.Errorf("Step %d: only the first step may be a canary step", i+1))
+		}
+		if step.Percent != 0 {
+			if step.Percent <= lastPercent {
+				multierror.Append(&mErr, fmt.Errorf("Step %d: percent must be greater than the previous step's: %d <= %d", i+1, step.Percent, lastPercent))
+			}
+			lastPercent = step.Percent
+		}
+	}
+
+	if err := u.Analysis.Validate(); err != nil {
+		multierror.Append(&mErr, fmt.Errorf("Analysis validation failed: %v", err))
+	}
+
+	return mErr.ErrorOrNil()
+}
+
+// Canaries returns the number of canaries to create when a change to the task
+// group is detected, which is either the canary count or the canary count of
+// the first step.
+func (u *UpdateStrategy) Canaries() int {
+	if u == nil {
+		return 0
+	}
+	if len(u.Steps) != 0 {
+		return u.Steps[0].Canary
+	}
+	return u.Canary
+}
+
+// UpdateStep is a stage of a multi-step rollout. A step either places canaries
+// alongside the previous allocations or updates a percentage of the task
+// group.
+type UpdateStep struct {
+	// Canary is the number of canaries to create in the step. Only the first
+	// step may be a canary step.
+	Canary int
+
+	// Percent is the percentage of the task group's allocations that are
+	// updated once the step is reached.
+	Percent int
+
+	// Pause is the time the allocations of the step must be healthy before
+	// the rollout advances to the next step.
+	Pause time.Duration
+}
+
+func (s *UpdateStep) Copy() *UpdateStep {
+	if s == nil {
+		return nil
+	}
+
+	ns := new(UpdateStep)
+	*ns = *s
+	return ns
+}
+
+func (s *UpdateStep) Validate() error {
+	if s == nil {
+		return fmt.Errorf("Step must be set")
+	}
+
+	var mErr multierror.Error
+	if s.Canary < 0 {
+		multierror.Append(&mErr, fmt.Errorf("Canary count can not be less than zero: %d < 0", s.Canary))
+	}
+	if s.Percent < 0 || s.Percent > 100 {
+		multierror.Append(&mErr, fmt.Errorf("Percent must be between 1 and 100: %d", s.Percent))
+	}
+	if (s.Canary == 0) == (s.Percent == 0) {
+		multierror.Append(&mErr, fmt.Errorf("Exactly one of canary or percent must be set"))
+	}
+	if s.Pause < 0 {
+		multierror.Append(&mErr, fmt.Errorf("Pause may not be less than zero: %v", s.Pause))
+	}
+
+	return mErr.ErrorOrNil()
+}
+
+// Target returns the number of allocations out of the given total that are
+// updated once the step is reached. At least one allocation is updated.
+func (s *UpdateStep) Target(total int) int {
+	target := (s.Percent*total + 99) / 100
+	return helper.IntMax(target, 1)
+}
+
+func copyUpdateSteps(steps []*UpdateStep) []*UpdateStep {
+	if steps == nil {
+		return nil
+	}
+
+	c := make([]*UpdateStep, len(steps))
+	for i, s := range steps {
+		c[i] = s.Copy()
+	}
+	return c
+}
+
+const (
+	// DefaultAnalysisInterval is the default interval at which the metrics of
+	// a deployment analysis are queried.
+	DefaultAnalysisInterval = 30 * time.Second
+
+	// DefaultAnalysisFailureLimit is the default number of consecutive failed
+	// analysis runs that fail a deployment.
+	DefaultAnalysisFailureLimit = 1
+)
+
+// DeploymentAnalysis gates the deployment of a task group on application
+// metrics. The metrics are queried from a Prometheus compatible HTTP API
+// while the deployment of the task group is in progress, and the deployment
+// is failed if they are out of their thresholds.
+type DeploymentAnalysis struct {
+	// Address is the address of the Prometheus compatible query API
+	Address string
+
+	// Interval is the interval at which the metrics are queried
+	Interval time.Duration
+
+	// FailureLimit is the number of consecutive failed analysis runs that
+	// fail the deployment.
+	FailureLimit int
+
+	// Metrics are the metrics to analyze
+	Metrics []*AnalysisMetric
+}
+
+func (a *DeploymentAnalysis) Copy() *DeploymentAnalysis {
+	if a == nil {
+		return nil
+	}
+
+	na := new(DeploymentAnalysis)
+	*na = *a
+	if a.Metrics != nil {
+		na.Metrics = make([]*AnalysisMetric, len(a.Metrics))
+		for i, m := range a.Metrics {
+			na.Metrics[i] = m.Copy()
+		}
+	}
+	return na
+}
+
+func (a *DeploymentAnalysis) Validate() error {
+	if a == nil {
+		return nil
+	}
+
+	var mErr multierror.Error
+	if a.Address == "" {
+		multierror.Append(&mErr, fmt.Errorf("Address must be set"))
+	} else if u, err := url.Parse(a.Address); err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		multierror.Append(&mErr, fmt.Errorf("Address must be an http or https URL: %q", a.Address))
+	}
+	if a.Interval <= 0 {
+		multierror.Append(&mErr, fmt.Errorf("Interval must be greater than zero: %v", a.Interval))
+	}
+	if a.FailureLimit < 1 {
+		multierror.Append(&mErr, fmt.Errorf("Failure limit can not be less than one: %d < 1", a.FailureLimit))
+	}
+	if len(a.Metrics) == 0 {
+		multierror.Append(&mErr, fmt.Errorf("At least one metric must be specified"))
+	}
+
+	names := make(map[string]struct{}, len(a.Metrics))
+	for i, m := range a.Metrics {
+		if _, ok := names[m.Name]; ok {
+			multierror.Append(&mErr, fmt.Errorf("Metric %d redefines %q", i+1, m.Name))
+		}
+		names[m.Name] = struct{}{}
+
+		if err := m.Validate(); err != nil {
+			multierror.Append(&mErr, fmt.Errorf("Metric %d validation failed: %v", i+1, err))
+		}
+	}
+
+	return mErr.ErrorOrNil()
+}
+
+// AnalysisMetric is a metric of a deployment analysis and its thresholds.
+type AnalysisMetric struct {
+	// Name is the name of the metric
+	Name string
+
+	// Query is the query returning the value of the metric. The
+	// ${NOMAD_JOB_ID}, ${NOMAD_GROUP_NAME} and ${NOMAD_DEPLOYMENT_ID}
+	// variables are interpolated.
+	Query string
+
+	// Min and Max are the inclusive thresholds of the value of the metric.
+	// At least one of them must be set.
+	Min *float64
+	Max *float64
+}
+
+func (m *AnalysisMetric) Copy() *AnalysisMetric {
+	if m == nil {
+		return nil
+	}
+
+	nm := new(AnalysisMetric)
+	*nm = *m
+	if m.Min != nil {
+		nm.Min = helper.Float64ToPtr(*m.Min)
+	}
+	if m.Max != nil {
+		nm.Max = helper.Float64ToPtr(*m.Max)
+	}
+	return nm
+}
+
+func (m *AnalysisMetric) Validate() error {
+	var mErr multierror.Error
+	if m.Name == "" {
+		multierror.Append(&mErr, fmt.Errorf("Name must be set"))
+	}
+	if m.Query == "" {
+		multierror.Append(&mErr, fmt.Errorf("Query must be set"))
+	}
+	if m.Min == nil && m.Max == nil {
+		multierror.Append(&mErr, fmt.Errorf("Minimum or maximum must be set"))
+	}
+	if m.Min != nil && m.Max != nil && *m.Min > *m.Max {
+		multierror.Append(&mErr, fmt.Errorf("Minimum must be less than or equal to maximum: %v > %v", *m.Min, *m.Max))
+	}
 
 	return mErr.ErrorOrNil()
 }
@@ -3912,6 +4479,29 @@ const (
 	PeriodicSpecTest = "_internal_test"
 )
 
+const (
+	// PeriodicExcludeDateFormat is the format of dates excluded from launching
+	// a periodic job.
+	PeriodicExcludeDateFormat = "2006-01-02"
+
+	// periodicExcludeHorizonYears bounds how far ahead the next launch of a
+	// periodic job that isn't excluded is searched for.
+	periodicExcludeHorizonYears = 5
+)
+
+const (
+	// PeriodicCatchupSkip skips any launches that were missed while the
+	// periodic job could not be launched.
+	PeriodicCatchupSkip = "skip"
+
+	// PeriodicCatchupRunOnce launches only the most recent missed launch.
+	PeriodicCatchupRunOnce = "run_once"
+
+	// PeriodicCatchupRunAll launches every missed launch within the catch-up
+	// lookback.
+	PeriodicCatchupRunAll = "run_all"
+)
+
 // Periodic defines the interval a job should be run at.
 type PeriodicConfig struct {
 	// Enabled determines if the job should be run periodically.
@@ -3921,6 +4511,15 @@ type PeriodicConfig struct {
 	// on the SpecType.
 	Spec string
 
+	// Specs are additional cron expressions the job is run at. The job is
+	// launched at the earliest time matching any of Spec and Specs.
+	Specs []string
+
+	// Exclude is a list of dates, formatted as YYYY-MM-DD, and cron
+	// expressions. Launches on an excluded date or at a time matching an
+	// excluded cron expression are skipped.
+	Exclude []string
+
 	// SpecType defines the format of the spec.
 	SpecType string
 
@@ -3934,6 +4533,15 @@ type PeriodicConfig struct {
 	// Reference: https://www.iana.org/time-zones
 	TimeZone string
 
+	// Catchup is the policy used for launches that were missed because there
+	// was no leader or the job was disabled. It defaults to run_once.
+	Catchup string
+
+	// CatchupLookback bounds how far back missed launches are caught up.
+	// Missed launches older than the lookback are skipped. A zero value
+	// places no bound on run_once and is not allowed with run_all.
+	CatchupLookback time.Duration
+
 	// location is the time zone to evaluate the launch time against
 	location *time.Location
 }
@@ -3944,6 +4552,8 @@ func (p *PeriodicConfig) Copy() *PeriodicConfig {
 	}
 	np := new(PeriodicConfig)
 	*np = *p
+	np.Specs = helper.CopySliceString(p.Specs)
+	np.Exclude = helper.CopySliceString(p.Exclude)
 	return np
 }
 
@@ -3966,27 +4576,79 @@ func (p *PeriodicConfig) Validate() error {
 
 	switch p.SpecType {
 	case PeriodicSpecCron:
-		// Validate the cron spec
+		// Validate the cron specs
 		if _, err := cronexpr.Parse(p.Spec); err != nil {
 			multierror.Append(&mErr, fmt.Errorf("Invalid cron spec %q: %v", p.Spec, err))
 		}
+		for _, spec := range p.Specs {
+			if _, err := cronexpr.Parse(spec); err != nil {
+				multierror.Append(&mErr, fmt.Errorf("Invalid cron spec %q: %v", spec, err))
+			}
+		}
 	case PeriodicSpecTest:
 		// No-op
 	default:
 		multierror.Append(&mErr, fmt.Errorf("Unknown periodic specification type %q", p.SpecType))
 	}
 
-	return mErr.ErrorOrNil()
-}
-
-func (p *PeriodicConfig) Canonicalize() {
-	// Load the location
-	l, err := time.LoadLocation(p.TimeZone)
-	if err != nil {
-		p.location = time.UTC
+	if len(p.Specs) != 0 && p.SpecType != PeriodicSpecCron {
+		multierror.Append(&mErr, fmt.Errorf("Multiple specs are only supported with spec type %q", PeriodicSpecCron))
 	}
 
-	p.location = l
+	for _, exclude := range p.Exclude {
+		if _, err := time.Parse(PeriodicExcludeDateFormat, exclude); err == nil {
+			continue
+		}
+		if _, err := cronexpr.Parse(exclude); err != nil {
+			multierror.Append(&mErr, fmt.Errorf("Invalid exclusion %q: must be a date (YYYY-MM-DD) or a cron expression", exclude))
+		}
+	}
+
+	if p.CatchupLookback < 0 {
+		multierror.Append(&mErr, fmt.Errorf("Catch-up lookback may not be less than zero"))
+	}
+
+	switch p.Catchup {
+	case "", PeriodicCatchupSkip, PeriodicCatchupRunOnce:
+	case PeriodicCatchupRunAll:
+		if p.CatchupLookback == 0 {
+			multierror.Append(&mErr, fmt.Errorf("Catch-up policy %q requires a catch-up lookback", p.Catchup))
+		}
+	default:
+		multierror.Append(&mErr, fmt.Errorf("Unknown catch-up policy %q", p.Catchup))
+	}
+
+	// Check that the exclusions don't rule out every launch
+	if p.SpecType == PeriodicSpecCron && mErr.ErrorOrNil() == nil {
+		location := time.UTC
+		if p.TimeZone != "" {
+			location, _ = time.LoadLocation(p.TimeZone)
+		}
+		if _, err := p.Next(time.Now().In(location)); err != nil {
+			multierror.Append(&mErr, fmt.Errorf("Invalid periodic config: %v", err))
+		}
+	}
+
+	return mErr.ErrorOrNil()
+}
+
+// GetCatchup returns the catch-up policy of the periodic config, defaulting
+// to run_once for jobs that don't specify one.
+func (p *PeriodicConfig) GetCatchup() string {
+	if p.Catchup == "" {
+		return PeriodicCatchupRunOnce
+	}
+	return p.Catchup
+}
+
+func (p *PeriodicConfig) Canonicalize() {
+	// Load the location
+	l, err := time.LoadLocation(p.TimeZone)
+	if err != nil {
+		p.location = time.UTC
+	}
+
+	p.location = l
 }
 
 // CronParseNext is a helper that parses the next time for the given expression
@@ -4009,8 +4671,31 @@ func CronParseNext(e *cronexpr.Expression, fromTime time.Time, spec string) (t t
 func (p *PeriodicConfig) Next(fromTime time.Time) (time.Time, error) {
 	switch p.SpecType {
 	case PeriodicSpecCron:
-		if e, err := cronexpr.Parse(p.Spec); err == nil {
-			return CronParseNext(e, fromTime, p.Spec)
+		// Parse the specs and exclusions once rather than for every launch
+		specs := p.cronSpecs()
+		exclusions := p.exclusions(fromTime.Location())
+
+		// Excluded days and hours are skipped as a whole, so the search past
+		// excluded launches is bounded by time rather than by their number
+		horizon := fromTime.AddDate(periodicExcludeHorizonYears, 0, 0)
+		next := fromTime
+		for skipped := false; ; skipped = true {
+			var err error
+			next, err = periodicNextCron(specs, next)
+			if err != nil || next.IsZero() {
+				return next, err
+			}
+			if skipped && next.After(horizon) {
+				return time.Time{}, fmt.Errorf("every launch within %d years is excluded", periodicExcludeHorizonYears)
+			}
+
+			excluded, skipTo := periodicExcluded(exclusions, next)
+			if !excluded {
+				return next, nil
+			}
+			if !skipTo.IsZero() {
+				next = skipTo
+			}
 		}
 	case PeriodicSpecTest:
 		split := strings.Split(p.Spec, ",")
@@ -4040,6 +4725,136 @@ func (p *PeriodicConfig) Next(fromTime time.Time) (time.Time, error) {
 	return time.Time{}, nil
 }
 
+// periodicCronSpec is a parsed cron spec of a periodic config
+type periodicCronSpec struct {
+	spec string
+	expr *cronexpr.Expression
+}
+
+// cronSpecs returns the parsed cron specs of the periodic config. Specs that
+// fail to parse are ignored.
+func (p *PeriodicConfig) cronSpecs() []*periodicCronSpec {
+	specs := make([]*periodicCronSpec, 0, len(p.Specs)+1)
+	for _, spec := range append([]string{p.Spec}, p.Specs...) {
+		e, err := cronexpr.Parse(spec)
+		if err != nil {
+			continue
+		}
+		specs = append(specs, &periodicCronSpec{spec: spec, expr: e})
+	}
+	return specs
+}
+
+// periodicExclusion is a parsed exclusion of a periodic config, which is
+// either a date or a cron expression. Cron expressions matching every minute
+// of an hour, or of a day, exclude the whole hour or day.
+type periodicExclusion struct {
+	spec      string
+	date      time.Time
+	expr      *cronexpr.Expression
+	wholeHour bool
+	wholeDay  bool
+}
+
+// exclusions returns the parsed exclusions of the periodic config, with dates
+// in the passed location. Exclusions that fail to parse are ignored.
+func (p *PeriodicConfig) exclusions(location *time.Location) []*periodicExclusion {
+	exclusions := make([]*periodicExclusion, 0, len(p.Exclude))
+	for _, exclude := range p.Exclude {
+		if date, err := time.ParseInLocation(PeriodicExcludeDateFormat, exclude, location); err == nil {
+			exclusions = append(exclusions, &periodicExclusion{spec: exclude, date: date})
+			continue
+		}
+
+		e, err := cronexpr.Parse(exclude)
+		if err != nil {
+			continue
+		}
+		wholeHour, wholeDay := cronWholeHourDay(exclude)
+		exclusions = append(exclusions, &periodicExclusion{
+			spec:      exclude,
+			expr:      e,
+			wholeHour: wholeHour,
+			wholeDay:  wholeDay,
+		})
+	}
+	return exclusions
+}
+
+// cronWholeHourDay returns whether the cron expression matches every minute
+// of the hours it matches, and whether it also matches every hour of the days
+// it matches.
+func cronWholeHourDay(spec string) (bool, bool) {
+	fields := strings.Fields(spec)
+	var minute, hour string
+	switch len(fields) {
+	case 5, 6:
+		minute, hour = fields[0], fields[1]
+	case 7:
+		if !cronWildcard(fields[0], 59) {
+			return false, false
+		}
+		minute, hour = fields[1], fields[2]
+	default:
+		return false, false
+	}
+
+	wholeHour := cronWildcard(minute, 59)
+	return wholeHour, wholeHour && cronWildcard(hour, 23)
+}
+
+// cronWildcard returns whether the cron field matches all of its values from
+// zero to max
+func cronWildcard(field string, max int) bool {
+	return field == "*" || field == "*/1" || field == fmt.Sprintf("0-%d", max)
+}
+
+// periodicNextCron returns the earliest time after the passed time matching
+// any of the cron specs.
+func periodicNextCron(specs []*periodicCronSpec, fromTime time.Time) (time.Time, error) {
+	var next time.Time
+	for _, spec := range specs {
+		t, err := CronParseNext(spec.expr, fromTime, spec.spec)
+		if err != nil {
+			return time.Time{}, err
+		}
+		if !t.IsZero() && (next.IsZero() || t.Before(next)) {
+			next = t
+		}
+	}
+	return next, nil
+}
+
+// periodicExcluded returns whether the passed launch time is excluded. If the
+// launch is excluded by a date or by a cron expression matching its whole day
+// or hour, the time to continue searching for launches from is also returned,
+// so that the rest of the excluded day or hour is skipped.
+func periodicExcluded(exclusions []*periodicExclusion, launch time.Time) (bool, time.Time) {
+	y, m, d := launch.Date()
+	endOfDay := time.Date(y, m, d+1, 0, 0, 0, 0, launch.Location()).Add(-time.Second)
+	for _, exclusion := range exclusions {
+		if !exclusion.date.IsZero() {
+			if ey, em, ed := exclusion.date.Date(); ey == y && em == m && ed == d {
+				return true, endOfDay
+			}
+			continue
+		}
+
+		t, err := CronParseNext(exclusion.expr, launch.Add(-time.Second), exclusion.spec)
+		if err != nil || !t.Equal(launch) {
+			continue
+		}
+		switch {
+		case exclusion.wholeDay:
+			return true, endOfDay
+		case exclusion.wholeHour:
+			return true, time.Date(y, m, d, launch.Hour()+1, 0, 0, 0, launch.Location()).Add(-time.Second)
+		}
+		return true, time.Time{}
+	}
+	return false, time.Time{}
+}
+
 // GetLocation returns the location to use for determining the time zone to run
 // the periodic job against.
 func (p *PeriodicConfig) GetLocation() *time.Location {
@@ -4057,17 +4872,101 @@ const (
 	PeriodicLaunchSuffix = "/periodic-"
 )
 
+const (
+	// PeriodicLaunchStatusLaunched marks a launch made at its scheduled time
+	// or forced by an operator.
+	PeriodicLaunchStatusLaunched = "launched"
+
+	// PeriodicLaunchStatusCaughtUp marks a missed launch that was launched by
+	// the job's catch-up policy.
+	PeriodicLaunchStatusCaughtUp = "caught-up"
+
+	// PeriodicLaunchStatusSkipped marks a missed launch that was not launched.
+	PeriodicLaunchStatusSkipped = "skipped"
+
+	// PeriodicLaunchHistoryLimit is the number of launch events retained for
+	// each periodic job.
+	PeriodicLaunchHistoryLimit = 25
+)
+
 // PeriodicLaunch tracks the last launch time of a periodic job.
 type PeriodicLaunch struct {
 	ID        string    // ID of the periodic job.
 	Namespace string    // Namespace of the periodic job
 	Launch    time.Time // The last launch time.
 
+	// History is the set of most recent launch events, oldest first.
+	History []*PeriodicLaunchEvent
+
 	// Raft Indexes
 	CreateIndex uint64
 	ModifyIndex uint64
 }
 
+// PeriodicLaunchEvent records the outcome of a single launch of a periodic
+// job.
+type PeriodicLaunchEvent struct {
+	// Launch is the launch time the event is for.
+	Launch time.Time
+
+	// Status is one of launched, caught-up or skipped.
+	Status string
+
+	// Description gives the reason for the status.
+	Description string
+}
+
+func (p *PeriodicLaunch) Copy() *PeriodicLaunch {
+	if p == nil {
+		return nil
+	}
+	np := new(PeriodicLaunch)
+	*np = *p
+	if p.History != nil {
+		np.History = make([]*PeriodicLaunchEvent, len(p.History))
+		for i, e := range p.History {
+			ne := *e
+			np.History[i] = &ne
+		}
+	}
+	return np
+}
+
+// AddEvents records the passed launch events in the launch history. An event
+// for a launch time that is already recorded is ignored so that a caught-up
+// or skipped launch keeps its status once the launched job is registered. The
+// last launch time is advanced to the latest event and the history is
+// truncated to PeriodicLaunchHistoryLimit events.
+func (p *PeriodicLaunch) AddEvents(events ...*PeriodicLaunchEvent) {
+	for _, event := range events {
+		if event == nil {
+			continue
+		}
+
+		known := false
+		for _, e := range p.History {
+			if e.Launch.Equal(event.Launch) {
+				known = true
+				break
+			}
+		}
+		if !known {
+			p.History = append(p.History, event)
+		}
+
+		if event.Launch.After(p.Launch) {
+			p.Launch = event.Launch
+		}
+	}
+
+	sort.SliceStable(p.History, func(i, j int) bool {
+		return p.History[i].Launch.Before(p.History[j].Launch)
+	})
+	if n := len(p.History); n > PeriodicLaunchHistoryLimit {
+		p.History = p.History[n-PeriodicLaunchHistoryLimit:]
+	}
+}
+
 const (
 	DispatchPayloadForbidden = "forbidden"
 	DispatchPayloadOptional  = "optional"
@@ -4088,6 +4987,12 @@ type ParameterizedJobConfig struct {
 
 	// MetaOptional is metadata keys that may be specified by the dispatcher
 	MetaOptional []string
+
+	// MaxConcurrent is the maximum number of dispatched jobs that may be
+	// pending or running at once. Jobs dispatched beyond the limit are queued
+	// and released by the leader as running jobs complete. Zero means no
+	// limit.
+	MaxConcurrent int
 }
 
 func (d *ParameterizedJobConfig) Validate() error {
@@ -4104,6 +5009,10 @@ func (d *ParameterizedJobConfig) Validate() error {
 		multierror.Append(&mErr, fmt.Errorf("Required and optional meta keys should be disjoint. Following keys exist in both: %v", offending))
 	}
 
+	if d.MaxConcurrent < 0 {
+		multierror.Append(&mErr, fmt.Errorf("Max concurrent dispatched jobs may not be less than zero: %d", d.MaxConcurrent))
+	}
+
 	return mErr.ErrorOrNil()
 }
 
@@ -4131,6 +5040,23 @@ func DispatchedID(templateID string, t time.Time) string {
 	return fmt.Sprintf("%s%s%d-%s", templateID, DispatchLaunchSuffix, t.Unix(), u)
 }
 
+// DispatchRequestHash returns the hash of the meta and payload of a dispatch
+// request, used to detect idempotency tokens reused for different input.
+func DispatchRequestHash(meta map[string]string, payload []byte) string {
+	keys := make([]string, 0, len(meta))
+	for k := range meta {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	h := sha256.New()
+	for _, k := range keys {
+		fmt.Fprintf(h, "%d:%s%d:%s", len(k), k, len(meta[k]), meta[k])
+	}
+	h.Write(payload)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
 // DispatchPayloadConfig configures how a task gets its input from a job dispatch
 type DispatchPayloadConfig struct {
 	// File specifies a relative path to where the input data should be written
@@ -4265,7 +5191,7 @@ func NewRestartPolicy(jobType string) *RestartPolicy {
 	case JobTypeService, JobTypeSystem:
 		rp := DefaultServiceJobRestartPolicy
 		return &rp
-	case JobTypeBatch:
+	case JobTypeBatch, JobTypeSysBatch:
 		rp := DefaultBatchJobRestartPolicy
 		return &rp
 	}
@@ -4586,6 +5512,12 @@ type TaskGroup struct {
 	// Spread can be specified at the task group level to express spreading
 	// allocations across a desired attribute, such as datacenter
 	Spreads []*Spread
+
+	// MaxClientDisconnect, if set, configures the client to allow placed
+	// allocations for tasks in this group to attempt to resume running
+	// without a restart if the node is disconnected for less than this
+	// duration.
+	MaxClientDisconnect *time.Duration
 }
 
 func (tg *TaskGroup) Copy() *TaskGroup {
@@ -4614,6 +5546,10 @@ func (tg *TaskGroup) Copy() *TaskGroup {
 	if tg.EphemeralDisk != nil {
 		ntg.EphemeralDisk = tg.EphemeralDisk.Copy()
 	}
+
+	if tg.MaxClientDisconnect != nil {
+		ntg.MaxClientDisconnect = helper.TimeToPtr(*tg.MaxClientDisconnect)
+	}
 	return ntg
 }
 
@@ -4667,7 +5603,7 @@ func (tg *TaskGroup) Validate(j *Job) error {
 			mErr.Errors = append(mErr.Errors, outer)
 		}
 	}
-	if j.Type == JobTypeSystem {
+	if j.Type == JobTypeSystem || j.Type == JobTypeSysBatch {
 		if tg.Affinities != nil {
 			mErr.Errors = append(mErr.Errors, fmt.Errorf("System jobs may not have an affinity stanza"))
 		}
@@ -4688,7 +5624,7 @@ func (tg *TaskGroup) Validate(j *Job) error {
 		mErr.Errors = append(mErr.Errors, fmt.Errorf("Task Group %v should have a restart policy", tg.Name))
 	}
 
-	if j.Type == JobTypeSystem {
+	if j.Type == JobTypeSystem || j.Type == JobTypeSysBatch {
 		if tg.Spreads != nil {
 			mErr.Errors = append(mErr.Errors, fmt.Errorf("System jobs may not have a spread stanza"))
 		}
@@ -4701,7 +5637,7 @@ func (tg *TaskGroup) Validate(j *Job) error {
 		}
 	}
 
-	if j.Type == JobTypeSystem {
+	if j.Type == JobTypeSystem || j.Type == JobTypeSysBatch {
 		if tg.ReschedulePolicy != nil {
 			mErr.Errors = append(mErr.Errors, fmt.Errorf("System jobs should not have a reschedule policy"))
 		}
@@ -4715,6 +5651,14 @@ func (tg *TaskGroup) Validate(j *Job) error {
 		}
 	}
 
+	if tg.MaxClientDisconnect != nil {
+		if j.Type == JobTypeSystem || j.Type == JobTypeSysBatch {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("System jobs may not have a max_client_disconnect"))
+		} else if *tg.MaxClientDisconnect < 0 {
+			mErr.Errors = append(mErr.Errors, errors.New("max_client_disconnect cannot be negative"))
+		}
+	}
+
 	if tg.EphemeralDisk != nil {
 		if err := tg.EphemeralDisk.Validate(); err != nil {
 			mErr.Errors = append(mErr.Errors, err)
@@ -4730,6 +5674,9 @@ func (tg *TaskGroup) Validate(j *Job) error {
 		default:
 			mErr.Errors = append(mErr.Errors, fmt.Errorf("Job type %q does not allow update block", j.Type))
 		}
+		if len(u.Steps) != 0 && j.Type != JobTypeService {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("Job type %q does not allow update steps", j.Type))
+		}
 		if err := u.Validate(); err != nil {
 			mErr.Errors = append(mErr.Errors, err)
 		}
@@ -5468,7 +6415,7 @@ func (t *Task) Validate(ephemeralDisk *EphemeralDisk, jobType string) error {
 		}
 	}
 
-	if jobType == JobTypeSystem {
+	if jobType == JobTypeSystem || jobType == JobTypeSysBatch {
 		if t.Affinities != nil {
 			mErr.Errors = append(mErr.Errors, fmt.Errorf("System jobs may not have an affinity stanza"))
 		}
@@ -6919,6 +7866,14 @@ type Vault struct {
 	// Policies is the set of policies that the task needs access to
 	Policies []string
 
+	// Cluster is the name of the Vault cluster, configured by a vault block of
+	// the agents, to derive the token from
+	Cluster string
+
+	// Namespace is the Vault namespace to create the token in. If empty, the
+	// namespace of the cluster configuration is used.
+	Namespace string
+
 	// Env marks whether the Vault Token should be exposed as an environment
 	// variable
 	Env bool
@@ -6934,6 +7889,7 @@ type Vault struct {
 
 func DefaultVaultBlock() *Vault {
 	return &Vault{
+		Cluster:    config.VaultDefaultCluster,
 		Env:        true,
 		ChangeMode: VaultChangeModeRestart,
 	}
@@ -6954,6 +7910,18 @@ func (v *Vault) Canonicalize() {
 	if v.ChangeSignal != "" {
 		v.ChangeSignal = strings.ToUpper(v.ChangeSignal)
 	}
+	if v.Cluster == "" {
+		v.Cluster = config.VaultDefaultCluster
+	}
+}
+
+// GetCluster returns the name of the Vault cluster of the block. Jobs
+// registered before Vault clusters could be selected use the default cluster.
+func (v *Vault) GetCluster() string {
+	if v.Cluster == "" {
+		return config.VaultDefaultCluster
+	}
+	return v.Cluster
 }
 
 // Validate returns if the Vault block is valid.
@@ -6998,6 +7966,7 @@ const (
 	// deployment can be in.
 	DeploymentStatusDescriptionRunning               = "Deployment is running"
 	DeploymentStatusDescriptionRunningNeedsPromotion = "Deployment is running but requires promotion"
+	DeploymentStatusDescriptionRunningAutoPromotion  = "Deployment is running pending automatic promotion"
 	DeploymentStatusDescriptionPaused                = "Deployment is paused"
 	DeploymentStatusDescriptionSuccessful            = "Deployment completed successfully"
 	DeploymentStatusDescriptionStoppedJob            = "Cancelled because job is stopped"
@@ -7005,6 +7974,7 @@ const (
 	DeploymentStatusDescriptionFailedAllocations     = "Failed due to unhealthy allocations"
 	DeploymentStatusDescriptionProgressDeadline      = "Failed due to progress deadline"
 	DeploymentStatusDescriptionFailedByUser          = "Deployment marked as failed"
+	DeploymentStatusDescriptionFailedAnalysis        = "Failed due to metric analysis"
 )
 
 // DeploymentStatusDescriptionRollback is used to get the status description of
@@ -7025,6 +7995,32 @@ func DeploymentStatusDescriptionNoRollbackTarget(baseDescription string) string
 	return fmt.Sprintf("%s - no stable job version to auto revert to", baseDescription)
 }
 
+// DeploymentStatusDescriptionAutoPromoted is used to get the status description of
+// a deployment whose canaries were promoted automatically after being healthy
+// for the bake time.
+func DeploymentStatusDescriptionAutoPromoted(bakeTime time.Duration) string {
+	if bakeTime == 0 {
+		return fmt.Sprintf("%s - canaries automatically promoted once healthy", DeploymentStatusDescriptionRunning)
+	}
+	return fmt.Sprintf("%s - canaries automatically promoted after being healthy for %v", DeploymentStatusDescriptionRunning, bakeTime)
+}
+
+// DeploymentStatusDescriptionStepAdvanced is used to get the status description
+// of a deployment whose task group was advanced to the given rollout step. The
+// step is one-based and past the number of steps once all are completed.
+func DeploymentStatusDescriptionStepAdvanced(group string, step, steps int) string {
+	if step > steps {
+		return fmt.Sprintf("%s - task group %q completed its %d rollout steps", DeploymentStatusDescriptionRunning, group, steps)
+	}
+	return fmt.Sprintf("%s - task group %q advanced to rollout step %d of %d", DeploymentStatusDescriptionRunning, group, step, steps)
+}
+
+// DeploymentStatusDescriptionAnalysisFailure is used to get the status description of
+// a deployment that failed because of the metric analysis of a task group.
+func DeploymentStatusDescriptionAnalysisFailure(group, reason string) string {
+	return fmt.Sprintf("%s of task group %q: %s", DeploymentStatusDescriptionFailedAnalysis, group, reason)
+}
+
 // Deployment is the object that represents a job deployment which is used to
 // transition a job between versions.
 type Deployment struct {
@@ -7148,6 +8144,27 @@ func (d *Deployment) RequiresPromotion() bool {
 	return false
 }
 
+// HasAutoPromote returns whether all task groups of the deployment that
+// require promotion have auto promote set or are promoted by advancing their
+// rollout steps.
+func (d *Deployment) HasAutoPromote() bool {
+	if d == nil || len(d.TaskGroups) == 0 {
+		return false
+	}
+
+	autoPromote := false
+	for _, group := range d.TaskGroups {
+		if group.DesiredCanaries == 0 || group.Promoted {
+			continue
+		}
+		if !group.AutoPromote && len(group.Steps) == 0 {
+			return false
+		}
+		autoPromote = true
+	}
+	return autoPromote
+}
+
 func (d *Deployment) GoString() string {
 	base := fmt.Sprintf("Deployment ID %q for job %q has status %q (%v):", d.ID, d.JobID, d.Status, d.StatusDescription)
 	for group, state := range d.TaskGroups {
@@ -7162,6 +8179,21 @@ type DeploymentState struct {
 	// reverted on failure
 	AutoRevert bool
 
+	// AutoPromote marks whether the canaries of the task group should be
+	// promoted automatically once healthy
+	AutoPromote bool
+
+	// PromoteAfter is the time the canaries must be healthy before they are
+	// promoted automatically.
+	PromoteAfter time.Duration
+
+	// Steps are the stages of the task group's multi-step rollout
+	Steps []*UpdateStep
+
+	// CurrentStep is the index of the rollout step in progress. It is equal
+	// to the number of steps once all steps have been completed.
+	CurrentStep int
+
 	// ProgressDeadline is the deadline by which an allocation must transition
 	// to healthy before the deployment is considered failed.
 	ProgressDeadline time.Duration
@@ -7191,6 +8223,11 @@ type DeploymentState struct {
 
 	// UnhealthyAllocs are allocations that have been marked as unhealthy.
 	UnhealthyAllocs int
+
+	// AnalysisFailures is the number of consecutive failed metric analyses
+	// of the task group. It is kept in the deployment so that the count
+	// survives a leader election.
+	AnalysisFailures int
 }
 
 func (d *DeploymentState) GoString() string {
@@ -7202,13 +8239,27 @@ func (d *DeploymentState) GoString() string {
 	base += fmt.Sprintf("\n\tHealthy: %d", d.HealthyAllocs)
 	base += fmt.Sprintf("\n\tUnhealthy: %d", d.UnhealthyAllocs)
 	base += fmt.Sprintf("\n\tAutoRevert: %v", d.AutoRevert)
+	base += fmt.Sprintf("\n\tAutoPromote: %v", d.AutoPromote)
+	if len(d.Steps) != 0 {
+		base += fmt.Sprintf("\n\tStep: %d/%d", d.CurrentStep, len(d.Steps))
+	}
 	return base
 }
 
+// Step returns the rollout step in progress or nil if the task group has no
+// steps or all steps have been completed.
+func (d *DeploymentState) Step() *UpdateStep {
+	if d == nil || d.CurrentStep >= len(d.Steps) {
+		return nil
+	}
+	return d.Steps[d.CurrentStep]
+}
+
 func (d *DeploymentState) Copy() *DeploymentState {
 	c := &DeploymentState{}
 	*c = *d
 	c.PlacedCanaries = helper.CopySliceString(d.PlacedCanaries)
+	c.Steps = copyUpdateSteps(d.Steps)
 	return c
 }
 
@@ -7343,8 +8394,26 @@ const (
 	AllocClientStatusComplete = "complete"
 	AllocClientStatusFailed   = "failed"
 	AllocClientStatusLost     = "lost"
+	AllocClientStatusUnknown  = "unknown"
 )
 
+// AllocStateField records a single field of an allocation whose transitions
+// are tracked in its AllocStates.
+type AllocStateField uint8
+
+const (
+	AllocStateFieldClientStatus AllocStateField = iota
+)
+
+// AllocState records a single transition of an allocation field made by the
+// servers, such as the client status becoming unknown when the node running
+// the allocation disconnects.
+type AllocState struct {
+	Field AllocStateField
+	Value string
+	Time  time.Time
+}
+
 // Allocation is used to allocate the placement of a task group to a node.
 type Allocation struct {
 	// msgpack omit empty fields during serialization
@@ -7444,10 +8513,21 @@ type Allocation struct {
 	// in order to place this allocation
 	PreemptedAllocations []string
 
+	// AllocStates track meta data associated with changes to the state of the
+	// whole allocation, like becoming lost.
+	AllocStates []*AllocState
+
 	// PreemptedByAllocation tracks the alloc ID of the allocation that caused this allocation
 	// to stop running because it got preempted
 	PreemptedByAllocation string
 
+	// SignedIdentities is the workload identity JWT of each task, signed by
+	// the servers when the allocation is placed
+	SignedIdentities map[string]string
+
+	// SigningKeyID is the ID of the root key that signed the identities
+	SigningKeyID string
+
 	// Raft Indexes
 	CreateIndex uint64
 	ModifyIndex uint64
@@ -7488,6 +8568,18 @@ func (a *Allocation) CopySkipJob() *Allocation {
 	return a.copyImpl(false)
 }
 
+// Sanitize returns the allocation without its workload identities, which are
+// only handed to the node running it. The allocation is returned as is if it
+// has no identities, otherwise a shallow copy is made.
+func (a *Allocation) Sanitize() *Allocation {
+	if a == nil || a.SignedIdentities == nil {
+		return a
+	}
+	clean := *a
+	clean.SignedIdentities = nil
+	return &clean
+}
+
 func (a *Allocation) copyImpl(job bool) *Allocation {
 	if a == nil {
 		return nil
@@ -7524,9 +8616,42 @@ func (a *Allocation) copyImpl(job bool) *Allocation {
 
 	na.RescheduleTracker = a.RescheduleTracker.Copy()
 	na.PreemptedAllocations = helper.CopySliceString(a.PreemptedAllocations)
+	na.SignedIdentities = helper.CopyMapStringString(a.SignedIdentities)
+
+	if a.AllocStates != nil {
+		states := make([]*AllocState, len(a.AllocStates))
+		for i, state := range a.AllocStates {
+			s := *state
+			states[i] = &s
+		}
+		na.AllocStates = states
+	}
 	return na
 }
 
+// AppendState records a transition of the given field of the allocation.
+func (a *Allocation) AppendState(field AllocStateField, value string) {
+	a.AllocStates = append(a.AllocStates, &AllocState{
+		Field: field,
+		Value: value,
+		Time:  time.Now().UTC(),
+	})
+}
+
+// NeedsToReconnect returns true if the last known client status of the
+// allocation recorded by the servers is unknown, meaning the allocation was
+// running on a node that disconnected and has not been reconciled since.
+func (a *Allocation) NeedsToReconnect() bool {
+	for i := len(a.AllocStates) - 1; i >= 0; i-- {
+		state := a.AllocStates[i]
+		if state.Field != AllocStateFieldClientStatus {
+			continue
+		}
+		return state.Value == AllocClientStatusUnknown
+	}
+	return false
+}
+
 // TerminalStatus returns if the desired or actual status is terminal and
 // will no longer transition.
 func (a *Allocation) TerminalStatus() bool {
@@ -8198,6 +9323,7 @@ const (
 	EvalTriggerRetryFailedAlloc  = "alloc-failure"
 	EvalTriggerQueuedAllocs      = "queued-allocs"
 	EvalTriggerPreemption        = "preemption"
+	EvalTriggerReconnect         = "reconnect"
 )
 
 const (
@@ -8223,6 +9349,21 @@ const (
 	// check if they are terminal. If so, we delete these out of the system.
 	CoreJobDeploymentGC = "deployment-gc"
 
+	// CoreJobLocalTokenExpiredGC is used for the garbage collection of
+	// expired local ACL tokens. We periodically scan local tokens and delete
+	// those whose expiration time has passed.
+	CoreJobLocalTokenExpiredGC = "local-token-expired-gc"
+
+	// CoreJobGlobalTokenExpiredGC is used for the garbage collection of
+	// expired global ACL tokens. It only runs in the authoritative region,
+	// other regions remove the tokens through replication.
+	CoreJobGlobalTokenExpiredGC = "global-token-expired-gc"
+
+	// CoreJobRootKeyRotateOrGC is used to rotate the active root key of the
+	// keyring once it is old enough and to garbage collect inactive root
+	// keys no longer used by any live allocation.
+	CoreJobRootKeyRotateOrGC = "root-key-rotate-gc"
+
 	// CoreJobForceGC is used to force garbage collection of all GCable objects.
 	CoreJobForceGC = "force-gc"
 )
@@ -9020,16 +10161,561 @@ type ACLPolicyUpsertRequest struct {
 	WriteRequest
 }
 
+// ACLRole is used to represent a named set of ACL policies which may be
+// attached to tokens
+type ACLRole struct {
+	Name        string   // Unique name
+	Description string   // Human readable
+	Policies    []string // Policies this role grants
+	Hash        []byte
+	CreateIndex uint64
+	ModifyIndex uint64
+}
+
+// SetHash is used to compute and set the hash of the ACL role
+func (r *ACLRole) SetHash() []byte {
+	// Initialize a 256bit Blake2 hash (32 bytes)
+	hash, err := blake2b.New256(nil)
+	if err != nil {
+		panic(err)
+	}
+
+	// Write all the user set fields
+	hash.Write([]byte(r.Name))
+	hash.Write([]byte(r.Description))
+	for _, policyName := range r.Policies {
+		hash.Write([]byte(policyName))
+	}
+
+	// Finalize the hash
+	hashVal := hash.Sum(nil)
+
+	// Set and return the hash
+	r.Hash = hashVal
+	return hashVal
+}
+
+func (r *ACLRole) Stub() *ACLRoleListStub {
+	return &ACLRoleListStub{
+		Name:        r.Name,
+		Description: r.Description,
+		Policies:    r.Policies,
+		Hash:        r.Hash,
+		CreateIndex: r.CreateIndex,
+		ModifyIndex: r.ModifyIndex,
+	}
+}
+
+// Validate is used to sanity check a role
+func (r *ACLRole) Validate() error {
+	var mErr multierror.Error
+	if !validPolicyName.MatchString(r.Name) {
+		err := fmt.Errorf("invalid name '%s'", r.Name)
+		mErr.Errors = append(mErr.Errors, err)
+	}
+	if len(r.Description) > maxPolicyDescriptionLength {
+		err := fmt.Errorf("description longer than %d", maxPolicyDescriptionLength)
+		mErr.Errors = append(mErr.Errors, err)
+	}
+	if len(r.Policies) == 0 {
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("role missing policies"))
+	}
+	return mErr.ErrorOrNil()
+}
+
+// ACLRoleListStub is used to for listing ACL roles
+type ACLRoleListStub struct {
+	Name        string
+	Description string
+	Policies    []string
+	Hash        []byte
+	CreateIndex uint64
+	ModifyIndex uint64
+}
+
+// ACLRoleListRequest is used to request a list of roles
+type ACLRoleListRequest struct {
+	QueryOptions
+}
+
+// ACLRoleSpecificRequest is used to query a specific role
+type ACLRoleSpecificRequest struct {
+	Name string
+	QueryOptions
+}
+
+// ACLRoleSetRequest is used to query a set of roles
+type ACLRoleSetRequest struct {
+	Names []string
+	QueryOptions
+}
+
+// ACLRoleListResponse is used for a list request
+type ACLRoleListResponse struct {
+	Roles []*ACLRoleListStub
+	QueryMeta
+}
+
+// SingleACLRoleResponse is used to return a single role
+type SingleACLRoleResponse struct {
+	Role *ACLRole
+	QueryMeta
+}
+
+// ACLRoleSetResponse is used to return a set of roles
+type ACLRoleSetResponse struct {
+	Roles map[string]*ACLRole
+	QueryMeta
+}
+
+// ACLRoleDeleteRequest is used to delete a set of roles
+type ACLRoleDeleteRequest struct {
+	Names []string
+	WriteRequest
+}
+
+// ACLRoleUpsertRequest is used to upsert a set of roles
+type ACLRoleUpsertRequest struct {
+	Roles []*ACLRole
+	WriteRequest
+}
+
+const (
+	// ACLAuthMethodTypeJWT is an auth method which validates JWTs against a
+	// static set of public keys
+	ACLAuthMethodTypeJWT = "jwt"
+
+	// ACLAuthMethodTypeOIDC is an auth method which validates ID tokens
+	// against the keys published by an OpenID Connect provider
+	ACLAuthMethodTypeOIDC = "oidc"
+
+	// ACLAuthMethodTokenLocalityLocal creates tokens local to the region
+	// of the login
+	ACLAuthMethodTokenLocalityLocal = "local"
+
+	// ACLAuthMethodTokenLocalityGlobal creates global tokens
+	ACLAuthMethodTokenLocalityGlobal = "global"
+
+	// DefaultACLAuthMethodMaxTokenTTL is the lifetime of tokens created by
+	// an auth method if none is set
+	DefaultACLAuthMethodMaxTokenTTL = time.Hour
+
+	// ACLBindingRuleBindTypePolicy binds the login token to a policy
+	ACLBindingRuleBindTypePolicy = "policy"
+
+	// ACLBindingRuleBindTypeRole binds the login token to a role
+	ACLBindingRuleBindTypeRole = "role"
+
+	// maxACLBindingRuleSelectorLength limits the length of a binding rule
+	// selector
+	maxACLBindingRuleSelectorLength = 1024
+)
+
+// ACLAuthMethod is used to configure how identity tokens issued by an external
+// provider are exchanged for ACL tokens
+type ACLAuthMethod struct {
+	Name          string        // Unique name
+	Type          string        // jwt or oidc
+	TokenLocality string        // local or global
+	MaxTokenTTL   time.Duration // Lifetime of the tokens created by a login
+	Config        *ACLAuthMethodConfig
+	Hash          []byte
+	CreateIndex   uint64
+	ModifyIndex   uint64
+}
+
+// ACLAuthMethodConfig is the configuration used to validate the identity
+// tokens of an auth method and map their claims
+type ACLAuthMethodConfig struct {
+	// JWTValidationPubKeys are PEM encoded public keys used to validate the
+	// signature of JWTs. Only used by JWT auth methods.
+	JWTValidationPubKeys []string
+
+	// JWKS is a JSON Web Key Set document used to validate the signature of
+	// JWTs. Only used by JWT auth methods.
+	JWKS string
+
+	// OIDCDiscoveryURL is the issuer URL of the OpenID Connect provider. Only
+	// used by OIDC auth methods.
+	OIDCDiscoveryURL string
+
+	// DiscoveryCaPem are PEM encoded CA certificates used to connect to the
+	// OpenID Connect provider. The system roots are used if unset.
+	DiscoveryCaPem []string
+
+	// BoundIssuer is the required issuer of the token. Defaults to the
+	// discovery URL for OIDC auth methods.
+	BoundIssuer string
+
+	// BoundAudiences is the set of audiences of which the token must have at
+	// least one
+	BoundAudiences []string
+
+	// ClaimMappings maps scalar claims to the names they are addressed by in
+	// binding rules as "value.<name>"
+	ClaimMappings map[string]string
+
+	// ListClaimMappings maps list claims to the names they are addressed by
+	// in binding rules as "list.<name>"
+	ListClaimMappings map[string]string
+
+	// ClockSkewLeeway is the leeway allowed when validating the time based
+	// claims of the token
+	ClockSkewLeeway time.Duration
+}
+
+// Canonicalize sets the defaults of an auth method
+func (a *ACLAuthMethod) Canonicalize() {
+	if a.TokenLocality == "" {
+		a.TokenLocality = ACLAuthMethodTokenLocalityLocal
+	}
+	if a.MaxTokenTTL == 0 {
+		a.MaxTokenTTL = DefaultACLAuthMethodMaxTokenTTL
+	}
+}
+
+// SetHash is used to compute and set the hash of the auth method
+func (a *ACLAuthMethod) SetHash() []byte {
+	// Initialize a 256bit Blake2 hash (32 bytes)
+	hash, err := blake2b.New256(nil)
+	if err != nil {
+		panic(err)
+	}
+
+	// Write all the user set fields
+	hash.Write([]byte(a.Name))
+	hash.Write([]byte(a.Type))
+	hash.Write([]byte(a.TokenLocality))
+	hash.Write([]byte(a.MaxTokenTTL.String()))
+	if c := a.Config; c != nil {
+		for _, key := range c.JWTValidationPubKeys {
+			hash.Write([]byte(key))
+		}
+		hash.Write([]byte(c.JWKS))
+		hash.Write([]byte(c.OIDCDiscoveryURL))
+		for _, ca := range c.DiscoveryCaPem {
+			hash.Write([]byte(ca))
+		}
+		hash.Write([]byte(c.BoundIssuer))
+		for _, aud := range c.BoundAudiences {
+			hash.Write([]byte(aud))
+		}
+		for _, mappings := range []map[string]string{c.ClaimMappings, c.ListClaimMappings} {
+			keys := make([]string, 0, len(mappings))
+			for k := range mappings {
+				keys = append(keys, k)
+			}
+			sort.Strings(keys)
+			for _, k := range keys {
+				hash.Write([]byte(k))
+				hash.Write([]byte(mappings[k]))
+			}
+		}
+		hash.Write([]byte(c.ClockSkewLeeway.String()))
+	}
+
+	// Finalize the hash
+	hashVal := hash.Sum(nil)
+
+	// Set and return the hash
+	a.Hash = hashVal
+	return hashVal
+}
+
+func (a *ACLAuthMethod) Stub() *ACLAuthMethodListStub {
+	return &ACLAuthMethodListStub{
+		Name:          a.Name,
+		Type:          a.Type,
+		TokenLocality: a.TokenLocality,
+		MaxTokenTTL:   a.MaxTokenTTL,
+		Hash:          a.Hash,
+		CreateIndex:   a.CreateIndex,
+		ModifyIndex:   a.ModifyIndex,
+	}
+}
+
+// Validate is used to sanity check an auth method
+func (a *ACLAuthMethod) Validate() error {
+	var mErr multierror.Error
+	if !validPolicyName.MatchString(a.Name) {
+		err := fmt.Errorf("invalid name '%s'", a.Name)
+		mErr.Errors = append(mErr.Errors, err)
+	}
+	switch a.TokenLocality {
+	case ACLAuthMethodTokenLocalityLocal, ACLAuthMethodTokenLocalityGlobal:
+	default:
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("token locality must be local or global"))
+	}
+	if a.MaxTokenTTL <= 0 {
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("max token TTL must be positive"))
+	}
+
+	c := a.Config
+	if c == nil {
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("missing config"))
+		return mErr.ErrorOrNil()
+	}
+	switch a.Type {
+	case ACLAuthMethodTypeJWT:
+		if len(c.JWTValidationPubKeys) == 0 && c.JWKS == "" {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("jwt auth method requires validation public keys or a JWKS"))
+		}
+		if _, err := jwt.ParsePublicKeysPEM(c.JWTValidationPubKeys); err != nil {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("invalid validation public keys: %v", err))
+		}
+		if c.JWKS != "" {
+			if _, err := jwt.ParseJWKS([]byte(c.JWKS)); err != nil {
+				mErr.Errors = append(mErr.Errors, fmt.Errorf("invalid JWKS: %v", err))
+			}
+		}
+		if c.OIDCDiscoveryURL != "" {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("jwt auth method cannot use an OIDC discovery URL"))
+		}
+	case ACLAuthMethodTypeOIDC:
+		if c.OIDCDiscoveryURL == "" {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("oidc auth method requires a discovery URL"))
+		} else if u, err := url.Parse(c.OIDCDiscoveryURL); err != nil || (u.Scheme != "https" && u.Scheme != "http") {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("invalid OIDC discovery URL %q", c.OIDCDiscoveryURL))
+		}
+		if len(c.JWTValidationPubKeys) != 0 || c.JWKS != "" {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("oidc auth method cannot use static keys"))
+		}
+		if len(c.BoundAudiences) == 0 {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("oidc auth method requires bound audiences"))
+		}
+	default:
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("auth method type must be jwt or oidc"))
+	}
+	if c.ClockSkewLeeway < 0 {
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("clock skew leeway cannot be negative"))
+	}
+	return mErr.ErrorOrNil()
+}
+
+// ACLAuthMethodListStub is used to for listing auth methods
+type ACLAuthMethodListStub struct {
+	Name          string
+	Type          string
+	TokenLocality string
+	MaxTokenTTL   time.Duration
+	Hash          []byte
+	CreateIndex   uint64
+	ModifyIndex   uint64
+}
+
+// ACLAuthMethodListRequest is used to request a list of auth methods
+type ACLAuthMethodListRequest struct {
+	QueryOptions
+}
+
+// ACLAuthMethodSpecificRequest is used to query a specific auth method
+type ACLAuthMethodSpecificRequest struct {
+	Name string
+	QueryOptions
+}
+
+// ACLAuthMethodSetRequest is used to query a set of auth methods
+type ACLAuthMethodSetRequest struct {
+	Names []string
+	QueryOptions
+}
+
+// ACLAuthMethodListResponse is used for a list request
+type ACLAuthMethodListResponse struct {
+	AuthMethods []*ACLAuthMethodListStub
+	QueryMeta
+}
+
+// SingleACLAuthMethodResponse is used to return a single auth method
+type SingleACLAuthMethodResponse struct {
+	AuthMethod *ACLAuthMethod
+	QueryMeta
+}
+
+// ACLAuthMethodSetResponse is used to return a set of auth methods
+type ACLAuthMethodSetResponse struct {
+	AuthMethods map[string]*ACLAuthMethod
+	QueryMeta
+}
+
+// ACLAuthMethodDeleteRequest is used to delete a set of auth methods
+type ACLAuthMethodDeleteRequest struct {
+	Names []string
+	WriteRequest
+}
+
+// ACLAuthMethodUpsertRequest is used to upsert a set of auth methods
+type ACLAuthMethodUpsertRequest struct {
+	AuthMethods []*ACLAuthMethod
+	WriteRequest
+}
+
+// ACLBindingRule maps the identity of a login to the policy or role granted
+// to the resulting token
+type ACLBindingRule struct {
+	ID          string // Unique ID (UUID)
+	Description string // Human readable
+	AuthMethod  string // Name of the auth method the rule applies to
+	Selector    string // Expression the login identity must match
+	BindType    string // policy or role
+	BindName    string // Name of the policy or role, may be interpolated
+	Hash        []byte
+	CreateIndex uint64
+	ModifyIndex uint64
+}
+
+// SetHash is used to compute and set the hash of the binding rule
+func (b *ACLBindingRule) SetHash() []byte {
+	// Initialize a 256bit Blake2 hash (32 bytes)
+	hash, err := blake2b.New256(nil)
+	if err != nil {
+		panic(err)
+	}
+
+	// Write all the user set fields
+	hash.Write([]byte(b.ID))
+	hash.Write([]byte(b.Description))
+	hash.Write([]byte(b.AuthMethod))
+	hash.Write([]byte(b.Selector))
+	hash.Write([]byte(b.BindType))
+	hash.Write([]byte(b.BindName))
+
+	// Finalize the hash
+	hashVal := hash.Sum(nil)
+
+	// Set and return the hash
+	b.Hash = hashVal
+	return hashVal
+}
+
+func (b *ACLBindingRule) Stub() *ACLBindingRuleListStub {
+	return &ACLBindingRuleListStub{
+		ID:          b.ID,
+		Description: b.Description,
+		AuthMethod:  b.AuthMethod,
+		BindType:    b.BindType,
+		BindName:    b.BindName,
+		Hash:        b.Hash,
+		CreateIndex: b.CreateIndex,
+		ModifyIndex: b.ModifyIndex,
+	}
+}
+
+// Validate is used to sanity check a binding rule
+func (b *ACLBindingRule) Validate() error {
+	var mErr multierror.Error
+	if b.AuthMethod == "" {
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("missing auth method"))
+	}
+	if len(b.Description) > maxPolicyDescriptionLength {
+		err := fmt.Errorf("description longer than %d", maxPolicyDescriptionLength)
+		mErr.Errors = append(mErr.Errors, err)
+	}
+	if len(b.Selector) > maxACLBindingRuleSelectorLength {
+		err := fmt.Errorf("selector longer than %d", maxACLBindingRuleSelectorLength)
+		mErr.Errors = append(mErr.Errors, err)
+	} else if _, err := auth.ParseSelector(b.Selector); err != nil {
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("invalid selector: %v", err))
+	}
+	switch b.BindType {
+	case ACLBindingRuleBindTypePolicy, ACLBindingRuleBindTypeRole:
+	default:
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("bind type must be policy or role"))
+	}
+	if b.BindName == "" {
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("missing bind name"))
+	} else if err := auth.ValidateBindName(b.BindName); err != nil {
+		mErr.Errors = append(mErr.Errors, err)
+	}
+	return mErr.ErrorOrNil()
+}
+
+// ACLBindingRuleListStub is used to for listing binding rules
+type ACLBindingRuleListStub struct {
+	ID          string
+	Description string
+	AuthMethod  string
+	BindType    string
+	BindName    string
+	Hash        []byte
+	CreateIndex uint64
+	ModifyIndex uint64
+}
+
+// ACLBindingRuleListRequest is used to request a list of binding rules
+type ACLBindingRuleListRequest struct {
+	QueryOptions
+}
+
+// ACLBindingRuleSpecificRequest is used to query a specific binding rule
+type ACLBindingRuleSpecificRequest struct {
+	ID string
+	QueryOptions
+}
+
+// ACLBindingRuleSetRequest is used to query a set of binding rules
+type ACLBindingRuleSetRequest struct {
+	IDs []string
+	QueryOptions
+}
+
+// ACLBindingRuleListResponse is used for a list request
+type ACLBindingRuleListResponse struct {
+	BindingRules []*ACLBindingRuleListStub
+	QueryMeta
+}
+
+// SingleACLBindingRuleResponse is used to return a single binding rule
+type SingleACLBindingRuleResponse struct {
+	BindingRule *ACLBindingRule
+	QueryMeta
+}
+
+// ACLBindingRuleSetResponse is used to return a set of binding rules
+type ACLBindingRuleSetResponse struct {
+	BindingRules map[string]*ACLBindingRule
+	QueryMeta
+}
+
+// ACLBindingRuleDeleteRequest is used to delete a set of binding rules
+type ACLBindingRuleDeleteRequest struct {
+	IDs []string
+	WriteRequest
+}
+
+// ACLBindingRuleUpsertRequest is used to upsert a set of binding rules
+type ACLBindingRuleUpsertRequest struct {
+	BindingRules []*ACLBindingRule
+	WriteRequest
+}
+
+// ACLBindingRuleUpsertResponse is used to return from an ACLBindingRuleUpsertRequest
+type ACLBindingRuleUpsertResponse struct {
+	BindingRules []*ACLBindingRule
+	WriteMeta
+}
+
 // ACLToken represents a client token which is used to Authenticate
 type ACLToken struct {
-	AccessorID  string   // Public Accessor ID (UUID)
-	SecretID    string   // Secret ID, private (UUID)
-	Name        string   // Human friendly name
-	Type        string   // Client or Management
-	Policies    []string // Policies this token ties to
-	Global      bool     // Global or Region local
-	Hash        []byte
-	CreateTime  time.Time // Time of creation
+	AccessorID string   // Public Accessor ID (UUID)
+	SecretID   string   // Secret ID, private (UUID)
+	Name       string   // Human friendly name
+	Type       string   // Client or Management
+	Policies   []string // Policies this token ties to
+	Roles      []string // Roles this token ties to
+	Global     bool     // Global or Region local
+	Hash       []byte
+	CreateTime time.Time // Time of creation
+
+	// ExpirationTime is the time after which the token can no longer be
+	// used, nil if the token never expires. It may be set on creation
+	// directly or through ExpirationTTL.
+	ExpirationTime *time.Time
+
+	// ExpirationTTL is the duration after its creation that the token
+	// expires. It is only used on creation to compute ExpirationTime.
+	ExpirationTTL time.Duration
+
 	CreateIndex uint64
 	ModifyIndex uint64
 }
@@ -9047,15 +10733,17 @@ var (
 )
 
 type ACLTokenListStub struct {
-	AccessorID  string
-	Name        string
-	Type        string
-	Policies    []string
-	Global      bool
-	Hash        []byte
-	CreateTime  time.Time
-	CreateIndex uint64
-	ModifyIndex uint64
+	AccessorID     string
+	Name           string
+	Type           string
+	Policies       []string
+	Roles          []string
+	Global         bool
+	Hash           []byte
+	CreateTime     time.Time
+	ExpirationTime *time.Time
+	CreateIndex    uint64
+	ModifyIndex    uint64
 }
 
 // SetHash is used to compute and set the hash of the ACL token
@@ -9072,11 +10760,19 @@ func (a *ACLToken) SetHash() []byte {
 	for _, policyName := range a.Policies {
 		hash.Write([]byte(policyName))
 	}
+	for _, roleName := range a.Roles {
+		// Prefix the roles so that replacing a policy with a role of the
+		// same name changes the hash
+		hash.Write([]byte("role:" + roleName))
+	}
 	if a.Global {
 		hash.Write([]byte("global"))
 	} else {
 		hash.Write([]byte("local"))
 	}
+	if a.ExpirationTime != nil {
+		hash.Write([]byte(a.ExpirationTime.String()))
+	}
 
 	// Finalize the hash
 	hashVal := hash.Sum(nil)
@@ -9088,15 +10784,17 @@ func (a *ACLToken) SetHash() []byte {
 
 func (a *ACLToken) Stub() *ACLTokenListStub {
 	return &ACLTokenListStub{
-		AccessorID:  a.AccessorID,
-		Name:        a.Name,
-		Type:        a.Type,
-		Policies:    a.Policies,
-		Global:      a.Global,
-		Hash:        a.Hash,
-		CreateTime:  a.CreateTime,
-		CreateIndex: a.CreateIndex,
-		ModifyIndex: a.ModifyIndex,
+		AccessorID:     a.AccessorID,
+		Name:           a.Name,
+		Type:           a.Type,
+		Policies:       a.Policies,
+		Roles:          a.Roles,
+		Global:         a.Global,
+		Hash:           a.Hash,
+		CreateTime:     a.CreateTime,
+		ExpirationTime: a.ExpirationTime,
+		CreateIndex:    a.CreateIndex,
+		ModifyIndex:    a.ModifyIndex,
 	}
 }
 
@@ -9108,19 +10806,42 @@ func (a *ACLToken) Validate() error {
 	}
 	switch a.Type {
 	case ACLClientToken:
-		if len(a.Policies) == 0 {
-			mErr.Errors = append(mErr.Errors, fmt.Errorf("client token missing policies"))
+		if len(a.Policies) == 0 && len(a.Roles) == 0 {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("client token missing policies or roles"))
 		}
 	case ACLManagementToken:
 		if len(a.Policies) != 0 {
 			mErr.Errors = append(mErr.Errors, fmt.Errorf("management token cannot be associated with policies"))
 		}
+		if len(a.Roles) != 0 {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("management token cannot be associated with roles"))
+		}
 	default:
 		mErr.Errors = append(mErr.Errors, fmt.Errorf("token type must be client or management"))
 	}
+	if a.ExpirationTTL < 0 {
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("token expiration TTL cannot be negative"))
+	}
+	if a.ExpirationTTL != 0 && a.ExpirationTime != nil {
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("token cannot set both expiration TTL and time"))
+	}
 	return mErr.ErrorOrNil()
 }
 
+// SetExpirationTime computes the expiration time of a new token from its
+// expiration TTL and creation time.
+func (a *ACLToken) SetExpirationTime() {
+	if a.ExpirationTTL != 0 {
+		expiration := a.CreateTime.Add(a.ExpirationTTL)
+		a.ExpirationTime = &expiration
+	}
+}
+
+// IsExpired returns whether the token has expired as of the given time.
+func (a *ACLToken) IsExpired(t time.Time) bool {
+	return a.ExpirationTime != nil && a.ExpirationTime.Before(t)
+}
+
 // PolicySubset checks if a given set of policies is a subset of the token
 func (a *ACLToken) PolicySubset(policies []string) bool {
 	// Hot-path the management tokens, superset of all policies.
@@ -9139,6 +10860,24 @@ func (a *ACLToken) PolicySubset(policies []string) bool {
 	return true
 }
 
+// RoleSubset checks if a given set of roles is a subset of the token
+func (a *ACLToken) RoleSubset(roles []string) bool {
+	// Hot-path the management tokens, superset of all roles.
+	if a.Type == ACLManagementToken {
+		return true
+	}
+	associatedRoles := make(map[string]struct{}, len(a.Roles))
+	for _, role := range a.Roles {
+		associatedRoles[role] = struct{}{}
+	}
+	for _, role := range roles {
+		if _, ok := associatedRoles[role]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
 // ACLTokenListRequest is used to request a list of tokens
 type ACLTokenListRequest struct {
 	GlobalOnly bool
@@ -9211,3 +10950,17 @@ type ACLTokenUpsertResponse struct {
 	Tokens []*ACLToken
 	WriteMeta
 }
+
+// ACLLoginRequest is used to exchange an identity token issued by the provider
+// of an auth method for an ACL token
+type ACLLoginRequest struct {
+	AuthMethodName string
+	LoginToken     string
+	WriteRequest
+}
+
+// ACLLoginResponse is used to return the token created by a login
+type ACLLoginResponse struct {
+	Token *ACLToken
+	WriteMeta
+}
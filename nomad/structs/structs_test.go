@@ -8,8 +8,10 @@ import (
 	"testing"
 	"time"
 
+	"github.com/golang/snappy"
 	"github.com/hashicorp/consul/api"
 	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/nomad/helper"
 	"github.com/hashicorp/nomad/helper/uuid"
 	"github.com/kr/pretty"
 	"github.com/stretchr/testify/assert"
@@ -304,6 +306,32 @@ func TestJob_Copy(t *testing.T) {
 	}
 }
 
+func TestJob_DispatchStub(t *testing.T) {
+	require := require.New(t)
+
+	j := testJob()
+	j.ID = "example" + DispatchLaunchSuffix + "123"
+	j.ParentID = "example"
+	j.Meta = map[string]string{"foo": "bar"}
+	j.Payload = snappy.Encode(nil, []byte("hello world"))
+	j.DispatchIdempotencyToken = "token"
+
+	stub := j.DispatchStub()
+	require.Equal(j.ID, stub.ID)
+	require.Equal("example", stub.ParentID)
+	require.Equal(j.Meta, stub.Meta)
+	require.Equal(11, stub.PayloadSize)
+	require.Equal("token", stub.IdempotencyToken)
+
+	// The metadata must be copied
+	stub.Meta["foo"] = "baz"
+	require.Equal("bar", j.Meta["foo"])
+
+	// Jobs without a payload report a zero payload size
+	j.Payload = nil
+	require.Zero(j.DispatchStub().PayloadSize)
+}
+
 func TestJob_IsPeriodic(t *testing.T) {
 	j := &Job{
 		Type: JobTypeService,
@@ -375,6 +403,37 @@ func TestJob_IsPeriodicActive(t *testing.T) {
 	}
 }
 
+func TestJob_SysBatchJob_Validate(t *testing.T) {
+	j := testJob()
+	j.Type = JobTypeSysBatch
+	j.TaskGroups[0].ReschedulePolicy = nil
+	j.TaskGroups[0].Update = nil
+	j.TaskGroups[0].Migrate = nil
+	j.Update = UpdateStrategy{}
+	j.Canonicalize()
+	require.NoError(t, j.Validate())
+
+	// Periodic and parameterized sysbatch jobs are allowed
+	j.Periodic = &PeriodicConfig{
+		Enabled:  true,
+		SpecType: PeriodicSpecCron,
+		Spec:     "*/5 * * * *",
+	}
+	require.NoError(t, j.Validate())
+
+	j.Periodic = nil
+	j.ParameterizedJob = &ParameterizedJobConfig{
+		Payload: DispatchPayloadOptional,
+	}
+	require.NoError(t, j.Validate())
+
+	// A reschedule policy is not allowed
+	j.TaskGroups[0].ReschedulePolicy = &ReschedulePolicy{Attempts: 1, Interval: time.Hour}
+	err := j.Validate()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "should not have a reschedule policy")
+}
+
 func TestJob_SystemJob_Validate(t *testing.T) {
 	j := testJob()
 	j.Type = JobTypeSystem
@@ -754,6 +813,26 @@ func TestTaskGroup_Validate(t *testing.T) {
 		t.Errorf("expected %s but found: %v", expected, err)
 	}
 
+	tg = &TaskGroup{
+		MaxClientDisconnect: helper.TimeToPtr(-1 * time.Minute),
+	}
+	err = tg.Validate(&Job{})
+	expected = `max_client_disconnect cannot be negative`
+	if !strings.Contains(err.Error(), expected) {
+		t.Errorf("expected %s but found: %v", expected, err)
+	}
+
+	for _, jobType := range []string{JobTypeSystem, JobTypeSysBatch} {
+		tg = &TaskGroup{
+			MaxClientDisconnect: helper.TimeToPtr(5 * time.Minute),
+		}
+		err = tg.Validate(&Job{Type: jobType})
+		expected = `System jobs may not have a max_client_disconnect`
+		if !strings.Contains(err.Error(), expected) {
+			t.Errorf("expected %s but found: %v", expected, err)
+		}
+	}
+
 	tg = &TaskGroup{
 		Name:  "web",
 		Count: 1,
@@ -1753,6 +1832,7 @@ func TestUpdateStrategy_Validate(t *testing.T) {
 		ProgressDeadline: -25,
 		AutoRevert:       false,
 		Canary:           -1,
+		PromoteAfter:     -1,
 	}
 
 	err := u.Validate()
@@ -1781,6 +1861,125 @@ func TestUpdateStrategy_Validate(t *testing.T) {
 	if !strings.Contains(mErr.Errors[7].Error(), "Healthy deadline must be less than progress deadline") {
 		t.Fatalf("err: %s", err)
 	}
+	if !strings.Contains(mErr.Errors[9].Error(), "Promote after may not be less than zero") {
+		t.Fatalf("err: %s", err)
+	}
+
+	// Promote after requires auto promote
+	u = DefaultUpdateStrategy.Copy()
+	u.PromoteAfter = time.Minute
+	err = u.Validate()
+	if err == nil || !strings.Contains(err.Error(), "Promote after requires auto promote") {
+		t.Fatalf("err: %v", err)
+	}
+
+	u.AutoPromote = true
+	if err := u.Validate(); err != nil {
+		t.Fatalf("err: %v", err)
+	}
+}
+
+func TestUpdateStrategy_Validate_Steps(t *testing.T) {
+	require := require.New(t)
+
+	u := DefaultUpdateStrategy.Copy()
+	u.Steps = []*UpdateStep{
+		{Canary: 1, Pause: 10 * time.Minute},
+		{Percent: 25, Pause: 30 * time.Minute},
+		{Percent: 100},
+	}
+	require.NoError(u.Validate())
+	require.Equal(1, u.Canaries())
+
+	u.Canary = 1
+	u.AutoPromote = true
+	u.Steps = []*UpdateStep{
+		{Percent: 50},
+		{Canary: 1},
+		{Percent: 50},
+		{Canary: 1, Percent: 100},
+		{Percent: 101, Pause: -1},
+	}
+	err := u.Validate()
+	require.Error(err)
+
+	mErr := err.(*multierror.Error)
+	require.Len(mErr.Errors, 6)
+	require.Contains(mErr.Errors[0].Error(), "Canary count can not be set with steps")
+	require.Contains(mErr.Errors[1].Error(), "Auto promote can not be set with steps")
+	require.Contains(mErr.Errors[2].Error(), "Step 2: only the first step may be a canary step")
+	require.Contains(mErr.Errors[3].Error(), "Step 3: percent must be greater than the previous step's")
+	require.Contains(mErr.Errors[4].Error(), "Step 4 validation failed")
+	require.Contains(mErr.Errors[4].Error(), "Exactly one of canary or percent must be set")
+	require.Contains(mErr.Errors[5].Error(), "Percent must be between 1 and 100")
+	require.Contains(mErr.Errors[5].Error(), "Pause may not be less than zero")
+}
+
+func TestUpdateStep_Target(t *testing.T) {
+	cases := []struct {
+		percent int
+		total   int
+		target  int
+	}{
+		{percent: 25, total: 10, target: 3},
+		{percent: 50, total: 10, target: 5},
+		{percent: 100, total: 10, target: 10},
+		{percent: 1, total: 10, target: 1},
+		{percent: 50, total: 0, target: 1},
+	}
+
+	for _, c := range cases {
+		s := &UpdateStep{Percent: c.percent}
+		if target := s.Target(c.total); target != c.target {
+			t.Fatalf("%d%% of %d: got %d; want %d", c.percent, c.total, target, c.target)
+		}
+	}
+}
+
+func TestDeploymentAnalysis_Validate(t *testing.T) {
+	require := require.New(t)
+
+	a := &DeploymentAnalysis{
+		Address:      "prometheus:9090",
+		Interval:     0,
+		FailureLimit: 0,
+		Metrics: []*AnalysisMetric{
+			{
+				Name:  "errors",
+				Query: "errors",
+				Min:   helper.Float64ToPtr(1),
+				Max:   helper.Float64ToPtr(0),
+			},
+			{
+				Name: "errors",
+			},
+		},
+	}
+
+	err := a.Validate()
+	require.Error(err)
+	mErr := err.(*multierror.Error)
+	require.Len(mErr.Errors, 6)
+	require.Contains(mErr.Errors[0].Error(), "Address must be an http or https URL")
+	require.Contains(mErr.Errors[1].Error(), "Interval must be greater than zero")
+	require.Contains(mErr.Errors[2].Error(), "Failure limit can not be less than one")
+	require.Contains(mErr.Errors[3].Error(), "Minimum must be less than or equal to maximum")
+	require.Contains(mErr.Errors[4].Error(), `Metric 2 redefines "errors"`)
+	require.Contains(mErr.Errors[5].Error(), "Metric 2 validation failed")
+
+	a = &DeploymentAnalysis{
+		Address:      "http://prometheus:9090",
+		Interval:     DefaultAnalysisInterval,
+		FailureLimit: DefaultAnalysisFailureLimit,
+		Metrics: []*AnalysisMetric{
+			{
+				Name:  "errors",
+				Query: "errors",
+				Max:   helper.Float64ToPtr(0.1),
+			},
+		},
+	}
+	require.NoError(a.Validate())
 }
 
 func TestResource_NetIndex(t *testing.T) {
@@ -2287,6 +2486,174 @@ func TestPeriodicConfig_ValidCron(t *testing.T) {
 	}
 }
 
+func TestPeriodicConfig_Validate_Catchup(t *testing.T) {
+	require := require.New(t)
+
+	p := &PeriodicConfig{Enabled: true, SpecType: PeriodicSpecCron, Spec: "@hourly"}
+	require.NoError(p.Validate())
+	require.Equal(PeriodicCatchupRunOnce, p.GetCatchup())
+
+	p.Catchup = PeriodicCatchupSkip
+	require.NoError(p.Validate())
+
+	p.Catchup = "bad"
+	err := p.Validate()
+	require.Error(err)
+	require.Contains(err.Error(), "Unknown catch-up policy")
+
+	p.Catchup = PeriodicCatchupRunAll
+	err = p.Validate()
+	require.Error(err)
+	require.Contains(err.Error(), "requires a catch-up lookback")
+
+	p.CatchupLookback = time.Hour
+	require.NoError(p.Validate())
+
+	p.CatchupLookback = -time.Hour
+	err = p.Validate()
+	require.Error(err)
+	require.Contains(err.Error(), "Catch-up lookback may not be less than zero")
+}
+
+func TestPeriodicLaunch_AddEvents(t *testing.T) {
+	require := require.New(t)
+
+	now := time.Now().Round(time.Second)
+	launch := &PeriodicLaunch{ID: "foo", Launch: now.Add(-time.Hour)}
+	launch.AddEvents(
+		&PeriodicLaunchEvent{Launch: now.Add(-2 * time.Minute), Status: PeriodicLaunchStatusSkipped},
+		&PeriodicLaunchEvent{Launch: now.Add(-time.Minute), Status: PeriodicLaunchStatusCaughtUp},
+	)
+	require.Len(launch.History, 2)
+	require.Equal(now.Add(-time.Minute), launch.Launch)
+
+	// The launched job of a caught up launch keeps its status
+	launch.AddEvents(&PeriodicLaunchEvent{Launch: now.Add(-time.Minute), Status: PeriodicLaunchStatusLaunched})
+	require.Len(launch.History, 2)
+	require.Equal(PeriodicLaunchStatusCaughtUp, launch.History[1].Status)
+
+	// The history is bounded and keeps the most recent events
+	for i := 0; i < PeriodicLaunchHistoryLimit; i++ {
+		launch.AddEvents(&PeriodicLaunchEvent{
+			Launch: now.Add(time.Duration(i) * time.Minute),
+			Status: PeriodicLaunchStatusLaunched,
+		})
+	}
+	require.Len(launch.History, PeriodicLaunchHistoryLimit)
+	require.Equal(now, launch.History[0].Launch)
+	require.Equal(now.Add(time.Duration(PeriodicLaunchHistoryLimit-1)*time.Minute), launch.Launch)
+
+	// Copies don't share events
+	c := launch.Copy()
+	c.History[0].Status = PeriodicLaunchStatusSkipped
+	require.Equal(PeriodicLaunchStatusLaunched, launch.History[0].Status)
+}
+
+func TestPeriodicConfig_Validate_SpecsExclude(t *testing.T) {
+	require := require.New(t)
+
+	p := &PeriodicConfig{
+		Enabled:  true,
+		SpecType: PeriodicSpecCron,
+		Spec:     "0 9 * * 1-5",
+		Specs:    []string{"0 12 * * 0,6"},
+		Exclude:  []string{"2019-12-25", "* * * 1 *"},
+	}
+	require.NoError(p.Validate())
+
+	p.Specs = append(p.Specs, "bad")
+	p.Exclude = append(p.Exclude, "2019-13-45")
+	err := p.Validate()
+	require.Error(err)
+	require.Contains(err.Error(), `Invalid cron spec "bad"`)
+	require.Contains(err.Error(), `Invalid exclusion "2019-13-45"`)
+
+	p = &PeriodicConfig{Enabled: true, SpecType: PeriodicSpecTest, Spec: "1", Specs: []string{"2"}}
+	err = p.Validate()
+	require.Error(err)
+	require.Contains(err.Error(), "Multiple specs are only supported")
+
+	// Exclusions ruling out every launch are rejected
+	p = &PeriodicConfig{
+		Enabled:  true,
+		SpecType: PeriodicSpecCron,
+		Spec:     "0 9 * * *",
+		Exclude:  []string{"0 9 * * *"},
+	}
+	err = p.Validate()
+	require.Error(err)
+	require.Contains(err.Error(), "is excluded")
+
+	// Long exclusions of frequent launches are allowed
+	p = &PeriodicConfig{
+		Enabled:  true,
+		SpecType: PeriodicSpecCron,
+		Spec:     "* * * * *",
+		Exclude:  []string{"* * * 1-11 *"},
+	}
+	require.NoError(p.Validate())
+}
+
+func TestPeriodicConfig_Next_SpecsExclude(t *testing.T) {
+	require := require.New(t)
+
+	// Weekdays at 09:00 and weekends at 12:00, excluding Christmas and
+	// Mondays in January.
+	p := &PeriodicConfig{
+		Enabled:  true,
+		SpecType: PeriodicSpecCron,
+		Spec:     "0 9 * * 1-5",
+		Specs:    []string{"0 12 * * 0,6"},
+		Exclude:  []string{"2019-12-25", "* * * 1 1"},
+	}
+	p.Canonicalize()
+
+	cases := []struct {
+		from     time.Time
+		expected time.Time
+	}{
+		{
+			// Monday
+			from:     time.Date(2019, 12, 23, 0, 0, 0, 0, time.UTC),
+			expected: time.Date(2019, 12, 23, 9, 0, 0, 0, time.UTC),
+		},
+		{
+			// Tuesday after the launch skips Christmas
+			from:     time.Date(2019, 12, 24, 10, 0, 0, 0, time.UTC),
+			expected: time.Date(2019, 12, 26, 9, 0, 0, 0, time.UTC),
+		},
+		{
+			// Friday after the launch runs Saturday at noon
+			from:     time.Date(2019, 12, 27, 10, 0, 0, 0, time.UTC),
+			expected: time.Date(2019, 12, 28, 12, 0, 0, 0, time.UTC),
+		},
+		{
+			// Sunday after the launch skips Monday in January
+			from:     time.Date(2020, 1, 5, 13, 0, 0, 0, time.UTC),
+			expected: time.Date(2020, 1, 7, 9, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, c := range cases {
+		next, err := p.Next(c.from)
+		require.NoError(err)
+		require.Equal(c.expected, next, "from %v", c.from)
+	}
+
+	// Everything excluded
+	p.Exclude = []string{"* * * * *"}
+	_, err := p.Next(time.Date(2019, 12, 23, 0, 0, 0, 0, time.UTC))
+	require.Error(err)
+
+	// Excluded months of launches every minute are skipped
+	p.Spec = "* * * * *"
+	p.Specs = nil
+	p.Exclude = []string{"* * * 12 *", "* 0-8 * 1 *"}
+	next, err := p.Next(time.Date(2019, 11, 30, 23, 59, 0, 0, time.UTC))
+	require.NoError(err)
+	require.Equal(time.Date(2020, 1, 1, 9, 0, 0, 0, time.UTC), next)
+}
+
 func TestPeriodicConfig_NextCron(t *testing.T) {
 	require := require.New(t)
 
@@ -2992,6 +3359,25 @@ func TestEvaluation_MsgPackTags(t *testing.T) {
 	assert.Equal(t, msgPackTags.Tag, reflect.StructTag(`codec:",omitempty"`))
 }
 
+func TestAllocation_NeedsToReconnect(t *testing.T) {
+	require := require.New(t)
+
+	alloc := &Allocation{ClientStatus: AllocClientStatusRunning}
+	require.False(alloc.NeedsToReconnect())
+
+	alloc.ClientStatus = AllocClientStatusUnknown
+	alloc.AppendState(AllocStateFieldClientStatus, AllocClientStatusUnknown)
+	require.True(alloc.NeedsToReconnect())
+
+	// The client reporting a status does not reconcile the allocation
+	alloc.ClientStatus = AllocClientStatusRunning
+	require.True(alloc.NeedsToReconnect())
+
+	alloc.AppendState(AllocStateFieldClientStatus, AllocClientStatusRunning)
+	require.False(alloc.NeedsToReconnect())
+	require.Len(alloc.Copy().AllocStates, 2)
+}
+
 func TestAllocation_Terminated(t *testing.T) {
 	type desiredState struct {
 		ClientStatus  string
@@ -3785,6 +4171,13 @@ func TestParameterizedJobConfig_Validate(t *testing.T) {
 	if err := d.Validate(); err == nil || !strings.Contains(err.Error(), "disjoint") {
 		t.Fatalf("Expected meta not being disjoint error: %v", err)
 	}
+
+	d.MetaRequired = nil
+	d.MaxConcurrent = -1
+
+	if err := d.Validate(); err == nil || !strings.Contains(err.Error(), "Max concurrent") {
+		t.Fatalf("Expected negative max concurrent error: %v", err)
+	}
 }
 
 func TestParameterizedJobConfig_Validate_NonBatch(t *testing.T) {
@@ -3893,6 +4286,149 @@ func TestACLTokenValidate(t *testing.T) {
 	assert.Nil(t, err)
 }
 
+func TestACLTokenValidate_Expiration(t *testing.T) {
+	tk := &ACLToken{
+		Type:          ACLManagementToken,
+		ExpirationTTL: -1 * time.Hour,
+	}
+	err := tk.Validate()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "cannot be negative")
+
+	expiration := time.Now().Add(time.Hour)
+	tk.ExpirationTTL = time.Hour
+	tk.ExpirationTime = &expiration
+	err = tk.Validate()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "both expiration TTL and time")
+
+	tk.ExpirationTime = nil
+	require.NoError(t, tk.Validate())
+}
+
+func TestACLTokenValidate_Roles(t *testing.T) {
+	tk := &ACLToken{
+		Type:  ACLClientToken,
+		Roles: []string{"foo"},
+	}
+	require.NoError(t, tk.Validate())
+
+	tk.Type = ACLManagementToken
+	err := tk.Validate()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "cannot be associated with roles")
+}
+
+func TestACLToken_RoleSubset(t *testing.T) {
+	tk := &ACLToken{
+		Type:  ACLClientToken,
+		Roles: []string{"foo", "bar"},
+	}
+	require.True(t, tk.RoleSubset(nil))
+	require.True(t, tk.RoleSubset([]string{"foo"}))
+	require.True(t, tk.RoleSubset([]string{"foo", "bar"}))
+	require.False(t, tk.RoleSubset([]string{"baz"}))
+
+	tk.Type = ACLManagementToken
+	require.True(t, tk.RoleSubset([]string{"baz"}))
+}
+
+func TestACLRoleValidate(t *testing.T) {
+	role := &ACLRole{Name: "invalid name"}
+	err := role.Validate()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "invalid name")
+	require.Contains(t, err.Error(), "missing policies")
+
+	role.Name = "ops"
+	role.Policies = []string{"read"}
+	require.NoError(t, role.Validate())
+}
+
+func TestACLAuthMethodValidate(t *testing.T) {
+	method := &ACLAuthMethod{Name: "invalid name", Type: "saml", Config: &ACLAuthMethodConfig{}}
+	err := method.Validate()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "invalid name")
+	require.Contains(t, err.Error(), "token locality")
+	require.Contains(t, err.Error(), "max token TTL")
+	require.Contains(t, err.Error(), "type must be jwt or oidc")
+
+	// Canonicalize sets the defaults
+	method.Name = "sso"
+	method.Canonicalize()
+	require.Equal(t, ACLAuthMethodTokenLocalityLocal, method.TokenLocality)
+	require.Equal(t, DefaultACLAuthMethodMaxTokenTTL, method.MaxTokenTTL)
+
+	// JWT auth methods require valid keys
+	method.Type = ACLAuthMethodTypeJWT
+	err = method.Validate()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "requires validation public keys or a JWKS")
+
+	method.Config.JWTValidationPubKeys = []string{"invalid"}
+	method.Config.JWKS = `{"keys":[]}`
+	err = method.Validate()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "invalid validation public keys")
+	require.Contains(t, err.Error(), "invalid JWKS")
+
+	method.Config.JWKS = `{"keys":[{"kty":"EC","crv":"P-256","x":"qLGi7PUTlQbaFFdfl7fIaAYS_T1xEsNguy_IftOlGXs","y":"W3ovaVm6EgO5f4H-THmRPpDO5LHfFn6OgAZTG4CQQrM"}]}`
+	method.Config.JWTValidationPubKeys = nil
+	require.NoError(t, method.Validate())
+
+	// OIDC auth methods require a discovery URL and no static keys
+	method.Type = ACLAuthMethodTypeOIDC
+	err = method.Validate()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "requires a discovery URL")
+	require.Contains(t, err.Error(), "cannot use static keys")
+	require.Contains(t, err.Error(), "requires bound audiences")
+
+	method.Config.JWKS = ""
+	method.Config.OIDCDiscoveryURL = "https://sso.example.com"
+	method.Config.BoundAudiences = []string{"nomad"}
+	require.NoError(t, method.Validate())
+
+	method.Config = nil
+	err = method.Validate()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "missing config")
+}
+
+func TestACLBindingRuleValidate(t *testing.T) {
+	rule := &ACLBindingRule{
+		Selector: `value.user ==`,
+		BindType: "token",
+		BindName: "${list.groups}",
+	}
+	err := rule.Validate()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "missing auth method")
+	require.Contains(t, err.Error(), "invalid selector")
+	require.Contains(t, err.Error(), "bind type must be policy or role")
+	require.Contains(t, err.Error(), "invalid bind name variable")
+
+	rule.AuthMethod = "sso"
+	rule.Selector = `value.user == "alice" or "ops" in list.groups`
+	rule.BindType = ACLBindingRuleBindTypeRole
+	rule.BindName = "team-${value.team}"
+	require.NoError(t, rule.Validate())
+}
+
+func TestACLToken_IsExpired(t *testing.T) {
+	now := time.Now().UTC()
+	tk := &ACLToken{CreateTime: now}
+	require.False(t, tk.IsExpired(now.Add(24*time.Hour)))
+
+	tk.ExpirationTTL = time.Hour
+	tk.SetExpirationTime()
+	require.NotNil(t, tk.ExpirationTime)
+	require.Equal(t, now.Add(time.Hour), *tk.ExpirationTime)
+	require.False(t, tk.IsExpired(now))
+	require.True(t, tk.IsExpired(now.Add(2*time.Hour)))
+}
+
 func TestACLTokenPolicySubset(t *testing.T) {
 	tk := &ACLToken{
 		Type:     ACLClientToken,
@@ -0,0 +1,239 @@
+package structs
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+const (
+	// VariablesApplyRPCMethod is the RPC method for upserting or deleting a
+	// variable
+	VariablesApplyRPCMethod = "Variables.Apply"
+
+	// VariablesReadRPCMethod is the RPC method for reading a variable
+	VariablesReadRPCMethod = "Variables.Read"
+
+	// VariablesListRPCMethod is the RPC method for listing variables
+	VariablesListRPCMethod = "Variables.List"
+
+	// MaxVariableSize is the maximum size of the encrypted items of a
+	// variable
+	MaxVariableSize = 64 * 1024
+
+	// VariableJobPathPrefix is the path prefix of the variables a task can
+	// read with its workload identity. The variables of a job are stored
+	// under "nomad/jobs/<job ID>".
+	VariableJobPathPrefix = "nomad/jobs"
+)
+
+var (
+	// validVariablePath is used to validate the path of a variable
+	validVariablePath = regexp.MustCompile("^[a-zA-Z0-9_.~/-]{1,128}$")
+)
+
+// VarOp is the operation of a variable apply request
+type VarOp string
+
+const (
+	// VarOpSet unconditionally upserts a variable
+	VarOpSet VarOp = "set"
+
+	// VarOpDelete unconditionally deletes a variable
+	VarOpDelete VarOp = "delete"
+
+	// VarOpCAS upserts a variable if its modify index matches the one of the
+	// request. An index of zero only creates the variable.
+	VarOpCAS VarOp = "cas"
+
+	// VarOpDeleteCAS deletes a variable if its modify index matches the one
+	// of the request
+	VarOpDeleteCAS VarOp = "delete-cas"
+)
+
+// VarOpResult is the result of a variable apply request
+type VarOpResult string
+
+const (
+	VarOpResultOk       VarOpResult = "ok"
+	VarOpResultConflict VarOpResult = "conflict"
+)
+
+// VariableMetadata is the metadata of a variable which can be listed without
+// decrypting it
+type VariableMetadata struct {
+	Namespace   string
+	Path        string
+	CreateIndex uint64
+	CreateTime  int64
+	ModifyIndex uint64
+	ModifyTime  int64
+}
+
+// VariableItems are the key/value pairs of a variable
+type VariableItems map[string]string
+
+// Size returns the size of the items in bytes
+func (vi VariableItems) Size() int {
+	var size int
+	for k, v := range vi {
+		size += len(k) + len(v)
+	}
+	return size
+}
+
+// VariableDecrypted is a variable with its items in plaintext. It is only
+// used in RPC and HTTP requests and responses and is never persisted.
+type VariableDecrypted struct {
+	VariableMetadata
+	Items VariableItems
+}
+
+// Copy returns a deep copy of the variable
+func (v *VariableDecrypted) Copy() *VariableDecrypted {
+	if v == nil {
+		return nil
+	}
+	nv := *v
+	if v.Items != nil {
+		nv.Items = make(VariableItems, len(v.Items))
+		for k, val := range v.Items {
+			nv.Items[k] = val
+		}
+	}
+	return &nv
+}
+
+// Validate returns an error if the variable is invalid
+func (v *VariableDecrypted) Validate() error {
+	if v.Namespace == "" {
+		return fmt.Errorf("variable namespace is required")
+	}
+	if err := ValidateVariablePath(v.Path); err != nil {
+		return err
+	}
+	if len(v.Items) == 0 {
+		return fmt.Errorf("variable missing items")
+	}
+	for k := range v.Items {
+		if k == "" {
+			return fmt.Errorf("variable item keys can not be empty")
+		}
+	}
+	if v.Items.Size() > MaxVariableSize {
+		return fmt.Errorf("variable items exceed the maximum size of %d bytes", MaxVariableSize)
+	}
+	return nil
+}
+
+// ValidateVariablePath returns an error if the path is not a valid variable
+// path. Paths under "nomad/" are reserved, except the job paths.
+func ValidateVariablePath(path string) error {
+	if !validVariablePath.MatchString(path) {
+		return fmt.Errorf("invalid variable path %q: must be 1-128 alphanumeric, dash, underscore, period, tilde or slash characters", path)
+	}
+	if strings.HasPrefix(path, "/") || strings.HasSuffix(path, "/") || strings.Contains(path, "//") {
+		return fmt.Errorf("invalid variable path %q: path segments can not be empty", path)
+	}
+	if path == "nomad" || strings.HasPrefix(path, "nomad/") {
+		if path != VariableJobPathPrefix && !strings.HasPrefix(path, VariableJobPathPrefix+"/") {
+			return fmt.Errorf("invalid variable path %q: \"nomad/\" is reserved for %q", path, VariableJobPathPrefix)
+		}
+	}
+	return nil
+}
+
+// VariableEncrypted is a variable as it is stored in raft, with its items
+// encrypted by a root key of the keyring
+type VariableEncrypted struct {
+	VariableMetadata
+	VariableData
+}
+
+// VariableData is the encrypted items of a variable and the ID of the root
+// key used to encrypt them
+type VariableData struct {
+	Data  []byte
+	KeyID string
+}
+
+// Copy returns a deep copy of the variable
+func (v *VariableEncrypted) Copy() *VariableEncrypted {
+	if v == nil {
+		return nil
+	}
+	nv := *v
+	nv.Data = make([]byte, len(v.Data))
+	copy(nv.Data, v.Data)
+	return &nv
+}
+
+// VariablesApplyRequest is used to upsert or delete a variable
+type VariablesApplyRequest struct {
+	Op  VarOp
+	Var *VariableDecrypted
+	WriteRequest
+}
+
+// VariablesApplyResponse is the response to a variable apply request. On a
+// check-and-set conflict, Conflict holds the current variable, redacted to
+// its metadata if the caller is not allowed to read it.
+type VariablesApplyResponse struct {
+	Op       VarOp
+	Result   VarOpResult
+	Conflict *VariableDecrypted
+	Output   *VariableDecrypted
+	WriteMeta
+}
+
+// IsConflict returns whether the operation failed its check-and-set
+func (r *VariablesApplyResponse) IsConflict() bool {
+	return r.Result == VarOpResultConflict
+}
+
+// VarApplyStateRequest is used to upsert or delete an encrypted variable
+// through raft
+type VarApplyStateRequest struct {
+	Op  VarOp
+	Var *VariableEncrypted
+	WriteRequest
+}
+
+// VarApplyStateResponse is the response of the FSM to a variable apply
+// request. On a check-and-set conflict, Conflict holds the current variable.
+type VarApplyStateResponse struct {
+	Result   VarOpResult
+	Conflict *VariableEncrypted
+
+	// Meta is the metadata of the upserted variable
+	Meta *VariableMetadata
+}
+
+// IsConflict returns whether the operation failed its check-and-set
+func (r *VarApplyStateResponse) IsConflict() bool {
+	return r.Result == VarOpResultConflict
+}
+
+// VariablesReadRequest is used to read a variable
+type VariablesReadRequest struct {
+	Path string
+	QueryOptions
+}
+
+// VariablesReadResponse is the response to a variable read request
+type VariablesReadResponse struct {
+	Data *VariableDecrypted
+	QueryMeta
+}
+
+// VariablesListRequest is used to list the metadata of the variables of a
+// namespace, optionally filtered by path prefix
+type VariablesListRequest struct {
+	QueryOptions
+}
+
+// VariablesListResponse is the response to a variable list request
+type VariablesListResponse struct {
+	Data []*VariableMetadata
+	QueryMeta
+}
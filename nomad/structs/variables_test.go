@@ -0,0 +1,81 @@
+package structs
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateVariablePath(t *testing.T) {
+	cases := []struct {
+		Path string
+		Err  string
+	}{
+		{Path: "foo"},
+		{Path: "foo/bar-baz_~.txt"},
+		{Path: "nomad/jobs"},
+		{Path: "nomad/jobs/example/web"},
+		{Path: "", Err: "must be 1-128"},
+		{Path: strings.Repeat("a", 129), Err: "must be 1-128"},
+		{Path: "foo bar", Err: "must be 1-128"},
+		{Path: "/foo", Err: "segments can not be empty"},
+		{Path: "foo/", Err: "segments can not be empty"},
+		{Path: "foo//bar", Err: "segments can not be empty"},
+		{Path: "nomad", Err: "reserved"},
+		{Path: "nomad/foo", Err: "reserved"},
+		{Path: "nomad/jobsfoo", Err: "reserved"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Path, func(t *testing.T) {
+			err := ValidateVariablePath(tc.Path)
+			if tc.Err == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			require.Contains(t, err.Error(), tc.Err)
+		})
+	}
+}
+
+func TestVariableDecrypted_Validate(t *testing.T) {
+	require := require.New(t)
+
+	v := &VariableDecrypted{
+		VariableMetadata: VariableMetadata{
+			Namespace: DefaultNamespace,
+			Path:      "foo",
+		},
+		Items: VariableItems{"key": "value"},
+	}
+	require.NoError(v.Validate())
+
+	v.Namespace = ""
+	require.Error(v.Validate())
+	v.Namespace = DefaultNamespace
+
+	v.Items = nil
+	require.Error(v.Validate())
+
+	v.Items = VariableItems{"": "value"}
+	require.Error(v.Validate())
+
+	v.Items = VariableItems{"key": strings.Repeat("a", MaxVariableSize)}
+	err := v.Validate()
+	require.Error(err)
+	require.Contains(err.Error(), "maximum size")
+}
+
+func TestVariableDecrypted_Copy(t *testing.T) {
+	v := &VariableDecrypted{
+		VariableMetadata: VariableMetadata{Path: "foo"},
+		Items:            VariableItems{"key": "value"},
+	}
+	cp := v.Copy()
+	require.Equal(t, v, cp)
+
+	cp.Items["key"] = "other"
+	require.Equal(t, "value", v.Items["key"])
+}
@@ -62,7 +62,7 @@ func TestServer(t testing.T, cb func(*Config)) *Server {
 
 	// Disable Vault
 	f := false
-	config.VaultConfig.Enabled = &f
+	config.GetDefaultVault().Enabled = &f
 
 	// Squelch output when -v isn't specified
 	config.LogOutput = testlog.NewWriter(t)
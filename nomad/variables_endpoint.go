@@ -0,0 +1,238 @@
+package nomad
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	metrics "github.com/armon/go-metrics"
+	log "github.com/hashicorp/go-hclog"
+	memdb "github.com/hashicorp/go-memdb"
+	"github.com/hashicorp/nomad/acl"
+	"github.com/hashicorp/nomad/nomad/state"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// Variables endpoint is used to manage the encrypted variables of the
+// namespaces
+type Variables struct {
+	srv    *Server
+	logger log.Logger
+}
+
+// Apply upserts or deletes a variable, optionally checking its modify index
+func (v *Variables) Apply(args *structs.VariablesApplyRequest, reply *structs.VariablesApplyResponse) error {
+	if done, err := v.srv.forward(structs.VariablesApplyRPCMethod, args, args, reply); done {
+		return err
+	}
+	defer metrics.MeasureSince([]string{"nomad", "variables", "apply"}, time.Now())
+
+	if args.Var == nil {
+		return fmt.Errorf("missing variable")
+	}
+	ns := args.RequestNamespace()
+	args.Var.Namespace = ns
+
+	// Validate the operation
+	var capability string
+	switch args.Op {
+	case structs.VarOpSet, structs.VarOpCAS:
+		capability = acl.VariablesCapabilityWrite
+		if err := args.Var.Validate(); err != nil {
+			return err
+		}
+	case structs.VarOpDelete, structs.VarOpDeleteCAS:
+		capability = acl.VariablesCapabilityDestroy
+		if err := structs.ValidateVariablePath(args.Var.Path); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("invalid variable operation %q", args.Op)
+	}
+
+	// Check the permissions of the token
+	aclObj, claims, err := v.srv.resolveTokenOrIdentity(args.AuthToken)
+	if err != nil {
+		return err
+	}
+	if !allowVariableOperation(aclObj, claims, ns, args.Var.Path, capability) {
+		return structs.ErrPermissionDenied
+	}
+
+	// Encrypt the items, deletes only need the metadata
+	var ev *structs.VariableEncrypted
+	if capability == acl.VariablesCapabilityWrite {
+		now := time.Now().UTC().UnixNano()
+		args.Var.CreateTime = now
+		args.Var.ModifyTime = now
+		ev, err = v.srv.encryptVariable(args.Var)
+		if err != nil {
+			return err
+		}
+	} else {
+		ev = &structs.VariableEncrypted{VariableMetadata: args.Var.VariableMetadata}
+	}
+
+	// Update via Raft
+	req := &structs.VarApplyStateRequest{
+		Op:           args.Op,
+		Var:          ev,
+		WriteRequest: args.WriteRequest,
+	}
+	out, index, err := v.srv.raftApply(structs.VarApplyStateRequestType, req)
+	if err != nil {
+		return err
+	}
+	if err, ok := out.(error); ok && err != nil {
+		return err
+	}
+	resp, ok := out.(*structs.VarApplyStateResponse)
+	if !ok {
+		return fmt.Errorf("unexpected response to variable apply: %T", out)
+	}
+
+	reply.Op = args.Op
+	reply.Result = resp.Result
+	reply.Index = index
+
+	if resp.IsConflict() {
+		// Only return the items of the conflicting variable if the caller can
+		// read them
+		conflict := resp.Conflict
+		if conflict.KeyID == "" || !allowVariableOperation(aclObj, claims, ns, args.Var.Path, acl.VariablesCapabilityRead) {
+			reply.Conflict = &structs.VariableDecrypted{VariableMetadata: conflict.VariableMetadata}
+			return nil
+		}
+		reply.Conflict, err = v.srv.decryptVariable(conflict)
+		return err
+	}
+
+	if resp.Meta != nil {
+		reply.Output = &structs.VariableDecrypted{
+			VariableMetadata: *resp.Meta,
+			Items:            args.Var.Items,
+		}
+	}
+	return nil
+}
+
+// Read returns the decrypted variable at a path
+func (v *Variables) Read(args *structs.VariablesReadRequest, reply *structs.VariablesReadResponse) error {
+	if done, err := v.srv.forward(structs.VariablesReadRPCMethod, args, args, reply); done {
+		return err
+	}
+	defer metrics.MeasureSince([]string{"nomad", "variables", "read"}, time.Now())
+
+	ns := args.RequestNamespace()
+	aclObj, claims, err := v.srv.resolveTokenOrIdentity(args.AuthToken)
+	if err != nil {
+		return err
+	}
+	if !allowVariableOperation(aclObj, claims, ns, args.Path, acl.VariablesCapabilityRead) {
+		return structs.ErrPermissionDenied
+	}
+
+	// Setup the blocking query
+	opts := blockingOptions{
+		queryOpts: &args.QueryOptions,
+		queryMeta: &reply.QueryMeta,
+		run: func(ws memdb.WatchSet, state *state.StateStore) error {
+			out, err := state.VarGet(ws, ns, args.Path)
+			if err != nil {
+				return err
+			}
+
+			reply.Data = nil
+			if out != nil {
+				reply.Data, err = v.srv.decryptVariable(out)
+				if err != nil {
+					return err
+				}
+				reply.Index = out.ModifyIndex
+			} else {
+				// Use the last index that affected the variables table
+				index, err := state.Index("variables")
+				if err != nil {
+					return err
+				}
+				reply.Index = index
+			}
+
+			// Set the query response
+			v.srv.setQueryMeta(&reply.QueryMeta)
+			return nil
+		}}
+	return v.srv.blockingRPC(&opts)
+}
+
+// List returns the metadata of the variables of a namespace the token can
+// list, optionally filtered by path prefix
+func (v *Variables) List(args *structs.VariablesListRequest, reply *structs.VariablesListResponse) error {
+	if done, err := v.srv.forward(structs.VariablesListRPCMethod, args, args, reply); done {
+		return err
+	}
+	defer metrics.MeasureSince([]string{"nomad", "variables", "list"}, time.Now())
+
+	ns := args.RequestNamespace()
+	aclObj, claims, err := v.srv.resolveTokenOrIdentity(args.AuthToken)
+	if err != nil {
+		return err
+	}
+
+	// Setup the blocking query
+	opts := blockingOptions{
+		queryOpts: &args.QueryOptions,
+		queryMeta: &reply.QueryMeta,
+		run: func(ws memdb.WatchSet, state *state.StateStore) error {
+			iter, err := state.VariablesByNamespacePrefix(ws, ns, args.Prefix)
+			if err != nil {
+				return err
+			}
+
+			reply.Data = []*structs.VariableMetadata{}
+			for raw := iter.Next(); raw != nil; raw = iter.Next() {
+				ev := raw.(*structs.VariableEncrypted)
+				if !allowVariableOperation(aclObj, claims, ns, ev.Path, acl.VariablesCapabilityList) {
+					continue
+				}
+				meta := ev.VariableMetadata
+				reply.Data = append(reply.Data, &meta)
+			}
+
+			// Use the last index that affected the variables table
+			index, err := state.Index("variables")
+			if err != nil {
+				return err
+			}
+
+			// Ensure we never set the index to zero, otherwise a blocking query cannot be used.
+			// We floor the index at one, since realistically the first write must have a higher index.
+			if index == 0 {
+				index = 1
+			}
+			reply.Index = index
+
+			// Set the query response
+			v.srv.setQueryMeta(&reply.QueryMeta)
+			return nil
+		}}
+	return v.srv.blockingRPC(&opts)
+}
+
+// allowVariableOperation returns whether the ACL or the workload identity
+// claims allow the operation on the variable path. Workload identities can
+// only read and list the variables of their own job, stored under
+// "nomad/jobs/<job ID>".
+func allowVariableOperation(aclObj *acl.ACL, claims *structs.IdentityClaims, ns, path, op string) bool {
+	if claims != nil {
+		if op != acl.VariablesCapabilityRead && op != acl.VariablesCapabilityList {
+			return false
+		}
+		if ns != claims.Namespace {
+			return false
+		}
+		jobPath := structs.VariableJobPathPrefix + "/" + claims.JobID
+		return path == jobPath || strings.HasPrefix(path, jobPath+"/")
+	}
+	return aclObj == nil || aclObj.AllowVariableOperation(ns, path, op)
+}
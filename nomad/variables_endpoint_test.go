@@ -0,0 +1,261 @@
+package nomad
+
+import (
+	"testing"
+
+	msgpackrpc "github.com/hashicorp/net-rpc-msgpackrpc"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVariablesEndpoint_Apply_CAS(t *testing.T) {
+	t.Parallel()
+	s1 := TestServer(t, func(c *Config) {
+		c.Build = "0.9.2+unittest"
+	})
+	defer s1.Shutdown()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+	waitForActiveRootKey(t, s1)
+
+	v := mock.Variable()
+	req := &structs.VariablesApplyRequest{
+		Op:           structs.VarOpCAS,
+		Var:          v,
+		WriteRequest: structs.WriteRequest{Region: "global"},
+	}
+	var resp structs.VariablesApplyResponse
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, structs.VariablesApplyRPCMethod, req, &resp))
+	require.False(t, resp.IsConflict())
+	require.NotZero(t, resp.Index)
+	require.Equal(t, resp.Index, resp.Output.ModifyIndex)
+	require.Equal(t, v.Items, resp.Output.Items)
+	require.NotZero(t, resp.Output.CreateTime)
+
+	// The variable is encrypted at rest
+	stored, err := s1.fsm.State().VarGet(nil, v.Namespace, v.Path)
+	require.NoError(t, err)
+	require.NotEmpty(t, stored.KeyID)
+	require.NotContains(t, string(stored.Data), v.Items["password"])
+
+	// Creating it again conflicts and returns the current variable
+	var conflictResp structs.VariablesApplyResponse
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, structs.VariablesApplyRPCMethod, req, &conflictResp))
+	require.True(t, conflictResp.IsConflict())
+	require.Equal(t, resp.Index, conflictResp.Conflict.ModifyIndex)
+	require.Equal(t, v.Items, conflictResp.Conflict.Items)
+
+	// Read it back decrypted
+	readReq := &structs.VariablesReadRequest{
+		Path:         v.Path,
+		QueryOptions: structs.QueryOptions{Region: "global"},
+	}
+	var readResp structs.VariablesReadResponse
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, structs.VariablesReadRPCMethod, readReq, &readResp))
+	require.Equal(t, v.Items, readResp.Data.Items)
+	require.Equal(t, resp.Index, readResp.Index)
+
+	// Delete it with its modify index
+	delReq := &structs.VariablesApplyRequest{
+		Op: structs.VarOpDeleteCAS,
+		Var: &structs.VariableDecrypted{
+			VariableMetadata: structs.VariableMetadata{
+				Path:        v.Path,
+				ModifyIndex: resp.Index,
+			},
+		},
+		WriteRequest: structs.WriteRequest{Region: "global"},
+	}
+	var delResp structs.VariablesApplyResponse
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, structs.VariablesApplyRPCMethod, delReq, &delResp))
+	require.False(t, delResp.IsConflict())
+
+	readResp = structs.VariablesReadResponse{}
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, structs.VariablesReadRPCMethod, readReq, &readResp))
+	require.Nil(t, readResp.Data)
+
+	// Invalid paths are rejected
+	req.Var = mock.Variable()
+	req.Var.Path = "nomad/other"
+	err = msgpackrpc.CallWithCodec(codec, structs.VariablesApplyRPCMethod, req, &resp)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "reserved")
+}
+
+func TestVariablesEndpoint_ACL(t *testing.T) {
+	t.Parallel()
+	s1, root := TestACLServer(t, func(c *Config) {
+		c.Build = "0.9.2+unittest"
+	})
+	defer s1.Shutdown()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+	waitForActiveRootKey(t, s1)
+	state := s1.fsm.State()
+
+	policy := mock.ACLPolicy()
+	policy.Rules = `
+	namespace "default" {
+		variables {
+			path "shared/*" {
+				capabilities = ["list", "write"]
+			}
+		}
+	}`
+	policy.SetHash()
+	require.NoError(t, state.UpsertACLPolicies(1000, []*structs.ACLPolicy{policy}))
+	token := mock.CreateToken(t, state, 1001, []string{policy.Name})
+
+	write := func(path, token string) (*structs.VariablesApplyResponse, error) {
+		v := mock.Variable()
+		v.Path = path
+		req := &structs.VariablesApplyRequest{
+			Op:  structs.VarOpCAS,
+			Var: v,
+			WriteRequest: structs.WriteRequest{
+				Region:    "global",
+				AuthToken: token,
+			},
+		}
+		var resp structs.VariablesApplyResponse
+		err := msgpackrpc.CallWithCodec(codec, structs.VariablesApplyRPCMethod, req, &resp)
+		return &resp, err
+	}
+
+	// The token can only write under its path
+	_, err := write("secret", token.SecretID)
+	require.EqualError(t, err, structs.ErrPermissionDenied.Error())
+
+	_, err = write("secret", root.SecretID)
+	require.NoError(t, err)
+
+	_, err = write("shared/db", token.SecretID)
+	require.NoError(t, err)
+
+	// Conflicts are redacted without the read capability
+	resp, err := write("shared/db", token.SecretID)
+	require.NoError(t, err)
+	require.True(t, resp.IsConflict())
+	require.NotZero(t, resp.Conflict.ModifyIndex)
+	require.Nil(t, resp.Conflict.Items)
+
+	// The token can't read the variables it wrote
+	readReq := &structs.VariablesReadRequest{
+		Path: "shared/db",
+		QueryOptions: structs.QueryOptions{
+			Region:    "global",
+			AuthToken: token.SecretID,
+		},
+	}
+	var readResp structs.VariablesReadResponse
+	err = msgpackrpc.CallWithCodec(codec, structs.VariablesReadRPCMethod, readReq, &readResp)
+	require.EqualError(t, err, structs.ErrPermissionDenied.Error())
+
+	// Listing is filtered by path
+	listReq := &structs.VariablesListRequest{
+		QueryOptions: structs.QueryOptions{
+			Region:    "global",
+			AuthToken: token.SecretID,
+		},
+	}
+	var listResp structs.VariablesListResponse
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, structs.VariablesListRPCMethod, listReq, &listResp))
+	require.Len(t, listResp.Data, 1)
+	require.Equal(t, "shared/db", listResp.Data[0].Path)
+
+	listReq.AuthToken = root.SecretID
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, structs.VariablesListRPCMethod, listReq, &listResp))
+	require.Len(t, listResp.Data, 2)
+}
+
+func TestVariablesEndpoint_WorkloadIdentity(t *testing.T) {
+	t.Parallel()
+	s1, root := TestACLServer(t, func(c *Config) {
+		c.Build = "0.9.2+unittest"
+	})
+	defer s1.Shutdown()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+	waitForActiveRootKey(t, s1)
+	state := s1.fsm.State()
+
+	// Sign the identity of a running allocation
+	alloc := mock.Alloc()
+	require.NoError(t, s1.signAllocIdentities(alloc.Job, []*structs.Allocation{alloc}))
+	require.NoError(t, state.UpsertJobSummary(999, mock.JobSummary(alloc.JobID)))
+	require.NoError(t, state.UpsertAllocs(1000, []*structs.Allocation{alloc}))
+	identity := alloc.SignedIdentities["web"]
+	require.NotEmpty(t, identity)
+
+	jobPath := structs.VariableJobPathPrefix + "/" + alloc.JobID
+	for _, path := range []string{jobPath, jobPath + "/web", "nomad/jobs/other", "shared"} {
+		v := mock.Variable()
+		v.Path = path
+		req := &structs.VariablesApplyRequest{
+			Op:  structs.VarOpSet,
+			Var: v,
+			WriteRequest: structs.WriteRequest{
+				Region:    "global",
+				AuthToken: root.SecretID,
+			},
+		}
+		var resp structs.VariablesApplyResponse
+		require.NoError(t, msgpackrpc.CallWithCodec(codec, structs.VariablesApplyRPCMethod, req, &resp))
+	}
+
+	read := func(path, token string) error {
+		req := &structs.VariablesReadRequest{
+			Path: path,
+			QueryOptions: structs.QueryOptions{
+				Region:    "global",
+				AuthToken: token,
+			},
+		}
+		var resp structs.VariablesReadResponse
+		return msgpackrpc.CallWithCodec(codec, structs.VariablesReadRPCMethod, req, &resp)
+	}
+
+	// The identity can read the variables of its job
+	require.NoError(t, read(jobPath, identity))
+	require.NoError(t, read(jobPath+"/web", identity))
+	require.EqualError(t, read("nomad/jobs/other", identity), structs.ErrPermissionDenied.Error())
+	require.EqualError(t, read("shared", identity), structs.ErrPermissionDenied.Error())
+
+	// Listing only returns the variables of its job
+	listReq := &structs.VariablesListRequest{
+		QueryOptions: structs.QueryOptions{
+			Region:    "global",
+			AuthToken: identity,
+		},
+	}
+	var listResp structs.VariablesListResponse
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, structs.VariablesListRPCMethod, listReq, &listResp))
+	require.Len(t, listResp.Data, 2)
+
+	// The identity can't write
+	v := mock.Variable()
+	v.Path = jobPath
+	req := &structs.VariablesApplyRequest{
+		Op:  structs.VarOpSet,
+		Var: v,
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			AuthToken: identity,
+		},
+	}
+	var resp structs.VariablesApplyResponse
+	err := msgpackrpc.CallWithCodec(codec, structs.VariablesApplyRPCMethod, req, &resp)
+	require.EqualError(t, err, structs.ErrPermissionDenied.Error())
+
+	// Identities of terminal allocations are rejected
+	stopped := alloc.Copy()
+	stopped.DesiredStatus = structs.AllocDesiredStatusStop
+	stopped.ClientStatus = structs.AllocClientStatusComplete
+	require.NoError(t, state.UpsertAllocs(1001, []*structs.Allocation{stopped}))
+	require.EqualError(t, read(jobPath, identity), structs.ErrPermissionDenied.Error())
+
+	// Forged identities are rejected
+	require.EqualError(t, read(jobPath, "a.b.c"), structs.ErrPermissionDenied.Error())
+}
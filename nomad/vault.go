@@ -107,17 +107,19 @@ type VaultClient interface {
 	// creation/lookup/revocation operation are allowed.
 	SetActive(active bool)
 
-	// SetConfig updates the config used by the Vault client
-	SetConfig(config *config.VaultConfig) error
+	// SetConfig updates the configs of the Vault clusters, indexed by name
+	SetConfig(configs map[string]*config.VaultConfig) error
 
 	// CreateToken takes an allocation and task and returns an appropriate Vault
-	// Secret
+	// Secret from the Vault cluster of the task
 	CreateToken(ctx context.Context, a *structs.Allocation, task string) (*vapi.Secret, error)
 
-	// LookupToken takes a token string and returns its capabilities.
-	LookupToken(ctx context.Context, token string) (*vapi.Secret, error)
+	// LookupToken takes a token string and returns its capabilities in the
+	// Vault cluster.
+	LookupToken(ctx context.Context, cluster, token string) (*vapi.Secret, error)
 
-	// RevokeTokens takes a set of tokens accessor and revokes the tokens
+	// RevokeTokens takes a set of tokens accessor and revokes the tokens from
+	// their Vault clusters
 	RevokeTokens(ctx context.Context, accessors []*structs.VaultAccessor, committed bool) error
 
 	// Stop is used to stop token renewal
@@ -187,6 +189,10 @@ type vaultClient struct {
 	// auth is the Vault token auth API client
 	auth *vapi.TokenAuth
 
+	// namespaceClients are the Vault API clients of the namespaces tasks
+	// create their tokens in, when it isn't the namespace of the config
+	namespaceClients map[string]*vapi.Client
+
 	// config is the user passed Vault config
 	config *config.VaultConfig
 
@@ -248,7 +254,7 @@ func NewVaultClient(c *config.VaultConfig, logger log.Logger, purgeFn PurgeVault
 
 	v := &vaultClient{
 		config:   c,
-		logger:   logger.Named("vault"),
+		logger:   logger.Named("vault").With("cluster", c.GetName()),
 		limiter:  rate.NewLimiter(requestRateLimit, int(requestRateLimit)),
 		revoking: make(map[*structs.VaultAccessor]time.Time),
 		purgeFn:  purgeFn,
@@ -315,6 +321,7 @@ func (v *vaultClient) flush() {
 	v.client = nil
 	v.clientSys = nil
 	v.auth = nil
+	v.namespaceClients = nil
 	v.connEstablished = false
 	v.connEstablishedErr = nil
 	v.token = ""
@@ -427,10 +434,41 @@ func (v *vaultClient) buildClient() error {
 	v.token = v.config.Token
 	client.SetToken(v.token)
 	v.auth = client.Auth().Token()
+	v.namespaceClients = make(map[string]*vapi.Client)
 
 	return nil
 }
 
+// tokenAuth returns the Vault token auth API client of the namespace, which
+// defaults to the namespace of the config. The clients of other namespaces
+// are built on first use, once the connection is established.
+func (v *vaultClient) tokenAuth(namespace string) (*vapi.TokenAuth, error) {
+	v.l.Lock()
+	defer v.l.Unlock()
+
+	if namespace == "" || namespace == v.config.Namespace {
+		return v.auth, nil
+	}
+
+	if client, ok := v.namespaceClients[namespace]; ok {
+		return client.Auth().Token(), nil
+	}
+
+	client, err := v.client.Clone()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Vault client for namespace %q: %v", namespace, err)
+	}
+	client.SetToken(v.token)
+	client.SetNamespace(namespace)
+	client.SetWrappingLookupFunc(v.getWrappingFn())
+
+	if v.namespaceClients == nil {
+		v.namespaceClients = make(map[string]*vapi.Client)
+	}
+	v.namespaceClients[namespace] = client
+	return client.Auth().Token(), nil
+}
+
 // establishConnection is used to make first contact with Vault. This should be
 // called in a go-routine since the connection is retried until the Vault Client
 // is stopped or the connection is successfully made at which point the renew
@@ -729,13 +767,13 @@ func (v *vaultClient) parseSelfToken() error {
 
 	// Check we have the correct capabilities
 	if err := v.validateCapabilities(role, data.Root); err != nil {
-		multierror.Append(&mErr, err)
+		multierror.Append(&mErr, fmt.Errorf("Vault cluster %q: %v", v.config.GetName(), err))
 	}
 
 	// If given a role validate it
 	if role != "" {
 		if err := v.validateRole(role); err != nil {
-			multierror.Append(&mErr, err)
+			multierror.Append(&mErr, fmt.Errorf("Vault cluster %q: %v", v.config.GetName(), err))
 		}
 	}
 
@@ -966,17 +1004,22 @@ func (v *vaultClient) CreateToken(ctx context.Context, a *structs.Allocation, ta
 		return nil, err
 	}
 
+	// Create the token in the namespace of the task
+	auth, err := v.tokenAuth(taskVault.Namespace)
+	if err != nil {
+		return nil, err
+	}
+
 	// Make the request and switch depending on whether we are using a root
 	// token or a role based token
 	var secret *vapi.Secret
-	var err error
 	role := v.getRole()
 	if v.tokenData.Root && role == "" {
 		req.Period = v.childTTL
-		secret, err = v.auth.Create(req)
+		secret, err = auth.Create(req)
 	} else {
 		// Make the token using the role
-		secret, err = v.auth.CreateWithRole(req, v.getRole())
+		secret, err = auth.CreateWithRole(req, v.getRole())
 	}
 
 	// Determine whether it is unrecoverable
@@ -1162,7 +1205,11 @@ func (v *vaultClient) parallelRevoke(ctx context.Context, accessors []*structs.V
 						return nil
 					}
 
-					if err := v.auth.RevokeAccessor(va.Accessor); err != nil {
+					auth, err := v.tokenAuth(va.Namespace)
+					if err != nil {
+						return err
+					}
+					if err := auth.RevokeAccessor(va.Accessor); err != nil {
 						return fmt.Errorf("failed to revoke token (alloc: %q, node: %q, task: %q): %v", va.AllocID, va.NodeID, va.Task, err)
 					}
 				case <-pCtx.Done():
@@ -1317,9 +1364,7 @@ func (v *vaultClient) EmitStats(period time.Duration, stopCh <-chan struct{}) {
 	for {
 		select {
 		case <-time.After(period):
-			stats := v.stats()
-			metrics.SetGauge([]string{"nomad", "vault", "distributed_tokens_revoking"}, float32(stats.TrackedForRevoke))
-			metrics.SetGauge([]string{"nomad", "vault", "token_ttl"}, float32(stats.TokenTTL/time.Millisecond))
+			v.emitStats()
 
 		case <-stopCh:
 			return
@@ -1327,9 +1372,296 @@ func (v *vaultClient) EmitStats(period time.Duration, stopCh <-chan struct{}) {
 	}
 }
 
+// emitStats exports the metrics of the client, labeled by Vault cluster
+func (v *vaultClient) emitStats() {
+	stats := v.stats()
+	labels := []metrics.Label{{Name: "cluster", Value: v.config.GetName()}}
+	metrics.SetGaugeWithLabels([]string{"nomad", "vault", "distributed_tokens_revoking"}, float32(stats.TrackedForRevoke), labels)
+	metrics.SetGaugeWithLabels([]string{"nomad", "vault", "token_ttl"}, float32(stats.TokenTTL/time.Millisecond), labels)
+}
+
 // extendExpiration sets the current auth token expiration record to ttLSeconds seconds from now
 func (v *vaultClient) extendExpiration(ttlSeconds int) {
 	v.currentExpirationLock.Lock()
 	v.currentExpiration = time.Now().Add(time.Duration(ttlSeconds) * time.Second)
 	v.currentExpirationLock.Unlock()
 }
+
+// vaultClients is the VaultClient of the servers. It routes the token
+// operations to the clients of the Vault clusters, indexed by name.
+type vaultClients struct {
+	logger  log.Logger
+	purgeFn PurgeVaultAccessorFn
+
+	// clients are the clients of the Vault clusters, indexed by name
+	clients map[string]*vaultClient
+
+	// orphaned tracks the committed accessors of Vault clusters that are not
+	// configured, indexed by cluster. They are revoked once the cluster is
+	// configured again or dropped once their TTL is reached.
+	orphaned map[string]map[*structs.VaultAccessor]time.Time
+
+	// active indicates whether the clients are active
+	active bool
+
+	l sync.RWMutex
+}
+
+// NewVaultClients returns a VaultClient for the Vault clusters of the
+// configs, indexed by name. An error is returned if any of the clients
+// couldn't be made.
+func NewVaultClients(configs map[string]*config.VaultConfig, logger log.Logger, purgeFn PurgeVaultAccessorFn) (*vaultClients, error) {
+	if logger == nil {
+		return nil, fmt.Errorf("must pass valid logger")
+	}
+
+	v := &vaultClients{
+		logger:   logger,
+		purgeFn:  purgeFn,
+		clients:  make(map[string]*vaultClient, len(configs)),
+		orphaned: make(map[string]map[*structs.VaultAccessor]time.Time),
+	}
+	for name, c := range configs {
+		client, err := NewVaultClient(c, logger, purgeFn)
+		if err != nil {
+			v.Stop()
+			return nil, fmt.Errorf("failed to create client of Vault cluster %q: %v", name, err)
+		}
+		v.clients[name] = client
+	}
+
+	return v, nil
+}
+
+// client returns the client of the Vault cluster, which defaults to the
+// default cluster.
+func (v *vaultClients) client(cluster string) (*vaultClient, error) {
+	if cluster == "" {
+		cluster = config.VaultDefaultCluster
+	}
+
+	v.l.RLock()
+	defer v.l.RUnlock()
+	client, ok := v.clients[cluster]
+	if !ok {
+		return nil, fmt.Errorf("Vault cluster %q is not configured", cluster)
+	}
+	return client, nil
+}
+
+// SetActive activates or de-activates the clients. Like the queued
+// revocations of the clients, the orphaned accessors are dropped as the
+// accessors are revoked again by the next leader.
+func (v *vaultClients) SetActive(active bool) {
+	v.l.Lock()
+	defer v.l.Unlock()
+	v.active = active
+	for _, client := range v.clients {
+		client.SetActive(active)
+	}
+	v.orphaned = make(map[string]map[*structs.VaultAccessor]time.Time)
+}
+
+// SetConfig updates the configs of the clients, creating the clients of new
+// clusters and stopping the ones of the clusters that have been removed.
+func (v *vaultClients) SetConfig(configs map[string]*config.VaultConfig) error {
+	v.l.Lock()
+	defer v.l.Unlock()
+
+	var mErr multierror.Error
+	for name, c := range configs {
+		if client, ok := v.clients[name]; ok {
+			if err := client.SetConfig(c); err != nil {
+				multierror.Append(&mErr, fmt.Errorf("Vault cluster %q: %v", name, err))
+			}
+			continue
+		}
+
+		client, err := NewVaultClient(c, v.logger, v.purgeFn)
+		if err != nil {
+			multierror.Append(&mErr, fmt.Errorf("failed to create client of Vault cluster %q: %v", name, err))
+			continue
+		}
+		client.SetActive(v.active)
+		v.clients[name] = client
+
+		// Revoke the tokens that could not be revoked while the cluster was
+		// not configured
+		if accessors := v.takeOrphaned(name); len(accessors) != 0 && v.active {
+			v.logger.Info("revoking Vault tokens of re-configured cluster", "cluster", name, "tokens", len(accessors))
+			if err := client.RevokeTokens(context.Background(), accessors, true); err != nil {
+				multierror.Append(&mErr, fmt.Errorf("failed to revoke tokens of Vault cluster %q: %v", name, err))
+			}
+		}
+	}
+
+	for name, client := range v.clients {
+		if _, ok := configs[name]; ok {
+			continue
+		}
+		v.logger.Warn("Vault cluster removed from config, its tokens will be revoked once it is configured again", "cluster", name)
+		client.Stop()
+		delete(v.clients, name)
+	}
+
+	return mErr.ErrorOrNil()
+}
+
+// CreateToken creates the token of the task in the Vault cluster of the task
+func (v *vaultClients) CreateToken(ctx context.Context, a *structs.Allocation, task string) (*vapi.Secret, error) {
+	cluster := config.VaultDefaultCluster
+	if a.Job != nil {
+		if tg := a.Job.LookupTaskGroup(a.TaskGroup); tg != nil {
+			if t := tg.LookupTask(task); t != nil {
+				cluster = t.Vault.GetCluster()
+			}
+		}
+	}
+
+	client, err := v.client(cluster)
+	if err != nil {
+		return nil, err
+	}
+	return client.CreateToken(ctx, a, task)
+}
+
+func (v *vaultClients) LookupToken(ctx context.Context, cluster, token string) (*vapi.Secret, error) {
+	client, err := v.client(cluster)
+	if err != nil {
+		return nil, err
+	}
+	return client.LookupToken(ctx, token)
+}
+
+// RevokeTokens revokes the tokens from the Vault clusters they were created
+// in. The committed accessors of clusters that are no longer configured are
+// kept and revoked once the cluster is configured again.
+func (v *vaultClients) RevokeTokens(ctx context.Context, accessors []*structs.VaultAccessor, committed bool) error {
+	byCluster := make(map[string][]*structs.VaultAccessor)
+	for _, va := range accessors {
+		cluster := va.Cluster
+		if cluster == "" {
+			cluster = config.VaultDefaultCluster
+		}
+		byCluster[cluster] = append(byCluster[cluster], va)
+	}
+
+	// Look up the clients and store the orphaned accessors under the same
+	// lock so that they can't miss a cluster being configured again
+	clients := make(map[string]*vaultClient, len(byCluster))
+	v.l.Lock()
+	for cluster, accessors := range byCluster {
+		if client, ok := v.clients[cluster]; ok {
+			clients[cluster] = client
+			continue
+		}
+
+		// Uncommitted tokens were never handed out and expire shortly, so
+		// they are only revoked on a best effort basis
+		if !committed {
+			metrics.IncrCounter([]string{"nomad", "vault", "undistributed_tokens_abandoned"}, float32(len(accessors)))
+			continue
+		}
+		v.logger.Warn("Vault cluster is not configured, tokens will be revoked once it is",
+			"cluster", cluster, "tokens", len(accessors))
+		v.storeOrphaned(cluster, accessors)
+	}
+	v.l.Unlock()
+
+	var mErr multierror.Error
+	for cluster, client := range clients {
+		if err := client.RevokeTokens(ctx, byCluster[cluster], committed); err != nil {
+			multierror.Append(&mErr, err)
+		}
+	}
+	return mErr.ErrorOrNil()
+}
+
+// storeOrphaned stores the accessors of a cluster that is not configured
+// until their TTL is reached. The lock must be held.
+func (v *vaultClients) storeOrphaned(cluster string, accessors []*structs.VaultAccessor) {
+	orphaned, ok := v.orphaned[cluster]
+	if !ok {
+		orphaned = make(map[*structs.VaultAccessor]time.Time)
+		v.orphaned[cluster] = orphaned
+	}
+
+	now := time.Now()
+	for va, ttl := range orphaned {
+		if now.After(ttl) {
+			delete(orphaned, va)
+		}
+	}
+	for _, va := range accessors {
+		orphaned[va] = now.Add(time.Duration(va.CreationTTL) * time.Second)
+	}
+}
+
+// takeOrphaned removes and returns the orphaned accessors of the cluster that
+// haven't reached their TTL. The lock must be held.
+func (v *vaultClients) takeOrphaned(cluster string) []*structs.VaultAccessor {
+	orphaned := v.orphaned[cluster]
+	delete(v.orphaned, cluster)
+
+	now := time.Now()
+	accessors := make([]*structs.VaultAccessor, 0, len(orphaned))
+	for va, ttl := range orphaned {
+		if now.Before(ttl) {
+			accessors = append(accessors, va)
+		}
+	}
+	return accessors
+}
+
+func (v *vaultClients) Stop() {
+	v.l.RLock()
+	defer v.l.RUnlock()
+	for _, client := range v.clients {
+		client.Stop()
+	}
+}
+
+func (v *vaultClients) Running() bool {
+	v.l.RLock()
+	defer v.l.RUnlock()
+	for _, client := range v.clients {
+		if client.Running() {
+			return true
+		}
+	}
+	return false
+}
+
+// Stats returns the stats of the clients. The stats of the clusters other
+// than the default one are prefixed by their name.
+func (v *vaultClients) Stats() map[string]string {
+	v.l.RLock()
+	defer v.l.RUnlock()
+
+	stats := make(map[string]string)
+	for name, client := range v.clients {
+		for k, s := range client.Stats() {
+			if name != config.VaultDefaultCluster {
+				k = name + "." + k
+			}
+			stats[k] = s
+		}
+	}
+	return stats
+}
+
+func (v *vaultClients) EmitStats(period time.Duration, stopCh <-chan struct{}) {
+	for {
+		select {
+		case <-time.After(period):
+			v.l.RLock()
+			for _, client := range v.clients {
+				client.emitStats()
+			}
+			v.l.RUnlock()
+
+		case <-stopCh:
+			return
+		}
+	}
+}
@@ -1466,3 +1466,53 @@ func TestVaultClient_nextBackoff(t *testing.T) {
 		}
 	})
 }
+
+func TestVaultClients_RevokeTokens_UnconfiguredCluster(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	disabled := func(name string) *config.VaultConfig {
+		return &config.VaultConfig{Name: name, Enabled: helper.BoolToPtr(false)}
+	}
+	configs := map[string]*config.VaultConfig{
+		config.VaultDefaultCluster: disabled(config.VaultDefaultCluster),
+	}
+	v, err := NewVaultClients(configs, testlog.HCLogger(t), nil)
+	require.NoError(err)
+	defer v.Stop()
+	v.SetActive(true)
+
+	// Committed accessors of a cluster that is not configured are kept
+	committed := mock.VaultAccessor()
+	committed.Cluster = "other"
+	expired := mock.VaultAccessor()
+	expired.Cluster = "other"
+	expired.CreationTTL = 0
+	require.NoError(v.RevokeTokens(context.Background(), []*structs.VaultAccessor{committed, expired}, true))
+
+	// Uncommitted ones are abandoned
+	uncommitted := mock.VaultAccessor()
+	uncommitted.Cluster = "other"
+	require.NoError(v.RevokeTokens(context.Background(), []*structs.VaultAccessor{uncommitted}, false))
+
+	v.l.Lock()
+	require.Len(v.orphaned["other"], 2)
+	require.Equal([]*structs.VaultAccessor{committed}, v.takeOrphaned("other"))
+	require.Empty(v.orphaned)
+	v.l.Unlock()
+
+	// The accessors are handed to the client of the cluster once it is
+	// configured again
+	require.NoError(v.RevokeTokens(context.Background(), []*structs.VaultAccessor{committed}, true))
+	configs["other"] = disabled("other")
+	require.NoError(v.SetConfig(configs))
+	require.Empty(v.orphaned)
+
+	// The accessors are dropped when the server loses leadership
+	delete(configs, "other")
+	require.NoError(v.SetConfig(configs))
+	require.NoError(v.RevokeTokens(context.Background(), []*structs.VaultAccessor{committed}, true))
+	require.Len(v.orphaned["other"], 1)
+	v.SetActive(false)
+	require.Empty(v.orphaned)
+}
@@ -32,7 +32,7 @@ type TestVaultClient struct {
 	RevokedTokens []*structs.VaultAccessor
 }
 
-func (v *TestVaultClient) LookupToken(ctx context.Context, token string) (*vapi.Secret, error) {
+func (v *TestVaultClient) LookupToken(ctx context.Context, cluster, token string) (*vapi.Secret, error) {
 	var secret *vapi.Secret
 	var err error
 
@@ -137,7 +137,7 @@ func (v *TestVaultClient) RevokeTokens(ctx context.Context, accessors []*structs
 
 func (v *TestVaultClient) Stop()                                                  {}
 func (v *TestVaultClient) SetActive(enabled bool)                                 {}
-func (v *TestVaultClient) SetConfig(config *config.VaultConfig) error             { return nil }
+func (v *TestVaultClient) SetConfig(configs map[string]*config.VaultConfig) error { return nil }
 func (v *TestVaultClient) Running() bool                                          { return true }
 func (v *TestVaultClient) Stats() map[string]string                               { return map[string]string{} }
 func (v *TestVaultClient) EmitStats(period time.Duration, stopCh <-chan struct{}) {}
@@ -277,6 +277,11 @@ type LinuxResources struct {
 	CpusetCPUs       string
 	CpusetMems       string
 
+	// CpusetCgroupPath is the path of the cpuset cgroup managed by the client
+	// that the task should join, so that its cpuset follows the cores
+	// reserved on the client while it runs.
+	CpusetCgroupPath string
+
 	// PrecentTicks is used to calculate the CPUQuota, currently the docker
 	// driver exposes cpu period and quota through the driver configuration
 	// and thus the calculation for CPUQuota cannot be done on the client.
@@ -2100,7 +2100,10 @@ type LinuxResources struct {
 	// CpusetMems constrains the allowed set of memory nodes. Default: "" (not specified)
 	CpusetMems string `protobuf:"bytes,7,opt,name=cpuset_mems,json=cpusetMems,proto3" json:"cpuset_mems,omitempty"`
 	// PercentTicks is a compatibility option for docker and should not be used
-	PercentTicks         float64  `protobuf:"fixed64,8,opt,name=PercentTicks,proto3" json:"PercentTicks,omitempty"`
+	PercentTicks float64 `protobuf:"fixed64,8,opt,name=PercentTicks,proto3" json:"PercentTicks,omitempty"`
+	// CpusetCgroup is the path of the cpuset cgroup managed by the client that
+	// the task should join. Default: "" (not specified)
+	CpusetCgroup         string   `protobuf:"bytes,9,opt,name=cpuset_cgroup,json=cpusetCgroup,proto3" json:"cpuset_cgroup,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -2186,6 +2189,13 @@ func (m *LinuxResources) GetPercentTicks() float64 {
 	return 0
 }
 
+func (m *LinuxResources) GetCpusetCgroup() string {
+	if m != nil {
+		return m.CpusetCgroup
+	}
+	return ""
+}
+
 type Mount struct {
 	// TaskPath is the file path within the task directory to mount to
 	TaskPath string `protobuf:"bytes,1,opt,name=task_path,json=taskPath,proto3" json:"task_path,omitempty"`
@@ -3747,208 +3757,209 @@ func init() {
 }
 
 var fileDescriptor_driver_26c1fb94e7ec6ab0 = []byte{
-	// 3242 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x5a, 0xcb, 0x73, 0x1b, 0xc7,
-	0x99, 0x27, 0x9e, 0x04, 0x3e, 0x90, 0xe0, 0xa8, 0x25, 0xd9, 0x10, 0xbc, 0xbb, 0x96, 0xa7, 0xca,
-	0x5b, 0x2c, 0xdb, 0x02, 0x6d, 0xba, 0x56, 0xaf, 0xb5, 0x2d, 0xc1, 0x20, 0x44, 0xd2, 0x22, 0x41,
-	0x6e, 0x03, 0x2c, 0x59, 0xab, 0xb5, 0x67, 0x87, 0x33, 0x2d, 0x60, 0xc4, 0x79, 0x79, 0xa6, 0x87,
-	0x22, 0xbd, 0xb5, 0xb5, 0x1b, 0xa7, 0x2a, 0x95, 0x1c, 0x52, 0x95, 0x8b, 0xcb, 0x97, 0x9c, 0x92,
-	0x63, 0xfe, 0x81, 0x3c, 0xca, 0xe7, 0xfc, 0x11, 0xc9, 0x25, 0x87, 0x54, 0xe5, 0x9a, 0xff, 0x20,
-	0xd5, 0x8f, 0x19, 0x0c, 0x08, 0xca, 0x1a, 0x80, 0x3a, 0xcd, 0x7c, 0x5f, 0x77, 0xff, 0xfa, 0xeb,
-	0xfe, 0x1e, 0xfd, 0xf5, 0x03, 0x54, 0xdf, 0x8e, 0x86, 0x96, 0x1b, 0xae, 0x99, 0x81, 0x75, 0x4c,
-	0x82, 0x70, 0xcd, 0x0f, 0x3c, 0xea, 0x49, 0xaa, 0xc5, 0x09, 0xf4, 0xf6, 0x48, 0x0f, 0x47, 0x96,
-	0xe1, 0x05, 0x7e, 0xcb, 0xf5, 0x1c, 0xdd, 0x6c, 0xc9, 0x36, 0x2d, 0xd9, 0x46, 0x54, 0x6b, 0xfe,
-	0xcb, 0xd0, 0xf3, 0x86, 0x36, 0x11, 0x08, 0x87, 0xd1, 0xd3, 0x35, 0x33, 0x0a, 0x74, 0x6a, 0x79,
-	0xae, 0x2c, 0x7f, 0xf3, 0x6c, 0x39, 0xb5, 0x1c, 0x12, 0x52, 0xdd, 0xf1, 0x65, 0x85, 0xfb, 0x43,
-	0x8b, 0x8e, 0xa2, 0xc3, 0x96, 0xe1, 0x39, 0x6b, 0x49, 0x97, 0x6b, 0xbc, 0xcb, 0xb5, 0x58, 0xcc,
-	0x70, 0xa4, 0x07, 0xc4, 0x5c, 0x1b, 0x19, 0x76, 0xe8, 0x13, 0x83, 0x7d, 0x35, 0xf6, 0x23, 0x11,
-	0x36, 0xb3, 0x23, 0x84, 0x34, 0x88, 0x0c, 0x1a, 0x8f, 0x57, 0xa7, 0x34, 0xb0, 0x0e, 0x23, 0x4a,
-	0x04, 0x90, 0x7a, 0x0d, 0x5e, 0x1f, 0xe8, 0xe1, 0x51, 0xc7, 0x73, 0x9f, 0x5a, 0xc3, 0xbe, 0x31,
-	0x22, 0x8e, 0x8e, 0xc9, 0x57, 0x11, 0x09, 0xa9, 0xfa, 0x5f, 0xd0, 0x98, 0x2e, 0x0a, 0x7d, 0xcf,
-	0x0d, 0x09, 0xba, 0x0f, 0x45, 0x26, 0x4d, 0x23, 0x77, 0x3d, 0xb7, 0x5a, 0x5b, 0x7f, 0xaf, 0xf5,
-	0xa2, 0x89, 0x13, 0x32, 0xb4, 0xe4, 0x28, 0x5a, 0x7d, 0x9f, 0x18, 0x98, 0xb7, 0x54, 0xaf, 0xc2,
-	0xe5, 0x8e, 0xee, 0xeb, 0x87, 0x96, 0x6d, 0x51, 0x8b, 0x84, 0x71, 0xa7, 0x11, 0x5c, 0x99, 0x64,
-	0xcb, 0x0e, 0xbf, 0x80, 0x25, 0x23, 0xc5, 0x97, 0x1d, 0xdf, 0x69, 0x65, 0xd2, 0x58, 0x6b, 0x83,
-	0x53, 0x13, 0xc0, 0x13, 0x70, 0xea, 0x15, 0x40, 0x0f, 0x2c, 0x77, 0x48, 0x02, 0x3f, 0xb0, 0x5c,
-	0x1a, 0x0b, 0xf3, 0x7d, 0x01, 0x2e, 0x4f, 0xb0, 0xa5, 0x30, 0xcf, 0x00, 0x92, 0x79, 0x64, 0xa2,
-	0x14, 0x56, 0x6b, 0xeb, 0x9f, 0x65, 0x14, 0xe5, 0x1c, 0xbc, 0x56, 0x3b, 0x01, 0xeb, 0xba, 0x34,
-	0x38, 0xc5, 0x29, 0x74, 0xf4, 0x25, 0x94, 0x47, 0x44, 0xb7, 0xe9, 0xa8, 0x91, 0xbf, 0x9e, 0x5b,
-	0xad, 0xaf, 0x3f, 0xb8, 0x40, 0x3f, 0x5b, 0x1c, 0xa8, 0x4f, 0x75, 0x4a, 0xb0, 0x44, 0x45, 0x37,
-	0x00, 0x89, 0x3f, 0xcd, 0x24, 0xa1, 0x11, 0x58, 0x3e, 0x33, 0xe4, 0x46, 0xe1, 0x7a, 0x6e, 0xb5,
-	0x8a, 0x2f, 0x89, 0x92, 0x8d, 0x71, 0x41, 0xd3, 0x87, 0x95, 0x33, 0xd2, 0x22, 0x05, 0x0a, 0x47,
-	0xe4, 0x94, 0x6b, 0xa4, 0x8a, 0xd9, 0x2f, 0xda, 0x84, 0xd2, 0xb1, 0x6e, 0x47, 0x84, 0x8b, 0x5c,
-	0x5b, 0xff, 0xe0, 0x65, 0xe6, 0x21, 0x4d, 0x74, 0x3c, 0x0f, 0x58, 0xb4, 0xbf, 0x9b, 0xbf, 0x9d,
-	0x53, 0xef, 0x40, 0x2d, 0x25, 0x37, 0xaa, 0x03, 0x1c, 0xf4, 0x36, 0xba, 0x83, 0x6e, 0x67, 0xd0,
-	0xdd, 0x50, 0x16, 0xd0, 0x32, 0x54, 0x0f, 0x7a, 0x5b, 0xdd, 0xf6, 0xce, 0x60, 0xeb, 0xb1, 0x92,
-	0x43, 0x35, 0x58, 0x8c, 0x89, 0xbc, 0x7a, 0x02, 0x08, 0x13, 0xc3, 0x3b, 0x26, 0x01, 0x33, 0x64,
-	0xa9, 0x55, 0xf4, 0x3a, 0x2c, 0x52, 0x3d, 0x3c, 0xd2, 0x2c, 0x53, 0xca, 0x5c, 0x66, 0xe4, 0xb6,
-	0x89, 0xb6, 0xa1, 0x3c, 0xd2, 0x5d, 0xd3, 0x7e, 0xb9, 0xdc, 0x93, 0x53, 0xcd, 0xc0, 0xb7, 0x78,
-	0x43, 0x2c, 0x01, 0x98, 0x75, 0x4f, 0xf4, 0x2c, 0x14, 0xa0, 0x3e, 0x06, 0xa5, 0x4f, 0xf5, 0x80,
-	0xa6, 0xc5, 0xe9, 0x42, 0x91, 0xf5, 0x2f, 0x2d, 0x7a, 0x96, 0x3e, 0x85, 0x67, 0x62, 0xde, 0x5c,
-	0xfd, 0x7b, 0x1e, 0x2e, 0xa5, 0xb0, 0xa5, 0xa5, 0x3e, 0x82, 0x72, 0x40, 0xc2, 0xc8, 0xa6, 0x1c,
-	0xbe, 0xbe, 0x7e, 0x2f, 0x23, 0xfc, 0x14, 0x52, 0x0b, 0x73, 0x18, 0x2c, 0xe1, 0xd0, 0x2a, 0x28,
-	0xa2, 0x85, 0x46, 0x82, 0xc0, 0x0b, 0x34, 0x27, 0x1c, 0xf2, 0x59, 0xab, 0xe2, 0xba, 0xe0, 0x77,
-	0x19, 0x7b, 0x37, 0x1c, 0xa6, 0x66, 0xb5, 0x70, 0xc1, 0x59, 0x45, 0x3a, 0x28, 0x2e, 0xa1, 0xcf,
-	0xbd, 0xe0, 0x48, 0x63, 0x53, 0x1b, 0x58, 0x26, 0x69, 0x14, 0x39, 0xe8, 0xcd, 0x8c, 0xa0, 0x3d,
-	0xd1, 0x7c, 0x4f, 0xb6, 0xc6, 0x2b, 0xee, 0x24, 0x43, 0x7d, 0x17, 0xca, 0x62, 0xa4, 0xcc, 0x92,
-	0xfa, 0x07, 0x9d, 0x4e, 0xb7, 0xdf, 0x57, 0x16, 0x50, 0x15, 0x4a, 0xb8, 0x3b, 0xc0, 0xcc, 0xc2,
-	0xaa, 0x50, 0x7a, 0xd0, 0x1e, 0xb4, 0x77, 0x94, 0xbc, 0xfa, 0x0e, 0xac, 0x3c, 0xd2, 0x2d, 0x9a,
-	0xc5, 0xb8, 0x54, 0x0f, 0x94, 0x71, 0x5d, 0xa9, 0x9d, 0xed, 0x09, 0xed, 0x64, 0x9f, 0x9a, 0xee,
-	0x89, 0x45, 0xcf, 0xe8, 0x43, 0x81, 0x02, 0x09, 0x02, 0xa9, 0x02, 0xf6, 0xab, 0x3e, 0x87, 0x95,
-	0x3e, 0xf5, 0xfc, 0x4c, 0x96, 0xff, 0x21, 0x2c, 0xb2, 0x35, 0xca, 0x8b, 0xa8, 0x34, 0xfd, 0x6b,
-	0x2d, 0xb1, 0x86, 0xb5, 0xe2, 0x35, 0xac, 0xb5, 0x21, 0xd7, 0x38, 0x1c, 0xd7, 0x44, 0xaf, 0x41,
-	0x39, 0xb4, 0x86, 0xae, 0x6e, 0xcb, 0x68, 0x21, 0x29, 0x15, 0x31, 0x23, 0x8f, 0x3b, 0x96, 0x86,
-	0xdf, 0x01, 0xb4, 0x41, 0x42, 0x1a, 0x78, 0xa7, 0x99, 0xe4, 0xb9, 0x02, 0xa5, 0xa7, 0x5e, 0x60,
-	0x08, 0x47, 0xac, 0x60, 0x41, 0x30, 0xa7, 0x9a, 0x00, 0x91, 0xd8, 0x37, 0x00, 0x6d, 0xbb, 0x6c,
-	0x4d, 0xc9, 0xa6, 0x88, 0x5f, 0xe4, 0xe1, 0xf2, 0x44, 0x7d, 0xa9, 0x8c, 0xf9, 0xfd, 0x90, 0x05,
-	0xa6, 0x28, 0x14, 0x7e, 0x88, 0xf6, 0xa0, 0x2c, 0x6a, 0xc8, 0x99, 0xbc, 0x35, 0x03, 0x90, 0x58,
-	0xa6, 0x24, 0x9c, 0x84, 0x39, 0xd7, 0xe8, 0x0b, 0xaf, 0xd6, 0xe8, 0x9f, 0x83, 0x12, 0x8f, 0x23,
-	0x7c, 0xa9, 0x6e, 0x3e, 0x83, 0xcb, 0x86, 0x67, 0xdb, 0xc4, 0x60, 0xd6, 0xa0, 0x59, 0x2e, 0x25,
-	0xc1, 0xb1, 0x6e, 0xbf, 0xdc, 0x6e, 0xd0, 0xb8, 0xd5, 0xb6, 0x6c, 0xa4, 0x3e, 0x81, 0x4b, 0xa9,
-	0x8e, 0xa5, 0x22, 0x1e, 0x40, 0x29, 0x64, 0x0c, 0xa9, 0x89, 0xf7, 0x67, 0xd4, 0x44, 0x88, 0x45,
-	0x73, 0xf5, 0xb2, 0x00, 0xef, 0x1e, 0x13, 0x37, 0x19, 0x96, 0xba, 0x01, 0x97, 0xfa, 0xdc, 0x4c,
-	0x33, 0xd9, 0xe1, 0xd8, 0xc4, 0xf3, 0x13, 0x26, 0x7e, 0x05, 0x50, 0x1a, 0x45, 0x1a, 0xe2, 0x29,
-	0xac, 0x74, 0x4f, 0x88, 0x91, 0x09, 0xb9, 0x01, 0x8b, 0x86, 0xe7, 0x38, 0xba, 0x6b, 0x36, 0xf2,
-	0xd7, 0x0b, 0xab, 0x55, 0x1c, 0x93, 0x69, 0x5f, 0x2c, 0x64, 0xf5, 0x45, 0xf5, 0xe7, 0x39, 0x50,
-	0xc6, 0x7d, 0xcb, 0x89, 0x64, 0xd2, 0x53, 0x93, 0x01, 0xb1, 0xbe, 0x97, 0xb0, 0xa4, 0x24, 0x3f,
-	0x0e, 0x17, 0x82, 0x4f, 0x82, 0x20, 0x15, 0x8e, 0x0a, 0x17, 0x0c, 0x47, 0xea, 0x16, 0xfc, 0x53,
-	0x2c, 0x4e, 0x9f, 0x06, 0x44, 0x77, 0x2c, 0x77, 0xb8, 0xbd, 0xb7, 0xe7, 0x13, 0x21, 0x38, 0x42,
-	0x50, 0x34, 0x75, 0xaa, 0x4b, 0xc1, 0xf8, 0x3f, 0x73, 0x7a, 0xc3, 0xf6, 0xc2, 0xc4, 0xe9, 0x39,
-	0xa1, 0xfe, 0xb1, 0x00, 0x8d, 0x29, 0xa8, 0x78, 0x7a, 0x9f, 0x40, 0x29, 0x24, 0x34, 0xf2, 0xa5,
-	0xa9, 0x74, 0x33, 0x0b, 0x7c, 0x3e, 0x5e, 0xab, 0xcf, 0xc0, 0xb0, 0xc0, 0x44, 0x43, 0xa8, 0x50,
-	0x7a, 0xaa, 0x85, 0xd6, 0xd7, 0x71, 0x42, 0xb0, 0x73, 0x51, 0xfc, 0x01, 0x09, 0x1c, 0xcb, 0xd5,
-	0xed, 0xbe, 0xf5, 0x35, 0xc1, 0x8b, 0x94, 0x9e, 0xb2, 0x1f, 0xf4, 0x98, 0x19, 0xbc, 0x69, 0xb9,
-	0x72, 0xda, 0x3b, 0xf3, 0xf6, 0x92, 0x9a, 0x60, 0x2c, 0x10, 0x9b, 0x3b, 0x50, 0xe2, 0x63, 0x9a,
-	0xc7, 0x10, 0x15, 0x28, 0x50, 0x7a, 0xca, 0x85, 0xaa, 0x60, 0xf6, 0xdb, 0xfc, 0x08, 0x96, 0xd2,
-	0x23, 0x60, 0x86, 0x34, 0x22, 0xd6, 0x70, 0x24, 0x0c, 0xac, 0x84, 0x25, 0xc5, 0x34, 0xf9, 0xdc,
-	0x32, 0x65, 0xca, 0x5a, 0xc2, 0x82, 0x50, 0x7f, 0x9b, 0x87, 0x6b, 0xe7, 0xcc, 0x8c, 0x34, 0xd6,
-	0x27, 0x13, 0xc6, 0xfa, 0x8a, 0x66, 0x21, 0xb6, 0xf8, 0x27, 0x13, 0x16, 0xff, 0x0a, 0xc1, 0x99,
-	0xdb, 0xbc, 0x06, 0x65, 0x72, 0x62, 0x51, 0x62, 0xca, 0xa9, 0x92, 0x54, 0xca, 0x9d, 0x8a, 0x17,
-	0x75, 0xa7, 0xbf, 0xe6, 0x00, 0x4d, 0xef, 0x61, 0xd0, 0x5b, 0xb0, 0x14, 0x12, 0xd7, 0xd4, 0x44,
-	0x54, 0x12, 0x01, 0xb3, 0x82, 0x6b, 0x8c, 0x27, 0xc2, 0x53, 0xc8, 0x1c, 0x8d, 0x9c, 0x10, 0x43,
-	0xfa, 0x14, 0xff, 0x47, 0x23, 0x58, 0x7a, 0x1a, 0x6a, 0x56, 0xe8, 0xd9, 0x7a, 0x92, 0xec, 0xd7,
-	0x33, 0x3b, 0xcf, 0xb4, 0x1c, 0xad, 0x07, 0xfd, 0xed, 0x18, 0x0c, 0xd7, 0x9e, 0x86, 0x09, 0xa1,
-	0xb6, 0xa0, 0x96, 0x2a, 0x43, 0x15, 0x28, 0xf6, 0xf6, 0x7a, 0x5d, 0x65, 0x01, 0x01, 0x94, 0x3b,
-	0x5b, 0x78, 0x6f, 0x6f, 0x20, 0x12, 0xaa, 0xed, 0xdd, 0xf6, 0x66, 0x57, 0xc9, 0xab, 0xbf, 0x2b,
-	0x03, 0x8c, 0x33, 0x5b, 0x54, 0x87, 0x7c, 0x62, 0xaf, 0x79, 0xcb, 0x64, 0x83, 0x71, 0x75, 0x87,
-	0xc8, 0x60, 0xcc, 0xff, 0xd1, 0x3a, 0x5c, 0x75, 0xc2, 0xa1, 0xaf, 0x1b, 0x47, 0x9a, 0x4c, 0x48,
-	0x0d, 0xde, 0x98, 0x8f, 0x6a, 0x09, 0x5f, 0x96, 0x85, 0x52, 0x6a, 0x81, 0xbb, 0x03, 0x05, 0xe2,
-	0x1e, 0x37, 0x8a, 0x7c, 0xe3, 0x76, 0x77, 0xe6, 0x8c, 0xbb, 0xd5, 0x75, 0x8f, 0xc5, 0x46, 0x8d,
-	0xc1, 0x20, 0x0d, 0xc0, 0x24, 0xc7, 0x96, 0x41, 0x34, 0x06, 0x5a, 0xe2, 0xa0, 0xf7, 0x67, 0x07,
-	0xdd, 0xe0, 0x18, 0x09, 0x74, 0xd5, 0x8c, 0x69, 0xd4, 0x83, 0x6a, 0x40, 0x42, 0x2f, 0x0a, 0x0c,
-	0x12, 0x36, 0xca, 0x33, 0x2d, 0x8a, 0x38, 0x6e, 0x87, 0xc7, 0x10, 0x68, 0x03, 0xca, 0x8e, 0x17,
-	0xb9, 0x34, 0x6c, 0x2c, 0x72, 0x61, 0xdf, 0xcb, 0x08, 0xb6, 0xcb, 0x1a, 0x61, 0xd9, 0x16, 0x6d,
-	0xc2, 0xa2, 0x10, 0x31, 0x6c, 0x54, 0x38, 0xcc, 0x8d, 0xac, 0x06, 0xc4, 0x5b, 0xe1, 0xb8, 0x35,
-	0xd3, 0x6a, 0x14, 0x92, 0xa0, 0x51, 0x15, 0x5a, 0x65, 0xff, 0xe8, 0x0d, 0xa8, 0xea, 0xb6, 0xed,
-	0x19, 0x9a, 0x69, 0x05, 0x0d, 0xe0, 0x05, 0x15, 0xce, 0xd8, 0xb0, 0x02, 0xf4, 0x26, 0xd4, 0x84,
-	0x5f, 0x6b, 0xbe, 0x4e, 0x47, 0x8d, 0x1a, 0x2f, 0x06, 0xc1, 0xda, 0xd7, 0xe9, 0x48, 0x56, 0x20,
-	0x41, 0x20, 0x2a, 0x2c, 0x25, 0x15, 0x48, 0x10, 0xf0, 0x0a, 0xff, 0x0a, 0x2b, 0x3c, 0x1a, 0x0e,
-	0x03, 0x2f, 0xf2, 0x35, 0x6e, 0x53, 0xcb, 0xbc, 0xd2, 0x32, 0x63, 0x6f, 0x32, 0x6e, 0x8f, 0x19,
-	0xd7, 0x35, 0xa8, 0x3c, 0xf3, 0x0e, 0x45, 0x85, 0x3a, 0xaf, 0xb0, 0xf8, 0xcc, 0x3b, 0x8c, 0x8b,
-	0x84, 0x84, 0x96, 0xd9, 0x58, 0x11, 0x45, 0x9c, 0xde, 0x36, 0x9b, 0x37, 0xa1, 0x12, 0xab, 0xf1,
-	0x9c, 0xcd, 0xf1, 0x95, 0xf4, 0xe6, 0xb8, 0x9a, 0xda, 0xe9, 0x36, 0x3f, 0x82, 0xfa, 0xa4, 0x11,
-	0xcc, 0xd2, 0x5a, 0xfd, 0x53, 0x0e, 0xaa, 0x89, 0xba, 0x91, 0x0b, 0x97, 0xb9, 0x38, 0x3a, 0x25,
-	0xa6, 0x36, 0xb6, 0x1e, 0x11, 0x5b, 0x3f, 0xce, 0xa8, 0xa9, 0x76, 0x8c, 0x20, 0xd3, 0x0a, 0x69,
-	0x4a, 0x28, 0x41, 0x1e, 0xf7, 0xf7, 0x25, 0xac, 0xd8, 0x96, 0x1b, 0x9d, 0xa4, 0xfa, 0x12, 0xa1,
-	0xf6, 0xdf, 0x32, 0xf6, 0xb5, 0xc3, 0x5a, 0x8f, 0xfb, 0xa8, 0xdb, 0x13, 0xb4, 0xfa, 0x6d, 0x1e,
-	0x5e, 0x3b, 0x5f, 0x1c, 0xd4, 0x83, 0x82, 0xe1, 0x47, 0x72, 0x68, 0x1f, 0xcd, 0x3a, 0xb4, 0x8e,
-	0x1f, 0x8d, 0x7b, 0x65, 0x40, 0x6c, 0xcf, 0xec, 0x10, 0xc7, 0x0b, 0x4e, 0xe5, 0x08, 0xee, 0xcd,
-	0x0a, 0xb9, 0xcb, 0x5b, 0x8f, 0x51, 0x25, 0x1c, 0xc2, 0x50, 0x91, 0x99, 0x77, 0x28, 0xc3, 0xc4,
-	0x8c, 0x19, 0x7c, 0x0c, 0x89, 0x13, 0x1c, 0xf5, 0x26, 0x5c, 0x3d, 0x77, 0x28, 0xe8, 0x9f, 0x01,
-	0x0c, 0x3f, 0xd2, 0xf8, 0x09, 0x8b, 0xd0, 0x7b, 0x01, 0x57, 0x0d, 0x3f, 0xea, 0x73, 0x86, 0x7a,
-	0x0b, 0x1a, 0x2f, 0x92, 0x97, 0x39, 0x9f, 0x90, 0x58, 0x73, 0x0e, 0xf9, 0x1c, 0x14, 0x70, 0x45,
-	0x30, 0x76, 0x0f, 0xd5, 0xef, 0xf2, 0xb0, 0x72, 0x46, 0x1c, 0xb6, 0x02, 0x0a, 0x67, 0x8e, 0x73,
-	0x0b, 0x41, 0x31, 0xcf, 0x36, 0x2c, 0x33, 0xde, 0x95, 0xf2, 0x7f, 0x1e, 0xd3, 0x7d, 0xb9, 0x63,
-	0xcc, 0x5b, 0x3e, 0x33, 0x68, 0xe7, 0xd0, 0xa2, 0x21, 0x5f, 0x24, 0x4b, 0x58, 0x10, 0xe8, 0x31,
-	0xd4, 0x03, 0x12, 0x92, 0xe0, 0x98, 0x98, 0x9a, 0xef, 0x05, 0x34, 0x9e, 0xb0, 0xf5, 0xd9, 0x26,
-	0x6c, 0xdf, 0x0b, 0x28, 0x5e, 0x8e, 0x91, 0x18, 0x15, 0xa2, 0x47, 0xb0, 0x6c, 0x9e, 0xba, 0xba,
-	0x63, 0x19, 0x12, 0xb9, 0x3c, 0x37, 0xf2, 0x92, 0x04, 0xe2, 0xc0, 0xea, 0x1d, 0xa8, 0xa5, 0x0a,
-	0xd9, 0xc0, 0x6c, 0xfd, 0x90, 0xd8, 0x72, 0x4e, 0x04, 0x31, 0xe9, 0xbf, 0x25, 0xe9, 0xbf, 0xea,
-	0xaf, 0xf3, 0x50, 0x9f, 0x74, 0x80, 0x58, 0x7f, 0x3e, 0x09, 0x2c, 0xcf, 0x4c, 0xe9, 0x6f, 0x9f,
-	0x33, 0x98, 0x8e, 0x58, 0xf1, 0x57, 0x91, 0x47, 0xf5, 0x58, 0x47, 0x86, 0x1f, 0xfd, 0x07, 0xa3,
-	0xcf, 0xe8, 0xbe, 0x70, 0x46, 0xf7, 0xe8, 0x3d, 0x40, 0x52, 0xbf, 0xb6, 0xe5, 0x58, 0x54, 0x3b,
-	0x3c, 0xa5, 0x44, 0xcc, 0x7f, 0x01, 0x2b, 0xa2, 0x64, 0x87, 0x15, 0x7c, 0xca, 0xf8, 0x48, 0x85,
-	0x65, 0xcf, 0x73, 0xb4, 0xd0, 0xf0, 0x02, 0xa2, 0xe9, 0xe6, 0xb3, 0x46, 0x89, 0x57, 0xac, 0x79,
-	0x9e, 0xd3, 0x67, 0xbc, 0xb6, 0xf9, 0x8c, 0x05, 0x5c, 0xc3, 0x8f, 0x42, 0x42, 0x35, 0xf6, 0xe1,
-	0x6b, 0x54, 0x15, 0x83, 0x60, 0x75, 0xfc, 0x28, 0x4c, 0x55, 0x70, 0x88, 0xc3, 0xd6, 0x9d, 0x54,
-	0x85, 0x5d, 0xe2, 0xb0, 0x5e, 0x96, 0xf6, 0x49, 0x60, 0x10, 0x97, 0x0e, 0x2c, 0xe3, 0x88, 0x2d,
-	0x29, 0xb9, 0xd5, 0x1c, 0x9e, 0xe0, 0xa9, 0x5f, 0x40, 0x89, 0x2f, 0x41, 0x6c, 0xf0, 0x3c, 0x7c,
-	0xf3, 0xe8, 0x2e, 0xa6, 0xb7, 0xc2, 0x18, 0x3c, 0xb6, 0xbf, 0x01, 0xd5, 0x91, 0x17, 0xca, 0xb5,
-	0x41, 0x58, 0x5e, 0x85, 0x31, 0x78, 0x61, 0x13, 0x2a, 0x01, 0xd1, 0x4d, 0xcf, 0xb5, 0xe3, 0xc4,
-	0x36, 0xa1, 0xd5, 0xaf, 0xa0, 0x2c, 0xc2, 0xef, 0x05, 0xf0, 0x6f, 0x00, 0x32, 0xc4, 0xa2, 0xe2,
-	0xb3, 0x44, 0x39, 0x0c, 0x2d, 0xcf, 0x0d, 0xe3, 0xd3, 0x54, 0x51, 0xb2, 0x3f, 0x2e, 0x50, 0xff,
-	0x9c, 0x13, 0xf9, 0x8e, 0x38, 0xe7, 0x62, 0xb9, 0x38, 0xb3, 0x34, 0x96, 0x93, 0x89, 0x84, 0x3a,
-	0x26, 0x59, 0x2e, 0x29, 0xd3, 0x9a, 0xfc, 0xbc, 0xc7, 0x84, 0x12, 0x20, 0xde, 0x5e, 0x13, 0x99,
-	0xf6, 0xcd, 0xba, 0xbd, 0x26, 0x62, 0x7b, 0x4d, 0x58, 0xf2, 0x29, 0x13, 0x2e, 0x01, 0x57, 0xe4,
-	0xf9, 0x56, 0xcd, 0x4c, 0xce, 0x30, 0x88, 0xfa, 0xb7, 0x5c, 0x12, 0x2b, 0xe2, 0xb3, 0x06, 0xf4,
-	0x25, 0x54, 0x98, 0xdb, 0x69, 0x8e, 0xee, 0xcb, 0x93, 0xf3, 0xce, 0x7c, 0xc7, 0x18, 0x2d, 0xe6,
-	0x65, 0xbb, 0xba, 0x2f, 0xd2, 0xa5, 0x45, 0x5f, 0x50, 0x2c, 0xe6, 0xe8, 0xe6, 0x38, 0xe6, 0xb0,
-	0x7f, 0xf4, 0x36, 0xd4, 0xf5, 0x88, 0x7a, 0x9a, 0x6e, 0x1e, 0x93, 0x80, 0x5a, 0x21, 0x91, 0xba,
-	0x5f, 0x66, 0xdc, 0x76, 0xcc, 0x6c, 0xde, 0x85, 0xa5, 0x34, 0xe6, 0xcb, 0x56, 0xdf, 0x52, 0x7a,
-	0xf5, 0xfd, 0x6f, 0x80, 0x71, 0xde, 0xce, 0x6c, 0x84, 0x6d, 0x02, 0x34, 0xc3, 0x33, 0x89, 0x54,
-	0x65, 0x85, 0x31, 0x3a, 0x9e, 0x49, 0xce, 0x1c, 0x2a, 0x94, 0xe2, 0x43, 0x05, 0xe6, 0xb5, 0xcc,
-	0xd1, 0x8e, 0x2c, 0xdb, 0x4e, 0xf6, 0x12, 0x55, 0xcf, 0x73, 0x1e, 0x72, 0x86, 0xfa, 0x7d, 0x5e,
-	0xd8, 0x8a, 0x38, 0x1e, 0xca, 0x94, 0x1b, 0xbf, 0x2a, 0x55, 0xdf, 0x01, 0x08, 0xa9, 0x1e, 0xb0,
-	0x54, 0x42, 0x8f, 0x77, 0x33, 0xcd, 0xa9, 0x53, 0x89, 0x41, 0x7c, 0xcb, 0x85, 0xab, 0xb2, 0x76,
-	0x9b, 0xa2, 0x8f, 0x61, 0xc9, 0xf0, 0x1c, 0xdf, 0x26, 0xb2, 0x71, 0xe9, 0xa5, 0x8d, 0x6b, 0x49,
-	0xfd, 0x36, 0x4d, 0xed, 0xa1, 0xca, 0x17, 0xdd, 0x43, 0xfd, 0x3e, 0x27, 0x4e, 0xb9, 0xd2, 0x87,
-	0x6c, 0x68, 0x78, 0xce, 0x4d, 0xce, 0xe6, 0x9c, 0x27, 0x76, 0x3f, 0x74, 0x8d, 0xd3, 0xfc, 0x38,
-	0xcb, 0xbd, 0xc9, 0x8b, 0x93, 0xbb, 0x3f, 0x14, 0xa0, 0x9a, 0x1c, 0x70, 0x4d, 0xe9, 0xfe, 0x36,
-	0x54, 0x93, 0x2b, 0x46, 0x19, 0x20, 0x7e, 0x50, 0x3d, 0x49, 0x65, 0xf4, 0x14, 0x90, 0x3e, 0x1c,
-	0x26, 0x49, 0x9b, 0x16, 0x85, 0xfa, 0x30, 0x3e, 0x5e, 0xbc, 0x3d, 0xc3, 0x3c, 0xc4, 0xeb, 0xd6,
-	0x01, 0x6b, 0x8f, 0x15, 0x7d, 0x38, 0x9c, 0xe0, 0xa0, 0xff, 0x81, 0xab, 0x93, 0x7d, 0x68, 0x87,
-	0xa7, 0x9a, 0x6f, 0x99, 0x72, 0x0f, 0xb6, 0x35, 0xeb, 0x19, 0x5f, 0x6b, 0x02, 0xfe, 0xd3, 0xd3,
-	0x7d, 0xcb, 0x14, 0x73, 0x8e, 0x82, 0xa9, 0x82, 0xe6, 0xff, 0xc1, 0xeb, 0x2f, 0xa8, 0x7e, 0x8e,
-	0x0e, 0x7a, 0x93, 0x77, 0x57, 0xf3, 0x4f, 0x42, 0x4a, 0x7b, 0xbf, 0xca, 0x89, 0xa3, 0xc8, 0xc9,
-	0x39, 0x69, 0xa7, 0xf3, 0xd6, 0xb5, 0x8c, 0xfd, 0x74, 0xf6, 0x0f, 0x04, 0x3c, 0x4f, 0x55, 0x3f,
-	0x3b, 0x93, 0xaa, 0x66, 0x4d, 0x62, 0x44, 0xc6, 0x27, 0x80, 0x24, 0x82, 0xfa, 0x9b, 0x02, 0x54,
-	0x62, 0x74, 0xbe, 0x83, 0x3a, 0x0d, 0x29, 0x71, 0x34, 0x27, 0x0e, 0x61, 0x39, 0x0c, 0x82, 0xb5,
-	0xcb, 0x82, 0xd8, 0x1b, 0x50, 0x65, 0x1b, 0x35, 0x51, 0x9c, 0xe7, 0xc5, 0x15, 0xc6, 0xe0, 0x85,
-	0x6f, 0x42, 0x8d, 0x7a, 0x54, 0xb7, 0x35, 0xca, 0xd7, 0xf2, 0x82, 0x68, 0xcd, 0x59, 0x7c, 0x25,
-	0x47, 0xef, 0xc2, 0x25, 0x3a, 0x0a, 0x3c, 0x4a, 0x6d, 0x96, 0xdf, 0xf1, 0x8c, 0x46, 0x24, 0x20,
-	0x45, 0xac, 0x24, 0x05, 0x22, 0xd3, 0x09, 0x59, 0xf4, 0x1e, 0x57, 0x66, 0xa6, 0xcb, 0x83, 0x48,
-	0x11, 0x2f, 0x27, 0x5c, 0x66, 0xda, 0x6c, 0xf1, 0xf4, 0x45, 0xb6, 0xc0, 0x63, 0x45, 0x0e, 0xc7,
-	0x24, 0xd2, 0x60, 0xc5, 0x21, 0x7a, 0x18, 0x05, 0xc4, 0xd4, 0x9e, 0x5a, 0xc4, 0x36, 0xc5, 0xc6,
-	0xb7, 0x9e, 0x39, 0xfd, 0x8e, 0xa7, 0xa5, 0xf5, 0x80, 0xb7, 0xc6, 0xf5, 0x18, 0x4e, 0xd0, 0x2c,
-	0x73, 0x10, 0x7f, 0x68, 0x05, 0x6a, 0xfd, 0xc7, 0xfd, 0x41, 0x77, 0x57, 0xdb, 0xdd, 0xdb, 0xe8,
-	0xca, 0xeb, 0xc9, 0x7e, 0x17, 0x0b, 0x32, 0xc7, 0xca, 0x07, 0x7b, 0x83, 0xf6, 0x8e, 0x36, 0xd8,
-	0xee, 0x3c, 0xec, 0x2b, 0x79, 0x74, 0x15, 0x2e, 0x0d, 0xb6, 0xf0, 0xde, 0x60, 0xb0, 0xd3, 0xdd,
-	0xd0, 0xf6, 0xbb, 0x78, 0x7b, 0x6f, 0xa3, 0xaf, 0x14, 0x10, 0x82, 0xfa, 0x98, 0x3d, 0xd8, 0xde,
-	0xed, 0x2a, 0x45, 0x54, 0x83, 0xc5, 0xfd, 0x2e, 0xee, 0x74, 0x7b, 0x03, 0xa5, 0xa4, 0x7e, 0x57,
-	0x80, 0x5a, 0x4a, 0x8b, 0xcc, 0x90, 0x83, 0x50, 0xe4, 0xf9, 0x45, 0xcc, 0x7e, 0xf9, 0x71, 0xaa,
-	0x6e, 0x8c, 0x84, 0x76, 0x8a, 0x58, 0x10, 0x3c, 0xb7, 0xd7, 0x4f, 0x52, 0x7e, 0x5e, 0xc4, 0x15,
-	0x47, 0x3f, 0x11, 0x20, 0x6f, 0xc1, 0xd2, 0x11, 0x09, 0x5c, 0x62, 0xcb, 0x72, 0xa1, 0x91, 0x9a,
-	0xe0, 0x89, 0x2a, 0xab, 0xa0, 0xc8, 0x2a, 0x63, 0x18, 0xa1, 0x8e, 0xba, 0xe0, 0xef, 0xc6, 0x60,
-	0x57, 0xa0, 0x24, 0x8a, 0x17, 0x45, 0xff, 0x9c, 0x60, 0xcb, 0x54, 0xf8, 0x5c, 0xf7, 0x79, 0x7e,
-	0x57, 0xc4, 0xfc, 0x1f, 0x1d, 0x4e, 0xeb, 0xa7, 0xcc, 0xf5, 0x73, 0x67, 0x76, 0x73, 0x7e, 0x91,
-	0x8a, 0x46, 0x89, 0x8a, 0x16, 0xa1, 0x80, 0xe3, 0x3b, 0xbd, 0x4e, 0xbb, 0xb3, 0xc5, 0xd4, 0xb2,
-	0x0c, 0xd5, 0xdd, 0xf6, 0xe7, 0xda, 0x41, 0x9f, 0x1f, 0x43, 0x21, 0x05, 0x96, 0x1e, 0x76, 0x71,
-	0xaf, 0xbb, 0x23, 0x39, 0x05, 0x74, 0x05, 0x14, 0xc9, 0x19, 0xd7, 0x2b, 0x32, 0x04, 0xf1, 0x5b,
-	0x42, 0x15, 0x28, 0xf6, 0x1f, 0xb5, 0xf7, 0x95, 0xb2, 0xfa, 0x97, 0x3c, 0xac, 0x88, 0x65, 0x21,
-	0xb9, 0x7d, 0x78, 0xf1, 0xe9, 0x6b, 0xfa, 0x14, 0x21, 0x3f, 0x71, 0x8a, 0x90, 0x24, 0xa1, 0x7c,
-	0x55, 0x2f, 0x8c, 0x93, 0x50, 0x7e, 0xfa, 0x30, 0x11, 0xf1, 0x8b, 0xb3, 0x44, 0xfc, 0x06, 0x2c,
-	0x3a, 0x24, 0x4c, 0xf4, 0x56, 0xc5, 0x31, 0x89, 0x2c, 0xa8, 0xe9, 0xae, 0xeb, 0x51, 0x7e, 0x56,
-	0x17, 0x6f, 0x8b, 0x36, 0x67, 0x3a, 0x15, 0x4c, 0x46, 0xdc, 0x6a, 0x8f, 0x91, 0x44, 0x60, 0x4e,
-	0x63, 0x37, 0x3f, 0x01, 0xe5, 0x6c, 0x85, 0x59, 0x96, 0xc3, 0x77, 0x3e, 0x18, 0xaf, 0x86, 0x84,
-	0xf9, 0xc5, 0x41, 0xef, 0x61, 0x6f, 0xef, 0x51, 0x4f, 0x59, 0x60, 0x04, 0x3e, 0xe8, 0xf5, 0xb6,
-	0x7b, 0x9b, 0x4a, 0x0e, 0x01, 0x94, 0xbb, 0x9f, 0x6f, 0x0f, 0xba, 0x1b, 0x4a, 0x7e, 0xfd, 0x47,
-	0x2b, 0x50, 0x16, 0x42, 0xa2, 0x6f, 0x65, 0x26, 0x90, 0x7e, 0xd9, 0x82, 0x3e, 0x99, 0x39, 0xa3,
-	0x9e, 0x78, 0x2d, 0xd3, 0xbc, 0x37, 0x77, 0x7b, 0x79, 0x7b, 0xb4, 0x80, 0x7e, 0x96, 0x83, 0xa5,
-	0x89, 0xf3, 0xdd, 0xac, 0x47, 0x93, 0xe7, 0x3c, 0xa4, 0x69, 0xfe, 0xfb, 0x5c, 0x6d, 0x13, 0x59,
-	0x7e, 0x9a, 0x83, 0x5a, 0xea, 0x09, 0x09, 0xba, 0x33, 0xcf, 0xb3, 0x13, 0x21, 0xc9, 0xdd, 0xf9,
-	0x5f, 0xac, 0xa8, 0x0b, 0xef, 0xe7, 0xd0, 0x4f, 0x72, 0x50, 0x4b, 0x3d, 0xa6, 0xc8, 0x2c, 0xca,
-	0xf4, 0xd3, 0x8f, 0xcc, 0xa2, 0x9c, 0xf7, 0x76, 0x63, 0x01, 0xfd, 0x7f, 0x0e, 0xaa, 0xc9, 0xc3,
-	0x08, 0x74, 0x6b, 0xf6, 0xa7, 0x14, 0x42, 0x88, 0xdb, 0xf3, 0xbe, 0xc1, 0x50, 0x17, 0xd0, 0xff,
-	0x42, 0x25, 0x7e, 0x45, 0x80, 0xb2, 0xae, 0x5e, 0x67, 0x9e, 0x28, 0x34, 0x6f, 0xcd, 0xdc, 0x2e,
-	0xdd, 0x7d, 0x7c, 0xb5, 0x9f, 0xb9, 0xfb, 0x33, 0x8f, 0x10, 0x9a, 0xb7, 0x66, 0x6e, 0x97, 0x74,
-	0xcf, 0x2c, 0x21, 0xf5, 0x02, 0x20, 0xb3, 0x25, 0x4c, 0x3f, 0x3d, 0xc8, 0x6c, 0x09, 0xe7, 0x3d,
-	0x38, 0x10, 0x82, 0xa4, 0xde, 0x10, 0x64, 0x16, 0x64, 0xfa, 0x9d, 0x42, 0x66, 0x41, 0xce, 0x79,
-	0xb2, 0xa0, 0x2e, 0xa0, 0x6f, 0x72, 0xe9, 0x7d, 0xc1, 0xad, 0x99, 0xaf, 0xca, 0x67, 0x34, 0xc9,
-	0xa9, 0xcb, 0x7a, 0xee, 0xa0, 0xdf, 0xc8, 0x53, 0x0c, 0x71, 0xd3, 0x8e, 0x66, 0x01, 0x9b, 0xb8,
-	0x9c, 0x6f, 0xde, 0x9c, 0x6f, 0xb1, 0xe1, 0x42, 0xfc, 0x38, 0x07, 0x30, 0xbe, 0x93, 0xcf, 0x2c,
-	0xc4, 0xd4, 0x63, 0x80, 0xe6, 0x9d, 0x39, 0x5a, 0xa6, 0x1d, 0x24, 0xbe, 0x33, 0xcc, 0xec, 0x20,
-	0x67, 0xde, 0x0c, 0x64, 0x76, 0x90, 0xb3, 0xf7, 0xfd, 0xea, 0x02, 0xfa, 0x65, 0x0e, 0x2e, 0x4d,
-	0xdd, 0x59, 0xa2, 0x7b, 0x17, 0xbc, 0xb6, 0x6e, 0xde, 0x9f, 0x1f, 0x20, 0x16, 0x6d, 0x35, 0xf7,
-	0x7e, 0xee, 0xd3, 0xc5, 0xff, 0x2c, 0x89, 0xe4, 0xa4, 0xcc, 0x3f, 0x1f, 0xfe, 0x23, 0x00, 0x00,
-	0xff, 0xff, 0x8b, 0x62, 0x9f, 0x34, 0x95, 0x2b, 0x00, 0x00,
+	// 3253 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x5a, 0x4b, 0x73, 0x1b, 0xc7,
+	0x11, 0x26, 0x9e, 0x04, 0x1a, 0x20, 0xb8, 0x1a, 0x49, 0x36, 0x04, 0x27, 0xb1, 0xbc, 0x29, 0xa7,
+	0x58, 0xb6, 0x05, 0xda, 0x74, 0x45, 0xaf, 0xd8, 0x96, 0x60, 0x10, 0x22, 0x69, 0x91, 0x20, 0x33,
+	0x00, 0x4b, 0x56, 0x14, 0x7b, 0xb3, 0xdc, 0x1d, 0x01, 0x2b, 0xee, 0xcb, 0xbb, 0x03, 0x8a, 0x74,
+	0x2a, 0x95, 0xc4, 0xa9, 0x4a, 0x25, 0x87, 0x54, 0xe5, 0xe2, 0xf2, 0x25, 0xa7, 0x5c, 0xf3, 0x07,
+	0xf2, 0x28, 0x9f, 0x93, 0xff, 0x90, 0x5c, 0x72, 0x48, 0x55, 0xae, 0xf9, 0x07, 0xa9, 0x79, 0xec,
+	0x62, 0x41, 0x50, 0xd6, 0x02, 0xd4, 0x69, 0xb7, 0x7b, 0xa6, 0xbf, 0xe9, 0x99, 0xee, 0x9e, 0xe9,
+	0x79, 0x80, 0xea, 0xdb, 0xa3, 0x81, 0xe5, 0x86, 0xab, 0x66, 0x60, 0x1d, 0x91, 0x20, 0x5c, 0xf5,
+	0x03, 0x8f, 0x7a, 0x92, 0x6a, 0x72, 0x02, 0xbd, 0x3e, 0xd4, 0xc3, 0xa1, 0x65, 0x78, 0x81, 0xdf,
+	0x74, 0x3d, 0x47, 0x37, 0x9b, 0x52, 0xa6, 0x29, 0x65, 0x44, 0xb5, 0xc6, 0x77, 0x06, 0x9e, 0x37,
+	0xb0, 0x89, 0x40, 0x38, 0x18, 0x3d, 0x5e, 0x35, 0x47, 0x81, 0x4e, 0x2d, 0xcf, 0x95, 0xe5, 0xaf,
+	0x9e, 0x2e, 0xa7, 0x96, 0x43, 0x42, 0xaa, 0x3b, 0xbe, 0xac, 0x70, 0x77, 0x60, 0xd1, 0xe1, 0xe8,
+	0xa0, 0x69, 0x78, 0xce, 0x6a, 0xdc, 0xe4, 0x2a, 0x6f, 0x72, 0x35, 0x52, 0x33, 0x1c, 0xea, 0x01,
+	0x31, 0x57, 0x87, 0x86, 0x1d, 0xfa, 0xc4, 0x60, 0x5f, 0x8d, 0xfd, 0x48, 0x84, 0x8d, 0xf4, 0x08,
+	0x21, 0x0d, 0x46, 0x06, 0x8d, 0xfa, 0xab, 0x53, 0x1a, 0x58, 0x07, 0x23, 0x4a, 0x04, 0x90, 0x7a,
+	0x05, 0x5e, 0xee, 0xeb, 0xe1, 0x61, 0xdb, 0x73, 0x1f, 0x5b, 0x83, 0x9e, 0x31, 0x24, 0x8e, 0x8e,
+	0xc9, 0x67, 0x23, 0x12, 0x52, 0xf5, 0xc7, 0x50, 0x9f, 0x2e, 0x0a, 0x7d, 0xcf, 0x0d, 0x09, 0xba,
+	0x0b, 0x79, 0xa6, 0x4d, 0x3d, 0x73, 0x35, 0xb3, 0x52, 0x59, 0x7b, 0xab, 0xf9, 0xac, 0x81, 0x13,
+	0x3a, 0x34, 0x65, 0x2f, 0x9a, 0x3d, 0x9f, 0x18, 0x98, 0x4b, 0xaa, 0x97, 0xe1, 0x62, 0x5b, 0xf7,
+	0xf5, 0x03, 0xcb, 0xb6, 0xa8, 0x45, 0xc2, 0xa8, 0xd1, 0x11, 0x5c, 0x9a, 0x64, 0xcb, 0x06, 0x3f,
+	0x81, 0xaa, 0x91, 0xe0, 0xcb, 0x86, 0x6f, 0x35, 0x53, 0x59, 0xac, 0xb9, 0xce, 0xa9, 0x09, 0xe0,
+	0x09, 0x38, 0xf5, 0x12, 0xa0, 0x7b, 0x96, 0x3b, 0x20, 0x81, 0x1f, 0x58, 0x2e, 0x8d, 0x94, 0xf9,
+	0x3a, 0x07, 0x17, 0x27, 0xd8, 0x52, 0x99, 0x27, 0x00, 0xf1, 0x38, 0x32, 0x55, 0x72, 0x2b, 0x95,
+	0xb5, 0x8f, 0x52, 0xaa, 0x72, 0x06, 0x5e, 0xb3, 0x15, 0x83, 0x75, 0x5c, 0x1a, 0x9c, 0xe0, 0x04,
+	0x3a, 0xfa, 0x14, 0x8a, 0x43, 0xa2, 0xdb, 0x74, 0x58, 0xcf, 0x5e, 0xcd, 0xac, 0xd4, 0xd6, 0xee,
+	0x9d, 0xa3, 0x9d, 0x4d, 0x0e, 0xd4, 0xa3, 0x3a, 0x25, 0x58, 0xa2, 0xa2, 0x6b, 0x80, 0xc4, 0x9f,
+	0x66, 0x92, 0xd0, 0x08, 0x2c, 0x9f, 0x39, 0x72, 0x3d, 0x77, 0x35, 0xb3, 0x52, 0xc6, 0x17, 0x44,
+	0xc9, 0xfa, 0xb8, 0xa0, 0xe1, 0xc3, 0xf2, 0x29, 0x6d, 0x91, 0x02, 0xb9, 0x43, 0x72, 0xc2, 0x2d,
+	0x52, 0xc6, 0xec, 0x17, 0x6d, 0x40, 0xe1, 0x48, 0xb7, 0x47, 0x84, 0xab, 0x5c, 0x59, 0x7b, 0xe7,
+	0x79, 0xee, 0x21, 0x5d, 0x74, 0x3c, 0x0e, 0x58, 0xc8, 0xdf, 0xce, 0xde, 0xcc, 0xa8, 0xb7, 0xa0,
+	0x92, 0xd0, 0x1b, 0xd5, 0x00, 0xf6, 0xbb, 0xeb, 0x9d, 0x7e, 0xa7, 0xdd, 0xef, 0xac, 0x2b, 0x0b,
+	0x68, 0x09, 0xca, 0xfb, 0xdd, 0xcd, 0x4e, 0x6b, 0xbb, 0xbf, 0xf9, 0x50, 0xc9, 0xa0, 0x0a, 0x2c,
+	0x46, 0x44, 0x56, 0x3d, 0x06, 0x84, 0x89, 0xe1, 0x1d, 0x91, 0x80, 0x39, 0xb2, 0xb4, 0x2a, 0x7a,
+	0x19, 0x16, 0xa9, 0x1e, 0x1e, 0x6a, 0x96, 0x29, 0x75, 0x2e, 0x32, 0x72, 0xcb, 0x44, 0x5b, 0x50,
+	0x1c, 0xea, 0xae, 0x69, 0x3f, 0x5f, 0xef, 0xc9, 0xa1, 0x66, 0xe0, 0x9b, 0x5c, 0x10, 0x4b, 0x00,
+	0xe6, 0xdd, 0x13, 0x2d, 0x0b, 0x03, 0xa8, 0x0f, 0x41, 0xe9, 0x51, 0x3d, 0xa0, 0x49, 0x75, 0x3a,
+	0x90, 0x67, 0xed, 0x4b, 0x8f, 0x9e, 0xa5, 0x4d, 0x11, 0x99, 0x98, 0x8b, 0xab, 0xff, 0xcb, 0xc2,
+	0x85, 0x04, 0xb6, 0xf4, 0xd4, 0x07, 0x50, 0x0c, 0x48, 0x38, 0xb2, 0x29, 0x87, 0xaf, 0xad, 0xdd,
+	0x49, 0x09, 0x3f, 0x85, 0xd4, 0xc4, 0x1c, 0x06, 0x4b, 0x38, 0xb4, 0x02, 0x8a, 0x90, 0xd0, 0x48,
+	0x10, 0x78, 0x81, 0xe6, 0x84, 0x03, 0x3e, 0x6a, 0x65, 0x5c, 0x13, 0xfc, 0x0e, 0x63, 0xef, 0x84,
+	0x83, 0xc4, 0xa8, 0xe6, 0xce, 0x39, 0xaa, 0x48, 0x07, 0xc5, 0x25, 0xf4, 0xa9, 0x17, 0x1c, 0x6a,
+	0x6c, 0x68, 0x03, 0xcb, 0x24, 0xf5, 0x3c, 0x07, 0xbd, 0x9e, 0x12, 0xb4, 0x2b, 0xc4, 0x77, 0xa5,
+	0x34, 0x5e, 0x76, 0x27, 0x19, 0xea, 0x9b, 0x50, 0x14, 0x3d, 0x65, 0x9e, 0xd4, 0xdb, 0x6f, 0xb7,
+	0x3b, 0xbd, 0x9e, 0xb2, 0x80, 0xca, 0x50, 0xc0, 0x9d, 0x3e, 0x66, 0x1e, 0x56, 0x86, 0xc2, 0xbd,
+	0x56, 0xbf, 0xb5, 0xad, 0x64, 0xd5, 0x37, 0x60, 0xf9, 0x81, 0x6e, 0xd1, 0x34, 0xce, 0xa5, 0x7a,
+	0xa0, 0x8c, 0xeb, 0x4a, 0xeb, 0x6c, 0x4d, 0x58, 0x27, 0xfd, 0xd0, 0x74, 0x8e, 0x2d, 0x7a, 0xca,
+	0x1e, 0x0a, 0xe4, 0x48, 0x10, 0x48, 0x13, 0xb0, 0x5f, 0xf5, 0x29, 0x2c, 0xf7, 0xa8, 0xe7, 0xa7,
+	0xf2, 0xfc, 0x77, 0x61, 0x91, 0xad, 0x51, 0xde, 0x88, 0x4a, 0xd7, 0xbf, 0xd2, 0x14, 0x6b, 0x58,
+	0x33, 0x5a, 0xc3, 0x9a, 0xeb, 0x72, 0x8d, 0xc3, 0x51, 0x4d, 0xf4, 0x12, 0x14, 0x43, 0x6b, 0xe0,
+	0xea, 0xb6, 0x9c, 0x2d, 0x24, 0xa5, 0x22, 0xe6, 0xe4, 0x51, 0xc3, 0xd2, 0xf1, 0xdb, 0x80, 0xd6,
+	0x49, 0x48, 0x03, 0xef, 0x24, 0x95, 0x3e, 0x97, 0xa0, 0xf0, 0xd8, 0x0b, 0x0c, 0x11, 0x88, 0x25,
+	0x2c, 0x08, 0x16, 0x54, 0x13, 0x20, 0x12, 0xfb, 0x1a, 0xa0, 0x2d, 0x97, 0xad, 0x29, 0xe9, 0x0c,
+	0xf1, 0xfb, 0x2c, 0x5c, 0x9c, 0xa8, 0x2f, 0x8d, 0x31, 0x7f, 0x1c, 0xb2, 0x89, 0x69, 0x14, 0x8a,
+	0x38, 0x44, 0xbb, 0x50, 0x14, 0x35, 0xe4, 0x48, 0xde, 0x98, 0x01, 0x48, 0x2c, 0x53, 0x12, 0x4e,
+	0xc2, 0x9c, 0xe9, 0xf4, 0xb9, 0x17, 0xeb, 0xf4, 0x4f, 0x41, 0x89, 0xfa, 0x11, 0x3e, 0xd7, 0x36,
+	0x1f, 0xc1, 0x45, 0xc3, 0xb3, 0x6d, 0x62, 0x30, 0x6f, 0xd0, 0x2c, 0x97, 0x92, 0xe0, 0x48, 0xb7,
+	0x9f, 0xef, 0x37, 0x68, 0x2c, 0xb5, 0x25, 0x85, 0xd4, 0x47, 0x70, 0x21, 0xd1, 0xb0, 0x34, 0xc4,
+	0x3d, 0x28, 0x84, 0x8c, 0x21, 0x2d, 0xf1, 0xf6, 0x8c, 0x96, 0x08, 0xb1, 0x10, 0x57, 0x2f, 0x0a,
+	0xf0, 0xce, 0x11, 0x71, 0xe3, 0x6e, 0xa9, 0xeb, 0x70, 0xa1, 0xc7, 0xdd, 0x34, 0x95, 0x1f, 0x8e,
+	0x5d, 0x3c, 0x3b, 0xe1, 0xe2, 0x97, 0x00, 0x25, 0x51, 0xa4, 0x23, 0x9e, 0xc0, 0x72, 0xe7, 0x98,
+	0x18, 0xa9, 0x90, 0xeb, 0xb0, 0x68, 0x78, 0x8e, 0xa3, 0xbb, 0x66, 0x3d, 0x7b, 0x35, 0xb7, 0x52,
+	0xc6, 0x11, 0x99, 0x8c, 0xc5, 0x5c, 0xda, 0x58, 0x54, 0x7f, 0x97, 0x01, 0x65, 0xdc, 0xb6, 0x1c,
+	0x48, 0xa6, 0x3d, 0x35, 0x19, 0x10, 0x6b, 0xbb, 0x8a, 0x25, 0x25, 0xf9, 0xd1, 0x74, 0x21, 0xf8,
+	0x24, 0x08, 0x12, 0xd3, 0x51, 0xee, 0x9c, 0xd3, 0x91, 0xba, 0x09, 0xdf, 0x8a, 0xd4, 0xe9, 0xd1,
+	0x80, 0xe8, 0x8e, 0xe5, 0x0e, 0xb6, 0x76, 0x77, 0x7d, 0x22, 0x14, 0x47, 0x08, 0xf2, 0xa6, 0x4e,
+	0x75, 0xa9, 0x18, 0xff, 0x67, 0x41, 0x6f, 0xd8, 0x5e, 0x18, 0x07, 0x3d, 0x27, 0xd4, 0xbf, 0xe7,
+	0xa0, 0x3e, 0x05, 0x15, 0x0d, 0xef, 0x23, 0x28, 0x84, 0x84, 0x8e, 0x7c, 0xe9, 0x2a, 0x9d, 0xd4,
+	0x0a, 0x9f, 0x8d, 0xd7, 0xec, 0x31, 0x30, 0x2c, 0x30, 0xd1, 0x00, 0x4a, 0x94, 0x9e, 0x68, 0xa1,
+	0xf5, 0x79, 0x94, 0x10, 0x6c, 0x9f, 0x17, 0xbf, 0x4f, 0x02, 0xc7, 0x72, 0x75, 0xbb, 0x67, 0x7d,
+	0x4e, 0xf0, 0x22, 0xa5, 0x27, 0xec, 0x07, 0x3d, 0x64, 0x0e, 0x6f, 0x5a, 0xae, 0x1c, 0xf6, 0xf6,
+	0xbc, 0xad, 0x24, 0x06, 0x18, 0x0b, 0xc4, 0xc6, 0x36, 0x14, 0x78, 0x9f, 0xe6, 0x71, 0x44, 0x05,
+	0x72, 0x94, 0x9e, 0x70, 0xa5, 0x4a, 0x98, 0xfd, 0x36, 0xde, 0x83, 0x6a, 0xb2, 0x07, 0xcc, 0x91,
+	0x86, 0xc4, 0x1a, 0x0c, 0x85, 0x83, 0x15, 0xb0, 0xa4, 0x98, 0x25, 0x9f, 0x5a, 0xa6, 0x4c, 0x59,
+	0x0b, 0x58, 0x10, 0xea, 0x9f, 0xb3, 0x70, 0xe5, 0x8c, 0x91, 0x91, 0xce, 0xfa, 0x68, 0xc2, 0x59,
+	0x5f, 0xd0, 0x28, 0x44, 0x1e, 0xff, 0x68, 0xc2, 0xe3, 0x5f, 0x20, 0x38, 0x0b, 0x9b, 0x97, 0xa0,
+	0x48, 0x8e, 0x2d, 0x4a, 0x4c, 0x39, 0x54, 0x92, 0x4a, 0x84, 0x53, 0xfe, 0xbc, 0xe1, 0xf4, 0x9f,
+	0x0c, 0xa0, 0xe9, 0x3d, 0x0c, 0x7a, 0x0d, 0xaa, 0x21, 0x71, 0x4d, 0x4d, 0xcc, 0x4a, 0x62, 0xc2,
+	0x2c, 0xe1, 0x0a, 0xe3, 0x89, 0xe9, 0x29, 0x64, 0x81, 0x46, 0x8e, 0x89, 0x21, 0x63, 0x8a, 0xff,
+	0xa3, 0x21, 0x54, 0x1f, 0x87, 0x9a, 0x15, 0x7a, 0xb6, 0x1e, 0x27, 0xfb, 0xb5, 0xd4, 0xc1, 0x33,
+	0xad, 0x47, 0xf3, 0x5e, 0x6f, 0x2b, 0x02, 0xc3, 0x95, 0xc7, 0x61, 0x4c, 0xa8, 0x4d, 0xa8, 0x24,
+	0xca, 0x50, 0x09, 0xf2, 0xdd, 0xdd, 0x6e, 0x47, 0x59, 0x40, 0x00, 0xc5, 0xf6, 0x26, 0xde, 0xdd,
+	0xed, 0x8b, 0x84, 0x6a, 0x6b, 0xa7, 0xb5, 0xd1, 0x51, 0xb2, 0xea, 0x5f, 0x8a, 0x00, 0xe3, 0xcc,
+	0x16, 0xd5, 0x20, 0x1b, 0xfb, 0x6b, 0xd6, 0x32, 0x59, 0x67, 0x5c, 0xdd, 0x21, 0x72, 0x32, 0xe6,
+	0xff, 0x68, 0x0d, 0x2e, 0x3b, 0xe1, 0xc0, 0xd7, 0x8d, 0x43, 0x4d, 0x26, 0xa4, 0x06, 0x17, 0xe6,
+	0xbd, 0xaa, 0xe2, 0x8b, 0xb2, 0x50, 0x6a, 0x2d, 0x70, 0xb7, 0x21, 0x47, 0xdc, 0xa3, 0x7a, 0x9e,
+	0x6f, 0xdc, 0x6e, 0xcf, 0x9c, 0x71, 0x37, 0x3b, 0xee, 0x91, 0xd8, 0xa8, 0x31, 0x18, 0xa4, 0x01,
+	0x98, 0xe4, 0xc8, 0x32, 0x88, 0xc6, 0x40, 0x0b, 0x1c, 0xf4, 0xee, 0xec, 0xa0, 0xeb, 0x1c, 0x23,
+	0x86, 0x2e, 0x9b, 0x11, 0x8d, 0xba, 0x50, 0x0e, 0x48, 0xe8, 0x8d, 0x02, 0x83, 0x84, 0xf5, 0xe2,
+	0x4c, 0x8b, 0x22, 0x8e, 0xe4, 0xf0, 0x18, 0x02, 0xad, 0x43, 0xd1, 0xf1, 0x46, 0x2e, 0x0d, 0xeb,
+	0x8b, 0x5c, 0xd9, 0xb7, 0x52, 0x82, 0xed, 0x30, 0x21, 0x2c, 0x65, 0xd1, 0x06, 0x2c, 0x0a, 0x15,
+	0xc3, 0x7a, 0x89, 0xc3, 0x5c, 0x4b, 0xeb, 0x40, 0x5c, 0x0a, 0x47, 0xd2, 0xcc, 0xaa, 0xa3, 0x90,
+	0x04, 0xf5, 0xb2, 0xb0, 0x2a, 0xfb, 0x47, 0xaf, 0x40, 0x59, 0xb7, 0x6d, 0xcf, 0xd0, 0x4c, 0x2b,
+	0xa8, 0x03, 0x2f, 0x28, 0x71, 0xc6, 0xba, 0x15, 0xa0, 0x57, 0xa1, 0x22, 0xe2, 0x5a, 0xf3, 0x75,
+	0x3a, 0xac, 0x57, 0x78, 0x31, 0x08, 0xd6, 0x9e, 0x4e, 0x87, 0xb2, 0x02, 0x09, 0x02, 0x51, 0xa1,
+	0x1a, 0x57, 0x20, 0x41, 0xc0, 0x2b, 0x7c, 0x0f, 0x96, 0xf9, 0x6c, 0x38, 0x08, 0xbc, 0x91, 0xaf,
+	0x71, 0x9f, 0x5a, 0xe2, 0x95, 0x96, 0x18, 0x7b, 0x83, 0x71, 0xbb, 0xcc, 0xb9, 0xae, 0x40, 0xe9,
+	0x89, 0x77, 0x20, 0x2a, 0xd4, 0x78, 0x85, 0xc5, 0x27, 0xde, 0x41, 0x54, 0x24, 0x34, 0xb4, 0xcc,
+	0xfa, 0xb2, 0x28, 0xe2, 0xf4, 0x96, 0xd9, 0xb8, 0x0e, 0xa5, 0xc8, 0x8c, 0x67, 0x6c, 0x8e, 0x2f,
+	0x25, 0x37, 0xc7, 0xe5, 0xc4, 0x4e, 0xb7, 0xf1, 0x1e, 0xd4, 0x26, 0x9d, 0x60, 0x16, 0x69, 0xf5,
+	0x9f, 0x19, 0x28, 0xc7, 0xe6, 0x46, 0x2e, 0x5c, 0xe4, 0xea, 0xe8, 0x94, 0x98, 0xda, 0xd8, 0x7b,
+	0xc4, 0xdc, 0xfa, 0x7e, 0x4a, 0x4b, 0xb5, 0x22, 0x04, 0x99, 0x56, 0x48, 0x57, 0x42, 0x31, 0xf2,
+	0xb8, 0xbd, 0x4f, 0x61, 0xd9, 0xb6, 0xdc, 0xd1, 0x71, 0xa2, 0x2d, 0x31, 0xd5, 0x7e, 0x3f, 0x65,
+	0x5b, 0xdb, 0x4c, 0x7a, 0xdc, 0x46, 0xcd, 0x9e, 0xa0, 0xd5, 0x2f, 0xb3, 0xf0, 0xd2, 0xd9, 0xea,
+	0xa0, 0x2e, 0xe4, 0x0c, 0x7f, 0x24, 0xbb, 0xf6, 0xde, 0xac, 0x5d, 0x6b, 0xfb, 0xa3, 0x71, 0xab,
+	0x0c, 0x88, 0xed, 0x99, 0x1d, 0xe2, 0x78, 0xc1, 0x89, 0xec, 0xc1, 0x9d, 0x59, 0x21, 0x77, 0xb8,
+	0xf4, 0x18, 0x55, 0xc2, 0x21, 0x0c, 0x25, 0x99, 0x79, 0x87, 0x72, 0x9a, 0x98, 0x31, 0x83, 0x8f,
+	0x20, 0x71, 0x8c, 0xa3, 0x5e, 0x87, 0xcb, 0x67, 0x76, 0x05, 0x7d, 0x1b, 0xc0, 0xf0, 0x47, 0x1a,
+	0x3f, 0x61, 0x11, 0x76, 0xcf, 0xe1, 0xb2, 0xe1, 0x8f, 0x7a, 0x9c, 0xa1, 0xde, 0x80, 0xfa, 0xb3,
+	0xf4, 0x65, 0xc1, 0x27, 0x34, 0xd6, 0x9c, 0x03, 0x3e, 0x06, 0x39, 0x5c, 0x12, 0x8c, 0x9d, 0x03,
+	0xf5, 0xab, 0x2c, 0x2c, 0x9f, 0x52, 0x87, 0xad, 0x80, 0x22, 0x98, 0xa3, 0xdc, 0x42, 0x50, 0x2c,
+	0xb2, 0x0d, 0xcb, 0x8c, 0x76, 0xa5, 0xfc, 0x9f, 0xcf, 0xe9, 0xbe, 0xdc, 0x31, 0x66, 0x2d, 0x9f,
+	0x39, 0xb4, 0x73, 0x60, 0xd1, 0x90, 0x2f, 0x92, 0x05, 0x2c, 0x08, 0xf4, 0x10, 0x6a, 0x01, 0x09,
+	0x49, 0x70, 0x44, 0x4c, 0xcd, 0xf7, 0x02, 0x1a, 0x0d, 0xd8, 0xda, 0x6c, 0x03, 0xb6, 0xe7, 0x05,
+	0x14, 0x2f, 0x45, 0x48, 0x8c, 0x0a, 0xd1, 0x03, 0x58, 0x32, 0x4f, 0x5c, 0xdd, 0xb1, 0x0c, 0x89,
+	0x5c, 0x9c, 0x1b, 0xb9, 0x2a, 0x81, 0x38, 0xb0, 0x7a, 0x0b, 0x2a, 0x89, 0x42, 0xd6, 0x31, 0x5b,
+	0x3f, 0x20, 0xb6, 0x1c, 0x13, 0x41, 0x4c, 0xc6, 0x6f, 0x41, 0xc6, 0xaf, 0xfa, 0x8f, 0x2c, 0xd4,
+	0x26, 0x03, 0x20, 0xb2, 0x9f, 0x4f, 0x02, 0xcb, 0x33, 0x13, 0xf6, 0xdb, 0xe3, 0x0c, 0x66, 0x23,
+	0x56, 0xfc, 0xd9, 0xc8, 0xa3, 0x7a, 0x64, 0x23, 0xc3, 0x1f, 0xfd, 0x90, 0xd1, 0xa7, 0x6c, 0x9f,
+	0x3b, 0x65, 0x7b, 0xf4, 0x16, 0x20, 0x69, 0x5f, 0xdb, 0x72, 0x2c, 0xaa, 0x1d, 0x9c, 0x50, 0x22,
+	0xc6, 0x3f, 0x87, 0x15, 0x51, 0xb2, 0xcd, 0x0a, 0x3e, 0x64, 0x7c, 0xa4, 0xc2, 0x92, 0xe7, 0x39,
+	0x5a, 0x68, 0x78, 0x01, 0xd1, 0x74, 0xf3, 0x49, 0xbd, 0xc0, 0x2b, 0x56, 0x3c, 0xcf, 0xe9, 0x31,
+	0x5e, 0xcb, 0x7c, 0xc2, 0x26, 0x5c, 0xc3, 0x1f, 0x85, 0x84, 0x6a, 0xec, 0xc3, 0xd7, 0xa8, 0x32,
+	0x06, 0xc1, 0x6a, 0xfb, 0xa3, 0x30, 0x51, 0xc1, 0x21, 0x0e, 0x5b, 0x77, 0x12, 0x15, 0x76, 0x88,
+	0xc3, 0x5a, 0xa9, 0xee, 0x91, 0xc0, 0x20, 0x2e, 0xed, 0x5b, 0xc6, 0x21, 0x5b, 0x52, 0x32, 0x2b,
+	0x19, 0x3c, 0xc1, 0x43, 0xdf, 0x85, 0xa5, 0xa8, 0x15, 0x3e, 0x71, 0xcb, 0x15, 0xa3, 0x2a, 0xdb,
+	0xe1, 0x3c, 0xf5, 0x13, 0x28, 0xf0, 0x75, 0x8a, 0x8d, 0x10, 0x9f, 0xe3, 0xf9, 0x12, 0x20, 0x6c,
+	0x50, 0x62, 0x0c, 0xbe, 0x00, 0xbc, 0x02, 0xe5, 0xa1, 0x17, 0xca, 0x05, 0x44, 0xb8, 0x67, 0x89,
+	0x31, 0x78, 0x61, 0x03, 0x4a, 0x01, 0xd1, 0x4d, 0xcf, 0xb5, 0xa3, 0xec, 0x37, 0xa6, 0xd5, 0xcf,
+	0xa0, 0x28, 0xe6, 0xe8, 0x73, 0xe0, 0x5f, 0x03, 0x24, 0x3a, 0xc0, 0xac, 0xeb, 0x58, 0x61, 0x68,
+	0x79, 0x6e, 0x18, 0x1d, 0xb9, 0x8a, 0x92, 0xbd, 0x71, 0x81, 0xfa, 0xaf, 0x8c, 0x48, 0x8a, 0xc4,
+	0x61, 0x18, 0x4b, 0xd8, 0x99, 0x3b, 0xb2, 0xc4, 0x4d, 0x64, 0xdd, 0x11, 0xc9, 0x12, 0x4e, 0x99,
+	0xfb, 0x64, 0xe7, 0x3d, 0x4b, 0x94, 0x00, 0xd1, 0x1e, 0x9c, 0xc8, 0xdc, 0x70, 0xd6, 0x3d, 0x38,
+	0x11, 0x7b, 0x70, 0xc2, 0x32, 0x54, 0x99, 0x95, 0x09, 0xb8, 0x3c, 0x4f, 0xca, 0x2a, 0x66, 0x7c,
+	0xd0, 0x41, 0xd4, 0xff, 0x66, 0xe2, 0x09, 0x25, 0x3a, 0x90, 0x40, 0x9f, 0x42, 0x89, 0xc5, 0xa6,
+	0xe6, 0xe8, 0xbe, 0x3c, 0x5e, 0x6f, 0xcf, 0x77, 0xd6, 0xd1, 0x64, 0xa1, 0xb8, 0xa3, 0xfb, 0x22,
+	0xa7, 0x5a, 0xf4, 0x05, 0xc5, 0x26, 0x26, 0xdd, 0x1c, 0x4f, 0x4c, 0xec, 0x1f, 0xbd, 0x0e, 0x35,
+	0x7d, 0x44, 0x3d, 0x4d, 0x37, 0x8f, 0x48, 0x40, 0xad, 0x90, 0x48, 0xdb, 0x2f, 0x31, 0x6e, 0x2b,
+	0x62, 0x36, 0x6e, 0x43, 0x35, 0x89, 0xf9, 0xbc, 0x25, 0xba, 0x90, 0x5c, 0xa2, 0x7f, 0x02, 0x30,
+	0x4e, 0xee, 0x99, 0x8f, 0xb0, 0x9d, 0x82, 0x66, 0x78, 0x26, 0x91, 0xa6, 0x2c, 0x31, 0x46, 0xdb,
+	0x33, 0xc9, 0xa9, 0x93, 0x87, 0x42, 0x74, 0xf2, 0xc0, 0x42, 0x9b, 0x45, 0xe3, 0xa1, 0x65, 0xdb,
+	0xf1, 0x86, 0xa3, 0xec, 0x79, 0xce, 0x7d, 0xce, 0x50, 0xbf, 0xce, 0x0a, 0x5f, 0x11, 0x67, 0x48,
+	0xa9, 0x12, 0xe8, 0x17, 0x65, 0xea, 0x5b, 0x00, 0x21, 0xd5, 0x03, 0x96, 0x6f, 0xe8, 0xd1, 0x96,
+	0xa7, 0x31, 0x75, 0x74, 0xd1, 0x8f, 0xae, 0xc2, 0x70, 0x59, 0xd6, 0x6e, 0x51, 0xf4, 0x3e, 0x54,
+	0x0d, 0xcf, 0xf1, 0x6d, 0x22, 0x85, 0x0b, 0xcf, 0x15, 0xae, 0xc4, 0xf5, 0x5b, 0x34, 0xb1, 0xd1,
+	0x2a, 0x9e, 0x77, 0xa3, 0xf5, 0xd7, 0x8c, 0x38, 0x0a, 0x4b, 0x9e, 0xc4, 0xa1, 0xc1, 0x19, 0xd7,
+	0x3d, 0x1b, 0x73, 0x1e, 0xeb, 0x7d, 0xd3, 0x5d, 0x4f, 0xe3, 0xfd, 0x34, 0x97, 0x2b, 0xcf, 0xce,
+	0x00, 0xff, 0x96, 0x83, 0x72, 0x7c, 0x0a, 0x36, 0x65, 0xfb, 0x9b, 0x50, 0x8e, 0xef, 0x21, 0xe5,
+	0x04, 0xf1, 0x8d, 0xe6, 0x89, 0x2b, 0xa3, 0xc7, 0x80, 0xf4, 0xc1, 0x20, 0xce, 0xec, 0xb4, 0x51,
+	0xa8, 0x0f, 0xa2, 0x33, 0xc8, 0x9b, 0x33, 0x8c, 0x43, 0xb4, 0xb8, 0xed, 0x33, 0x79, 0xac, 0xe8,
+	0x83, 0xc1, 0x04, 0x07, 0xfd, 0x14, 0x2e, 0x4f, 0xb6, 0xa1, 0x1d, 0x9c, 0x68, 0xbe, 0x65, 0xca,
+	0x8d, 0xda, 0xe6, 0xac, 0x07, 0x81, 0xcd, 0x09, 0xf8, 0x0f, 0x4f, 0xf6, 0x2c, 0x53, 0x8c, 0x39,
+	0x0a, 0xa6, 0x0a, 0x1a, 0x3f, 0x87, 0x97, 0x9f, 0x51, 0xfd, 0x0c, 0x1b, 0x74, 0x27, 0x2f, 0xb8,
+	0xe6, 0x1f, 0x84, 0x84, 0xf5, 0xfe, 0x98, 0x11, 0xe7, 0x95, 0x93, 0x63, 0xd2, 0x4a, 0x26, 0xb7,
+	0xab, 0x29, 0xdb, 0x69, 0xef, 0xed, 0x0b, 0x78, 0x9e, 0xcf, 0x7e, 0x74, 0x2a, 0x9f, 0x4d, 0x9b,
+	0xe9, 0x88, 0xb4, 0x50, 0x00, 0x49, 0x04, 0xf5, 0x4f, 0x39, 0x28, 0x45, 0xe8, 0x7c, 0x9b, 0x75,
+	0x12, 0x52, 0xe2, 0x68, 0x4e, 0x34, 0x85, 0x65, 0x30, 0x08, 0xd6, 0x0e, 0x9b, 0xc4, 0x5e, 0x81,
+	0x32, 0xdb, 0xcd, 0x89, 0xe2, 0x2c, 0x2f, 0x2e, 0x31, 0x06, 0x2f, 0x7c, 0x15, 0x2a, 0xd4, 0xa3,
+	0xba, 0xad, 0x51, 0xbe, 0xe0, 0xe7, 0x84, 0x34, 0x67, 0x89, 0xe5, 0xfe, 0x4d, 0xb8, 0x40, 0x87,
+	0x81, 0x47, 0xa9, 0xcd, 0x92, 0x40, 0x9e, 0xf6, 0x88, 0x2c, 0x25, 0x8f, 0x95, 0xb8, 0x40, 0xa4,
+	0x43, 0x21, 0x9b, 0xbd, 0xc7, 0x95, 0x99, 0xeb, 0xf2, 0x49, 0x24, 0x8f, 0x97, 0x62, 0x2e, 0x73,
+	0x6d, 0xb6, 0x78, 0xfa, 0x22, 0xa5, 0xe0, 0x73, 0x45, 0x06, 0x47, 0x24, 0xd2, 0x60, 0xd9, 0x21,
+	0x7a, 0x38, 0x0a, 0x88, 0xa9, 0x3d, 0xb6, 0x88, 0x6d, 0x8a, 0xdd, 0x71, 0x2d, 0x75, 0x8e, 0x1e,
+	0x0d, 0x4b, 0xf3, 0x1e, 0x97, 0xc6, 0xb5, 0x08, 0x4e, 0xd0, 0x2c, 0x73, 0x10, 0x7f, 0x68, 0x19,
+	0x2a, 0xbd, 0x87, 0xbd, 0x7e, 0x67, 0x47, 0xdb, 0xd9, 0x5d, 0xef, 0xc8, 0x3b, 0xcc, 0x5e, 0x07,
+	0x0b, 0x32, 0xc3, 0xca, 0xfb, 0xbb, 0xfd, 0xd6, 0xb6, 0xd6, 0xdf, 0x6a, 0xdf, 0xef, 0x29, 0x59,
+	0x74, 0x19, 0x2e, 0xf4, 0x37, 0xf1, 0x6e, 0xbf, 0xbf, 0xdd, 0x59, 0xd7, 0xf6, 0x3a, 0x78, 0x6b,
+	0x77, 0xbd, 0xa7, 0xe4, 0x10, 0x82, 0xda, 0x98, 0xdd, 0xdf, 0xda, 0xe9, 0x28, 0x79, 0x54, 0x81,
+	0xc5, 0xbd, 0x0e, 0x6e, 0x77, 0xba, 0x7d, 0xa5, 0xa0, 0x7e, 0x95, 0x83, 0x4a, 0xc2, 0x8a, 0xcc,
+	0x91, 0x83, 0x50, 0x6c, 0x06, 0xf2, 0x98, 0xfd, 0xf2, 0x33, 0x57, 0xdd, 0x18, 0x0a, 0xeb, 0xe4,
+	0xb1, 0x20, 0xf8, 0x06, 0x40, 0x3f, 0x4e, 0xc4, 0x79, 0x1e, 0x97, 0x1c, 0xfd, 0x58, 0x80, 0xbc,
+	0x06, 0xd5, 0x43, 0x12, 0xb8, 0xc4, 0x96, 0xe5, 0xc2, 0x22, 0x15, 0xc1, 0x13, 0x55, 0x56, 0x40,
+	0x91, 0x55, 0xc6, 0x30, 0xc2, 0x1c, 0x35, 0xc1, 0xdf, 0x89, 0xc0, 0x2e, 0x41, 0x41, 0x14, 0x2f,
+	0x8a, 0xf6, 0x39, 0xc1, 0x96, 0xa9, 0xf0, 0xa9, 0xee, 0xf3, 0x24, 0x30, 0x8f, 0xf9, 0x3f, 0x3a,
+	0x98, 0xb6, 0x4f, 0x91, 0xdb, 0xe7, 0xd6, 0xec, 0xee, 0xfc, 0x2c, 0x13, 0x0d, 0x63, 0x13, 0x2d,
+	0x42, 0x0e, 0x47, 0x17, 0x7f, 0xed, 0x56, 0x7b, 0x93, 0x99, 0x65, 0x09, 0xca, 0x3b, 0xad, 0x8f,
+	0xb5, 0xfd, 0x1e, 0x3f, 0xab, 0x42, 0x0a, 0x54, 0xef, 0x77, 0x70, 0xb7, 0xb3, 0x2d, 0x39, 0x39,
+	0x74, 0x09, 0x14, 0xc9, 0x19, 0xd7, 0xcb, 0x33, 0x04, 0xf1, 0x5b, 0x40, 0x25, 0xc8, 0xf7, 0x1e,
+	0xb4, 0xf6, 0x94, 0xa2, 0xfa, 0xef, 0x2c, 0x2c, 0x8b, 0x65, 0x21, 0xbe, 0xa2, 0x78, 0xf6, 0x11,
+	0x6d, 0xf2, 0xa8, 0x21, 0x3b, 0x71, 0xd4, 0x10, 0x27, 0xa1, 0x7c, 0x55, 0xcf, 0x8d, 0x93, 0x50,
+	0x7e, 0x44, 0x31, 0x31, 0xe3, 0xe7, 0x67, 0x99, 0xf1, 0xeb, 0xb0, 0xe8, 0x90, 0x30, 0xb6, 0x5b,
+	0x19, 0x47, 0x24, 0xb2, 0xa0, 0xa2, 0xbb, 0xae, 0x47, 0xf9, 0x81, 0x5e, 0xb4, 0x77, 0xda, 0x98,
+	0xe9, 0xe8, 0x30, 0xee, 0x71, 0xb3, 0x35, 0x46, 0x12, 0x13, 0x73, 0x12, 0xbb, 0xf1, 0x01, 0x28,
+	0xa7, 0x2b, 0xcc, 0xb2, 0x1c, 0xbe, 0xf1, 0xce, 0x78, 0x35, 0x24, 0x2c, 0x2e, 0xf6, 0xbb, 0xf7,
+	0xbb, 0xbb, 0x0f, 0xba, 0xca, 0x02, 0x23, 0xf0, 0x7e, 0xb7, 0xbb, 0xd5, 0xdd, 0x50, 0x32, 0x08,
+	0xa0, 0xd8, 0xf9, 0x78, 0xab, 0xdf, 0x59, 0x57, 0xb2, 0x6b, 0xbf, 0x5c, 0x86, 0xa2, 0x50, 0x12,
+	0x7d, 0x29, 0x33, 0x81, 0xe4, 0xf3, 0x17, 0xf4, 0xc1, 0xcc, 0x19, 0xf5, 0xc4, 0x93, 0x9a, 0xc6,
+	0x9d, 0xb9, 0xe5, 0xe5, 0x15, 0xd3, 0x02, 0xfa, 0x6d, 0x06, 0xaa, 0x13, 0x87, 0xc0, 0x69, 0xcf,
+	0x2f, 0xcf, 0x78, 0x6d, 0xd3, 0xf8, 0xc1, 0x5c, 0xb2, 0xb1, 0x2e, 0xbf, 0xc9, 0x40, 0x25, 0xf1,
+	0xce, 0x04, 0xdd, 0x9a, 0xe7, 0x6d, 0x8a, 0xd0, 0xe4, 0xf6, 0xfc, 0xcf, 0x5a, 0xd4, 0x85, 0xb7,
+	0x33, 0xe8, 0xd7, 0x19, 0xa8, 0x24, 0x5e, 0x5c, 0xa4, 0x56, 0x65, 0xfa, 0x7d, 0x48, 0x6a, 0x55,
+	0xce, 0x7a, 0xe0, 0xb1, 0x80, 0x7e, 0x91, 0x81, 0x72, 0xfc, 0x7a, 0x02, 0xdd, 0x98, 0xfd, 0xbd,
+	0x85, 0x50, 0xe2, 0xe6, 0xbc, 0x0f, 0x35, 0xd4, 0x05, 0xf4, 0x33, 0x28, 0x45, 0x4f, 0x0d, 0x50,
+	0xda, 0xd5, 0xeb, 0xd4, 0x3b, 0x86, 0xc6, 0x8d, 0x99, 0xe5, 0x92, 0xcd, 0x47, 0xf7, 0xff, 0xa9,
+	0x9b, 0x3f, 0xf5, 0x52, 0xa1, 0x71, 0x63, 0x66, 0xb9, 0xb8, 0x79, 0xe6, 0x09, 0x89, 0x67, 0x02,
+	0xa9, 0x3d, 0x61, 0xfa, 0x7d, 0x42, 0x6a, 0x4f, 0x38, 0xeb, 0x55, 0x82, 0x50, 0x24, 0xf1, 0xd0,
+	0x20, 0xb5, 0x22, 0xd3, 0x8f, 0x19, 0x52, 0x2b, 0x72, 0xc6, 0xbb, 0x06, 0x75, 0x01, 0x7d, 0x91,
+	0x49, 0xee, 0x0b, 0x6e, 0xcc, 0x7c, 0x9f, 0x3e, 0xa3, 0x4b, 0x4e, 0xdd, 0xe8, 0xf3, 0x00, 0xfd,
+	0x42, 0x9e, 0x62, 0x88, 0xeb, 0x78, 0x34, 0x0b, 0xd8, 0xc4, 0x0d, 0x7e, 0xe3, 0xfa, 0x7c, 0x8b,
+	0x0d, 0x57, 0xe2, 0x57, 0x19, 0x80, 0xf1, 0xc5, 0x7d, 0x6a, 0x25, 0xa6, 0x5e, 0x0c, 0x34, 0x6e,
+	0xcd, 0x21, 0x99, 0x0c, 0x90, 0xe8, 0x62, 0x31, 0x75, 0x80, 0x9c, 0x7a, 0x58, 0x90, 0x3a, 0x40,
+	0x4e, 0x3f, 0x0a, 0x50, 0x17, 0xd0, 0x1f, 0x32, 0x70, 0x61, 0xea, 0x62, 0x13, 0xdd, 0x39, 0xe7,
+	0xdd, 0x76, 0xe3, 0xee, 0xfc, 0x00, 0x91, 0x6a, 0x2b, 0x99, 0xb7, 0x33, 0x1f, 0x2e, 0xfe, 0xa8,
+	0x20, 0x92, 0x93, 0x22, 0xff, 0xbc, 0xfb, 0xff, 0x00, 0x00, 0x00, 0xff, 0xff, 0x22, 0xa6, 0x73,
+	0x5a, 0xba, 0x2b, 0x00, 0x00,
 }
@@ -140,6 +140,7 @@ func ResourcesFromProto(pb *proto.Resources) *Resources {
 			CpusetCPUs:       pb.LinuxResources.CpusetCpus,
 			CpusetMems:       pb.LinuxResources.CpusetMems,
 			PercentTicks:     pb.LinuxResources.PercentTicks,
+			CpusetCgroupPath: pb.LinuxResources.CpusetCgroup,
 		}
 	}
 
@@ -196,6 +197,7 @@ func ResourcesToProto(r *Resources) *proto.Resources {
 			CpusetCpus:       r.LinuxResources.CpusetCPUs,
 			CpusetMems:       r.LinuxResources.CpusetMems,
 			PercentTicks:     r.LinuxResources.PercentTicks,
+			CpusetCgroup:     r.LinuxResources.CpusetCgroupPath,
 		}
 	}
 
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"math"
 
+	"github.com/hashicorp/nomad/lib/cpuset"
 	"github.com/hashicorp/nomad/nomad/structs"
 )
 
@@ -197,6 +198,9 @@ OUTER:
 		devAllocator := newDeviceAllocator(iter.ctx, option.Node)
 		devAllocator.AddAllocs(proposed)
 
+		// Index the cores that are free to be reserved
+		availableCores := reservableCores(option.Node, proposed)
+
 		// Track the affinities of the devices
 		totalDeviceAffinityWeight := 0.0
 		sumMatchingAffinities := 0.0
@@ -235,6 +239,22 @@ OUTER:
 				},
 			}
 
+			// Check if we need to reserve cores
+			if task.Resources.Cores > 0 {
+				if availableCores.Size() < task.Resources.Cores {
+					iter.ctx.Metrics().ExhaustedNode(option.Node, "cores")
+					netIdx.Release()
+					continue OUTER
+				}
+
+				// Reserve the lowest numbered free cores and account for
+				// their full capacity in the CPU shares of the task
+				cores := availableCores.ToSlice()[:task.Resources.Cores]
+				availableCores = availableCores.Difference(cpuset.New(cores...))
+				taskResources.Cpu.ReservedCores = cores
+				taskResources.Cpu.CpuShares = int64(len(cores)) * option.Node.NodeResources.Cpu.SharesPerCore()
+			}
+
 			// Check if we need a network resource
 			if len(task.Resources.Networks) > 0 {
 				ask := task.Resources.Networks[0].Copy()
@@ -396,6 +416,28 @@ func (iter *BinPackIterator) Reset() {
 	iter.source.Reset()
 }
 
+// reservableCores returns the set of cores on the node which have not been
+// reserved by any of the given allocations.
+func reservableCores(node *structs.Node, allocs []*structs.Allocation) cpuset.CPUSet {
+	if node.NodeResources == nil {
+		return cpuset.New()
+	}
+
+	reserved := cpuset.New()
+	for _, alloc := range allocs {
+		if alloc.TerminalStatus() {
+			continue
+		}
+		cr := alloc.ComparableResources()
+		if cr == nil {
+			continue
+		}
+		reserved = reserved.Union(cpuset.New(cr.Flattened.Cpu.ReservedCores...))
+	}
+
+	return cpuset.New(node.NodeResources.Cpu.ReservableCpuCores...).Difference(reserved)
+}
+
 // JobAntiAffinityIterator is used to apply an anti-affinity to allocating
 // along side other allocations from this job. This is used to help distribute
 // load across the cluster.
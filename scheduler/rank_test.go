@@ -344,6 +344,105 @@ func TestBinPackIterator_ExistingAlloc(t *testing.T) {
 	}
 }
 
+func TestBinPackIterator_ReservedCores(t *testing.T) {
+	require := require.New(t)
+	state, ctx := testContext(t)
+	nodes := []*RankedNode{
+		{
+			Node: &structs.Node{
+				// Two of four cores already reserved
+				ID: uuid.Generate(),
+				NodeResources: &structs.NodeResources{
+					Cpu: structs.NodeCpuResources{
+						CpuShares:          4096,
+						ReservableCpuCores: []uint16{0, 1, 2, 3},
+					},
+					Memory: structs.NodeMemoryResources{
+						MemoryMB: 4096,
+					},
+				},
+			},
+		},
+		{
+			Node: &structs.Node{
+				// No reservable cores fingerprinted
+				ID: uuid.Generate(),
+				NodeResources: &structs.NodeResources{
+					Cpu: structs.NodeCpuResources{
+						CpuShares: 4096,
+					},
+					Memory: structs.NodeMemoryResources{
+						MemoryMB: 4096,
+					},
+				},
+			},
+		},
+	}
+	static := NewStaticRankIterator(ctx, nodes)
+
+	// Add an existing allocation reserving cores 0 and 1
+	j1 := mock.Job()
+	alloc1 := &structs.Allocation{
+		Namespace: structs.DefaultNamespace,
+		ID:        uuid.Generate(),
+		EvalID:    uuid.Generate(),
+		NodeID:    nodes[0].Node.ID,
+		JobID:     j1.ID,
+		Job:       j1,
+		AllocatedResources: &structs.AllocatedResources{
+			Tasks: map[string]*structs.AllocatedTaskResources{
+				"web": {
+					Cpu: structs.AllocatedCpuResources{
+						CpuShares:     2048,
+						ReservedCores: []uint16{0, 1},
+					},
+					Memory: structs.AllocatedMemoryResources{
+						MemoryMB: 1024,
+					},
+				},
+			},
+		},
+		DesiredStatus: structs.AllocDesiredStatusRun,
+		ClientStatus:  structs.AllocClientStatusPending,
+		TaskGroup:     "web",
+	}
+	require.NoError(state.UpsertJobSummary(998, mock.JobSummary(alloc1.JobID)))
+	require.NoError(state.UpsertAllocs(1000, []*structs.Allocation{alloc1}))
+
+	taskGroup := &structs.TaskGroup{
+		EphemeralDisk: &structs.EphemeralDisk{},
+		Tasks: []*structs.Task{
+			{
+				Name: "web",
+				Resources: &structs.Resources{
+					Cores:    2,
+					MemoryMB: 1024,
+				},
+			},
+		},
+	}
+	binp := NewBinPackIterator(ctx, static, false, 0)
+	binp.SetTaskGroup(taskGroup)
+
+	scoreNorm := NewScoreNormalizationIterator(ctx, binp)
+
+	out := collectRanked(scoreNorm)
+	require.Len(out, 1)
+	require.Equal(nodes[0], out[0])
+
+	// The remaining cores should be reserved along with their capacity
+	cpu := out[0].TaskResources["web"].Cpu
+	require.Equal([]uint16{2, 3}, cpu.ReservedCores)
+	require.EqualValues(2048, cpu.CpuShares)
+
+	// Asking for more cores than are free should exhaust the node
+	taskGroup.Tasks[0].Resources.Cores = 3
+	static.Reset()
+	binp.SetTaskGroup(taskGroup)
+	out = collectRanked(NewScoreNormalizationIterator(ctx, binp))
+	require.Empty(out)
+}
+
 func TestBinPackIterator_ExistingAlloc_PlannedEvict(t *testing.T) {
 	state, ctx := testContext(t)
 	nodes := []*RankedNode{
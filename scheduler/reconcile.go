@@ -112,6 +112,14 @@ type reconcileResults struct {
 	// jobspec change.
 	attributeUpdates map[string]*structs.Allocation
 
+	// disconnectUpdates is the set of allocations on disconnected clients
+	// that are being marked unknown.
+	disconnectUpdates map[string]*structs.Allocation
+
+	// reconnectUpdates is the set of allocations that were unknown and are
+	// being kept after their client reconnected.
+	reconnectUpdates map[string]*structs.Allocation
+
 	// desiredTGUpdates captures the desired set of changes to make for each
 	// task group.
 	desiredTGUpdates map[string]*structs.DesiredUpdates
@@ -171,6 +179,8 @@ func NewAllocReconciler(logger log.Logger, allocUpdateFn allocUpdateType, batch
 		evalID:         evalID,
 		now:            time.Now(),
 		result: &reconcileResults{
+			disconnectUpdates:    make(map[string]*structs.Allocation),
+			reconnectUpdates:     make(map[string]*structs.Allocation),
 			desiredTGUpdates:     make(map[string]*structs.DesiredUpdates),
 			desiredFollowupEvals: make(map[string][]*structs.Evaluation),
 		},
@@ -218,7 +228,11 @@ func (a *allocReconciler) Compute() *reconcileResults {
 	// Set the description of a created deployment
 	if d := a.result.deployment; d != nil {
 		if d.RequiresPromotion() {
-			d.StatusDescription = structs.DeploymentStatusDescriptionRunningNeedsPromotion
+			if d.HasAutoPromote() {
+				d.StatusDescription = structs.DeploymentStatusDescriptionRunningAutoPromotion
+			} else {
+				d.StatusDescription = structs.DeploymentStatusDescriptionRunningNeedsPromotion
+			}
 		}
 	}
 
@@ -273,10 +287,12 @@ func (a *allocReconciler) cancelDeployments() {
 func (a *allocReconciler) handleStop(m allocMatrix) {
 	for group, as := range m {
 		as = filterByTerminal(as)
-		untainted, migrate, lost := as.filterByTainted(a.taintedNodes)
+		untainted, migrate, lost, disconnecting, reconnecting := as.filterByTainted(a.taintedNodes)
 		a.markStop(untainted, "", allocNotNeeded)
 		a.markStop(migrate, "", allocNotNeeded)
 		a.markStop(lost, structs.AllocClientStatusLost, allocLost)
+		a.markStop(disconnecting, structs.AllocClientStatusLost, allocLost)
+		a.markStop(reconnecting, "", allocNotNeeded)
 		desiredChanges := new(structs.DesiredUpdates)
 		desiredChanges.Stop = uint64(len(as))
 		a.result.desiredTGUpdates[group] = desiredChanges
@@ -309,11 +325,13 @@ func (a *allocReconciler) computeGroup(group string, all allocSet) bool {
 	// If the task group is nil, then the task group has been removed so all we
 	// need to do is stop everything
 	if tg == nil {
-		untainted, migrate, lost := all.filterByTainted(a.taintedNodes)
+		untainted, migrate, lost, disconnecting, reconnecting := all.filterByTainted(a.taintedNodes)
 		a.markStop(untainted, "", allocNotNeeded)
 		a.markStop(migrate, "", allocNotNeeded)
 		a.markStop(lost, structs.AllocClientStatusLost, allocLost)
-		desiredChanges.Stop = uint64(len(untainted) + len(migrate) + len(lost))
+		a.markStop(disconnecting, structs.AllocClientStatusLost, allocLost)
+		a.markStop(reconnecting, "", allocNotNeeded)
+		desiredChanges.Stop = uint64(len(all))
 		return true
 	}
 
@@ -327,6 +345,9 @@ func (a *allocReconciler) computeGroup(group string, all allocSet) bool {
 		dstate = &structs.DeploymentState{}
 		if tg.Update != nil {
 			dstate.AutoRevert = tg.Update.AutoRevert
+			dstate.AutoPromote = tg.Update.AutoPromote
+			dstate.PromoteAfter = tg.Update.PromoteAfter
+			dstate.Steps = tg.Update.Copy().Steps
 			dstate.ProgressDeadline = tg.Update.ProgressDeadline
 		}
 	}
@@ -341,7 +362,14 @@ func (a *allocReconciler) computeGroup(group string, all allocSet) bool {
 	canaries, all := a.handleGroupCanaries(all, desiredChanges)
 
 	// Determine what set of allocations are on tainted nodes
-	untainted, migrate, lost := all.filterByTainted(a.taintedNodes)
+	untainted, migrate, lost, disconnecting, reconnecting := all.filterByTainted(a.taintedNodes)
+
+	// Choose which copy of the allocations on reconnected clients to keep
+	if len(reconnecting) != 0 {
+		keep, stopReconnecting := a.computeReconnecting(untainted, reconnecting)
+		desiredChanges.Stop += uint64(len(stopReconnecting))
+		untainted = untainted.difference(stopReconnecting).union(keep)
+	}
 
 	// Determine what set of terminal allocations need to be rescheduled
 	untainted, rescheduleNow, rescheduleLater := untainted.filterByRescheduleable(a.batch, a.now, a.evalID, a.deployment)
@@ -351,8 +379,13 @@ func (a *allocReconciler) computeGroup(group string, all allocSet) bool {
 	a.handleDelayedReschedules(rescheduleLater, all, tg.Name)
 
 	// Create a structure for choosing names. Seed with the taken names which is
-	// the union of untainted and migrating nodes (includes canaries)
-	nameIndex := newAllocNameIndex(a.jobID, group, tg.Count, untainted.union(migrate, rescheduleNow))
+	// the union of untainted, migrating and disconnected allocs (includes
+	// canaries)
+	nameIndex := newAllocNameIndex(a.jobID, group, tg.Count, untainted.union(migrate, rescheduleNow, disconnecting))
+
+	// Mark the allocations on disconnected clients as unknown and replace them
+	disconnectPlace := a.computeDisconnecting(tg, disconnecting)
+	desiredChanges.Ignore += uint64(len(disconnecting) - len(disconnectPlace))
 
 	// Stop any unneeded allocations and update the untainted set to not
 	// included stopped allocations.
@@ -381,12 +414,12 @@ func (a *allocReconciler) computeGroup(group string, all allocSet) bool {
 	numDestructive := len(destructive)
 	strategy := tg.Update
 	canariesPromoted := dstate != nil && dstate.Promoted
-	requireCanary := numDestructive != 0 && strategy != nil && len(canaries) < strategy.Canary && !canariesPromoted
+	requireCanary := numDestructive != 0 && strategy != nil && len(canaries) < strategy.Canaries() && !canariesPromoted
 	if requireCanary && !a.deploymentPaused && !a.deploymentFailed {
-		number := strategy.Canary - len(canaries)
+		number := strategy.Canaries() - len(canaries)
 		desiredChanges.Canary += uint64(number)
 		if !existingDeployment {
-			dstate.DesiredCanaries = strategy.Canary
+			dstate.DesiredCanaries = strategy.Canaries()
 		}
 
 		for _, name := range nameIndex.NextCanaries(uint(number), canaries, destructive) {
@@ -406,11 +439,18 @@ func (a *allocReconciler) computeGroup(group string, all allocSet) bool {
 	// * The deployment is not paused or failed
 	// * Not placing any canaries
 	// * If there are any canaries that they have been promoted
-	place := a.computePlacements(tg, nameIndex, untainted, migrate, rescheduleNow)
+	// Allocations on disconnected clients are already being replaced so they
+	// count towards the existing allocations.
+	place := a.computePlacements(tg, nameIndex, untainted.union(disconnecting), migrate, rescheduleNow)
 	if !existingDeployment {
 		dstate.DesiredTotal += len(place)
 	}
 
+	// Replacements for allocations on disconnected clients are always placed
+	// as the originals may not be running anymore.
+	desiredChanges.Place += uint64(len(disconnectPlace))
+	a.result.place = append(a.result.place, disconnectPlace...)
+
 	// deploymentPlaceReady tracks whether the deployment is in a state where
 	// placements can be made without any other consideration.
 	deploymentPlaceReady := !a.deploymentPaused && !a.deploymentFailed && !canaryState
@@ -450,6 +490,9 @@ func (a *allocReconciler) computeGroup(group string, all allocSet) bool {
 	}
 
 	if deploymentPlaceReady {
+		// Only update as many allocations as the current rollout step allows
+		limit = a.computeStepLimit(dstate, untainted, limit)
+
 		// Do all destructive updates
 		min := helper.IntMin(len(destructive), limit)
 		desiredChanges.DestructiveUpdate += uint64(min)
@@ -507,7 +550,7 @@ func (a *allocReconciler) computeGroup(group string, all allocSet) bool {
 
 	// deploymentComplete is whether the deployment is complete which largely
 	// means that no placements were made or desired to be made
-	deploymentComplete := len(destructive)+len(inplace)+len(place)+len(migrate)+len(rescheduleNow)+len(rescheduleLater) == 0 && !requireCanary
+	deploymentComplete := len(destructive)+len(inplace)+len(place)+len(migrate)+len(rescheduleNow)+len(rescheduleLater)+len(disconnectPlace) == 0 && !requireCanary
 
 	// Final check to see if the deployment is complete is to ensure everything
 	// is healthy
@@ -520,9 +563,99 @@ func (a *allocReconciler) computeGroup(group string, all allocSet) bool {
 		}
 	}
 
+	// Allocations on reconnected clients that are being stopped or updated
+	// do not need to be kept
+	a.trimReconnectUpdates()
+
 	return deploymentComplete
 }
 
+// computeDisconnecting marks the allocations on disconnected clients as
+// unknown and returns the placements replacing them. Allocations that are
+// already unknown have been replaced before and are ignored.
+func (a *allocReconciler) computeDisconnecting(group *structs.TaskGroup, disconnecting allocSet) []allocPlaceResult {
+	var place []allocPlaceResult
+	for _, alloc := range disconnecting.nameOrder() {
+		if alloc.ClientStatus == structs.AllocClientStatusUnknown {
+			continue
+		}
+
+		updated := alloc.Copy()
+		updated.ClientStatus = structs.AllocClientStatusUnknown
+		updated.AppendState(structs.AllocStateFieldClientStatus, structs.AllocClientStatusUnknown)
+		a.result.disconnectUpdates[updated.ID] = updated
+
+		place = append(place, allocPlaceResult{
+			name:          alloc.Name,
+			taskGroup:     group,
+			previousAlloc: alloc,
+		})
+	}
+	return place
+}
+
+// computeReconnecting chooses which copy to keep of each allocation whose
+// client reconnected: the original allocation or its replacements. The
+// original is kept if it is still running and healthy or if it was never
+// replaced. It returns the allocations to keep and those that were stopped.
+func (a *allocReconciler) computeReconnecting(untainted, reconnecting allocSet) (keep, stop allocSet) {
+	keep = make(map[string]*structs.Allocation)
+	stop = make(map[string]*structs.Allocation)
+
+	for _, alloc := range reconnecting {
+		// Wait for the client to report the status of the allocation
+		if alloc.ClientStatus == structs.AllocClientStatusUnknown {
+			continue
+		}
+
+		replacements := make(map[string]*structs.Allocation)
+		for id, replacement := range untainted {
+			if replacement.PreviousAllocation == alloc.ID && !replacement.TerminalStatus() {
+				replacements[id] = replacement
+			}
+		}
+
+		healthy := alloc.ClientStatus == structs.AllocClientStatusRunning &&
+			!alloc.DeploymentStatus.IsUnhealthy()
+		if !healthy && len(replacements) != 0 {
+			stop[alloc.ID] = alloc
+			a.markStop(allocSet{alloc.ID: alloc}, "", allocReconnected)
+			continue
+		}
+
+		updated := alloc.Copy()
+		updated.AppendState(structs.AllocStateFieldClientStatus, alloc.ClientStatus)
+		keep[updated.ID] = updated
+		a.result.reconnectUpdates[updated.ID] = updated
+
+		for id, replacement := range replacements {
+			stop[id] = replacement
+		}
+		a.markStop(replacements, "", allocReconnected)
+	}
+
+	return keep, stop
+}
+
+// trimReconnectUpdates removes the allocations that are being stopped or
+// updated from the set of reconnect updates as those changes already persist
+// the allocation.
+func (a *allocReconciler) trimReconnectUpdates() {
+	if len(a.result.reconnectUpdates) == 0 {
+		return
+	}
+
+	for _, s := range a.result.stop {
+		delete(a.result.reconnectUpdates, s.alloc.ID)
+	}
+	for _, alloc := range a.result.inplaceUpdate {
+		delete(a.result.reconnectUpdates, alloc.ID)
+	}
+	for _, d := range a.result.destructiveUpdate {
+		delete(a.result.reconnectUpdates, d.stopAlloc.ID)
+	}
+}
+
 // filterOldTerminalAllocs filters allocations that should be ignored since they
 // are allocations that are terminal from a previous job version.
 func (a *allocReconciler) filterOldTerminalAllocs(all allocSet) (filtered, ignore allocSet) {
@@ -586,7 +719,7 @@ func (a *allocReconciler) handleGroupCanaries(all allocSet, desiredChanges *stru
 		}
 
 		canaries = all.fromKeys(canaryIDs)
-		untainted, migrate, lost := canaries.filterByTainted(a.taintedNodes)
+		untainted, migrate, lost, _, _ := canaries.filterByTainted(a.taintedNodes)
 		a.markStop(migrate, "", allocMigrating)
 		a.markStop(lost, structs.AllocClientStatusLost, allocLost)
 
@@ -642,6 +775,28 @@ func (a *allocReconciler) computeLimit(group *structs.TaskGroup, untainted, dest
 	return limit
 }
 
+// computeStepLimit returns the number of destructive updates allowed by the
+// rollout step in progress, capped by the passed limit. The step's target is
+// reduced by the allocations that are already running the new version.
+func (a *allocReconciler) computeStepLimit(dstate *structs.DeploymentState, untainted allocSet, limit int) int {
+	step := dstate.Step()
+	if step == nil || step.Percent == 0 {
+		return limit
+	}
+
+	// Allocations of the old version that are ignored or updated in-place
+	// are neither destructive updates nor running the new version, so the
+	// allocations are counted by their job version.
+	updated := 0
+	for _, alloc := range untainted {
+		if alloc.Job != nil && alloc.Job.Version == a.job.Version && alloc.Job.CreateIndex == a.job.CreateIndex {
+			updated++
+		}
+	}
+	allowed := helper.IntMax(step.Target(dstate.DesiredTotal)-updated, 0)
+	return helper.IntMin(allowed, limit)
+}
+
 // computePlacement returns the set of allocations to place given the group
 // definition, the set of untainted, migrating and reschedule allocations for the group.
 func (a *allocReconciler) computePlacements(group *structs.TaskGroup,
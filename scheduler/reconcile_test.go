@@ -2564,6 +2564,194 @@ func TestReconciler_CreateDeployment_RollingUpgrade_Destructive(t *testing.T) {
 	assertNamesHaveIndexes(t, intRange(0, 3), destructiveResultsToNames(r.destructiveUpdate))
 }
 
+// Tests the reconciler only updates as many allocations as the first rollout
+// step allows when creating a deployment
+func TestReconciler_CreateDeployment_RollingUpgrade_Steps(t *testing.T) {
+	job := mock.Job()
+	job.TaskGroups[0].Update = noCanaryUpdate.Copy()
+	job.TaskGroups[0].Update.Steps = []*structs.UpdateStep{
+		{Percent: 20},
+		{Percent: 100},
+	}
+	jobOld := job.Copy()
+	job.Version++
+
+	// Create 10 allocations from the old job
+	var allocs []*structs.Allocation
+	for i := 0; i < 10; i++ {
+		alloc := mock.Alloc()
+		alloc.Job = jobOld
+		alloc.JobID = job.ID
+		alloc.NodeID = uuid.Generate()
+		alloc.Name = structs.AllocName(job.ID, job.TaskGroups[0].Name, uint(i))
+		alloc.TaskGroup = job.TaskGroups[0].Name
+		allocs = append(allocs, alloc)
+	}
+
+	reconciler := NewAllocReconciler(testlog.HCLogger(t), allocUpdateFnDestructive, false, job.ID, job, nil, allocs, nil, "")
+	r := reconciler.Compute()
+
+	d := structs.NewDeployment(job)
+	d.TaskGroups[job.TaskGroups[0].Name] = &structs.DeploymentState{
+		Steps:        job.TaskGroups[0].Update.Steps,
+		DesiredTotal: 10,
+	}
+
+	// Assert the correct results
+	assertResults(t, r, &resultExpectation{
+		createDeployment:  d,
+		deploymentUpdates: nil,
+		destructive:       2,
+		desiredTGUpdates: map[string]*structs.DesiredUpdates{
+			job.TaskGroups[0].Name: {
+				DestructiveUpdate: 2,
+				Ignore:            8,
+			},
+		},
+	})
+
+	assertNamesHaveIndexes(t, intRange(0, 1), destructiveResultsToNames(r.destructiveUpdate))
+}
+
+// Tests the reconciler updates the allocations of the rollout step in progress
+// of an existing deployment
+func TestReconciler_RollingUpgrade_Steps(t *testing.T) {
+	job := mock.Job()
+	job.TaskGroups[0].Update = noCanaryUpdate.Copy()
+	job.TaskGroups[0].Update.Steps = []*structs.UpdateStep{
+		{Percent: 20},
+		{Percent: 50},
+		{Percent: 100},
+	}
+	jobOld := job.Copy()
+	job.Version++
+
+	cases := []struct {
+		step        int
+		destructive int
+	}{
+		{step: 0, destructive: 0},
+		{step: 1, destructive: 3},
+		{step: 2, destructive: 4},
+		{step: 3, destructive: 4},
+	}
+
+	for _, c := range cases {
+		t.Run(fmt.Sprintf("step %d", c.step), func(t *testing.T) {
+			d := structs.NewDeployment(job)
+			d.TaskGroups[job.TaskGroups[0].Name] = &structs.DeploymentState{
+				Steps:        job.TaskGroups[0].Update.Steps,
+				CurrentStep:  c.step,
+				DesiredTotal: 10,
+				PlacedAllocs: 2,
+			}
+
+			// Create 8 allocations from the old job and 2 healthy ones from the
+			// new job
+			var allocs []*structs.Allocation
+			handled := make(map[string]allocUpdateType)
+			for i := 0; i < 10; i++ {
+				alloc := mock.Alloc()
+				alloc.Job = jobOld
+				alloc.JobID = job.ID
+				alloc.NodeID = uuid.Generate()
+				alloc.Name = structs.AllocName(job.ID, job.TaskGroups[0].Name, uint(i))
+				alloc.TaskGroup = job.TaskGroups[0].Name
+				if i < 2 {
+					alloc.Job = job
+					alloc.DeploymentID = d.ID
+					alloc.DeploymentStatus = &structs.AllocDeploymentStatus{
+						Healthy: helper.BoolToPtr(true),
+					}
+					handled[alloc.ID] = allocUpdateFnIgnore
+				}
+				allocs = append(allocs, alloc)
+			}
+
+			mockUpdateFn := allocUpdateFnMock(handled, allocUpdateFnDestructive)
+			reconciler := NewAllocReconciler(testlog.HCLogger(t), mockUpdateFn, false, job.ID, job, d, allocs, nil, "")
+			r := reconciler.Compute()
+
+			// Assert the correct results
+			assertResults(t, r, &resultExpectation{
+				createDeployment:  nil,
+				deploymentUpdates: nil,
+				destructive:       c.destructive,
+				desiredTGUpdates: map[string]*structs.DesiredUpdates{
+					job.TaskGroups[0].Name: {
+						DestructiveUpdate: uint64(c.destructive),
+						Ignore:            uint64(10 - c.destructive),
+					},
+				},
+			})
+		})
+	}
+}
+
+// Tests that allocations of the old version that aren't updated destructively
+// don't count towards the rollout step in progress
+func TestReconciler_RollingUpgrade_Steps_IgnoredOldVersion(t *testing.T) {
+	job := mock.Job()
+	job.TaskGroups[0].Update = noCanaryUpdate.Copy()
+	job.TaskGroups[0].Update.Steps = []*structs.UpdateStep{
+		{Percent: 20},
+		{Percent: 50},
+		{Percent: 100},
+	}
+	jobOld := job.Copy()
+	job.Version++
+
+	d := structs.NewDeployment(job)
+	d.TaskGroups[job.TaskGroups[0].Name] = &structs.DeploymentState{
+		Steps:        job.TaskGroups[0].Update.Steps,
+		CurrentStep:  1,
+		DesiredTotal: 10,
+		PlacedAllocs: 2,
+	}
+
+	// Create 2 healthy allocations from the new job, 2 allocations from the
+	// old job that are ignored and 6 that require destructive updates
+	var allocs []*structs.Allocation
+	handled := make(map[string]allocUpdateType)
+	for i := 0; i < 10; i++ {
+		alloc := mock.Alloc()
+		alloc.Job = jobOld
+		alloc.JobID = job.ID
+		alloc.NodeID = uuid.Generate()
+		alloc.Name = structs.AllocName(job.ID, job.TaskGroups[0].Name, uint(i))
+		alloc.TaskGroup = job.TaskGroups[0].Name
+		switch {
+		case i < 2:
+			alloc.Job = job
+			alloc.DeploymentID = d.ID
+			alloc.DeploymentStatus = &structs.AllocDeploymentStatus{
+				Healthy: helper.BoolToPtr(true),
+			}
+			handled[alloc.ID] = allocUpdateFnIgnore
+		case i < 4:
+			handled[alloc.ID] = allocUpdateFnIgnore
+		}
+		allocs = append(allocs, alloc)
+	}
+
+	mockUpdateFn := allocUpdateFnMock(handled, allocUpdateFnDestructive)
+	reconciler := NewAllocReconciler(testlog.HCLogger(t), mockUpdateFn, false, job.ID, job, d, allocs, nil, "")
+	r := reconciler.Compute()
+
+	// The step's target of 5 allocations only has 2 running the new version
+	assertResults(t, r, &resultExpectation{
+		createDeployment:  nil,
+		deploymentUpdates: nil,
+		destructive:       3,
+		desiredTGUpdates: map[string]*structs.DesiredUpdates{
+			job.TaskGroups[0].Name: {
+				DestructiveUpdate: 3,
+				Ignore:            7,
+			},
+		},
+	})
+}
+
 // Tests the reconciler creates a deployment for inplace updates
 func TestReconciler_CreateDeployment_RollingUpgrade_Inplace(t *testing.T) {
 	jobOld := mock.Job()
@@ -3177,6 +3365,54 @@ func TestReconciler_NewCanaries(t *testing.T) {
 	assertNamesHaveIndexes(t, intRange(0, 1), placeResultsToNames(r.place))
 }
 
+// Tests the reconciler creates new canaries that are pending automatic
+// promotion when the job has auto promote set
+func TestReconciler_NewCanaries_AutoPromote(t *testing.T) {
+	job := mock.Job()
+	job.TaskGroups[0].Update = canaryUpdate.Copy()
+	job.TaskGroups[0].Update.AutoPromote = true
+	job.TaskGroups[0].Update.PromoteAfter = 5 * time.Minute
+
+	// Create 10 allocations from the old job
+	var allocs []*structs.Allocation
+	for i := 0; i < 10; i++ {
+		alloc := mock.Alloc()
+		alloc.Job = job
+		alloc.JobID = job.ID
+		alloc.NodeID = uuid.Generate()
+		alloc.Name = structs.AllocName(job.ID, job.TaskGroups[0].Name, uint(i))
+		alloc.TaskGroup = job.TaskGroups[0].Name
+		allocs = append(allocs, alloc)
+	}
+
+	reconciler := NewAllocReconciler(testlog.HCLogger(t), allocUpdateFnDestructive, false, job.ID, job, nil, allocs, nil, "")
+	r := reconciler.Compute()
+
+	newD := structs.NewDeployment(job)
+	newD.StatusDescription = structs.DeploymentStatusDescriptionRunningAutoPromotion
+	newD.TaskGroups[job.TaskGroups[0].Name] = &structs.DeploymentState{
+		AutoPromote:     true,
+		PromoteAfter:    5 * time.Minute,
+		DesiredCanaries: 2,
+		DesiredTotal:    10,
+	}
+
+	// Assert the correct results
+	assertResults(t, r, &resultExpectation{
+		createDeployment:  newD,
+		deploymentUpdates: nil,
+		place:             2,
+		inplace:           0,
+		stop:              0,
+		desiredTGUpdates: map[string]*structs.DesiredUpdates{
+			job.TaskGroups[0].Name: {
+				Canary: 2,
+				Ignore: 10,
+			},
+		},
+	})
+}
+
 // Tests the reconciler creates new canaries when the job changes and the
 // canary count is greater than the task group count
 func TestReconciler_NewCanaries_CountGreater(t *testing.T) {
@@ -4668,3 +4904,242 @@ func TestReconciler_ForceReschedule_Service(t *testing.T) {
 	assertPlaceResultsHavePreviousAllocs(t, 1, r.place)
 	assertPlacementsAreRescheduled(t, 1, r.place)
 }
+
+// Tests the reconciler marks allocations on disconnected nodes as unknown and
+// replaces them when the group tolerates client disconnects
+func TestReconciler_Disconnected_Client(t *testing.T) {
+	require := require.New(t)
+	job := mock.Job()
+	job.TaskGroups[0].MaxClientDisconnect = helper.TimeToPtr(5 * time.Minute)
+
+	// Create 10 existing allocations
+	var allocs []*structs.Allocation
+	for i := 0; i < 10; i++ {
+		alloc := mock.Alloc()
+		alloc.Job = job
+		alloc.JobID = job.ID
+		alloc.NodeID = uuid.Generate()
+		alloc.Name = structs.AllocName(job.ID, job.TaskGroups[0].Name, uint(i))
+		alloc.ClientStatus = structs.AllocClientStatusRunning
+		allocs = append(allocs, alloc)
+	}
+
+	// Disconnect the nodes of the first two allocations
+	tainted := make(map[string]*structs.Node, 2)
+	for i := 0; i < 2; i++ {
+		n := mock.Node()
+		n.ID = allocs[i].NodeID
+		n.Status = structs.NodeStatusDisconnected
+		tainted[n.ID] = n
+	}
+
+	reconciler := NewAllocReconciler(testlog.HCLogger(t), allocUpdateFnIgnore, false, job.ID, job, nil, allocs, tainted, "")
+	r := reconciler.Compute()
+
+	// Assert the correct results
+	assertResults(t, r, &resultExpectation{
+		createDeployment:  nil,
+		deploymentUpdates: nil,
+		place:             2,
+		stop:              0,
+		desiredTGUpdates: map[string]*structs.DesiredUpdates{
+			job.TaskGroups[0].Name: {
+				Place:  2,
+				Ignore: 8,
+			},
+		},
+	})
+
+	assertNamesHaveIndexes(t, intRange(0, 1), placeResultsToNames(r.place))
+	assertPlaceResultsHavePreviousAllocs(t, 2, r.place)
+
+	require.Len(r.disconnectUpdates, 2)
+	for _, alloc := range r.disconnectUpdates {
+		require.Equal(structs.AllocClientStatusUnknown, alloc.ClientStatus)
+		require.True(alloc.NeedsToReconnect())
+	}
+
+	// Once marked unknown the allocations are neither replaced nor updated
+	// again
+	for i := 0; i < 2; i++ {
+		allocs[i] = r.disconnectUpdates[allocs[i].ID]
+	}
+	for _, p := range r.place {
+		alloc := mock.Alloc()
+		alloc.Job = job
+		alloc.JobID = job.ID
+		alloc.Name = p.name
+		alloc.PreviousAllocation = p.previousAlloc.ID
+		alloc.ClientStatus = structs.AllocClientStatusRunning
+		allocs = append(allocs, alloc)
+	}
+
+	reconciler = NewAllocReconciler(testlog.HCLogger(t), allocUpdateFnIgnore, false, job.ID, job, nil, allocs, tainted, "")
+	r = reconciler.Compute()
+
+	assertResults(t, r, &resultExpectation{
+		desiredTGUpdates: map[string]*structs.DesiredUpdates{
+			job.TaskGroups[0].Name: {
+				Ignore: 12,
+			},
+		},
+	})
+	require.Empty(r.disconnectUpdates)
+}
+
+// Tests the reconciler marks allocations on disconnected nodes as lost when
+// the group does not tolerate client disconnects
+func TestReconciler_Disconnected_Client_NoMaxDisconnect(t *testing.T) {
+	job := mock.Job()
+
+	// Create 10 existing allocations
+	var allocs []*structs.Allocation
+	for i := 0; i < 10; i++ {
+		alloc := mock.Alloc()
+		alloc.Job = job
+		alloc.JobID = job.ID
+		alloc.NodeID = uuid.Generate()
+		alloc.Name = structs.AllocName(job.ID, job.TaskGroups[0].Name, uint(i))
+		alloc.ClientStatus = structs.AllocClientStatusRunning
+		allocs = append(allocs, alloc)
+	}
+
+	n := mock.Node()
+	n.ID = allocs[0].NodeID
+	n.Status = structs.NodeStatusDisconnected
+	tainted := map[string]*structs.Node{n.ID: n}
+
+	reconciler := NewAllocReconciler(testlog.HCLogger(t), allocUpdateFnIgnore, false, job.ID, job, nil, allocs, tainted, "")
+	r := reconciler.Compute()
+
+	// Assert the correct results
+	assertResults(t, r, &resultExpectation{
+		createDeployment:  nil,
+		deploymentUpdates: nil,
+		place:             1,
+		stop:              1,
+		desiredTGUpdates: map[string]*structs.DesiredUpdates{
+			job.TaskGroups[0].Name: {
+				Place:  1,
+				Stop:   1,
+				Ignore: 9,
+			},
+		},
+	})
+
+	require.Empty(t, r.disconnectUpdates)
+	require.Equal(t, structs.AllocClientStatusLost, r.stop[0].clientStatus)
+}
+
+// Tests the reconciler keeps the original allocation of a reconnected client
+// when it is still running and stops its replacement
+func TestReconciler_Reconnect_KeepOriginal(t *testing.T) {
+	require := require.New(t)
+	job := mock.Job()
+	job.TaskGroups[0].Count = 2
+	job.TaskGroups[0].MaxClientDisconnect = helper.TimeToPtr(5 * time.Minute)
+
+	// Create 2 existing allocations, the first of which was unknown and whose
+	// client reconnected
+	var allocs []*structs.Allocation
+	for i := 0; i < 2; i++ {
+		alloc := mock.Alloc()
+		alloc.Job = job
+		alloc.JobID = job.ID
+		alloc.NodeID = uuid.Generate()
+		alloc.Name = structs.AllocName(job.ID, job.TaskGroups[0].Name, uint(i))
+		alloc.ClientStatus = structs.AllocClientStatusRunning
+		allocs = append(allocs, alloc)
+	}
+	allocs[0].AppendState(structs.AllocStateFieldClientStatus, structs.AllocClientStatusUnknown)
+
+	// Create the replacement of the original allocation
+	replacement := mock.Alloc()
+	replacement.Job = job
+	replacement.JobID = job.ID
+	replacement.NodeID = uuid.Generate()
+	replacement.Name = allocs[0].Name
+	replacement.PreviousAllocation = allocs[0].ID
+	replacement.ClientStatus = structs.AllocClientStatusRunning
+	allocs = append(allocs, replacement)
+
+	reconciler := NewAllocReconciler(testlog.HCLogger(t), allocUpdateFnIgnore, false, job.ID, job, nil, allocs, nil, "")
+	r := reconciler.Compute()
+
+	// Assert the correct results
+	assertResults(t, r, &resultExpectation{
+		createDeployment:  nil,
+		deploymentUpdates: nil,
+		place:             0,
+		stop:              1,
+		desiredTGUpdates: map[string]*structs.DesiredUpdates{
+			job.TaskGroups[0].Name: {
+				Stop:   1,
+				Ignore: 2,
+			},
+		},
+	})
+
+	require.Equal(replacement.ID, r.stop[0].alloc.ID)
+	require.Equal(allocReconnected, r.stop[0].statusDescription)
+	require.Len(r.reconnectUpdates, 1)
+	require.False(r.reconnectUpdates[allocs[0].ID].NeedsToReconnect())
+}
+
+// Tests the reconciler stops the original allocation of a reconnected client
+// when it failed and keeps its replacement
+func TestReconciler_Reconnect_StopFailedOriginal(t *testing.T) {
+	require := require.New(t)
+	job := mock.Job()
+	job.TaskGroups[0].Count = 2
+	job.TaskGroups[0].MaxClientDisconnect = helper.TimeToPtr(5 * time.Minute)
+
+	// Create 2 existing allocations, the first of which was unknown and failed
+	// while its client was disconnected
+	var allocs []*structs.Allocation
+	for i := 0; i < 2; i++ {
+		alloc := mock.Alloc()
+		alloc.Job = job
+		alloc.JobID = job.ID
+		alloc.NodeID = uuid.Generate()
+		alloc.Name = structs.AllocName(job.ID, job.TaskGroups[0].Name, uint(i))
+		alloc.ClientStatus = structs.AllocClientStatusRunning
+		allocs = append(allocs, alloc)
+	}
+	allocs[0].AppendState(structs.AllocStateFieldClientStatus, structs.AllocClientStatusUnknown)
+	allocs[0].ClientStatus = structs.AllocClientStatusPending
+	allocs[0].DeploymentStatus = &structs.AllocDeploymentStatus{
+		Healthy: helper.BoolToPtr(false),
+	}
+
+	// Create the replacement of the original allocation
+	replacement := mock.Alloc()
+	replacement.Job = job
+	replacement.JobID = job.ID
+	replacement.NodeID = uuid.Generate()
+	replacement.Name = allocs[0].Name
+	replacement.PreviousAllocation = allocs[0].ID
+	replacement.ClientStatus = structs.AllocClientStatusRunning
+	allocs = append(allocs, replacement)
+
+	reconciler := NewAllocReconciler(testlog.HCLogger(t), allocUpdateFnIgnore, false, job.ID, job, nil, allocs, nil, "")
+	r := reconciler.Compute()
+
+	// Assert the correct results
+	assertResults(t, r, &resultExpectation{
+		createDeployment:  nil,
+		deploymentUpdates: nil,
+		place:             0,
+		stop:              1,
+		desiredTGUpdates: map[string]*structs.DesiredUpdates{
+			job.TaskGroups[0].Name: {
+				Stop:   1,
+				Ignore: 2,
+			},
+		},
+	})
+
+	require.Equal(allocs[0].ID, r.stop[0].alloc.ID)
+	require.Equal(allocReconnected, r.stop[0].statusDescription)
+	require.Empty(r.reconnectUpdates)
+}
@@ -190,14 +190,18 @@ func (a allocSet) fromKeys(keys ...[]string) allocSet {
 }
 
 // filterByTainted takes a set of tainted nodes and filters the allocation set
-// into three groups:
+// into five groups:
 // 1. Those that exist on untainted nodes
 // 2. Those exist on nodes that are draining
 // 3. Those that exist on lost nodes
-func (a allocSet) filterByTainted(nodes map[string]*structs.Node) (untainted, migrate, lost allocSet) {
+// 4. Those that exist on disconnected nodes and are or may be marked unknown
+// 5. Those that were unknown and whose node has reconnected
+func (a allocSet) filterByTainted(nodes map[string]*structs.Node) (untainted, migrate, lost, disconnecting, reconnecting allocSet) {
 	untainted = make(map[string]*structs.Allocation)
 	migrate = make(map[string]*structs.Allocation)
 	lost = make(map[string]*structs.Allocation)
+	disconnecting = make(map[string]*structs.Allocation)
+	reconnecting = make(map[string]*structs.Allocation)
 	for _, alloc := range a {
 		// Terminal allocs are always untainted as they should never be migrated
 		if alloc.TerminalStatus() {
@@ -205,14 +209,39 @@ func (a allocSet) filterByTainted(nodes map[string]*structs.Node) (untainted, mi
 			continue
 		}
 
+		n, tainted := nodes[alloc.NodeID]
+
+		// Allocs that were unknown and whose node is back up need to be
+		// reconciled with their replacements
+		if !tainted && alloc.NeedsToReconnect() {
+			reconnecting[alloc.ID] = alloc
+			continue
+		}
+
+		// Allocs on disconnected nodes are either unknown, if their group
+		// tolerates disconnects, or lost
+		if tainted && n != nil && n.Status == structs.NodeStatusDisconnected {
+			switch alloc.ClientStatus {
+			case structs.AllocClientStatusUnknown:
+				disconnecting[alloc.ID] = alloc
+				continue
+			case structs.AllocClientStatusRunning, structs.AllocClientStatusPending:
+				if tg := alloc.Job.LookupTaskGroup(alloc.TaskGroup); tg != nil && tg.MaxClientDisconnect != nil {
+					disconnecting[alloc.ID] = alloc
+					continue
+				}
+			}
+			lost[alloc.ID] = alloc
+			continue
+		}
+
 		// Non-terminal allocs that should migrate should always migrate
 		if alloc.DesiredTransition.ShouldMigrate() {
 			migrate[alloc.ID] = alloc
 			continue
 		}
 
-		n, ok := nodes[alloc.NodeID]
-		if !ok {
+		if !tainted {
 			// Node is untainted so alloc is untainted
 			untainted[alloc.ID] = alloc
 			continue
@@ -115,7 +115,7 @@ func TestAllocSet_filterByTainted(t *testing.T) {
 		},
 	}
 
-	untainted, migrate, lost := allocs.filterByTainted(nodes)
+	untainted, migrate, lost, _, _ := allocs.filterByTainted(nodes)
 	require.Len(untainted, 4)
 	require.Contains(untainted, "untainted1")
 	require.Contains(untainted, "untainted2")
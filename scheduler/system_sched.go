@@ -2,9 +2,11 @@ package scheduler
 
 import (
 	"fmt"
+	"sort"
 
 	log "github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/go-memdb"
+	"github.com/hashicorp/nomad/helper"
 	"github.com/hashicorp/nomad/helper/uuid"
 	"github.com/hashicorp/nomad/nomad/structs"
 )
@@ -16,12 +18,13 @@ const (
 	maxSystemScheduleAttempts = 5
 )
 
-// SystemScheduler is used for 'system' jobs. This scheduler is
-// designed for services that should be run on every client.
+// SystemScheduler is used for 'system' and 'sysbatch' jobs. This scheduler
+// is designed for services and batch work that should be run on every client.
 type SystemScheduler struct {
-	logger  log.Logger
-	state   State
-	planner Planner
+	logger   log.Logger
+	state    State
+	planner  Planner
+	sysbatch bool
 
 	eval       *structs.Evaluation
 	job        *structs.Job
@@ -35,6 +38,13 @@ type SystemScheduler struct {
 	limitReached bool
 	nextEval     *structs.Evaluation
 
+	// deployment is the current deployment for the job, if any
+	deployment *structs.Deployment
+
+	// canaryUpdates is the set of allocation IDs whose destructive update
+	// should be placed as a canary of the deployment
+	canaryUpdates map[string]struct{}
+
 	failedTGAllocs map[string]*structs.AllocMetric
 	queuedAllocs   map[string]int
 }
@@ -43,9 +53,21 @@ type SystemScheduler struct {
 // scheduler.
 func NewSystemScheduler(logger log.Logger, state State, planner Planner) Scheduler {
 	return &SystemScheduler{
-		logger:  logger.Named("system_sched"),
-		state:   state,
-		planner: planner,
+		logger:   logger.Named("system_sched"),
+		state:    state,
+		planner:  planner,
+		sysbatch: false,
+	}
+}
+
+// NewSysBatchScheduler is a factory function to instantiate a new sysbatch
+// scheduler.
+func NewSysBatchScheduler(logger log.Logger, state State, planner Planner) Scheduler {
+	return &SystemScheduler{
+		logger:   logger.Named("sysbatch_sched"),
+		state:    state,
+		planner:  planner,
+		sysbatch: true,
 	}
 }
 
@@ -62,11 +84,17 @@ func (s *SystemScheduler) Process(eval *structs.Evaluation) error {
 	case structs.EvalTriggerJobRegister, structs.EvalTriggerNodeUpdate, structs.EvalTriggerFailedFollowUp,
 		structs.EvalTriggerJobDeregister, structs.EvalTriggerRollingUpdate, structs.EvalTriggerPreemption,
 		structs.EvalTriggerDeploymentWatcher, structs.EvalTriggerNodeDrain, structs.EvalTriggerAllocStop:
+	case structs.EvalTriggerPeriodicJob:
+		// Only sysbatch jobs may be periodic
+		if s.sysbatch {
+			break
+		}
+		fallthrough
 	default:
 		desc := fmt.Sprintf("scheduler cannot handle '%s' evaluation reason",
 			eval.TriggeredBy)
 		return setStatus(s.logger, s.planner, s.eval, s.nextEval, nil, s.failedTGAllocs, structs.EvalStatusFailed, desc,
-			s.queuedAllocs, "")
+			s.queuedAllocs, s.deployment.GetID())
 	}
 
 	// Retry up to the maxSystemScheduleAttempts and reset if progress is made.
@@ -74,14 +102,14 @@ func (s *SystemScheduler) Process(eval *structs.Evaluation) error {
 	if err := retryMax(maxSystemScheduleAttempts, s.process, progress); err != nil {
 		if statusErr, ok := err.(*SetStatusError); ok {
 			return setStatus(s.logger, s.planner, s.eval, s.nextEval, nil, s.failedTGAllocs, statusErr.EvalStatus, err.Error(),
-				s.queuedAllocs, "")
+				s.queuedAllocs, s.deployment.GetID())
 		}
 		return err
 	}
 
 	// Update the status to complete
 	return setStatus(s.logger, s.planner, s.eval, s.nextEval, nil, s.failedTGAllocs, structs.EvalStatusComplete, "",
-		s.queuedAllocs, "")
+		s.queuedAllocs, s.deployment.GetID())
 }
 
 // process is wrapped in retryMax to iteratively run the handler until we have no
@@ -112,6 +140,14 @@ func (s *SystemScheduler) process() (bool, error) {
 	// Create a plan
 	s.plan = s.eval.MakePlan(s.job)
 
+	// Get any existing deployment
+	s.deployment, err = s.state.LatestDeploymentByJobID(ws, s.eval.Namespace, s.eval.JobID)
+	if err != nil {
+		return false, fmt.Errorf("failed to get job deployment %q: %v", s.eval.JobID, err)
+	}
+	s.deployment = s.deployment.Copy()
+	s.canaryUpdates = nil
+
 	// Reset the failed allocations
 	s.failedTGAllocs = nil
 
@@ -198,8 +234,21 @@ func (s *SystemScheduler) computeJobAllocs() error {
 	// nodes to lost
 	updateNonTerminalAllocsToLost(s.plan, tainted, allocs)
 
+	// Allocations of sysbatch jobs that ran successfully are kept so that
+	// they are not placed again on the same node
+	var completed []*structs.Allocation
+	if s.sysbatch {
+		for _, alloc := range allocs {
+			if alloc.TerminalStatus() && alloc.RanSuccessfully() &&
+				alloc.DesiredStatus == structs.AllocDesiredStatusRun {
+				completed = append(completed, alloc)
+			}
+		}
+	}
+
 	// Filter out the allocations in a terminal state
 	allocs, terminalAllocs := structs.FilterTerminalAllocs(allocs)
+	allocs = append(allocs, completed...)
 
 	// Diff the required and existing allocations
 	diff := diffSystemAllocs(s.job, s.nodes, tainted, allocs, terminalAllocs)
@@ -234,9 +283,13 @@ func (s *SystemScheduler) computeJobAllocs() error {
 		}
 	}
 
-	// Check if a rolling upgrade strategy is being used
+	// Determine the deployment and which destructive updates it allows
+	diff.update = s.computeDeployment(diff, inplaceUpdates, diff.update)
+
+	// Check if a rolling upgrade strategy is being used. Deployments are
+	// progressed by the deployment watcher rather than the stagger.
 	limit := len(diff.update)
-	if !s.job.Stopped() && s.job.Update.Rolling() {
+	if s.deployment == nil && !s.job.Stopped() && s.job.Update.Rolling() {
 		limit = s.job.Update.MaxParallel
 	}
 
@@ -262,6 +315,211 @@ func (s *SystemScheduler) computeJobAllocs() error {
 	return s.computePlacements(diff.place)
 }
 
+// computeDeployment cancels any deployment that is no longer needed, creates a
+// deployment when the job specification is being updated and marks the
+// deployment successful once every group is healthy. It returns the subset of
+// the destructive updates that may be made given each group's update strategy
+// and the state of the deployment.
+func (s *SystemScheduler) computeDeployment(diff *diffResult, inplace, destructive []allocTuple) []allocTuple {
+	// If the job is stopped, cancel any active deployment
+	if s.job.Stopped() {
+		if s.deployment != nil && s.deployment.Active() {
+			s.plan.DeploymentUpdates = append(s.plan.DeploymentUpdates, &structs.DeploymentStatusUpdate{
+				DeploymentID:      s.deployment.ID,
+				Status:            structs.DeploymentStatusCancelled,
+				StatusDescription: structs.DeploymentStatusDescriptionStoppedJob,
+			})
+		}
+		s.deployment = nil
+		return destructive
+	}
+
+	// Cancel an active deployment referencing an older job and clear any
+	// deployment that is no longer current
+	if d := s.deployment; d != nil {
+		if d.JobCreateIndex != s.job.CreateIndex || d.JobVersion != s.job.Version {
+			if d.Active() {
+				s.plan.DeploymentUpdates = append(s.plan.DeploymentUpdates, &structs.DeploymentStatusUpdate{
+					DeploymentID:      d.ID,
+					Status:            structs.DeploymentStatusCancelled,
+					StatusDescription: structs.DeploymentStatusDescriptionNewerJob,
+				})
+			}
+			s.deployment = nil
+		} else if d.Status == structs.DeploymentStatusSuccessful {
+			s.deployment = nil
+		}
+	}
+
+	deploymentPaused, deploymentFailed := false, false
+	if s.deployment != nil {
+		deploymentPaused = s.deployment.Status == structs.DeploymentStatusPaused
+		deploymentFailed = s.deployment.Status == structs.DeploymentStatusFailed
+	}
+
+	// Group the changes by task group
+	byGroup := func(tuples []allocTuple) map[string][]allocTuple {
+		m := make(map[string][]allocTuple)
+		for _, t := range tuples {
+			m[t.TaskGroup.Name] = append(m[t.TaskGroup.Name], t)
+		}
+		return m
+	}
+	groupDestructive := byGroup(destructive)
+	groupInplace := byGroup(inplace)
+	groupPlace := byGroup(diff.place)
+	groupIgnore := byGroup(diff.ignore)
+
+	var allowed []allocTuple
+	created := false
+	canariesPlaced := false
+	complete := true
+	for _, tg := range s.job.TaskGroups {
+		updates := groupDestructive[tg.Name]
+
+		// Groups without an update strategy are not part of deployments
+		strategy := tg.Update
+		if strategy == nil {
+			allowed = append(allowed, updates...)
+			continue
+		}
+
+		// Sort the updates so canaries are chosen deterministically
+		sort.Slice(updates, func(i, j int) bool {
+			return updates[i].Alloc.NodeID < updates[j].Alloc.NodeID
+		})
+
+		// Get the deployment state for the group
+		var dstate *structs.DeploymentState
+		existingDeployment := false
+		if s.deployment != nil {
+			dstate, existingDeployment = s.deployment.TaskGroups[tg.Name]
+		}
+
+		if !existingDeployment {
+			dstate = &structs.DeploymentState{
+				AutoRevert:       strategy.AutoRevert,
+				AutoPromote:      strategy.AutoPromote,
+				PromoteAfter:     strategy.PromoteAfter,
+				ProgressDeadline: strategy.ProgressDeadline,
+				DesiredTotal:     len(updates) + len(groupInplace[tg.Name]) + len(groupPlace[tg.Name]),
+			}
+			if strategy.Canary > 0 && len(updates) > 0 {
+				dstate.DesiredCanaries = helper.IntMin(strategy.Canary, len(updates))
+			}
+
+			// Create a new deployment if the job specification is being
+			// updated or it is the first time the job is running
+			updatingSpec := len(updates)+len(groupInplace[tg.Name]) != 0
+			hadRunning := len(groupIgnore[tg.Name]) != 0
+			if dstate.DesiredTotal != 0 && (!hadRunning || updatingSpec) {
+				if s.deployment == nil {
+					s.deployment = structs.NewDeployment(s.job)
+					created = true
+				}
+				s.deployment.TaskGroups[tg.Name] = dstate
+				existingDeployment = true
+			}
+		}
+
+		// Without a deployment the updates are limited by the stagger
+		if !existingDeployment {
+			allowed = append(allowed, updates...)
+			continue
+		}
+
+		switch {
+		case deploymentPaused || deploymentFailed:
+			// Do not make any further destructive updates
+		case dstate.DesiredCanaries != 0 && !dstate.Promoted:
+			// Only update the nodes chosen to run canaries until promoted
+			number := dstate.DesiredCanaries - len(dstate.PlacedCanaries)
+			for _, t := range updates[:helper.IntMax(0, helper.IntMin(number, len(updates)))] {
+				if s.canaryUpdates == nil {
+					s.canaryUpdates = make(map[string]struct{})
+				}
+				s.canaryUpdates[t.Alloc.ID] = struct{}{}
+				canariesPlaced = true
+				allowed = append(allowed, t)
+			}
+		default:
+			limit := s.computeLimit(strategy, groupIgnore[tg.Name])
+			allowed = append(allowed, updates[:helper.IntMin(limit, len(updates))]...)
+		}
+
+		// The group is complete when there is nothing left to change and all
+		// of its allocations are healthy and promoted
+		groupComplete := len(updates)+len(groupInplace[tg.Name])+len(groupPlace[tg.Name]) == 0 &&
+			dstate.HealthyAllocs >= helper.IntMax(dstate.DesiredTotal, dstate.DesiredCanaries) &&
+			(dstate.DesiredCanaries == 0 || dstate.Promoted)
+		complete = complete && groupComplete
+	}
+
+	if s.deployment == nil {
+		return allowed
+	}
+
+	if created {
+		if s.deployment.RequiresPromotion() {
+			if s.deployment.HasAutoPromote() {
+				s.deployment.StatusDescription = structs.DeploymentStatusDescriptionRunningAutoPromotion
+			} else {
+				s.deployment.StatusDescription = structs.DeploymentStatusDescriptionRunningNeedsPromotion
+			}
+		}
+		s.plan.Deployment = s.deployment
+	} else if canariesPlaced {
+		// Persist the newly placed canaries of the existing deployment
+		s.plan.Deployment = s.deployment
+	} else if complete && s.deployment.Active() {
+		s.plan.DeploymentUpdates = append(s.plan.DeploymentUpdates, &structs.DeploymentStatusUpdate{
+			DeploymentID:      s.deployment.ID,
+			Status:            structs.DeploymentStatusSuccessful,
+			StatusDescription: structs.DeploymentStatusDescriptionSuccessful,
+		})
+	}
+
+	// Attach the in-place updates to the deployment
+	if s.deployment.Active() {
+		for _, allocs := range s.plan.NodeAllocation {
+			for _, alloc := range allocs {
+				if alloc.DeploymentID != s.deployment.ID {
+					alloc.DeploymentID = s.deployment.ID
+					alloc.DeploymentStatus = nil
+				}
+			}
+		}
+	}
+
+	return allowed
+}
+
+// computeLimit returns the number of destructive updates that may be made for
+// a group given its update strategy and the health of the allocations already
+// updated as part of the deployment.
+func (s *SystemScheduler) computeLimit(strategy *structs.UpdateStrategy, current []allocTuple) int {
+	limit := strategy.MaxParallel
+	for _, t := range current {
+		if t.Alloc.DeploymentID != s.deployment.ID {
+			continue
+		}
+
+		// An unhealthy allocation means nothing else should happen
+		if t.Alloc.DeploymentStatus.IsUnhealthy() {
+			return 0
+		}
+
+		if !t.Alloc.DeploymentStatus.IsHealthy() {
+			limit--
+		}
+	}
+
+	if limit < 0 {
+		return 0
+	}
+	return limit
+}
+
 // computePlacements computes placements for allocations
 func (s *SystemScheduler) computePlacements(place []allocTuple) error {
 	nodeByID := make(map[string]*structs.Node, len(s.nodes))
@@ -269,6 +527,11 @@ func (s *SystemScheduler) computePlacements(place []allocTuple) error {
 		nodeByID[node.ID] = node
 	}
 
+	var deploymentID string
+	if s.deployment != nil && s.deployment.Active() {
+		deploymentID = s.deployment.ID
+	}
+
 	nodes := make([]*structs.Node, 1)
 	for _, missing := range place {
 		node, ok := nodeByID[missing.Alloc.NodeID]
@@ -352,6 +615,7 @@ func (s *SystemScheduler) computePlacements(place []allocTuple) error {
 			Metrics:            s.ctx.Metrics(),
 			NodeID:             option.Node.ID,
 			NodeName:           option.Node.Name,
+			DeploymentID:       deploymentID,
 			TaskResources:      resources.OldTaskResources(),
 			AllocatedResources: resources,
 			DesiredStatus:      structs.AllocDesiredStatusRun,
@@ -367,6 +631,18 @@ func (s *SystemScheduler) computePlacements(place []allocTuple) error {
 			alloc.PreviousAllocation = missing.Alloc.ID
 		}
 
+		// If we are placing a canary, add the canary to the deployment state
+		// object and mark it as a canary.
+		if _, ok := s.canaryUpdates[missing.Alloc.ID]; ok && deploymentID != "" {
+			if state, ok := s.deployment.TaskGroups[missing.TaskGroup.Name]; ok {
+				state.PlacedCanaries = append(state.PlacedCanaries, alloc.ID)
+			}
+
+			alloc.DeploymentStatus = &structs.AllocDeploymentStatus{
+				Canary: true,
+			}
+		}
+
 		// If this placement involves preemption, set DesiredState to evict for those allocations
 		if option.PreemptedAllocs != nil {
 			var preemptedAllocIDs []string
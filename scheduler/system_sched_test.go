@@ -735,6 +735,164 @@ func TestSystemSched_JobModify_Rolling(t *testing.T) {
 	}
 }
 
+func TestSystemSched_JobModify_Deployment(t *testing.T) {
+	require := require.New(t)
+	h := NewHarness(t)
+
+	// Create some nodes
+	var nodes []*structs.Node
+	for i := 0; i < 10; i++ {
+		node := mock.Node()
+		nodes = append(nodes, node)
+		require.NoError(h.State.UpsertNode(h.NextIndex(), node))
+	}
+
+	// Generate a fake job with allocations
+	job := mock.SystemJob()
+	require.NoError(h.State.UpsertJob(h.NextIndex(), job))
+
+	var allocs []*structs.Allocation
+	for _, node := range nodes {
+		alloc := mock.Alloc()
+		alloc.Job = job
+		alloc.JobID = job.ID
+		alloc.NodeID = node.ID
+		alloc.Name = "my-job.web[0]"
+		allocs = append(allocs, alloc)
+	}
+	require.NoError(h.State.UpsertAllocs(h.NextIndex(), allocs))
+
+	// Update the job with an update strategy, such that it cannot be done
+	// in-place
+	job2 := job.Copy()
+	job2.TaskGroups[0].Update = structs.DefaultUpdateStrategy.Copy()
+	job2.TaskGroups[0].Update.MaxParallel = 3
+	job2.TaskGroups[0].Update.AutoRevert = true
+	job2.TaskGroups[0].Tasks[0].Config["command"] = "/bin/other"
+	require.NoError(h.State.UpsertJob(h.NextIndex(), job2))
+
+	eval := &structs.Evaluation{
+		Namespace:   structs.DefaultNamespace,
+		ID:          uuid.Generate(),
+		Priority:    50,
+		TriggeredBy: structs.EvalTriggerJobRegister,
+		JobID:       job.ID,
+		Status:      structs.EvalStatusPending,
+	}
+	require.NoError(h.State.UpsertEvals(h.NextIndex(), []*structs.Evaluation{eval}))
+	require.NoError(h.Process(NewSystemScheduler, eval))
+
+	// Ensure a single plan
+	require.Len(h.Plans, 1)
+	plan := h.Plans[0]
+
+	// Ensure a deployment was created for every node
+	d := plan.Deployment
+	require.NotNil(d)
+	dstate := d.TaskGroups["web"]
+	require.NotNil(dstate)
+	require.Equal(10, dstate.DesiredTotal)
+	require.True(dstate.AutoRevert)
+
+	// Ensure the plan only updated MaxParallel allocations as part of the
+	// deployment
+	var update, planned []*structs.Allocation
+	for _, updateList := range plan.NodeUpdate {
+		update = append(update, updateList...)
+	}
+	for _, allocList := range plan.NodeAllocation {
+		planned = append(planned, allocList...)
+	}
+	require.Len(update, 3)
+	require.Len(planned, 3)
+	for _, alloc := range planned {
+		require.Equal(d.ID, alloc.DeploymentID)
+	}
+
+	// The deployment watcher drives the update so no follow up eval is made
+	require.Empty(h.CreateEvals)
+	h.AssertEvalStatus(t, structs.EvalStatusComplete)
+}
+
+func TestSystemSched_JobModify_Canaries(t *testing.T) {
+	require := require.New(t)
+	h := NewHarness(t)
+
+	// Create some nodes
+	var nodes []*structs.Node
+	for i := 0; i < 10; i++ {
+		node := mock.Node()
+		nodes = append(nodes, node)
+		require.NoError(h.State.UpsertNode(h.NextIndex(), node))
+	}
+
+	// Generate a fake job with allocations
+	job := mock.SystemJob()
+	require.NoError(h.State.UpsertJob(h.NextIndex(), job))
+
+	var allocs []*structs.Allocation
+	for _, node := range nodes {
+		alloc := mock.Alloc()
+		alloc.Job = job
+		alloc.JobID = job.ID
+		alloc.NodeID = node.ID
+		alloc.Name = "my-job.web[0]"
+		allocs = append(allocs, alloc)
+	}
+	require.NoError(h.State.UpsertAllocs(h.NextIndex(), allocs))
+
+	// Update the job to require two canaries
+	job2 := job.Copy()
+	job2.TaskGroups[0].Update = structs.DefaultUpdateStrategy.Copy()
+	job2.TaskGroups[0].Update.MaxParallel = 5
+	job2.TaskGroups[0].Update.Canary = 2
+	job2.TaskGroups[0].Tasks[0].Config["command"] = "/bin/other"
+	require.NoError(h.State.UpsertJob(h.NextIndex(), job2))
+
+	eval := &structs.Evaluation{
+		Namespace:   structs.DefaultNamespace,
+		ID:          uuid.Generate(),
+		Priority:    50,
+		TriggeredBy: structs.EvalTriggerJobRegister,
+		JobID:       job.ID,
+		Status:      structs.EvalStatusPending,
+	}
+	require.NoError(h.State.UpsertEvals(h.NextIndex(), []*structs.Evaluation{eval}))
+	require.NoError(h.Process(NewSystemScheduler, eval))
+
+	require.Len(h.Plans, 1)
+	plan := h.Plans[0]
+
+	// Ensure the deployment requires promotion
+	d := plan.Deployment
+	require.NotNil(d)
+	require.True(d.RequiresPromotion())
+	require.Equal(structs.DeploymentStatusDescriptionRunningNeedsPromotion, d.StatusDescription)
+	dstate := d.TaskGroups["web"]
+	require.Equal(2, dstate.DesiredCanaries)
+
+	// Ensure only the canaries were placed
+	var planned []*structs.Allocation
+	for _, allocList := range plan.NodeAllocation {
+		planned = append(planned, allocList...)
+	}
+	require.Len(planned, 2)
+	for _, alloc := range planned {
+		require.True(alloc.DeploymentStatus.IsCanary())
+		require.Contains(dstate.PlacedCanaries, alloc.ID)
+	}
+
+	// Apply the plan and ensure a subsequent eval doesn't update any other
+	// node until the canaries are promoted
+	h.Plans = nil
+	eval2 := eval.Copy()
+	eval2.ID = uuid.Generate()
+	eval2.TriggeredBy = structs.EvalTriggerDeploymentWatcher
+	require.NoError(h.State.UpsertEvals(h.NextIndex(), []*structs.Evaluation{eval2}))
+	require.NoError(h.Process(NewSystemScheduler, eval2))
+	require.Empty(h.Plans)
+}
+
 func TestSystemSched_JobModify_InPlace(t *testing.T) {
 	h := NewHarness(t)
 
@@ -1955,3 +2113,200 @@ func TestSystemSched_Preemption(t *testing.T) {
 	h.AssertEvalStatus(t, structs.EvalStatusComplete)
 
 }
+
+func TestSysBatchSched_JobRegister(t *testing.T) {
+	h := NewHarness(t)
+
+	// Create some nodes
+	for i := 0; i < 10; i++ {
+		node := mock.Node()
+		noErr(t, h.State.UpsertNode(h.NextIndex(), node))
+	}
+
+	// Create a job
+	job := mock.SysBatchJob()
+	noErr(t, h.State.UpsertJob(h.NextIndex(), job))
+
+	// Create a mock evaluation to register the job
+	eval := &structs.Evaluation{
+		Namespace:   structs.DefaultNamespace,
+		ID:          uuid.Generate(),
+		Priority:    job.Priority,
+		TriggeredBy: structs.EvalTriggerJobRegister,
+		JobID:       job.ID,
+		Status:      structs.EvalStatusPending,
+	}
+	noErr(t, h.State.UpsertEvals(h.NextIndex(), []*structs.Evaluation{eval}))
+
+	// Process the evaluation
+	require.NoError(t, h.Process(NewSysBatchScheduler, eval))
+
+	// Ensure a single plan placing an alloc on every node
+	require.Len(t, h.Plans, 1)
+	var planned []*structs.Allocation
+	for _, allocList := range h.Plans[0].NodeAllocation {
+		planned = append(planned, allocList...)
+	}
+	require.Len(t, planned, 10)
+
+	h.AssertEvalStatus(t, structs.EvalStatusComplete)
+}
+
+func TestSysBatchSched_IgnoreCompleted(t *testing.T) {
+	h := NewHarness(t)
+
+	// Create some nodes
+	var nodes []*structs.Node
+	for i := 0; i < 10; i++ {
+		node := mock.Node()
+		nodes = append(nodes, node)
+		noErr(t, h.State.UpsertNode(h.NextIndex(), node))
+	}
+
+	// Generate a fake job with allocations that have completed on half of
+	// the nodes and failed on the other half
+	job := mock.SysBatchJob()
+	noErr(t, h.State.UpsertJob(h.NextIndex(), job))
+
+	var allocs []*structs.Allocation
+	for i, node := range nodes {
+		alloc := mock.Alloc()
+		alloc.Job = job
+		alloc.JobID = job.ID
+		alloc.NodeID = node.ID
+		alloc.Name = "my-job.web[0]"
+		alloc.TaskStates = map[string]*structs.TaskState{
+			"web": {State: structs.TaskStateDead, Failed: i >= 5},
+		}
+		alloc.ClientStatus = structs.AllocClientStatusComplete
+		if i >= 5 {
+			alloc.ClientStatus = structs.AllocClientStatusFailed
+		}
+		allocs = append(allocs, alloc)
+	}
+	noErr(t, h.State.UpsertAllocs(h.NextIndex(), allocs))
+
+	// Create a mock evaluation triggered by a node update
+	eval := &structs.Evaluation{
+		Namespace:   structs.DefaultNamespace,
+		ID:          uuid.Generate(),
+		Priority:    50,
+		TriggeredBy: structs.EvalTriggerNodeUpdate,
+		JobID:       job.ID,
+		Status:      structs.EvalStatusPending,
+	}
+	noErr(t, h.State.UpsertEvals(h.NextIndex(), []*structs.Evaluation{eval}))
+
+	// Process the evaluation
+	require.NoError(t, h.Process(NewSysBatchScheduler, eval))
+
+	// Ensure only the failed allocations are replaced
+	require.Len(t, h.Plans, 1)
+	plan := h.Plans[0]
+	require.Empty(t, plan.NodeUpdate)
+
+	var planned []*structs.Allocation
+	for _, allocList := range plan.NodeAllocation {
+		planned = append(planned, allocList...)
+	}
+	require.Len(t, planned, 5)
+	for _, alloc := range planned {
+		require.Contains(t, []string{nodes[5].ID, nodes[6].ID, nodes[7].ID, nodes[8].ID, nodes[9].ID}, alloc.NodeID)
+	}
+
+	h.AssertEvalStatus(t, structs.EvalStatusComplete)
+}
+
+func TestSysBatchSched_JobModify_RerunCompleted(t *testing.T) {
+	h := NewHarness(t)
+
+	// Create some nodes
+	var nodes []*structs.Node
+	for i := 0; i < 5; i++ {
+		node := mock.Node()
+		nodes = append(nodes, node)
+		noErr(t, h.State.UpsertNode(h.NextIndex(), node))
+	}
+
+	// Generate a fake job with completed allocations
+	job := mock.SysBatchJob()
+	noErr(t, h.State.UpsertJob(h.NextIndex(), job))
+
+	var allocs []*structs.Allocation
+	for _, node := range nodes {
+		alloc := mock.Alloc()
+		alloc.Job = job
+		alloc.JobID = job.ID
+		alloc.NodeID = node.ID
+		alloc.Name = "my-job.web[0]"
+		alloc.TaskStates = map[string]*structs.TaskState{
+			"web": {State: structs.TaskStateDead},
+		}
+		alloc.ClientStatus = structs.AllocClientStatusComplete
+		allocs = append(allocs, alloc)
+	}
+	noErr(t, h.State.UpsertAllocs(h.NextIndex(), allocs))
+
+	// Update the task, such that it cannot be done in-place
+	job2 := job.Copy()
+	job2.TaskGroups[0].Tasks[0].Config["command"] = "/bin/other"
+	noErr(t, h.State.UpsertJob(h.NextIndex(), job2))
+
+	// Create a mock evaluation to register the job
+	eval := &structs.Evaluation{
+		Namespace:   structs.DefaultNamespace,
+		ID:          uuid.Generate(),
+		Priority:    50,
+		TriggeredBy: structs.EvalTriggerJobRegister,
+		JobID:       job.ID,
+		Status:      structs.EvalStatusPending,
+	}
+	noErr(t, h.State.UpsertEvals(h.NextIndex(), []*structs.Evaluation{eval}))
+
+	// Process the evaluation
+	require.NoError(t, h.Process(NewSysBatchScheduler, eval))
+
+	// Ensure the completed allocations are run again with the new version
+	require.Len(t, h.Plans, 1)
+	var planned []*structs.Allocation
+	for _, allocList := range h.Plans[0].NodeAllocation {
+		planned = append(planned, allocList...)
+	}
+	require.Len(t, planned, 5)
+
+	h.AssertEvalStatus(t, structs.EvalStatusComplete)
+}
+
+func TestSysBatchSched_PeriodicTrigger(t *testing.T) {
+	h := NewHarness(t)
+
+	node := mock.Node()
+	noErr(t, h.State.UpsertNode(h.NextIndex(), node))
+
+	job := mock.SysBatchJob()
+	noErr(t, h.State.UpsertJob(h.NextIndex(), job))
+
+	eval := &structs.Evaluation{
+		Namespace:   structs.DefaultNamespace,
+		ID:          uuid.Generate(),
+		Priority:    50,
+		TriggeredBy: structs.EvalTriggerPeriodicJob,
+		JobID:       job.ID,
+		Status:      structs.EvalStatusPending,
+	}
+	noErr(t, h.State.UpsertEvals(h.NextIndex(), []*structs.Evaluation{eval}))
+
+	// The sysbatch scheduler handles periodic evaluations
+	require.NoError(t, h.Process(NewSysBatchScheduler, eval))
+	require.Len(t, h.Plans, 1)
+	h.AssertEvalStatus(t, structs.EvalStatusComplete)
+
+	// The system scheduler does not
+	h = NewHarness(t)
+	noErr(t, h.State.UpsertNode(h.NextIndex(), node))
+	noErr(t, h.State.UpsertJob(h.NextIndex(), job))
+	noErr(t, h.State.UpsertEvals(h.NextIndex(), []*structs.Evaluation{eval}))
+	require.NoError(t, h.Process(NewSystemScheduler, eval))
+	require.Empty(t, h.Plans)
+	h.AssertEvalStatus(t, structs.EvalStatusFailed)
+}
@@ -78,6 +78,18 @@ func NewHarnessWithState(t testing.T, state *state.StateStore) *Harness {
 	}
 }
 
+// NewHarnessAtIndex returns a harness applying plans to the given state at
+// indexes above the given one. It is used to run schedulers against a copy of
+// the state that already holds data at higher indexes than a fresh harness
+// would use.
+func NewHarnessAtIndex(t testing.T, state *state.StateStore, index uint64) *Harness {
+	return &Harness{
+		t:         t,
+		State:     state,
+		nextIndex: index + 1,
+	}
+}
+
 // SubmitPlan is used to handle plan submission
 func (h *Harness) SubmitPlan(plan *structs.Plan) (*structs.PlanResult, State, error) {
 	// Ensure sequential plan application
@@ -104,12 +104,13 @@ func diffAllocs(job *structs.Job, taintedNodes map[string]*structs.Node,
 		// If we are on a tainted node, we must migrate if we are a service or
 		// if the batch allocation did not finish
 		if node, ok := taintedNodes[exist.NodeID]; ok {
-			// If the job is batch and finished successfully, the fact that the
-			// node is tainted does not mean it should be migrated or marked as
-			// lost as the work was already successfully finished. However for
-			// service/system jobs, tasks should never complete. The check of
-			// batch type, defends against client bugs.
-			if exist.Job.Type == structs.JobTypeBatch && exist.RanSuccessfully() {
+			// If the job is batch or sysbatch and finished successfully, the
+			// fact that the node is tainted does not mean it should be migrated
+			// or marked as lost as the work was already successfully finished.
+			// However for service/system jobs, tasks should never complete. The
+			// check of batch type, defends against client bugs.
+			isBatch := exist.Job.Type == structs.JobTypeBatch || exist.Job.Type == structs.JobTypeSysBatch
+			if isBatch && exist.RanSuccessfully() {
 				goto IGNORE
 			}
 
@@ -407,6 +408,8 @@ func tasksUpdated(jobA, jobB *structs.Job, taskGroup string) bool {
 		// Inspect the non-network resources
 		if ar, br := at.Resources, bt.Resources; ar.CPU != br.CPU {
 			return true
+		} else if ar.Cores != br.Cores {
+			return true
 		} else if ar.MemoryMB != br.MemoryMB {
 			return true
 		}
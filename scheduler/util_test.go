@@ -570,6 +570,13 @@ func TestTasksUpdated(t *testing.T) {
 	if !tasksUpdated(j1, j18, name) {
 		t.Fatal("bad")
 	}
+
+	// Change the reserved cores
+	j19 := mock.Job()
+	j19.TaskGroups[0].Tasks[0].Resources.Cores = 2
+	if !tasksUpdated(j1, j19, name) {
+		t.Fatal("bad")
+	}
 }
 
 func TestEvictAndPlace_LimitLessThanAllocs(t *testing.T) {